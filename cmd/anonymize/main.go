@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/database/mongodb"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/infra/database/anonymization"
+	"github.com/joho/godotenv"
+	"go.uber.org/zap"
+	"log"
+	"os"
+)
+
+// Anonymizes the personal data of the database at MONGODB_URL, meant for a
+// copy of a production snapshot about to be loaded into staging. The same
+// ANONYMIZE_KEY gives the same replacements on every run.
+func main() {
+	ctx := context.Background()
+
+	if err := godotenv.Load("cmd/auction/.env"); err != nil {
+		log.Fatal("Error trying to load env variables")
+		return
+	}
+
+	key := os.Getenv("ANONYMIZE_KEY")
+	if key == "" {
+		log.Fatal("ANONYMIZE_KEY is not set")
+		return
+	}
+
+	databaseConnection, err := mongodb.NewMongoDBConnection(ctx)
+	if err != nil {
+		log.Fatal(err.Error())
+		return
+	}
+
+	for collection, rules := range anonymization.NewAnonymizer(key).Rules() {
+		anonymized, err := anonymization.AnonymizeCollection(
+			ctx, databaseConnection.Collection(collection), rules)
+		if err != nil {
+			log.Fatalf("Error trying to anonymize collection %s: %s", collection, err.Error())
+			return
+		}
+
+		logger.Info("Collection anonymized",
+			zap.String("collection", collection),
+			zap.Int64("anonymized_documents", anonymized))
+	}
+}
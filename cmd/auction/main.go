@@ -2,65 +2,729 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"fullcycle-auction_go/configuration/clock"
 	"fullcycle-auction_go/configuration/database/mongodb"
+	sqlite_database "fullcycle-auction_go/configuration/database/sqlite"
+	"fullcycle-auction_go/configuration/enum"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/configuration/redis"
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/entity/category_entity"
+	"fullcycle-auction_go/internal/entity/fraud_entity"
+	"fullcycle-auction_go/internal/entity/job_entity"
+	"fullcycle-auction_go/internal/entity/notification_entity"
+	"fullcycle-auction_go/internal/entity/offer_entity"
+	"fullcycle-auction_go/internal/entity/product_entity"
+	"fullcycle-auction_go/internal/entity/question_entity"
+	"fullcycle-auction_go/internal/entity/user_entity"
+	"fullcycle-auction_go/internal/infra/api/web/controller/absentee_controller"
+	"fullcycle-auction_go/internal/infra/api/web/controller/activity_controller"
 	"fullcycle-auction_go/internal/infra/api/web/controller/auction_controller"
+	"fullcycle-auction_go/internal/infra/api/web/controller/auctioneer_controller"
 	"fullcycle-auction_go/internal/infra/api/web/controller/bid_controller"
+	"fullcycle-auction_go/internal/infra/api/web/controller/category_controller"
+	"fullcycle-auction_go/internal/infra/api/web/controller/export_controller"
+	"fullcycle-auction_go/internal/infra/api/web/controller/fraud_controller"
+	"fullcycle-auction_go/internal/infra/api/web/controller/job_controller"
+	"fullcycle-auction_go/internal/infra/api/web/controller/live_controller"
+	"fullcycle-auction_go/internal/infra/api/web/controller/notification_controller"
+	"fullcycle-auction_go/internal/infra/api/web/controller/offer_controller"
+	"fullcycle-auction_go/internal/infra/api/web/controller/product_controller"
+	"fullcycle-auction_go/internal/infra/api/web/controller/proxy_controller"
+	"fullcycle-auction_go/internal/infra/api/web/controller/question_controller"
+	"fullcycle-auction_go/internal/infra/api/web/controller/recurring_auction_controller"
+	"fullcycle-auction_go/internal/infra/api/web/controller/registration_controller"
+	"fullcycle-auction_go/internal/infra/api/web/controller/retention_controller"
+	"fullcycle-auction_go/internal/infra/api/web/controller/series_controller"
+	"fullcycle-auction_go/internal/infra/api/web/controller/transfer_controller"
 	"fullcycle-auction_go/internal/infra/api/web/controller/user_controller"
+	"fullcycle-auction_go/internal/infra/api/web/controller/view_controller"
+	"fullcycle-auction_go/internal/infra/api/web/middleware"
+	"fullcycle-auction_go/internal/infra/auth"
 	"fullcycle-auction_go/internal/infra/database/auction"
 	"fullcycle-auction_go/internal/infra/database/bid"
+	"fullcycle-auction_go/internal/infra/database/category"
+	"fullcycle-auction_go/internal/infra/database/fraud"
+	"fullcycle-auction_go/internal/infra/database/inbox"
+	"fullcycle-auction_go/internal/infra/database/instrumentation"
+	"fullcycle-auction_go/internal/infra/database/job"
+	"fullcycle-auction_go/internal/infra/database/memory"
+	"fullcycle-auction_go/internal/infra/database/offer"
+	"fullcycle-auction_go/internal/infra/database/product"
+	"fullcycle-auction_go/internal/infra/database/question"
+	"fullcycle-auction_go/internal/infra/database/sqlite"
 	"fullcycle-auction_go/internal/infra/database/user"
+	"fullcycle-auction_go/internal/infra/diagnostics"
+	"fullcycle-auction_go/internal/infra/event"
+	"fullcycle-auction_go/internal/infra/notification"
+	"fullcycle-auction_go/internal/infra/profiling"
+	"fullcycle-auction_go/internal/infra/tenant"
+	"fullcycle-auction_go/internal/usecase/absentee_usecase"
+	"fullcycle-auction_go/internal/usecase/activity_usecase"
 	"fullcycle-auction_go/internal/usecase/auction_usecase"
+	"fullcycle-auction_go/internal/usecase/auctioneer_usecase"
 	"fullcycle-auction_go/internal/usecase/bid_usecase"
+	"fullcycle-auction_go/internal/usecase/category_usecase"
+	"fullcycle-auction_go/internal/usecase/fraud_usecase"
+	"fullcycle-auction_go/internal/usecase/job_usecase"
+	"fullcycle-auction_go/internal/usecase/lifecycle_usecase"
+	"fullcycle-auction_go/internal/usecase/live_usecase"
+	"fullcycle-auction_go/internal/usecase/notification_usecase"
+	"fullcycle-auction_go/internal/usecase/offer_usecase"
+	"fullcycle-auction_go/internal/usecase/product_usecase"
+	"fullcycle-auction_go/internal/usecase/proxy_usecase"
+	"fullcycle-auction_go/internal/usecase/question_usecase"
+	"fullcycle-auction_go/internal/usecase/registration_usecase"
+	"fullcycle-auction_go/internal/usecase/retention_usecase"
+	"fullcycle-auction_go/internal/usecase/transfer_usecase"
 	"fullcycle-auction_go/internal/usecase/user_usecase"
+	"fullcycle-auction_go/internal/usecase/view_usecase"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/joho/godotenv"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	goredis "github.com/redis/go-redis/v9"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
 	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
 )
 
+const (
+	STORAGE     = "STORAGE"
+	CLOCK_SPEED = "CLOCK_SPEED"
+
+	// LEGACY_NUMERIC_ENUMS keeps accepting numeric conditions and statuses
+	// in place of their names, for the deprecation window.
+	LEGACY_NUMERIC_ENUMS = "LEGACY_NUMERIC_ENUMS"
+
+	// TENANT_REGISTRY is the JSON file mapping each tenant to its own
+	// database, a single tenant being served when unset.
+	TENANT_REGISTRY = "TENANT_REGISTRY"
+
+	API_ADDR = "API_ADDR"
+	// ADMIN_ADDR is where the metrics, profiling and /admin endpoints are
+	// served, never on API_ADDR.
+	ADMIN_ADDR = "ADMIN_ADDR"
+
+	PUBLIC_RATE_LIMIT = "PUBLIC_RATE_LIMIT"
+	PUBLIC_CACHE_TTL  = "PUBLIC_CACHE_TTL"
+
+	// REDIS_EVENTS_CHANNEL is the Redis channel the instances share their
+	// events on, each tenant on its own.
+	REDIS_EVENTS_CHANNEL = "REDIS_EVENTS_CHANNEL"
+)
+
+type repositories struct {
+	auction        auction_entity.AuctionRepositoryInterface
+	bid            bid_entity.BidEntityRepository
+	user           user_entity.UserRepositoryInterface
+	product        product_entity.ProductRepositoryInterface
+	categorySchema category_entity.CategorySchemaRepositoryInterface
+	category       category_entity.CategoryRepositoryInterface
+	fraudFlag      fraud_entity.FraudFlagRepositoryInterface
+	rejectedBid    bid_entity.RejectedBidRepositoryInterface
+	question       question_entity.QuestionRepositoryInterface
+	terms          auction_entity.TermsAcceptanceRepositoryInterface
+	offer          offer_entity.OfferRepositoryInterface
+	transfer       auction_entity.TransferRepositoryInterface
+	job            job_entity.JobRepositoryInterface
+	recurring      auction_entity.RecurringAuctionRepositoryInterface
+	series         auction_entity.SeriesRepositoryInterface
+	absenteeBid    bid_entity.AbsenteeBidRepositoryInterface
+	proxyBid       bid_entity.ProxyBidRepositoryInterface
+	registration   auction_entity.RegistrationRepositoryInterface
+	notification   notification_entity.NotificationRepositoryInterface
+}
+
 func main() {
+	check := flag.Bool("check", false, "check the environment, print a diagnostics report and exit")
+	flag.Parse()
+
 	ctx := context.Background()
 
+	// Bids and the other entities get their ids from a pool of random bytes
+	// rather than a read from crypto/rand each.
+	uuid.EnableRandPool()
+
 	if err := godotenv.Load("cmd/auction/.env"); err != nil {
 		log.Fatal("Error trying to load env variables")
 		return
 	}
 
-	databaseConnection, err := mongodb.NewMongoDBConnection(ctx)
+	report := diagnostics.Run(ctx, 10*time.Second)
+	if *check {
+		report.Print(os.Stdout)
+		if report.Failed() {
+			os.Exit(1)
+		}
+		return
+	}
+
+	report.Log()
+	if report.Failed() {
+		log.Fatal("Startup self-check failed, run with --check for a report")
+		return
+	}
+
+	enum.SetAcceptNumeric(getLegacyNumericEnums())
+
+	profiling.Start(profiling.LoadConfig())
+
+	tokenIssuer := auth.NewTokenIssuerFromEnv()
+
+	registry, err := tenant.LoadRegistry(os.Getenv(TENANT_REGISTRY))
+	if err != nil {
+		log.Fatal(err.Error())
+		return
+	}
+
+	redisClient, err := redis.NewRedisConnection(ctx)
+	if err != nil {
+		log.Fatal(err.Error())
+		return
+	}
+
+	if registry != nil {
+		router, admin, err := initTenantRouters(ctx, registry, tokenIssuer, redisClient)
+		if err != nil {
+			log.Fatal(err.Error())
+			return
+		}
+
+		serve(router, admin, report.ReadOnly)
+		return
+	}
+
+	broker := event.NewBroker()
+	cluster := event.NewRedisRelay(ctx, redisClient, getEventsChannel(""), broker)
+
+	repos, err := initRepositories(ctx, broker)
 	if err != nil {
 		log.Fatal(err.Error())
 		return
 	}
 
-	router := gin.Default()
+	if instrumentation.Enabled() {
+		repos = instrumentRepositories(repos)
+	}
+
+	router, admin := initRouters(repos, tokenIssuer, broker, cluster)
+	serve(router, admin, report.ReadOnly)
+}
+
+// initRouters wires the use cases over the repositories and routes the API
+// and the admin endpoints to their controllers. The live feeds and caches
+// follow the events of the cluster, the rest those of the instance.
+func initRouters(
+	repos *repositories,
+	tokenIssuer *auth.TokenIssuer,
+	broker, cluster *event.Broker) (router *gin.Engine, admin *gin.Engine) {
+	authenticated := middleware.Authentication(tokenIssuer)
+	optionallyAuthenticated := middleware.OptionalAuthentication(tokenIssuer)
 
-	userController, bidController, auctionsController := initDependencies(databaseConnection)
+	router = gin.Default()
+	router.Use(middleware.Compression("/ws"))
 
-	router.GET("/auction", auctionsController.FindAuctions)
+	userController, bidController, auctionsController, productController, categoryController, exportController, fraudController, questionController, activityController, offerController, transferController, jobController, recurringAuctionController, seriesController, liveController, auctioneerController, absenteeController, proxyController, retentionController, registrationController, viewController, notificationController :=
+		initDependencies(repos, tokenIssuer, broker, cluster)
+
+	// The public API is read-only and safe to expose without keys, cached
+	// and rate limited per client apart from the rest.
+	publicRateLimit := getPublicRateLimit()
+	public := router.Group("/public",
+		middleware.RateLimit(publicRateLimit, publicRateLimit/4+1),
+		middleware.ResponseCache(getPublicCacheTTL()))
+	public.GET("/auctions", auctionsController.FindAuctions)
+	public.GET("/auctions/:auctionId", auctionsController.FindAuctionById)
+	public.GET("/auctions/:auctionId/bids", bidController.FindBidByAuctionId)
+
+	router.GET("/auction", optionallyAuthenticated, auctionsController.FindAuctions)
+	router.GET("/auction/changes", auctionsController.FindAuctionChanges)
+	router.GET("/auction/search", optionallyAuthenticated, auctionsController.SearchAuctions)
+	router.GET("/auction/ending-soon", optionallyAuthenticated, auctionsController.FindEndingSoonAuctions)
+	router.GET("/auction/trending", optionallyAuthenticated, auctionsController.FindTrendingAuctions)
 	router.GET("/auction/:auctionId", auctionsController.FindAuctionById)
-	router.POST("/auction", auctionsController.CreateAuction)
+	router.POST("/auction", authenticated, auctionsController.CreateAuction)
+	router.POST("/auction/status-batch", auctionsController.FindAuctionStatuses)
 	router.GET("/auction/winner/:auctionId", auctionsController.FindWinningBidByAuctionId)
-	router.POST("/bid", bidController.CreateBid)
-	router.GET("/bid/:auctionId", bidController.FindBidByAuctionId)
+	router.POST("/auction/:auctionId/end-early", authenticated, auctionsController.EndAuctionEarly)
+	router.DELETE("/auction/:auctionId", authenticated, auctionsController.CancelAuction)
+	router.PATCH("/auction/:auctionId/pause", authenticated, auctionsController.PauseAuction)
+	router.PATCH("/auction/:auctionId/resume", authenticated, auctionsController.ResumeAuction)
+	router.POST("/auction/:auctionId/relist", authenticated, auctionsController.RelistAuction)
+	router.PUT("/auction/:auctionId", authenticated, auctionsController.UpdateDraft)
+	router.PATCH("/auction/:auctionId", authenticated, auctionsController.UpdateAuctionDetails)
+	router.POST("/auction/:auctionId/publish", authenticated, auctionsController.PublishAuction)
+	router.GET("/auction/:auctionId/rejected-bids", authenticated, bidController.FindRejectedBids)
+	router.GET("/auction/:auctionId/suggested-bids", bidController.FindSuggestedBids)
+	router.POST("/auction/:auctionId/questions", authenticated, questionController.PostQuestion)
+	router.GET("/auction/:auctionId/questions", questionController.FindQuestions)
+	router.POST("/auction/:auctionId/questions/:questionId/answer", authenticated, questionController.AnswerQuestion)
+	router.GET("/auction/:auctionId/activity", activityController.FindAuctionActivity)
+	router.POST("/auction/:auctionId/view", optionallyAuthenticated, viewController.RecordView)
+	router.GET("/auction/:auctionId/stats", viewController.FindAuctionStats)
+	router.POST("/auction/:auctionId/offers", authenticated, offerController.CreateOffers)
+	router.GET("/auction/:auctionId/offers", authenticated, offerController.FindOffers)
+	router.POST("/auction/:auctionId/offers/runner-up", authenticated, offerController.OfferRunnerUp)
+	router.GET("/auction/:auctionId/bidders", authenticated, offerController.FindBidderRanking)
+	router.POST("/offers/:offerId/accept", authenticated, offerController.AcceptOffer)
+	router.POST("/offers/:offerId/decline", authenticated, offerController.DeclineOffer)
+	router.POST("/recurring-auctions", authenticated, recurringAuctionController.CreateRecurringAuction)
+	router.GET("/recurring-auctions", authenticated, recurringAuctionController.FindRecurringAuctions)
+	router.GET("/recurring-auctions/:recurringAuctionId", recurringAuctionController.FindRecurringAuctionById)
+	router.PATCH("/recurring-auctions/:recurringAuctionId", authenticated, recurringAuctionController.UpdateRecurringAuction)
+	router.POST("/series", authenticated, seriesController.CreateSeries)
+	router.GET("/series", seriesController.FindSeries)
+	router.GET("/series/:seriesId", seriesController.FindSeriesById)
+	router.GET("/series/:seriesId/auctions", seriesController.FindSeriesAuctions)
+	router.POST("/series/:seriesId/schedule", authenticated, seriesController.ScheduleSeries)
+	router.POST("/bid", authenticated, bidController.CreateBid)
+	router.POST("/bid/bulk", authenticated, bidController.CreateBids)
+	router.DELETE("/bid/:bidId", authenticated, bidController.RetractBid)
+	router.POST("/staff/bids", authenticated, bidController.CreatePhoneBid)
+	router.GET("/bid/:auctionId", optionallyAuthenticated, bidController.FindBidByAuctionId)
+	router.GET("/bid/:auctionId/status/:bidId", bidController.FindBidStatus)
+	router.POST("/auction/:auctionId/absentee-bid", authenticated, absenteeController.LodgeAbsenteeBid)
+	router.GET("/auction/:auctionId/absentee-bid", authenticated, absenteeController.FindAbsenteeBid)
+	router.POST("/auction/:auctionId/proxy-bid", authenticated, proxyController.SetProxyBid)
+	router.GET("/auction/:auctionId/proxy-bid", authenticated, proxyController.FindProxyBid)
+	router.POST("/auction/:auctionId/registration", authenticated, registrationController.Register)
+	router.GET("/auction/:auctionId/registration", authenticated, registrationController.FindRegistration)
+	router.GET("/auction/:auctionId/registrations", authenticated, registrationController.FindRegistrations)
+	router.PUT("/auction/:auctionId/registrations/:userId", authenticated, registrationController.DecideRegistration)
+	router.GET("/ws/auction/:auctionId", liveController.FollowAuction)
+	router.POST("/auctioneer/auctions/:auctionId/open", authenticated, auctioneerController.OpenBidding)
+	router.POST("/auctioneer/auctions/:auctionId/call", authenticated, auctioneerController.Call)
+	router.PUT("/auctioneer/auctions/:auctionId/increment", authenticated, auctioneerController.SetIncrement)
+	router.POST("/auctioneer/auctions/:auctionId/hammer", authenticated, auctioneerController.Hammer)
+	router.POST("/users/register", userController.RegisterUser)
+	router.POST("/users/login", userController.Login)
 	router.GET("/user/:userId", userController.FindUserById)
+	router.PATCH("/users/:userId/profile", authenticated, userController.UpdateUserProfile)
+	router.GET("/users/:userId/winning-status", authenticated, bidController.FindWinningStatus)
+	router.GET("/users/:userId/notifications", authenticated, notificationController.FindNotifications)
+	router.PATCH("/users/:userId/notifications", authenticated, notificationController.MarkNotificationsRead)
+	router.GET("/sellers/:sellerId/storefront", auctionsController.FindSellerStorefront)
+	router.GET("/feeds/results.xml", auctionsController.FindResultsFeed)
+	router.GET("/sitemap.xml", auctionsController.FindSitemap)
+	router.GET("/auction/:auctionId/metadata", auctionsController.FindAuctionMetadata)
+	router.GET("/product", productController.FindProducts)
+	router.GET("/product/:productId", productController.FindProductById)
+	router.POST("/product", productController.CreateProduct)
+	router.PUT("/product/:productId", productController.UpdateProduct)
+	router.DELETE("/product/:productId", productController.DeleteProduct)
+	router.GET("/category", categoryController.FindCategories)
+	router.POST("/category", categoryController.CreateCategory)
+	router.GET("/category/:category", categoryController.FindCategory)
+	router.PUT("/category/:category", categoryController.UpdateCategory)
+	router.DELETE("/category/:category", categoryController.DeleteCategory)
+	router.GET("/category/:category/schema", categoryController.FindCategorySchema)
+	router.PUT("/category/:category/schema", categoryController.UpsertCategorySchema)
+
+	// The operational endpoints are served apart from the API, on an
+	// address kept private, since none of them is authenticated.
+	admin = gin.Default()
+	// Exports stream and flush as they go, the metrics handler negotiates
+	// its own compression and profiles are written gzipped already.
+	admin.Use(middleware.Compression("/admin/export", "/metrics", "/debug/pprof"))
+	admin.GET("/debug/pprof/*profile", gin.WrapH(profiling.Handler()))
+	admin.GET("/metrics", gin.WrapH(promhttp.Handler()))
+	admin.GET("/admin/export/auctions", exportController.ExportAuctions)
+	admin.GET("/admin/export/bids", exportController.ExportBids)
+	admin.POST("/admin/auctions/resolve", auctionsController.ResolveAuctions)
+	admin.POST("/admin/auctions/:auctionId/transfer", transferController.TransferAuction)
+	admin.GET("/admin/auctions/:auctionId/transfers", transferController.FindTransfers)
+	admin.GET("/admin/analytics/bid-sources", bidController.FindBidSourceStats)
+	admin.GET("/admin/analytics/estimates", auctionsController.FindEstimateAccuracy)
+	admin.GET("/admin/bids/buffer", bidController.FindBufferStats)
+	admin.GET("/admin/fraud-flags", fraudController.FindFraudFlags)
+	admin.GET("/admin/auctions/:auctionId/rejected-bids", bidController.FindRejectedBidsAsAdmin)
+	admin.PATCH("/admin/questions/:questionId", questionController.ModerateQuestion)
+	admin.PUT("/admin/users/:userId/role", userController.UpdateUserRole)
+	admin.GET("/admin/jobs", jobController.FindJobs)
+	admin.GET("/admin/jobs/:jobId", jobController.FindJobById)
+	admin.POST("/admin/jobs/:jobId/retry", jobController.RetryJob)
+	admin.GET("/admin/retention/report", retentionController.FindRetentionReport)
+	admin.POST("/admin/retention/run", retentionController.RunRetention)
 
-	router.Run(":8080")
+	return router, admin
 }
 
-func initDependencies(database *mongo.Database) (
+// initTenantRouters wires the repositories of each tenant of the registry
+// on its own database, through a single MongoDB client, along with its own
+// use cases, and routes the requests to the tenant they are for. Tokens are
+// only valid for the tenant they were issued by.
+func initTenantRouters(
+	ctx context.Context,
+	registry *tenant.Registry,
+	tokenIssuer *auth.TokenIssuer,
+	redisClient *goredis.Client) (http.Handler, http.Handler, error) {
+	if storage := os.Getenv(STORAGE); storage == "memory" || storage == "sqlite" {
+		return nil, nil, fmt.Errorf("the tenant registry needs the MongoDB storage, not %s", storage)
+	}
+
+	database, err := mongodb.NewMongoDBConnection(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	routers := make(map[string]http.Handler)
+	admins := make(map[string]http.Handler)
+	for _, registered := range registry.Tenants() {
+		broker := event.NewBroker()
+		cluster := event.NewRedisRelay(ctx, redisClient, getEventsChannel(registered.Id), broker)
+
+		repos, err := initMongoRepositories(ctx, database.Client().Database(registered.Database), broker)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if instrumentation.Enabled() {
+			repos = instrumentRepositories(repos)
+		}
+
+		routers[registered.Id], admins[registered.Id] =
+			initRouters(repos, tokenIssuer.ForAudience(registered.Id), broker, cluster)
+		logger.Info("Serving tenant",
+			zap.String("tenant", registered.Id), zap.String("database", registered.Database))
+	}
+
+	return tenant.NewRouter(registry, routers), tenant.NewRouter(registry, admins), nil
+}
+
+// serve runs the API and the admin endpoints, refusing their writes when
+// readOnly.
+func serve(router, admin http.Handler, readOnly bool) {
+	if readOnly {
+		logger.Warn("Serving read-only, the data was migrated past the schema this build reads")
+		router, admin = refuseWrites(router), refuseWrites(admin)
+	}
+
+	go func() {
+		if err := http.ListenAndServe(getAdminAddr(), admin); err != nil {
+			log.Fatal(err.Error())
+		}
+	}()
+
+	if err := http.ListenAndServe(getApiAddr(), router); err != nil {
+		log.Fatal(err.Error())
+	}
+}
+
+// refuseWrites answers 503 to every request but reads.
+func refuseWrites(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		switch request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			handler.ServeHTTP(writer, request)
+			return
+		}
+
+		errRest := rest_err.NewServiceUnavailableError("The API is read-only until a newer build is deployed")
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		writer.WriteHeader(errRest.Code)
+		json.NewEncoder(writer).Encode(errRest)
+	})
+}
+
+// initRepositories wires the MongoDB repositories by default. STORAGE=memory
+// runs the API without any infrastructure and STORAGE=sqlite keeps the data in
+// a single local file, for demos and single-binary deployments.
+func initRepositories(ctx context.Context, broker *event.Broker) (*repositories, error) {
+	switch os.Getenv(STORAGE) {
+	case "memory":
+		clock.SetSpeed(getClockSpeed())
+		logger.Info("Using in-memory storage", zap.String("clock_speed", os.Getenv(CLOCK_SPEED)))
+
+		auctionRepository := memory.NewAuctionRepository(broker)
+		return &repositories{
+			auction:        auctionRepository,
+			bid:            memory.NewBidRepository(auctionRepository, broker),
+			user:           memory.NewUserRepository(),
+			product:        memory.NewProductRepository(),
+			categorySchema: memory.NewCategorySchemaRepository(),
+			category:       memory.NewCategoryRepository(),
+			fraudFlag:      memory.NewFraudFlagRepository(),
+			rejectedBid:    memory.NewRejectedBidRepository(),
+			question:       memory.NewQuestionRepository(),
+			terms:          memory.NewTermsAcceptanceRepository(),
+			offer:          memory.NewOfferRepository(),
+			transfer:       memory.NewTransferRepository(),
+			job:            memory.NewJobRepository(),
+			recurring:      memory.NewRecurringAuctionRepository(),
+			series:         memory.NewSeriesRepository(),
+			absenteeBid:    memory.NewAbsenteeBidRepository(),
+			proxyBid:       memory.NewProxyBidRepository(),
+			registration:   memory.NewRegistrationRepository(),
+			notification:   memory.NewNotificationRepository(),
+		}, nil
+	case "sqlite":
+		database, err := sqlite_database.NewSQLiteConnection(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := sqlite.Migrate(ctx, database); err != nil {
+			return nil, err
+		}
+
+		auctionRepository := sqlite.NewAuctionRepository(database, broker)
+		if err := auctionRepository.RecoverAuctions(ctx); err != nil {
+			return nil, err
+		}
+		return &repositories{
+			auction:        auctionRepository,
+			bid:            sqlite.NewBidRepository(database, auctionRepository, broker),
+			user:           sqlite.NewUserRepository(database),
+			product:        sqlite.NewProductRepository(database),
+			categorySchema: sqlite.NewCategorySchemaRepository(database),
+			category:       sqlite.NewCategoryRepository(database),
+			fraudFlag:      sqlite.NewFraudFlagRepository(database),
+			rejectedBid:    sqlite.NewRejectedBidRepository(database),
+			question:       sqlite.NewQuestionRepository(database),
+			terms:          sqlite.NewTermsAcceptanceRepository(database),
+			offer:          sqlite.NewOfferRepository(database),
+			transfer:       sqlite.NewTransferRepository(database),
+			job:            sqlite.NewJobRepository(database),
+			recurring:      sqlite.NewRecurringAuctionRepository(database),
+			series:         sqlite.NewSeriesRepository(database),
+			absenteeBid:    sqlite.NewAbsenteeBidRepository(database),
+			proxyBid:       sqlite.NewProxyBidRepository(database),
+			registration:   sqlite.NewRegistrationRepository(database),
+			notification:   sqlite.NewNotificationRepository(database),
+		}, nil
+	}
+
+	database, err := mongodb.NewMongoDBConnection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return initMongoRepositories(ctx, database, broker)
+}
+
+// initMongoRepositories is the repository factory of the MongoDB storage,
+// wiring the repositories over the database, one per tenant.
+func initMongoRepositories(
+	ctx context.Context, database *mongo.Database, broker *event.Broker) (*repositories, error) {
+	auctionRepository := auction.NewAuctionRepository(database, broker)
+	if err := auctionRepository.CreateSearchIndex(ctx); err != nil {
+		return nil, err
+	}
+	if err := auctionRepository.CreateListingIndexes(ctx); err != nil {
+		return nil, err
+	}
+	if err := auctionRepository.RecoverAuctions(ctx); err != nil {
+		return nil, err
+	}
+
+	return &repositories{
+		auction:        auctionRepository,
+		bid:            bid.NewBidRepository(database, auctionRepository, broker),
+		user:           user.NewUserRepository(database),
+		product:        product.NewProductRepository(database),
+		categorySchema: category.NewCategorySchemaRepository(database),
+		category:       category.NewCategoryRepository(database),
+		fraudFlag:      fraud.NewFraudFlagRepository(database),
+		rejectedBid:    bid.NewRejectedBidRepository(database),
+		question:       question.NewQuestionRepository(database),
+		terms:          auction.NewTermsAcceptanceRepository(database),
+		offer:          offer.NewOfferRepository(database),
+		transfer:       auction.NewTransferRepository(database),
+		job:            job.NewJobRepository(database),
+		recurring:      auction.NewRecurringAuctionRepository(database),
+		series:         auction.NewSeriesRepository(database),
+		absenteeBid:    bid.NewAbsenteeBidRepository(database),
+		proxyBid:       bid.NewProxyBidRepository(database),
+		registration:   auction.NewRegistrationRepository(database),
+		notification:   inbox.NewNotificationRepository(database),
+	}, nil
+}
+
+// instrumentRepositories wraps every repository with latency and error
+// metrics, enabled with REPOSITORY_METRICS_ENABLED=true.
+func instrumentRepositories(repos *repositories) *repositories {
+	metrics := instrumentation.NewInstrumentation()
+
+	return &repositories{
+		auction:        instrumentation.NewAuctionRepository(repos.auction, metrics),
+		bid:            instrumentation.NewBidRepository(repos.bid, metrics),
+		user:           instrumentation.NewUserRepository(repos.user, metrics),
+		product:        instrumentation.NewProductRepository(repos.product, metrics),
+		categorySchema: instrumentation.NewCategorySchemaRepository(repos.categorySchema, metrics),
+		category:       instrumentation.NewCategoryRepository(repos.category, metrics),
+		fraudFlag:      instrumentation.NewFraudFlagRepository(repos.fraudFlag, metrics),
+		rejectedBid:    instrumentation.NewRejectedBidRepository(repos.rejectedBid, metrics),
+		question:       instrumentation.NewQuestionRepository(repos.question, metrics),
+		terms:          instrumentation.NewTermsAcceptanceRepository(repos.terms, metrics),
+		offer:          instrumentation.NewOfferRepository(repos.offer, metrics),
+		transfer:       instrumentation.NewTransferRepository(repos.transfer, metrics),
+		job:            instrumentation.NewJobRepository(repos.job, metrics),
+		recurring:      instrumentation.NewRecurringAuctionRepository(repos.recurring, metrics),
+		series:         instrumentation.NewSeriesRepository(repos.series, metrics),
+		absenteeBid:    instrumentation.NewAbsenteeBidRepository(repos.absenteeBid, metrics),
+		proxyBid:       instrumentation.NewProxyBidRepository(repos.proxyBid, metrics),
+		registration:   instrumentation.NewRegistrationRepository(repos.registration, metrics),
+		notification:   instrumentation.NewNotificationRepository(repos.notification, metrics),
+	}
+}
+
+func initDependencies(repos *repositories, tokenIssuer user_usecase.TokenIssuer, broker, cluster *event.Broker) (
 	userController *user_controller.UserController,
 	bidController *bid_controller.BidController,
-	auctionController *auction_controller.AuctionController) {
+	auctionController *auction_controller.AuctionController,
+	productController *product_controller.ProductController,
+	categoryController *category_controller.CategoryController,
+	exportController *export_controller.ExportController,
+	fraudController *fraud_controller.FraudController,
+	questionController *question_controller.QuestionController,
+	activityController *activity_controller.ActivityController,
+	offerController *offer_controller.OfferController,
+	transferController *transfer_controller.TransferController,
+	jobController *job_controller.JobController,
+	recurringAuctionController *recurring_auction_controller.RecurringAuctionController,
+	seriesController *series_controller.SeriesController,
+	liveController *live_controller.LiveController,
+	auctioneerController *auctioneer_controller.AuctioneerController,
+	absenteeController *absentee_controller.AbsenteeController,
+	proxyController *proxy_controller.ProxyController,
+	retentionController *retention_controller.RetentionController,
+	registrationController *registration_controller.RegistrationController,
+	viewController *view_controller.ViewController,
+	notificationController *notification_controller.NotificationController) {
 
-	auctionRepository := auction.NewAuctionRepository(database)
-	bidRepository := bid.NewBidRepository(database, auctionRepository)
-	userRepository := user.NewUserRepository(database)
+	jobQueue := job_usecase.NewJobQueue(repos.job)
+	jobQueue.Register(notification.SendNotificationJob, notification.DeliverJob(notification.NewMultiNotifier(
+		notification.NewInboxNotifier(repos.notification), notification.NewLogNotifier())))
+	notifier := notification.NewQueuedNotifier(jobQueue)
+	lifecycle_usecase.NewExpirationWarner(repos.auction, repos.bid, broker, notifier)
+	hub := event.NewHub(cluster)
+	viewUseCase := view_usecase.NewViewUseCase(repos.auction, repos.bid, hub)
+	lifecycle_usecase.NewHeatScorer(repos.auction, repos.bid, hub, viewUseCase)
+	lifecycle_usecase.NewReserveEnforcer(repos.auction, repos.bid, broker)
+
+	bidUseCase := bid_usecase.NewBidUseCase(
+		repos.bid, repos.auction, repos.user, repos.rejectedBid, repos.terms, repos.registration,
+		lifecycle_usecase.NewLifecycleManager(
+			repos.auction, lifecycle_usecase.NewExtensionPolicy(),
+			lifecycle_usecase.NewSeriesCascade(repos.series, repos.auction)))
+	auctionUseCase := auction_usecase.NewAuctionUseCase(
+		repos.auction, repos.bid, repos.product, repos.categorySchema, repos.category, repos.user, repos.series,
+		bidUseCase, cluster)
 
 	userController = user_controller.NewUserController(
-		user_usecase.NewUserUseCase(userRepository))
-	auctionController = auction_controller.NewAuctionController(
-		auction_usecase.NewAuctionUseCase(auctionRepository, bidRepository))
-	bidController = bid_controller.NewBidController(bid_usecase.NewBidUseCase(bidRepository))
+		user_usecase.NewUserUseCase(repos.user, tokenIssuer))
+	auctionController = auction_controller.NewAuctionController(auctionUseCase)
+	bidController = bid_controller.NewBidController(bidUseCase)
+	productController = product_controller.NewProductController(
+		product_usecase.NewProductUseCase(repos.product))
+	categoryController = category_controller.NewCategoryController(
+		category_usecase.NewCategoryUseCase(repos.categorySchema, repos.category))
+	exportController = export_controller.NewExportController(auctionUseCase, bidUseCase)
+	fraudController = fraud_controller.NewFraudController(
+		fraud_usecase.NewFraudUseCase(repos.auction, repos.bid, repos.fraudFlag))
+	questionController = question_controller.NewQuestionController(
+		question_usecase.NewQuestionUseCase(repos.question, repos.auction, notifier))
+	activityController = activity_controller.NewActivityController(
+		activity_usecase.NewActivityUseCase(repos.auction, repos.bid, repos.question))
+	offerController = offer_controller.NewOfferController(
+		offer_usecase.NewOfferUseCase(repos.offer, repos.auction, repos.bid, notifier))
+	transferController = transfer_controller.NewTransferController(
+		transfer_usecase.NewTransferUseCase(repos.auction, repos.transfer, notifier))
+	jobController = job_controller.NewJobController(
+		job_usecase.NewJobUseCase(repos.job))
+	recurringAuctionController = recurring_auction_controller.NewRecurringAuctionController(
+		auction_usecase.NewRecurringAuctionUseCase(repos.recurring, auctionUseCase))
+	seriesController = series_controller.NewSeriesController(
+		auction_usecase.NewSeriesUseCase(repos.series, repos.auction, repos.bid))
+	liveController = live_controller.NewLiveController(
+		live_usecase.NewLiveUseCase(repos.auction, repos.bid, hub))
+	auctioneerController = auctioneer_controller.NewAuctioneerController(
+		auctioneer_usecase.NewAuctioneerUseCase(repos.auction, repos.user, bidUseCase, broker))
+	absenteeController = absentee_controller.NewAbsenteeController(
+		absentee_usecase.NewAbsenteeUseCase(repos.absenteeBid, repos.auction, bidUseCase, broker))
+	proxyController = proxy_controller.NewProxyController(
+		proxy_usecase.NewProxyUseCase(repos.proxyBid, repos.auction, repos.bid, bidUseCase, broker))
+	retentionController = retention_controller.NewRetentionController(
+		retention_usecase.NewRetentionUseCase(repos.auction, repos.bid, repos.rejectedBid))
+	registrationController = registration_controller.NewRegistrationController(
+		registration_usecase.NewRegistrationUseCase(repos.registration, repos.auction, repos.user))
+	viewController = view_controller.NewViewController(viewUseCase)
+	notificationController = notification_controller.NewNotificationController(
+		notification_usecase.NewNotificationUseCase(repos.notification))
 
 	return
 }
+
+// getPublicRateLimit is how many requests a minute each client can make to
+// the public API.
+func getPublicRateLimit() int {
+	value, err := strconv.Atoi(os.Getenv(PUBLIC_RATE_LIMIT))
+	if err != nil || value <= 0 {
+		return 60
+	}
+
+	return value
+}
+
+func getPublicCacheTTL() time.Duration {
+	value, err := time.ParseDuration(os.Getenv(PUBLIC_CACHE_TTL))
+	if err != nil || value <= 0 {
+		return 30 * time.Second
+	}
+
+	return value
+}
+
+// getEventsChannel is the Redis channel of the tenant, the channel of the
+// single tenant when empty.
+func getEventsChannel(tenantId string) string {
+	channel := os.Getenv(REDIS_EVENTS_CHANNEL)
+	if channel == "" {
+		channel = "auction:events"
+	}
+	if tenantId != "" {
+		channel += ":" + tenantId
+	}
+
+	return channel
+}
+
+func getApiAddr() string {
+	if addr := os.Getenv(API_ADDR); addr != "" {
+		return addr
+	}
+	return ":8080"
+}
+
+// getAdminAddr defaults to the loopback interface, so the admin endpoints
+// are only reachable from the host unless bound elsewhere on purpose.
+func getAdminAddr() string {
+	if addr := os.Getenv(ADMIN_ADDR); addr != "" {
+		return addr
+	}
+	return "127.0.0.1:8081"
+}
+
+func getLegacyNumericEnums() bool {
+	accept, err := strconv.ParseBool(os.Getenv(LEGACY_NUMERIC_ENUMS))
+	return err != nil || accept
+}
+
+func getClockSpeed() float64 {
+	value, err := strconv.ParseFloat(os.Getenv(CLOCK_SPEED), 64)
+	if err != nil {
+		return 1
+	}
+
+	return value
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/database/mongodb"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/infra/database/auction"
+	"fullcycle-auction_go/internal/infra/database/bid"
+	"fullcycle-auction_go/internal/infra/database/migration"
+	"fullcycle-auction_go/internal/infra/database/user"
+	"github.com/joho/godotenv"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+	"log"
+)
+
+func main() {
+	ctx := context.Background()
+
+	if err := godotenv.Load("cmd/auction/.env"); err != nil {
+		log.Fatal("Error trying to load env variables")
+		return
+	}
+
+	databaseConnection, err := mongodb.NewMongoDBConnection(ctx)
+	if err != nil {
+		log.Fatal(err.Error())
+		return
+	}
+
+	migrations := []struct {
+		collection *mongo.Collection
+		chain      *migration.Chain
+	}{
+		{auction.NewAuctionRepository(databaseConnection, nil).Collection, auction.AuctionUpcasters},
+		{databaseConnection.Collection("bids"), bid.BidUpcasters},
+		{user.NewUserRepository(databaseConnection).Collection, user.UserUpcasters},
+	}
+
+	for _, m := range migrations {
+		migrated, err := m.chain.MigrateCollection(ctx, m.collection)
+		if err != nil {
+			log.Fatalf("Error trying to migrate collection %s: %s", m.collection.Name(), err.Error())
+			return
+		}
+
+		// The version is recorded once every document is migrated, for the
+		// builds that do not read it to refuse to start.
+		if err := migration.RecordVersion(
+			ctx, databaseConnection, m.collection.Name(), m.chain.LatestVersion()); err != nil {
+			log.Fatalf("Error trying to record the schema version of %s: %s", m.collection.Name(), err.Error())
+			return
+		}
+
+		logger.Info("Collection migrated",
+			zap.String("collection", m.collection.Name()),
+			zap.Int("schema_version", m.chain.LatestVersion()),
+			zap.Int64("migrated_documents", migrated))
+	}
+}
@@ -0,0 +1,48 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+var (
+	mutex sync.RWMutex
+	start = time.Now()
+	speed = 1.0
+)
+
+// SetSpeed makes the application clock run speed times faster than the wall
+// clock. It is meant for development runs where auctions should complete in
+// seconds instead of minutes.
+func SetSpeed(multiplier float64) {
+	if multiplier <= 0 {
+		multiplier = 1
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	start = time.Now()
+	speed = multiplier
+}
+
+func Now() time.Time {
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	if speed == 1 {
+		return time.Now()
+	}
+
+	elapsed := time.Since(start)
+	return start.Add(time.Duration(float64(elapsed) * speed))
+}
+
+// Scale converts an application duration into the wall clock duration to
+// wait for it, according to the configured speed.
+func Scale(duration time.Duration) time.Duration {
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	return time.Duration(float64(duration) / speed)
+}
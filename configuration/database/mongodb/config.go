@@ -0,0 +1,68 @@
+package mongodb
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Config tunes the MongoDB client. Options set in MONGODB_URL, such as
+// maxPoolSize, take precedence over it.
+type Config struct {
+	URL      string
+	Database string
+
+	MinPoolSize uint64
+	MaxPoolSize uint64
+	// MaxConnIdleTime closes connections idle for longer, zero keeps them.
+	MaxConnIdleTime time.Duration
+	// OperationTimeout bounds each operation whose context has no
+	// deadline, zero leaves them unbounded.
+	OperationTimeout time.Duration
+}
+
+// LoadConfig reads the configuration from the environment, falling back to
+// the driver defaults for the settings left unset.
+func LoadConfig() Config {
+	return Config{
+		URL:              os.Getenv(MONGODB_URL),
+		Database:         os.Getenv(MONGODB_DB),
+		MinPoolSize:      getUint("MONGODB_MIN_POOL_SIZE", 0),
+		MaxPoolSize:      getUint("MONGODB_MAX_POOL_SIZE", 100),
+		MaxConnIdleTime:  getDuration("MONGODB_MAX_CONN_IDLE_TIME", 0),
+		OperationTimeout: getDuration("MONGODB_OPERATION_TIMEOUT", 0),
+	}
+}
+
+func (c Config) clientOptions() *options.ClientOptions {
+	clientOptions := options.Client().
+		SetMinPoolSize(c.MinPoolSize).
+		SetMaxPoolSize(c.MaxPoolSize).
+		SetMaxConnIdleTime(c.MaxConnIdleTime).
+		SetPoolMonitor(newPoolMonitor())
+	if c.OperationTimeout > 0 {
+		clientOptions.SetTimeout(c.OperationTimeout)
+	}
+
+	return clientOptions.ApplyURI(c.URL)
+}
+
+func getUint(name string, fallback uint64) uint64 {
+	value, err := strconv.ParseUint(os.Getenv(name), 10, 64)
+	if err != nil {
+		return fallback
+	}
+
+	return value
+}
+
+func getDuration(name string, fallback time.Duration) time.Duration {
+	duration, err := time.ParseDuration(os.Getenv(name))
+	if err != nil || duration < 0 {
+		return fallback
+	}
+
+	return duration
+}
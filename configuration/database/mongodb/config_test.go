@@ -0,0 +1,35 @@
+package mongodb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Setenv("MONGODB_MAX_POOL_SIZE", "200")
+	t.Setenv("MONGODB_OPERATION_TIMEOUT", "5s")
+	t.Setenv("MONGODB_MAX_CONN_IDLE_TIME", "not a duration")
+
+	config := LoadConfig()
+
+	assert.Equal(t, uint64(0), config.MinPoolSize)
+	assert.Equal(t, uint64(200), config.MaxPoolSize)
+	assert.Equal(t, time.Duration(0), config.MaxConnIdleTime)
+	assert.Equal(t, 5*time.Second, config.OperationTimeout)
+}
+
+func TestConfig_URLOptionsTakePrecedence(t *testing.T) {
+	config := Config{
+		URL:         "mongodb://localhost:27017/?maxPoolSize=10",
+		MinPoolSize: 2,
+		MaxPoolSize: 200,
+	}
+
+	clientOptions := config.clientOptions()
+
+	assert.Equal(t, uint64(10), *clientOptions.MaxPoolSize)
+	assert.Equal(t, uint64(2), *clientOptions.MinPoolSize)
+	assert.Nil(t, clientOptions.Timeout)
+}
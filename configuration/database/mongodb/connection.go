@@ -3,10 +3,8 @@ package mongodb
 import (
 	"context"
 	"fullcycle-auction_go/configuration/logger"
-	"os"
 
 	"go.mongodb.org/mongo-driver/mongo"
-	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 const (
@@ -14,12 +12,14 @@ const (
 	MONGODB_DB  = "MONGODB_DB"
 )
 
+// NewMongoDBConnection connects with the configuration read from the
+// environment.
 func NewMongoDBConnection(ctx context.Context) (*mongo.Database, error) {
-	mongoURL := os.Getenv(MONGODB_URL)
-	mongoDatabase := os.Getenv(MONGODB_DB)
+	return NewMongoDBConnectionWithConfig(ctx, LoadConfig())
+}
 
-	client, err := mongo.Connect(
-		ctx, options.Client().ApplyURI(mongoURL))
+func NewMongoDBConnectionWithConfig(ctx context.Context, config Config) (*mongo.Database, error) {
+	client, err := mongo.Connect(ctx, config.clientOptions())
 	if err != nil {
 		logger.Error("Error trying to connect to mongodb database", err)
 		return nil, err
@@ -30,5 +30,5 @@ func NewMongoDBConnection(ctx context.Context) (*mongo.Database, error) {
 		return nil, err
 	}
 
-	return client.Database(mongoDatabase), nil
+	return client.Database(config.Database), nil
 }
@@ -0,0 +1,52 @@
+package mongodb
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+var (
+	poolConnections = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "mongodb_pool_connections",
+		Help: "Connections of the MongoDB pool by state: open or in_use.",
+	}, []string{"address", "state"})
+
+	poolMaxSize = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "mongodb_pool_max_size",
+		Help: "Configured maximum number of connections per MongoDB server.",
+	})
+
+	poolCheckoutFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "mongodb_pool_checkout_failures_total",
+		Help: "Connection checkouts that failed, by reason, e.g. timeout when the pool is exhausted.",
+	}, []string{"address", "reason"})
+)
+
+func init() {
+	prometheus.MustRegister(poolConnections, poolMaxSize, poolCheckoutFailures)
+}
+
+// newPoolMonitor tracks the pool in the metrics, so its utilization is
+// mongodb_pool_connections{state="in_use"} over mongodb_pool_max_size.
+func newPoolMonitor() *event.PoolMonitor {
+	return &event.PoolMonitor{
+		Event: func(poolEvent *event.PoolEvent) {
+			switch poolEvent.Type {
+			case event.PoolCreated:
+				if poolEvent.PoolOptions != nil {
+					poolMaxSize.Set(float64(poolEvent.PoolOptions.MaxPoolSize))
+				}
+			case event.ConnectionCreated:
+				poolConnections.WithLabelValues(poolEvent.Address, "open").Inc()
+			case event.ConnectionClosed:
+				poolConnections.WithLabelValues(poolEvent.Address, "open").Dec()
+			case event.GetSucceeded:
+				poolConnections.WithLabelValues(poolEvent.Address, "in_use").Inc()
+			case event.ConnectionReturned:
+				poolConnections.WithLabelValues(poolEvent.Address, "in_use").Dec()
+			case event.GetFailed:
+				poolCheckoutFailures.WithLabelValues(poolEvent.Address, poolEvent.Reason).Inc()
+			}
+		},
+	}
+}
@@ -0,0 +1,38 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fullcycle-auction_go/configuration/logger"
+	"os"
+
+	_ "modernc.org/sqlite"
+)
+
+const (
+	SQLITE_PATH = "SQLITE_PATH"
+)
+
+func NewSQLiteConnection(ctx context.Context) (*sql.DB, error) {
+	sqlitePath := os.Getenv(SQLITE_PATH)
+	if sqlitePath == "" {
+		sqlitePath = "auction.db"
+	}
+
+	database, err := sql.Open("sqlite", sqlitePath)
+	if err != nil {
+		logger.Error("Error trying to open sqlite database", err)
+		return nil, err
+	}
+
+	// SQLite allows a single writer, so concurrent bid inserts would only
+	// fail with SQLITE_BUSY on a larger pool.
+	database.SetMaxOpenConns(1)
+
+	if err := database.PingContext(ctx); err != nil {
+		logger.Error("Error trying to ping sqlite database", err)
+		return nil, err
+	}
+
+	return database, nil
+}
@@ -0,0 +1,127 @@
+// Package enum serializes integer enums as their names, so API payloads
+// read "active" or "refurbished" instead of bare numbers.
+package enum
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+var acceptNumeric atomic.Bool
+
+func init() {
+	acceptNumeric.Store(true)
+}
+
+// SetAcceptNumeric tells whether the legacy numeric values are still
+// accepted in place of the names. They are until the deprecation window
+// closes.
+func SetAcceptNumeric(accept bool) {
+	acceptNumeric.Store(accept)
+}
+
+// Error is returned for a value that is not a name of the enum, nor one of
+// its numeric values while they are accepted.
+type Error struct {
+	Kind  string
+	Value string
+	Names []string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s must be one of %s, got %s", e.Kind, strings.Join(e.Names, ", "), e.Value)
+}
+
+// Enum maps the values of T to their names. Values with no name, the
+// unset zero of an optional enum among them, are written as null.
+type Enum[T ~int64] struct {
+	kind   string
+	names  map[T]string
+	values map[string]T
+}
+
+func New[T ~int64](kind string, names map[T]string) Enum[T] {
+	values := make(map[string]T, len(names))
+	for value, name := range names {
+		values[name] = value
+	}
+
+	return Enum[T]{kind: kind, names: names, values: values}
+}
+
+func (e Enum[T]) Name(value T) string {
+	return e.names[value]
+}
+
+func (e Enum[T]) Marshal(value T) ([]byte, error) {
+	name, ok := e.names[value]
+	if !ok {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(name)
+}
+
+// Unmarshal parses a JSON name or, while accepted, a numeric value. Null
+// leaves the zero value.
+func (e Enum[T]) Unmarshal(data []byte) (T, error) {
+	if bytes.Equal(data, []byte("null")) {
+		return 0, nil
+	}
+
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		if value, ok := e.values[name]; ok {
+			return value, nil
+		}
+		return 0, e.error(name)
+	}
+
+	return e.parseNumeric(string(data))
+}
+
+// Parse parses a name or, while accepted, a numeric value, as sent in query
+// params.
+func (e Enum[T]) Parse(text string) (T, error) {
+	if value, ok := e.values[text]; ok {
+		return value, nil
+	}
+
+	return e.parseNumeric(text)
+}
+
+// parseNumeric accepts the numeric values with a name, and zero, which
+// legacy clients send for an unset optional enum.
+func (e Enum[T]) parseNumeric(text string) (T, error) {
+	number, err := strconv.ParseInt(text, 10, 64)
+	if err != nil || !acceptNumeric.Load() {
+		return 0, e.error(text)
+	}
+
+	value := T(number)
+	if _, ok := e.names[value]; !ok && value != 0 {
+		return 0, e.error(text)
+	}
+
+	return value, nil
+}
+
+func (e Enum[T]) error(value string) *Error {
+	values := make([]T, 0, len(e.names))
+	for value := range e.names {
+		values = append(values, value)
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+
+	names := make([]string, 0, len(values))
+	for _, value := range values {
+		names = append(names, e.names[value])
+	}
+
+	return &Error{Kind: e.kind, Value: value, Names: names}
+}
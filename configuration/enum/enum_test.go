@@ -0,0 +1,62 @@
+package enum
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type color int64
+
+var colors = New("color", map[color]string{1: "red", 2: "green"})
+
+func TestEnum_Marshal(t *testing.T) {
+	data, err := colors.Marshal(2)
+	require.NoError(t, err)
+	assert.Equal(t, `"green"`, string(data))
+
+	data, err = colors.Marshal(0)
+	require.NoError(t, err)
+	assert.Equal(t, `null`, string(data))
+}
+
+func TestEnum_Unmarshal(t *testing.T) {
+	value, err := colors.Unmarshal([]byte(`"red"`))
+	require.NoError(t, err)
+	assert.Equal(t, color(1), value)
+
+	value, err = colors.Unmarshal([]byte(`null`))
+	require.NoError(t, err)
+	assert.Equal(t, color(0), value)
+
+	_, err = colors.Unmarshal([]byte(`"Red"`))
+	var enumErr *Error
+	require.ErrorAs(t, err, &enumErr)
+	assert.Equal(t, "color must be one of red, green, got Red", enumErr.Error())
+
+	_, err = colors.Unmarshal([]byte(`true`))
+	assert.Error(t, err)
+}
+
+func TestEnum_LegacyNumericValues(t *testing.T) {
+	defer SetAcceptNumeric(true)
+
+	value, err := colors.Unmarshal([]byte(`2`))
+	require.NoError(t, err)
+	assert.Equal(t, color(2), value)
+
+	value, err = colors.Parse("0")
+	require.NoError(t, err)
+	assert.Equal(t, color(0), value)
+
+	_, err = colors.Unmarshal([]byte(`3`))
+	assert.Error(t, err)
+
+	SetAcceptNumeric(false)
+	_, err = colors.Unmarshal([]byte(`2`))
+	assert.Error(t, err)
+	value, err = colors.Parse("green")
+	require.NoError(t, err)
+	assert.Equal(t, color(2), value)
+}
@@ -0,0 +1,108 @@
+package i18n
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Languages lists the supported languages, by their primary subtag.
+var Languages = []string{"en", "pt", "es"}
+
+// catalog holds the labels of the fixed codes served by the API. Categories
+// are not fixed, their labels live in the category schemas.
+var catalog = map[string]map[string]string{
+	"en": {
+		"status.active":         "Active",
+		"status.completed":      "Completed",
+		"status.scheduled":      "Scheduled",
+		"status.closed_no_sale": "Closed, not sold",
+		"status.cancelled":      "Cancelled",
+		"status.paused":         "Paused",
+		"status.draft":          "Draft",
+		"condition.new":         "New",
+		"condition.used":        "Used",
+		"condition.refurbished": "Refurbished",
+	},
+	"pt": {
+		"status.active":         "Ativo",
+		"status.completed":      "Encerrado",
+		"status.scheduled":      "Agendado",
+		"status.closed_no_sale": "Encerrado sem venda",
+		"status.cancelled":      "Cancelado",
+		"status.paused":         "Pausado",
+		"status.draft":          "Rascunho",
+		"condition.new":         "Novo",
+		"condition.used":        "Usado",
+		"condition.refurbished": "Recondicionado",
+	},
+	"es": {
+		"status.active":         "Activa",
+		"status.completed":      "Finalizada",
+		"status.scheduled":      "Programada",
+		"status.closed_no_sale": "Finalizada sin venta",
+		"status.cancelled":      "Cancelada",
+		"status.paused":         "Pausada",
+		"status.draft":          "Borrador",
+		"condition.new":         "Nuevo",
+		"condition.used":        "Usado",
+		"condition.refurbished": "Reacondicionado",
+	},
+}
+
+// Negotiate picks the supported language that best matches an
+// Accept-Language header, comparing primary subtags only. It returns an
+// empty string when the header is missing or nothing matches.
+func Negotiate(acceptLanguage string) string {
+	type weighted struct {
+		tag    string
+		weight float64
+	}
+
+	var tags []weighted
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if tag == "" {
+			continue
+		}
+
+		weight := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			weight = parsed
+		}
+		if weight > 0 {
+			tags = append(tags, weighted{tag: strings.ToLower(tag), weight: weight})
+		}
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].weight > tags[j].weight
+	})
+
+	for _, tag := range tags {
+		if tag.tag == "*" {
+			return Languages[0]
+		}
+
+		primary, _, _ := strings.Cut(tag.tag, "-")
+		if _, ok := catalog[primary]; ok {
+			return primary
+		}
+	}
+
+	return ""
+}
+
+// Label returns the label of key in the language, falling back to the key
+// itself when the catalog has none.
+func Label(language, key string) string {
+	if label, ok := catalog[language][key]; ok {
+		return label
+	}
+
+	return key
+}
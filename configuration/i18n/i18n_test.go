@@ -0,0 +1,16 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiate(t *testing.T) {
+	assert.Equal(t, "", Negotiate(""))
+	assert.Equal(t, "pt", Negotiate("pt-BR"))
+	assert.Equal(t, "es", Negotiate("fr-FR, es;q=0.8, en;q=0.5"))
+	assert.Equal(t, "en", Negotiate("pt;q=0.2, en-US;q=0.9"))
+	assert.Equal(t, "en", Negotiate("fr, *;q=0.1"))
+	assert.Equal(t, "", Negotiate("fr, pt;q=0"))
+}
@@ -0,0 +1,36 @@
+package redis
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"os"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	REDIS_URL = "REDIS_URL"
+)
+
+// NewRedisConnection connects to REDIS_URL, returning nil when it is not
+// set: the instance then runs alone.
+func NewRedisConnection(ctx context.Context) (*redis.Client, error) {
+	url := os.Getenv(REDIS_URL)
+	if url == "" {
+		return nil, nil
+	}
+
+	options, err := redis.ParseURL(url)
+	if err != nil {
+		logger.Error("Error trying to parse the redis url", err)
+		return nil, err
+	}
+
+	client := redis.NewClient(options)
+	if err := client.Ping(ctx).Err(); err != nil {
+		logger.Error("Error trying to ping redis", err)
+		return nil, err
+	}
+
+	return client, nil
+}
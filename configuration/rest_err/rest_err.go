@@ -10,6 +10,7 @@ type RestErr struct {
 	Err     string   `json:"err"`
 	Code    int      `json:"code"`
 	Causes  []Causes `json:"causes"`
+	Reason  string   `json:"reason,omitempty"`
 }
 
 type Causes struct {
@@ -22,14 +23,24 @@ func (r *RestErr) Error() string {
 }
 
 func ConvertError(internalError *internal_error.InternalError) *RestErr {
+	var restErr *RestErr
 	switch internalError.Err {
 	case "bad_request":
-		return NewBadRequestError(internalError.Error())
+		restErr = NewBadRequestError(internalError.Error())
 	case "not_found":
-		return NewNotFoundError(internalError.Error())
+		restErr = NewNotFoundError(internalError.Error())
+	case "forbidden":
+		restErr = NewForbiddenError(internalError.Error())
+	case "unauthorized":
+		restErr = NewUnauthorizedError(internalError.Error())
+	case "conflict":
+		restErr = NewConflictError(internalError.Error())
 	default:
-		return NewInternalServerError(internalError.Error())
+		restErr = NewInternalServerError(internalError.Error())
 	}
+	restErr.Reason = internalError.Reason
+
+	return restErr
 }
 
 func NewBadRequestError(message string, causes ...Causes) *RestErr {
@@ -58,3 +69,48 @@ func NewNotFoundError(message string) *RestErr {
 		Causes:  nil,
 	}
 }
+
+func NewForbiddenError(message string) *RestErr {
+	return &RestErr{
+		Message: message,
+		Err:     "forbidden",
+		Code:    http.StatusForbidden,
+		Causes:  nil,
+	}
+}
+
+func NewUnauthorizedError(message string) *RestErr {
+	return &RestErr{
+		Message: message,
+		Err:     "unauthorized",
+		Code:    http.StatusUnauthorized,
+		Causes:  nil,
+	}
+}
+
+func NewConflictError(message string) *RestErr {
+	return &RestErr{
+		Message: message,
+		Err:     "conflict",
+		Code:    http.StatusConflict,
+		Causes:  nil,
+	}
+}
+
+func NewTooManyRequestsError(message string) *RestErr {
+	return &RestErr{
+		Message: message,
+		Err:     "too_many_requests",
+		Code:    http.StatusTooManyRequests,
+		Causes:  nil,
+	}
+}
+
+func NewServiceUnavailableError(message string) *RestErr {
+	return &RestErr{
+		Message: message,
+		Err:     "service_unavailable",
+		Code:    http.StatusServiceUnavailable,
+		Causes:  nil,
+	}
+}
@@ -2,22 +2,33 @@ package auction_entity
 
 import (
 	"context"
+	"fullcycle-auction_go/configuration/clock"
 	"fullcycle-auction_go/internal/internal_error"
 	"github.com/google/uuid"
 	"time"
 )
 
 func CreateAuction(
-	productName, category, description string,
-	condition ProductCondition) (*Auction, *internal_error.InternalError) {
+	sellerId, productId, productName, category, description string,
+	grading ConditionGrading,
+	attributes map[string]string) (*Auction, *internal_error.InternalError) {
+	now := clock.Now()
 	auction := &Auction{
 		Id:          uuid.New().String(),
+		SellerId:    sellerId,
+		ProductId:   productId,
 		ProductName: productName,
 		Category:    category,
 		Description: description,
-		Condition:   condition,
+		Condition:   grading.Condition(),
+		Grading:     grading,
+		Attributes:  attributes,
 		Status:      Active,
-		Timestamp:   time.Now(),
+		Timestamp:   now,
+		UpdatedAt:   now,
+		StartsAt:    now,
+
+		BidderVisibility: BidderPublic,
 	}
 
 	if err := auction.Validate(); err != nil {
@@ -36,17 +47,199 @@ func (au *Auction) Validate() *internal_error.InternalError {
 		return internal_error.NewBadRequestError("invalid auction object")
 	}
 
-	return nil
+	return au.Grading.Validate()
 }
 
 type Auction struct {
 	Id          string
+	SellerId    string
+	ProductId   string
 	ProductName string
 	Category    string
 	Description string
 	Condition   ProductCondition
+	Grading     ConditionGrading
+	Attributes  map[string]string
 	Status      AuctionStatus
 	Timestamp   time.Time
+	UpdatedAt   time.Time
+	StartsAt    time.Time
+	EndsAt      time.Time
+	WinnerBidId string
+
+	BidderVisibility BidderVisibility
+	Terms            *Terms
+
+	// Descriptions holds the description translated by the seller, by
+	// language. Description is served in the languages it lacks.
+	Descriptions map[string]string
+
+	// RecurringAuctionId links the auction to the recurring auction it was
+	// created for, empty for one-off auctions.
+	RecurringAuctionId string
+
+	// SeriesId groups the auction with the other lots of a series, LotNumber
+	// being its position in it. Both are empty outside of a series.
+	SeriesId  string
+	LotNumber int
+
+	// Live lots are run by an auctioneer: bids are taken only once they
+	// open bidding, at BiddingOpenedAt, and the lot ends when they hammer
+	// it, the end time being a fallback.
+	Live            bool
+	BiddingOpenedAt time.Time
+
+	// ReservePrice is the lowest amount the seller sells for, zero when the
+	// auction has no reserve.
+	ReservePrice float64
+
+	// MinIncrement is how much a bid must raise the highest bid by, any
+	// raise being enough when zero.
+	MinIncrement float64
+
+	// Sealed auctions keep the bids hidden until they end, when the highest
+	// one is revealed as the winner.
+	Sealed bool
+
+	// StartingPrice is the lowest amount bids open at, zero when any amount
+	// does.
+	StartingPrice float64
+
+	// BuyNowPrice completes the auction as soon as a bid meets it, zero when
+	// the auction cannot be bought outright.
+	BuyNowPrice float64
+
+	// Quantity is how many identical units the auction sells, one to each
+	// of the highest bidders, zero meaning a single one. UniformPrice makes
+	// every winner pay the lowest winning bid rather than their own.
+	Quantity     int
+	UniformPrice bool
+
+	// EstimateLow and EstimateHigh are the range the seller expects the lot
+	// to sell within, both zero when there is no estimate.
+	EstimateLow  float64
+	EstimateHigh float64
+
+	// RegistrationRequired lots only take bids from the users whose
+	// registration for them was approved.
+	RegistrationRequired bool
+
+	// PausedAt is when the auction was paused, zero unless it is Paused.
+	PausedAt time.Time
+
+	// PublicAt is when the auction starts showing in public listings, zero
+	// when it always did. Until then only its seller and Invitees, the
+	// users the seller invited, see it listed.
+	PublicAt time.Time
+	Invitees []string
+
+	// RelistedFrom is the auction this one lists the lot of again.
+	RelistedFrom string
+
+	// HeatScore ranks how popular the auction is, updated while it is
+	// active by the heat scorer.
+	HeatScore float64
+
+	// Views counts the views of the auction page, one per viewer an hour.
+	Views int64
+}
+
+// Schedule makes the auction start at startsAt rather than right away,
+// staying Scheduled until then.
+func (au *Auction) Schedule(startsAt time.Time) {
+	au.Status = Scheduled
+	au.StartsAt = startsAt
+}
+
+// Publish makes the draft run for as long as it was set to from now, or
+// from its start time when still ahead, staying Scheduled until then.
+func (au *Auction) Publish(now time.Time) {
+	duration := au.EndsAt.Sub(au.OpensAt())
+
+	au.Status = Active
+	if au.StartsAt.After(now) {
+		au.Status = Scheduled
+	} else {
+		au.StartsAt = now
+	}
+	au.EndsAt = au.StartsAt.Add(duration)
+	au.UpdatedAt = now
+}
+
+// OpensAt is when the auction starts, its creation for auctions stored
+// before start times existed.
+func (au *Auction) OpensAt() time.Time {
+	if au.StartsAt.IsZero() {
+		return au.Timestamp
+	}
+
+	return au.StartsAt
+}
+
+// AcceptsBids reports whether bidding is open, which live lots wait for the
+// auctioneer to do.
+func (au *Auction) AcceptsBids() bool {
+	return au.Status == Active && (!au.Live || !au.BiddingOpenedAt.IsZero())
+}
+
+// TakesAbsenteeBids reports whether absentee bids can still be lodged, up to
+// the start of scheduled auctions and the opening of live lots.
+func (au *Auction) TakesAbsenteeBids() bool {
+	return au.Status == Scheduled || au.Status == Active && au.Live && au.BiddingOpenedAt.IsZero()
+}
+
+// BidsHidden reports whether the amounts of the bids are kept from the
+// public, which sealed auctions do until they end.
+func (au *Auction) BidsHidden() bool {
+	return au.Sealed && !au.Status.Ended()
+}
+
+// SoldBy reports whether the user is the seller of the auction.
+func (au *Auction) SoldBy(userId string) bool {
+	return userId != "" && userId == au.SellerId
+}
+
+// ListedFor reports whether listings show the auction to the user at now,
+// userId being empty for anonymous requests. Drafts are only listed for
+// their seller.
+func (au *Auction) ListedFor(userId string, now time.Time) bool {
+	if au.Status == Draft {
+		return userId != "" && userId == au.SellerId
+	}
+	if !now.Before(au.PublicAt) {
+		return true
+	}
+	if userId == "" {
+		return false
+	}
+	if userId == au.SellerId {
+		return true
+	}
+	for _, invitee := range au.Invitees {
+		if invitee == userId {
+			return true
+		}
+	}
+	return false
+}
+
+// Units is how many units the auction sells.
+func (au *Auction) Units() int {
+	if au.Quantity < 1 {
+		return 1
+	}
+	return au.Quantity
+}
+
+// BuysNow reports whether a bid of amount buys the lot outright.
+func (au *Auction) BuysNow(amount float64) bool {
+	return au.BuyNowPrice > 0 && amount >= au.BuyNowPrice
+}
+
+// ReserveMet reports whether a highest bid of amount sells the lot, which
+// any bid does without a reserve.
+func (au *Auction) ReserveMet(amount float64) bool {
+	return au.ReservePrice <= 0 || amount >= au.ReservePrice
 }
 
 type ProductCondition int
@@ -55,8 +248,27 @@ type AuctionStatus int
 const (
 	Active AuctionStatus = iota
 	Completed
+	// Scheduled auctions become Active at their start time.
+	Scheduled
+	// ClosedNoSale auctions completed with no bid reaching their reserve
+	// price.
+	ClosedNoSale
+	// Cancelled auctions were called off by their seller or an admin and
+	// have no winner.
+	Cancelled
+	// Paused auctions take no bids and their countdown stands still until
+	// they are resumed, ending later by as long as they were paused.
+	Paused
+	// Draft auctions are only seen by their seller, who can still edit them,
+	// and neither take bids nor run until published.
+	Draft
 )
 
+// Ended reports whether the auction is over, sold, not sold or cancelled.
+func (s AuctionStatus) Ended() bool {
+	return s == Completed || s == ClosedNoSale || s == Cancelled
+}
+
 const (
 	New ProductCondition = iota + 1
 	Used
@@ -68,11 +280,117 @@ type AuctionRepositoryInterface interface {
 		ctx context.Context,
 		auctionEntity *Auction) *internal_error.InternalError
 
+	// FindAuctions loads only the fields listed, named as in the API, when
+	// the storage supports it. Empty fields load every field.
 	FindAuctions(
 		ctx context.Context,
-		status AuctionStatus,
-		category, productName string) ([]Auction, *internal_error.InternalError)
+		filter AuctionFilter,
+		fields []string) ([]Auction, *internal_error.InternalError)
 
 	FindAuctionById(
 		ctx context.Context, id string) (*Auction, *internal_error.InternalError)
+
+	// FindAuctionsByIds skips the ids not found.
+	FindAuctionsByIds(
+		ctx context.Context, ids []string) ([]Auction, *internal_error.InternalError)
+
+	StreamAuctions(
+		ctx context.Context,
+		status AuctionStatus,
+		category string,
+		handle func(Auction) error) *internal_error.InternalError
+
+	// FindSellerAuctions pages through the auctions of the seller in the
+	// status, newest first, and returns the total count alongside.
+	FindSellerAuctions(
+		ctx context.Context,
+		sellerId string,
+		status AuctionStatus,
+		offset, limit int) ([]Auction, int, *internal_error.InternalError)
+
+	// FindEstimatedAuctions returns the Completed and ClosedNoSale auctions
+	// with an estimate, of the seller and in the category when not empty.
+	FindEstimatedAuctions(
+		ctx context.Context,
+		sellerId, category string) ([]Auction, *internal_error.InternalError)
+
+	// FindAuctionChanges returns the auctions created or updated after since,
+	// ordered by UpdatedAt.
+	FindAuctionChanges(
+		ctx context.Context, since time.Time) ([]Auction, *internal_error.InternalError)
+
+	UpdateAuctionWinner(
+		ctx context.Context, auctionId, winnerBidId string) *internal_error.InternalError
+
+	// UpdateAuctionStatus moves the auction from the expected status to
+	// status, returning a conflict error when it is no longer in the
+	// expected one.
+	UpdateAuctionStatus(
+		ctx context.Context, auctionId string, expected, status AuctionStatus) *internal_error.InternalError
+
+	// UpdateAuctionSeller hands the auction over to toSellerId, returning a
+	// not found error when it no longer belongs to fromSellerId.
+	UpdateAuctionSeller(
+		ctx context.Context, auctionId, fromSellerId, toSellerId string) *internal_error.InternalError
+
+	// UpdateAuctionDetails stores the product name, description, category and
+	// condition of auctionEntity, returning a not found error when the
+	// auction is missing or ended.
+	UpdateAuctionDetails(
+		ctx context.Context, auctionEntity *Auction) *internal_error.InternalError
+
+	// ExtendAuction moves the end of an active auction to endsAt. It never
+	// shortens an auction, so concurrent extensions keep the latest end.
+	ExtendAuction(
+		ctx context.Context, auctionId string, endsAt time.Time) *internal_error.InternalError
+
+	// OpenAuctionBidding opens bidding on an active live lot still closed
+	// to bids, moving its end to endsAt when later. It returns a not found
+	// error when there is no such lot.
+	OpenAuctionBidding(
+		ctx context.Context, auctionId string, openedAt, endsAt time.Time) *internal_error.InternalError
+
+	// UpdateAuctionIncrement sets the minimum increment of an active
+	// auction, enforced on the bids placed from then on.
+	UpdateAuctionIncrement(
+		ctx context.Context, auctionId string, minIncrement float64) *internal_error.InternalError
+
+	UpdateHeatScore(
+		ctx context.Context, auctionId string, heatScore float64) *internal_error.InternalError
+
+	// UpdateDraft replaces a Draft auction with auctionEntity, returning a
+	// not found error when there is no such draft.
+	UpdateDraft(
+		ctx context.Context, auctionEntity *Auction) *internal_error.InternalError
+
+	// PublishAuction stores the status and times auctionEntity.Publish set
+	// on a Draft auction and starts its timers, returning a not found error
+	// when there is no such draft.
+	PublishAuction(
+		ctx context.Context, auctionEntity *Auction) *internal_error.InternalError
+
+	// AddViews adds views to the view count of the auction.
+	AddViews(
+		ctx context.Context, auctionId string, views int64) *internal_error.InternalError
+
+	// PauseAuction pauses an active auction at pausedAt, returning a not
+	// found error when there is no such auction.
+	PauseAuction(
+		ctx context.Context, auctionId string, pausedAt time.Time) *internal_error.InternalError
+
+	// ResumeAuction makes a paused auction active again, moving its end by
+	// the time it spent paused, and returns the new end. It returns a not
+	// found error when there is no such auction.
+	ResumeAuction(
+		ctx context.Context, auctionId string, resumedAt time.Time) (time.Time, *internal_error.InternalError)
+
+	// SearchAuctions finds the auctions whose product name, description
+	// or description in the language matches any word of text, the most
+	// relevant first.
+	SearchAuctions(
+		ctx context.Context, language, text string) ([]Auction, *internal_error.InternalError)
+
+	// FindSeriesAuctions returns the lots of the series by lot number.
+	FindSeriesAuctions(
+		ctx context.Context, seriesId string) ([]Auction, *internal_error.InternalError)
 }
@@ -0,0 +1,89 @@
+package auction_entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuction_ListedFor(t *testing.T) {
+	now := time.Date(2026, 10, 15, 10, 0, 0, 0, time.UTC)
+	auction := Auction{
+		SellerId: "seller",
+		PublicAt: now.Add(10 * time.Minute),
+		Invitees: []string{"invitee"},
+	}
+
+	assert.True(t, auction.ListedFor("seller", now))
+	assert.True(t, auction.ListedFor("invitee", now))
+	assert.False(t, auction.ListedFor("someone", now))
+	assert.False(t, auction.ListedFor("", now))
+
+	assert.True(t, auction.ListedFor("", auction.PublicAt))
+	assert.True(t, (&Auction{}).ListedFor("", now))
+}
+
+func TestAuction_ListedFor_Draft(t *testing.T) {
+	now := time.Date(2026, 10, 15, 10, 0, 0, 0, time.UTC)
+	auction := Auction{SellerId: "seller", Status: Draft, Invitees: []string{"invitee"}}
+
+	assert.True(t, auction.ListedFor("seller", now))
+	assert.False(t, auction.ListedFor("invitee", now))
+	assert.False(t, auction.ListedFor("", now))
+}
+
+func TestAuction_Publish(t *testing.T) {
+	created := time.Date(2026, 10, 15, 10, 0, 0, 0, time.UTC)
+	now := created.Add(3 * time.Hour)
+
+	auction := Auction{Status: Draft, StartsAt: created, EndsAt: created.Add(2 * time.Hour)}
+	auction.Publish(now)
+	assert.Equal(t, Active, auction.Status)
+	assert.Equal(t, now, auction.StartsAt)
+	assert.Equal(t, now.Add(2*time.Hour), auction.EndsAt)
+
+	startsAt := now.Add(24 * time.Hour)
+	auction = Auction{Status: Draft, StartsAt: startsAt, EndsAt: startsAt.Add(time.Hour)}
+	auction.Publish(now)
+	assert.Equal(t, Scheduled, auction.Status)
+	assert.Equal(t, startsAt, auction.StartsAt)
+	assert.Equal(t, startsAt.Add(time.Hour), auction.EndsAt)
+}
+
+func TestAuction_EstimateOutcome(t *testing.T) {
+	auction := Auction{EstimateLow: 80, EstimateHigh: 120}
+
+	assert.True(t, auction.HasEstimate())
+	assert.Equal(t, EstimateBelow, auction.EstimateOutcome(79))
+	assert.Equal(t, EstimateWithin, auction.EstimateOutcome(80))
+	assert.Equal(t, EstimateWithin, auction.EstimateOutcome(120))
+	assert.Equal(t, EstimateAbove, auction.EstimateOutcome(121))
+	assert.InDelta(t, 0.5, auction.EstimateDeviation(150), 1e-9)
+	assert.InDelta(t, -0.25, auction.EstimateDeviation(75), 1e-9)
+}
+
+func TestRankSearchResults(t *testing.T) {
+	now := time.Date(2026, 10, 15, 10, 0, 0, 0, time.UTC)
+	auctions := []Auction{
+		{Id: "case", ProductName: "Laptop sleeve", Description: "Fits a MacBook Pro 14", Timestamp: now},
+		{Id: "macbook", ProductName: "MacBook Pro 2021", Description: "Barely used laptop", Timestamp: now},
+		{Id: "lamp", ProductName: "Lamp", Description: "A brass desk lamp", Timestamp: now},
+		{
+			Id: "translated", ProductName: "Notebook", Description: "Used notebook",
+			Descriptions: map[string]string{"pt": "Um macbook usado"}, Timestamp: now.Add(time.Minute),
+		},
+	}
+
+	var ids []string
+	for _, auction := range RankSearchResults(auctions, "en", "macbook pro") {
+		ids = append(ids, auction.Id)
+	}
+	assert.Equal(t, []string{"macbook", "case"}, ids)
+
+	ids = nil
+	for _, auction := range RankSearchResults(auctions, "pt", "MacBook") {
+		ids = append(ids, auction.Id)
+	}
+	assert.Equal(t, []string{"macbook", "translated", "case"}, ids)
+}
@@ -0,0 +1,28 @@
+package auction_entity
+
+// BidderVisibility controls how bidders are identified on the public bid
+// listings of an auction.
+type BidderVisibility string
+
+const (
+	BidderPublic    BidderVisibility = "public"
+	BidderMasked    BidderVisibility = "masked"
+	BidderAnonymous BidderVisibility = "anonymous"
+)
+
+// Present returns the bidder id as it may be shown publicly: unchanged,
+// masked down to its first and last characters, or empty. Auctions stored
+// before the setting existed are public.
+func (bv BidderVisibility) Present(userId string) string {
+	switch bv {
+	case BidderMasked:
+		if len(userId) <= 2 {
+			return "***"
+		}
+		return userId[:1] + "***" + userId[len(userId)-1:]
+	case BidderAnonymous:
+		return ""
+	default:
+		return userId
+	}
+}
@@ -0,0 +1,59 @@
+package auction_entity
+
+import (
+	"fullcycle-auction_go/internal/internal_error"
+)
+
+type Grade string
+
+const (
+	GradeNew         Grade = "new"
+	GradeLikeNew     Grade = "like_new"
+	GradeGood        Grade = "good"
+	GradeFair        Grade = "fair"
+	GradePoor        Grade = "poor"
+	GradeRefurbished Grade = "refurbished"
+)
+
+type ConditionGrading struct {
+	Grade           Grade
+	Defects         []string
+	InspectionNotes string
+	GraderId        string
+}
+
+func (cg ConditionGrading) Validate() *internal_error.InternalError {
+	switch cg.Grade {
+	case GradeNew, GradeLikeNew, GradeGood, GradeFair, GradePoor, GradeRefurbished:
+		return nil
+	default:
+		return internal_error.NewBadRequestError("invalid condition grade")
+	}
+}
+
+// Condition collapses the grade into the legacy ProductCondition enum, kept
+// for clients that still read the numeric condition field.
+func (cg ConditionGrading) Condition() ProductCondition {
+	switch cg.Grade {
+	case GradeNew:
+		return New
+	case GradeRefurbished:
+		return Refurbished
+	default:
+		return Used
+	}
+}
+
+// GradingFromCondition maps a legacy ProductCondition into a grading, used
+// for auctions created without grading and for documents stored before the
+// grading structure existed.
+func GradingFromCondition(condition ProductCondition) ConditionGrading {
+	switch condition {
+	case New:
+		return ConditionGrading{Grade: GradeNew}
+	case Refurbished:
+		return ConditionGrading{Grade: GradeRefurbished}
+	default:
+		return ConditionGrading{Grade: GradeGood}
+	}
+}
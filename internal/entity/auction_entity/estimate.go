@@ -0,0 +1,34 @@
+package auction_entity
+
+// EstimateOutcome places the price a lot sold for against its estimate.
+type EstimateOutcome string
+
+const (
+	EstimateBelow  EstimateOutcome = "below"
+	EstimateWithin EstimateOutcome = "within"
+	EstimateAbove  EstimateOutcome = "above"
+)
+
+func (au *Auction) HasEstimate() bool {
+	return au.EstimateHigh > 0
+}
+
+// EstimateOutcome places price against the estimate, the bounds being
+// within it.
+func (au *Auction) EstimateOutcome(price float64) EstimateOutcome {
+	switch {
+	case price < au.EstimateLow:
+		return EstimateBelow
+	case price > au.EstimateHigh:
+		return EstimateAbove
+	default:
+		return EstimateWithin
+	}
+}
+
+// EstimateDeviation is how far price is from the middle of the estimate,
+// relative to it, negative below it.
+func (au *Auction) EstimateDeviation(price float64) float64 {
+	middle := (au.EstimateLow + au.EstimateHigh) / 2
+	return (price - middle) / middle
+}
@@ -0,0 +1,23 @@
+package auction_entity
+
+import "time"
+
+// AuctionFilter selects the auctions of a listing. Each zero field leaves
+// the auctions unfiltered by it, Status included: Active, being zero, means
+// every status.
+type AuctionFilter struct {
+	Status AuctionStatus
+	// Categories match auctions in any of them.
+	Categories  []string
+	ProductName string
+	Attributes  map[string]string
+
+	// MinPrice and MaxPrice bound the starting price, inclusive.
+	MinPrice float64
+	MaxPrice float64
+
+	// EndingBefore, EndingAfter and CreatedAfter are exclusive bounds.
+	EndingBefore time.Time
+	EndingAfter  time.Time
+	CreatedAfter time.Time
+}
@@ -0,0 +1,111 @@
+package auction_entity
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/clock"
+	"fullcycle-auction_go/internal/internal_error"
+	"github.com/google/uuid"
+	"time"
+)
+
+// RecurringAuction is an auction a seller runs again on a schedule, e.g.
+// every Monday at 10:00. Each time the schedule fires an auction is created
+// from the template and linked to it through its RecurringAuctionId.
+type RecurringAuction struct {
+	Id       string
+	SellerId string
+	// Schedule is a cron expression, see ParseSchedule, evaluated in
+	// Timezone.
+	Schedule string
+	Timezone string
+	Template AuctionTemplate
+	Active   bool
+	// NextRunAt is when the next auction of the series is created, zero
+	// once the schedule never fires again.
+	NextRunAt time.Time
+	LastRunAt time.Time
+	CreatedAt time.Time
+}
+
+// AuctionTemplate holds what the auctions of a series are created with.
+type AuctionTemplate struct {
+	ProductId        string
+	ProductName      string
+	Category         string
+	Description      string
+	Grading          ConditionGrading
+	Attributes       map[string]string
+	BidderVisibility BidderVisibility
+	Terms            *Terms
+}
+
+func CreateRecurringAuction(
+	sellerId, schedule, timezone string,
+	template AuctionTemplate) (*RecurringAuction, *internal_error.InternalError) {
+	now := clock.Now()
+	recurringAuction := &RecurringAuction{
+		Id:        uuid.New().String(),
+		SellerId:  sellerId,
+		Schedule:  schedule,
+		Timezone:  timezone,
+		Template:  template,
+		Active:    true,
+		CreatedAt: now,
+	}
+
+	next, err := recurringAuction.NextRun(now)
+	if err != nil {
+		return nil, err
+	}
+	if next.IsZero() {
+		return nil, internal_error.NewBadRequestError("Schedule never fires")
+	}
+	recurringAuction.NextRunAt = next
+
+	return recurringAuction, nil
+}
+
+// NextRun returns the first time after after the schedule fires at.
+func (ra *RecurringAuction) NextRun(after time.Time) (time.Time, *internal_error.InternalError) {
+	schedule, err := ParseSchedule(ra.Schedule)
+	if err != nil {
+		return time.Time{}, internal_error.NewBadRequestError("Invalid schedule: " + err.Error())
+	}
+
+	location := time.UTC
+	if ra.Timezone != "" {
+		if location, err = time.LoadLocation(ra.Timezone); err != nil {
+			return time.Time{}, internal_error.NewBadRequestError("Invalid timezone")
+		}
+	}
+
+	return schedule.Next(after.In(location)), nil
+}
+
+type RecurringAuctionRepositoryInterface interface {
+	CreateRecurringAuction(
+		ctx context.Context, recurringAuction *RecurringAuction) *internal_error.InternalError
+
+	FindRecurringAuctionById(
+		ctx context.Context, id string) (*RecurringAuction, *internal_error.InternalError)
+
+	// FindRecurringAuctions lists the series of the seller, oldest first.
+	FindRecurringAuctions(
+		ctx context.Context, sellerId string) ([]RecurringAuction, *internal_error.InternalError)
+
+	// FindDueRecurringAuctions lists the active series whose next run is at
+	// or before now.
+	FindDueRecurringAuctions(
+		ctx context.Context, now time.Time) ([]RecurringAuction, *internal_error.InternalError)
+
+	// UpdateRecurringAuctionRun records a run, moving the next run from
+	// runAt to nextRunAt. It returns a not found error when the next run is
+	// no longer runAt, so only one instance creates the auction of a run.
+	UpdateRecurringAuctionRun(
+		ctx context.Context, id string, runAt, nextRunAt time.Time) *internal_error.InternalError
+
+	// UpdateRecurringAuctionActive pauses or resumes the series, along with
+	// its next run.
+	UpdateRecurringAuctionActive(
+		ctx context.Context, id string, active bool, nextRunAt time.Time) *internal_error.InternalError
+}
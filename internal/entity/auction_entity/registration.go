@@ -0,0 +1,89 @@
+package auction_entity
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/clock"
+	"fullcycle-auction_go/internal/internal_error"
+	"github.com/google/uuid"
+	"time"
+)
+
+type RegistrationStatus string
+
+const (
+	RegistrationPending  RegistrationStatus = "pending"
+	RegistrationApproved RegistrationStatus = "approved"
+	RegistrationRejected RegistrationStatus = "rejected"
+)
+
+// Registration is a user's request to bid on a lot that requires one. Only
+// the users whose registration the seller or an admin approved can bid on
+// it. A user has one per auction, registering again after a rejection
+// replaces it.
+type Registration struct {
+	Id        string
+	AuctionId string
+	UserId    string
+	// Deposit is the amount the user put down to register, zero when none.
+	Deposit   float64
+	Status    RegistrationStatus
+	Timestamp time.Time
+
+	// DecidedBy is the user who approved or rejected the registration, at
+	// DecidedAt, both empty while it is pending.
+	DecidedBy string
+	DecidedAt time.Time
+}
+
+func CreateRegistration(
+	userId, auctionId string, deposit float64) (*Registration, *internal_error.InternalError) {
+	registration := &Registration{
+		Id:        uuid.New().String(),
+		AuctionId: auctionId,
+		UserId:    userId,
+		Deposit:   deposit,
+		Status:    RegistrationPending,
+		Timestamp: clock.Now(),
+	}
+
+	if err := registration.Validate(); err != nil {
+		return nil, err
+	}
+
+	return registration, nil
+}
+
+func (r *Registration) Validate() *internal_error.InternalError {
+	if err := uuid.Validate(r.UserId); err != nil {
+		return internal_error.NewBadRequestError("UserId is not a valid id")
+	} else if err := uuid.Validate(r.AuctionId); err != nil {
+		return internal_error.NewBadRequestError("AuctionId is not a valid id")
+	} else if r.Deposit < 0 {
+		return internal_error.NewBadRequestError("Deposit cannot be negative")
+	}
+
+	return nil
+}
+
+type RegistrationRepositoryInterface interface {
+	// UpsertRegistration stores the registration, replacing the one the
+	// user already made for the auction, if any.
+	UpsertRegistration(
+		ctx context.Context, registration *Registration) *internal_error.InternalError
+
+	FindRegistration(
+		ctx context.Context, auctionId, userId string) (*Registration, *internal_error.InternalError)
+
+	// FindRegistrations lists the registrations of the auction, earliest
+	// first.
+	FindRegistrations(
+		ctx context.Context, auctionId string) ([]Registration, *internal_error.InternalError)
+
+	// DecideRegistration approves or rejects the registration of the user
+	// for the auction.
+	DecideRegistration(
+		ctx context.Context,
+		auctionId, userId string,
+		status RegistrationStatus,
+		decidedBy string) *internal_error.InternalError
+}
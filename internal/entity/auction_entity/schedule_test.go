@@ -0,0 +1,53 @@
+package auction_entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedule_Next(t *testing.T) {
+	// A Wednesday.
+	from := time.Date(2026, 10, 14, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		expression string
+		expected   time.Time
+	}{
+		{"0 10 * * MON", time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2026, 10, 14, 10, 45, 0, 0, time.UTC)},
+		{"0 9-17/4 * * 1-5", time.Date(2026, 10, 14, 13, 0, 0, 0, time.UTC)},
+		{"30 10 1,15 * *", time.Date(2026, 10, 15, 10, 30, 0, 0, time.UTC)},
+		{"0 0 1 jan *", time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 12 * * 7", time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)},
+		// Either the 20th or a Friday.
+		{"0 8 20 * 5", time.Date(2026, 10, 16, 8, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 0 30 2 *", time.Time{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expression, func(t *testing.T) {
+			schedule, err := ParseSchedule(test.expression)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, schedule.Next(from))
+		})
+	}
+}
+
+func TestParseSchedule_Invalid(t *testing.T) {
+	for _, expression := range []string{
+		"* * * *",
+		"60 * * * *",
+		"* * 0 * *",
+		"* * * * FUN",
+		"5-1 * * * *",
+		"*/0 * * * *",
+	} {
+		_, err := ParseSchedule(expression)
+		assert.Error(t, err, expression)
+	}
+}
@@ -0,0 +1,62 @@
+package auction_entity
+
+import (
+	"sort"
+	"strings"
+)
+
+// Search weights: a word found in the product name says more about the
+// auction than one found somewhere in its description.
+const (
+	ProductNameSearchWeight = 10
+	DescriptionSearchWeight = 2
+)
+
+// SearchRelevance scores how well the auction matches the lower case
+// words, adding the weight of each field a word is found in: the product
+// name, the default description and its translation to the language. Zero
+// means no word matched.
+func (au *Auction) SearchRelevance(language string, words []string) int {
+	name := strings.ToLower(au.ProductName)
+	description := strings.ToLower(au.Description)
+	translation := strings.ToLower(au.Descriptions[language])
+
+	relevance := 0
+	for _, word := range words {
+		if strings.Contains(name, word) {
+			relevance += ProductNameSearchWeight
+		}
+		if strings.Contains(description, word) {
+			relevance += DescriptionSearchWeight
+		}
+		if translation != "" && strings.Contains(translation, word) {
+			relevance += DescriptionSearchWeight
+		}
+	}
+
+	return relevance
+}
+
+// RankSearchResults keeps the auctions matching any word of text, most
+// relevant first, the newest first among equally relevant ones.
+func RankSearchResults(auctions []Auction, language, text string) []Auction {
+	words := strings.Fields(strings.ToLower(text))
+
+	relevance := make(map[string]int, len(auctions))
+	var ranked []Auction
+	for _, auction := range auctions {
+		if score := auction.SearchRelevance(language, words); score > 0 {
+			relevance[auction.Id] = score
+			ranked = append(ranked, auction)
+		}
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if relevance[ranked[i].Id] != relevance[ranked[j].Id] {
+			return relevance[ranked[i].Id] > relevance[ranked[j].Id]
+		}
+		return ranked[i].Timestamp.After(ranked[j].Timestamp)
+	})
+
+	return ranked
+}
@@ -0,0 +1,63 @@
+package auction_entity
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/clock"
+	"fullcycle-auction_go/internal/internal_error"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+// Series groups the auctions of a named event, e.g. "Spring Art Sale",
+// each auction being a lot numbered in the order it was added.
+type Series struct {
+	Id          string
+	SellerId    string
+	Name        string
+	Description string
+	// LotCount is the number of lots added so far, the last lot number.
+	LotCount int
+	// CascadeInterval is set once the lots are scheduled to close one after
+	// the other, each lot ending at least CascadeInterval after the one
+	// before it, even when that one is extended.
+	CascadeInterval time.Duration
+	CreatedAt       time.Time
+}
+
+func CreateSeries(sellerId, name, description string) (*Series, *internal_error.InternalError) {
+	series := &Series{
+		Id:          uuid.New().String(),
+		SellerId:    sellerId,
+		Name:        strings.TrimSpace(name),
+		Description: description,
+		CreatedAt:   clock.Now(),
+	}
+
+	if series.Name == "" {
+		return nil, internal_error.NewBadRequestError("Series name is required")
+	}
+
+	return series, nil
+}
+
+type SeriesRepositoryInterface interface {
+	CreateSeries(
+		ctx context.Context, series *Series) *internal_error.InternalError
+
+	FindSeriesById(
+		ctx context.Context, id string) (*Series, *internal_error.InternalError)
+
+	// FindSeries lists the series of the seller, or every series when
+	// sellerId is empty, newest first.
+	FindSeries(
+		ctx context.Context, sellerId string) ([]Series, *internal_error.InternalError)
+
+	// NextLotNumber counts one more lot in the series and returns its
+	// number, so concurrent auctions never share one.
+	NextLotNumber(
+		ctx context.Context, seriesId string) (int, *internal_error.InternalError)
+
+	UpdateSeriesCascade(
+		ctx context.Context, seriesId string, interval time.Duration) *internal_error.InternalError
+}
@@ -0,0 +1,34 @@
+package auction_entity
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+)
+
+// Terms are the seller's conditions of sale. Bidders accept a version of
+// them along with their first bid on the auction.
+type Terms struct {
+	ReturnsPolicy       string
+	PaymentDeadlineDays int
+	Version             int
+}
+
+// TermsAcceptance records that the user accepted a version of the auction
+// terms, kept for compliance.
+type TermsAcceptance struct {
+	AuctionId    string
+	UserId       string
+	TermsVersion int
+	Timestamp    time.Time
+}
+
+type TermsAcceptanceRepositoryInterface interface {
+	// CreateTermsAcceptance stores the acceptance, replacing the one of an
+	// earlier version.
+	CreateTermsAcceptance(
+		ctx context.Context, acceptance *TermsAcceptance) *internal_error.InternalError
+
+	FindTermsAcceptance(
+		ctx context.Context, auctionId, userId string) (*TermsAcceptance, *internal_error.InternalError)
+}
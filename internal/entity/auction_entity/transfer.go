@@ -0,0 +1,47 @@
+package auction_entity
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/clock"
+	"fullcycle-auction_go/internal/internal_error"
+	"github.com/google/uuid"
+	"time"
+)
+
+// Transfer records an admin moving an auction, with the payout of its sale,
+// from one seller account to another. Transfers are kept as an audit trail.
+type Transfer struct {
+	Id           string
+	AuctionId    string
+	FromSellerId string
+	ToSellerId   string
+	AdminId      string
+	Reason       string
+	Timestamp    time.Time
+}
+
+func CreateTransfer(
+	auctionId, fromSellerId, toSellerId, adminId, reason string) (*Transfer, *internal_error.InternalError) {
+	if fromSellerId == toSellerId {
+		return nil, internal_error.NewBadRequestError("Auction already belongs to this seller")
+	}
+
+	return &Transfer{
+		Id:           uuid.New().String(),
+		AuctionId:    auctionId,
+		FromSellerId: fromSellerId,
+		ToSellerId:   toSellerId,
+		AdminId:      adminId,
+		Reason:       reason,
+		Timestamp:    clock.Now(),
+	}, nil
+}
+
+type TransferRepositoryInterface interface {
+	CreateTransfer(
+		ctx context.Context, transfer *Transfer) *internal_error.InternalError
+
+	// FindTransfers lists the transfers of the auction, oldest first.
+	FindTransfers(
+		ctx context.Context, auctionId string) ([]Transfer, *internal_error.InternalError)
+}
@@ -0,0 +1,84 @@
+package bid_entity
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/clock"
+	"fullcycle-auction_go/internal/internal_error"
+	"github.com/google/uuid"
+	"time"
+)
+
+type AbsenteeBidStatus string
+
+const (
+	AbsenteePending AbsenteeBidStatus = "pending"
+	// AbsenteeLeading absentee bids won the opening of their lot.
+	AbsenteeLeading AbsenteeBidStatus = "leading"
+	AbsenteeOutbid  AbsenteeBidStatus = "outbid"
+	// AbsenteeRejected absentee bids had their bid turned down when placed.
+	AbsenteeRejected AbsenteeBidStatus = "rejected"
+)
+
+// AbsenteeBid is the maximum a user is ready to bid on a lot, lodged before
+// bidding opens. A user has one per auction, lodging again replaces it.
+type AbsenteeBid struct {
+	Id           string
+	AuctionId    string
+	UserId       string
+	MaxAmount    float64
+	TermsVersion int
+	Status       AbsenteeBidStatus
+	Timestamp    time.Time
+}
+
+func CreateAbsenteeBid(
+	userId, auctionId string,
+	maxAmount float64,
+	termsVersion int) (*AbsenteeBid, *internal_error.InternalError) {
+	absenteeBid := &AbsenteeBid{
+		Id:           uuid.New().String(),
+		AuctionId:    auctionId,
+		UserId:       userId,
+		MaxAmount:    maxAmount,
+		TermsVersion: termsVersion,
+		Status:       AbsenteePending,
+		Timestamp:    clock.Now(),
+	}
+
+	if err := absenteeBid.Validate(); err != nil {
+		return nil, err
+	}
+
+	return absenteeBid, nil
+}
+
+func (ab *AbsenteeBid) Validate() *internal_error.InternalError {
+	if err := uuid.Validate(ab.UserId); err != nil {
+		return internal_error.NewBadRequestError("UserId is not a valid id")
+	} else if err := uuid.Validate(ab.AuctionId); err != nil {
+		return internal_error.NewBadRequestError("AuctionId is not a valid id")
+	} else if ab.MaxAmount <= 0 {
+		return internal_error.NewBadRequestError("Max amount must be greater than 0")
+	}
+
+	return nil
+}
+
+type AbsenteeBidRepositoryInterface interface {
+	// UpsertAbsenteeBid stores the absentee bid, replacing the maximum,
+	// terms version and timestamp of the one the user already lodged on the
+	// auction, if any.
+	UpsertAbsenteeBid(
+		ctx context.Context, absenteeBid *AbsenteeBid) *internal_error.InternalError
+
+	FindAbsenteeBid(
+		ctx context.Context, auctionId, userId string) (*AbsenteeBid, *internal_error.InternalError)
+
+	// FindPendingAbsenteeBids lists the pending absentee bids of the
+	// auction, highest maximum first and the earliest first among equal ones.
+	FindPendingAbsenteeBids(
+		ctx context.Context, auctionId string) ([]AbsenteeBid, *internal_error.InternalError)
+
+	UpdateAbsenteeBidStatus(
+		ctx context.Context, id string, status AbsenteeBidStatus) *internal_error.InternalError
+}
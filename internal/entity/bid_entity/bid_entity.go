@@ -2,6 +2,8 @@ package bid_entity
 
 import (
 	"context"
+	"fullcycle-auction_go/configuration/clock"
+	"fullcycle-auction_go/internal/entity/event_entity"
 	"fullcycle-auction_go/internal/internal_error"
 	"github.com/google/uuid"
 	"time"
@@ -12,16 +14,39 @@ type Bid struct {
 	UserId    string
 	AuctionId string
 	Amount    float64
+	Source    BidSource
+	Client    ClientInfo
 	Timestamp time.Time
+
+	// PlacedBy is the staff user who placed the bid on behalf of UserId,
+	// empty when the bidder placed it.
+	PlacedBy string
+
+	// RetractedAt is when the bidder took the bid back, zero while it
+	// stands. Retracted bids are kept but never win.
+	RetractedAt time.Time
+}
+
+// ClientInfo identifies the client a bid came from. It is kept for fraud
+// detection only and never rendered by the public API.
+type ClientInfo struct {
+	Ip                string
+	DeviceFingerprint string
 }
 
-func CreateBid(userId, auctionId string, amount float64) (*Bid, *internal_error.InternalError) {
+func CreateBid(
+	userId, auctionId string,
+	amount float64,
+	source BidSource,
+	client ClientInfo) (*Bid, *internal_error.InternalError) {
 	bid := &Bid{
 		Id:        uuid.New().String(),
 		UserId:    userId,
 		AuctionId: auctionId,
 		Amount:    amount,
-		Timestamp: time.Now(),
+		Source:    source,
+		Client:    client,
+		Timestamp: clock.Now(),
 	}
 
 	if err := bid.Validate(); err != nil {
@@ -31,6 +56,39 @@ func CreateBid(userId, auctionId string, amount float64) (*Bid, *internal_error.
 	return bid, nil
 }
 
+// PlacedEvent is published once the bid is stored. It carries the raw user
+// id, so whoever renders it applies the auction's bidder visibility.
+func (b *Bid) PlacedEvent() event_entity.Event {
+	return event_entity.NewEvent(event_entity.BidPlaced, b.AuctionId, map[string]interface{}{
+		"bid_id":    b.Id,
+		"user_id":   b.UserId,
+		"amount":    b.Amount,
+		"source":    string(b.Source),
+		"timestamp": b.Timestamp,
+	})
+}
+
+// RetractedEvent is published once the bid is retracted, along with the
+// highest bid left standing on the auction, nil when there is none.
+func (b *Bid) RetractedEvent(highest *Bid) event_entity.Event {
+	payload := map[string]interface{}{
+		"bid_id":  b.Id,
+		"user_id": b.UserId,
+		"amount":  b.Amount,
+	}
+	if highest != nil {
+		payload["highest_bid_id"] = highest.Id
+		payload["highest_user_id"] = highest.UserId
+		payload["highest_amount"] = highest.Amount
+	}
+
+	return event_entity.NewEvent(event_entity.BidRetracted, b.AuctionId, payload)
+}
+
+func (b *Bid) Retracted() bool {
+	return !b.RetractedAt.IsZero()
+}
+
 func (b *Bid) Validate() *internal_error.InternalError {
 	if err := uuid.Validate(b.UserId); err != nil {
 		return internal_error.NewBadRequestError("UserId is not a valid id")
@@ -40,17 +98,62 @@ func (b *Bid) Validate() *internal_error.InternalError {
 		return internal_error.NewBadRequestError("Amount is not a valid value")
 	}
 
-	return nil
+	return b.Source.Validate()
 }
 
 type BidEntityRepository interface {
+	// CreateBid stores the bids, returning those it left out because their
+	// auction had ended by the time they were accepted or are written.
 	CreateBid(
 		ctx context.Context,
-		bidEntities []Bid) *internal_error.InternalError
+		bidEntities []Bid) ([]Bid, *internal_error.InternalError)
 
+	// FindBidByAuctionId leaves the retracted bids out, as do the winner
+	// and bidder lookups and HasBids. FindBidById and StreamBids keep them.
 	FindBidByAuctionId(
 		ctx context.Context, auctionId string) ([]Bid, *internal_error.InternalError)
 
 	FindWinningBidByAuctionId(
 		ctx context.Context, auctionId string) (*Bid, *internal_error.InternalError)
+
+	FindBidById(
+		ctx context.Context, bidId string) (*Bid, *internal_error.InternalError)
+
+	// RetractBid marks the standing bid retracted and publishes the
+	// retraction with the highest bid left on its auction.
+	RetractBid(
+		ctx context.Context, bidId string, retractedAt time.Time) *internal_error.InternalError
+
+	// HasBids tells whether any bid on the auction is stored, without
+	// loading them.
+	HasBids(
+		ctx context.Context, auctionId string) (bool, *internal_error.InternalError)
+
+	// FindWinningBidsByAuctionIds maps each auction with bids to its highest
+	// bid.
+	FindWinningBidsByAuctionIds(
+		ctx context.Context, auctionIds []string) (map[string]Bid, *internal_error.InternalError)
+
+	// FindUserAuctionBids maps each auction the user bid on to the highest
+	// bid of each of its bidders, the earliest standing for ties.
+	FindUserAuctionBids(
+		ctx context.Context, userId string) (map[string][]Bid, *internal_error.InternalError)
+
+	StreamBids(
+		ctx context.Context,
+		auctionId string,
+		handle func(Bid) error) *internal_error.InternalError
+
+	// PurgeClientData clears the client info of the bids placed before the
+	// time and returns how many bids had any. With dryRun set, the bids are
+	// only counted.
+	PurgeClientData(
+		ctx context.Context, before time.Time, dryRun bool) (int64, *internal_error.InternalError)
+
+	// ReplaceBidders moves the bids of the auction from each user id to the
+	// one it maps to and returns how many bids were moved.
+	ReplaceBidders(
+		ctx context.Context,
+		auctionId string,
+		userIds map[string]string) (int64, *internal_error.InternalError)
 }
@@ -0,0 +1,27 @@
+package bid_entity
+
+import "fullcycle-auction_go/internal/internal_error"
+
+// BidSource is the channel a bid was placed through.
+type BidSource string
+
+const (
+	SourceWeb    BidSource = "web"
+	SourceMobile BidSource = "mobile"
+	SourceAPIKey BidSource = "api_key"
+	// SourceProxy bids are placed for proxy bids, up to their maximum.
+	SourceProxy BidSource = "proxy"
+	// SourceAbsentee bids are placed for absentee bids when bidding opens.
+	SourceAbsentee BidSource = "absentee"
+	// SourcePhone bids are placed by staff for bidders on the phone.
+	SourcePhone BidSource = "phone"
+)
+
+func (s BidSource) Validate() *internal_error.InternalError {
+	switch s {
+	case SourceWeb, SourceMobile, SourceAPIKey, SourceProxy, SourceAbsentee, SourcePhone:
+		return nil
+	}
+
+	return internal_error.NewBadRequestError("Source is not a valid bid source")
+}
@@ -0,0 +1,82 @@
+package bid_entity
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/clock"
+	"fullcycle-auction_go/internal/internal_error"
+	"github.com/google/uuid"
+	"time"
+)
+
+type ProxyBidStatus string
+
+const (
+	ProxyActive ProxyBidStatus = "active"
+	// ProxyExhausted proxy bids were outbid past their maximum.
+	ProxyExhausted ProxyBidStatus = "exhausted"
+)
+
+// ProxyBid is the maximum a user is ready to bid on an open lot. The system
+// bids for the user, one increment over each competing bid, until it is
+// reached. A user has one per auction, setting it again replaces it.
+type ProxyBid struct {
+	Id           string
+	AuctionId    string
+	UserId       string
+	MaxAmount    float64
+	TermsVersion int
+	Status       ProxyBidStatus
+	Timestamp    time.Time
+}
+
+func CreateProxyBid(
+	userId, auctionId string,
+	maxAmount float64,
+	termsVersion int) (*ProxyBid, *internal_error.InternalError) {
+	proxyBid := &ProxyBid{
+		Id:           uuid.New().String(),
+		AuctionId:    auctionId,
+		UserId:       userId,
+		MaxAmount:    maxAmount,
+		TermsVersion: termsVersion,
+		Status:       ProxyActive,
+		Timestamp:    clock.Now(),
+	}
+
+	if err := proxyBid.Validate(); err != nil {
+		return nil, err
+	}
+
+	return proxyBid, nil
+}
+
+func (pb *ProxyBid) Validate() *internal_error.InternalError {
+	if err := uuid.Validate(pb.UserId); err != nil {
+		return internal_error.NewBadRequestError("UserId is not a valid id")
+	} else if err := uuid.Validate(pb.AuctionId); err != nil {
+		return internal_error.NewBadRequestError("AuctionId is not a valid id")
+	} else if pb.MaxAmount <= 0 {
+		return internal_error.NewBadRequestError("Max amount must be greater than 0")
+	}
+
+	return nil
+}
+
+type ProxyBidRepositoryInterface interface {
+	// UpsertProxyBid stores the proxy bid, replacing the maximum, terms
+	// version, status and timestamp of the one the user already set on the
+	// auction, if any.
+	UpsertProxyBid(
+		ctx context.Context, proxyBid *ProxyBid) *internal_error.InternalError
+
+	FindProxyBid(
+		ctx context.Context, auctionId, userId string) (*ProxyBid, *internal_error.InternalError)
+
+	// FindActiveProxyBids lists the active proxy bids of the auction,
+	// highest maximum first and the earliest first among equal ones.
+	FindActiveProxyBids(
+		ctx context.Context, auctionId string) ([]ProxyBid, *internal_error.InternalError)
+
+	UpdateProxyBidStatus(
+		ctx context.Context, id string, status ProxyBidStatus) *internal_error.InternalError
+}
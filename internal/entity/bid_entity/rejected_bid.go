@@ -0,0 +1,116 @@
+package bid_entity
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+)
+
+type RejectionReason string
+
+const (
+	// RejectionTooLow is a bid not higher than the current highest bid.
+	RejectionTooLow RejectionReason = "too_low"
+	// RejectionAuctionClosed is a bid placed after the auction ended.
+	RejectionAuctionClosed RejectionReason = "auction_closed"
+	// RejectionUserBanned is a bid placed by a user banned from bidding.
+	RejectionUserBanned RejectionReason = "user_banned"
+	// RejectionTermsNotAccepted is a first bid on an auction with terms that
+	// did not accept their current version.
+	RejectionTermsNotAccepted RejectionReason = "terms_not_accepted"
+	// RejectionNotStarted is a bid placed before the auction started.
+	RejectionNotStarted RejectionReason = "auction_not_started"
+	// RejectionBiddingNotOpen is a bid on a live lot the auctioneer did not
+	// open yet.
+	RejectionBiddingNotOpen RejectionReason = "bidding_not_open"
+	// RejectionBelowIncrement is a bid higher than the current highest bid
+	// by less than the minimum increment of the auction.
+	RejectionBelowIncrement RejectionReason = "below_min_increment"
+	// RejectionAuctionCancelled is a bid on a cancelled auction, voided
+	// along with the bids still waiting to be stored when it was cancelled.
+	RejectionAuctionCancelled RejectionReason = "auction_cancelled"
+	// RejectionAuctionPaused is a bid on a paused auction.
+	RejectionAuctionPaused RejectionReason = "auction_paused"
+	// RejectionClockSkew is a bid whose client timestamp is too far from
+	// the server time.
+	RejectionClockSkew RejectionReason = "client_clock_skew"
+	// RejectionReplayed is a bid whose client timestamp is not later than
+	// the one of the last bid of the bidder on the auction, as a replayed
+	// request would be.
+	RejectionReplayed RejectionReason = "replayed_bid"
+	// RejectionNotRegistered is a bid on a lot requiring registration by a
+	// user whose registration for it was not approved.
+	RejectionNotRegistered RejectionReason = "not_registered"
+	// RejectionBelowStartingPrice is a bid below the starting price of the
+	// auction.
+	RejectionBelowStartingPrice RejectionReason = "below_starting_price"
+	// RejectionSellerBid is a bid placed by the seller of the auction.
+	RejectionSellerBid RejectionReason = "seller_bid"
+)
+
+// Message is the error returned to the bidder for the reason.
+func (r RejectionReason) Message() string {
+	switch r {
+	case RejectionTooLow:
+		return "Bid amount must be higher than the current highest bid"
+	case RejectionAuctionClosed:
+		return "Auction is closed"
+	case RejectionUserBanned:
+		return "User is banned from bidding"
+	case RejectionTermsNotAccepted:
+		return "The current auction terms must be accepted"
+	case RejectionNotStarted:
+		return "Auction has not started yet"
+	case RejectionBiddingNotOpen:
+		return "Bidding is not open yet"
+	case RejectionBelowIncrement:
+		return "Bid must exceed the current highest bid by at least the minimum increment"
+	case RejectionAuctionCancelled:
+		return "Auction was cancelled"
+	case RejectionAuctionPaused:
+		return "Auction is paused"
+	case RejectionClockSkew:
+		return "Bid timestamp is too far from the server time"
+	case RejectionReplayed:
+		return "Bid timestamp must be later than the one of your last bid"
+	case RejectionNotRegistered:
+		return "Bidding on this lot requires an approved registration"
+	case RejectionBelowStartingPrice:
+		return "Bid amount must be at least the starting price"
+	case RejectionSellerBid:
+		return "Sellers cannot bid on their own auction"
+	}
+
+	return "Bid was rejected"
+}
+
+// AsError is the error a bid rejected for the reason is answered with,
+// carrying the reason as its code.
+func (r RejectionReason) AsError() *internal_error.InternalError {
+	err := internal_error.NewBadRequestError(r.Message())
+	err.Reason = string(r)
+
+	return err
+}
+
+// RejectedBid is a bid turned down when it was placed, kept for support and
+// fraud analysis.
+type RejectedBid struct {
+	Bid
+	Reason RejectionReason
+}
+
+type RejectedBidRepositoryInterface interface {
+	CreateRejectedBid(
+		ctx context.Context, rejectedBid *RejectedBid) *internal_error.InternalError
+
+	// FindRejectedBids lists the rejected bids of the auction, newest first.
+	FindRejectedBids(
+		ctx context.Context, auctionId string) ([]RejectedBid, *internal_error.InternalError)
+
+	// PurgeClientData clears the client info of the rejected bids placed
+	// before the time and returns how many had any. With dryRun set, they
+	// are only counted.
+	PurgeClientData(
+		ctx context.Context, before time.Time, dryRun bool) (int64, *internal_error.InternalError)
+}
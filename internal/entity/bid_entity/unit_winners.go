@@ -0,0 +1,37 @@
+package bid_entity
+
+import "sort"
+
+// UnitWinners returns the winning bids of an auction selling units, the
+// highest bid of each of the highest bidders, one per unit, highest first.
+// A bidder's earliest bid stands for their ties, and the earliest bidder
+// wins a tie between bidders.
+func UnitWinners(bids []Bid, units int) []Bid {
+	highest := make(map[string]Bid)
+	for _, bid := range bids {
+		current, ok := highest[bid.UserId]
+		if !ok || outranks(bid, current) {
+			highest[bid.UserId] = bid
+		}
+	}
+
+	winners := make([]Bid, 0, len(highest))
+	for _, bid := range highest {
+		winners = append(winners, bid)
+	}
+	sort.Slice(winners, func(i, j int) bool {
+		return outranks(winners[i], winners[j])
+	})
+
+	if len(winners) > units {
+		winners = winners[:units]
+	}
+	return winners
+}
+
+func outranks(bid, other Bid) bool {
+	if bid.Amount != other.Amount {
+		return bid.Amount > other.Amount
+	}
+	return bid.Timestamp.Before(other.Timestamp)
+}
@@ -0,0 +1,32 @@
+package bid_entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitWinners(t *testing.T) {
+	at := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	bids := []Bid{
+		{Id: "ann-1", UserId: "ann", Amount: 10, Timestamp: at},
+		{Id: "bob-1", UserId: "bob", Amount: 20, Timestamp: at.Add(time.Second)},
+		{Id: "ann-2", UserId: "ann", Amount: 30, Timestamp: at.Add(2 * time.Second)},
+		{Id: "cid-1", UserId: "cid", Amount: 20, Timestamp: at.Add(3 * time.Second)},
+	}
+
+	ids := func(winners []Bid) []string {
+		var result []string
+		for _, bid := range winners {
+			result = append(result, bid.Id)
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"ann-2"}, ids(UnitWinners(bids, 1)))
+	// Bob bid 20 before Cid did.
+	assert.Equal(t, []string{"ann-2", "bob-1"}, ids(UnitWinners(bids, 2)))
+	assert.Equal(t, []string{"ann-2", "bob-1", "cid-1"}, ids(UnitWinners(bids, 5)))
+	assert.Empty(t, UnitWinners(nil, 3))
+}
@@ -0,0 +1,91 @@
+package category_entity
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+)
+
+// Category is a node of the category tree auctions are filed under, named
+// the way auctions refer to it.
+type Category struct {
+	Name string
+	// Parent is the category this one belongs to, empty for the top level.
+	Parent    string
+	Timestamp time.Time
+}
+
+func CreateCategory(name, parent string) (*Category, *internal_error.InternalError) {
+	category := &Category{
+		Name:      name,
+		Parent:    parent,
+		Timestamp: time.Now(),
+	}
+
+	if err := category.Validate(); err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+func (c *Category) Validate() *internal_error.InternalError {
+	if len(c.Name) <= 2 || c.Name == c.Parent {
+		return internal_error.NewBadRequestError("invalid category object")
+	}
+
+	return nil
+}
+
+// Subtree returns name followed by every category below it in the tree.
+// A name the tree does not know about is returned on its own.
+func Subtree(categories []Category, name string) []string {
+	children := make(map[string][]string)
+	for _, category := range categories {
+		children[category.Parent] = append(children[category.Parent], category.Name)
+	}
+
+	subtree := []string{name}
+	for i := 0; i < len(subtree); i++ {
+		subtree = append(subtree, children[subtree[i]]...)
+	}
+
+	return subtree
+}
+
+// CreatesCycle reports whether moving name under parent would make name an
+// ancestor of itself.
+func CreatesCycle(categories []Category, name, parent string) bool {
+	parents := make(map[string]string)
+	for _, category := range categories {
+		parents[category.Name] = category.Parent
+	}
+
+	visited := make(map[string]bool)
+	for ancestor := parent; ancestor != "" && !visited[ancestor]; ancestor = parents[ancestor] {
+		if ancestor == name {
+			return true
+		}
+		visited[ancestor] = true
+	}
+
+	return false
+}
+
+type CategoryRepositoryInterface interface {
+	CreateCategory(
+		ctx context.Context, category *Category) *internal_error.InternalError
+
+	// UpdateCategory moves the category under its new parent.
+	UpdateCategory(
+		ctx context.Context, category *Category) *internal_error.InternalError
+
+	DeleteCategory(
+		ctx context.Context, name string) *internal_error.InternalError
+
+	FindCategory(
+		ctx context.Context, name string) (*Category, *internal_error.InternalError)
+
+	FindCategories(
+		ctx context.Context) ([]Category, *internal_error.InternalError)
+}
@@ -0,0 +1,29 @@
+package category_entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var tree = []Category{
+	{Name: "Electronics"},
+	{Name: "Computers", Parent: "Electronics"},
+	{Name: "Laptops", Parent: "Computers"},
+	{Name: "Phones", Parent: "Electronics"},
+	{Name: "Furniture"},
+}
+
+func TestSubtree(t *testing.T) {
+	assert.ElementsMatch(t,
+		[]string{"Electronics", "Computers", "Laptops", "Phones"}, Subtree(tree, "Electronics"))
+	assert.Equal(t, []string{"Laptops"}, Subtree(tree, "Laptops"))
+	assert.Equal(t, []string{"Toys"}, Subtree(tree, "Toys"))
+}
+
+func TestCreatesCycle(t *testing.T) {
+	assert.True(t, CreatesCycle(tree, "Electronics", "Laptops"))
+	assert.True(t, CreatesCycle(tree, "Computers", "Computers"))
+	assert.False(t, CreatesCycle(tree, "Laptops", "Phones"))
+	assert.False(t, CreatesCycle(tree, "Furniture", ""))
+}
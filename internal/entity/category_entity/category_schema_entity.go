@@ -0,0 +1,124 @@
+package category_entity
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/internal/internal_error"
+	"strconv"
+)
+
+type AttributeType string
+
+const (
+	StringAttribute  AttributeType = "string"
+	NumberAttribute  AttributeType = "number"
+	BooleanAttribute AttributeType = "boolean"
+)
+
+type AttributeDefinition struct {
+	Name     string
+	Type     AttributeType
+	Required bool
+}
+
+type CategorySchema struct {
+	Category   string
+	Attributes []AttributeDefinition
+	// Labels are the display names of the category, by language.
+	Labels map[string]string
+}
+
+func CreateCategorySchema(
+	category string,
+	attributes []AttributeDefinition,
+	labels map[string]string) (*CategorySchema, *internal_error.InternalError) {
+	schema := &CategorySchema{
+		Category:   category,
+		Attributes: attributes,
+		Labels:     labels,
+	}
+
+	if err := schema.Validate(); err != nil {
+		return nil, err
+	}
+
+	return schema, nil
+}
+
+func (cs *CategorySchema) Validate() *internal_error.InternalError {
+	if len(cs.Category) <= 2 {
+		return internal_error.NewBadRequestError("invalid category schema object")
+	}
+
+	names := make(map[string]bool)
+	for _, attribute := range cs.Attributes {
+		if attribute.Name == "" || names[attribute.Name] {
+			return internal_error.NewBadRequestError("invalid category schema object")
+		}
+
+		if attribute.Type != StringAttribute &&
+			attribute.Type != NumberAttribute &&
+			attribute.Type != BooleanAttribute {
+			return internal_error.NewBadRequestError(
+				fmt.Sprintf("invalid type for attribute %s", attribute.Name))
+		}
+
+		names[attribute.Name] = true
+	}
+
+	return nil
+}
+
+// ValidateAttributes checks an auction attribute map against the schema:
+// required attributes must be present, values must parse as the declared
+// type and attributes the schema does not know about are rejected.
+func (cs *CategorySchema) ValidateAttributes(attributes map[string]string) *internal_error.InternalError {
+	definitions := make(map[string]AttributeDefinition)
+	for _, attribute := range cs.Attributes {
+		definitions[attribute.Name] = attribute
+	}
+
+	for name, value := range attributes {
+		definition, ok := definitions[name]
+		if !ok {
+			return internal_error.NewBadRequestError(
+				fmt.Sprintf("attribute %s is not allowed for category %s", name, cs.Category))
+		}
+
+		if !definition.accepts(value) {
+			return internal_error.NewBadRequestError(
+				fmt.Sprintf("attribute %s must be a %s value", name, definition.Type))
+		}
+	}
+
+	for _, definition := range cs.Attributes {
+		if _, ok := attributes[definition.Name]; definition.Required && !ok {
+			return internal_error.NewBadRequestError(
+				fmt.Sprintf("attribute %s is required for category %s", definition.Name, cs.Category))
+		}
+	}
+
+	return nil
+}
+
+func (ad AttributeDefinition) accepts(value string) bool {
+	switch ad.Type {
+	case NumberAttribute:
+		_, err := strconv.ParseFloat(value, 64)
+		return err == nil
+	case BooleanAttribute:
+		_, err := strconv.ParseBool(value)
+		return err == nil
+	default:
+		return true
+	}
+}
+
+type CategorySchemaRepositoryInterface interface {
+	UpsertCategorySchema(
+		ctx context.Context,
+		schema *CategorySchema) *internal_error.InternalError
+
+	FindCategorySchema(
+		ctx context.Context, category string) (*CategorySchema, *internal_error.InternalError)
+}
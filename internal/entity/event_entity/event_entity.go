@@ -0,0 +1,55 @@
+package event_entity
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/clock"
+	"time"
+)
+
+type Type string
+
+const (
+	AuctionEndingIn5m Type = "auction.ending_in_5m"
+	AuctionEndingIn1m Type = "auction.ending_in_1m"
+	AuctionStarted    Type = "auction.started"
+	AuctionHighestBid Type = "auction.highest_bid"
+	AuctionCompleted  Type = "auction.completed"
+	AuctionCancelled  Type = "auction.cancelled"
+	AuctionPaused     Type = "auction.paused"
+	// AuctionResumed carries the ends_at the lot was moved to.
+	AuctionResumed Type = "auction.resumed"
+	BidPlaced      Type = "bid.placed"
+	// BidRetracted carries the highest bid left standing, if any.
+	BidRetracted Type = "bid.retracted"
+
+	// Live lots, announced by the auctioneer.
+	AuctionBiddingOpened Type = "auction.bidding_opened"
+	AuctionGoingOnce     Type = "auction.going_once"
+	AuctionGoingTwice    Type = "auction.going_twice"
+	// AuctionIncrementChanged carries the new min_increment of the lot.
+	AuctionIncrementChanged Type = "auction.increment_changed"
+)
+
+// Event is something that happened on an auction, published for anyone
+// following it.
+type Event struct {
+	Type      Type
+	AuctionId string
+	Payload   map[string]interface{}
+	Timestamp time.Time
+}
+
+func NewEvent(eventType Type, auctionId string, payload map[string]interface{}) Event {
+	return Event{
+		Type:      eventType,
+		AuctionId: auctionId,
+		Payload:   payload,
+		Timestamp: clock.Now(),
+	}
+}
+
+// Publisher hands events to the broker. Like notifications, publishing is
+// best effort and never fails the caller.
+type Publisher interface {
+	Publish(ctx context.Context, event Event)
+}
@@ -0,0 +1,155 @@
+package event_entity
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type Operator string
+
+const (
+	Equal          Operator = "="
+	NotEqual       Operator = "!="
+	Greater        Operator = ">"
+	GreaterOrEqual Operator = ">="
+	Less           Operator = "<"
+	LessOrEqual    Operator = "<="
+)
+
+// Longer operators come first so ">=" is not read as ">".
+var operators = []Operator{GreaterOrEqual, LessOrEqual, NotEqual, Equal, Greater, Less}
+
+// Condition compares the payload value at Path, a dot separated path such
+// as "$.auction.category", with Value. Numbers compare numerically, other
+// values only support equality.
+type Condition struct {
+	Path     string
+	Operator Operator
+	Value    string
+}
+
+// ParseCondition reads conditions written as "$.amount>1000" or
+// "category=Art". Values may be quoted to keep operators in them.
+func ParseCondition(expression string) (Condition, error) {
+	for _, operator := range operators {
+		path, value, found := strings.Cut(expression, string(operator))
+		if !found {
+			continue
+		}
+
+		path = strings.TrimSpace(path)
+		if strings.TrimPrefix(path, "$.") == "" {
+			return Condition{}, fmt.Errorf("condition %q has no path", expression)
+		}
+
+		value = strings.TrimSpace(value)
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		}
+
+		return Condition{Path: path, Operator: operator, Value: value}, nil
+	}
+
+	return Condition{}, fmt.Errorf("condition %q has no operator", expression)
+}
+
+func (c Condition) Matches(payload map[string]interface{}) bool {
+	actual, ok := lookup(payload, c.Path)
+	if !ok {
+		return false
+	}
+
+	actualNumber, actualIsNumber := toFloat(actual)
+	expectedNumber, err := strconv.ParseFloat(c.Value, 64)
+	if actualIsNumber && err == nil {
+		switch c.Operator {
+		case Equal:
+			return actualNumber == expectedNumber
+		case NotEqual:
+			return actualNumber != expectedNumber
+		case Greater:
+			return actualNumber > expectedNumber
+		case GreaterOrEqual:
+			return actualNumber >= expectedNumber
+		case Less:
+			return actualNumber < expectedNumber
+		case LessOrEqual:
+			return actualNumber <= expectedNumber
+		}
+		return false
+	}
+
+	switch c.Operator {
+	case Equal:
+		return fmt.Sprint(actual) == c.Value
+	case NotEqual:
+		return fmt.Sprint(actual) != c.Value
+	}
+	return false
+}
+
+// Filter selects the events a consumer, such as a webhook subscription,
+// wants delivered. Empty types match every type and all conditions must
+// hold.
+type Filter struct {
+	Types      []Type
+	Conditions []Condition
+}
+
+func (f Filter) Matches(event Event) bool {
+	if len(f.Types) > 0 {
+		matched := false
+		for _, eventType := range f.Types {
+			if eventType == event.Type {
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			return false
+		}
+	}
+
+	for _, condition := range f.Conditions {
+		if !condition.Matches(event.Payload) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func lookup(payload map[string]interface{}, path string) (interface{}, bool) {
+	var value interface{} = payload
+	for _, key := range strings.Split(strings.TrimPrefix(path, "$."), ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+
+		if value, ok = object[key]; !ok {
+			return nil, false
+		}
+	}
+
+	return value, true
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch number := value.(type) {
+	case float64:
+		return number, true
+	case float32:
+		return float64(number), true
+	case int:
+		return float64(number), true
+	case int64:
+		return float64(number), true
+	case int32:
+		return float64(number), true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,50 @@
+package event_entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCondition(t *testing.T) {
+	condition, err := ParseCondition("$.amount >= 1000")
+	assert.Nil(t, err)
+	assert.Equal(t, Condition{Path: "$.amount", Operator: GreaterOrEqual, Value: "1000"}, condition)
+
+	condition, err = ParseCondition(`category="Art"`)
+	assert.Nil(t, err)
+	assert.Equal(t, Condition{Path: "category", Operator: Equal, Value: "Art"}, condition)
+
+	_, err = ParseCondition("amount")
+	assert.NotNil(t, err)
+
+	_, err = ParseCondition("$.=1")
+	assert.NotNil(t, err)
+}
+
+func TestFilterMatches(t *testing.T) {
+	event := Event{
+		Type: AuctionEndingIn5m,
+		Payload: map[string]interface{}{
+			"amount":  1500.0,
+			"auction": map[string]interface{}{"category": "Art"},
+		},
+	}
+
+	expensiveArt := Filter{Conditions: []Condition{
+		{Path: "$.amount", Operator: Greater, Value: "1000"},
+		{Path: "$.auction.category", Operator: Equal, Value: "Art"},
+	}}
+	assert.True(t, expensiveArt.Matches(event))
+
+	assert.False(t, Filter{Types: []Type{AuctionEndingIn1m}}.Matches(event))
+	assert.False(t, Filter{Conditions: []Condition{
+		{Path: "$.amount", Operator: Less, Value: "1000"},
+	}}.Matches(event))
+	assert.False(t, Filter{Conditions: []Condition{
+		{Path: "$.seller.id", Operator: Equal, Value: "x"},
+	}}.Matches(event))
+	assert.False(t, Filter{Conditions: []Condition{
+		{Path: "$.auction.category", Operator: Greater, Value: "A"},
+	}}.Matches(event))
+}
@@ -0,0 +1,56 @@
+package fraud_entity
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/clock"
+	"fullcycle-auction_go/internal/internal_error"
+	"github.com/google/uuid"
+	"time"
+)
+
+type Signal string
+
+const (
+	// SellerSelfBid is a bid placed by the seller of the auction.
+	SellerSelfBid Signal = "seller_self_bid"
+	// RapidSelfOutbid is a bidder repeatedly raising a bid they already
+	// lead, which only pushes the price up.
+	RapidSelfOutbid Signal = "rapid_self_outbid"
+	// SharedClient is a bidder whose bids came from the same IP address or
+	// device as another bidder, or as the seller, on the same auction.
+	SharedClient Signal = "shared_client"
+)
+
+type FraudFlag struct {
+	Id        string
+	AuctionId string
+	UserId    string
+	Signal    Signal
+	Details   string
+	Timestamp time.Time
+}
+
+// CreateFraudFlag derives the id from the auction, user and signal, so
+// scanning the same bids again updates the flag instead of duplicating it.
+func CreateFraudFlag(auctionId, userId string, signal Signal, details string) *FraudFlag {
+	return &FraudFlag{
+		Id:        uuid.NewSHA1(uuid.NameSpaceOID, []byte(auctionId+"/"+userId+"/"+string(signal))).String(),
+		AuctionId: auctionId,
+		UserId:    userId,
+		Signal:    signal,
+		Details:   details,
+		Timestamp: clock.Now(),
+	}
+}
+
+type FraudFlagRepositoryInterface interface {
+	// UpsertFraudFlag refreshes the details of an existing flag but keeps the
+	// timestamp of when it was first raised.
+	UpsertFraudFlag(
+		ctx context.Context, flag *FraudFlag) *internal_error.InternalError
+
+	// FindFraudFlags lists the flags of one auction, or of every auction when
+	// auctionId is empty, newest first.
+	FindFraudFlags(
+		ctx context.Context, auctionId string) ([]FraudFlag, *internal_error.InternalError)
+}
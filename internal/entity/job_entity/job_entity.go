@@ -0,0 +1,112 @@
+package job_entity
+
+import (
+	"context"
+	"encoding/json"
+	"fullcycle-auction_go/configuration/clock"
+	"fullcycle-auction_go/internal/internal_error"
+	"github.com/google/uuid"
+	"time"
+)
+
+type JobStatus string
+
+const (
+	Pending   JobStatus = "pending"
+	Running   JobStatus = "running"
+	Succeeded JobStatus = "succeeded"
+	// Failed jobs ran out of attempts and wait for an admin to retry them.
+	Failed JobStatus = "failed"
+)
+
+// Job is a unit of background work of a kind, run by the handler
+// registered for the kind with its JSON payload.
+type Job struct {
+	Id          string
+	Kind        string
+	Payload     []byte
+	Status      JobStatus
+	Attempts    int
+	MaxAttempts int
+	RunAt       time.Time
+	// LockedUntil is when a running job is considered abandoned by its
+	// worker and can be claimed again.
+	LockedUntil time.Time
+	LastError   string
+	Timestamp   time.Time
+	UpdatedAt   time.Time
+}
+
+func CreateJob(
+	kind string,
+	payload interface{},
+	runAt time.Time,
+	maxAttempts int) (*Job, *internal_error.InternalError) {
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return nil, internal_error.NewBadRequestError("Job payload is not valid JSON")
+	}
+
+	now := clock.Now()
+	if runAt.IsZero() {
+		runAt = now
+	}
+
+	return &Job{
+		Id:          uuid.New().String(),
+		Kind:        kind,
+		Payload:     encoded,
+		Status:      Pending,
+		MaxAttempts: maxAttempts,
+		RunAt:       runAt,
+		Timestamp:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+// Handler runs a job from its payload. Returning an error schedules a
+// retry while the job has attempts left.
+type Handler func(ctx context.Context, payload []byte) error
+
+// Queue takes jobs to run in the background. A zero runAt runs the job as
+// soon as a worker is free.
+type Queue interface {
+	Enqueue(
+		ctx context.Context,
+		kind string,
+		payload interface{},
+		runAt time.Time) *internal_error.InternalError
+}
+
+type JobRepositoryInterface interface {
+	CreateJob(
+		ctx context.Context, job *Job) *internal_error.InternalError
+
+	// ClaimJob hands one job of the kinds due at now to the caller, marking
+	// it running until lockedUntil and counting the attempt. It picks
+	// pending jobs and running jobs whose lock expired, returning a not found
+	// error when there are none.
+	ClaimJob(
+		ctx context.Context,
+		kinds []string,
+		now, lockedUntil time.Time) (*Job, *internal_error.InternalError)
+
+	// UpdateJob stores the status, schedule, attempts and error of the job,
+	// as long as it is still locked until lockedUntil: the lock it was
+	// claimed with, or the one it was read with. A job claimed again since,
+	// its lock having expired, is not found, so the result of the worker
+	// that lost it does not overwrite the new one.
+	UpdateJob(
+		ctx context.Context, job *Job, lockedUntil time.Time) *internal_error.InternalError
+
+	FindJobById(
+		ctx context.Context, id string) (*Job, *internal_error.InternalError)
+
+	// FindJobs lists up to limit jobs, newest first, filtered by status and
+	// kind when they are not empty.
+	FindJobs(
+		ctx context.Context,
+		status JobStatus,
+		kind string,
+		limit int) ([]Job, *internal_error.InternalError)
+}
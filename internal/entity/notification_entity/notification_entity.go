@@ -0,0 +1,88 @@
+package notification_entity
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/clock"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Kind string
+
+const (
+	QuestionPosted     Kind = "question_posted"
+	QuestionAnswered   Kind = "question_answered"
+	AuctionEnding      Kind = "auction_ending"
+	OfferReceived      Kind = "offer_received"
+	OfferAnswered      Kind = "offer_answered"
+	AuctionTransferred Kind = "auction_transferred"
+)
+
+// Notification is a message addressed to one user about something that
+// happened on an auction.
+type Notification struct {
+	Id        string
+	UserId    string
+	AuctionId string
+	Kind      Kind
+	Message   string
+	Timestamp time.Time
+	// ReadAt and ArchivedAt are zero until the notification is read in the
+	// inbox or archived from it.
+	ReadAt     time.Time
+	ArchivedAt time.Time
+}
+
+func NewNotification(userId, auctionId string, kind Kind, message string) Notification {
+	return Notification{
+		Id:        uuid.New().String(),
+		UserId:    userId,
+		AuctionId: auctionId,
+		Kind:      kind,
+		Message:   message,
+		Timestamp: clock.Now(),
+	}
+}
+
+func (n *Notification) Read() bool {
+	return !n.ReadAt.IsZero()
+}
+
+func (n *Notification) Archived() bool {
+	return !n.ArchivedAt.IsZero()
+}
+
+// Notifier delivers notifications. Delivery is best effort: failures are
+// handled by the notifier and never fail the action that triggered them.
+type Notifier interface {
+	Notify(ctx context.Context, notification Notification)
+}
+
+// NotificationRepositoryInterface stores the in-app inbox of each user.
+type NotificationRepositoryInterface interface {
+	CreateNotification(
+		ctx context.Context, notification *Notification) *internal_error.InternalError
+
+	// FindNotifications lists the notifications of the user, newest first,
+	// either the archived ones or the others.
+	FindNotifications(
+		ctx context.Context,
+		userId string,
+		archived bool) ([]Notification, *internal_error.InternalError)
+
+	// MarkNotificationsRead marks the unread notifications of the user
+	// among ids, or all of them when ids is empty, and returns how many.
+	MarkNotificationsRead(
+		ctx context.Context,
+		userId string,
+		ids []string,
+		readAt time.Time) (int64, *internal_error.InternalError)
+
+	// ArchiveNotifications archives the notifications sent before cutoff
+	// that are not archived yet and returns how many.
+	ArchiveNotifications(
+		ctx context.Context,
+		cutoff, archivedAt time.Time) (int64, *internal_error.InternalError)
+}
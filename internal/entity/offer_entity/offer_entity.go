@@ -0,0 +1,100 @@
+package offer_entity
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/clock"
+	"fullcycle-auction_go/internal/internal_error"
+	"github.com/google/uuid"
+	"time"
+)
+
+type OfferStatus string
+
+const (
+	Pending  OfferStatus = "pending"
+	Accepted OfferStatus = "accepted"
+	Declined OfferStatus = "declined"
+	// Expired offers were left pending past their expiry.
+	Expired OfferStatus = "expired"
+)
+
+// Offer is a second-chance offer from the seller of a completed auction to
+// one of its losing bidders, at the amount of their highest bid.
+type Offer struct {
+	Id          string
+	AuctionId   string
+	SellerId    string
+	UserId      string
+	Amount      float64
+	Status      OfferStatus
+	ExpiresAt   time.Time
+	RespondedAt time.Time
+	Timestamp   time.Time
+}
+
+func CreateOffer(
+	auctionId, sellerId, userId string,
+	amount float64,
+	expiresIn time.Duration) (*Offer, *internal_error.InternalError) {
+	now := clock.Now()
+	offer := &Offer{
+		Id:        uuid.New().String(),
+		AuctionId: auctionId,
+		SellerId:  sellerId,
+		UserId:    userId,
+		Amount:    amount,
+		Status:    Pending,
+		ExpiresAt: now.Add(expiresIn),
+		Timestamp: now,
+	}
+
+	if err := offer.Validate(); err != nil {
+		return nil, err
+	}
+
+	return offer, nil
+}
+
+func (o *Offer) Validate() *internal_error.InternalError {
+	if err := uuid.Validate(o.AuctionId); err != nil {
+		return internal_error.NewBadRequestError("AuctionId is not a valid id")
+	} else if err := uuid.Validate(o.UserId); err != nil {
+		return internal_error.NewBadRequestError("UserId is not a valid id")
+	} else if o.Amount <= 0 {
+		return internal_error.NewBadRequestError("Amount must be greater than 0")
+	} else if !o.ExpiresAt.After(o.Timestamp) {
+		return internal_error.NewBadRequestError("Offer must expire after it is made")
+	}
+
+	return nil
+}
+
+// CurrentStatus is the status of the offer at now. Pending offers past
+// their expiry are expired even before it is stored.
+func (o *Offer) CurrentStatus(now time.Time) OfferStatus {
+	if o.Status == Pending && now.After(o.ExpiresAt) {
+		return Expired
+	}
+
+	return o.Status
+}
+
+type OfferRepositoryInterface interface {
+	CreateOffer(
+		ctx context.Context, offer *Offer) *internal_error.InternalError
+
+	FindOfferById(
+		ctx context.Context, id string) (*Offer, *internal_error.InternalError)
+
+	// FindOffers lists the offers made on the auction, oldest first.
+	FindOffers(
+		ctx context.Context, auctionId string) ([]Offer, *internal_error.InternalError)
+
+	// UpdateOfferStatus moves a pending offer to status, returning a not
+	// found error when it is no longer pending.
+	UpdateOfferStatus(
+		ctx context.Context,
+		id string,
+		status OfferStatus,
+		respondedAt time.Time) *internal_error.InternalError
+}
@@ -0,0 +1,73 @@
+package product_entity
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/clock"
+	"fullcycle-auction_go/internal/internal_error"
+	"github.com/google/uuid"
+	"time"
+)
+
+type Product struct {
+	Id          string
+	Sku         string
+	Name        string
+	Description string
+	Category    string
+	Attributes  map[string]string
+	Images      []string
+	Timestamp   time.Time
+}
+
+func CreateProduct(
+	sku, name, description, category string,
+	attributes map[string]string,
+	images []string) (*Product, *internal_error.InternalError) {
+	product := &Product{
+		Id:          uuid.New().String(),
+		Sku:         sku,
+		Name:        name,
+		Description: description,
+		Category:    category,
+		Attributes:  attributes,
+		Images:      images,
+		Timestamp:   clock.Now(),
+	}
+
+	if err := product.Validate(); err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
+
+func (p *Product) Validate() *internal_error.InternalError {
+	if len(p.Sku) == 0 ||
+		len(p.Name) <= 1 ||
+		len(p.Category) <= 2 ||
+		len(p.Description) <= 10 {
+		return internal_error.NewBadRequestError("invalid product object")
+	}
+
+	return nil
+}
+
+type ProductRepositoryInterface interface {
+	CreateProduct(
+		ctx context.Context,
+		productEntity *Product) *internal_error.InternalError
+
+	FindProductById(
+		ctx context.Context, id string) (*Product, *internal_error.InternalError)
+
+	FindProducts(
+		ctx context.Context,
+		category, sku string) ([]Product, *internal_error.InternalError)
+
+	UpdateProduct(
+		ctx context.Context,
+		productEntity *Product) *internal_error.InternalError
+
+	DeleteProduct(
+		ctx context.Context, id string) *internal_error.InternalError
+}
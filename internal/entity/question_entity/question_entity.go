@@ -0,0 +1,78 @@
+package question_entity
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/clock"
+	"fullcycle-auction_go/internal/internal_error"
+	"github.com/google/uuid"
+	"time"
+)
+
+type QuestionStatus string
+
+const (
+	Visible QuestionStatus = "visible"
+	// Hidden questions were moderated out of the public thread.
+	Hidden QuestionStatus = "hidden"
+)
+
+type Question struct {
+	Id         string
+	AuctionId  string
+	UserId     string
+	Text       string
+	Answer     string
+	AnsweredAt time.Time
+	Status     QuestionStatus
+	Timestamp  time.Time
+}
+
+func CreateQuestion(auctionId, userId, text string) (*Question, *internal_error.InternalError) {
+	question := &Question{
+		Id:        uuid.New().String(),
+		AuctionId: auctionId,
+		UserId:    userId,
+		Text:      text,
+		Status:    Visible,
+		Timestamp: clock.Now(),
+	}
+
+	if err := question.Validate(); err != nil {
+		return nil, err
+	}
+
+	return question, nil
+}
+
+func (q *Question) Validate() *internal_error.InternalError {
+	if err := uuid.Validate(q.AuctionId); err != nil {
+		return internal_error.NewBadRequestError("AuctionId is not a valid id")
+	} else if err := uuid.Validate(q.UserId); err != nil {
+		return internal_error.NewBadRequestError("UserId is not a valid id")
+	} else if len(q.Text) < 3 {
+		return internal_error.NewBadRequestError("Question is too short")
+	}
+
+	return nil
+}
+
+func (q *Question) Answered() bool {
+	return !q.AnsweredAt.IsZero()
+}
+
+type QuestionRepositoryInterface interface {
+	CreateQuestion(
+		ctx context.Context, question *Question) *internal_error.InternalError
+
+	FindQuestionById(
+		ctx context.Context, id string) (*Question, *internal_error.InternalError)
+
+	// FindQuestions lists every question of the auction, hidden ones
+	// included, oldest first.
+	FindQuestions(
+		ctx context.Context, auctionId string) ([]Question, *internal_error.InternalError)
+
+	// UpdateQuestion stores the answer and the status of the question.
+	UpdateQuestion(
+		ctx context.Context, question *Question) *internal_error.InternalError
+}
@@ -3,14 +3,110 @@ package user_entity
 import (
 	"context"
 	"fullcycle-auction_go/internal/internal_error"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"strings"
 )
 
 type User struct {
 	Id   string
 	Name string
+	// Banned users have every bid they place rejected.
+	Banned bool
+
+	DisplayName string
+	AvatarUrl   string
+	Bio         string
+
+	// Email and PasswordHash are empty for users created before
+	// registration existed, who cannot log in.
+	Email        string
+	PasswordHash string
+
+	Role Role
+}
+
+// Role grants a user access to staff features. Regular users have none.
+type Role string
+
+const (
+	RoleNone Role = ""
+	// RoleAuctioneer runs live lots: opens bidding, calls and hammers them.
+	RoleAuctioneer Role = "auctioneer"
+	// RoleStaff handles the auction floor, such as bids from phone bidders.
+	RoleStaff Role = "staff"
+	// RoleAdmin runs the marketplace, such as cancelling any auction.
+	RoleAdmin Role = "admin"
+)
+
+func (r Role) Validate() *internal_error.InternalError {
+	switch r {
+	case RoleNone, RoleAuctioneer, RoleStaff, RoleAdmin:
+		return nil
+	}
+
+	return internal_error.NewBadRequestError("Invalid role")
+}
+
+// CreateUser registers a user, storing only the bcrypt hash of the
+// password. The email is compared case-insensitively.
+func CreateUser(name, email, password string) (*User, *internal_error.InternalError) {
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, internal_error.NewBadRequestError("Invalid password")
+	}
+
+	return &User{
+		Id:           uuid.New().String(),
+		Name:         name,
+		Email:        NormalizeEmail(email),
+		PasswordHash: string(passwordHash),
+	}, nil
+}
+
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// CheckPassword reports whether password matches the stored hash, always
+// false for users without credentials.
+func (u *User) CheckPassword(password string) bool {
+	if u.PasswordHash == "" {
+		return false
+	}
+
+	return bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password)) == nil
+}
+
+// PublicName is the name shown to other users, the display name when the
+// user picked one.
+func (u *User) PublicName() string {
+	if u.DisplayName != "" {
+		return u.DisplayName
+	}
+
+	return u.Name
 }
 
 type UserRepositoryInterface interface {
 	FindUserById(
 		ctx context.Context, userId string) (*User, *internal_error.InternalError)
+
+	// FindUsersByIds returns the users found among userIds, in no particular
+	// order.
+	FindUsersByIds(
+		ctx context.Context, userIds []string) ([]User, *internal_error.InternalError)
+
+	// UpdateUserProfile stores the display name, avatar and bio of the user.
+	UpdateUserProfile(
+		ctx context.Context, user *User) *internal_error.InternalError
+
+	CreateUser(
+		ctx context.Context, user *User) *internal_error.InternalError
+
+	FindUserByEmail(
+		ctx context.Context, email string) (*User, *internal_error.InternalError)
+
+	UpdateUserRole(
+		ctx context.Context, userId string, role Role) *internal_error.InternalError
 }
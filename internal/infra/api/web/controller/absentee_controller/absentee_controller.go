@@ -0,0 +1,83 @@
+package absentee_controller
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/infra/api/web/middleware"
+	"fullcycle-auction_go/internal/infra/api/web/validation"
+	"fullcycle-auction_go/internal/usecase/absentee_usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+type AbsenteeController struct {
+	absenteeUseCase absentee_usecase.AbsenteeUseCaseInterface
+}
+
+func NewAbsenteeController(
+	absenteeUseCase absentee_usecase.AbsenteeUseCaseInterface) *AbsenteeController {
+	return &AbsenteeController{
+		absenteeUseCase: absenteeUseCase,
+	}
+}
+
+func (u *AbsenteeController) LodgeAbsenteeBid(c *gin.Context) {
+	auctionId, ok := validAuctionId(c)
+	if !ok {
+		return
+	}
+
+	var inputDTO absentee_usecase.AbsenteeBidInputDTO
+	if err := c.ShouldBindJSON(&inputDTO); err != nil {
+		restErr := validation.ValidateErr(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	absenteeBid, err := u.absenteeUseCase.LodgeAbsenteeBid(
+		context.Background(), auctionId, middleware.AuthenticatedUserId(c), inputDTO)
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.JSON(http.StatusCreated, absenteeBid)
+}
+
+func (u *AbsenteeController) FindAbsenteeBid(c *gin.Context) {
+	auctionId, ok := validAuctionId(c)
+	if !ok {
+		return
+	}
+
+	absenteeBid, err := u.absenteeUseCase.FindAbsenteeBid(
+		context.Background(), auctionId, middleware.AuthenticatedUserId(c))
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, absenteeBid)
+}
+
+func validAuctionId(c *gin.Context) (string, bool) {
+	auctionId := c.Param("auctionId")
+
+	if err := uuid.Validate(auctionId); err != nil {
+		errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+			Field:   "auctionId",
+			Message: "Invalid UUID value",
+		})
+
+		c.JSON(errRest.Code, errRest)
+		return "", false
+	}
+
+	return auctionId, true
+}
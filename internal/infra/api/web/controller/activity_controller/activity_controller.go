@@ -0,0 +1,59 @@
+package activity_controller
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/usecase/activity_usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"strconv"
+)
+
+type ActivityController struct {
+	activityUseCase activity_usecase.ActivityUseCaseInterface
+}
+
+func NewActivityController(activityUseCase activity_usecase.ActivityUseCaseInterface) *ActivityController {
+	return &ActivityController{
+		activityUseCase: activityUseCase,
+	}
+}
+
+func (u *ActivityController) FindAuctionActivity(c *gin.Context) {
+	auctionId := c.Param("auctionId")
+
+	if err := uuid.Validate(auctionId); err != nil {
+		errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+			Field:   "auctionId",
+			Message: "Invalid UUID value",
+		})
+
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	page, errPage := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if errPage != nil || page < 1 {
+		errRest := rest_err.NewBadRequestError("Error trying to validate page param")
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	pageSize, errPageSize := strconv.Atoi(
+		c.DefaultQuery("page_size", strconv.Itoa(activity_usecase.DefaultActivityPageSize)))
+	if errPageSize != nil || pageSize < 1 || pageSize > activity_usecase.MaxActivityPageSize {
+		errRest := rest_err.NewBadRequestError("Error trying to validate page_size param")
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	activity, err := u.activityUseCase.FindAuctionActivity(context.Background(), auctionId, page, pageSize)
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.JSON(http.StatusOK, activity)
+}
@@ -3,6 +3,7 @@ package auction_controller
 import (
 	"context"
 	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/infra/api/web/middleware"
 	"fullcycle-auction_go/internal/infra/api/web/validation"
 	"fullcycle-auction_go/internal/usecase/auction_usecase"
 	"github.com/gin-gonic/gin"
@@ -19,6 +20,8 @@ func NewAuctionController(auctionUseCase auction_usecase.AuctionUseCaseInterface
 	}
 }
 
+// CreateAuction sells the auction as the authenticated user, who cannot
+// name another seller.
 func (u *AuctionController) CreateAuction(c *gin.Context) {
 	var auctionInputDTO auction_usecase.AuctionInputDTO
 
@@ -29,6 +32,15 @@ func (u *AuctionController) CreateAuction(c *gin.Context) {
 		return
 	}
 
+	userId := middleware.AuthenticatedUserId(c)
+	if auctionInputDTO.SellerId != "" && auctionInputDTO.SellerId != userId {
+		restErr := rest_err.NewForbiddenError("Auctions can only be created for their authenticated seller")
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+	auctionInputDTO.SellerId = userId
+
 	err := u.auctionUseCase.CreateAuction(context.Background(), auctionInputDTO)
 	if err != nil {
 		restErr := rest_err.ConvertError(err)
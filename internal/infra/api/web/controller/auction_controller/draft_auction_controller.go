@@ -0,0 +1,81 @@
+package auction_controller
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/infra/api/web/middleware"
+	"fullcycle-auction_go/internal/infra/api/web/validation"
+	"fullcycle-auction_go/internal/usecase/auction_usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+// UpdateDraft edits the draft of the authenticated seller, who cannot hand
+// it to another seller.
+func (u *AuctionController) UpdateDraft(c *gin.Context) {
+	auctionId, ok := auctionIdParam(c)
+	if !ok {
+		return
+	}
+
+	var auctionInputDTO auction_usecase.AuctionInputDTO
+	if err := c.ShouldBindJSON(&auctionInputDTO); err != nil {
+		restErr := validation.ValidateErr(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	sellerId := middleware.AuthenticatedUserId(c)
+	if auctionInputDTO.SellerId != "" && auctionInputDTO.SellerId != sellerId {
+		restErr := rest_err.NewForbiddenError("Drafts can only be edited for their authenticated seller")
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	auctionData, err := u.auctionUseCase.UpdateDraft(context.Background(), auctionId, sellerId, auctionInputDTO)
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, auctionData)
+}
+
+func (u *AuctionController) PublishAuction(c *gin.Context) {
+	auctionId, ok := auctionIdParam(c)
+	if !ok {
+		return
+	}
+
+	auctionData, err := u.auctionUseCase.PublishAuction(
+		context.Background(), auctionId, middleware.AuthenticatedUserId(c))
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, auctionData)
+}
+
+func auctionIdParam(c *gin.Context) (string, bool) {
+	auctionId := c.Param("auctionId")
+
+	if err := uuid.Validate(auctionId); err != nil {
+		errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+			Field:   "auctionId",
+			Message: "Invalid UUID value",
+		})
+
+		c.JSON(errRest.Code, errRest)
+		return "", false
+	}
+
+	return auctionId, true
+}
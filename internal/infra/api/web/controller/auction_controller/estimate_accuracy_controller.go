@@ -0,0 +1,34 @@
+package auction_controller
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/rest_err"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+func (u *AuctionController) FindEstimateAccuracy(c *gin.Context) {
+	sellerId := c.Query("sellerId")
+
+	if sellerId != "" {
+		if err := uuid.Validate(sellerId); err != nil {
+			errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+				Field:   "sellerId",
+				Message: "Invalid UUID value",
+			})
+
+			c.JSON(errRest.Code, errRest)
+			return
+		}
+	}
+
+	accuracy, err := u.auctionUseCase.FindEstimateAccuracy(context.Background(), sellerId, c.Query("category"))
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.JSON(http.StatusOK, accuracy)
+}
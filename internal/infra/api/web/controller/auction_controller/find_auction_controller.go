@@ -2,12 +2,17 @@ package auction_controller
 
 import (
 	"context"
+	"fullcycle-auction_go/configuration/i18n"
 	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/infra/api/web/fieldset"
+	"fullcycle-auction_go/internal/infra/api/web/middleware"
+	"fullcycle-auction_go/internal/infra/api/web/paging"
 	"fullcycle-auction_go/internal/usecase/auction_usecase"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"net/http"
 	"strconv"
+	"time"
 )
 
 func (u *AuctionController) FindAuctionById(c *gin.Context) {
@@ -23,6 +28,12 @@ func (u *AuctionController) FindAuctionById(c *gin.Context) {
 		return
 	}
 
+	fields, errRest := fieldset.Parse(c, auction_usecase.AuctionOutputDTO{})
+	if errRest != nil {
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
 	auctionData, err := u.auctionUseCase.FindAuctionById(context.Background(), auctionId)
 	if err != nil {
 		errRest := rest_err.ConvertError(err)
@@ -30,30 +41,113 @@ func (u *AuctionController) FindAuctionById(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, auctionData)
+	auctions := []auction_usecase.AuctionOutputDTO{*auctionData}
+	u.localize(c, auctions)
+
+	fieldset.JSON(c, http.StatusOK, auctions[0], fields)
 }
 
 func (u *AuctionController) FindAuctions(c *gin.Context) {
 	status := c.Query("status")
-	category := c.Query("category")
-	productName := c.Query("productName")
 
-	statusNumber, errConv := strconv.Atoi(status)
+	auctionStatus, errConv := auction_usecase.ParseAuctionStatus(status)
 	if errConv != nil {
-		errRest := rest_err.NewBadRequestError("Error trying to validate auction status param")
+		errRest := rest_err.NewBadRequestError("Error trying to validate auction status param", rest_err.Causes{
+			Field:   "status",
+			Message: errConv.Error(),
+		})
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	filter := auction_usecase.AuctionFilterDTO{
+		Status:      auctionStatus,
+		Category:    c.Query("category"),
+		ProductName: c.Query("productName"),
+		Attributes:  c.QueryMap("attributes"),
+	}
+
+	var errRest *rest_err.RestErr
+	if filter.MinPrice, errRest = priceParam(c, "minPrice"); errRest != nil {
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+	if filter.MaxPrice, errRest = priceParam(c, "maxPrice"); errRest != nil {
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+	if filter.EndingBefore, errRest = timeParam(c, "endingBefore"); errRest != nil {
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+	if filter.EndingAfter, errRest = timeParam(c, "endingAfter"); errRest != nil {
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+	if filter.CreatedAfter, errRest = timeParam(c, "createdAfter"); errRest != nil {
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	fields, errRest := fieldset.Parse(c, auction_usecase.AuctionOutputDTO{})
+	if errRest != nil {
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	page, errRest := paging.Parse(c)
+	if errRest != nil {
 		c.JSON(errRest.Code, errRest)
 		return
 	}
 
 	auctions, err := u.auctionUseCase.FindAuctions(context.Background(),
-		auction_usecase.AuctionStatus(statusNumber), category, productName)
+		filter, fields, c.Query("sort"), middleware.AuthenticatedUserId(c), page)
 	if err != nil {
 		errRest := rest_err.ConvertError(err)
 		c.JSON(errRest.Code, errRest)
 		return
 	}
 
-	c.JSON(http.StatusOK, auctions)
+	u.localize(c, auctions.Items)
+
+	fieldset.JSONPage(c, http.StatusOK, auctions, fields)
+}
+
+// priceParam parses the price query param, zero when missing.
+func priceParam(c *gin.Context, name string) (float64, *rest_err.RestErr) {
+	value := c.Query(name)
+	if value == "" {
+		return 0, nil
+	}
+
+	price, err := strconv.ParseFloat(value, 64)
+	if err != nil || price < 0 {
+		return 0, rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+			Field:   name,
+			Message: "Must be a non-negative number",
+		})
+	}
+
+	return price, nil
+}
+
+// timeParam parses the RFC 3339 time query param, zero when missing.
+func timeParam(c *gin.Context, name string) (time.Time, *rest_err.RestErr) {
+	value := c.Query(name)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+			Field:   name,
+			Message: "Must be an RFC 3339 timestamp",
+		})
+	}
+
+	return parsed, nil
 }
 
 func (u *AuctionController) FindWinningBidByAuctionId(c *gin.Context) {
@@ -78,3 +172,39 @@ func (u *AuctionController) FindWinningBidByAuctionId(c *gin.Context) {
 
 	c.JSON(http.StatusOK, auctionData)
 }
+
+func (u *AuctionController) FindAuctionChanges(c *gin.Context) {
+	since, errParse := auction_usecase.ParseChangesCursor(c.Query("since"))
+	if errParse != nil {
+		errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+			Field:   "since",
+			Message: "Must be a changes cursor or an RFC 3339 timestamp",
+		})
+
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	changes, err := u.auctionUseCase.FindAuctionChanges(context.Background(), since)
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.JSON(http.StatusOK, changes)
+}
+
+// localize adds the labels in the language negotiated from Accept-Language,
+// leaving the auctions untouched when there is none.
+func (u *AuctionController) localize(c *gin.Context, auctions []auction_usecase.AuctionOutputDTO) {
+	c.Header("Vary", "Accept-Language")
+
+	language := i18n.Negotiate(c.GetHeader("Accept-Language"))
+	if language == "" {
+		return
+	}
+
+	u.auctionUseCase.LocalizeAuctions(context.Background(), language, auctions)
+	c.Header("Content-Language", language)
+}
@@ -0,0 +1,48 @@
+package auction_controller
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/infra/api/web/middleware"
+	"fullcycle-auction_go/internal/infra/api/web/validation"
+	"fullcycle-auction_go/internal/usecase/auction_usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+func (u *AuctionController) RelistAuction(c *gin.Context) {
+	auctionId := c.Param("auctionId")
+
+	if err := uuid.Validate(auctionId); err != nil {
+		errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+			Field:   "auctionId",
+			Message: "Invalid UUID value",
+		})
+
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	// Every field of the payload is optional, so it may be left out.
+	var inputDTO auction_usecase.RelistAuctionInputDTO
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&inputDTO); err != nil {
+			restErr := validation.ValidateErr(err)
+
+			c.JSON(restErr.Code, restErr)
+			return
+		}
+	}
+
+	auctionData, err := u.auctionUseCase.RelistAuction(
+		context.Background(), auctionId, middleware.AuthenticatedUserId(c), inputDTO)
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.JSON(http.StatusCreated, auctionData)
+}
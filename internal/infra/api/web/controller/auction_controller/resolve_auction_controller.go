@@ -0,0 +1,79 @@
+package auction_controller
+
+import (
+	"encoding/json"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/usecase/auction_usecase"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ResolveAuctions streams one NDJSON line per processed auction followed by
+// a final line holding the summary.
+func (u *AuctionController) ResolveAuctions(c *gin.Context) {
+	status, ok := parseResolvableStatus(c.DefaultQuery("status", "completed"))
+	if !ok {
+		errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+			Field:   "status",
+			Message: "status must be active or completed",
+		})
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	missingWinner, errConv := strconv.ParseBool(c.DefaultQuery("missing_winner", "true"))
+	if errConv != nil {
+		errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+			Field:   "missing_winner",
+			Message: "missing_winner must be a boolean",
+		})
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	encoder := json.NewEncoder(c.Writer)
+	progress := func(resolution auction_usecase.AuctionResolutionDTO) {
+		if !c.Writer.Written() {
+			c.Header("Content-Type", "application/x-ndjson")
+			c.Status(http.StatusOK)
+		}
+
+		if err := encoder.Encode(resolution); err != nil {
+			logger.Error("Error trying to write auction resolution progress", err)
+			return
+		}
+		c.Writer.Flush()
+	}
+
+	summary, err := u.auctionUseCase.ResolveAuctions(c.Request.Context(), status, missingWinner, progress)
+	if err != nil {
+		if !c.Writer.Written() {
+			errRest := rest_err.ConvertError(err)
+			c.JSON(errRest.Code, errRest)
+			return
+		}
+
+		logger.Error("Error trying to resolve auctions", err)
+		return
+	}
+
+	if !c.Writer.Written() {
+		c.Header("Content-Type", "application/x-ndjson")
+		c.Status(http.StatusOK)
+	}
+	encoder.Encode(gin.H{"summary": summary})
+}
+
+// parseResolvableStatus also takes the capitalized names the endpoint
+// first documented.
+func parseResolvableStatus(value string) (auction_usecase.AuctionStatus, bool) {
+	status, err := auction_usecase.ParseAuctionStatus(strings.ToLower(value))
+	if err != nil || status.String() != "active" && status.String() != "completed" {
+		return 0, false
+	}
+
+	return status, true
+}
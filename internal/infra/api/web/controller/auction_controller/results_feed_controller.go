@@ -0,0 +1,85 @@
+package auction_controller
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"fullcycle-auction_go/configuration/rest_err"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title       string    `xml:"title"`
+	Link        string    `xml:"link"`
+	Description string    `xml:"description"`
+	Items       []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string `xml:"title"`
+	Link        string `xml:"link"`
+	Guid        string `xml:"guid"`
+	Category    string `xml:"category"`
+	Description string `xml:"description"`
+	PubDate     string `xml:"pubDate"`
+}
+
+// FindResultsFeed serves the latest auction results as an RSS 2.0 feed,
+// optionally of a single category and its subcategories.
+func (u *AuctionController) FindResultsFeed(c *gin.Context) {
+	category := c.Query("category")
+
+	results, err := u.auctionUseCase.FindRecentResults(context.Background(), category)
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	feed := rssFeed{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:       "Auction results",
+			Link:        siteUrl(c) + c.Request.URL.RequestURI(),
+			Description: "Recently completed auctions and their winning prices",
+			Items:       make([]rssItem, 0, len(results)),
+		},
+	}
+	if category != "" {
+		feed.Channel.Title = fmt.Sprintf("Auction results: %s", category)
+	}
+
+	for _, result := range results {
+		description := "Closed without bids"
+		if result.FinalPrice != nil {
+			description = fmt.Sprintf("Sold for %.2f", *result.FinalPrice)
+		}
+
+		link := auctionPageUrl(c, result.Auction.Id)
+		feed.Channel.Items = append(feed.Channel.Items, rssItem{
+			Title:       result.Auction.ProductName,
+			Link:        link,
+			Guid:        link,
+			Category:    result.Auction.Category,
+			Description: description,
+			PubDate:     result.Auction.UpdatedAt.UTC().Format(time.RFC1123Z),
+		})
+	}
+
+	body, errMarshal := xml.MarshalIndent(feed, "", "  ")
+	if errMarshal != nil {
+		errRest := rest_err.NewInternalServerError("Error trying to render results feed")
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.Data(http.StatusOK, "application/rss+xml; charset=utf-8", append([]byte(xml.Header), body...))
+}
@@ -0,0 +1,65 @@
+package auction_controller
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/i18n"
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/infra/api/web/middleware"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strings"
+)
+
+// SearchAuctions searches the product names and descriptions, translated
+// ones in the language query param, or else in the one negotiated from
+// Accept-Language, and serves the auctions localized to it.
+func (u *AuctionController) SearchAuctions(c *gin.Context) {
+	text := strings.TrimSpace(c.Query("q"))
+	if text == "" {
+		errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+			Field:   "q",
+			Message: "Must not be empty",
+		})
+
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	language := c.Query("language")
+	if language == "" {
+		language = i18n.Negotiate(c.GetHeader("Accept-Language"))
+	}
+	if !supportedLanguage(language) {
+		errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+			Field:   "language",
+			Message: "Must be one of " + strings.Join(i18n.Languages, ", "),
+		})
+
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	auctions, err := u.auctionUseCase.SearchAuctions(
+		context.Background(), language, text, middleware.AuthenticatedUserId(c))
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	u.auctionUseCase.LocalizeAuctions(context.Background(), language, auctions)
+	c.Header("Vary", "Accept-Language")
+	c.Header("Content-Language", language)
+
+	c.JSON(http.StatusOK, auctions)
+}
+
+func supportedLanguage(language string) bool {
+	for _, supported := range i18n.Languages {
+		if language == supported {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,103 @@
+package auction_controller
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/usecase/auction_usecase"
+	"fullcycle-auction_go/internal/usecase/pagination"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+type sitemapUrlSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	Urls    []sitemapUrl `xml:"url"`
+}
+
+type sitemapUrl struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod"`
+}
+
+// FindSitemap lists the pages of the active auctions for crawlers.
+func (u *AuctionController) FindSitemap(c *gin.Context) {
+	auctions, err := u.auctionUseCase.FindAuctions(context.Background(),
+		auction_usecase.AuctionFilterDTO{}, []string{"id", "updated_at"}, "", "", pagination.Params{})
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	urlSet := sitemapUrlSet{
+		Xmlns: "http://www.sitemaps.org/schemas/sitemap/0.9",
+		Urls:  make([]sitemapUrl, 0, len(auctions.Items)),
+	}
+	for _, auction := range auctions.Items {
+		urlSet.Urls = append(urlSet.Urls, sitemapUrl{
+			Loc:     auctionPageUrl(c, auction.Id),
+			LastMod: auction.UpdatedAt.UTC().Format(time.RFC3339),
+		})
+	}
+
+	body, errMarshal := xml.MarshalIndent(urlSet, "", "  ")
+	if errMarshal != nil {
+		errRest := rest_err.NewInternalServerError("Error trying to render sitemap")
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.Data(http.StatusOK, "application/xml; charset=utf-8", append([]byte(xml.Header), body...))
+}
+
+// FindAuctionMetadata returns what a frontend needs to render the meta tags
+// of the auction page.
+func (u *AuctionController) FindAuctionMetadata(c *gin.Context) {
+	auctionId := c.Param("auctionId")
+
+	if err := uuid.Validate(auctionId); err != nil {
+		errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+			Field:   "auctionId",
+			Message: "Invalid UUID value",
+		})
+
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	metadata, err := u.auctionUseCase.FindAuctionMetadata(
+		context.Background(), auctionId, auctionPageUrl(c, auctionId))
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.JSON(http.StatusOK, metadata)
+}
+
+// siteUrl is the public address of the site, SITE_URL when set and the
+// address the request was made to otherwise.
+func siteUrl(c *gin.Context) string {
+	if url := os.Getenv("SITE_URL"); url != "" {
+		return strings.TrimSuffix(url, "/")
+	}
+
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+
+	return fmt.Sprintf("%s://%s", scheme, c.Request.Host)
+}
+
+func auctionPageUrl(c *gin.Context, auctionId string) string {
+	return fmt.Sprintf("%s/auction/%s", siteUrl(c), auctionId)
+}
@@ -0,0 +1,31 @@
+package auction_controller
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/infra/api/web/validation"
+	"fullcycle-auction_go/internal/usecase/auction_usecase"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+func (u *AuctionController) FindAuctionStatuses(c *gin.Context) {
+	var statusBatchInputDTO auction_usecase.AuctionStatusBatchInputDTO
+
+	if err := c.ShouldBindJSON(&statusBatchInputDTO); err != nil {
+		restErr := validation.ValidateErr(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	statuses, err := u.auctionUseCase.FindAuctionStatuses(context.Background(), statusBatchInputDTO)
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, statuses)
+}
@@ -0,0 +1,75 @@
+package auction_controller
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/usecase/auction_usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"strconv"
+)
+
+// storefrontMaxAge lets clients and shared caches reuse a storefront for a
+// short while, revalidating with the ETag afterwards.
+const storefrontMaxAge = "public, max-age=30"
+
+func (u *AuctionController) FindSellerStorefront(c *gin.Context) {
+	sellerId := c.Param("sellerId")
+
+	if err := uuid.Validate(sellerId); err != nil {
+		errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+			Field:   "sellerId",
+			Message: "Invalid UUID value",
+		})
+
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	page, errPage := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if errPage != nil || page < 1 {
+		errRest := rest_err.NewBadRequestError("Error trying to validate page param")
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	pageSize, errPageSize := strconv.Atoi(
+		c.DefaultQuery("page_size", strconv.Itoa(auction_usecase.DefaultStorefrontPageSize)))
+	if errPageSize != nil || pageSize < 1 || pageSize > auction_usecase.MaxStorefrontPageSize {
+		errRest := rest_err.NewBadRequestError("Error trying to validate page_size param")
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	storefront, err := u.auctionUseCase.FindSellerStorefront(
+		context.Background(), sellerId, page, pageSize)
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	body, errMarshal := json.Marshal(storefront)
+	if errMarshal != nil {
+		errRest := rest_err.NewInternalServerError("Error trying to render storefront")
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	sum := sha1.Sum(body)
+	etag := `"` + hex.EncodeToString(sum[:]) + `"`
+
+	c.Header("Cache-Control", storefrontMaxAge)
+	c.Header("ETag", etag)
+
+	if c.GetHeader("If-None-Match") == etag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json; charset=utf-8", body)
+}
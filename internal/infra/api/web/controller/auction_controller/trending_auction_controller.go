@@ -0,0 +1,82 @@
+package auction_controller
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/infra/api/web/fieldset"
+	"fullcycle-auction_go/internal/infra/api/web/middleware"
+	"fullcycle-auction_go/internal/infra/api/web/paging"
+	"fullcycle-auction_go/internal/usecase/auction_usecase"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+// FindEndingSoonAuctions lists the active auctions ending within the
+// window query param, a duration such as 30m.
+func (u *AuctionController) FindEndingSoonAuctions(c *gin.Context) {
+	var within time.Duration
+	if value := c.Query("within"); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+				Field:   "within",
+				Message: "Must be a duration such as 30m or 2h",
+			})
+
+			c.JSON(errRest.Code, errRest)
+			return
+		}
+		within = parsed
+	}
+
+	fields, errRest := fieldset.Parse(c, auction_usecase.AuctionOutputDTO{})
+	if errRest != nil {
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	page, errRest := paging.Parse(c)
+	if errRest != nil {
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	auctions, err := u.auctionUseCase.FindEndingSoonAuctions(
+		context.Background(), within, fields, middleware.AuthenticatedUserId(c), page)
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	u.localize(c, auctions.Items)
+
+	fieldset.JSONPage(c, http.StatusOK, auctions, fields)
+}
+
+func (u *AuctionController) FindTrendingAuctions(c *gin.Context) {
+	fields, errRest := fieldset.Parse(c, auction_usecase.AuctionOutputDTO{})
+	if errRest != nil {
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	page, errRest := paging.Parse(c)
+	if errRest != nil {
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	auctions, err := u.auctionUseCase.FindTrendingAuctions(
+		context.Background(), fields, middleware.AuthenticatedUserId(c), page)
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	u.localize(c, auctions.Items)
+
+	fieldset.JSONPage(c, http.StatusOK, auctions, fields)
+}
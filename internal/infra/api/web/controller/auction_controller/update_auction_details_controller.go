@@ -0,0 +1,37 @@
+package auction_controller
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/infra/api/web/middleware"
+	"fullcycle-auction_go/internal/infra/api/web/validation"
+	"fullcycle-auction_go/internal/usecase/auction_usecase"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+func (u *AuctionController) UpdateAuctionDetails(c *gin.Context) {
+	auctionId, ok := auctionIdParam(c)
+	if !ok {
+		return
+	}
+
+	var inputDTO auction_usecase.UpdateAuctionDetailsInputDTO
+	if err := c.ShouldBindJSON(&inputDTO); err != nil {
+		restErr := validation.ValidateErr(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	auctionData, err := u.auctionUseCase.UpdateAuctionDetails(
+		context.Background(), auctionId, middleware.AuthenticatedUserId(c), inputDTO)
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, auctionData)
+}
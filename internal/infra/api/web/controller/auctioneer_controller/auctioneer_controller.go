@@ -0,0 +1,127 @@
+package auctioneer_controller
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/infra/api/web/middleware"
+	"fullcycle-auction_go/internal/infra/api/web/validation"
+	"fullcycle-auction_go/internal/usecase/auctioneer_usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+type AuctioneerController struct {
+	auctioneerUseCase auctioneer_usecase.AuctioneerUseCaseInterface
+}
+
+func NewAuctioneerController(
+	auctioneerUseCase auctioneer_usecase.AuctioneerUseCaseInterface) *AuctioneerController {
+	return &AuctioneerController{
+		auctioneerUseCase: auctioneerUseCase,
+	}
+}
+
+func (u *AuctioneerController) OpenBidding(c *gin.Context) {
+	auctionId, ok := validAuctionId(c)
+	if !ok {
+		return
+	}
+
+	err := u.auctioneerUseCase.OpenBidding(
+		context.Background(), auctionId, middleware.AuthenticatedUserId(c))
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (u *AuctioneerController) Call(c *gin.Context) {
+	auctionId, ok := validAuctionId(c)
+	if !ok {
+		return
+	}
+
+	var inputDTO auctioneer_usecase.CallInputDTO
+	if err := c.ShouldBindJSON(&inputDTO); err != nil {
+		restErr := validation.ValidateErr(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	err := u.auctioneerUseCase.Call(
+		context.Background(), auctionId, middleware.AuthenticatedUserId(c), inputDTO.Call)
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (u *AuctioneerController) SetIncrement(c *gin.Context) {
+	auctionId, ok := validAuctionId(c)
+	if !ok {
+		return
+	}
+
+	var inputDTO auctioneer_usecase.IncrementInputDTO
+	if err := c.ShouldBindJSON(&inputDTO); err != nil {
+		restErr := validation.ValidateErr(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	err := u.auctioneerUseCase.SetIncrement(
+		context.Background(), auctionId, middleware.AuthenticatedUserId(c), inputDTO.MinIncrement)
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (u *AuctioneerController) Hammer(c *gin.Context) {
+	auctionId, ok := validAuctionId(c)
+	if !ok {
+		return
+	}
+
+	err := u.auctioneerUseCase.Hammer(
+		context.Background(), auctionId, middleware.AuthenticatedUserId(c))
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func validAuctionId(c *gin.Context) (string, bool) {
+	auctionId := c.Param("auctionId")
+
+	if err := uuid.Validate(auctionId); err != nil {
+		errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+			Field:   "auctionId",
+			Message: "Invalid UUID value",
+		})
+
+		c.JSON(errRest.Code, errRest)
+		return "", false
+	}
+
+	return auctionId, true
+}
@@ -3,12 +3,20 @@ package bid_controller
 import (
 	"context"
 	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/infra/api/web/middleware"
 	"fullcycle-auction_go/internal/infra/api/web/validation"
 	"fullcycle-auction_go/internal/usecase/bid_usecase"
 	"github.com/gin-gonic/gin"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 )
 
+// DeviceFingerprintHeader optionally carries a client generated device
+// fingerprint, stored with the bid for fraud detection.
+const DeviceFingerprintHeader = "X-Device-Fingerprint"
+
 type BidController struct {
 	bidUseCase bid_usecase.BidUseCaseInterface
 }
@@ -19,6 +27,11 @@ func NewBidController(bidUseCase bid_usecase.BidUseCaseInterface) *BidController
 	}
 }
 
+// CreateBid answers 202 once the bid is accepted into the batch buffer,
+// with a Location header pointing at its status and a Retry-After header
+// telling when it is expected to be written. With sync=true it waits for
+// the write, answering 201 once the bid is stored, the error when it was
+// rejected or lost meanwhile, and 202 if its batch takes too long.
 func (u *BidController) CreateBid(c *gin.Context) {
 	var bidInputDTO bid_usecase.BidInputDTO
 
@@ -29,7 +42,76 @@ func (u *BidController) CreateBid(c *gin.Context) {
 		return
 	}
 
-	err := u.bidUseCase.CreateBid(context.Background(), bidInputDTO)
+	sync, errConv := strconv.ParseBool(c.DefaultQuery("sync", "false"))
+	if errConv != nil {
+		restErr := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+			Field:   "sync",
+			Message: "Invalid boolean value",
+		})
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+	bidInputDTO.Sync = sync
+
+	bidInputDTO.UserId = middleware.AuthenticatedUserId(c)
+	bidInputDTO.ClientIp = c.ClientIP()
+	bidInputDTO.DeviceFingerprint = c.GetHeader(DeviceFingerprintHeader)
+
+	accepted, err := u.bidUseCase.CreateBid(context.Background(), bidInputDTO)
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.Header("Location", "/bid/"+accepted.AuctionId+"/status/"+accepted.Id)
+	if sync && accepted.Status == bid_usecase.BidPersisted {
+		c.JSON(http.StatusCreated, accepted)
+		return
+	}
+
+	estimate := time.Duration(accepted.EstimatedPersistenceMs) * time.Millisecond
+	c.Header("Retry-After", strconv.Itoa(int(math.Ceil(estimate.Seconds()))))
+	c.JSON(http.StatusAccepted, accepted)
+}
+
+func (u *BidController) FindBidStatus(c *gin.Context) {
+	auctionId, ok := validation.ValidUUIDParam(c, "auctionId")
+	if !ok {
+		return
+	}
+	bidId, ok := validation.ValidUUIDParam(c, "bidId")
+	if !ok {
+		return
+	}
+
+	status, err := u.bidUseCase.FindBidStatus(context.Background(), auctionId, bidId)
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// CreatePhoneBid lets staff place a bid for a bidder on the phone.
+func (u *BidController) CreatePhoneBid(c *gin.Context) {
+	var phoneBidInputDTO bid_usecase.PhoneBidInputDTO
+
+	if err := c.ShouldBindJSON(&phoneBidInputDTO); err != nil {
+		restErr := validation.ValidateErr(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	phoneBidInputDTO.StaffId = middleware.AuthenticatedUserId(c)
+
+	err := u.bidUseCase.CreatePhoneBid(context.Background(), phoneBidInputDTO)
 	if err != nil {
 		restErr := rest_err.ConvertError(err)
 
@@ -39,3 +121,24 @@ func (u *BidController) CreateBid(c *gin.Context) {
 
 	c.Status(http.StatusCreated)
 }
+
+// CreateBids answers 200 with a result per bid, since each one is accepted
+// or rejected on its own.
+func (u *BidController) CreateBids(c *gin.Context) {
+	var bulkBidInputDTO bid_usecase.BulkBidInputDTO
+
+	if err := c.ShouldBindJSON(&bulkBidInputDTO); err != nil {
+		restErr := validation.ValidateErr(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	bulkBidInputDTO.UserId = middleware.AuthenticatedUserId(c)
+	bulkBidInputDTO.ClientIp = c.ClientIP()
+	bulkBidInputDTO.DeviceFingerprint = c.GetHeader(DeviceFingerprintHeader)
+
+	results := u.bidUseCase.CreateBids(context.Background(), bulkBidInputDTO)
+
+	c.JSON(http.StatusOK, results)
+}
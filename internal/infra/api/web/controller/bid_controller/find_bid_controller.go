@@ -3,11 +3,22 @@ package bid_controller
 import (
 	"context"
 	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/infra/api/web/fieldset"
+	"fullcycle-auction_go/internal/infra/api/web/middleware"
+	"fullcycle-auction_go/internal/infra/api/web/paging"
+	"fullcycle-auction_go/internal/usecase/bid_usecase"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"net/http"
 )
 
+// The consistency a bid listing is read with. ConsistencyReadYourWrites
+// adds the bids of the authenticated caller still waiting to be written.
+const (
+	ConsistencyEventual       = "eventual"
+	ConsistencyReadYourWrites = "read_your_writes"
+)
+
 func (u *BidController) FindBidByAuctionId(c *gin.Context) {
 	auctionId := c.Param("auctionId")
 
@@ -21,12 +32,127 @@ func (u *BidController) FindBidByAuctionId(c *gin.Context) {
 		return
 	}
 
-	bidOutputList, err := u.bidUseCase.FindBidByAuctionId(context.Background(), auctionId)
+	fields, errRest := fieldset.Parse(c, bid_usecase.BidOutputDTO{})
+	if errRest != nil {
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	page, errRest := paging.Parse(c)
+	if errRest != nil {
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	var pendingUserId string
+	switch c.DefaultQuery("consistency", ConsistencyEventual) {
+	case ConsistencyEventual:
+	case ConsistencyReadYourWrites:
+		if pendingUserId = middleware.AuthenticatedUserId(c); pendingUserId == "" {
+			errRest := rest_err.NewUnauthorizedError("Reading your own writes needs an authenticated request")
+			c.JSON(errRest.Code, errRest)
+			return
+		}
+	default:
+		errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+			Field:   "consistency",
+			Message: "Must be " + ConsistencyEventual + " or " + ConsistencyReadYourWrites,
+		})
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	bids, err := u.bidUseCase.FindBidByAuctionId(
+		context.Background(), auctionId, c.Query("source"), c.Query("sort"), pendingUserId, page)
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	fieldset.JSONPage(c, http.StatusOK, bids, fields)
+}
+
+func (u *BidController) FindSuggestedBids(c *gin.Context) {
+	auctionId := c.Param("auctionId")
+
+	if err := uuid.Validate(auctionId); err != nil {
+		errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+			Field:   "auctionId",
+			Message: "Invalid UUID value",
+		})
+
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	suggestedBids, err := u.bidUseCase.FindSuggestedBids(context.Background(), auctionId)
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.JSON(http.StatusOK, suggestedBids)
+}
+
+func (u *BidController) FindBidSourceStats(c *gin.Context) {
+	auctionId := c.Query("auctionId")
+
+	if auctionId != "" {
+		if err := uuid.Validate(auctionId); err != nil {
+			errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+				Field:   "auctionId",
+				Message: "Invalid UUID value",
+			})
+
+			c.JSON(errRest.Code, errRest)
+			return
+		}
+	}
+
+	stats, err := u.bidUseCase.FindBidSourceStats(context.Background(), auctionId)
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+func (u *BidController) FindBufferStats(c *gin.Context) {
+	c.JSON(http.StatusOK, u.bidUseCase.FindBufferStats())
+}
+
+// FindRejectedBids serves the authenticated seller of the auction.
+func (u *BidController) FindRejectedBids(c *gin.Context) {
+	u.findRejectedBids(c, middleware.AuthenticatedUserId(c))
+}
+
+func (u *BidController) FindRejectedBidsAsAdmin(c *gin.Context) {
+	u.findRejectedBids(c, "")
+}
+
+func (u *BidController) findRejectedBids(c *gin.Context, sellerId string) {
+	auctionId := c.Param("auctionId")
+
+	if err := uuid.Validate(auctionId); err != nil {
+		errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+			Field:   "auctionId",
+			Message: "Invalid UUID value",
+		})
+
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	rejectedBids, err := u.bidUseCase.FindRejectedBids(context.Background(), auctionId, sellerId)
 	if err != nil {
 		errRest := rest_err.ConvertError(err)
 		c.JSON(errRest.Code, errRest)
 		return
 	}
 
-	c.JSON(http.StatusOK, bidOutputList)
+	c.JSON(http.StatusOK, rejectedBids)
 }
@@ -0,0 +1,27 @@
+package bid_controller
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/infra/api/web/middleware"
+	"fullcycle-auction_go/internal/infra/api/web/validation"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+func (u *BidController) RetractBid(c *gin.Context) {
+	bidId, ok := validation.ValidUUIDParam(c, "bidId")
+	if !ok {
+		return
+	}
+
+	err := u.bidUseCase.RetractBid(context.Background(), bidId, middleware.AuthenticatedUserId(c))
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
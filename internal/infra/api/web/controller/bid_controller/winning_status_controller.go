@@ -0,0 +1,40 @@
+package bid_controller
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/infra/api/web/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+// FindWinningStatus only shows users where they stand themselves.
+func (u *BidController) FindWinningStatus(c *gin.Context) {
+	userId := c.Param("userId")
+
+	if err := uuid.Validate(userId); err != nil {
+		errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+			Field:   "userId",
+			Message: "Invalid UUID value",
+		})
+
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	if userId != middleware.AuthenticatedUserId(c) {
+		errRest := rest_err.NewForbiddenError("Users can only see their own winning status")
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	statuses, err := u.bidUseCase.FindWinningStatus(context.Background(), userId)
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.JSON(http.StatusOK, statuses)
+}
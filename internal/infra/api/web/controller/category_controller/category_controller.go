@@ -0,0 +1,91 @@
+package category_controller
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/infra/api/web/validation"
+	"fullcycle-auction_go/internal/usecase/category_usecase"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+func (u *CategoryController) CreateCategory(c *gin.Context) {
+	var categoryInputDTO category_usecase.CategoryInputDTO
+
+	if err := c.ShouldBindJSON(&categoryInputDTO); err != nil {
+		restErr := validation.ValidateErr(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	categoryData, err := u.categoryUseCase.CreateCategory(context.Background(), categoryInputDTO)
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.JSON(http.StatusCreated, categoryData)
+}
+
+func (u *CategoryController) FindCategories(c *gin.Context) {
+	categories, err := u.categoryUseCase.FindCategories(context.Background())
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.JSON(http.StatusOK, categories)
+}
+
+func (u *CategoryController) FindCategory(c *gin.Context) {
+	category := c.Param("category")
+
+	categoryData, err := u.categoryUseCase.FindCategory(context.Background(), category)
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.JSON(http.StatusOK, categoryData)
+}
+
+func (u *CategoryController) UpdateCategory(c *gin.Context) {
+	category := c.Param("category")
+
+	var categoryInputDTO category_usecase.UpdateCategoryInputDTO
+
+	if err := c.ShouldBindJSON(&categoryInputDTO); err != nil {
+		restErr := validation.ValidateErr(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	categoryData, err := u.categoryUseCase.UpdateCategory(context.Background(), category, categoryInputDTO)
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, categoryData)
+}
+
+func (u *CategoryController) DeleteCategory(c *gin.Context) {
+	category := c.Param("category")
+
+	if err := u.categoryUseCase.DeleteCategory(context.Background(), category); err != nil {
+		restErr := rest_err.ConvertError(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,56 @@
+package category_controller
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/infra/api/web/validation"
+	"fullcycle-auction_go/internal/usecase/category_usecase"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type CategoryController struct {
+	categoryUseCase category_usecase.CategoryUseCaseInterface
+}
+
+func NewCategoryController(categoryUseCase category_usecase.CategoryUseCaseInterface) *CategoryController {
+	return &CategoryController{
+		categoryUseCase: categoryUseCase,
+	}
+}
+
+func (u *CategoryController) UpsertCategorySchema(c *gin.Context) {
+	category := c.Param("category")
+
+	var schemaInputDTO category_usecase.CategorySchemaInputDTO
+
+	if err := c.ShouldBindJSON(&schemaInputDTO); err != nil {
+		restErr := validation.ValidateErr(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	schemaData, err := u.categoryUseCase.UpsertCategorySchema(context.Background(), category, schemaInputDTO)
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, schemaData)
+}
+
+func (u *CategoryController) FindCategorySchema(c *gin.Context) {
+	category := c.Param("category")
+
+	schemaData, err := u.categoryUseCase.FindCategorySchema(context.Background(), category)
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.JSON(http.StatusOK, schemaData)
+}
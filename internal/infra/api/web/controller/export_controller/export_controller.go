@@ -0,0 +1,189 @@
+package export_controller
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/internal_error"
+	"fullcycle-auction_go/internal/usecase/auction_usecase"
+	"fullcycle-auction_go/internal/usecase/bid_usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// flushEvery is how many records are written between flushes of the
+// response, so clients start receiving data before the export ends.
+const flushEvery = 100
+
+type ExportController struct {
+	auctionUseCase auction_usecase.AuctionUseCaseInterface
+	bidUseCase     bid_usecase.BidUseCaseInterface
+}
+
+func NewExportController(
+	auctionUseCase auction_usecase.AuctionUseCaseInterface,
+	bidUseCase bid_usecase.BidUseCaseInterface) *ExportController {
+	return &ExportController{
+		auctionUseCase: auctionUseCase,
+		bidUseCase:     bidUseCase,
+	}
+}
+
+func (u *ExportController) ExportAuctions(c *gin.Context) {
+	status, errConv := auction_usecase.ParseAuctionStatus(c.DefaultQuery("status", "active"))
+	if errConv != nil {
+		errRest := rest_err.NewBadRequestError("Error trying to validate auction status param", rest_err.Causes{
+			Field:   "status",
+			Message: errConv.Error(),
+		})
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	writer, errRest := newExportWriter(c, "auctions", []string{
+		"id", "product_id", "product_name", "category", "description",
+		"condition", "grade", "status", "timestamp"})
+	if errRest != nil {
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	err := u.auctionUseCase.ExportAuctions(c.Request.Context(),
+		status, c.Query("category"),
+		func(auction auction_usecase.AuctionOutputDTO) error {
+			return writer.write(auction, []string{
+				auction.Id,
+				auction.ProductId,
+				auction.ProductName,
+				auction.Category,
+				auction.Description,
+				auction.Condition.String(),
+				auction.Grading.Grade,
+				auction.Status.String(),
+				auction.Timestamp.Format(time.RFC3339),
+			})
+		})
+	writer.close(err)
+}
+
+func (u *ExportController) ExportBids(c *gin.Context) {
+	auctionId := c.Query("auctionId")
+
+	if auctionId != "" {
+		if err := uuid.Validate(auctionId); err != nil {
+			errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+				Field:   "auctionId",
+				Message: "Invalid UUID value",
+			})
+
+			c.JSON(errRest.Code, errRest)
+			return
+		}
+	}
+
+	writer, errRest := newExportWriter(c, "bids", []string{
+		"id", "user_id", "auction_id", "amount", "source", "timestamp", "placed_by"})
+	if errRest != nil {
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	err := u.bidUseCase.ExportBids(c.Request.Context(), auctionId,
+		func(bid bid_usecase.BidOutputDTO) error {
+			return writer.write(bid, []string{
+				bid.Id,
+				bid.UserId,
+				bid.AuctionId,
+				strconv.FormatFloat(bid.Amount, 'f', 2, 64),
+				bid.Source,
+				bid.Timestamp.Format(time.RFC3339),
+				bid.PlacedBy,
+			})
+		})
+	writer.close(err)
+}
+
+// exportWriter writes each record either as a NDJSON line or as a CSV row,
+// depending on the format query param, flushing the response periodically.
+type exportWriter struct {
+	c       *gin.Context
+	json    *json.Encoder
+	csv     *csv.Writer
+	written int
+}
+
+func newExportWriter(c *gin.Context, name string, header []string) (*exportWriter, *rest_err.RestErr) {
+	writer := &exportWriter{c: c}
+
+	switch c.DefaultQuery("format", "ndjson") {
+	case "ndjson":
+		c.Header("Content-Type", "application/x-ndjson")
+		c.Header("Content-Disposition", "attachment; filename="+name+".ndjson")
+		writer.json = json.NewEncoder(c.Writer)
+	case "csv":
+		c.Header("Content-Type", "text/csv")
+		c.Header("Content-Disposition", "attachment; filename="+name+".csv")
+		writer.csv = csv.NewWriter(c.Writer)
+		if err := writer.csv.Write(header); err != nil {
+			return nil, rest_err.NewInternalServerError("Error trying to write export")
+		}
+	default:
+		return nil, rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+			Field:   "format",
+			Message: "format must be ndjson or csv",
+		})
+	}
+
+	c.Status(http.StatusOK)
+	return writer, nil
+}
+
+func (w *exportWriter) write(value interface{}, record []string) error {
+	if err := w.c.Request.Context().Err(); err != nil {
+		return err
+	}
+
+	var err error
+	if w.csv != nil {
+		err = w.csv.Write(record)
+	} else {
+		err = w.json.Encode(value)
+	}
+	if err != nil {
+		return err
+	}
+
+	w.written++
+	if w.written%flushEvery == 0 {
+		w.flush()
+	}
+
+	return nil
+}
+
+func (w *exportWriter) flush() {
+	if w.csv != nil {
+		w.csv.Flush()
+	}
+	w.c.Writer.Flush()
+}
+
+// close flushes what is left. Once the first bytes are sent the status code
+// can no longer change, so a failure halfway is only logged.
+func (w *exportWriter) close(err *internal_error.InternalError) {
+	if err != nil && !w.c.Writer.Written() {
+		errRest := rest_err.ConvertError(err)
+		w.c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	w.flush()
+
+	if err != nil {
+		logger.Error("Error trying to stream export", err)
+	}
+}
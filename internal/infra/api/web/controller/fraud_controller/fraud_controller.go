@@ -0,0 +1,45 @@
+package fraud_controller
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/usecase/fraud_usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+type FraudController struct {
+	fraudUseCase fraud_usecase.FraudUseCaseInterface
+}
+
+func NewFraudController(fraudUseCase fraud_usecase.FraudUseCaseInterface) *FraudController {
+	return &FraudController{
+		fraudUseCase: fraudUseCase,
+	}
+}
+
+func (u *FraudController) FindFraudFlags(c *gin.Context) {
+	auctionId := c.Query("auctionId")
+
+	if auctionId != "" {
+		if err := uuid.Validate(auctionId); err != nil {
+			errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+				Field:   "auctionId",
+				Message: "Invalid UUID value",
+			})
+
+			c.JSON(errRest.Code, errRest)
+			return
+		}
+	}
+
+	flags, err := u.fraudUseCase.FindFraudFlags(context.Background(), auctionId)
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.JSON(http.StatusOK, flags)
+}
@@ -0,0 +1,91 @@
+package job_controller
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/usecase/job_usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"strconv"
+)
+
+type JobController struct {
+	jobUseCase job_usecase.JobUseCaseInterface
+}
+
+func NewJobController(jobUseCase job_usecase.JobUseCaseInterface) *JobController {
+	return &JobController{
+		jobUseCase: jobUseCase,
+	}
+}
+
+func (u *JobController) FindJobs(c *gin.Context) {
+	limit, errConv := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(job_usecase.DefaultJobsLimit)))
+	if errConv != nil {
+		errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+			Field:   "limit",
+			Message: "Invalid integer value",
+		})
+
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	jobs, err := u.jobUseCase.FindJobs(context.Background(), c.Query("status"), c.Query("kind"), limit)
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.JSON(http.StatusOK, jobs)
+}
+
+func (u *JobController) FindJobById(c *gin.Context) {
+	jobId, ok := validJobId(c)
+	if !ok {
+		return
+	}
+
+	jobData, err := u.jobUseCase.FindJobById(context.Background(), jobId)
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.JSON(http.StatusOK, jobData)
+}
+
+func (u *JobController) RetryJob(c *gin.Context) {
+	jobId, ok := validJobId(c)
+	if !ok {
+		return
+	}
+
+	jobData, err := u.jobUseCase.RetryJob(context.Background(), jobId)
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.JSON(http.StatusOK, jobData)
+}
+
+func validJobId(c *gin.Context) (string, bool) {
+	jobId := c.Param("jobId")
+
+	if err := uuid.Validate(jobId); err != nil {
+		errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+			Field:   "jobId",
+			Message: "Invalid UUID value",
+		})
+
+		c.JSON(errRest.Code, errRest)
+		return "", false
+	}
+
+	return jobId, true
+}
@@ -0,0 +1,137 @@
+package live_controller
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/entity/event_entity"
+	"fullcycle-auction_go/internal/usecase/live_usecase"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	writeWait  = 10 * time.Second
+	pongWait   = 60 * time.Second
+	pingPeriod = pongWait * 9 / 10
+)
+
+// The feed is public and read only, so browsers may open it from any origin.
+var upgrader = websocket.Upgrader{
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// closingEvents end the feed, closing the connection with their reason.
+var closingEvents = map[event_entity.Type]string{
+	event_entity.AuctionCompleted: "auction completed",
+	event_entity.AuctionCancelled: "auction cancelled",
+}
+
+type LiveController struct {
+	liveUseCase live_usecase.LiveUseCaseInterface
+}
+
+func NewLiveController(liveUseCase live_usecase.LiveUseCaseInterface) *LiveController {
+	return &LiveController{
+		liveUseCase: liveUseCase,
+	}
+}
+
+// FollowAuction upgrades the request to a WebSocket that gets a snapshot of
+// the auction, then its bids, highest bid and completion as they happen.
+// The connection is closed once the auction completes.
+func (u *LiveController) FollowAuction(c *gin.Context) {
+	auctionId := c.Param("auctionId")
+
+	if err := uuid.Validate(auctionId); err != nil {
+		errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+			Field:   "auctionId",
+			Message: "Invalid UUID value",
+		})
+
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	feed, err := u.liveUseCase.FollowAuction(context.Background(), auctionId)
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+	defer feed.Stop()
+
+	conn, errUpgrade := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if errUpgrade != nil {
+		// The upgrader already replied with the error.
+		return
+	}
+	defer conn.Close()
+
+	closed := make(chan struct{})
+	go readUntilClosed(conn, closed)
+
+	if !writeMessage(conn, feed.Snapshot) {
+		return
+	}
+
+	ticker := time.NewTicker(pingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-closed:
+			return
+		case <-ticker.C:
+			conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		case event, ok := <-feed.Events:
+			if !ok {
+				return
+			}
+
+			for _, message := range feed.Translate(event) {
+				if !writeMessage(conn, message) {
+					return
+				}
+			}
+
+			if reason, ok := closingEvents[event.Type]; ok {
+				conn.SetWriteDeadline(time.Now().Add(writeWait))
+				conn.WriteMessage(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseNormalClosure, reason))
+				return
+			}
+		}
+	}
+}
+
+// readUntilClosed discards what the client sends, keeping the pongs coming,
+// and closes closed once the connection is gone.
+func readUntilClosed(conn *websocket.Conn, closed chan<- struct{}) {
+	defer close(closed)
+
+	conn.SetReadLimit(512)
+	conn.SetReadDeadline(time.Now().Add(pongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
+
+// writeMessage reports whether the message was written; a client that went
+// away is not an error.
+func writeMessage(conn *websocket.Conn, message live_usecase.MessageDTO) bool {
+	conn.SetWriteDeadline(time.Now().Add(writeWait))
+	return conn.WriteJSON(message) == nil
+}
@@ -0,0 +1,100 @@
+package notification_controller
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/infra/api/web/middleware"
+	"fullcycle-auction_go/internal/infra/api/web/validation"
+	"fullcycle-auction_go/internal/usecase/notification_usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"strconv"
+)
+
+type NotificationController struct {
+	notificationUseCase notification_usecase.NotificationUseCaseInterface
+}
+
+func NewNotificationController(
+	notificationUseCase notification_usecase.NotificationUseCaseInterface) *NotificationController {
+	return &NotificationController{
+		notificationUseCase: notificationUseCase,
+	}
+}
+
+func (u *NotificationController) FindNotifications(c *gin.Context) {
+	userId, ok := inboxOwner(c)
+	if !ok {
+		return
+	}
+
+	archived, errConv := strconv.ParseBool(c.DefaultQuery("archived", "false"))
+	if errConv != nil {
+		errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+			Field:   "archived",
+			Message: "archived must be a boolean",
+		})
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	notifications, err := u.notificationUseCase.FindNotifications(context.Background(), userId, archived)
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.JSON(http.StatusOK, notifications)
+}
+
+func (u *NotificationController) MarkNotificationsRead(c *gin.Context) {
+	userId, ok := inboxOwner(c)
+	if !ok {
+		return
+	}
+
+	var markInputDTO notification_usecase.MarkNotificationsInputDTO
+
+	if err := c.ShouldBindJSON(&markInputDTO); err != nil {
+		restErr := validation.ValidateErr(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	marked, err := u.notificationUseCase.MarkNotificationsRead(context.Background(), userId, markInputDTO)
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, marked)
+}
+
+// inboxOwner returns the user of the inbox, writing the error response
+// unless it is the authenticated user: inboxes are private.
+func inboxOwner(c *gin.Context) (string, bool) {
+	userId := c.Param("userId")
+
+	if err := uuid.Validate(userId); err != nil {
+		errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+			Field:   "userId",
+			Message: "Invalid UUID value",
+		})
+
+		c.JSON(errRest.Code, errRest)
+		return "", false
+	}
+
+	if userId != middleware.AuthenticatedUserId(c) {
+		errRest := rest_err.NewForbiddenError("Users can only see their own notifications")
+		c.JSON(errRest.Code, errRest)
+		return "", false
+	}
+
+	return userId, true
+}
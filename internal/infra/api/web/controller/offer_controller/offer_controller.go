@@ -0,0 +1,139 @@
+package offer_controller
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/infra/api/web/middleware"
+	"fullcycle-auction_go/internal/infra/api/web/validation"
+	"fullcycle-auction_go/internal/internal_error"
+	"fullcycle-auction_go/internal/usecase/offer_usecase"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type OfferController struct {
+	offerUseCase offer_usecase.OfferUseCaseInterface
+}
+
+func NewOfferController(offerUseCase offer_usecase.OfferUseCaseInterface) *OfferController {
+	return &OfferController{
+		offerUseCase: offerUseCase,
+	}
+}
+
+func (u *OfferController) CreateOffers(c *gin.Context) {
+	auctionId, ok := validation.ValidUUIDParam(c, "auctionId")
+	if !ok {
+		return
+	}
+
+	var offerInputDTO offer_usecase.OfferInputDTO
+
+	if err := c.ShouldBindJSON(&offerInputDTO); err != nil {
+		restErr := validation.ValidateErr(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	offers, err := u.offerUseCase.CreateOffers(
+		context.Background(), auctionId, middleware.AuthenticatedUserId(c), offerInputDTO)
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.JSON(http.StatusCreated, offers)
+}
+
+func (u *OfferController) OfferRunnerUp(c *gin.Context) {
+	auctionId, ok := validation.ValidUUIDParam(c, "auctionId")
+	if !ok {
+		return
+	}
+
+	// Every field of the payload is optional, so it may be left out.
+	var runnerUpInputDTO offer_usecase.RunnerUpOfferInputDTO
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&runnerUpInputDTO); err != nil {
+			restErr := validation.ValidateErr(err)
+
+			c.JSON(restErr.Code, restErr)
+			return
+		}
+	}
+
+	offer, err := u.offerUseCase.OfferRunnerUp(
+		context.Background(), auctionId, middleware.AuthenticatedUserId(c), runnerUpInputDTO)
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.JSON(http.StatusCreated, offer)
+}
+
+func (u *OfferController) FindBidderRanking(c *gin.Context) {
+	auctionId, ok := validation.ValidUUIDParam(c, "auctionId")
+	if !ok {
+		return
+	}
+
+	ranking, err := u.offerUseCase.FindBidderRanking(
+		context.Background(), auctionId, middleware.AuthenticatedUserId(c))
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.JSON(http.StatusOK, ranking)
+}
+
+func (u *OfferController) FindOffers(c *gin.Context) {
+	auctionId, ok := validation.ValidUUIDParam(c, "auctionId")
+	if !ok {
+		return
+	}
+
+	offers, err := u.offerUseCase.FindOffers(
+		context.Background(), auctionId, middleware.AuthenticatedUserId(c))
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.JSON(http.StatusOK, offers)
+}
+
+func (u *OfferController) AcceptOffer(c *gin.Context) {
+	u.respond(c, u.offerUseCase.AcceptOffer)
+}
+
+func (u *OfferController) DeclineOffer(c *gin.Context) {
+	u.respond(c, u.offerUseCase.DeclineOffer)
+}
+
+func (u *OfferController) respond(
+	c *gin.Context,
+	respond func(context.Context, string, string) (*offer_usecase.OfferOutputDTO, *internal_error.InternalError)) {
+	offerId, ok := validation.ValidUUIDParam(c, "offerId")
+	if !ok {
+		return
+	}
+
+	offerData, err := respond(context.Background(), offerId, middleware.AuthenticatedUserId(c))
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, offerData)
+}
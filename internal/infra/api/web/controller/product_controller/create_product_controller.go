@@ -0,0 +1,41 @@
+package product_controller
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/infra/api/web/validation"
+	"fullcycle-auction_go/internal/usecase/product_usecase"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type ProductController struct {
+	productUseCase product_usecase.ProductUseCaseInterface
+}
+
+func NewProductController(productUseCase product_usecase.ProductUseCaseInterface) *ProductController {
+	return &ProductController{
+		productUseCase: productUseCase,
+	}
+}
+
+func (u *ProductController) CreateProduct(c *gin.Context) {
+	var productInputDTO product_usecase.ProductInputDTO
+
+	if err := c.ShouldBindJSON(&productInputDTO); err != nil {
+		restErr := validation.ValidateErr(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	productData, err := u.productUseCase.CreateProduct(context.Background(), productInputDTO)
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.JSON(http.StatusCreated, productData)
+}
@@ -0,0 +1,46 @@
+package product_controller
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/rest_err"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+func (u *ProductController) FindProductById(c *gin.Context) {
+	productId := c.Param("productId")
+
+	if err := uuid.Validate(productId); err != nil {
+		errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+			Field:   "productId",
+			Message: "Invalid UUID value",
+		})
+
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	productData, err := u.productUseCase.FindProductById(context.Background(), productId)
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.JSON(http.StatusOK, productData)
+}
+
+func (u *ProductController) FindProducts(c *gin.Context) {
+	category := c.Query("category")
+	sku := c.Query("sku")
+
+	products, err := u.productUseCase.FindProducts(context.Background(), category, sku)
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.JSON(http.StatusOK, products)
+}
@@ -0,0 +1,83 @@
+package proxy_controller
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/infra/api/web/middleware"
+	"fullcycle-auction_go/internal/infra/api/web/validation"
+	"fullcycle-auction_go/internal/usecase/proxy_usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+type ProxyController struct {
+	proxyUseCase proxy_usecase.ProxyUseCaseInterface
+}
+
+func NewProxyController(
+	proxyUseCase proxy_usecase.ProxyUseCaseInterface) *ProxyController {
+	return &ProxyController{
+		proxyUseCase: proxyUseCase,
+	}
+}
+
+func (u *ProxyController) SetProxyBid(c *gin.Context) {
+	auctionId, ok := validAuctionId(c)
+	if !ok {
+		return
+	}
+
+	var inputDTO proxy_usecase.ProxyBidInputDTO
+	if err := c.ShouldBindJSON(&inputDTO); err != nil {
+		restErr := validation.ValidateErr(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	proxyBid, err := u.proxyUseCase.SetProxyBid(
+		context.Background(), auctionId, middleware.AuthenticatedUserId(c), inputDTO)
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.JSON(http.StatusCreated, proxyBid)
+}
+
+func (u *ProxyController) FindProxyBid(c *gin.Context) {
+	auctionId, ok := validAuctionId(c)
+	if !ok {
+		return
+	}
+
+	proxyBid, err := u.proxyUseCase.FindProxyBid(
+		context.Background(), auctionId, middleware.AuthenticatedUserId(c))
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, proxyBid)
+}
+
+func validAuctionId(c *gin.Context) (string, bool) {
+	auctionId := c.Param("auctionId")
+
+	if err := uuid.Validate(auctionId); err != nil {
+		errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+			Field:   "auctionId",
+			Message: "Invalid UUID value",
+		})
+
+		c.JSON(errRest.Code, errRest)
+		return "", false
+	}
+
+	return auctionId, true
+}
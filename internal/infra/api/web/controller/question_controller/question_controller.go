@@ -0,0 +1,122 @@
+package question_controller
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/infra/api/web/middleware"
+	"fullcycle-auction_go/internal/infra/api/web/validation"
+	"fullcycle-auction_go/internal/usecase/question_usecase"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type QuestionController struct {
+	questionUseCase question_usecase.QuestionUseCaseInterface
+}
+
+func NewQuestionController(questionUseCase question_usecase.QuestionUseCaseInterface) *QuestionController {
+	return &QuestionController{
+		questionUseCase: questionUseCase,
+	}
+}
+
+func (u *QuestionController) PostQuestion(c *gin.Context) {
+	auctionId, ok := validation.ValidUUIDParam(c, "auctionId")
+	if !ok {
+		return
+	}
+
+	var questionInputDTO question_usecase.QuestionInputDTO
+
+	if err := c.ShouldBindJSON(&questionInputDTO); err != nil {
+		restErr := validation.ValidateErr(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	questionData, err := u.questionUseCase.PostQuestion(
+		context.Background(), auctionId, middleware.AuthenticatedUserId(c), questionInputDTO)
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.JSON(http.StatusCreated, questionData)
+}
+
+func (u *QuestionController) FindQuestions(c *gin.Context) {
+	auctionId, ok := validation.ValidUUIDParam(c, "auctionId")
+	if !ok {
+		return
+	}
+
+	questions, err := u.questionUseCase.FindQuestions(context.Background(), auctionId)
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.JSON(http.StatusOK, questions)
+}
+
+func (u *QuestionController) AnswerQuestion(c *gin.Context) {
+	auctionId, ok := validation.ValidUUIDParam(c, "auctionId")
+	if !ok {
+		return
+	}
+
+	questionId, ok := validation.ValidUUIDParam(c, "questionId")
+	if !ok {
+		return
+	}
+
+	var answerInputDTO question_usecase.AnswerInputDTO
+
+	if err := c.ShouldBindJSON(&answerInputDTO); err != nil {
+		restErr := validation.ValidateErr(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	questionData, err := u.questionUseCase.AnswerQuestion(
+		context.Background(), auctionId, questionId, middleware.AuthenticatedUserId(c), answerInputDTO)
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, questionData)
+}
+
+func (u *QuestionController) ModerateQuestion(c *gin.Context) {
+	questionId, ok := validation.ValidUUIDParam(c, "questionId")
+	if !ok {
+		return
+	}
+
+	var moderationInputDTO question_usecase.ModerationInputDTO
+
+	if err := c.ShouldBindJSON(&moderationInputDTO); err != nil {
+		restErr := validation.ValidateErr(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	questionData, err := u.questionUseCase.ModerateQuestion(context.Background(), questionId, moderationInputDTO)
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, questionData)
+}
@@ -0,0 +1,120 @@
+package recurring_auction_controller
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/infra/api/web/middleware"
+	"fullcycle-auction_go/internal/infra/api/web/validation"
+	"fullcycle-auction_go/internal/usecase/auction_usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+type RecurringAuctionController struct {
+	recurringAuctionUseCase auction_usecase.RecurringAuctionUseCaseInterface
+}
+
+func NewRecurringAuctionController(
+	recurringAuctionUseCase auction_usecase.RecurringAuctionUseCaseInterface) *RecurringAuctionController {
+	return &RecurringAuctionController{
+		recurringAuctionUseCase: recurringAuctionUseCase,
+	}
+}
+
+func (u *RecurringAuctionController) CreateRecurringAuction(c *gin.Context) {
+	var recurringAuctionInputDTO auction_usecase.RecurringAuctionInputDTO
+
+	if err := c.ShouldBindJSON(&recurringAuctionInputDTO); err != nil {
+		restErr := validation.ValidateErr(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	recurringAuctionData, err := u.recurringAuctionUseCase.CreateRecurringAuction(
+		context.Background(), middleware.AuthenticatedUserId(c), recurringAuctionInputDTO)
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.JSON(http.StatusCreated, recurringAuctionData)
+}
+
+// FindRecurringAuctions lists the recurring auctions of the authenticated
+// seller.
+func (u *RecurringAuctionController) FindRecurringAuctions(c *gin.Context) {
+	recurringAuctions, err := u.recurringAuctionUseCase.FindRecurringAuctions(
+		context.Background(), middleware.AuthenticatedUserId(c))
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.JSON(http.StatusOK, recurringAuctions)
+}
+
+func (u *RecurringAuctionController) FindRecurringAuctionById(c *gin.Context) {
+	recurringAuctionId, ok := validRecurringAuctionId(c)
+	if !ok {
+		return
+	}
+
+	recurringAuctionData, err := u.recurringAuctionUseCase.FindRecurringAuctionById(
+		context.Background(), recurringAuctionId)
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.JSON(http.StatusOK, recurringAuctionData)
+}
+
+// UpdateRecurringAuction pauses or resumes the series.
+func (u *RecurringAuctionController) UpdateRecurringAuction(c *gin.Context) {
+	recurringAuctionId, ok := validRecurringAuctionId(c)
+	if !ok {
+		return
+	}
+
+	var activeInputDTO auction_usecase.RecurringAuctionActiveInputDTO
+
+	if err := c.ShouldBindJSON(&activeInputDTO); err != nil {
+		restErr := validation.ValidateErr(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	recurringAuctionData, err := u.recurringAuctionUseCase.UpdateRecurringAuctionActive(
+		context.Background(), recurringAuctionId, middleware.AuthenticatedUserId(c), activeInputDTO)
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, recurringAuctionData)
+}
+
+func validRecurringAuctionId(c *gin.Context) (string, bool) {
+	recurringAuctionId := c.Param("recurringAuctionId")
+
+	if err := uuid.Validate(recurringAuctionId); err != nil {
+		errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+			Field:   "recurringAuctionId",
+			Message: "Invalid UUID value",
+		})
+
+		c.JSON(errRest.Code, errRest)
+		return "", false
+	}
+
+	return recurringAuctionId, true
+}
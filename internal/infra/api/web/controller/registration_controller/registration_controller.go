@@ -0,0 +1,114 @@
+package registration_controller
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/infra/api/web/middleware"
+	"fullcycle-auction_go/internal/infra/api/web/validation"
+	"fullcycle-auction_go/internal/usecase/registration_usecase"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type RegistrationController struct {
+	registrationUseCase registration_usecase.RegistrationUseCaseInterface
+}
+
+func NewRegistrationController(
+	registrationUseCase registration_usecase.RegistrationUseCaseInterface) *RegistrationController {
+	return &RegistrationController{
+		registrationUseCase: registrationUseCase,
+	}
+}
+
+func (u *RegistrationController) Register(c *gin.Context) {
+	auctionId, ok := validation.ValidUUIDParam(c, "auctionId")
+	if !ok {
+		return
+	}
+
+	var inputDTO registration_usecase.RegistrationInputDTO
+	if err := c.ShouldBindJSON(&inputDTO); err != nil {
+		restErr := validation.ValidateErr(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	registration, err := u.registrationUseCase.Register(
+		context.Background(), auctionId, middleware.AuthenticatedUserId(c), inputDTO)
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.JSON(http.StatusCreated, registration)
+}
+
+func (u *RegistrationController) FindRegistration(c *gin.Context) {
+	auctionId, ok := validation.ValidUUIDParam(c, "auctionId")
+	if !ok {
+		return
+	}
+
+	registration, err := u.registrationUseCase.FindRegistration(
+		context.Background(), auctionId, middleware.AuthenticatedUserId(c))
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, registration)
+}
+
+func (u *RegistrationController) FindRegistrations(c *gin.Context) {
+	auctionId, ok := validation.ValidUUIDParam(c, "auctionId")
+	if !ok {
+		return
+	}
+
+	registrations, err := u.registrationUseCase.FindRegistrations(
+		context.Background(), auctionId, middleware.AuthenticatedUserId(c))
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, registrations)
+}
+
+func (u *RegistrationController) DecideRegistration(c *gin.Context) {
+	auctionId, ok := validation.ValidUUIDParam(c, "auctionId")
+	if !ok {
+		return
+	}
+	userId, ok := validation.ValidUUIDParam(c, "userId")
+	if !ok {
+		return
+	}
+
+	var inputDTO registration_usecase.DecisionInputDTO
+	if err := c.ShouldBindJSON(&inputDTO); err != nil {
+		restErr := validation.ValidateErr(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	registration, err := u.registrationUseCase.DecideRegistration(
+		context.Background(), auctionId, userId, middleware.AuthenticatedUserId(c), inputDTO)
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, registration)
+}
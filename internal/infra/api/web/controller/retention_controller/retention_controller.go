@@ -0,0 +1,41 @@
+package retention_controller
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/usecase/retention_usecase"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type RetentionController struct {
+	retentionUseCase retention_usecase.RetentionUseCaseInterface
+}
+
+func NewRetentionController(
+	retentionUseCase retention_usecase.RetentionUseCaseInterface) *RetentionController {
+	return &RetentionController{
+		retentionUseCase: retentionUseCase,
+	}
+}
+
+// FindRetentionReport tells what the retention rules would change now,
+// changing nothing.
+func (u *RetentionController) FindRetentionReport(c *gin.Context) {
+	u.runRetention(c, true)
+}
+
+func (u *RetentionController) RunRetention(c *gin.Context) {
+	u.runRetention(c, false)
+}
+
+func (u *RetentionController) runRetention(c *gin.Context, dryRun bool) {
+	report, err := u.retentionUseCase.RunRetention(context.Background(), dryRun)
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
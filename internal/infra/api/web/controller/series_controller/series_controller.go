@@ -0,0 +1,147 @@
+package series_controller
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/infra/api/web/middleware"
+	"fullcycle-auction_go/internal/infra/api/web/validation"
+	"fullcycle-auction_go/internal/usecase/auction_usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+type SeriesController struct {
+	seriesUseCase auction_usecase.SeriesUseCaseInterface
+}
+
+func NewSeriesController(seriesUseCase auction_usecase.SeriesUseCaseInterface) *SeriesController {
+	return &SeriesController{
+		seriesUseCase: seriesUseCase,
+	}
+}
+
+func (u *SeriesController) CreateSeries(c *gin.Context) {
+	var seriesInputDTO auction_usecase.SeriesInputDTO
+
+	if err := c.ShouldBindJSON(&seriesInputDTO); err != nil {
+		restErr := validation.ValidateErr(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	seriesData, err := u.seriesUseCase.CreateSeries(
+		context.Background(), middleware.AuthenticatedUserId(c), seriesInputDTO)
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.JSON(http.StatusCreated, seriesData)
+}
+
+// FindSeries lists every series, or only those of the seller_id query
+// parameter.
+func (u *SeriesController) FindSeries(c *gin.Context) {
+	sellerId := c.Query("seller_id")
+
+	if sellerId != "" {
+		if err := uuid.Validate(sellerId); err != nil {
+			errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+				Field:   "seller_id",
+				Message: "Invalid UUID value",
+			})
+
+			c.JSON(errRest.Code, errRest)
+			return
+		}
+	}
+
+	series, err := u.seriesUseCase.FindSeries(context.Background(), sellerId)
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.JSON(http.StatusOK, series)
+}
+
+func (u *SeriesController) FindSeriesById(c *gin.Context) {
+	seriesId, ok := validSeriesId(c)
+	if !ok {
+		return
+	}
+
+	seriesData, err := u.seriesUseCase.FindSeriesById(context.Background(), seriesId)
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.JSON(http.StatusOK, seriesData)
+}
+
+func (u *SeriesController) FindSeriesAuctions(c *gin.Context) {
+	seriesId, ok := validSeriesId(c)
+	if !ok {
+		return
+	}
+
+	auctions, err := u.seriesUseCase.FindSeriesAuctions(context.Background(), seriesId)
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.JSON(http.StatusOK, auctions)
+}
+
+// ScheduleSeries staggers the ends of the active lots of the series.
+func (u *SeriesController) ScheduleSeries(c *gin.Context) {
+	seriesId, ok := validSeriesId(c)
+	if !ok {
+		return
+	}
+
+	var scheduleInputDTO auction_usecase.SeriesScheduleInputDTO
+
+	if err := c.ShouldBindJSON(&scheduleInputDTO); err != nil {
+		restErr := validation.ValidateErr(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	schedule, err := u.seriesUseCase.ScheduleSeries(
+		context.Background(), seriesId, middleware.AuthenticatedUserId(c), scheduleInputDTO)
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, schedule)
+}
+
+func validSeriesId(c *gin.Context) (string, bool) {
+	seriesId := c.Param("seriesId")
+
+	if err := uuid.Validate(seriesId); err != nil {
+		errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+			Field:   "seriesId",
+			Message: "Invalid UUID value",
+		})
+
+		c.JSON(errRest.Code, errRest)
+		return "", false
+	}
+
+	return seriesId, true
+}
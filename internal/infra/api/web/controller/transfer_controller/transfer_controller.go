@@ -0,0 +1,79 @@
+package transfer_controller
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/infra/api/web/validation"
+	"fullcycle-auction_go/internal/usecase/transfer_usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+type TransferController struct {
+	transferUseCase transfer_usecase.TransferUseCaseInterface
+}
+
+func NewTransferController(transferUseCase transfer_usecase.TransferUseCaseInterface) *TransferController {
+	return &TransferController{
+		transferUseCase: transferUseCase,
+	}
+}
+
+func (u *TransferController) TransferAuction(c *gin.Context) {
+	auctionId, ok := validAuctionId(c)
+	if !ok {
+		return
+	}
+
+	var transferInputDTO transfer_usecase.TransferInputDTO
+
+	if err := c.ShouldBindJSON(&transferInputDTO); err != nil {
+		restErr := validation.ValidateErr(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	transferData, err := u.transferUseCase.TransferAuction(context.Background(), auctionId, transferInputDTO)
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.JSON(http.StatusCreated, transferData)
+}
+
+func (u *TransferController) FindTransfers(c *gin.Context) {
+	auctionId, ok := validAuctionId(c)
+	if !ok {
+		return
+	}
+
+	transfers, err := u.transferUseCase.FindTransfers(context.Background(), auctionId)
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.JSON(http.StatusOK, transfers)
+}
+
+func validAuctionId(c *gin.Context) (string, bool) {
+	auctionId := c.Param("auctionId")
+
+	if err := uuid.Validate(auctionId); err != nil {
+		errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+			Field:   "auctionId",
+			Message: "Invalid UUID value",
+		})
+
+		c.JSON(errRest.Code, errRest)
+		return "", false
+	}
+
+	return auctionId, true
+}
@@ -0,0 +1,52 @@
+package user_controller
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/infra/api/web/validation"
+	"fullcycle-auction_go/internal/usecase/user_usecase"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+func (u *UserController) RegisterUser(c *gin.Context) {
+	var registerInputDTO user_usecase.RegisterInputDTO
+
+	if err := c.ShouldBindJSON(&registerInputDTO); err != nil {
+		restErr := validation.ValidateErr(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	userData, err := u.userUseCase.RegisterUser(context.Background(), registerInputDTO)
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.JSON(http.StatusCreated, userData)
+}
+
+func (u *UserController) Login(c *gin.Context) {
+	var loginInputDTO user_usecase.LoginInputDTO
+
+	if err := c.ShouldBindJSON(&loginInputDTO); err != nil {
+		restErr := validation.ValidateErr(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	token, err := u.userUseCase.Login(context.Background(), loginInputDTO)
+	if err != nil {
+		restErr := rest_err.ConvertError(err)
+
+		c.JSON(restErr.Code, restErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, token)
+}
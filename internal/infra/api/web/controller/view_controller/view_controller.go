@@ -0,0 +1,69 @@
+package view_controller
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/infra/api/web/middleware"
+	"fullcycle-auction_go/internal/usecase/view_usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+type ViewController struct {
+	viewUseCase view_usecase.ViewUseCaseInterface
+}
+
+func NewViewController(viewUseCase view_usecase.ViewUseCaseInterface) *ViewController {
+	return &ViewController{
+		viewUseCase: viewUseCase,
+	}
+}
+
+func (u *ViewController) RecordView(c *gin.Context) {
+	auctionId, ok := validAuctionId(c)
+	if !ok {
+		return
+	}
+
+	if err := u.viewUseCase.RecordView(
+		context.Background(), auctionId, middleware.AuthenticatedUserId(c), c.ClientIP()); err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (u *ViewController) FindAuctionStats(c *gin.Context) {
+	auctionId, ok := validAuctionId(c)
+	if !ok {
+		return
+	}
+
+	stats, err := u.viewUseCase.FindAuctionStats(context.Background(), auctionId)
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+func validAuctionId(c *gin.Context) (string, bool) {
+	auctionId := c.Param("auctionId")
+
+	if err := uuid.Validate(auctionId); err != nil {
+		errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+			Field:   "auctionId",
+			Message: "Invalid UUID value",
+		})
+
+		c.JSON(errRest.Code, errRest)
+		return "", false
+	}
+
+	return auctionId, true
+}
@@ -0,0 +1,137 @@
+package fieldset
+
+import (
+	"bytes"
+	"encoding/json"
+	"fullcycle-auction_go/configuration/rest_err"
+	"github.com/gin-gonic/gin"
+	"reflect"
+	"strings"
+)
+
+// Parse reads the comma separated fields query parameter, checking each
+// field against the top-level JSON fields of model. Without the parameter
+// every field is selected and Parse returns nil.
+func Parse(c *gin.Context, model interface{}) ([]string, *rest_err.RestErr) {
+	query := c.Query("fields")
+	if query == "" {
+		return nil, nil
+	}
+
+	known := jsonFields(reflect.TypeOf(model))
+
+	var fields []string
+	for _, field := range strings.Split(query, ",") {
+		field = strings.TrimSpace(field)
+		if !known[field] {
+			return nil, rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+				Field:   "fields",
+				Message: "Unknown field " + field,
+			})
+		}
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+// JSON responds with value, an object or a list of objects, keeping only
+// the selected fields.
+func JSON(c *gin.Context, code int, value interface{}, fields []string) {
+	if len(fields) == 0 {
+		c.JSON(code, value)
+		return
+	}
+
+	body, err := json.Marshal(value)
+	if err != nil {
+		errRest := rest_err.NewInternalServerError("Error trying to render response")
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	keep := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		keep[field] = true
+	}
+
+	if bytes.HasPrefix(bytes.TrimSpace(body), []byte("[")) {
+		var objects []map[string]json.RawMessage
+		if err := json.Unmarshal(body, &objects); err == nil {
+			for _, object := range objects {
+				trim(object, keep)
+			}
+			c.JSON(code, objects)
+			return
+		}
+	} else {
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal(body, &object); err == nil {
+			trim(object, keep)
+			c.JSON(code, object)
+			return
+		}
+	}
+
+	c.Data(code, "application/json; charset=utf-8", body)
+}
+
+// JSONPage responds with a page of pagination.Page, keeping only the
+// selected fields of its items.
+func JSONPage(c *gin.Context, code int, page interface{}, fields []string) {
+	if len(fields) == 0 {
+		c.JSON(code, page)
+		return
+	}
+
+	body, err := json.Marshal(page)
+	if err != nil {
+		errRest := rest_err.NewInternalServerError("Error trying to render response")
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	keep := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		keep[field] = true
+	}
+
+	var envelope map[string]json.RawMessage
+	var items []map[string]json.RawMessage
+	if err := json.Unmarshal(body, &envelope); err != nil || json.Unmarshal(envelope["items"], &items) != nil {
+		c.Data(code, "application/json; charset=utf-8", body)
+		return
+	}
+
+	for _, item := range items {
+		trim(item, keep)
+	}
+	trimmed, _ := json.Marshal(items)
+	envelope["items"] = trimmed
+
+	c.JSON(code, envelope)
+}
+
+func trim(object map[string]json.RawMessage, keep map[string]bool) {
+	for field := range object {
+		if !keep[field] {
+			delete(object, field)
+		}
+	}
+}
+
+func jsonFields(modelType reflect.Type) map[string]bool {
+	for modelType.Kind() == reflect.Pointer || modelType.Kind() == reflect.Slice {
+		modelType = modelType.Elem()
+	}
+
+	fields := make(map[string]bool)
+	for i := 0; i < modelType.NumField(); i++ {
+		name, _, _ := strings.Cut(modelType.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			fields[name] = true
+		}
+	}
+
+	return fields
+}
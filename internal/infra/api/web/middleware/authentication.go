@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"fullcycle-auction_go/configuration/rest_err"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const authenticatedUserIdKey = "authenticated_user_id"
+
+type TokenVerifier interface {
+	// Verify returns the id of the user the token was issued to.
+	Verify(token string) (string, error)
+}
+
+// Authentication requires a valid "Authorization: Bearer <token>" header,
+// answering 401 otherwise, and stores the authenticated user id in the
+// context for AuthenticatedUserId.
+func Authentication(verifier TokenVerifier) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			restErr := rest_err.NewUnauthorizedError("Missing bearer token")
+			c.AbortWithStatusJSON(restErr.Code, restErr)
+			return
+		}
+
+		userId, err := verifier.Verify(token)
+		if err != nil {
+			restErr := rest_err.NewUnauthorizedError("Invalid or expired token")
+			c.AbortWithStatusJSON(restErr.Code, restErr)
+			return
+		}
+
+		c.Set(authenticatedUserIdKey, userId)
+		c.Next()
+	}
+}
+
+// OptionalAuthentication authenticates the requests that send a bearer
+// token as Authentication does, letting the ones without one through
+// anonymously.
+func OptionalAuthentication(verifier TokenVerifier) gin.HandlerFunc {
+	authentication := Authentication(verifier)
+
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" {
+			c.Next()
+			return
+		}
+
+		authentication(c)
+	}
+}
+
+// AuthenticatedUserId is the user authenticated by Authentication, empty on
+// routes it does not guard.
+func AuthenticatedUserId(c *gin.Context) string {
+	return c.GetString(authenticatedUserIdKey)
+}
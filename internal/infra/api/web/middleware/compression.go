@@ -0,0 +1,179 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/klauspost/compress/zstd"
+)
+
+// compressedContentTypes are payloads that are already compressed and would
+// only waste CPU when compressed again.
+var compressedContentTypes = []string{
+	"image/",
+	"video/",
+	"audio/",
+	"application/zip",
+	"application/gzip",
+	"application/x-gzip",
+	"application/zstd",
+	"application/x-7z-compressed",
+}
+
+var (
+	gzipWriterPool = sync.Pool{New: func() interface{} {
+		writer, _ := gzip.NewWriterLevel(nil, gzip.DefaultCompression)
+		return writer
+	}}
+	zstdWriterPool = sync.Pool{New: func() interface{} {
+		writer, _ := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedDefault))
+		return writer
+	}}
+)
+
+// Compression compresses responses with zstd or gzip according to the
+// request Accept-Encoding header. Requests whose path starts with one of
+// excludedPaths, such as streaming endpoints that flush as they go, are
+// served uncompressed.
+func Compression(excludedPaths ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, path := range excludedPaths {
+			if strings.HasPrefix(c.Request.URL.Path, path) {
+				c.Next()
+				return
+			}
+		}
+
+		encoding := negotiateEncoding(c.GetHeader("Accept-Encoding"))
+		if encoding == "" {
+			c.Next()
+			return
+		}
+
+		writer := &compressWriter{ResponseWriter: c.Writer, encoding: encoding}
+		c.Writer = writer
+		defer writer.close()
+
+		c.Next()
+	}
+}
+
+// negotiateEncoding picks zstd over gzip, ignoring encodings the client
+// disabled with q=0.
+func negotiateEncoding(acceptEncoding string) string {
+	accepted := make(map[string]bool)
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+				quality = parsed
+			}
+		}
+		accepted[strings.ToLower(strings.TrimSpace(name))] = quality > 0
+	}
+
+	switch {
+	case accepted["zstd"]:
+		return "zstd"
+	case accepted["gzip"]:
+		return "gzip"
+	default:
+		return ""
+	}
+}
+
+type flushWriteCloser interface {
+	Write(p []byte) (int, error)
+	Flush() error
+	Close() error
+}
+
+type compressWriter struct {
+	gin.ResponseWriter
+	encoding    string
+	encoder     flushWriteCloser
+	decided     bool
+	passthrough bool
+}
+
+// decide runs on the first body write, once the handler has set its
+// headers, to skip responses that are already encoded or compressed.
+func (w *compressWriter) decide() {
+	w.decided = true
+
+	header := w.Header()
+	if header.Get("Content-Encoding") != "" || isCompressedContentType(header.Get("Content-Type")) {
+		w.passthrough = true
+		return
+	}
+
+	header.Set("Content-Encoding", w.encoding)
+	header.Add("Vary", "Accept-Encoding")
+	header.Del("Content-Length")
+
+	switch w.encoding {
+	case "zstd":
+		encoder := zstdWriterPool.Get().(*zstd.Encoder)
+		encoder.Reset(w.ResponseWriter)
+		w.encoder = encoder
+	default:
+		encoder := gzipWriterPool.Get().(*gzip.Writer)
+		encoder.Reset(w.ResponseWriter)
+		w.encoder = encoder
+	}
+}
+
+func (w *compressWriter) Write(data []byte) (int, error) {
+	if !w.decided {
+		w.decide()
+	}
+
+	if w.passthrough {
+		return w.ResponseWriter.Write(data)
+	}
+
+	return w.encoder.Write(data)
+}
+
+func (w *compressWriter) WriteString(data string) (int, error) {
+	return w.Write([]byte(data))
+}
+
+func (w *compressWriter) Flush() {
+	if w.encoder != nil {
+		w.encoder.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+func (w *compressWriter) close() {
+	if w.encoder == nil {
+		return
+	}
+
+	w.encoder.Close()
+
+	switch encoder := w.encoder.(type) {
+	case *zstd.Encoder:
+		encoder.Reset(nil)
+		zstdWriterPool.Put(encoder)
+	case *gzip.Writer:
+		encoder.Reset(nil)
+		gzipWriterPool.Put(encoder)
+	}
+	w.encoder = nil
+}
+
+func isCompressedContentType(contentType string) bool {
+	for _, prefix := range compressedContentTypes {
+		if strings.HasPrefix(contentType, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
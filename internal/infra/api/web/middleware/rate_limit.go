@@ -0,0 +1,89 @@
+package middleware
+
+import (
+	"fullcycle-auction_go/configuration/rest_err"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// rateLimitSweepInterval is how often the buckets of clients gone quiet are
+// dropped.
+const rateLimitSweepInterval = time.Minute
+
+type rateBucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+type rateLimiter struct {
+	perSecond float64
+	burst     float64
+
+	mutex     sync.Mutex
+	buckets   map[string]*rateBucket
+	lastSweep time.Time
+}
+
+// RateLimit lets each client IP make burst requests at once, refilled at
+// perMinute requests a minute, and answers 429 with a Retry-After header
+// beyond that.
+func RateLimit(perMinute, burst int) gin.HandlerFunc {
+	limiter := &rateLimiter{
+		perSecond: float64(perMinute) / 60,
+		burst:     float64(burst),
+		buckets:   make(map[string]*rateBucket),
+		lastSweep: time.Now(),
+	}
+
+	return func(c *gin.Context) {
+		if wait := limiter.take(c.ClientIP(), time.Now()); wait > 0 {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			restErr := rest_err.NewTooManyRequestsError("Too many requests")
+			c.AbortWithStatusJSON(restErr.Code, restErr)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// take spends a token of the client, returning how long until one is
+// available when there is none left.
+func (l *rateLimiter) take(client string, now time.Time) time.Duration {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if now.Sub(l.lastSweep) >= rateLimitSweepInterval {
+		l.sweep(now)
+	}
+
+	bucket, ok := l.buckets[client]
+	if !ok {
+		bucket = &rateBucket{tokens: l.burst, updatedAt: now}
+		l.buckets[client] = bucket
+	}
+
+	bucket.tokens = math.Min(l.burst, bucket.tokens+now.Sub(bucket.updatedAt).Seconds()*l.perSecond)
+	bucket.updatedAt = now
+	if bucket.tokens < 1 {
+		return time.Duration((1 - bucket.tokens) / l.perSecond * float64(time.Second))
+	}
+
+	bucket.tokens--
+	return 0
+}
+
+// sweep drops the buckets refilled by now, which are no different from new
+// ones.
+func (l *rateLimiter) sweep(now time.Time) {
+	for client, bucket := range l.buckets {
+		if bucket.tokens+now.Sub(bucket.updatedAt).Seconds()*l.perSecond >= l.burst {
+			delete(l.buckets, client)
+		}
+	}
+	l.lastSweep = now
+}
@@ -0,0 +1,119 @@
+package middleware
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxCachedResponses bounds the memory of a response cache. Responses are
+// not cached while it is full of fresh ones.
+const maxCachedResponses = 1000
+
+type cachedResponse struct {
+	contentType string
+	body        []byte
+	expiresAt   time.Time
+}
+
+type responseCache struct {
+	ttl       time.Duration
+	mutex     sync.Mutex
+	responses map[string]cachedResponse
+}
+
+// ResponseCache serves successful GET responses from memory for ttl, and
+// lets clients and shared caches keep them as long. Responses vary with the
+// request URI and Accept-Language, as auctions are localized.
+func ResponseCache(ttl time.Duration) gin.HandlerFunc {
+	cache := &responseCache{ttl: ttl, responses: make(map[string]cachedResponse)}
+	cacheControl := fmt.Sprintf("public, max-age=%d", int(ttl.Seconds()))
+
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet {
+			c.Next()
+			return
+		}
+
+		key := c.Request.URL.RequestURI() + "|" + c.GetHeader("Accept-Language")
+		if response, ok := cache.get(key, time.Now()); ok {
+			c.Header("Cache-Control", cacheControl)
+			c.Header("X-Cache", "HIT")
+			c.Data(http.StatusOK, response.contentType, response.body)
+			c.Abort()
+			return
+		}
+
+		c.Header("X-Cache", "MISS")
+		writer := &recordingWriter{ResponseWriter: c.Writer, cacheControl: cacheControl}
+		c.Writer = writer
+
+		c.Next()
+
+		if writer.Status() == http.StatusOK {
+			cache.put(key, writer.Header().Get("Content-Type"), writer.body.Bytes(), time.Now())
+		}
+	}
+}
+
+func (rc *responseCache) get(key string, now time.Time) (cachedResponse, bool) {
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+
+	response, ok := rc.responses[key]
+	if !ok || now.After(response.expiresAt) {
+		return cachedResponse{}, false
+	}
+
+	return response, true
+}
+
+func (rc *responseCache) put(key, contentType string, body []byte, now time.Time) {
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+
+	if len(rc.responses) >= maxCachedResponses {
+		for cachedKey, response := range rc.responses {
+			if now.After(response.expiresAt) {
+				delete(rc.responses, cachedKey)
+			}
+		}
+		if len(rc.responses) >= maxCachedResponses {
+			return
+		}
+	}
+
+	rc.responses[key] = cachedResponse{
+		contentType: contentType,
+		body:        body,
+		expiresAt:   now.Add(rc.ttl),
+	}
+}
+
+// recordingWriter keeps a copy of the body written through it, marking
+// successful responses as cacheable.
+type recordingWriter struct {
+	gin.ResponseWriter
+	cacheControl string
+	body         bytes.Buffer
+}
+
+func (w *recordingWriter) WriteHeader(code int) {
+	if code == http.StatusOK {
+		w.Header().Set("Cache-Control", w.cacheControl)
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *recordingWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *recordingWriter) WriteString(data string) (int, error) {
+	return w.Write([]byte(data))
+}
@@ -0,0 +1,45 @@
+package paging
+
+import (
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/usecase/pagination"
+	"github.com/gin-gonic/gin"
+	"strconv"
+)
+
+// Parse reads the limit, offset and cursor query parameters, limit
+// defaulting to pagination.DefaultLimit and capped at pagination.MaxLimit.
+func Parse(c *gin.Context) (pagination.Params, *rest_err.RestErr) {
+	params := pagination.Params{
+		Limit:  pagination.DefaultLimit,
+		Cursor: c.Query("cursor"),
+	}
+
+	if value := c.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > pagination.MaxLimit {
+			return params, invalid("limit", "Must be a number from 1 to "+strconv.Itoa(pagination.MaxLimit))
+		}
+		params.Limit = limit
+	}
+
+	if value := c.Query("offset"); value != "" {
+		offset, err := strconv.Atoi(value)
+		if err != nil || offset < 0 {
+			return params, invalid("offset", "Must be a non-negative number")
+		}
+		if params.Cursor != "" {
+			return params, invalid("offset", "Cannot be combined with a cursor")
+		}
+		params.Offset = offset
+	}
+
+	return params, nil
+}
+
+func invalid(field, message string) *rest_err.RestErr {
+	return rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+		Field:   field,
+		Message: message,
+	})
+}
@@ -3,12 +3,15 @@ package validation
 import (
 	"encoding/json"
 	"errors"
+	"fullcycle-auction_go/configuration/enum"
 	"fullcycle-auction_go/configuration/rest_err"
+	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/locales/en"
 	ut "github.com/go-playground/universal-translator"
 	"github.com/go-playground/validator/v10"
 	validator_en "github.com/go-playground/validator/v10/translations/en"
+	"github.com/google/uuid"
 )
 
 var (
@@ -28,9 +31,15 @@ func init() {
 func ValidateErr(validation_err error) *rest_err.RestErr {
 	var jsonErr *json.UnmarshalTypeError
 	var jsonValidation validator.ValidationErrors
+	var enumErr *enum.Error
 
 	if errors.As(validation_err, &jsonErr) {
 		return rest_err.NewNotFoundError("Invalid type error")
+	} else if errors.As(validation_err, &enumErr) {
+		return rest_err.NewBadRequestError("Invalid field values", rest_err.Causes{
+			Field:   enumErr.Kind,
+			Message: enumErr.Error(),
+		})
 	} else if errors.As(validation_err, &jsonValidation) {
 		errorCauses := []rest_err.Causes{}
 
@@ -46,3 +55,21 @@ func ValidateErr(validation_err error) *rest_err.RestErr {
 		return rest_err.NewBadRequestError("Error trying to convert fields")
 	}
 }
+
+// ValidUUIDParam returns the path parameter name, answering bad request
+// unless it is a UUID.
+func ValidUUIDParam(c *gin.Context, name string) (string, bool) {
+	value := c.Param(name)
+
+	if err := uuid.Validate(value); err != nil {
+		errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+			Field:   name,
+			Message: "Invalid UUID value",
+		})
+
+		c.JSON(errRest.Code, errRest)
+		return "", false
+	}
+
+	return value, true
+}
@@ -0,0 +1,121 @@
+// Package auth issues and verifies the HS256 JSON Web Tokens that
+// authenticate users, the user id being the token subject.
+package auth
+
+import (
+	"crypto/rand"
+	"errors"
+	"fullcycle-auction_go/configuration/clock"
+	"fullcycle-auction_go/configuration/logger"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const issuer = "fullcycle-auction"
+
+var ErrInvalidToken = errors.New("invalid token")
+
+type TokenIssuer struct {
+	secret []byte
+	ttl    time.Duration
+	// audience, when set, is carried by the tokens issued and required
+	// from the tokens verified.
+	audience string
+}
+
+func NewTokenIssuer(secret []byte, ttl time.Duration) *TokenIssuer {
+	return &TokenIssuer{
+		secret: secret,
+		ttl:    ttl,
+	}
+}
+
+// NewTokenIssuerFromEnv signs with JWT_SECRET and issues tokens valid for
+// JWT_TTL. Without JWT_SECRET a random secret is generated, so tokens do
+// not survive a restart nor work across instances.
+func NewTokenIssuerFromEnv() *TokenIssuer {
+	secret := []byte(os.Getenv("JWT_SECRET"))
+	if len(secret) == 0 {
+		logger.Warn("JWT_SECRET is not set, tokens are signed with a random secret")
+
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			panic(err)
+		}
+	}
+
+	return NewTokenIssuer(secret, getTokenTTL())
+}
+
+// ForAudience returns an issuer signing with the same secret whose tokens
+// are only valid for the audience, such as a tenant, and no other.
+func (ti *TokenIssuer) ForAudience(audience string) *TokenIssuer {
+	return &TokenIssuer{
+		secret:   ti.secret,
+		ttl:      ti.ttl,
+		audience: audience,
+	}
+}
+
+// Issue returns a token for the user and when it expires.
+func (ti *TokenIssuer) Issue(userId string) (string, time.Time, error) {
+	now := clock.Now()
+	expiresAt := now.Add(ti.ttl)
+
+	claims := jwt.RegisteredClaims{
+		Issuer:    issuer,
+		Subject:   userId,
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+	}
+	if ti.audience != "" {
+		claims.Audience = jwt.ClaimStrings{ti.audience}
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+
+	signed, err := token.SignedString(ti.secret)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return signed, expiresAt, nil
+}
+
+// Verify returns the user id of a valid, unexpired token, or
+// ErrInvalidToken.
+func (ti *TokenIssuer) Verify(tokenString string) (string, error) {
+	var claims jwt.RegisteredClaims
+
+	parserOptions := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(issuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(clock.Now),
+	}
+	if ti.audience != "" {
+		parserOptions = append(parserOptions, jwt.WithAudience(ti.audience))
+	}
+
+	_, err := jwt.ParseWithClaims(tokenString, &claims,
+		func(*jwt.Token) (interface{}, error) {
+			return ti.secret, nil
+		},
+		parserOptions...)
+	if err != nil || claims.Subject == "" {
+		return "", ErrInvalidToken
+	}
+
+	return claims.Subject, nil
+}
+
+func getTokenTTL() time.Duration {
+	ttl, err := time.ParseDuration(os.Getenv("JWT_TTL"))
+	if err != nil || ttl <= 0 {
+		return 24 * time.Hour
+	}
+
+	return ttl
+}
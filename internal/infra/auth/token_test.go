@@ -0,0 +1,52 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenIssuer_Verify(t *testing.T) {
+	issuer := NewTokenIssuer([]byte("secret"), time.Hour)
+
+	token, expiresAt, err := issuer.Issue("user-1")
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), expiresAt, time.Minute)
+
+	userId, err := issuer.Verify(token)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", userId)
+
+	_, err = NewTokenIssuer([]byte("other"), time.Hour).Verify(token)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	_, err = issuer.Verify(token + "x")
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	expired, _, err := NewTokenIssuer([]byte("secret"), -time.Minute).Issue("user-1")
+	require.NoError(t, err)
+	_, err = issuer.Verify(expired)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestTokenIssuer_ForAudience(t *testing.T) {
+	issuer := NewTokenIssuer([]byte("secret"), time.Hour)
+	acme, globex := issuer.ForAudience("acme"), issuer.ForAudience("globex")
+
+	token, _, err := acme.Issue("user-1")
+	require.NoError(t, err)
+
+	userId, err := acme.Verify(token)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", userId)
+
+	_, err = globex.Verify(token)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	unscoped, _, err := issuer.Issue("user-1")
+	require.NoError(t, err)
+	_, err = acme.Verify(unscoped)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
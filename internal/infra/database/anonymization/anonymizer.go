@@ -0,0 +1,155 @@
+package anonymization
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Anonymizer replaces personal data with fake values derived from a keyed
+// hash of the original, so a value always gets the same replacement and
+// data matching across documents, such as bids from one IP, still does.
+// Ids are not personal data and are kept, which preserves the references
+// between collections.
+type Anonymizer struct {
+	key []byte
+}
+
+func NewAnonymizer(key string) *Anonymizer {
+	return &Anonymizer{key: []byte(key)}
+}
+
+func (a *Anonymizer) digest(kind, value string) []byte {
+	mac := hmac.New(sha256.New, a.key)
+	mac.Write([]byte(kind + ":" + value))
+	return mac.Sum(nil)
+}
+
+func (a *Anonymizer) Name(value string) string {
+	return "User " + hex.EncodeToString(a.digest("name", value))[:8]
+}
+
+// Email keeps emails unique, as they are compared case-insensitively.
+func (a *Anonymizer) Email(value string) string {
+	return fmt.Sprintf("user-%s@example.com",
+		hex.EncodeToString(a.digest("email", strings.ToLower(value)))[:16])
+}
+
+// Ip maps IPv4 addresses into 10.0.0.0/8 and IPv6 ones into fd00::/8,
+// anything else being treated as opaque.
+func (a *Anonymizer) Ip(value string) string {
+	ip := net.ParseIP(value)
+	if ip == nil {
+		return a.Opaque(value)
+	}
+
+	digest := a.digest("ip", ip.String())
+	if ip.To4() != nil {
+		return net.IPv4(10, digest[0], digest[1], digest[2]).String()
+	}
+
+	anonymized := make(net.IP, net.IPv6len)
+	anonymized[0] = 0xfd
+	copy(anonymized[1:], digest)
+	return anonymized.String()
+}
+
+func (a *Anonymizer) Opaque(value string) string {
+	return hex.EncodeToString(a.digest("opaque", value))[:32]
+}
+
+// Remove drops the field altogether.
+func (a *Anonymizer) Remove(value string) string {
+	return ""
+}
+
+// Rules lists, per collection, how each field holding personal data is
+// anonymized. Password hashes are removed, so anonymized users cannot log
+// in until they register again.
+func (a *Anonymizer) Rules() map[string]map[string]func(string) string {
+	clientRules := map[string]func(string) string{
+		"client_ip":          a.Ip,
+		"device_fingerprint": a.Opaque,
+	}
+
+	return map[string]map[string]func(string) string{
+		"users": {
+			"name":          a.Name,
+			"display_name":  a.Name,
+			"email":         a.Email,
+			"bio":           a.Remove,
+			"avatar_url":    a.Remove,
+			"password_hash": a.Remove,
+		},
+		"bids":          clientRules,
+		"rejected_bids": clientRules,
+	}
+}
+
+// AnonymizeCollection applies the rules to every document of the
+// collection and returns how many documents were changed.
+func AnonymizeCollection(
+	ctx context.Context,
+	collection *mongo.Collection,
+	rules map[string]func(string) string) (int64, error) {
+	cursor, err := collection.Find(ctx, bson.M{})
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var anonymized int64
+	for cursor.Next(ctx) {
+		var document bson.M
+		if err := cursor.Decode(&document); err != nil {
+			return anonymized, err
+		}
+
+		set, unset := anonymizeDocument(document, rules)
+		if len(set) == 0 && len(unset) == 0 {
+			continue
+		}
+
+		update := bson.M{}
+		if len(set) > 0 {
+			update["$set"] = set
+		}
+		if len(unset) > 0 {
+			update["$unset"] = unset
+		}
+		if _, err := collection.UpdateOne(ctx, bson.M{"_id": document["_id"]}, update); err != nil {
+			return anonymized, err
+		}
+		anonymized++
+	}
+
+	return anonymized, cursor.Err()
+}
+
+// anonymizeDocument returns the fields to set and to unset, leaving out the
+// empty ones.
+func anonymizeDocument(
+	document bson.M, rules map[string]func(string) string) (set, unset bson.M) {
+	set, unset = bson.M{}, bson.M{}
+	for field, anonymize := range rules {
+		value, ok := document[field].(string)
+		if !ok || value == "" {
+			continue
+		}
+
+		if replacement := anonymize(value); replacement != "" {
+			set[field] = replacement
+		} else {
+			unset[field] = ""
+		}
+	}
+
+	return set, unset
+}
@@ -0,0 +1,29 @@
+package anonymization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestAnonymizeDocument(t *testing.T) {
+	anonymizer := NewAnonymizer("secret")
+	rules := anonymizer.Rules()
+
+	set, unset := anonymizeDocument(bson.M{
+		"_id":           "user",
+		"name":          "Alice",
+		"email":         "Alice@Example.org",
+		"password_hash": "hash",
+	}, rules["users"])
+	assert.Equal(t, anonymizer.Name("Alice"), set["name"])
+	assert.NotContains(t, set["email"], "Alice")
+	assert.Equal(t, bson.M{"password_hash": ""}, unset)
+
+	// The same value always gets the same replacement.
+	assert.Equal(t, anonymizer.Email("alice@example.org"), set["email"])
+	assert.Equal(t, anonymizer.Ip("203.0.113.7"), anonymizer.Ip("203.0.113.7"))
+	assert.Regexp(t, `^10\.`, anonymizer.Ip("203.0.113.7"))
+	assert.NotEqual(t, NewAnonymizer("other").Ip("203.0.113.7"), anonymizer.Ip("203.0.113.7"))
+}
@@ -2,8 +2,11 @@ package auction
 
 import (
 	"context"
+	"fullcycle-auction_go/configuration/clock"
 	"fullcycle-auction_go/configuration/logger"
 	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/event_entity"
+	"fullcycle-auction_go/internal/infra/scheduler"
 	"fullcycle-auction_go/internal/internal_error"
 	"os"
 	"time"
@@ -12,59 +15,268 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
+type ConditionGradingMongo struct {
+	Grade           auction_entity.Grade `bson:"grade"`
+	Defects         []string             `bson:"defects,omitempty"`
+	InspectionNotes string               `bson:"inspection_notes,omitempty"`
+	GraderId        string               `bson:"grader_id,omitempty"`
+}
+
+type TermsMongo struct {
+	ReturnsPolicy       string `bson:"returns_policy"`
+	PaymentDeadlineDays int    `bson:"payment_deadline_days"`
+	Version             int    `bson:"version"`
+}
+
+// DescriptionMongo is a translated description. Its language, an ISO 639-1
+// code MongoDB knows, is the one the search index stems the text in.
+type DescriptionMongo struct {
+	Language string `bson:"language"`
+	Text     string `bson:"text"`
+}
+
 type AuctionEntityMongo struct {
-	Id          string                          `bson:"_id"`
-	ProductName string                          `bson:"product_name"`
-	Category    string                          `bson:"category"`
-	Description string                          `bson:"description"`
-	Condition   auction_entity.ProductCondition `bson:"condition"`
-	Status      auction_entity.AuctionStatus    `bson:"status"`
-	Timestamp   int64                           `bson:"timestamp"`
+	Id               string                          `bson:"_id"`
+	SellerId         string                          `bson:"seller_id,omitempty"`
+	ProductId        string                          `bson:"product_id,omitempty"`
+	ProductName      string                          `bson:"product_name"`
+	Category         string                          `bson:"category"`
+	Description      string                          `bson:"description"`
+	Condition        auction_entity.ProductCondition `bson:"condition"`
+	Grading          *ConditionGradingMongo          `bson:"grading,omitempty"`
+	Attributes       map[string]string               `bson:"attributes,omitempty"`
+	Status           auction_entity.AuctionStatus    `bson:"status"`
+	Timestamp        int64                           `bson:"timestamp"`
+	UpdatedAt        int64                           `bson:"updated_at"`
+	StartsAt         int64                           `bson:"starts_at,omitempty"`
+	EndsAt           int64                           `bson:"ends_at"`
+	WinnerBidId      string                          `bson:"winner_bid_id,omitempty"`
+	BidderVisibility auction_entity.BidderVisibility `bson:"bidder_visibility"`
+	Terms            *TermsMongo                     `bson:"terms,omitempty"`
+	Descriptions     []DescriptionMongo              `bson:"descriptions,omitempty"`
+	SchemaVersion    int                             `bson:"schema_version"`
+
+	RecurringAuctionId string `bson:"recurring_auction_id,omitempty"`
+	SeriesId           string `bson:"series_id,omitempty"`
+	LotNumber          int    `bson:"lot_number,omitempty"`
+
+	Live            bool  `bson:"live,omitempty"`
+	BiddingOpenedAt int64 `bson:"bidding_opened_at,omitempty"`
+
+	ReservePrice float64 `bson:"reserve_price,omitempty"`
+	MinIncrement float64 `bson:"min_increment,omitempty"`
+	Sealed       bool    `bson:"sealed,omitempty"`
+	BuyNowPrice  float64 `bson:"buy_now_price,omitempty"`
+
+	StartingPrice float64 `bson:"starting_price,omitempty"`
+
+	Quantity     int  `bson:"quantity,omitempty"`
+	UniformPrice bool `bson:"uniform_price,omitempty"`
+
+	EstimateLow  float64 `bson:"estimate_low,omitempty"`
+	EstimateHigh float64 `bson:"estimate_high,omitempty"`
+
+	RegistrationRequired bool `bson:"registration_required,omitempty"`
+
+	PausedAt int64 `bson:"paused_at,omitempty"`
+
+	PublicAt int64    `bson:"public_at,omitempty"`
+	Invitees []string `bson:"invitees,omitempty"`
+
+	RelistedFrom string `bson:"relisted_from,omitempty"`
+
+	HeatScore float64 `bson:"heat_score,omitempty"`
+	Views     int64   `bson:"views,omitempty"`
 }
+
 type AuctionRepository struct {
 	Collection *mongo.Collection
+	publisher  event_entity.Publisher
+	scheduler  *scheduler.Scheduler
 }
 
-func NewAuctionRepository(database *mongo.Database) *AuctionRepository {
+// NewAuctionRepository publishes the completion of every auction to
+// publisher, which may be nil.
+func NewAuctionRepository(database *mongo.Database, publisher event_entity.Publisher) *AuctionRepository {
 	return &AuctionRepository{
 		Collection: database.Collection("auctions"),
+		publisher:  publisher,
+		scheduler:  scheduler.New(),
 	}
 }
 
 func (ar *AuctionRepository) CreateAuction(
 	ctx context.Context,
 	auctionEntity *auction_entity.Auction) *internal_error.InternalError {
-	auctionEntityMongo := &AuctionEntityMongo{
-		Id:          auctionEntity.Id,
-		ProductName: auctionEntity.ProductName,
-		Category:    auctionEntity.Category,
-		Description: auctionEntity.Description,
-		Condition:   auctionEntity.Condition,
-		Status:      auctionEntity.Status,
-		Timestamp:   auctionEntity.Timestamp.Unix(),
-	}
-	_, err := ar.Collection.InsertOne(ctx, auctionEntityMongo)
+	if auctionEntity.EndsAt.IsZero() {
+		auctionEntity.EndsAt = auctionEntity.OpensAt().Add(getAuctionInterval())
+	}
+
+	_, err := ar.Collection.InsertOne(ctx, toAuctionEntityMongo(auctionEntity))
 	if err != nil {
 		logger.Error("Error trying to insert auction", err)
 		return internal_error.NewInternalServerError("Error trying to insert auction")
 	}
 
-	go func() {
-		<-time.After(getAuctionInterval())
-		auctionEntity.Status = auction_entity.Completed
-		filter := bson.M{"_id": auctionEntity.Id}
+	ar.scheduleTimers(auctionEntity)
+
+	return nil
+}
+
+// scheduleTimers starts the timer of the auction's current status, drafts
+// having none until published.
+func (ar *AuctionRepository) scheduleTimers(auctionEntity *auction_entity.Auction) {
+	switch auctionEntity.Status {
+	case auction_entity.Draft:
+	case auction_entity.Scheduled:
+		ar.scheduleStart(auctionEntity.Id, auctionEntity.StartsAt, auctionEntity.EndsAt)
+	default:
+		ar.scheduleCompletion(auctionEntity.Id, auctionEntity.EndsAt)
+	}
+}
+
+func toAuctionEntityMongo(auctionEntity *auction_entity.Auction) *AuctionEntityMongo {
+	auctionEntityMongo := &AuctionEntityMongo{
+		Id:               auctionEntity.Id,
+		SellerId:         auctionEntity.SellerId,
+		ProductId:        auctionEntity.ProductId,
+		ProductName:      auctionEntity.ProductName,
+		Category:         auctionEntity.Category,
+		Description:      auctionEntity.Description,
+		Condition:        auctionEntity.Condition,
+		Grading:          toConditionGradingMongo(auctionEntity.Grading),
+		Attributes:       auctionEntity.Attributes,
+		Status:           auctionEntity.Status,
+		Timestamp:        auctionEntity.Timestamp.Unix(),
+		UpdatedAt:        auctionEntity.UpdatedAt.UnixMilli(),
+		StartsAt:         toUnixMilli(auctionEntity.StartsAt),
+		EndsAt:           auctionEntity.EndsAt.UnixMilli(),
+		BidderVisibility: auctionEntity.BidderVisibility,
+		Descriptions:     toDescriptionsMongo(auctionEntity.Descriptions),
+		SchemaVersion:    AuctionUpcasters.LatestVersion(),
 
-		update := bson.M{"$set": bson.M{"status": auctionEntity.Status}}
+		RecurringAuctionId: auctionEntity.RecurringAuctionId,
+		SeriesId:           auctionEntity.SeriesId,
+		LotNumber:          auctionEntity.LotNumber,
 
-		_, err := ar.Collection.UpdateOne(ctx, filter, update)
+		Live: auctionEntity.Live,
 
-		if err != nil {
-			logger.Error("Error trying to update auction status", err)
-			return
+		ReservePrice: auctionEntity.ReservePrice,
+		MinIncrement: auctionEntity.MinIncrement,
+		Sealed:       auctionEntity.Sealed,
+		BuyNowPrice:  auctionEntity.BuyNowPrice,
+
+		StartingPrice: auctionEntity.StartingPrice,
+
+		Quantity:     auctionEntity.Quantity,
+		UniformPrice: auctionEntity.UniformPrice,
+
+		EstimateLow:  auctionEntity.EstimateLow,
+		EstimateHigh: auctionEntity.EstimateHigh,
+
+		RegistrationRequired: auctionEntity.RegistrationRequired,
+
+		PublicAt: toUnixMilli(auctionEntity.PublicAt),
+		Invitees: auctionEntity.Invitees,
+
+		RelistedFrom: auctionEntity.RelistedFrom,
+
+		HeatScore: auctionEntity.HeatScore,
+		Views:     auctionEntity.Views,
+	}
+	if auctionEntity.Terms != nil {
+		auctionEntityMongo.Terms = &TermsMongo{
+			ReturnsPolicy:       auctionEntity.Terms.ReturnsPolicy,
+			PaymentDeadlineDays: auctionEntity.Terms.PaymentDeadlineDays,
+			Version:             auctionEntity.Terms.Version,
 		}
-	}()
+	}
 
-	return nil
+	return auctionEntityMongo
+}
+
+func toConditionGradingMongo(grading auction_entity.ConditionGrading) *ConditionGradingMongo {
+	return &ConditionGradingMongo{
+		Grade:           grading.Grade,
+		Defects:         grading.Defects,
+		InspectionNotes: grading.InspectionNotes,
+		GraderId:        grading.GraderId,
+	}
+}
+
+func (ar *AuctionRepository) scheduleStart(auctionId string, startsAt, endsAt time.Time) {
+	ar.scheduler.Schedule(auctionId, startsAt, func() { ar.startAuction(auctionId, endsAt) })
+}
+
+// scheduleCompletion completes the auction once the completion grace has
+// passed its end too, leaving the bids accepted before the end time to be
+// flushed. It replaces the timer the auction had.
+func (ar *AuctionRepository) scheduleCompletion(auctionId string, endsAt time.Time) {
+	ar.scheduler.Schedule(auctionId, endsAt.Add(getCompletionGrace()), func() { ar.completeAuction(auctionId, endsAt) })
+}
+
+// startAuction activates the scheduled auction and schedules its end.
+func (ar *AuctionRepository) startAuction(auctionId string, endsAt time.Time) {
+	ctx := context.Background()
+
+	filter := bson.M{"_id": auctionId, "status": auction_entity.Scheduled}
+	update := bson.M{"$set": bson.M{
+		"status":     auction_entity.Active,
+		"updated_at": clock.Now().UnixMilli(),
+	}}
+
+	result, err := ar.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		logger.Error("Error trying to start auction", err)
+		return
+	}
+	if result.ModifiedCount == 0 {
+		return
+	}
+
+	if ar.publisher != nil {
+		ar.publisher.Publish(ctx, event_entity.NewEvent(event_entity.AuctionStarted, auctionId, nil))
+	}
+
+	ar.scheduleCompletion(auctionId, endsAt)
+}
+
+// completeAuction marks the auction completed, unless its end moved past
+// the one it was scheduled for, when it is scheduled again.
+func (ar *AuctionRepository) completeAuction(auctionId string, endsAt time.Time) {
+	ctx := context.Background()
+
+	auctionEntity, err := ar.FindAuctionById(ctx, auctionId)
+	if err != nil || auctionEntity.Status != auction_entity.Active {
+		return
+	}
+
+	if auctionEntity.EndsAt.After(endsAt) {
+		ar.scheduleCompletion(auctionId, auctionEntity.EndsAt)
+		return
+	}
+
+	filter := bson.M{"_id": auctionId, "status": auction_entity.Active}
+	update := bson.M{"$set": bson.M{
+		"status":     auction_entity.Completed,
+		"updated_at": clock.Now().UnixMilli(),
+	}}
+
+	result, errUpdate := ar.Collection.UpdateOne(ctx, filter, update)
+	if errUpdate != nil {
+		logger.Error("Error trying to update auction status", errUpdate)
+		return
+	}
+
+	if result.ModifiedCount > 0 {
+		ar.publishCompleted(ctx, auctionId)
+	}
+}
+
+func (ar *AuctionRepository) publishCompleted(ctx context.Context, auctionId string) {
+	if ar.publisher != nil {
+		ar.publisher.Publish(ctx, event_entity.NewEvent(event_entity.AuctionCompleted, auctionId, nil))
+	}
 }
 
 func getAuctionInterval() time.Duration {
@@ -76,3 +288,14 @@ func getAuctionInterval() time.Duration {
 	}
 	return duration
 }
+
+// getCompletionGrace reads AUCTION_COMPLETION_GRACE, how long completion
+// waits past the end for bids accepted before it to be flushed.
+func getCompletionGrace() time.Duration {
+	completionGrace := os.Getenv("AUCTION_COMPLETION_GRACE")
+	duration, err := time.ParseDuration(completionGrace)
+	if err != nil {
+		return 2 * time.Second
+	}
+	return duration
+}
@@ -8,6 +8,8 @@ import (
 	"fullcycle-auction_go/internal/internal_error"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"regexp"
 	"time"
 )
 
@@ -15,67 +17,331 @@ func (ar *AuctionRepository) FindAuctionById(
 	ctx context.Context, id string) (*auction_entity.Auction, *internal_error.InternalError) {
 	filter := bson.M{"_id": id}
 
-	var auctionEntityMongo AuctionEntityMongo
-	if err := ar.Collection.FindOne(ctx, filter).Decode(&auctionEntityMongo); err != nil {
+	var document bson.M
+	if err := ar.Collection.FindOne(ctx, filter).Decode(&document); err != nil {
 		logger.Error(fmt.Sprintf("Error trying to find auction by id = %s", id), err)
 		return nil, internal_error.NewInternalServerError("Error trying to find auction by id")
 	}
 
-	return &auction_entity.Auction{
-		Id:          auctionEntityMongo.Id,
-		ProductName: auctionEntityMongo.ProductName,
-		Category:    auctionEntityMongo.Category,
-		Description: auctionEntityMongo.Description,
-		Condition:   auctionEntityMongo.Condition,
-		Status:      auctionEntityMongo.Status,
-		Timestamp:   time.Unix(auctionEntityMongo.Timestamp, 0),
-	}, nil
+	var auctionEntityMongo AuctionEntityMongo
+	if err := AuctionUpcasters.Decode(document, &auctionEntityMongo); err != nil {
+		logger.Error(fmt.Sprintf("Error trying to decode auction by id = %s", id), err)
+		return nil, internal_error.NewInternalServerError("Error trying to find auction by id")
+	}
+
+	return auctionEntityMongo.toAuctionEntity(), nil
 }
 
-func (repo *AuctionRepository) FindAuctions(
-	ctx context.Context,
-	status auction_entity.AuctionStatus,
-	category string,
-	productName string) ([]auction_entity.Auction, *internal_error.InternalError) {
-	filter := bson.M{}
+func (ar *AuctionRepository) FindAuctionsByIds(
+	ctx context.Context, ids []string) ([]auction_entity.Auction, *internal_error.InternalError) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
 
-	if status != 0 {
-		filter["status"] = status
+	filter := bson.M{"_id": bson.M{"$in": ids}}
+
+	cursor, err := ar.Collection.Find(ctx, filter)
+	if err != nil {
+		logger.Error("Error trying to find auctions by ids", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find auctions by ids")
 	}
+	defer cursor.Close(ctx)
 
-	if category != "" {
-		filter["category"] = category
+	var documents []bson.M
+	if err := cursor.All(ctx, &documents); err != nil {
+		logger.Error("Error trying to find auctions by ids", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find auctions by ids")
 	}
 
-	if productName != "" {
-		filter["productName"] = primitive.Regex{Pattern: productName, Options: "i"}
+	var auctionsEntity []auction_entity.Auction
+	for _, document := range documents {
+		var auction AuctionEntityMongo
+		if err := AuctionUpcasters.Decode(document, &auction); err != nil {
+			logger.Error("Error trying to decode auctions by ids", err)
+			return nil, internal_error.NewInternalServerError("Error trying to find auctions by ids")
+		}
+
+		auctionsEntity = append(auctionsEntity, *auction.toAuctionEntity())
+	}
+
+	return auctionsEntity, nil
+}
+
+func (repo *AuctionRepository) FindAuctions(
+	ctx context.Context,
+	auctionFilter auction_entity.AuctionFilter,
+	fields []string) ([]auction_entity.Auction, *internal_error.InternalError) {
+	filter := listingFilter(auctionFilter)
+
+	opts := options.Find()
+	if len(fields) > 0 {
+		opts.SetProjection(projection(fields))
 	}
 
-	cursor, err := repo.Collection.Find(ctx, filter)
+	cursor, err := repo.Collection.Find(ctx, filter, opts)
 	if err != nil {
 		logger.Error("Error finding auctions", err)
 		return nil, internal_error.NewInternalServerError("Error finding auctions")
 	}
 	defer cursor.Close(ctx)
 
-	var auctionsMongo []AuctionEntityMongo
-	if err := cursor.All(ctx, &auctionsMongo); err != nil {
+	var documents []bson.M
+	if err := cursor.All(ctx, &documents); err != nil {
 		logger.Error("Error decoding auctions", err)
 		return nil, internal_error.NewInternalServerError("Error decoding auctions")
 	}
 
 	var auctionsEntity []auction_entity.Auction
-	for _, auction := range auctionsMongo {
-		auctionsEntity = append(auctionsEntity, auction_entity.Auction{
-			Id:          auction.Id,
-			ProductName: auction.ProductName,
-			Category:    auction.Category,
-			Status:      auction.Status,
-			Description: auction.Description,
-			Condition:   auction.Condition,
-			Timestamp:   time.Unix(auction.Timestamp, 0),
-		})
+	for _, document := range documents {
+		var auction AuctionEntityMongo
+		if err := AuctionUpcasters.Decode(document, &auction); err != nil {
+			logger.Error("Error decoding auctions", err)
+			return nil, internal_error.NewInternalServerError("Error decoding auctions")
+		}
+
+		auctionsEntity = append(auctionsEntity, *auction.toAuctionEntity())
+	}
+
+	return auctionsEntity, nil
+}
+
+// upcastSources lists the fields the upcasters derive a field from, which
+// older documents need loaded along with it.
+var upcastSources = map[string][]string{
+	"grading":    {"condition"},
+	"updated_at": {"timestamp"},
+	"ends_at":    {"timestamp"},
+}
+
+// projection loads the fields, whose stored names match the API ones but
+// for the id, along with the schema version the upcasters start from.
+func projection(fields []string) bson.M {
+	projection := bson.M{"schema_version": 1}
+	for _, field := range fields {
+		if field == "id" {
+			continue
+		}
+
+		projection[field] = 1
+		for _, source := range upcastSources[field] {
+			projection[source] = 1
+		}
+	}
+
+	return projection
+}
+
+// listingFilter translates the filter into a query the listing indexes
+// serve.
+func listingFilter(auctionFilter auction_entity.AuctionFilter) bson.M {
+	filter := bson.M{}
+
+	if auctionFilter.Status != 0 {
+		filter["status"] = auctionFilter.Status
+	}
+
+	if len(auctionFilter.Categories) > 0 {
+		filter["category"] = bson.M{"$in": auctionFilter.Categories}
+	}
+
+	if auctionFilter.ProductName != "" {
+		filter["product_name"] = primitive.Regex{Pattern: regexp.QuoteMeta(auctionFilter.ProductName), Options: "i"}
+	}
+
+	for name, value := range auctionFilter.Attributes {
+		filter["attributes."+name] = value
+	}
+
+	// A zero starting price is not stored, which $not matches.
+	price := bson.M{}
+	if auctionFilter.MinPrice > 0 {
+		price["$gte"] = auctionFilter.MinPrice
+	}
+	if auctionFilter.MaxPrice > 0 {
+		price["$not"] = bson.M{"$gt": auctionFilter.MaxPrice}
+	}
+	if len(price) > 0 {
+		filter["starting_price"] = price
+	}
+
+	endsAt := bson.M{}
+	if !auctionFilter.EndingAfter.IsZero() {
+		endsAt["$gt"] = auctionFilter.EndingAfter.UnixMilli()
+	}
+	if !auctionFilter.EndingBefore.IsZero() {
+		endsAt["$lt"] = auctionFilter.EndingBefore.UnixMilli()
+	}
+	if len(endsAt) > 0 {
+		filter["ends_at"] = endsAt
+	}
+
+	if !auctionFilter.CreatedAfter.IsZero() {
+		filter["timestamp"] = bson.M{"$gt": auctionFilter.CreatedAfter.Unix()}
+	}
+
+	return filter
+}
+
+func (am *AuctionEntityMongo) toAuctionEntity() *auction_entity.Auction {
+	auctionEntity := &auction_entity.Auction{
+		Id:          am.Id,
+		SellerId:    am.SellerId,
+		ProductId:   am.ProductId,
+		ProductName: am.ProductName,
+		Category:    am.Category,
+		Description: am.Description,
+		Condition:   am.Condition,
+		Attributes:  am.Attributes,
+		Status:      am.Status,
+		Timestamp:   time.Unix(am.Timestamp, 0),
+		UpdatedAt:   time.UnixMilli(am.UpdatedAt),
+		StartsAt:    fromUnixMilli(am.StartsAt),
+		EndsAt:      time.UnixMilli(am.EndsAt),
+		WinnerBidId: am.WinnerBidId,
+
+		BidderVisibility:   am.BidderVisibility,
+		RecurringAuctionId: am.RecurringAuctionId,
+		SeriesId:           am.SeriesId,
+		LotNumber:          am.LotNumber,
+
+		Live:            am.Live,
+		BiddingOpenedAt: fromUnixMilli(am.BiddingOpenedAt),
+
+		ReservePrice: am.ReservePrice,
+		MinIncrement: am.MinIncrement,
+		Sealed:       am.Sealed,
+		BuyNowPrice:  am.BuyNowPrice,
+
+		StartingPrice: am.StartingPrice,
+
+		Quantity:     am.Quantity,
+		UniformPrice: am.UniformPrice,
+
+		EstimateLow:  am.EstimateLow,
+		EstimateHigh: am.EstimateHigh,
+
+		RegistrationRequired: am.RegistrationRequired,
+
+		PausedAt: fromUnixMilli(am.PausedAt),
+
+		PublicAt: fromUnixMilli(am.PublicAt),
+		Invitees: am.Invitees,
+
+		RelistedFrom: am.RelistedFrom,
+
+		HeatScore: am.HeatScore,
+		Views:     am.Views,
+	}
+
+	if am.Grading != nil {
+		auctionEntity.Grading = auction_entity.ConditionGrading{
+			Grade:           am.Grading.Grade,
+			Defects:         am.Grading.Defects,
+			InspectionNotes: am.Grading.InspectionNotes,
+			GraderId:        am.Grading.GraderId,
+		}
+	}
+
+	if len(am.Descriptions) > 0 {
+		auctionEntity.Descriptions = make(map[string]string, len(am.Descriptions))
+		for _, description := range am.Descriptions {
+			auctionEntity.Descriptions[description.Language] = description.Text
+		}
+	}
+
+	if am.Terms != nil {
+		auctionEntity.Terms = &auction_entity.Terms{
+			ReturnsPolicy:       am.Terms.ReturnsPolicy,
+			PaymentDeadlineDays: am.Terms.PaymentDeadlineDays,
+			Version:             am.Terms.Version,
+		}
+	}
+
+	return auctionEntity
+}
+
+func (ar *AuctionRepository) FindEstimatedAuctions(
+	ctx context.Context,
+	sellerId, category string) ([]auction_entity.Auction, *internal_error.InternalError) {
+	filter := bson.M{
+		"status": bson.M{"$in": []auction_entity.AuctionStatus{
+			auction_entity.Completed, auction_entity.ClosedNoSale}},
+		"estimate_high": bson.M{"$gt": 0},
+	}
+	if sellerId != "" {
+		filter["seller_id"] = sellerId
+	}
+	if category != "" {
+		filter["category"] = category
+	}
+
+	cursor, err := ar.Collection.Find(ctx, filter)
+	if err != nil {
+		logger.Error("Error finding estimated auctions", err)
+		return nil, internal_error.NewInternalServerError("Error finding estimated auctions")
+	}
+	defer cursor.Close(ctx)
+
+	var documents []bson.M
+	if err := cursor.All(ctx, &documents); err != nil {
+		logger.Error("Error decoding estimated auctions", err)
+		return nil, internal_error.NewInternalServerError("Error decoding estimated auctions")
+	}
+
+	var auctionsEntity []auction_entity.Auction
+	for _, document := range documents {
+		var auction AuctionEntityMongo
+		if err := AuctionUpcasters.Decode(document, &auction); err != nil {
+			logger.Error("Error decoding estimated auctions", err)
+			return nil, internal_error.NewInternalServerError("Error decoding estimated auctions")
+		}
+
+		auctionsEntity = append(auctionsEntity, *auction.toAuctionEntity())
 	}
 
 	return auctionsEntity, nil
 }
+
+func (ar *AuctionRepository) FindSellerAuctions(
+	ctx context.Context,
+	sellerId string,
+	status auction_entity.AuctionStatus,
+	offset, limit int) ([]auction_entity.Auction, int, *internal_error.InternalError) {
+	filter := bson.M{"seller_id": sellerId, "status": status}
+
+	total, err := ar.Collection.CountDocuments(ctx, filter)
+	if err != nil {
+		logger.Error("Error counting seller auctions", err)
+		return nil, 0, internal_error.NewInternalServerError("Error finding seller auctions")
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "timestamp", Value: -1}, {Key: "_id", Value: 1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+	cursor, err := ar.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		logger.Error("Error finding seller auctions", err)
+		return nil, 0, internal_error.NewInternalServerError("Error finding seller auctions")
+	}
+	defer cursor.Close(ctx)
+
+	var documents []bson.M
+	if err := cursor.All(ctx, &documents); err != nil {
+		logger.Error("Error decoding seller auctions", err)
+		return nil, 0, internal_error.NewInternalServerError("Error decoding seller auctions")
+	}
+
+	var auctionsEntity []auction_entity.Auction
+	for _, document := range documents {
+		var auction AuctionEntityMongo
+		if err := AuctionUpcasters.Decode(document, &auction); err != nil {
+			logger.Error("Error decoding seller auctions", err)
+			return nil, 0, internal_error.NewInternalServerError("Error decoding seller auctions")
+		}
+
+		auctionsEntity = append(auctionsEntity, *auction.toAuctionEntity())
+	}
+
+	return auctionsEntity, int(total), nil
+}
@@ -0,0 +1,44 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (ar *AuctionRepository) FindAuctionChanges(
+	ctx context.Context, since time.Time) ([]auction_entity.Auction, *internal_error.InternalError) {
+	filter := bson.M{"updated_at": bson.M{"$gt": since.UnixMilli()}}
+	opts := options.Find().SetSort(bson.D{{Key: "updated_at", Value: 1}})
+
+	cursor, err := ar.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		logger.Error("Error finding auction changes", err)
+		return nil, internal_error.NewInternalServerError("Error finding auction changes")
+	}
+	defer cursor.Close(ctx)
+
+	var documents []bson.M
+	if err := cursor.All(ctx, &documents); err != nil {
+		logger.Error("Error decoding auction changes", err)
+		return nil, internal_error.NewInternalServerError("Error decoding auction changes")
+	}
+
+	var auctionsEntity []auction_entity.Auction
+	for _, document := range documents {
+		var auction AuctionEntityMongo
+		if err := AuctionUpcasters.Decode(document, &auction); err != nil {
+			logger.Error("Error decoding auction changes", err)
+			return nil, internal_error.NewInternalServerError("Error decoding auction changes")
+		}
+
+		auctionsEntity = append(auctionsEntity, *auction.toAuctionEntity())
+	}
+
+	return auctionsEntity, nil
+}
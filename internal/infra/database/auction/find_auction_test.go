@@ -0,0 +1,42 @@
+package auction
+
+import (
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestListingFilter(t *testing.T) {
+	endingAfter := time.UnixMilli(1_700_000_000_000)
+	createdAfter := time.Unix(1_690_000_000, 0)
+
+	filter := listingFilter(auction_entity.AuctionFilter{
+		Status:       auction_entity.Completed,
+		ProductName:  "c++",
+		MinPrice:     10,
+		MaxPrice:     100,
+		EndingAfter:  endingAfter,
+		EndingBefore: endingAfter.Add(time.Hour),
+		CreatedAfter: createdAfter,
+	})
+
+	assert.Equal(t, bson.M{
+		"status":       auction_entity.Completed,
+		"product_name": primitive.Regex{Pattern: `c\+\+`, Options: "i"},
+		"starting_price": bson.M{
+			"$gte": 10.0,
+			"$not": bson.M{"$gt": 100.0},
+		},
+		"ends_at": bson.M{
+			"$gt": endingAfter.UnixMilli(),
+			"$lt": endingAfter.Add(time.Hour).UnixMilli(),
+		},
+		"timestamp": bson.M{"$gt": createdAfter.Unix()},
+	}, filter)
+
+	assert.Empty(t, listingFilter(auction_entity.AuctionFilter{}))
+}
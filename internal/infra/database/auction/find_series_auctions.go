@@ -0,0 +1,43 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (ar *AuctionRepository) FindSeriesAuctions(
+	ctx context.Context, seriesId string) ([]auction_entity.Auction, *internal_error.InternalError) {
+	filter := bson.M{"series_id": seriesId}
+	opts := options.Find().SetSort(bson.D{{Key: "lot_number", Value: 1}})
+
+	cursor, err := ar.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		logger.Error("Error finding series auctions", err)
+		return nil, internal_error.NewInternalServerError("Error finding series auctions")
+	}
+	defer cursor.Close(ctx)
+
+	var documents []bson.M
+	if err := cursor.All(ctx, &documents); err != nil {
+		logger.Error("Error decoding series auctions", err)
+		return nil, internal_error.NewInternalServerError("Error decoding series auctions")
+	}
+
+	var auctionsEntity []auction_entity.Auction
+	for _, document := range documents {
+		var auction AuctionEntityMongo
+		if err := AuctionUpcasters.Decode(document, &auction); err != nil {
+			logger.Error("Error decoding series auctions", err)
+			return nil, internal_error.NewInternalServerError("Error decoding series auctions")
+		}
+
+		auctionsEntity = append(auctionsEntity, *auction.toAuctionEntity())
+	}
+
+	return auctionsEntity, nil
+}
@@ -0,0 +1,29 @@
+package auction
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CreateListingIndexes creates the indexes serving the price and time
+// bounds of FindAuctions, doing nothing for those that exist.
+func (ar *AuctionRepository) CreateListingIndexes(ctx context.Context) error {
+	_, err := ar.Collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "starting_price", Value: 1}},
+			Options: options.Index().SetName("listing_starting_price"),
+		},
+		{
+			Keys:    bson.D{{Key: "ends_at", Value: 1}},
+			Options: options.Index().SetName("listing_ends_at"),
+		},
+		{
+			Keys:    bson.D{{Key: "timestamp", Value: -1}},
+			Options: options.Index().SetName("listing_timestamp"),
+		},
+	})
+	return err
+}
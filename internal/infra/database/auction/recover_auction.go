@@ -0,0 +1,49 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// RecoverAuctions re-arms the timers of the scheduled and active auctions,
+// which live in memory and are lost when the process stops. Auctions that ended
+// while it was down are completed right away, and the ones that should have
+// started meanwhile are started first.
+func (ar *AuctionRepository) RecoverAuctions(ctx context.Context) error {
+	filter := bson.M{"status": bson.M{"$in": []auction_entity.AuctionStatus{
+		auction_entity.Active, auction_entity.Scheduled}}}
+	opts := options.Find().SetProjection(bson.M{"status": 1, "starts_at": 1, "ends_at": 1})
+
+	cursor, err := ar.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		logger.Error("Error trying to find auctions to recover", err)
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	var auctionsMongo []AuctionEntityMongo
+	if err := cursor.All(ctx, &auctionsMongo); err != nil {
+		logger.Error("Error trying to decode auctions to recover", err)
+		return err
+	}
+
+	// Documents written before ends_at existed decode with a zero end,
+	// which completeAuction corrects on its first read.
+	for _, auctionMongo := range auctionsMongo {
+		if auctionMongo.Status == auction_entity.Scheduled {
+			ar.scheduleStart(auctionMongo.Id,
+				fromUnixMilli(auctionMongo.StartsAt), fromUnixMilli(auctionMongo.EndsAt))
+		} else {
+			ar.scheduleCompletion(auctionMongo.Id, fromUnixMilli(auctionMongo.EndsAt))
+		}
+	}
+
+	logger.Info("Auction timers recovered", zap.Int("auctions", len(auctionsMongo)))
+
+	return nil
+}
@@ -0,0 +1,238 @@
+package auction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type AuctionTemplateMongo struct {
+	ProductId        string                          `bson:"product_id,omitempty"`
+	ProductName      string                          `bson:"product_name"`
+	Category         string                          `bson:"category"`
+	Description      string                          `bson:"description"`
+	Grading          ConditionGradingMongo           `bson:"grading"`
+	Attributes       map[string]string               `bson:"attributes,omitempty"`
+	BidderVisibility auction_entity.BidderVisibility `bson:"bidder_visibility,omitempty"`
+	Terms            *TermsMongo                     `bson:"terms,omitempty"`
+}
+
+type RecurringAuctionEntityMongo struct {
+	Id        string               `bson:"_id"`
+	SellerId  string               `bson:"seller_id"`
+	Schedule  string               `bson:"schedule"`
+	Timezone  string               `bson:"timezone,omitempty"`
+	Template  AuctionTemplateMongo `bson:"template"`
+	Active    bool                 `bson:"active"`
+	NextRunAt int64                `bson:"next_run_at"`
+	LastRunAt int64                `bson:"last_run_at"`
+	CreatedAt int64                `bson:"created_at"`
+}
+
+type RecurringAuctionRepository struct {
+	Collection *mongo.Collection
+}
+
+func NewRecurringAuctionRepository(database *mongo.Database) *RecurringAuctionRepository {
+	return &RecurringAuctionRepository{
+		Collection: database.Collection("recurring_auctions"),
+	}
+}
+
+func (rr *RecurringAuctionRepository) CreateRecurringAuction(
+	ctx context.Context, recurringAuction *auction_entity.RecurringAuction) *internal_error.InternalError {
+	template := recurringAuction.Template
+	recurringAuctionMongo := &RecurringAuctionEntityMongo{
+		Id:       recurringAuction.Id,
+		SellerId: recurringAuction.SellerId,
+		Schedule: recurringAuction.Schedule,
+		Timezone: recurringAuction.Timezone,
+		Template: AuctionTemplateMongo{
+			ProductId:   template.ProductId,
+			ProductName: template.ProductName,
+			Category:    template.Category,
+			Description: template.Description,
+			Grading: ConditionGradingMongo{
+				Grade:           template.Grading.Grade,
+				Defects:         template.Grading.Defects,
+				InspectionNotes: template.Grading.InspectionNotes,
+				GraderId:        template.Grading.GraderId,
+			},
+			Attributes:       template.Attributes,
+			BidderVisibility: template.BidderVisibility,
+		},
+		Active:    recurringAuction.Active,
+		NextRunAt: toUnixMilli(recurringAuction.NextRunAt),
+		LastRunAt: toUnixMilli(recurringAuction.LastRunAt),
+		CreatedAt: recurringAuction.CreatedAt.UnixMilli(),
+	}
+
+	if template.Terms != nil {
+		recurringAuctionMongo.Template.Terms = &TermsMongo{
+			ReturnsPolicy:       template.Terms.ReturnsPolicy,
+			PaymentDeadlineDays: template.Terms.PaymentDeadlineDays,
+			Version:             template.Terms.Version,
+		}
+	}
+
+	if _, err := rr.Collection.InsertOne(ctx, recurringAuctionMongo); err != nil {
+		logger.Error("Error trying to insert recurring auction", err)
+		return internal_error.NewInternalServerError("Error trying to insert recurring auction")
+	}
+
+	return nil
+}
+
+func (rr *RecurringAuctionRepository) FindRecurringAuctionById(
+	ctx context.Context, id string) (*auction_entity.RecurringAuction, *internal_error.InternalError) {
+	var recurringAuctionMongo RecurringAuctionEntityMongo
+	if err := rr.Collection.FindOne(ctx, bson.M{"_id": id}).Decode(&recurringAuctionMongo); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, internal_error.NewNotFoundError(
+				fmt.Sprintf("Recurring auction not found with this id = %s", id))
+		}
+
+		logger.Error(fmt.Sprintf("Error trying to find recurring auction by id = %s", id), err)
+		return nil, internal_error.NewInternalServerError("Error trying to find recurring auction by id")
+	}
+
+	return recurringAuctionMongo.toRecurringAuctionEntity(), nil
+}
+
+func (rr *RecurringAuctionRepository) FindRecurringAuctions(
+	ctx context.Context, sellerId string) ([]auction_entity.RecurringAuction, *internal_error.InternalError) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	return rr.findRecurringAuctions(ctx, bson.M{"seller_id": sellerId}, opts)
+}
+
+func (rr *RecurringAuctionRepository) FindDueRecurringAuctions(
+	ctx context.Context, now time.Time) ([]auction_entity.RecurringAuction, *internal_error.InternalError) {
+	filter := bson.M{
+		"active":      true,
+		"next_run_at": bson.M{"$gt": 0, "$lte": now.UnixMilli()},
+	}
+
+	return rr.findRecurringAuctions(ctx, filter, options.Find())
+}
+
+func (rr *RecurringAuctionRepository) findRecurringAuctions(
+	ctx context.Context,
+	filter bson.M,
+	opts *options.FindOptions) ([]auction_entity.RecurringAuction, *internal_error.InternalError) {
+	cursor, err := rr.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		logger.Error("Error finding recurring auctions", err)
+		return nil, internal_error.NewInternalServerError("Error finding recurring auctions")
+	}
+	defer cursor.Close(ctx)
+
+	var recurringAuctionsMongo []RecurringAuctionEntityMongo
+	if err := cursor.All(ctx, &recurringAuctionsMongo); err != nil {
+		logger.Error("Error decoding recurring auctions", err)
+		return nil, internal_error.NewInternalServerError("Error decoding recurring auctions")
+	}
+
+	var recurringAuctions []auction_entity.RecurringAuction
+	for _, recurringAuctionMongo := range recurringAuctionsMongo {
+		recurringAuctions = append(recurringAuctions, *recurringAuctionMongo.toRecurringAuctionEntity())
+	}
+
+	return recurringAuctions, nil
+}
+
+func (rr *RecurringAuctionRepository) UpdateRecurringAuctionRun(
+	ctx context.Context, id string, runAt, nextRunAt time.Time) *internal_error.InternalError {
+	filter := bson.M{"_id": id, "next_run_at": runAt.UnixMilli()}
+	update := bson.M{"$set": bson.M{
+		"next_run_at": toUnixMilli(nextRunAt),
+		"last_run_at": runAt.UnixMilli(),
+	}}
+
+	return rr.update(ctx, filter, update)
+}
+
+func (rr *RecurringAuctionRepository) UpdateRecurringAuctionActive(
+	ctx context.Context, id string, active bool, nextRunAt time.Time) *internal_error.InternalError {
+	filter := bson.M{"_id": id}
+	update := bson.M{"$set": bson.M{
+		"active":      active,
+		"next_run_at": toUnixMilli(nextRunAt),
+	}}
+
+	return rr.update(ctx, filter, update)
+}
+
+func (rr *RecurringAuctionRepository) update(
+	ctx context.Context, filter, update bson.M) *internal_error.InternalError {
+	result, err := rr.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		logger.Error("Error trying to update recurring auction", err)
+		return internal_error.NewInternalServerError("Error trying to update recurring auction")
+	}
+	if result.MatchedCount == 0 {
+		return internal_error.NewNotFoundError("Recurring auction not found")
+	}
+
+	return nil
+}
+
+func (rm *RecurringAuctionEntityMongo) toRecurringAuctionEntity() *auction_entity.RecurringAuction {
+	template := rm.Template
+	recurringAuction := &auction_entity.RecurringAuction{
+		Id:       rm.Id,
+		SellerId: rm.SellerId,
+		Schedule: rm.Schedule,
+		Timezone: rm.Timezone,
+		Template: auction_entity.AuctionTemplate{
+			ProductId:   template.ProductId,
+			ProductName: template.ProductName,
+			Category:    template.Category,
+			Description: template.Description,
+			Grading: auction_entity.ConditionGrading{
+				Grade:           template.Grading.Grade,
+				Defects:         template.Grading.Defects,
+				InspectionNotes: template.Grading.InspectionNotes,
+				GraderId:        template.Grading.GraderId,
+			},
+			Attributes:       template.Attributes,
+			BidderVisibility: template.BidderVisibility,
+		},
+		Active:    rm.Active,
+		NextRunAt: fromUnixMilli(rm.NextRunAt),
+		LastRunAt: fromUnixMilli(rm.LastRunAt),
+		CreatedAt: time.UnixMilli(rm.CreatedAt),
+	}
+
+	if template.Terms != nil {
+		recurringAuction.Template.Terms = &auction_entity.Terms{
+			ReturnsPolicy:       template.Terms.ReturnsPolicy,
+			PaymentDeadlineDays: template.Terms.PaymentDeadlineDays,
+			Version:             template.Terms.Version,
+		}
+	}
+
+	return recurringAuction
+}
+
+// Unset times are stored as 0 rather than the zero time.
+func toUnixMilli(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMilli()
+}
+
+func fromUnixMilli(millis int64) time.Time {
+	if millis == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(millis)
+}
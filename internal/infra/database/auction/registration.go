@@ -0,0 +1,139 @@
+package auction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/configuration/clock"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type RegistrationEntityMongo struct {
+	Id        string                            `bson:"_id"`
+	AuctionId string                            `bson:"auction_id"`
+	UserId    string                            `bson:"user_id"`
+	Deposit   float64                           `bson:"deposit,omitempty"`
+	Status    auction_entity.RegistrationStatus `bson:"status"`
+	Timestamp int64                             `bson:"timestamp"`
+	DecidedBy string                            `bson:"decided_by,omitempty"`
+	DecidedAt int64                             `bson:"decided_at,omitempty"`
+}
+
+type RegistrationRepository struct {
+	Collection *mongo.Collection
+}
+
+func NewRegistrationRepository(database *mongo.Database) *RegistrationRepository {
+	return &RegistrationRepository{
+		Collection: database.Collection("registrations"),
+	}
+}
+
+func (rr *RegistrationRepository) UpsertRegistration(
+	ctx context.Context, registration *auction_entity.Registration) *internal_error.InternalError {
+	filter := bson.M{"auction_id": registration.AuctionId, "user_id": registration.UserId}
+	update := bson.M{
+		"$set": bson.M{
+			"deposit":   registration.Deposit,
+			"status":    registration.Status,
+			"timestamp": registration.Timestamp.UnixMilli(),
+		},
+		"$unset":       bson.M{"decided_by": "", "decided_at": ""},
+		"$setOnInsert": bson.M{"_id": registration.Id},
+	}
+
+	opts := options.Update().SetUpsert(true)
+	if _, err := rr.Collection.UpdateOne(ctx, filter, update, opts); err != nil {
+		logger.Error("Error trying to save registration", err)
+		return internal_error.NewInternalServerError("Error trying to save registration")
+	}
+
+	return nil
+}
+
+func (rr *RegistrationRepository) FindRegistration(
+	ctx context.Context, auctionId, userId string) (*auction_entity.Registration, *internal_error.InternalError) {
+	var registrationMongo RegistrationEntityMongo
+	filter := bson.M{"auction_id": auctionId, "user_id": userId}
+	if err := rr.Collection.FindOne(ctx, filter).Decode(&registrationMongo); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, internal_error.NewNotFoundError(
+				fmt.Sprintf("Registration not found for auction = %s and user = %s", auctionId, userId))
+		}
+
+		logger.Error("Error trying to find registration", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find registration")
+	}
+
+	return registrationMongo.toRegistrationEntity(), nil
+}
+
+func (rr *RegistrationRepository) FindRegistrations(
+	ctx context.Context, auctionId string) ([]auction_entity.Registration, *internal_error.InternalError) {
+	opts := options.Find().SetSort(bson.D{{Key: "timestamp", Value: 1}})
+	cursor, err := rr.Collection.Find(ctx, bson.M{"auction_id": auctionId}, opts)
+	if err != nil {
+		logger.Error("Error finding registrations", err)
+		return nil, internal_error.NewInternalServerError("Error finding registrations")
+	}
+	defer cursor.Close(ctx)
+
+	var registrationsMongo []RegistrationEntityMongo
+	if err := cursor.All(ctx, &registrationsMongo); err != nil {
+		logger.Error("Error decoding registrations", err)
+		return nil, internal_error.NewInternalServerError("Error decoding registrations")
+	}
+
+	var registrations []auction_entity.Registration
+	for _, registrationMongo := range registrationsMongo {
+		registrations = append(registrations, *registrationMongo.toRegistrationEntity())
+	}
+
+	return registrations, nil
+}
+
+func (rr *RegistrationRepository) DecideRegistration(
+	ctx context.Context,
+	auctionId, userId string,
+	status auction_entity.RegistrationStatus,
+	decidedBy string) *internal_error.InternalError {
+	filter := bson.M{"auction_id": auctionId, "user_id": userId}
+	update := bson.M{"$set": bson.M{
+		"status":     status,
+		"decided_by": decidedBy,
+		"decided_at": clock.Now().UnixMilli(),
+	}}
+
+	result, err := rr.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		logger.Error("Error trying to update registration", err)
+		return internal_error.NewInternalServerError("Error trying to update registration")
+	}
+
+	if result.MatchedCount == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("Registration not found for auction = %s and user = %s", auctionId, userId))
+	}
+
+	return nil
+}
+
+func (rm *RegistrationEntityMongo) toRegistrationEntity() *auction_entity.Registration {
+	return &auction_entity.Registration{
+		Id:        rm.Id,
+		AuctionId: rm.AuctionId,
+		UserId:    rm.UserId,
+		Deposit:   rm.Deposit,
+		Status:    rm.Status,
+		Timestamp: time.UnixMilli(rm.Timestamp),
+		DecidedBy: rm.DecidedBy,
+		DecidedAt: fromUnixMilli(rm.DecidedAt),
+	}
+}
@@ -0,0 +1,110 @@
+package auction
+
+import (
+	"context"
+	"errors"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"sort"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// searchIndexName is the text index over the product name and the
+// descriptions, weighted as auction_entity ranks the other storages. The
+// language of each translated description overrides the one of the index,
+// so it is stemmed and stripped of stop words in its own language, while
+// the name and the default description, in no known language, are not.
+const searchIndexName = "search_text"
+
+// legacySearchIndexName is the former text index over the translated
+// descriptions alone. A collection holds a single text index, so it is
+// dropped for searchIndexName.
+const legacySearchIndexName = "descriptions_text"
+
+// CreateSearchIndex creates the index SearchAuctions needs, doing nothing
+// when it exists.
+func (ar *AuctionRepository) CreateSearchIndex(ctx context.Context) error {
+	if _, err := ar.Collection.Indexes().DropOne(ctx, legacySearchIndexName); err != nil && !isIndexNotFound(err) {
+		return err
+	}
+
+	_, err := ar.Collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "product_name", Value: "text"},
+			{Key: "description", Value: "text"},
+			{Key: "descriptions.text", Value: "text"},
+		},
+		Options: options.Index().
+			SetName(searchIndexName).
+			SetWeights(bson.D{
+				{Key: "product_name", Value: auction_entity.ProductNameSearchWeight},
+				{Key: "description", Value: auction_entity.DescriptionSearchWeight},
+				{Key: "descriptions.text", Value: auction_entity.DescriptionSearchWeight},
+			}).
+			SetDefaultLanguage("none").
+			SetLanguageOverride("language"),
+	})
+	return err
+}
+
+// isIndexNotFound tells whether dropping an index failed only because the
+// index, or the whole collection, does not exist.
+func isIndexNotFound(err error) bool {
+	var commandErr mongo.CommandError
+	return errors.As(err, &commandErr) && (commandErr.Code == 26 || commandErr.Code == 27)
+}
+
+// SearchAuctions queries the text index, stemming text in the language,
+// best matches first.
+func (ar *AuctionRepository) SearchAuctions(
+	ctx context.Context, language, text string) ([]auction_entity.Auction, *internal_error.InternalError) {
+	filter := bson.M{
+		"$text": bson.M{"$search": text, "$language": language},
+	}
+	opts := options.Find().SetSort(bson.M{"score": bson.M{"$meta": "textScore"}})
+
+	cursor, err := ar.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		logger.Error("Error searching auctions", err)
+		return nil, internal_error.NewInternalServerError("Error searching auctions")
+	}
+	defer cursor.Close(ctx)
+
+	var documents []bson.M
+	if err := cursor.All(ctx, &documents); err != nil {
+		logger.Error("Error decoding auctions", err)
+		return nil, internal_error.NewInternalServerError("Error decoding auctions")
+	}
+
+	var auctionsEntity []auction_entity.Auction
+	for _, document := range documents {
+		var auction AuctionEntityMongo
+		if err := AuctionUpcasters.Decode(document, &auction); err != nil {
+			logger.Error("Error decoding auctions", err)
+			return nil, internal_error.NewInternalServerError("Error decoding auctions")
+		}
+
+		auctionsEntity = append(auctionsEntity, *auction.toAuctionEntity())
+	}
+
+	return auctionsEntity, nil
+}
+
+// toDescriptionsMongo orders the descriptions by language, so documents do
+// not change with the iteration order of the map.
+func toDescriptionsMongo(descriptions map[string]string) []DescriptionMongo {
+	descriptionsMongo := make([]DescriptionMongo, 0, len(descriptions))
+	for language, text := range descriptions {
+		descriptionsMongo = append(descriptionsMongo, DescriptionMongo{Language: language, Text: text})
+	}
+
+	sort.Slice(descriptionsMongo, func(i, j int) bool {
+		return descriptionsMongo[i].Language < descriptionsMongo[j].Language
+	})
+
+	return descriptionsMongo
+}
@@ -0,0 +1,151 @@
+package auction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type SeriesEntityMongo struct {
+	Id          string `bson:"_id"`
+	SellerId    string `bson:"seller_id"`
+	Name        string `bson:"name"`
+	Description string `bson:"description,omitempty"`
+	LotCount    int    `bson:"lot_count"`
+	// CascadeInterval is in milliseconds.
+	CascadeInterval int64 `bson:"cascade_interval,omitempty"`
+	CreatedAt       int64 `bson:"created_at"`
+}
+
+type SeriesRepository struct {
+	Collection *mongo.Collection
+}
+
+func NewSeriesRepository(database *mongo.Database) *SeriesRepository {
+	return &SeriesRepository{
+		Collection: database.Collection("auction_series"),
+	}
+}
+
+func (sr *SeriesRepository) CreateSeries(
+	ctx context.Context, series *auction_entity.Series) *internal_error.InternalError {
+	seriesMongo := &SeriesEntityMongo{
+		Id:              series.Id,
+		SellerId:        series.SellerId,
+		Name:            series.Name,
+		Description:     series.Description,
+		LotCount:        series.LotCount,
+		CascadeInterval: series.CascadeInterval.Milliseconds(),
+		CreatedAt:       series.CreatedAt.UnixMilli(),
+	}
+
+	if _, err := sr.Collection.InsertOne(ctx, seriesMongo); err != nil {
+		logger.Error("Error trying to insert series", err)
+		return internal_error.NewInternalServerError("Error trying to insert series")
+	}
+
+	return nil
+}
+
+func (sr *SeriesRepository) FindSeriesById(
+	ctx context.Context, id string) (*auction_entity.Series, *internal_error.InternalError) {
+	var seriesMongo SeriesEntityMongo
+	if err := sr.Collection.FindOne(ctx, bson.M{"_id": id}).Decode(&seriesMongo); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, internal_error.NewNotFoundError(
+				fmt.Sprintf("Series not found with this id = %s", id))
+		}
+
+		logger.Error(fmt.Sprintf("Error trying to find series by id = %s", id), err)
+		return nil, internal_error.NewInternalServerError("Error trying to find series by id")
+	}
+
+	return seriesMongo.toSeriesEntity(), nil
+}
+
+func (sr *SeriesRepository) FindSeries(
+	ctx context.Context, sellerId string) ([]auction_entity.Series, *internal_error.InternalError) {
+	filter := bson.M{}
+	if sellerId != "" {
+		filter["seller_id"] = sellerId
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := sr.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		logger.Error("Error trying to find series", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find series")
+	}
+	defer cursor.Close(ctx)
+
+	var seriesMongo []SeriesEntityMongo
+	if err := cursor.All(ctx, &seriesMongo); err != nil {
+		logger.Error("Error trying to decode series", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find series")
+	}
+
+	series := make([]auction_entity.Series, 0, len(seriesMongo))
+	for _, value := range seriesMongo {
+		series = append(series, *value.toSeriesEntity())
+	}
+
+	return series, nil
+}
+
+func (sr *SeriesRepository) NextLotNumber(
+	ctx context.Context, seriesId string) (int, *internal_error.InternalError) {
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var seriesMongo SeriesEntityMongo
+	err := sr.Collection.FindOneAndUpdate(ctx,
+		bson.M{"_id": seriesId}, bson.M{"$inc": bson.M{"lot_count": 1}}, opts).Decode(&seriesMongo)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return 0, internal_error.NewNotFoundError(
+				fmt.Sprintf("Series not found with this id = %s", seriesId))
+		}
+
+		logger.Error("Error trying to number series lot", err)
+		return 0, internal_error.NewInternalServerError("Error trying to number series lot")
+	}
+
+	return seriesMongo.LotCount, nil
+}
+
+func (sr *SeriesRepository) UpdateSeriesCascade(
+	ctx context.Context, seriesId string, interval time.Duration) *internal_error.InternalError {
+	update := bson.M{"$set": bson.M{"cascade_interval": interval.Milliseconds()}}
+
+	result, err := sr.Collection.UpdateOne(ctx, bson.M{"_id": seriesId}, update)
+	if err != nil {
+		logger.Error("Error trying to update series cascade", err)
+		return internal_error.NewInternalServerError("Error trying to update series cascade")
+	}
+
+	if result.MatchedCount == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("Series not found with this id = %s", seriesId))
+	}
+
+	return nil
+}
+
+func (sm *SeriesEntityMongo) toSeriesEntity() *auction_entity.Series {
+	return &auction_entity.Series{
+		Id:              sm.Id,
+		SellerId:        sm.SellerId,
+		Name:            sm.Name,
+		Description:     sm.Description,
+		LotCount:        sm.LotCount,
+		CascadeInterval: time.Duration(sm.CascadeInterval) * time.Millisecond,
+		CreatedAt:       time.UnixMilli(sm.CreatedAt),
+	}
+}
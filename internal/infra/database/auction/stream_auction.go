@@ -0,0 +1,62 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// StreamAuctions walks the cursor one document at a time, so exports never
+// hold the whole collection in memory. Iteration stops at the first error
+// returned by handle or when ctx is cancelled.
+func (ar *AuctionRepository) StreamAuctions(
+	ctx context.Context,
+	status auction_entity.AuctionStatus,
+	category string,
+	handle func(auction_entity.Auction) error) *internal_error.InternalError {
+	filter := bson.M{}
+
+	if status != 0 {
+		filter["status"] = status
+	}
+
+	if category != "" {
+		filter["category"] = category
+	}
+
+	cursor, err := ar.Collection.Find(ctx, filter)
+	if err != nil {
+		logger.Error("Error streaming auctions", err)
+		return internal_error.NewInternalServerError("Error streaming auctions")
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var document bson.M
+		if err := cursor.Decode(&document); err != nil {
+			logger.Error("Error decoding auctions", err)
+			return internal_error.NewInternalServerError("Error streaming auctions")
+		}
+
+		var auctionEntityMongo AuctionEntityMongo
+		if err := AuctionUpcasters.Decode(document, &auctionEntityMongo); err != nil {
+			logger.Error("Error decoding auctions", err)
+			return internal_error.NewInternalServerError("Error streaming auctions")
+		}
+
+		if err := handle(*auctionEntityMongo.toAuctionEntity()); err != nil {
+			logger.Error("Error streaming auctions", err)
+			return internal_error.NewInternalServerError("Error streaming auctions")
+		}
+	}
+
+	if err := cursor.Err(); err != nil {
+		logger.Error("Error streaming auctions", err)
+		return internal_error.NewInternalServerError("Error streaming auctions")
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package auction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// TermsAcceptanceEntityMongo is keyed by auction and user, holding the last
+// version the user accepted.
+type TermsAcceptanceEntityMongo struct {
+	Id           string `bson:"_id"`
+	AuctionId    string `bson:"auction_id"`
+	UserId       string `bson:"user_id"`
+	TermsVersion int    `bson:"terms_version"`
+	Timestamp    int64  `bson:"timestamp"`
+}
+
+type TermsAcceptanceRepository struct {
+	Collection *mongo.Collection
+}
+
+func NewTermsAcceptanceRepository(database *mongo.Database) *TermsAcceptanceRepository {
+	return &TermsAcceptanceRepository{
+		Collection: database.Collection("terms_acceptances"),
+	}
+}
+
+func (tr *TermsAcceptanceRepository) CreateTermsAcceptance(
+	ctx context.Context, acceptance *auction_entity.TermsAcceptance) *internal_error.InternalError {
+	acceptanceMongo := &TermsAcceptanceEntityMongo{
+		Id:           termsAcceptanceId(acceptance.AuctionId, acceptance.UserId),
+		AuctionId:    acceptance.AuctionId,
+		UserId:       acceptance.UserId,
+		TermsVersion: acceptance.TermsVersion,
+		Timestamp:    acceptance.Timestamp.Unix(),
+	}
+
+	filter := bson.M{"_id": acceptanceMongo.Id}
+	opts := options.Replace().SetUpsert(true)
+	if _, err := tr.Collection.ReplaceOne(ctx, filter, acceptanceMongo, opts); err != nil {
+		logger.Error("Error trying to save terms acceptance", err)
+		return internal_error.NewInternalServerError("Error trying to save terms acceptance")
+	}
+
+	return nil
+}
+
+func (tr *TermsAcceptanceRepository) FindTermsAcceptance(
+	ctx context.Context, auctionId, userId string) (*auction_entity.TermsAcceptance, *internal_error.InternalError) {
+	var acceptanceMongo TermsAcceptanceEntityMongo
+	filter := bson.M{"_id": termsAcceptanceId(auctionId, userId)}
+	if err := tr.Collection.FindOne(ctx, filter).Decode(&acceptanceMongo); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, internal_error.NewNotFoundError(
+				fmt.Sprintf("Terms acceptance not found for auction = %s and user = %s", auctionId, userId))
+		}
+
+		logger.Error("Error trying to find terms acceptance", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find terms acceptance")
+	}
+
+	return &auction_entity.TermsAcceptance{
+		AuctionId:    acceptanceMongo.AuctionId,
+		UserId:       acceptanceMongo.UserId,
+		TermsVersion: acceptanceMongo.TermsVersion,
+		Timestamp:    time.Unix(acceptanceMongo.Timestamp, 0),
+	}, nil
+}
+
+func termsAcceptanceId(auctionId, userId string) string {
+	return auctionId + ":" + userId
+}
@@ -0,0 +1,87 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type TransferEntityMongo struct {
+	Id           string `bson:"_id"`
+	AuctionId    string `bson:"auction_id"`
+	FromSellerId string `bson:"from_seller_id"`
+	ToSellerId   string `bson:"to_seller_id"`
+	AdminId      string `bson:"admin_id"`
+	Reason       string `bson:"reason"`
+	Timestamp    int64  `bson:"timestamp"`
+}
+
+type TransferRepository struct {
+	Collection *mongo.Collection
+}
+
+func NewTransferRepository(database *mongo.Database) *TransferRepository {
+	return &TransferRepository{
+		Collection: database.Collection("auction_transfers"),
+	}
+}
+
+func (tr *TransferRepository) CreateTransfer(
+	ctx context.Context, transfer *auction_entity.Transfer) *internal_error.InternalError {
+	transferMongo := &TransferEntityMongo{
+		Id:           transfer.Id,
+		AuctionId:    transfer.AuctionId,
+		FromSellerId: transfer.FromSellerId,
+		ToSellerId:   transfer.ToSellerId,
+		AdminId:      transfer.AdminId,
+		Reason:       transfer.Reason,
+		Timestamp:    transfer.Timestamp.UnixMilli(),
+	}
+
+	if _, err := tr.Collection.InsertOne(ctx, transferMongo); err != nil {
+		logger.Error("Error trying to insert auction transfer", err)
+		return internal_error.NewInternalServerError("Error trying to insert auction transfer")
+	}
+
+	return nil
+}
+
+func (tr *TransferRepository) FindTransfers(
+	ctx context.Context, auctionId string) ([]auction_entity.Transfer, *internal_error.InternalError) {
+	filter := bson.M{"auction_id": auctionId}
+
+	opts := options.Find().SetSort(bson.D{{Key: "timestamp", Value: 1}})
+	cursor, err := tr.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		logger.Error("Error finding auction transfers", err)
+		return nil, internal_error.NewInternalServerError("Error finding auction transfers")
+	}
+	defer cursor.Close(ctx)
+
+	var transfersMongo []TransferEntityMongo
+	if err := cursor.All(ctx, &transfersMongo); err != nil {
+		logger.Error("Error decoding auction transfers", err)
+		return nil, internal_error.NewInternalServerError("Error decoding auction transfers")
+	}
+
+	var transfers []auction_entity.Transfer
+	for _, transferMongo := range transfersMongo {
+		transfers = append(transfers, auction_entity.Transfer{
+			Id:           transferMongo.Id,
+			AuctionId:    transferMongo.AuctionId,
+			FromSellerId: transferMongo.FromSellerId,
+			ToSellerId:   transferMongo.ToSellerId,
+			AdminId:      transferMongo.AdminId,
+			Reason:       transferMongo.Reason,
+			Timestamp:    time.UnixMilli(transferMongo.Timestamp),
+		})
+	}
+
+	return transfers, nil
+}
@@ -0,0 +1,84 @@
+package auction
+
+import (
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/infra/database/migration"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+var AuctionUpcasters = migration.NewChain(
+	upcastAuctionV1ToV2,
+	upcastAuctionV2ToV3,
+	upcastAuctionV3ToV4,
+	upcastAuctionV4ToV5,
+)
+
+// upcastAuctionV1ToV2 derives the grading structure from the legacy
+// condition enum for auctions stored before grading existed.
+func upcastAuctionV1ToV2(document bson.M) bson.M {
+	if _, ok := document["grading"]; ok {
+		return document
+	}
+
+	var condition auction_entity.ProductCondition
+	switch value := document["condition"].(type) {
+	case int32:
+		condition = auction_entity.ProductCondition(value)
+	case int64:
+		condition = auction_entity.ProductCondition(value)
+	}
+
+	grading := auction_entity.GradingFromCondition(condition)
+	document["grading"] = bson.M{"grade": grading.Grade}
+
+	return document
+}
+
+// upcastAuctionV2ToV3 backfills updated_at, kept in milliseconds, from the
+// creation timestamp so older auctions show up in change listings.
+func upcastAuctionV2ToV3(document bson.M) bson.M {
+	if _, ok := document["updated_at"]; ok {
+		return document
+	}
+
+	switch value := document["timestamp"].(type) {
+	case int32:
+		document["updated_at"] = int64(value) * 1000
+	case int64:
+		document["updated_at"] = value * 1000
+	}
+
+	return document
+}
+
+// upcastAuctionV3ToV4 stores the end of auctions created when every auction
+// lasted AUCTION_INTERVAL, before extensions could move it.
+func upcastAuctionV3ToV4(document bson.M) bson.M {
+	if _, ok := document["ends_at"]; ok {
+		return document
+	}
+
+	var timestamp int64
+	switch value := document["timestamp"].(type) {
+	case int32:
+		timestamp = int64(value)
+	case int64:
+		timestamp = value
+	}
+
+	document["ends_at"] = time.Unix(timestamp, 0).Add(getAuctionInterval()).UnixMilli()
+
+	return document
+}
+
+// upcastAuctionV4ToV5 keeps bidders public on auctions stored before the
+// visibility setting existed.
+func upcastAuctionV4ToV5(document bson.M) bson.M {
+	if _, ok := document["bidder_visibility"]; !ok {
+		document["bidder_visibility"] = string(auction_entity.BidderPublic)
+	}
+
+	return document
+}
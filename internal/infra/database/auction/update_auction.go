@@ -0,0 +1,396 @@
+package auction
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/configuration/clock"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/event_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func (ar *AuctionRepository) UpdateAuctionWinner(
+	ctx context.Context, auctionId, winnerBidId string) *internal_error.InternalError {
+	filter := bson.M{"_id": auctionId}
+	update := bson.M{"$set": bson.M{
+		"winner_bid_id": winnerBidId,
+		"updated_at":    clock.Now().UnixMilli(),
+	}}
+
+	result, err := ar.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		logger.Error("Error trying to update auction winner", err)
+		return internal_error.NewInternalServerError("Error trying to update auction winner")
+	}
+
+	if result.MatchedCount == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("Auction not found with this id = %s", auctionId))
+	}
+
+	return nil
+}
+
+func (ar *AuctionRepository) UpdateAuctionStatus(
+	ctx context.Context,
+	auctionId string,
+	expected, status auction_entity.AuctionStatus) *internal_error.InternalError {
+	filter := bson.M{"_id": auctionId, "status": expected}
+	update := bson.M{"$set": bson.M{
+		"status":     status,
+		"updated_at": clock.Now().UnixMilli(),
+	}}
+
+	result, err := ar.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		logger.Error("Error trying to update auction status", err)
+		return internal_error.NewInternalServerError("Error trying to update auction status")
+	}
+
+	if result.MatchedCount == 0 {
+		if _, err := ar.FindAuctionById(ctx, auctionId); err != nil {
+			return err
+		}
+		return internal_error.NewConflictError(
+			fmt.Sprintf("Auction %s changed status meanwhile", auctionId))
+	}
+
+	if status.Ended() {
+		ar.scheduler.Cancel(auctionId)
+	}
+
+	switch status {
+	case auction_entity.Completed:
+		ar.publishCompleted(ctx, auctionId)
+	case auction_entity.Cancelled:
+		if ar.publisher != nil {
+			ar.publisher.Publish(ctx, event_entity.NewEvent(event_entity.AuctionCancelled, auctionId, nil))
+		}
+	}
+
+	return nil
+}
+
+func (ar *AuctionRepository) UpdateAuctionSeller(
+	ctx context.Context, auctionId, fromSellerId, toSellerId string) *internal_error.InternalError {
+	filter := bson.M{"_id": auctionId, "seller_id": fromSellerId}
+	update := bson.M{"$set": bson.M{
+		"seller_id":  toSellerId,
+		"updated_at": clock.Now().UnixMilli(),
+	}}
+
+	result, err := ar.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		logger.Error("Error trying to update auction seller", err)
+		return internal_error.NewInternalServerError("Error trying to update auction seller")
+	}
+
+	if result.MatchedCount == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("Auction not found with this id = %s and seller = %s", auctionId, fromSellerId))
+	}
+
+	return nil
+}
+
+func (ar *AuctionRepository) UpdateAuctionDetails(
+	ctx context.Context, auctionEntity *auction_entity.Auction) *internal_error.InternalError {
+	filter := bson.M{
+		"_id": auctionEntity.Id,
+		"status": bson.M{"$nin": []auction_entity.AuctionStatus{
+			auction_entity.Completed, auction_entity.ClosedNoSale, auction_entity.Cancelled}},
+	}
+	update := bson.M{"$set": bson.M{
+		"product_name": auctionEntity.ProductName,
+		"description":  auctionEntity.Description,
+		"category":     auctionEntity.Category,
+		"condition":    auctionEntity.Condition,
+		"grading":      toConditionGradingMongo(auctionEntity.Grading),
+		"updated_at":   auctionEntity.UpdatedAt.UnixMilli(),
+	}}
+
+	result, err := ar.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		logger.Error("Error trying to update auction details", err)
+		return internal_error.NewInternalServerError("Error trying to update auction details")
+	}
+
+	if result.MatchedCount == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("No open auction found with this id = %s", auctionEntity.Id))
+	}
+
+	return nil
+}
+
+func (ar *AuctionRepository) ExtendAuction(
+	ctx context.Context,
+	auctionId string,
+	endsAt time.Time) *internal_error.InternalError {
+	filter := bson.M{
+		"_id":     auctionId,
+		"status":  auction_entity.Active,
+		"ends_at": bson.M{"$lt": endsAt.UnixMilli()},
+	}
+	update := bson.M{"$set": bson.M{
+		"ends_at":    endsAt.UnixMilli(),
+		"updated_at": clock.Now().UnixMilli(),
+	}}
+
+	result, err := ar.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		logger.Error("Error trying to extend auction", err)
+		return internal_error.NewInternalServerError("Error trying to extend auction")
+	}
+
+	if result.ModifiedCount > 0 {
+		ar.scheduleCompletion(auctionId, endsAt)
+	}
+
+	return nil
+}
+
+func (ar *AuctionRepository) OpenAuctionBidding(
+	ctx context.Context,
+	auctionId string,
+	openedAt, endsAt time.Time) *internal_error.InternalError {
+	filter := bson.M{
+		"_id":               auctionId,
+		"status":            auction_entity.Active,
+		"live":              true,
+		"bidding_opened_at": bson.M{"$exists": false},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"bidding_opened_at": openedAt.UnixMilli(),
+			"updated_at":        clock.Now().UnixMilli(),
+		},
+		"$max": bson.M{"ends_at": endsAt.UnixMilli()},
+	}
+
+	result, err := ar.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		logger.Error("Error trying to open auction bidding", err)
+		return internal_error.NewInternalServerError("Error trying to open auction bidding")
+	}
+
+	if result.MatchedCount == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("No live auction closed to bids found with this id = %s", auctionId))
+	}
+
+	return nil
+}
+
+func (ar *AuctionRepository) UpdateAuctionIncrement(
+	ctx context.Context,
+	auctionId string,
+	minIncrement float64) *internal_error.InternalError {
+	filter := bson.M{"_id": auctionId, "status": auction_entity.Active}
+	update := bson.M{
+		"$set": bson.M{
+			"min_increment": minIncrement,
+			"updated_at":    clock.Now().UnixMilli(),
+		},
+	}
+
+	result, err := ar.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		logger.Error("Error trying to update auction increment", err)
+		return internal_error.NewInternalServerError("Error trying to update auction increment")
+	}
+
+	if result.MatchedCount == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("No active auction found with this id = %s", auctionId))
+	}
+
+	return nil
+}
+
+// UpdateHeatScore leaves updated_at alone, the score being no change to
+// the auction for its followers.
+func (ar *AuctionRepository) UpdateHeatScore(
+	ctx context.Context,
+	auctionId string,
+	heatScore float64) *internal_error.InternalError {
+	filter := bson.M{"_id": auctionId}
+	update := bson.M{"$set": bson.M{"heat_score": heatScore}}
+
+	result, err := ar.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		logger.Error("Error trying to update auction heat score", err)
+		return internal_error.NewInternalServerError("Error trying to update auction heat score")
+	}
+
+	if result.MatchedCount == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("Auction not found with this id = %s", auctionId))
+	}
+
+	return nil
+}
+
+// AddViews leaves updated_at alone, as UpdateHeatScore does.
+func (ar *AuctionRepository) AddViews(
+	ctx context.Context,
+	auctionId string,
+	views int64) *internal_error.InternalError {
+	filter := bson.M{"_id": auctionId}
+	update := bson.M{"$inc": bson.M{"views": views}}
+
+	result, err := ar.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		logger.Error("Error trying to add auction views", err)
+		return internal_error.NewInternalServerError("Error trying to add auction views")
+	}
+
+	if result.MatchedCount == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("Auction not found with this id = %s", auctionId))
+	}
+
+	return nil
+}
+
+func (ar *AuctionRepository) UpdateDraft(
+	ctx context.Context,
+	auctionEntity *auction_entity.Auction) *internal_error.InternalError {
+	if auctionEntity.EndsAt.IsZero() {
+		auctionEntity.EndsAt = auctionEntity.OpensAt().Add(getAuctionInterval())
+	}
+
+	filter := bson.M{"_id": auctionEntity.Id, "status": auction_entity.Draft}
+
+	result, err := ar.Collection.ReplaceOne(ctx, filter, toAuctionEntityMongo(auctionEntity))
+	if err != nil {
+		logger.Error("Error trying to update draft auction", err)
+		return internal_error.NewInternalServerError("Error trying to update draft auction")
+	}
+
+	if result.MatchedCount == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("No draft auction found with this id = %s", auctionEntity.Id))
+	}
+
+	return nil
+}
+
+func (ar *AuctionRepository) PublishAuction(
+	ctx context.Context,
+	auctionEntity *auction_entity.Auction) *internal_error.InternalError {
+	filter := bson.M{"_id": auctionEntity.Id, "status": auction_entity.Draft}
+	update := bson.M{"$set": bson.M{
+		"status":     auctionEntity.Status,
+		"starts_at":  toUnixMilli(auctionEntity.StartsAt),
+		"ends_at":    auctionEntity.EndsAt.UnixMilli(),
+		"public_at":  toUnixMilli(auctionEntity.PublicAt),
+		"updated_at": auctionEntity.UpdatedAt.UnixMilli(),
+	}}
+
+	result, err := ar.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		logger.Error("Error trying to publish auction", err)
+		return internal_error.NewInternalServerError("Error trying to publish auction")
+	}
+
+	if result.MatchedCount == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("No draft auction found with this id = %s", auctionEntity.Id))
+	}
+
+	ar.scheduleTimers(auctionEntity)
+
+	return nil
+}
+
+func (ar *AuctionRepository) PauseAuction(
+	ctx context.Context,
+	auctionId string,
+	pausedAt time.Time) *internal_error.InternalError {
+	filter := bson.M{"_id": auctionId, "status": auction_entity.Active}
+	update := bson.M{"$set": bson.M{
+		"status":     auction_entity.Paused,
+		"paused_at":  pausedAt.UnixMilli(),
+		"updated_at": clock.Now().UnixMilli(),
+	}}
+
+	result, err := ar.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		logger.Error("Error trying to pause auction", err)
+		return internal_error.NewInternalServerError("Error trying to pause auction")
+	}
+
+	if result.MatchedCount == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("No active auction found with this id = %s", auctionId))
+	}
+
+	ar.scheduler.Cancel(auctionId)
+
+	if ar.publisher != nil {
+		ar.publisher.Publish(ctx, event_entity.NewEvent(event_entity.AuctionPaused, auctionId, nil))
+	}
+
+	return nil
+}
+
+// ResumeAuction schedules the completion again, dropped on pausing, with
+// the end moved by the pause. The update is
+// conditioned on the pause read, so only one of concurrent resumes applies.
+func (ar *AuctionRepository) ResumeAuction(
+	ctx context.Context,
+	auctionId string,
+	resumedAt time.Time) (time.Time, *internal_error.InternalError) {
+	auctionEntity, err := ar.FindAuctionById(ctx, auctionId)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if auctionEntity.Status != auction_entity.Paused {
+		return time.Time{}, internal_error.NewNotFoundError(
+			fmt.Sprintf("No paused auction found with this id = %s", auctionId))
+	}
+
+	endsAt := auctionEntity.EndsAt
+	if resumedAt.After(auctionEntity.PausedAt) {
+		endsAt = endsAt.Add(resumedAt.Sub(auctionEntity.PausedAt))
+	}
+
+	filter := bson.M{
+		"_id":       auctionId,
+		"status":    auction_entity.Paused,
+		"paused_at": toUnixMilli(auctionEntity.PausedAt),
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"status":     auction_entity.Active,
+			"ends_at":    endsAt.UnixMilli(),
+			"updated_at": clock.Now().UnixMilli(),
+		},
+		"$unset": bson.M{"paused_at": ""},
+	}
+
+	result, errUpdate := ar.Collection.UpdateOne(ctx, filter, update)
+	if errUpdate != nil {
+		logger.Error("Error trying to resume auction", errUpdate)
+		return time.Time{}, internal_error.NewInternalServerError("Error trying to resume auction")
+	}
+
+	if result.MatchedCount == 0 {
+		return time.Time{}, internal_error.NewNotFoundError(
+			fmt.Sprintf("No paused auction found with this id = %s", auctionId))
+	}
+
+	ar.scheduleCompletion(auctionId, endsAt)
+
+	if ar.publisher != nil {
+		ar.publisher.Publish(ctx, event_entity.NewEvent(event_entity.AuctionResumed, auctionId,
+			map[string]interface{}{"ends_at": endsAt}))
+	}
+
+	return endsAt, nil
+}
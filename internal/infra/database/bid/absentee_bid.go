@@ -0,0 +1,130 @@
+package bid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type AbsenteeBidEntityMongo struct {
+	Id           string                       `bson:"_id"`
+	AuctionId    string                       `bson:"auction_id"`
+	UserId       string                       `bson:"user_id"`
+	MaxAmount    float64                      `bson:"max_amount"`
+	TermsVersion int                          `bson:"terms_version"`
+	Status       bid_entity.AbsenteeBidStatus `bson:"status"`
+	Timestamp    int64                        `bson:"timestamp"`
+}
+
+type AbsenteeBidRepository struct {
+	Collection *mongo.Collection
+}
+
+func NewAbsenteeBidRepository(database *mongo.Database) *AbsenteeBidRepository {
+	return &AbsenteeBidRepository{
+		Collection: database.Collection("absentee_bids"),
+	}
+}
+
+func (ar *AbsenteeBidRepository) UpsertAbsenteeBid(
+	ctx context.Context, absenteeBid *bid_entity.AbsenteeBid) *internal_error.InternalError {
+	filter := bson.M{"auction_id": absenteeBid.AuctionId, "user_id": absenteeBid.UserId}
+	update := bson.M{
+		"$set": bson.M{
+			"max_amount":    absenteeBid.MaxAmount,
+			"terms_version": absenteeBid.TermsVersion,
+			"status":        absenteeBid.Status,
+			"timestamp":     absenteeBid.Timestamp.UnixMilli(),
+		},
+		"$setOnInsert": bson.M{"_id": absenteeBid.Id},
+	}
+
+	opts := options.Update().SetUpsert(true)
+	if _, err := ar.Collection.UpdateOne(ctx, filter, update, opts); err != nil {
+		logger.Error("Error trying to save absentee bid", err)
+		return internal_error.NewInternalServerError("Error trying to save absentee bid")
+	}
+
+	return nil
+}
+
+func (ar *AbsenteeBidRepository) FindAbsenteeBid(
+	ctx context.Context, auctionId, userId string) (*bid_entity.AbsenteeBid, *internal_error.InternalError) {
+	var absenteeBidMongo AbsenteeBidEntityMongo
+	filter := bson.M{"auction_id": auctionId, "user_id": userId}
+	if err := ar.Collection.FindOne(ctx, filter).Decode(&absenteeBidMongo); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, internal_error.NewNotFoundError(
+				fmt.Sprintf("Absentee bid not found for auction = %s and user = %s", auctionId, userId))
+		}
+
+		logger.Error("Error trying to find absentee bid", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find absentee bid")
+	}
+
+	return absenteeBidMongo.toAbsenteeBidEntity(), nil
+}
+
+func (ar *AbsenteeBidRepository) FindPendingAbsenteeBids(
+	ctx context.Context, auctionId string) ([]bid_entity.AbsenteeBid, *internal_error.InternalError) {
+	filter := bson.M{"auction_id": auctionId, "status": bid_entity.AbsenteePending}
+
+	opts := options.Find().SetSort(bson.D{{Key: "max_amount", Value: -1}, {Key: "timestamp", Value: 1}})
+	cursor, err := ar.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		logger.Error("Error finding absentee bids", err)
+		return nil, internal_error.NewInternalServerError("Error finding absentee bids")
+	}
+	defer cursor.Close(ctx)
+
+	var absenteeBidsMongo []AbsenteeBidEntityMongo
+	if err := cursor.All(ctx, &absenteeBidsMongo); err != nil {
+		logger.Error("Error decoding absentee bids", err)
+		return nil, internal_error.NewInternalServerError("Error decoding absentee bids")
+	}
+
+	var absenteeBids []bid_entity.AbsenteeBid
+	for _, absenteeBidMongo := range absenteeBidsMongo {
+		absenteeBids = append(absenteeBids, *absenteeBidMongo.toAbsenteeBidEntity())
+	}
+
+	return absenteeBids, nil
+}
+
+func (ar *AbsenteeBidRepository) UpdateAbsenteeBidStatus(
+	ctx context.Context, id string, status bid_entity.AbsenteeBidStatus) *internal_error.InternalError {
+	update := bson.M{"$set": bson.M{"status": status}}
+
+	result, err := ar.Collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		logger.Error("Error trying to update absentee bid", err)
+		return internal_error.NewInternalServerError("Error trying to update absentee bid")
+	}
+
+	if result.MatchedCount == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("Absentee bid not found with this id = %s", id))
+	}
+
+	return nil
+}
+
+func (am *AbsenteeBidEntityMongo) toAbsenteeBidEntity() *bid_entity.AbsenteeBid {
+	return &bid_entity.AbsenteeBid{
+		Id:           am.Id,
+		AuctionId:    am.AuctionId,
+		UserId:       am.UserId,
+		MaxAmount:    am.MaxAmount,
+		TermsVersion: am.TermsVersion,
+		Status:       am.Status,
+		Timestamp:    time.UnixMilli(am.Timestamp),
+	}
+}
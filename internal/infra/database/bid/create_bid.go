@@ -5,9 +5,9 @@ import (
 	"fullcycle-auction_go/configuration/logger"
 	"fullcycle-auction_go/internal/entity/auction_entity"
 	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/entity/event_entity"
 	"fullcycle-auction_go/internal/infra/database/auction"
 	"fullcycle-auction_go/internal/internal_error"
-	"os"
 	"sync"
 	"time"
 
@@ -15,39 +15,57 @@ import (
 )
 
 type BidEntityMongo struct {
-	Id        string  `bson:"_id"`
-	UserId    string  `bson:"user_id"`
-	AuctionId string  `bson:"auction_id"`
-	Amount    float64 `bson:"amount"`
-	Timestamp int64   `bson:"timestamp"`
+	Id                string  `bson:"_id"`
+	UserId            string  `bson:"user_id"`
+	AuctionId         string  `bson:"auction_id"`
+	Amount            float64 `bson:"amount"`
+	Source            string  `bson:"source"`
+	ClientIp          string  `bson:"client_ip,omitempty"`
+	DeviceFingerprint string  `bson:"device_fingerprint,omitempty"`
+	Timestamp         int64   `bson:"timestamp"`
+	PlacedBy          string  `bson:"placed_by,omitempty"`
+	RetractedAt       int64   `bson:"retracted_at,omitempty"`
+	SchemaVersion     int     `bson:"schema_version"`
 }
 
 type BidRepository struct {
 	Collection            *mongo.Collection
 	AuctionRepository     *auction.AuctionRepository
-	auctionInterval       time.Duration
 	auctionStatusMap      map[string]auction_entity.AuctionStatus
 	auctionEndTimeMap     map[string]time.Time
 	auctionStatusMapMutex *sync.Mutex
 	auctionEndTimeMutex   *sync.Mutex
+	publisher             event_entity.Publisher
 }
 
-func NewBidRepository(database *mongo.Database, auctionRepository *auction.AuctionRepository) *BidRepository {
+// NewBidRepository publishes every stored bid to publisher, which may be nil.
+func NewBidRepository(
+	database *mongo.Database,
+	auctionRepository *auction.AuctionRepository,
+	publisher event_entity.Publisher) *BidRepository {
 	return &BidRepository{
-		auctionInterval:       getAuctionInterval(),
 		auctionStatusMap:      make(map[string]auction_entity.AuctionStatus),
 		auctionEndTimeMap:     make(map[string]time.Time),
 		auctionStatusMapMutex: &sync.Mutex{},
 		auctionEndTimeMutex:   &sync.Mutex{},
 		Collection:            database.Collection("bids"),
 		AuctionRepository:     auctionRepository,
+		publisher:             publisher,
 	}
 }
 
 func (bd *BidRepository) CreateBid(
 	ctx context.Context,
-	bidEntities []bid_entity.Bid) *internal_error.InternalError {
+	bidEntities []bid_entity.Bid) ([]bid_entity.Bid, *internal_error.InternalError) {
 	var wg sync.WaitGroup
+	var closedMutex sync.Mutex
+	var closed []bid_entity.Bid
+	closeBid := func(bid bid_entity.Bid) {
+		closedMutex.Lock()
+		closed = append(closed, bid)
+		closedMutex.Unlock()
+	}
+
 	for _, bid := range bidEntities {
 		wg.Add(1)
 		go func(bidValue bid_entity.Bid) {
@@ -62,24 +80,28 @@ func (bd *BidRepository) CreateBid(
 			bd.auctionEndTimeMutex.Unlock()
 
 			bidEntityMongo := &BidEntityMongo{
-				Id:        bidValue.Id,
-				UserId:    bidValue.UserId,
-				AuctionId: bidValue.AuctionId,
-				Amount:    bidValue.Amount,
-				Timestamp: bidValue.Timestamp.Unix(),
+				Id:                bidValue.Id,
+				UserId:            bidValue.UserId,
+				AuctionId:         bidValue.AuctionId,
+				Amount:            bidValue.Amount,
+				Source:            string(bidValue.Source),
+				ClientIp:          bidValue.Client.Ip,
+				DeviceFingerprint: bidValue.Client.DeviceFingerprint,
+				Timestamp:         bidValue.Timestamp.UnixMicro(),
+				PlacedBy:          bidValue.PlacedBy,
+				SchemaVersion:     BidUpcasters.LatestVersion(),
 			}
 
-			if okEndTime && okStatus {
-				now := time.Now()
-				if auctionStatus == auction_entity.Completed || now.After(auctionEndTime) {
-					return
-				}
-
-				if _, err := bd.Collection.InsertOne(ctx, bidEntityMongo); err != nil {
-					logger.Error("Error trying to insert bid", err)
+			// Bids are cut off by the time they were accepted, not flushed.
+			// Past the cached end the auction is read again, since it may
+			// have been extended meanwhile.
+			if okEndTime && okStatus && !bidValue.Timestamp.After(auctionEndTime) {
+				if auctionStatus.Ended() {
+					closeBid(bidValue)
 					return
 				}
 
+				bd.insertBid(ctx, bidValue, bidEntityMongo)
 				return
 			}
 
@@ -88,7 +110,8 @@ func (bd *BidRepository) CreateBid(
 				logger.Error("Error trying to find auction by id", err)
 				return
 			}
-			if auctionEntity.Status == auction_entity.Completed {
+			if auctionEntity.Status.Ended() || bidValue.Timestamp.After(auctionEntity.EndsAt) {
+				closeBid(bidValue)
 				return
 			}
 
@@ -97,25 +120,23 @@ func (bd *BidRepository) CreateBid(
 			bd.auctionStatusMapMutex.Unlock()
 
 			bd.auctionEndTimeMutex.Lock()
-			bd.auctionEndTimeMap[bidValue.AuctionId] = auctionEntity.Timestamp.Add(bd.auctionInterval)
+			bd.auctionEndTimeMap[bidValue.AuctionId] = auctionEntity.EndsAt
 			bd.auctionEndTimeMutex.Unlock()
 
-			if _, err := bd.Collection.InsertOne(ctx, bidEntityMongo); err != nil {
-				logger.Error("Error trying to insert bid", err)
-				return
-			}
+			bd.insertBid(ctx, bidValue, bidEntityMongo)
 		}(bid)
 	}
 	wg.Wait()
-	return nil
+	return closed, nil
 }
 
-func getAuctionInterval() time.Duration {
-	auctionInterval := os.Getenv("AUCTION_INTERVAL")
-	duration, err := time.ParseDuration(auctionInterval)
-	if err != nil {
-		return time.Minute * 5
+func (bd *BidRepository) insertBid(ctx context.Context, bid bid_entity.Bid, bidEntityMongo *BidEntityMongo) {
+	if _, err := bd.Collection.InsertOne(ctx, bidEntityMongo); err != nil {
+		logger.Error("Error trying to insert bid", err)
+		return
 	}
 
-	return duration
+	if bd.publisher != nil {
+		bd.publisher.Publish(ctx, bid.PlacedEvent())
+	}
 }
@@ -2,6 +2,7 @@ package bid
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"fullcycle-auction_go/configuration/logger"
 	"fullcycle-auction_go/internal/entity/bid_entity"
@@ -9,12 +10,13 @@ import (
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 func (bd *BidRepository) FindBidByAuctionId(
 	ctx context.Context, auctionId string) ([]bid_entity.Bid, *internal_error.InternalError) {
-	filter := bson.M{"auction_id": auctionId}
+	filter := bson.M{"auction_id": auctionId, "retracted_at": bson.M{"$exists": false}}
 
 	cursor, err := bd.Collection.Find(ctx, filter)
 	if err != nil {
@@ -24,8 +26,8 @@ func (bd *BidRepository) FindBidByAuctionId(
 			fmt.Sprintf("Error trying to find bids by auctionId %s", auctionId))
 	}
 
-	var bidEntitiesMongo []BidEntityMongo
-	if err := cursor.All(ctx, &bidEntitiesMongo); err != nil {
+	var documents []bson.M
+	if err := cursor.All(ctx, &documents); err != nil {
 		logger.Error(
 			fmt.Sprintf("Error trying to find bids by auctionId %s", auctionId), err)
 		return nil, internal_error.NewInternalServerError(
@@ -33,14 +35,16 @@ func (bd *BidRepository) FindBidByAuctionId(
 	}
 
 	var bidEntities []bid_entity.Bid
-	for _, bidEntityMongo := range bidEntitiesMongo {
-		bidEntities = append(bidEntities, bid_entity.Bid{
-			Id:        bidEntityMongo.Id,
-			UserId:    bidEntityMongo.UserId,
-			AuctionId: bidEntityMongo.AuctionId,
-			Amount:    bidEntityMongo.Amount,
-			Timestamp: time.Unix(bidEntityMongo.Timestamp, 0),
-		})
+	for _, document := range documents {
+		var bidEntityMongo BidEntityMongo
+		if err := BidUpcasters.Decode(document, &bidEntityMongo); err != nil {
+			logger.Error(
+				fmt.Sprintf("Error trying to decode bids by auctionId %s", auctionId), err)
+			return nil, internal_error.NewInternalServerError(
+				fmt.Sprintf("Error trying to find bids by auctionId %s", auctionId))
+		}
+
+		bidEntities = append(bidEntities, *bidEntityMongo.toBidEntity())
 	}
 
 	return bidEntities, nil
@@ -48,20 +52,185 @@ func (bd *BidRepository) FindBidByAuctionId(
 
 func (bd *BidRepository) FindWinningBidByAuctionId(
 	ctx context.Context, auctionId string) (*bid_entity.Bid, *internal_error.InternalError) {
-	filter := bson.M{"auction_id": auctionId}
+	filter := bson.M{"auction_id": auctionId, "retracted_at": bson.M{"$exists": false}}
+
+	var document bson.M
+	opts := options.FindOne().SetSort(bson.D{{Key: "amount", Value: -1}, {Key: "timestamp", Value: 1}})
+	if err := bd.Collection.FindOne(ctx, filter, opts).Decode(&document); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, internal_error.NewNotFoundError(
+				fmt.Sprintf("No bids found for auction = %s", auctionId))
+		}
 
-	var bidEntityMongo BidEntityMongo
-	opts := options.FindOne().SetSort(bson.D{{"amount", -1}})
-	if err := bd.Collection.FindOne(ctx, filter, opts).Decode(&bidEntityMongo); err != nil {
 		logger.Error("Error trying to find the auction winner", err)
 		return nil, internal_error.NewInternalServerError("Error trying to find the auction winner")
 	}
 
-	return &bid_entity.Bid{
-		Id:        bidEntityMongo.Id,
-		UserId:    bidEntityMongo.UserId,
-		AuctionId: bidEntityMongo.AuctionId,
-		Amount:    bidEntityMongo.Amount,
-		Timestamp: time.Unix(bidEntityMongo.Timestamp, 0),
-	}, nil
+	var bidEntityMongo BidEntityMongo
+	if err := BidUpcasters.Decode(document, &bidEntityMongo); err != nil {
+		logger.Error("Error trying to decode the auction winner", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find the auction winner")
+	}
+
+	return bidEntityMongo.toBidEntity(), nil
+}
+
+func (bd *BidRepository) FindBidById(
+	ctx context.Context, bidId string) (*bid_entity.Bid, *internal_error.InternalError) {
+	filter := bson.M{"_id": bidId}
+
+	var document bson.M
+	if err := bd.Collection.FindOne(ctx, filter).Decode(&document); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, internal_error.NewNotFoundError(
+				fmt.Sprintf("Bid not found with this id = %s", bidId))
+		}
+
+		logger.Error(fmt.Sprintf("Error trying to find bid by id = %s", bidId), err)
+		return nil, internal_error.NewInternalServerError("Error trying to find bid by id")
+	}
+
+	var bidEntityMongo BidEntityMongo
+	if err := BidUpcasters.Decode(document, &bidEntityMongo); err != nil {
+		logger.Error(fmt.Sprintf("Error trying to decode bid by id = %s", bidId), err)
+		return nil, internal_error.NewInternalServerError("Error trying to find bid by id")
+	}
+
+	return bidEntityMongo.toBidEntity(), nil
+}
+
+func (bd *BidRepository) HasBids(
+	ctx context.Context, auctionId string) (bool, *internal_error.InternalError) {
+	filter := bson.M{"auction_id": auctionId, "retracted_at": bson.M{"$exists": false}}
+
+	count, err := bd.Collection.CountDocuments(ctx, filter, options.Count().SetLimit(1))
+	if err != nil {
+		logger.Error("Error trying to check auction bids", err)
+		return false, internal_error.NewInternalServerError("Error trying to check auction bids")
+	}
+
+	return count > 0, nil
+}
+
+// FindWinningBidsByAuctionIds keeps the highest bid of each auction in a
+// single aggregation.
+func (bd *BidRepository) FindWinningBidsByAuctionIds(
+	ctx context.Context, auctionIds []string) (map[string]bid_entity.Bid, *internal_error.InternalError) {
+	winningBids := make(map[string]bid_entity.Bid)
+	if len(auctionIds) == 0 {
+		return winningBids, nil
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"auction_id":   bson.M{"$in": auctionIds},
+			"retracted_at": bson.M{"$exists": false},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "amount", Value: -1}, {Key: "timestamp", Value: 1}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$auction_id", "bid": bson.M{"$first": "$$ROOT"}}}},
+	}
+
+	cursor, err := bd.Collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		logger.Error("Error trying to find the auction winners", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find the auction winners")
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Bid bson.M `bson:"bid"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		logger.Error("Error trying to decode the auction winners", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find the auction winners")
+	}
+
+	for _, result := range results {
+		var bidEntityMongo BidEntityMongo
+		if err := BidUpcasters.Decode(result.Bid, &bidEntityMongo); err != nil {
+			logger.Error("Error trying to decode the auction winners", err)
+			return nil, internal_error.NewInternalServerError("Error trying to find the auction winners")
+		}
+
+		winningBids[bidEntityMongo.AuctionId] = *bidEntityMongo.toBidEntity()
+	}
+
+	return winningBids, nil
+}
+
+// FindUserAuctionBids looks the bidders of the user's auctions up in a
+// single aggregation.
+func (bd *BidRepository) FindUserAuctionBids(
+	ctx context.Context, userId string) (map[string][]bid_entity.Bid, *internal_error.InternalError) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"user_id": userId, "retracted_at": bson.M{"$exists": false}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$auction_id"}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from": bd.Collection.Name(),
+			"let":  bson.M{"auctionId": "$_id"},
+			"pipeline": mongo.Pipeline{
+				{{Key: "$match", Value: bson.M{
+					"$expr":        bson.M{"$eq": bson.A{"$auction_id", "$$auctionId"}},
+					"retracted_at": bson.M{"$exists": false},
+				}}},
+				{{Key: "$sort", Value: bson.D{{Key: "amount", Value: -1}, {Key: "timestamp", Value: 1}}}},
+				{{Key: "$group", Value: bson.M{"_id": "$user_id", "bid": bson.M{"$first": "$$ROOT"}}}},
+			},
+			"as": "bidders",
+		}}},
+	}
+
+	cursor, err := bd.Collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		logger.Error("Error trying to find the user auction bids", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find the user auction bids")
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		AuctionId string `bson:"_id"`
+		Bidders   []struct {
+			Bid bson.M `bson:"bid"`
+		} `bson:"bidders"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		logger.Error("Error trying to decode the user auction bids", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find the user auction bids")
+	}
+
+	auctionBids := make(map[string][]bid_entity.Bid, len(results))
+	for _, result := range results {
+		for _, bidder := range result.Bidders {
+			var bidEntityMongo BidEntityMongo
+			if err := BidUpcasters.Decode(bidder.Bid, &bidEntityMongo); err != nil {
+				logger.Error("Error trying to decode the user auction bids", err)
+				return nil, internal_error.NewInternalServerError("Error trying to find the user auction bids")
+			}
+
+			auctionBids[result.AuctionId] = append(auctionBids[result.AuctionId], *bidEntityMongo.toBidEntity())
+		}
+	}
+
+	return auctionBids, nil
+}
+
+func (bm *BidEntityMongo) toBidEntity() *bid_entity.Bid {
+	bid := &bid_entity.Bid{
+		Id:        bm.Id,
+		UserId:    bm.UserId,
+		AuctionId: bm.AuctionId,
+		Amount:    bm.Amount,
+		Source:    bid_entity.BidSource(bm.Source),
+		Client: bid_entity.ClientInfo{
+			Ip:                bm.ClientIp,
+			DeviceFingerprint: bm.DeviceFingerprint,
+		},
+		Timestamp: time.UnixMicro(bm.Timestamp),
+		PlacedBy:  bm.PlacedBy,
+	}
+	if bm.RetractedAt != 0 {
+		bid.RetractedAt = time.Unix(bm.RetractedAt, 0)
+	}
+
+	return bid
 }
@@ -0,0 +1,130 @@
+package bid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ProxyBidEntityMongo struct {
+	Id           string                    `bson:"_id"`
+	AuctionId    string                    `bson:"auction_id"`
+	UserId       string                    `bson:"user_id"`
+	MaxAmount    float64                   `bson:"max_amount"`
+	TermsVersion int                       `bson:"terms_version"`
+	Status       bid_entity.ProxyBidStatus `bson:"status"`
+	Timestamp    int64                     `bson:"timestamp"`
+}
+
+type ProxyBidRepository struct {
+	Collection *mongo.Collection
+}
+
+func NewProxyBidRepository(database *mongo.Database) *ProxyBidRepository {
+	return &ProxyBidRepository{
+		Collection: database.Collection("proxy_bids"),
+	}
+}
+
+func (pr *ProxyBidRepository) UpsertProxyBid(
+	ctx context.Context, proxyBid *bid_entity.ProxyBid) *internal_error.InternalError {
+	filter := bson.M{"auction_id": proxyBid.AuctionId, "user_id": proxyBid.UserId}
+	update := bson.M{
+		"$set": bson.M{
+			"max_amount":    proxyBid.MaxAmount,
+			"terms_version": proxyBid.TermsVersion,
+			"status":        proxyBid.Status,
+			"timestamp":     proxyBid.Timestamp.UnixMilli(),
+		},
+		"$setOnInsert": bson.M{"_id": proxyBid.Id},
+	}
+
+	opts := options.Update().SetUpsert(true)
+	if _, err := pr.Collection.UpdateOne(ctx, filter, update, opts); err != nil {
+		logger.Error("Error trying to save proxy bid", err)
+		return internal_error.NewInternalServerError("Error trying to save proxy bid")
+	}
+
+	return nil
+}
+
+func (pr *ProxyBidRepository) FindProxyBid(
+	ctx context.Context, auctionId, userId string) (*bid_entity.ProxyBid, *internal_error.InternalError) {
+	var proxyBidMongo ProxyBidEntityMongo
+	filter := bson.M{"auction_id": auctionId, "user_id": userId}
+	if err := pr.Collection.FindOne(ctx, filter).Decode(&proxyBidMongo); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, internal_error.NewNotFoundError(
+				fmt.Sprintf("Proxy bid not found for auction = %s and user = %s", auctionId, userId))
+		}
+
+		logger.Error("Error trying to find proxy bid", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find proxy bid")
+	}
+
+	return proxyBidMongo.toProxyBidEntity(), nil
+}
+
+func (pr *ProxyBidRepository) FindActiveProxyBids(
+	ctx context.Context, auctionId string) ([]bid_entity.ProxyBid, *internal_error.InternalError) {
+	filter := bson.M{"auction_id": auctionId, "status": bid_entity.ProxyActive}
+
+	opts := options.Find().SetSort(bson.D{{Key: "max_amount", Value: -1}, {Key: "timestamp", Value: 1}})
+	cursor, err := pr.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		logger.Error("Error finding proxy bids", err)
+		return nil, internal_error.NewInternalServerError("Error finding proxy bids")
+	}
+	defer cursor.Close(ctx)
+
+	var proxyBidsMongo []ProxyBidEntityMongo
+	if err := cursor.All(ctx, &proxyBidsMongo); err != nil {
+		logger.Error("Error decoding proxy bids", err)
+		return nil, internal_error.NewInternalServerError("Error decoding proxy bids")
+	}
+
+	var proxyBids []bid_entity.ProxyBid
+	for _, proxyBidMongo := range proxyBidsMongo {
+		proxyBids = append(proxyBids, *proxyBidMongo.toProxyBidEntity())
+	}
+
+	return proxyBids, nil
+}
+
+func (pr *ProxyBidRepository) UpdateProxyBidStatus(
+	ctx context.Context, id string, status bid_entity.ProxyBidStatus) *internal_error.InternalError {
+	update := bson.M{"$set": bson.M{"status": status}}
+
+	result, err := pr.Collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		logger.Error("Error trying to update proxy bid", err)
+		return internal_error.NewInternalServerError("Error trying to update proxy bid")
+	}
+
+	if result.MatchedCount == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("Proxy bid not found with this id = %s", id))
+	}
+
+	return nil
+}
+
+func (pm *ProxyBidEntityMongo) toProxyBidEntity() *bid_entity.ProxyBid {
+	return &bid_entity.ProxyBid{
+		Id:           pm.Id,
+		AuctionId:    pm.AuctionId,
+		UserId:       pm.UserId,
+		MaxAmount:    pm.MaxAmount,
+		TermsVersion: pm.TermsVersion,
+		Status:       pm.Status,
+		Timestamp:    time.UnixMilli(pm.Timestamp),
+	}
+}
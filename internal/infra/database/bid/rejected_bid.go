@@ -0,0 +1,100 @@
+package bid
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type RejectedBidEntityMongo struct {
+	Id                string                     `bson:"_id"`
+	UserId            string                     `bson:"user_id"`
+	AuctionId         string                     `bson:"auction_id"`
+	Amount            float64                    `bson:"amount"`
+	Source            string                     `bson:"source"`
+	ClientIp          string                     `bson:"client_ip,omitempty"`
+	DeviceFingerprint string                     `bson:"device_fingerprint,omitempty"`
+	Reason            bid_entity.RejectionReason `bson:"reason"`
+	Timestamp         int64                      `bson:"timestamp"`
+	PlacedBy          string                     `bson:"placed_by,omitempty"`
+}
+
+type RejectedBidRepository struct {
+	Collection *mongo.Collection
+}
+
+func NewRejectedBidRepository(database *mongo.Database) *RejectedBidRepository {
+	return &RejectedBidRepository{
+		Collection: database.Collection("rejected_bids"),
+	}
+}
+
+func (rr *RejectedBidRepository) CreateRejectedBid(
+	ctx context.Context, rejectedBid *bid_entity.RejectedBid) *internal_error.InternalError {
+	rejectedBidMongo := &RejectedBidEntityMongo{
+		Id:                rejectedBid.Id,
+		UserId:            rejectedBid.UserId,
+		AuctionId:         rejectedBid.AuctionId,
+		Amount:            rejectedBid.Amount,
+		Source:            string(rejectedBid.Source),
+		ClientIp:          rejectedBid.Client.Ip,
+		DeviceFingerprint: rejectedBid.Client.DeviceFingerprint,
+		Reason:            rejectedBid.Reason,
+		Timestamp:         rejectedBid.Timestamp.Unix(),
+		PlacedBy:          rejectedBid.PlacedBy,
+	}
+
+	if _, err := rr.Collection.InsertOne(ctx, rejectedBidMongo); err != nil {
+		logger.Error("Error trying to insert rejected bid", err)
+		return internal_error.NewInternalServerError("Error trying to insert rejected bid")
+	}
+
+	return nil
+}
+
+func (rr *RejectedBidRepository) FindRejectedBids(
+	ctx context.Context, auctionId string) ([]bid_entity.RejectedBid, *internal_error.InternalError) {
+	filter := bson.M{"auction_id": auctionId}
+
+	opts := options.Find().SetSort(bson.D{{Key: "timestamp", Value: -1}})
+	cursor, err := rr.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		logger.Error("Error finding rejected bids", err)
+		return nil, internal_error.NewInternalServerError("Error finding rejected bids")
+	}
+	defer cursor.Close(ctx)
+
+	var rejectedBidsMongo []RejectedBidEntityMongo
+	if err := cursor.All(ctx, &rejectedBidsMongo); err != nil {
+		logger.Error("Error decoding rejected bids", err)
+		return nil, internal_error.NewInternalServerError("Error decoding rejected bids")
+	}
+
+	var rejectedBids []bid_entity.RejectedBid
+	for _, rejectedBid := range rejectedBidsMongo {
+		rejectedBids = append(rejectedBids, bid_entity.RejectedBid{
+			Bid: bid_entity.Bid{
+				Id:        rejectedBid.Id,
+				UserId:    rejectedBid.UserId,
+				AuctionId: rejectedBid.AuctionId,
+				Amount:    rejectedBid.Amount,
+				Source:    bid_entity.BidSource(rejectedBid.Source),
+				Client: bid_entity.ClientInfo{
+					Ip:                rejectedBid.ClientIp,
+					DeviceFingerprint: rejectedBid.DeviceFingerprint,
+				},
+				Timestamp: time.Unix(rejectedBid.Timestamp, 0),
+				PlacedBy:  rejectedBid.PlacedBy,
+			},
+			Reason: rejectedBid.Reason,
+		})
+	}
+
+	return rejectedBids, nil
+}
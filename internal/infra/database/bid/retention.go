@@ -0,0 +1,83 @@
+package bid
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func (bd *BidRepository) PurgeClientData(
+	ctx context.Context, before time.Time, dryRun bool) (int64, *internal_error.InternalError) {
+	return purgeClientData(ctx, bd.Collection, bidsBefore(before), dryRun)
+}
+
+func (rr *RejectedBidRepository) PurgeClientData(
+	ctx context.Context, before time.Time, dryRun bool) (int64, *internal_error.InternalError) {
+	return purgeClientData(ctx, rr.Collection, bson.M{"timestamp": bson.M{"$lt": before.Unix()}}, dryRun)
+}
+
+func (bd *BidRepository) ReplaceBidders(
+	ctx context.Context,
+	auctionId string,
+	userIds map[string]string) (int64, *internal_error.InternalError) {
+	var replaced int64
+	for from, to := range userIds {
+		filter := bson.M{"auction_id": auctionId, "user_id": from}
+		result, err := bd.Collection.UpdateMany(ctx, filter, bson.M{"$set": bson.M{"user_id": to}})
+		if err != nil {
+			logger.Error("Error trying to replace bidders", err)
+			return replaced, internal_error.NewInternalServerError("Error trying to replace bidders")
+		}
+		replaced += result.ModifiedCount
+	}
+
+	return replaced, nil
+}
+
+// bidsBefore matches the bids placed before the time, whose timestamps
+// are in seconds until the bids are migrated to version 3.
+func bidsBefore(before time.Time) bson.M {
+	return bson.M{"$or": bson.A{
+		bson.M{"schema_version": bson.M{"$gte": 3}, "timestamp": bson.M{"$lt": before.UnixMicro()}},
+		bson.M{"schema_version": bson.M{"$not": bson.M{"$gte": 3}}, "timestamp": bson.M{"$lt": before.Unix()}},
+	}}
+}
+
+// purgeClientData unsets the client fields of the bids of the collection
+// matching placedBefore, both of which are left out of the documents when
+// empty.
+func purgeClientData(
+	ctx context.Context,
+	collection *mongo.Collection,
+	placedBefore bson.M,
+	dryRun bool) (int64, *internal_error.InternalError) {
+	filter := bson.M{"$and": bson.A{
+		placedBefore,
+		bson.M{"$or": bson.A{
+			bson.M{"client_ip": bson.M{"$exists": true}},
+			bson.M{"device_fingerprint": bson.M{"$exists": true}},
+		}},
+	}}
+
+	if dryRun {
+		count, err := collection.CountDocuments(ctx, filter)
+		if err != nil {
+			logger.Error("Error trying to count bid client data", err)
+			return 0, internal_error.NewInternalServerError("Error trying to count bid client data")
+		}
+		return count, nil
+	}
+
+	update := bson.M{"$unset": bson.M{"client_ip": "", "device_fingerprint": ""}}
+	result, err := collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		logger.Error("Error trying to purge bid client data", err)
+		return 0, internal_error.NewInternalServerError("Error trying to purge bid client data")
+	}
+
+	return result.ModifiedCount, nil
+}
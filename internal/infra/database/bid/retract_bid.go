@@ -0,0 +1,52 @@
+package bid
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func (bd *BidRepository) RetractBid(
+	ctx context.Context, bidId string, retractedAt time.Time) *internal_error.InternalError {
+	filter := bson.M{"_id": bidId, "retracted_at": bson.M{"$exists": false}}
+	update := bson.M{"$set": bson.M{"retracted_at": retractedAt.Unix()}}
+
+	result, err := bd.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Error trying to retract bid = %s", bidId), err)
+		return internal_error.NewInternalServerError("Error trying to retract bid")
+	}
+	if result.MatchedCount == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("No standing bid found with this id = %s", bidId))
+	}
+
+	if bd.publisher != nil {
+		bd.publishRetraction(ctx, bidId)
+	}
+
+	return nil
+}
+
+// publishRetraction looks the retracted bid and the new highest bid up for
+// the event. Like publishing, it is best effort: a failed lookup is only
+// logged, the bid being retracted already.
+func (bd *BidRepository) publishRetraction(ctx context.Context, bidId string) {
+	bid, err := bd.FindBidById(ctx, bidId)
+	if err != nil {
+		logger.Error("Error trying to publish bid retraction", err)
+		return
+	}
+
+	highest, err := bd.FindWinningBidByAuctionId(ctx, bid.AuctionId)
+	if err != nil && err.Err != "not_found" {
+		logger.Error("Error trying to publish bid retraction", err)
+		return
+	}
+
+	bd.publisher.Publish(ctx, bid.RetractedEvent(highest))
+}
@@ -0,0 +1,56 @@
+package bid
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/internal_error"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// StreamBids walks the bids of an auction, or of every auction when
+// auctionId is empty, one document at a time.
+func (bd *BidRepository) StreamBids(
+	ctx context.Context,
+	auctionId string,
+	handle func(bid_entity.Bid) error) *internal_error.InternalError {
+	filter := bson.M{}
+
+	if auctionId != "" {
+		filter["auction_id"] = auctionId
+	}
+
+	cursor, err := bd.Collection.Find(ctx, filter)
+	if err != nil {
+		logger.Error("Error streaming bids", err)
+		return internal_error.NewInternalServerError("Error streaming bids")
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var document bson.M
+		if err := cursor.Decode(&document); err != nil {
+			logger.Error("Error decoding bids", err)
+			return internal_error.NewInternalServerError("Error streaming bids")
+		}
+
+		var bidEntityMongo BidEntityMongo
+		if err := BidUpcasters.Decode(document, &bidEntityMongo); err != nil {
+			logger.Error("Error decoding bids", err)
+			return internal_error.NewInternalServerError("Error streaming bids")
+		}
+
+		if err := handle(*bidEntityMongo.toBidEntity()); err != nil {
+			logger.Error("Error streaming bids", err)
+			return internal_error.NewInternalServerError("Error streaming bids")
+		}
+	}
+
+	if err := cursor.Err(); err != nil {
+		logger.Error("Error streaming bids", err)
+		return internal_error.NewInternalServerError("Error streaming bids")
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package bid
+
+import (
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/infra/database/migration"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+var BidUpcasters = migration.NewChain(
+	upcastBidV1ToV2,
+	upcastBidV2ToV3,
+)
+
+// upcastBidV1ToV2 attributes bids stored before sources were tracked to
+// the web channel, the only one that existed then.
+func upcastBidV1ToV2(document bson.M) bson.M {
+	if _, ok := document["source"]; !ok {
+		document["source"] = string(bid_entity.SourceWeb)
+	}
+
+	return document
+}
+
+// upcastBidV2ToV3 keeps the timestamp in microseconds instead of seconds,
+// so equal bids keep the order they were accepted in.
+func upcastBidV2ToV3(document bson.M) bson.M {
+	switch value := document["timestamp"].(type) {
+	case int32:
+		document["timestamp"] = time.Unix(int64(value), 0).UnixMicro()
+	case int64:
+		document["timestamp"] = time.Unix(value, 0).UnixMicro()
+	}
+
+	return document
+}
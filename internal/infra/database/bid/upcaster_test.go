@@ -0,0 +1,23 @@
+package bid
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestUpcastBid_TimestampInMicroseconds(t *testing.T) {
+	placedAt := time.Unix(1_700_000_000, 0)
+
+	var bidEntityMongo BidEntityMongo
+	err := BidUpcasters.Decode(bson.M{"_id": "bid", "timestamp": placedAt.Unix(), "schema_version": int32(2)}, &bidEntityMongo)
+	assert.NoError(t, err)
+	assert.Equal(t, placedAt.UnixMicro(), bidEntityMongo.Timestamp)
+
+	placedAt = placedAt.Add(250 * time.Microsecond)
+	current := bson.M{"_id": "bid", "timestamp": placedAt.UnixMicro(), "schema_version": int32(3)}
+	assert.NoError(t, BidUpcasters.Decode(current, &bidEntityMongo))
+	assert.True(t, placedAt.Equal(bidEntityMongo.toBidEntity().Timestamp))
+}
@@ -0,0 +1,129 @@
+package category
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/category_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type CategoryEntityMongo struct {
+	Name      string `bson:"_id"`
+	Parent    string `bson:"parent"`
+	Timestamp int64  `bson:"timestamp"`
+}
+
+type CategoryRepository struct {
+	Collection *mongo.Collection
+}
+
+func NewCategoryRepository(database *mongo.Database) *CategoryRepository {
+	return &CategoryRepository{
+		Collection: database.Collection("categories"),
+	}
+}
+
+func (cr *CategoryRepository) CreateCategory(
+	ctx context.Context, category *category_entity.Category) *internal_error.InternalError {
+	categoryMongo := &CategoryEntityMongo{
+		Name:      category.Name,
+		Parent:    category.Parent,
+		Timestamp: category.Timestamp.Unix(),
+	}
+
+	if _, err := cr.Collection.InsertOne(ctx, categoryMongo); err != nil {
+		logger.Error("Error trying to insert category", err)
+		return internal_error.NewInternalServerError("Error trying to insert category")
+	}
+
+	return nil
+}
+
+func (cr *CategoryRepository) UpdateCategory(
+	ctx context.Context, category *category_entity.Category) *internal_error.InternalError {
+	filter := bson.M{"_id": category.Name}
+	update := bson.M{"$set": bson.M{"parent": category.Parent}}
+
+	result, err := cr.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Error trying to update category = %s", category.Name), err)
+		return internal_error.NewInternalServerError("Error trying to update category")
+	}
+	if result.MatchedCount == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("Category not found with name = %s", category.Name))
+	}
+
+	return nil
+}
+
+func (cr *CategoryRepository) DeleteCategory(
+	ctx context.Context, name string) *internal_error.InternalError {
+	result, err := cr.Collection.DeleteOne(ctx, bson.M{"_id": name})
+	if err != nil {
+		logger.Error(fmt.Sprintf("Error trying to delete category = %s", name), err)
+		return internal_error.NewInternalServerError("Error trying to delete category")
+	}
+	if result.DeletedCount == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("Category not found with name = %s", name))
+	}
+
+	return nil
+}
+
+func (cr *CategoryRepository) FindCategory(
+	ctx context.Context, name string) (*category_entity.Category, *internal_error.InternalError) {
+	var categoryMongo CategoryEntityMongo
+	if err := cr.Collection.FindOne(ctx, bson.M{"_id": name}).Decode(&categoryMongo); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, internal_error.NewNotFoundError(
+				fmt.Sprintf("Category not found with name = %s", name))
+		}
+
+		logger.Error(fmt.Sprintf("Error trying to find category = %s", name), err)
+		return nil, internal_error.NewInternalServerError("Error trying to find category")
+	}
+
+	return toCategoryEntity(categoryMongo), nil
+}
+
+func (cr *CategoryRepository) FindCategories(
+	ctx context.Context) ([]category_entity.Category, *internal_error.InternalError) {
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+
+	cursor, err := cr.Collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		logger.Error("Error finding categories", err)
+		return nil, internal_error.NewInternalServerError("Error finding categories")
+	}
+	defer cursor.Close(ctx)
+
+	var categoriesMongo []CategoryEntityMongo
+	if err := cursor.All(ctx, &categoriesMongo); err != nil {
+		logger.Error("Error decoding categories", err)
+		return nil, internal_error.NewInternalServerError("Error decoding categories")
+	}
+
+	categories := make([]category_entity.Category, 0, len(categoriesMongo))
+	for _, categoryMongo := range categoriesMongo {
+		categories = append(categories, *toCategoryEntity(categoryMongo))
+	}
+
+	return categories, nil
+}
+
+func toCategoryEntity(categoryMongo CategoryEntityMongo) *category_entity.Category {
+	return &category_entity.Category{
+		Name:      categoryMongo.Name,
+		Parent:    categoryMongo.Parent,
+		Timestamp: time.Unix(categoryMongo.Timestamp, 0),
+	}
+}
@@ -0,0 +1,91 @@
+package category
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/category_entity"
+	"fullcycle-auction_go/internal/internal_error"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type AttributeDefinitionMongo struct {
+	Name     string                        `bson:"name"`
+	Type     category_entity.AttributeType `bson:"type"`
+	Required bool                          `bson:"required"`
+}
+
+type CategorySchemaEntityMongo struct {
+	Category   string                     `bson:"_id"`
+	Attributes []AttributeDefinitionMongo `bson:"attributes"`
+	Labels     map[string]string          `bson:"labels,omitempty"`
+}
+
+type CategorySchemaRepository struct {
+	Collection *mongo.Collection
+}
+
+func NewCategorySchemaRepository(database *mongo.Database) *CategorySchemaRepository {
+	return &CategorySchemaRepository{
+		Collection: database.Collection("category_schemas"),
+	}
+}
+
+func (cr *CategorySchemaRepository) UpsertCategorySchema(
+	ctx context.Context,
+	schema *category_entity.CategorySchema) *internal_error.InternalError {
+	schemaMongo := &CategorySchemaEntityMongo{
+		Category: schema.Category,
+		Labels:   schema.Labels,
+	}
+	for _, attribute := range schema.Attributes {
+		schemaMongo.Attributes = append(schemaMongo.Attributes, AttributeDefinitionMongo{
+			Name:     attribute.Name,
+			Type:     attribute.Type,
+			Required: attribute.Required,
+		})
+	}
+
+	filter := bson.M{"_id": schema.Category}
+	opts := options.Replace().SetUpsert(true)
+	if _, err := cr.Collection.ReplaceOne(ctx, filter, schemaMongo, opts); err != nil {
+		logger.Error("Error trying to upsert category schema", err)
+		return internal_error.NewInternalServerError("Error trying to upsert category schema")
+	}
+
+	return nil
+}
+
+func (cr *CategorySchemaRepository) FindCategorySchema(
+	ctx context.Context, category string) (*category_entity.CategorySchema, *internal_error.InternalError) {
+	filter := bson.M{"_id": category}
+
+	var schemaMongo CategorySchemaEntityMongo
+	if err := cr.Collection.FindOne(ctx, filter).Decode(&schemaMongo); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, internal_error.NewNotFoundError(
+				fmt.Sprintf("Category schema not found for category = %s", category))
+		}
+
+		logger.Error(fmt.Sprintf("Error trying to find category schema for category = %s", category), err)
+		return nil, internal_error.NewInternalServerError("Error trying to find category schema")
+	}
+
+	schema := &category_entity.CategorySchema{
+		Category: schemaMongo.Category,
+		Labels:   schemaMongo.Labels,
+	}
+	for _, attribute := range schemaMongo.Attributes {
+		schema.Attributes = append(schema.Attributes, category_entity.AttributeDefinition{
+			Name:     attribute.Name,
+			Type:     attribute.Type,
+			Required: attribute.Required,
+		})
+	}
+
+	return schema, nil
+}
@@ -0,0 +1,90 @@
+package fraud
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/fraud_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type FraudFlagEntityMongo struct {
+	Id        string              `bson:"_id"`
+	AuctionId string              `bson:"auction_id"`
+	UserId    string              `bson:"user_id"`
+	Signal    fraud_entity.Signal `bson:"signal"`
+	Details   string              `bson:"details"`
+	Timestamp int64               `bson:"timestamp"`
+}
+
+type FraudFlagRepository struct {
+	Collection *mongo.Collection
+}
+
+func NewFraudFlagRepository(database *mongo.Database) *FraudFlagRepository {
+	return &FraudFlagRepository{
+		Collection: database.Collection("fraud_flags"),
+	}
+}
+
+func (fr *FraudFlagRepository) UpsertFraudFlag(
+	ctx context.Context, flag *fraud_entity.FraudFlag) *internal_error.InternalError {
+	filter := bson.M{"_id": flag.Id}
+	update := bson.M{
+		"$set": bson.M{"details": flag.Details},
+		"$setOnInsert": bson.M{
+			"auction_id": flag.AuctionId,
+			"user_id":    flag.UserId,
+			"signal":     flag.Signal,
+			"timestamp":  flag.Timestamp.Unix(),
+		},
+	}
+
+	opts := options.Update().SetUpsert(true)
+	if _, err := fr.Collection.UpdateOne(ctx, filter, update, opts); err != nil {
+		logger.Error("Error trying to upsert fraud flag", err)
+		return internal_error.NewInternalServerError("Error trying to upsert fraud flag")
+	}
+
+	return nil
+}
+
+func (fr *FraudFlagRepository) FindFraudFlags(
+	ctx context.Context, auctionId string) ([]fraud_entity.FraudFlag, *internal_error.InternalError) {
+	filter := bson.M{}
+	if auctionId != "" {
+		filter["auction_id"] = auctionId
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "timestamp", Value: -1}})
+	cursor, err := fr.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		logger.Error("Error finding fraud flags", err)
+		return nil, internal_error.NewInternalServerError("Error finding fraud flags")
+	}
+	defer cursor.Close(ctx)
+
+	var flagsMongo []FraudFlagEntityMongo
+	if err := cursor.All(ctx, &flagsMongo); err != nil {
+		logger.Error("Error decoding fraud flags", err)
+		return nil, internal_error.NewInternalServerError("Error decoding fraud flags")
+	}
+
+	var flags []fraud_entity.FraudFlag
+	for _, flag := range flagsMongo {
+		flags = append(flags, fraud_entity.FraudFlag{
+			Id:        flag.Id,
+			AuctionId: flag.AuctionId,
+			UserId:    flag.UserId,
+			Signal:    flag.Signal,
+			Details:   flag.Details,
+			Timestamp: time.Unix(flag.Timestamp, 0),
+		})
+	}
+
+	return flags, nil
+}
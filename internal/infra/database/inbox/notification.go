@@ -0,0 +1,147 @@
+package inbox
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/notification_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Timestamps are kept in milliseconds so notifications sent within the
+// same second keep their order in the inbox.
+type NotificationEntityMongo struct {
+	Id         string                   `bson:"_id"`
+	UserId     string                   `bson:"user_id"`
+	AuctionId  string                   `bson:"auction_id"`
+	Kind       notification_entity.Kind `bson:"kind"`
+	Message    string                   `bson:"message"`
+	Timestamp  int64                    `bson:"timestamp"`
+	ReadAt     int64                    `bson:"read_at,omitempty"`
+	ArchivedAt int64                    `bson:"archived_at,omitempty"`
+}
+
+type NotificationRepository struct {
+	Collection *mongo.Collection
+}
+
+func NewNotificationRepository(database *mongo.Database) *NotificationRepository {
+	return &NotificationRepository{
+		Collection: database.Collection("notifications"),
+	}
+}
+
+func (nr *NotificationRepository) CreateNotification(
+	ctx context.Context, notification *notification_entity.Notification) *internal_error.InternalError {
+	notificationMongo := &NotificationEntityMongo{
+		Id:        notification.Id,
+		UserId:    notification.UserId,
+		AuctionId: notification.AuctionId,
+		Kind:      notification.Kind,
+		Message:   notification.Message,
+		Timestamp: notification.Timestamp.UnixMilli(),
+	}
+
+	if _, err := nr.Collection.InsertOne(ctx, notificationMongo); err != nil {
+		logger.Error("Error trying to insert notification", err)
+		return internal_error.NewInternalServerError("Error trying to insert notification")
+	}
+
+	return nil
+}
+
+func (nr *NotificationRepository) FindNotifications(
+	ctx context.Context,
+	userId string,
+	archived bool) ([]notification_entity.Notification, *internal_error.InternalError) {
+	filter := bson.M{
+		"user_id":     userId,
+		"archived_at": bson.M{"$exists": archived},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "timestamp", Value: -1}})
+
+	cursor, err := nr.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Error finding notifications of user = %s", userId), err)
+		return nil, internal_error.NewInternalServerError("Error finding notifications")
+	}
+	defer cursor.Close(ctx)
+
+	var notificationsMongo []NotificationEntityMongo
+	if err := cursor.All(ctx, &notificationsMongo); err != nil {
+		logger.Error("Error decoding notifications", err)
+		return nil, internal_error.NewInternalServerError("Error decoding notifications")
+	}
+
+	notifications := make([]notification_entity.Notification, 0, len(notificationsMongo))
+	for _, notificationMongo := range notificationsMongo {
+		notifications = append(notifications, notificationMongo.toEntity())
+	}
+
+	return notifications, nil
+}
+
+func (nr *NotificationRepository) MarkNotificationsRead(
+	ctx context.Context,
+	userId string,
+	ids []string,
+	readAt time.Time) (int64, *internal_error.InternalError) {
+	filter := bson.M{
+		"user_id": userId,
+		"read_at": bson.M{"$exists": false},
+	}
+	if len(ids) > 0 {
+		filter["_id"] = bson.M{"$in": ids}
+	}
+	update := bson.M{"$set": bson.M{"read_at": readAt.UnixMilli()}}
+
+	result, err := nr.Collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Error trying to mark notifications of user = %s as read", userId), err)
+		return 0, internal_error.NewInternalServerError("Error trying to mark notifications as read")
+	}
+
+	return result.ModifiedCount, nil
+}
+
+func (nr *NotificationRepository) ArchiveNotifications(
+	ctx context.Context,
+	cutoff, archivedAt time.Time) (int64, *internal_error.InternalError) {
+	filter := bson.M{
+		"timestamp":   bson.M{"$lt": cutoff.UnixMilli()},
+		"archived_at": bson.M{"$exists": false},
+	}
+	update := bson.M{"$set": bson.M{"archived_at": archivedAt.UnixMilli()}}
+
+	result, err := nr.Collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		logger.Error("Error trying to archive notifications", err)
+		return 0, internal_error.NewInternalServerError("Error trying to archive notifications")
+	}
+
+	return result.ModifiedCount, nil
+}
+
+func (nm NotificationEntityMongo) toEntity() notification_entity.Notification {
+	notification := notification_entity.Notification{
+		Id:        nm.Id,
+		UserId:    nm.UserId,
+		AuctionId: nm.AuctionId,
+		Kind:      nm.Kind,
+		Message:   nm.Message,
+		Timestamp: time.UnixMilli(nm.Timestamp),
+	}
+	if nm.ReadAt != 0 {
+		notification.ReadAt = time.UnixMilli(nm.ReadAt)
+	}
+	if nm.ArchivedAt != 0 {
+		notification.ArchivedAt = time.UnixMilli(nm.ArchivedAt)
+	}
+
+	return notification
+}
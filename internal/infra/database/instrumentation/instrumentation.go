@@ -0,0 +1,96 @@
+package instrumentation
+
+import (
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/internal_error"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+var (
+	operationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "repository_operation_duration_seconds",
+		Help:    "Latency of repository operations.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"repository", "method"})
+
+	operationErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "repository_operation_errors_total",
+		Help: "Repository operations that returned an error, by error kind.",
+	}, []string{"repository", "method", "error"})
+)
+
+func init() {
+	prometheus.MustRegister(operationDuration, operationErrors)
+}
+
+// Instrumentation records latency and errors of the repository calls it
+// observes and logs calls slower than the configured threshold.
+type Instrumentation struct {
+	slowQueryThreshold time.Duration
+}
+
+func NewInstrumentation() *Instrumentation {
+	return &Instrumentation{
+		slowQueryThreshold: getSlowQueryThreshold(),
+	}
+}
+
+func Enabled() bool {
+	enabled, err := strconv.ParseBool(os.Getenv("REPOSITORY_METRICS_ENABLED"))
+	return err == nil && enabled
+}
+
+func (i *Instrumentation) record(
+	repository, method string,
+	start time.Time,
+	err *internal_error.InternalError) {
+	elapsed := time.Since(start)
+	operationDuration.WithLabelValues(repository, method).Observe(elapsed.Seconds())
+
+	if err != nil {
+		operationErrors.WithLabelValues(repository, method, err.Err).Inc()
+	}
+
+	if elapsed >= i.slowQueryThreshold {
+		logger.Warn("Slow repository operation",
+			zap.String("repository", repository),
+			zap.String("method", method),
+			zap.Duration("elapsed", elapsed))
+	}
+}
+
+func observe[T any](
+	i *Instrumentation,
+	repository, method string,
+	call func() (T, *internal_error.InternalError)) (T, *internal_error.InternalError) {
+	start := time.Now()
+	result, err := call()
+	i.record(repository, method, start, err)
+
+	return result, err
+}
+
+func observeErr(
+	i *Instrumentation,
+	repository, method string,
+	call func() *internal_error.InternalError) *internal_error.InternalError {
+	start := time.Now()
+	err := call()
+	i.record(repository, method, start, err)
+
+	return err
+}
+
+func getSlowQueryThreshold() time.Duration {
+	duration, err := time.ParseDuration(os.Getenv("SLOW_QUERY_THRESHOLD"))
+	if err != nil {
+		return 200 * time.Millisecond
+	}
+
+	return duration
+}
@@ -0,0 +1,1052 @@
+package instrumentation
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/entity/category_entity"
+	"fullcycle-auction_go/internal/entity/fraud_entity"
+	"fullcycle-auction_go/internal/entity/job_entity"
+	"fullcycle-auction_go/internal/entity/notification_entity"
+	"fullcycle-auction_go/internal/entity/offer_entity"
+	"fullcycle-auction_go/internal/entity/product_entity"
+	"fullcycle-auction_go/internal/entity/question_entity"
+	"fullcycle-auction_go/internal/entity/user_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+)
+
+// The decorators embed the wrapped interface, so methods that are not
+// overridden here are still delegated, just without metrics.
+
+type AuctionRepository struct {
+	auction_entity.AuctionRepositoryInterface
+	instrumentation *Instrumentation
+}
+
+func NewAuctionRepository(
+	repository auction_entity.AuctionRepositoryInterface,
+	instrumentation *Instrumentation) *AuctionRepository {
+	return &AuctionRepository{
+		AuctionRepositoryInterface: repository,
+		instrumentation:            instrumentation,
+	}
+}
+
+func (r *AuctionRepository) CreateAuction(
+	ctx context.Context,
+	auctionEntity *auction_entity.Auction) *internal_error.InternalError {
+	return observeErr(r.instrumentation, "auction", "CreateAuction", func() *internal_error.InternalError {
+		return r.AuctionRepositoryInterface.CreateAuction(ctx, auctionEntity)
+	})
+}
+
+func (r *AuctionRepository) FindAuctions(
+	ctx context.Context,
+	filter auction_entity.AuctionFilter,
+	fields []string) ([]auction_entity.Auction, *internal_error.InternalError) {
+	return observe(r.instrumentation, "auction", "FindAuctions", func() ([]auction_entity.Auction, *internal_error.InternalError) {
+		return r.AuctionRepositoryInterface.FindAuctions(ctx, filter, fields)
+	})
+}
+
+func (r *AuctionRepository) FindAuctionById(
+	ctx context.Context, id string) (*auction_entity.Auction, *internal_error.InternalError) {
+	return observe(r.instrumentation, "auction", "FindAuctionById", func() (*auction_entity.Auction, *internal_error.InternalError) {
+		return r.AuctionRepositoryInterface.FindAuctionById(ctx, id)
+	})
+}
+
+func (r *AuctionRepository) FindAuctionsByIds(
+	ctx context.Context, ids []string) ([]auction_entity.Auction, *internal_error.InternalError) {
+	return observe(r.instrumentation, "auction", "FindAuctionsByIds", func() ([]auction_entity.Auction, *internal_error.InternalError) {
+		return r.AuctionRepositoryInterface.FindAuctionsByIds(ctx, ids)
+	})
+}
+
+func (r *AuctionRepository) StreamAuctions(
+	ctx context.Context,
+	status auction_entity.AuctionStatus,
+	category string,
+	handle func(auction_entity.Auction) error) *internal_error.InternalError {
+	return observeErr(r.instrumentation, "auction", "StreamAuctions", func() *internal_error.InternalError {
+		return r.AuctionRepositoryInterface.StreamAuctions(ctx, status, category, handle)
+	})
+}
+
+func (r *AuctionRepository) FindEstimatedAuctions(
+	ctx context.Context,
+	sellerId, category string) ([]auction_entity.Auction, *internal_error.InternalError) {
+	return observe(r.instrumentation, "auction", "FindEstimatedAuctions", func() ([]auction_entity.Auction, *internal_error.InternalError) {
+		return r.AuctionRepositoryInterface.FindEstimatedAuctions(ctx, sellerId, category)
+	})
+}
+
+func (r *AuctionRepository) FindSellerAuctions(
+	ctx context.Context,
+	sellerId string,
+	status auction_entity.AuctionStatus,
+	offset, limit int) ([]auction_entity.Auction, int, *internal_error.InternalError) {
+	var total int
+	auctions, err := observe(r.instrumentation, "auction", "FindSellerAuctions", func() ([]auction_entity.Auction, *internal_error.InternalError) {
+		var auctions []auction_entity.Auction
+		var err *internal_error.InternalError
+		auctions, total, err = r.AuctionRepositoryInterface.FindSellerAuctions(ctx, sellerId, status, offset, limit)
+		return auctions, err
+	})
+	return auctions, total, err
+}
+
+func (r *AuctionRepository) FindAuctionChanges(
+	ctx context.Context, since time.Time) ([]auction_entity.Auction, *internal_error.InternalError) {
+	return observe(r.instrumentation, "auction", "FindAuctionChanges", func() ([]auction_entity.Auction, *internal_error.InternalError) {
+		return r.AuctionRepositoryInterface.FindAuctionChanges(ctx, since)
+	})
+}
+
+func (r *AuctionRepository) UpdateAuctionWinner(
+	ctx context.Context, auctionId, winnerBidId string) *internal_error.InternalError {
+	return observeErr(r.instrumentation, "auction", "UpdateAuctionWinner", func() *internal_error.InternalError {
+		return r.AuctionRepositoryInterface.UpdateAuctionWinner(ctx, auctionId, winnerBidId)
+	})
+}
+
+func (r *AuctionRepository) UpdateAuctionStatus(
+	ctx context.Context,
+	auctionId string,
+	expected, status auction_entity.AuctionStatus) *internal_error.InternalError {
+	return observeErr(r.instrumentation, "auction", "UpdateAuctionStatus", func() *internal_error.InternalError {
+		return r.AuctionRepositoryInterface.UpdateAuctionStatus(ctx, auctionId, expected, status)
+	})
+}
+
+func (r *AuctionRepository) UpdateAuctionSeller(
+	ctx context.Context, auctionId, fromSellerId, toSellerId string) *internal_error.InternalError {
+	return observeErr(r.instrumentation, "auction", "UpdateAuctionSeller", func() *internal_error.InternalError {
+		return r.AuctionRepositoryInterface.UpdateAuctionSeller(ctx, auctionId, fromSellerId, toSellerId)
+	})
+}
+
+func (r *AuctionRepository) UpdateAuctionDetails(
+	ctx context.Context, auctionEntity *auction_entity.Auction) *internal_error.InternalError {
+	return observeErr(r.instrumentation, "auction", "UpdateAuctionDetails", func() *internal_error.InternalError {
+		return r.AuctionRepositoryInterface.UpdateAuctionDetails(ctx, auctionEntity)
+	})
+}
+
+func (r *AuctionRepository) ExtendAuction(
+	ctx context.Context,
+	auctionId string,
+	endsAt time.Time) *internal_error.InternalError {
+	return observeErr(r.instrumentation, "auction", "ExtendAuction", func() *internal_error.InternalError {
+		return r.AuctionRepositoryInterface.ExtendAuction(ctx, auctionId, endsAt)
+	})
+}
+
+func (r *AuctionRepository) OpenAuctionBidding(
+	ctx context.Context,
+	auctionId string,
+	openedAt, endsAt time.Time) *internal_error.InternalError {
+	return observeErr(r.instrumentation, "auction", "OpenAuctionBidding", func() *internal_error.InternalError {
+		return r.AuctionRepositoryInterface.OpenAuctionBidding(ctx, auctionId, openedAt, endsAt)
+	})
+}
+
+func (r *AuctionRepository) UpdateAuctionIncrement(
+	ctx context.Context,
+	auctionId string,
+	minIncrement float64) *internal_error.InternalError {
+	return observeErr(r.instrumentation, "auction", "UpdateAuctionIncrement", func() *internal_error.InternalError {
+		return r.AuctionRepositoryInterface.UpdateAuctionIncrement(ctx, auctionId, minIncrement)
+	})
+}
+
+func (r *AuctionRepository) UpdateHeatScore(
+	ctx context.Context,
+	auctionId string,
+	heatScore float64) *internal_error.InternalError {
+	return observeErr(r.instrumentation, "auction", "UpdateHeatScore", func() *internal_error.InternalError {
+		return r.AuctionRepositoryInterface.UpdateHeatScore(ctx, auctionId, heatScore)
+	})
+}
+
+func (r *AuctionRepository) AddViews(
+	ctx context.Context,
+	auctionId string,
+	views int64) *internal_error.InternalError {
+	return observeErr(r.instrumentation, "auction", "AddViews", func() *internal_error.InternalError {
+		return r.AuctionRepositoryInterface.AddViews(ctx, auctionId, views)
+	})
+}
+
+func (r *AuctionRepository) UpdateDraft(
+	ctx context.Context,
+	auctionEntity *auction_entity.Auction) *internal_error.InternalError {
+	return observeErr(r.instrumentation, "auction", "UpdateDraft", func() *internal_error.InternalError {
+		return r.AuctionRepositoryInterface.UpdateDraft(ctx, auctionEntity)
+	})
+}
+
+func (r *AuctionRepository) PublishAuction(
+	ctx context.Context,
+	auctionEntity *auction_entity.Auction) *internal_error.InternalError {
+	return observeErr(r.instrumentation, "auction", "PublishAuction", func() *internal_error.InternalError {
+		return r.AuctionRepositoryInterface.PublishAuction(ctx, auctionEntity)
+	})
+}
+
+func (r *AuctionRepository) PauseAuction(
+	ctx context.Context,
+	auctionId string,
+	pausedAt time.Time) *internal_error.InternalError {
+	return observeErr(r.instrumentation, "auction", "PauseAuction", func() *internal_error.InternalError {
+		return r.AuctionRepositoryInterface.PauseAuction(ctx, auctionId, pausedAt)
+	})
+}
+
+func (r *AuctionRepository) ResumeAuction(
+	ctx context.Context,
+	auctionId string,
+	resumedAt time.Time) (time.Time, *internal_error.InternalError) {
+	return observe(r.instrumentation, "auction", "ResumeAuction", func() (time.Time, *internal_error.InternalError) {
+		return r.AuctionRepositoryInterface.ResumeAuction(ctx, auctionId, resumedAt)
+	})
+}
+
+func (r *AuctionRepository) SearchAuctions(
+	ctx context.Context, language, text string) ([]auction_entity.Auction, *internal_error.InternalError) {
+	return observe(r.instrumentation, "auction", "SearchAuctions", func() ([]auction_entity.Auction, *internal_error.InternalError) {
+		return r.AuctionRepositoryInterface.SearchAuctions(ctx, language, text)
+	})
+}
+
+func (r *AuctionRepository) FindSeriesAuctions(
+	ctx context.Context, seriesId string) ([]auction_entity.Auction, *internal_error.InternalError) {
+	return observe(r.instrumentation, "auction", "FindSeriesAuctions", func() ([]auction_entity.Auction, *internal_error.InternalError) {
+		return r.AuctionRepositoryInterface.FindSeriesAuctions(ctx, seriesId)
+	})
+}
+
+type BidRepository struct {
+	bid_entity.BidEntityRepository
+	instrumentation *Instrumentation
+}
+
+func NewBidRepository(
+	repository bid_entity.BidEntityRepository,
+	instrumentation *Instrumentation) *BidRepository {
+	return &BidRepository{
+		BidEntityRepository: repository,
+		instrumentation:     instrumentation,
+	}
+}
+
+func (r *BidRepository) CreateBid(
+	ctx context.Context,
+	bidEntities []bid_entity.Bid) ([]bid_entity.Bid, *internal_error.InternalError) {
+	return observe(r.instrumentation, "bid", "CreateBid", func() ([]bid_entity.Bid, *internal_error.InternalError) {
+		return r.BidEntityRepository.CreateBid(ctx, bidEntities)
+	})
+}
+
+func (r *BidRepository) FindBidByAuctionId(
+	ctx context.Context, auctionId string) ([]bid_entity.Bid, *internal_error.InternalError) {
+	return observe(r.instrumentation, "bid", "FindBidByAuctionId", func() ([]bid_entity.Bid, *internal_error.InternalError) {
+		return r.BidEntityRepository.FindBidByAuctionId(ctx, auctionId)
+	})
+}
+
+func (r *BidRepository) FindWinningBidByAuctionId(
+	ctx context.Context, auctionId string) (*bid_entity.Bid, *internal_error.InternalError) {
+	return observe(r.instrumentation, "bid", "FindWinningBidByAuctionId", func() (*bid_entity.Bid, *internal_error.InternalError) {
+		return r.BidEntityRepository.FindWinningBidByAuctionId(ctx, auctionId)
+	})
+}
+
+func (r *BidRepository) FindBidById(
+	ctx context.Context, bidId string) (*bid_entity.Bid, *internal_error.InternalError) {
+	return observe(r.instrumentation, "bid", "FindBidById", func() (*bid_entity.Bid, *internal_error.InternalError) {
+		return r.BidEntityRepository.FindBidById(ctx, bidId)
+	})
+}
+
+func (r *BidRepository) RetractBid(
+	ctx context.Context, bidId string, retractedAt time.Time) *internal_error.InternalError {
+	return observeErr(r.instrumentation, "bid", "RetractBid", func() *internal_error.InternalError {
+		return r.BidEntityRepository.RetractBid(ctx, bidId, retractedAt)
+	})
+}
+
+func (r *BidRepository) HasBids(
+	ctx context.Context, auctionId string) (bool, *internal_error.InternalError) {
+	return observe(r.instrumentation, "bid", "HasBids", func() (bool, *internal_error.InternalError) {
+		return r.BidEntityRepository.HasBids(ctx, auctionId)
+	})
+}
+
+func (r *BidRepository) FindUserAuctionBids(
+	ctx context.Context, userId string) (map[string][]bid_entity.Bid, *internal_error.InternalError) {
+	return observe(r.instrumentation, "bid", "FindUserAuctionBids", func() (map[string][]bid_entity.Bid, *internal_error.InternalError) {
+		return r.BidEntityRepository.FindUserAuctionBids(ctx, userId)
+	})
+}
+
+func (r *BidRepository) FindWinningBidsByAuctionIds(
+	ctx context.Context, auctionIds []string) (map[string]bid_entity.Bid, *internal_error.InternalError) {
+	return observe(r.instrumentation, "bid", "FindWinningBidsByAuctionIds", func() (map[string]bid_entity.Bid, *internal_error.InternalError) {
+		return r.BidEntityRepository.FindWinningBidsByAuctionIds(ctx, auctionIds)
+	})
+}
+
+func (r *BidRepository) StreamBids(
+	ctx context.Context,
+	auctionId string,
+	handle func(bid_entity.Bid) error) *internal_error.InternalError {
+	return observeErr(r.instrumentation, "bid", "StreamBids", func() *internal_error.InternalError {
+		return r.BidEntityRepository.StreamBids(ctx, auctionId, handle)
+	})
+}
+
+func (r *BidRepository) PurgeClientData(
+	ctx context.Context, before time.Time, dryRun bool) (int64, *internal_error.InternalError) {
+	return observe(r.instrumentation, "bid", "PurgeClientData", func() (int64, *internal_error.InternalError) {
+		return r.BidEntityRepository.PurgeClientData(ctx, before, dryRun)
+	})
+}
+
+func (r *BidRepository) ReplaceBidders(
+	ctx context.Context,
+	auctionId string,
+	userIds map[string]string) (int64, *internal_error.InternalError) {
+	return observe(r.instrumentation, "bid", "ReplaceBidders", func() (int64, *internal_error.InternalError) {
+		return r.BidEntityRepository.ReplaceBidders(ctx, auctionId, userIds)
+	})
+}
+
+type RejectedBidRepository struct {
+	bid_entity.RejectedBidRepositoryInterface
+	instrumentation *Instrumentation
+}
+
+func NewRejectedBidRepository(
+	repository bid_entity.RejectedBidRepositoryInterface,
+	instrumentation *Instrumentation) *RejectedBidRepository {
+	return &RejectedBidRepository{
+		RejectedBidRepositoryInterface: repository,
+		instrumentation:                instrumentation,
+	}
+}
+
+func (r *RejectedBidRepository) CreateRejectedBid(
+	ctx context.Context, rejectedBid *bid_entity.RejectedBid) *internal_error.InternalError {
+	return observeErr(r.instrumentation, "rejected_bid", "CreateRejectedBid", func() *internal_error.InternalError {
+		return r.RejectedBidRepositoryInterface.CreateRejectedBid(ctx, rejectedBid)
+	})
+}
+
+func (r *RejectedBidRepository) FindRejectedBids(
+	ctx context.Context, auctionId string) ([]bid_entity.RejectedBid, *internal_error.InternalError) {
+	return observe(r.instrumentation, "rejected_bid", "FindRejectedBids", func() ([]bid_entity.RejectedBid, *internal_error.InternalError) {
+		return r.RejectedBidRepositoryInterface.FindRejectedBids(ctx, auctionId)
+	})
+}
+
+func (r *RejectedBidRepository) PurgeClientData(
+	ctx context.Context, before time.Time, dryRun bool) (int64, *internal_error.InternalError) {
+	return observe(r.instrumentation, "rejected_bid", "PurgeClientData", func() (int64, *internal_error.InternalError) {
+		return r.RejectedBidRepositoryInterface.PurgeClientData(ctx, before, dryRun)
+	})
+}
+
+type UserRepository struct {
+	user_entity.UserRepositoryInterface
+	instrumentation *Instrumentation
+}
+
+func NewUserRepository(
+	repository user_entity.UserRepositoryInterface,
+	instrumentation *Instrumentation) *UserRepository {
+	return &UserRepository{
+		UserRepositoryInterface: repository,
+		instrumentation:         instrumentation,
+	}
+}
+
+func (r *UserRepository) FindUserById(
+	ctx context.Context, userId string) (*user_entity.User, *internal_error.InternalError) {
+	return observe(r.instrumentation, "user", "FindUserById", func() (*user_entity.User, *internal_error.InternalError) {
+		return r.UserRepositoryInterface.FindUserById(ctx, userId)
+	})
+}
+
+func (r *UserRepository) FindUsersByIds(
+	ctx context.Context, userIds []string) ([]user_entity.User, *internal_error.InternalError) {
+	return observe(r.instrumentation, "user", "FindUsersByIds", func() ([]user_entity.User, *internal_error.InternalError) {
+		return r.UserRepositoryInterface.FindUsersByIds(ctx, userIds)
+	})
+}
+
+func (r *UserRepository) UpdateUserProfile(
+	ctx context.Context, user *user_entity.User) *internal_error.InternalError {
+	return observeErr(r.instrumentation, "user", "UpdateUserProfile", func() *internal_error.InternalError {
+		return r.UserRepositoryInterface.UpdateUserProfile(ctx, user)
+	})
+}
+
+func (r *UserRepository) UpdateUserRole(
+	ctx context.Context, userId string, role user_entity.Role) *internal_error.InternalError {
+	return observeErr(r.instrumentation, "user", "UpdateUserRole", func() *internal_error.InternalError {
+		return r.UserRepositoryInterface.UpdateUserRole(ctx, userId, role)
+	})
+}
+
+func (r *UserRepository) CreateUser(
+	ctx context.Context, user *user_entity.User) *internal_error.InternalError {
+	return observeErr(r.instrumentation, "user", "CreateUser", func() *internal_error.InternalError {
+		return r.UserRepositoryInterface.CreateUser(ctx, user)
+	})
+}
+
+func (r *UserRepository) FindUserByEmail(
+	ctx context.Context, email string) (*user_entity.User, *internal_error.InternalError) {
+	return observe(r.instrumentation, "user", "FindUserByEmail", func() (*user_entity.User, *internal_error.InternalError) {
+		return r.UserRepositoryInterface.FindUserByEmail(ctx, email)
+	})
+}
+
+type ProductRepository struct {
+	product_entity.ProductRepositoryInterface
+	instrumentation *Instrumentation
+}
+
+func NewProductRepository(
+	repository product_entity.ProductRepositoryInterface,
+	instrumentation *Instrumentation) *ProductRepository {
+	return &ProductRepository{
+		ProductRepositoryInterface: repository,
+		instrumentation:            instrumentation,
+	}
+}
+
+func (r *ProductRepository) CreateProduct(
+	ctx context.Context,
+	productEntity *product_entity.Product) *internal_error.InternalError {
+	return observeErr(r.instrumentation, "product", "CreateProduct", func() *internal_error.InternalError {
+		return r.ProductRepositoryInterface.CreateProduct(ctx, productEntity)
+	})
+}
+
+func (r *ProductRepository) FindProductById(
+	ctx context.Context, id string) (*product_entity.Product, *internal_error.InternalError) {
+	return observe(r.instrumentation, "product", "FindProductById", func() (*product_entity.Product, *internal_error.InternalError) {
+		return r.ProductRepositoryInterface.FindProductById(ctx, id)
+	})
+}
+
+func (r *ProductRepository) FindProducts(
+	ctx context.Context,
+	category, sku string) ([]product_entity.Product, *internal_error.InternalError) {
+	return observe(r.instrumentation, "product", "FindProducts", func() ([]product_entity.Product, *internal_error.InternalError) {
+		return r.ProductRepositoryInterface.FindProducts(ctx, category, sku)
+	})
+}
+
+func (r *ProductRepository) UpdateProduct(
+	ctx context.Context,
+	productEntity *product_entity.Product) *internal_error.InternalError {
+	return observeErr(r.instrumentation, "product", "UpdateProduct", func() *internal_error.InternalError {
+		return r.ProductRepositoryInterface.UpdateProduct(ctx, productEntity)
+	})
+}
+
+func (r *ProductRepository) DeleteProduct(
+	ctx context.Context, id string) *internal_error.InternalError {
+	return observeErr(r.instrumentation, "product", "DeleteProduct", func() *internal_error.InternalError {
+		return r.ProductRepositoryInterface.DeleteProduct(ctx, id)
+	})
+}
+
+type CategorySchemaRepository struct {
+	category_entity.CategorySchemaRepositoryInterface
+	instrumentation *Instrumentation
+}
+
+func NewCategorySchemaRepository(
+	repository category_entity.CategorySchemaRepositoryInterface,
+	instrumentation *Instrumentation) *CategorySchemaRepository {
+	return &CategorySchemaRepository{
+		CategorySchemaRepositoryInterface: repository,
+		instrumentation:                   instrumentation,
+	}
+}
+
+func (r *CategorySchemaRepository) UpsertCategorySchema(
+	ctx context.Context,
+	schema *category_entity.CategorySchema) *internal_error.InternalError {
+	return observeErr(r.instrumentation, "category_schema", "UpsertCategorySchema", func() *internal_error.InternalError {
+		return r.CategorySchemaRepositoryInterface.UpsertCategorySchema(ctx, schema)
+	})
+}
+
+func (r *CategorySchemaRepository) FindCategorySchema(
+	ctx context.Context, category string) (*category_entity.CategorySchema, *internal_error.InternalError) {
+	return observe(r.instrumentation, "category_schema", "FindCategorySchema", func() (*category_entity.CategorySchema, *internal_error.InternalError) {
+		return r.CategorySchemaRepositoryInterface.FindCategorySchema(ctx, category)
+	})
+}
+
+type CategoryRepository struct {
+	category_entity.CategoryRepositoryInterface
+	instrumentation *Instrumentation
+}
+
+func NewCategoryRepository(
+	repository category_entity.CategoryRepositoryInterface,
+	instrumentation *Instrumentation) *CategoryRepository {
+	return &CategoryRepository{
+		CategoryRepositoryInterface: repository,
+		instrumentation:             instrumentation,
+	}
+}
+
+func (r *CategoryRepository) CreateCategory(
+	ctx context.Context, category *category_entity.Category) *internal_error.InternalError {
+	return observeErr(r.instrumentation, "category", "CreateCategory", func() *internal_error.InternalError {
+		return r.CategoryRepositoryInterface.CreateCategory(ctx, category)
+	})
+}
+
+func (r *CategoryRepository) UpdateCategory(
+	ctx context.Context, category *category_entity.Category) *internal_error.InternalError {
+	return observeErr(r.instrumentation, "category", "UpdateCategory", func() *internal_error.InternalError {
+		return r.CategoryRepositoryInterface.UpdateCategory(ctx, category)
+	})
+}
+
+func (r *CategoryRepository) DeleteCategory(
+	ctx context.Context, name string) *internal_error.InternalError {
+	return observeErr(r.instrumentation, "category", "DeleteCategory", func() *internal_error.InternalError {
+		return r.CategoryRepositoryInterface.DeleteCategory(ctx, name)
+	})
+}
+
+func (r *CategoryRepository) FindCategory(
+	ctx context.Context, name string) (*category_entity.Category, *internal_error.InternalError) {
+	return observe(r.instrumentation, "category", "FindCategory", func() (*category_entity.Category, *internal_error.InternalError) {
+		return r.CategoryRepositoryInterface.FindCategory(ctx, name)
+	})
+}
+
+func (r *CategoryRepository) FindCategories(
+	ctx context.Context) ([]category_entity.Category, *internal_error.InternalError) {
+	return observe(r.instrumentation, "category", "FindCategories", func() ([]category_entity.Category, *internal_error.InternalError) {
+		return r.CategoryRepositoryInterface.FindCategories(ctx)
+	})
+}
+
+type FraudFlagRepository struct {
+	fraud_entity.FraudFlagRepositoryInterface
+	instrumentation *Instrumentation
+}
+
+func NewFraudFlagRepository(
+	repository fraud_entity.FraudFlagRepositoryInterface,
+	instrumentation *Instrumentation) *FraudFlagRepository {
+	return &FraudFlagRepository{
+		FraudFlagRepositoryInterface: repository,
+		instrumentation:              instrumentation,
+	}
+}
+
+func (r *FraudFlagRepository) UpsertFraudFlag(
+	ctx context.Context, flag *fraud_entity.FraudFlag) *internal_error.InternalError {
+	return observeErr(r.instrumentation, "fraud_flag", "UpsertFraudFlag", func() *internal_error.InternalError {
+		return r.FraudFlagRepositoryInterface.UpsertFraudFlag(ctx, flag)
+	})
+}
+
+func (r *FraudFlagRepository) FindFraudFlags(
+	ctx context.Context, auctionId string) ([]fraud_entity.FraudFlag, *internal_error.InternalError) {
+	return observe(r.instrumentation, "fraud_flag", "FindFraudFlags", func() ([]fraud_entity.FraudFlag, *internal_error.InternalError) {
+		return r.FraudFlagRepositoryInterface.FindFraudFlags(ctx, auctionId)
+	})
+}
+
+type QuestionRepository struct {
+	question_entity.QuestionRepositoryInterface
+	instrumentation *Instrumentation
+}
+
+func NewQuestionRepository(
+	repository question_entity.QuestionRepositoryInterface,
+	instrumentation *Instrumentation) *QuestionRepository {
+	return &QuestionRepository{
+		QuestionRepositoryInterface: repository,
+		instrumentation:             instrumentation,
+	}
+}
+
+func (r *QuestionRepository) CreateQuestion(
+	ctx context.Context, question *question_entity.Question) *internal_error.InternalError {
+	return observeErr(r.instrumentation, "question", "CreateQuestion", func() *internal_error.InternalError {
+		return r.QuestionRepositoryInterface.CreateQuestion(ctx, question)
+	})
+}
+
+func (r *QuestionRepository) FindQuestionById(
+	ctx context.Context, id string) (*question_entity.Question, *internal_error.InternalError) {
+	return observe(r.instrumentation, "question", "FindQuestionById", func() (*question_entity.Question, *internal_error.InternalError) {
+		return r.QuestionRepositoryInterface.FindQuestionById(ctx, id)
+	})
+}
+
+func (r *QuestionRepository) FindQuestions(
+	ctx context.Context, auctionId string) ([]question_entity.Question, *internal_error.InternalError) {
+	return observe(r.instrumentation, "question", "FindQuestions", func() ([]question_entity.Question, *internal_error.InternalError) {
+		return r.QuestionRepositoryInterface.FindQuestions(ctx, auctionId)
+	})
+}
+
+func (r *QuestionRepository) UpdateQuestion(
+	ctx context.Context, question *question_entity.Question) *internal_error.InternalError {
+	return observeErr(r.instrumentation, "question", "UpdateQuestion", func() *internal_error.InternalError {
+		return r.QuestionRepositoryInterface.UpdateQuestion(ctx, question)
+	})
+}
+
+type TermsAcceptanceRepository struct {
+	auction_entity.TermsAcceptanceRepositoryInterface
+	instrumentation *Instrumentation
+}
+
+func NewTermsAcceptanceRepository(
+	repository auction_entity.TermsAcceptanceRepositoryInterface,
+	instrumentation *Instrumentation) *TermsAcceptanceRepository {
+	return &TermsAcceptanceRepository{
+		TermsAcceptanceRepositoryInterface: repository,
+		instrumentation:                    instrumentation,
+	}
+}
+
+func (r *TermsAcceptanceRepository) CreateTermsAcceptance(
+	ctx context.Context, acceptance *auction_entity.TermsAcceptance) *internal_error.InternalError {
+	return observeErr(r.instrumentation, "terms_acceptance", "CreateTermsAcceptance", func() *internal_error.InternalError {
+		return r.TermsAcceptanceRepositoryInterface.CreateTermsAcceptance(ctx, acceptance)
+	})
+}
+
+func (r *TermsAcceptanceRepository) FindTermsAcceptance(
+	ctx context.Context, auctionId, userId string) (*auction_entity.TermsAcceptance, *internal_error.InternalError) {
+	return observe(r.instrumentation, "terms_acceptance", "FindTermsAcceptance", func() (*auction_entity.TermsAcceptance, *internal_error.InternalError) {
+		return r.TermsAcceptanceRepositoryInterface.FindTermsAcceptance(ctx, auctionId, userId)
+	})
+}
+
+type OfferRepository struct {
+	offer_entity.OfferRepositoryInterface
+	instrumentation *Instrumentation
+}
+
+func NewOfferRepository(
+	repository offer_entity.OfferRepositoryInterface,
+	instrumentation *Instrumentation) *OfferRepository {
+	return &OfferRepository{
+		OfferRepositoryInterface: repository,
+		instrumentation:          instrumentation,
+	}
+}
+
+func (r *OfferRepository) CreateOffer(
+	ctx context.Context, offer *offer_entity.Offer) *internal_error.InternalError {
+	return observeErr(r.instrumentation, "offer", "CreateOffer", func() *internal_error.InternalError {
+		return r.OfferRepositoryInterface.CreateOffer(ctx, offer)
+	})
+}
+
+func (r *OfferRepository) FindOfferById(
+	ctx context.Context, id string) (*offer_entity.Offer, *internal_error.InternalError) {
+	return observe(r.instrumentation, "offer", "FindOfferById", func() (*offer_entity.Offer, *internal_error.InternalError) {
+		return r.OfferRepositoryInterface.FindOfferById(ctx, id)
+	})
+}
+
+func (r *OfferRepository) FindOffers(
+	ctx context.Context, auctionId string) ([]offer_entity.Offer, *internal_error.InternalError) {
+	return observe(r.instrumentation, "offer", "FindOffers", func() ([]offer_entity.Offer, *internal_error.InternalError) {
+		return r.OfferRepositoryInterface.FindOffers(ctx, auctionId)
+	})
+}
+
+func (r *OfferRepository) UpdateOfferStatus(
+	ctx context.Context,
+	id string,
+	status offer_entity.OfferStatus,
+	respondedAt time.Time) *internal_error.InternalError {
+	return observeErr(r.instrumentation, "offer", "UpdateOfferStatus", func() *internal_error.InternalError {
+		return r.OfferRepositoryInterface.UpdateOfferStatus(ctx, id, status, respondedAt)
+	})
+}
+
+type TransferRepository struct {
+	auction_entity.TransferRepositoryInterface
+	instrumentation *Instrumentation
+}
+
+func NewTransferRepository(
+	repository auction_entity.TransferRepositoryInterface,
+	instrumentation *Instrumentation) *TransferRepository {
+	return &TransferRepository{
+		TransferRepositoryInterface: repository,
+		instrumentation:             instrumentation,
+	}
+}
+
+func (r *TransferRepository) CreateTransfer(
+	ctx context.Context, transfer *auction_entity.Transfer) *internal_error.InternalError {
+	return observeErr(r.instrumentation, "transfer", "CreateTransfer", func() *internal_error.InternalError {
+		return r.TransferRepositoryInterface.CreateTransfer(ctx, transfer)
+	})
+}
+
+func (r *TransferRepository) FindTransfers(
+	ctx context.Context, auctionId string) ([]auction_entity.Transfer, *internal_error.InternalError) {
+	return observe(r.instrumentation, "transfer", "FindTransfers", func() ([]auction_entity.Transfer, *internal_error.InternalError) {
+		return r.TransferRepositoryInterface.FindTransfers(ctx, auctionId)
+	})
+}
+
+type JobRepository struct {
+	job_entity.JobRepositoryInterface
+	instrumentation *Instrumentation
+}
+
+func NewJobRepository(
+	repository job_entity.JobRepositoryInterface,
+	instrumentation *Instrumentation) *JobRepository {
+	return &JobRepository{
+		JobRepositoryInterface: repository,
+		instrumentation:        instrumentation,
+	}
+}
+
+func (r *JobRepository) CreateJob(
+	ctx context.Context, job *job_entity.Job) *internal_error.InternalError {
+	return observeErr(r.instrumentation, "job", "CreateJob", func() *internal_error.InternalError {
+		return r.JobRepositoryInterface.CreateJob(ctx, job)
+	})
+}
+
+func (r *JobRepository) ClaimJob(
+	ctx context.Context,
+	kinds []string,
+	now, lockedUntil time.Time) (*job_entity.Job, *internal_error.InternalError) {
+	return observe(r.instrumentation, "job", "ClaimJob", func() (*job_entity.Job, *internal_error.InternalError) {
+		return r.JobRepositoryInterface.ClaimJob(ctx, kinds, now, lockedUntil)
+	})
+}
+
+func (r *JobRepository) UpdateJob(
+	ctx context.Context, job *job_entity.Job, lockedUntil time.Time) *internal_error.InternalError {
+	return observeErr(r.instrumentation, "job", "UpdateJob", func() *internal_error.InternalError {
+		return r.JobRepositoryInterface.UpdateJob(ctx, job, lockedUntil)
+	})
+}
+
+func (r *JobRepository) FindJobById(
+	ctx context.Context, id string) (*job_entity.Job, *internal_error.InternalError) {
+	return observe(r.instrumentation, "job", "FindJobById", func() (*job_entity.Job, *internal_error.InternalError) {
+		return r.JobRepositoryInterface.FindJobById(ctx, id)
+	})
+}
+
+func (r *JobRepository) FindJobs(
+	ctx context.Context,
+	status job_entity.JobStatus,
+	kind string,
+	limit int) ([]job_entity.Job, *internal_error.InternalError) {
+	return observe(r.instrumentation, "job", "FindJobs", func() ([]job_entity.Job, *internal_error.InternalError) {
+		return r.JobRepositoryInterface.FindJobs(ctx, status, kind, limit)
+	})
+}
+
+type RecurringAuctionRepository struct {
+	auction_entity.RecurringAuctionRepositoryInterface
+	instrumentation *Instrumentation
+}
+
+func NewRecurringAuctionRepository(
+	repository auction_entity.RecurringAuctionRepositoryInterface,
+	instrumentation *Instrumentation) *RecurringAuctionRepository {
+	return &RecurringAuctionRepository{
+		RecurringAuctionRepositoryInterface: repository,
+		instrumentation:                     instrumentation,
+	}
+}
+
+func (r *RecurringAuctionRepository) CreateRecurringAuction(
+	ctx context.Context, recurringAuction *auction_entity.RecurringAuction) *internal_error.InternalError {
+	return observeErr(r.instrumentation, "recurring_auction", "CreateRecurringAuction", func() *internal_error.InternalError {
+		return r.RecurringAuctionRepositoryInterface.CreateRecurringAuction(ctx, recurringAuction)
+	})
+}
+
+func (r *RecurringAuctionRepository) FindRecurringAuctionById(
+	ctx context.Context, id string) (*auction_entity.RecurringAuction, *internal_error.InternalError) {
+	return observe(r.instrumentation, "recurring_auction", "FindRecurringAuctionById", func() (*auction_entity.RecurringAuction, *internal_error.InternalError) {
+		return r.RecurringAuctionRepositoryInterface.FindRecurringAuctionById(ctx, id)
+	})
+}
+
+func (r *RecurringAuctionRepository) FindRecurringAuctions(
+	ctx context.Context, sellerId string) ([]auction_entity.RecurringAuction, *internal_error.InternalError) {
+	return observe(r.instrumentation, "recurring_auction", "FindRecurringAuctions", func() ([]auction_entity.RecurringAuction, *internal_error.InternalError) {
+		return r.RecurringAuctionRepositoryInterface.FindRecurringAuctions(ctx, sellerId)
+	})
+}
+
+func (r *RecurringAuctionRepository) FindDueRecurringAuctions(
+	ctx context.Context, now time.Time) ([]auction_entity.RecurringAuction, *internal_error.InternalError) {
+	return observe(r.instrumentation, "recurring_auction", "FindDueRecurringAuctions", func() ([]auction_entity.RecurringAuction, *internal_error.InternalError) {
+		return r.RecurringAuctionRepositoryInterface.FindDueRecurringAuctions(ctx, now)
+	})
+}
+
+func (r *RecurringAuctionRepository) UpdateRecurringAuctionRun(
+	ctx context.Context, id string, runAt, nextRunAt time.Time) *internal_error.InternalError {
+	return observeErr(r.instrumentation, "recurring_auction", "UpdateRecurringAuctionRun", func() *internal_error.InternalError {
+		return r.RecurringAuctionRepositoryInterface.UpdateRecurringAuctionRun(ctx, id, runAt, nextRunAt)
+	})
+}
+
+func (r *RecurringAuctionRepository) UpdateRecurringAuctionActive(
+	ctx context.Context, id string, active bool, nextRunAt time.Time) *internal_error.InternalError {
+	return observeErr(r.instrumentation, "recurring_auction", "UpdateRecurringAuctionActive", func() *internal_error.InternalError {
+		return r.RecurringAuctionRepositoryInterface.UpdateRecurringAuctionActive(ctx, id, active, nextRunAt)
+	})
+}
+
+type SeriesRepository struct {
+	auction_entity.SeriesRepositoryInterface
+	instrumentation *Instrumentation
+}
+
+func NewSeriesRepository(
+	repository auction_entity.SeriesRepositoryInterface,
+	instrumentation *Instrumentation) *SeriesRepository {
+	return &SeriesRepository{
+		SeriesRepositoryInterface: repository,
+		instrumentation:           instrumentation,
+	}
+}
+
+func (r *SeriesRepository) CreateSeries(
+	ctx context.Context, series *auction_entity.Series) *internal_error.InternalError {
+	return observeErr(r.instrumentation, "series", "CreateSeries", func() *internal_error.InternalError {
+		return r.SeriesRepositoryInterface.CreateSeries(ctx, series)
+	})
+}
+
+func (r *SeriesRepository) FindSeriesById(
+	ctx context.Context, id string) (*auction_entity.Series, *internal_error.InternalError) {
+	return observe(r.instrumentation, "series", "FindSeriesById", func() (*auction_entity.Series, *internal_error.InternalError) {
+		return r.SeriesRepositoryInterface.FindSeriesById(ctx, id)
+	})
+}
+
+func (r *SeriesRepository) FindSeries(
+	ctx context.Context, sellerId string) ([]auction_entity.Series, *internal_error.InternalError) {
+	return observe(r.instrumentation, "series", "FindSeries", func() ([]auction_entity.Series, *internal_error.InternalError) {
+		return r.SeriesRepositoryInterface.FindSeries(ctx, sellerId)
+	})
+}
+
+func (r *SeriesRepository) NextLotNumber(
+	ctx context.Context, seriesId string) (int, *internal_error.InternalError) {
+	return observe(r.instrumentation, "series", "NextLotNumber", func() (int, *internal_error.InternalError) {
+		return r.SeriesRepositoryInterface.NextLotNumber(ctx, seriesId)
+	})
+}
+
+func (r *SeriesRepository) UpdateSeriesCascade(
+	ctx context.Context, seriesId string, interval time.Duration) *internal_error.InternalError {
+	return observeErr(r.instrumentation, "series", "UpdateSeriesCascade", func() *internal_error.InternalError {
+		return r.SeriesRepositoryInterface.UpdateSeriesCascade(ctx, seriesId, interval)
+	})
+}
+
+type AbsenteeBidRepository struct {
+	bid_entity.AbsenteeBidRepositoryInterface
+	instrumentation *Instrumentation
+}
+
+func NewAbsenteeBidRepository(
+	repository bid_entity.AbsenteeBidRepositoryInterface,
+	instrumentation *Instrumentation) *AbsenteeBidRepository {
+	return &AbsenteeBidRepository{
+		AbsenteeBidRepositoryInterface: repository,
+		instrumentation:                instrumentation,
+	}
+}
+
+func (r *AbsenteeBidRepository) UpsertAbsenteeBid(
+	ctx context.Context, absenteeBid *bid_entity.AbsenteeBid) *internal_error.InternalError {
+	return observeErr(r.instrumentation, "absentee_bid", "UpsertAbsenteeBid", func() *internal_error.InternalError {
+		return r.AbsenteeBidRepositoryInterface.UpsertAbsenteeBid(ctx, absenteeBid)
+	})
+}
+
+func (r *AbsenteeBidRepository) FindAbsenteeBid(
+	ctx context.Context, auctionId, userId string) (*bid_entity.AbsenteeBid, *internal_error.InternalError) {
+	return observe(r.instrumentation, "absentee_bid", "FindAbsenteeBid", func() (*bid_entity.AbsenteeBid, *internal_error.InternalError) {
+		return r.AbsenteeBidRepositoryInterface.FindAbsenteeBid(ctx, auctionId, userId)
+	})
+}
+
+func (r *AbsenteeBidRepository) FindPendingAbsenteeBids(
+	ctx context.Context, auctionId string) ([]bid_entity.AbsenteeBid, *internal_error.InternalError) {
+	return observe(r.instrumentation, "absentee_bid", "FindPendingAbsenteeBids", func() ([]bid_entity.AbsenteeBid, *internal_error.InternalError) {
+		return r.AbsenteeBidRepositoryInterface.FindPendingAbsenteeBids(ctx, auctionId)
+	})
+}
+
+func (r *AbsenteeBidRepository) UpdateAbsenteeBidStatus(
+	ctx context.Context, id string, status bid_entity.AbsenteeBidStatus) *internal_error.InternalError {
+	return observeErr(r.instrumentation, "absentee_bid", "UpdateAbsenteeBidStatus", func() *internal_error.InternalError {
+		return r.AbsenteeBidRepositoryInterface.UpdateAbsenteeBidStatus(ctx, id, status)
+	})
+}
+
+type ProxyBidRepository struct {
+	bid_entity.ProxyBidRepositoryInterface
+	instrumentation *Instrumentation
+}
+
+func NewProxyBidRepository(
+	repository bid_entity.ProxyBidRepositoryInterface,
+	instrumentation *Instrumentation) *ProxyBidRepository {
+	return &ProxyBidRepository{
+		ProxyBidRepositoryInterface: repository,
+		instrumentation:             instrumentation,
+	}
+}
+
+func (r *ProxyBidRepository) UpsertProxyBid(
+	ctx context.Context, proxyBid *bid_entity.ProxyBid) *internal_error.InternalError {
+	return observeErr(r.instrumentation, "proxy_bid", "UpsertProxyBid", func() *internal_error.InternalError {
+		return r.ProxyBidRepositoryInterface.UpsertProxyBid(ctx, proxyBid)
+	})
+}
+
+func (r *ProxyBidRepository) FindProxyBid(
+	ctx context.Context, auctionId, userId string) (*bid_entity.ProxyBid, *internal_error.InternalError) {
+	return observe(r.instrumentation, "proxy_bid", "FindProxyBid", func() (*bid_entity.ProxyBid, *internal_error.InternalError) {
+		return r.ProxyBidRepositoryInterface.FindProxyBid(ctx, auctionId, userId)
+	})
+}
+
+func (r *ProxyBidRepository) FindActiveProxyBids(
+	ctx context.Context, auctionId string) ([]bid_entity.ProxyBid, *internal_error.InternalError) {
+	return observe(r.instrumentation, "proxy_bid", "FindActiveProxyBids", func() ([]bid_entity.ProxyBid, *internal_error.InternalError) {
+		return r.ProxyBidRepositoryInterface.FindActiveProxyBids(ctx, auctionId)
+	})
+}
+
+func (r *ProxyBidRepository) UpdateProxyBidStatus(
+	ctx context.Context, id string, status bid_entity.ProxyBidStatus) *internal_error.InternalError {
+	return observeErr(r.instrumentation, "proxy_bid", "UpdateProxyBidStatus", func() *internal_error.InternalError {
+		return r.ProxyBidRepositoryInterface.UpdateProxyBidStatus(ctx, id, status)
+	})
+}
+
+type RegistrationRepository struct {
+	auction_entity.RegistrationRepositoryInterface
+	instrumentation *Instrumentation
+}
+
+func NewRegistrationRepository(
+	repository auction_entity.RegistrationRepositoryInterface,
+	instrumentation *Instrumentation) *RegistrationRepository {
+	return &RegistrationRepository{
+		RegistrationRepositoryInterface: repository,
+		instrumentation:                 instrumentation,
+	}
+}
+
+func (r *RegistrationRepository) UpsertRegistration(
+	ctx context.Context, registration *auction_entity.Registration) *internal_error.InternalError {
+	return observeErr(r.instrumentation, "registration", "UpsertRegistration", func() *internal_error.InternalError {
+		return r.RegistrationRepositoryInterface.UpsertRegistration(ctx, registration)
+	})
+}
+
+func (r *RegistrationRepository) FindRegistration(
+	ctx context.Context, auctionId, userId string) (*auction_entity.Registration, *internal_error.InternalError) {
+	return observe(r.instrumentation, "registration", "FindRegistration", func() (*auction_entity.Registration, *internal_error.InternalError) {
+		return r.RegistrationRepositoryInterface.FindRegistration(ctx, auctionId, userId)
+	})
+}
+
+func (r *RegistrationRepository) FindRegistrations(
+	ctx context.Context, auctionId string) ([]auction_entity.Registration, *internal_error.InternalError) {
+	return observe(r.instrumentation, "registration", "FindRegistrations", func() ([]auction_entity.Registration, *internal_error.InternalError) {
+		return r.RegistrationRepositoryInterface.FindRegistrations(ctx, auctionId)
+	})
+}
+
+func (r *RegistrationRepository) DecideRegistration(
+	ctx context.Context,
+	auctionId, userId string,
+	status auction_entity.RegistrationStatus,
+	decidedBy string) *internal_error.InternalError {
+	return observeErr(r.instrumentation, "registration", "DecideRegistration", func() *internal_error.InternalError {
+		return r.RegistrationRepositoryInterface.DecideRegistration(ctx, auctionId, userId, status, decidedBy)
+	})
+}
+
+type NotificationRepository struct {
+	notification_entity.NotificationRepositoryInterface
+	instrumentation *Instrumentation
+}
+
+func NewNotificationRepository(
+	repository notification_entity.NotificationRepositoryInterface,
+	instrumentation *Instrumentation) *NotificationRepository {
+	return &NotificationRepository{
+		NotificationRepositoryInterface: repository,
+		instrumentation:                 instrumentation,
+	}
+}
+
+func (r *NotificationRepository) CreateNotification(
+	ctx context.Context, notification *notification_entity.Notification) *internal_error.InternalError {
+	return observeErr(r.instrumentation, "notification", "CreateNotification", func() *internal_error.InternalError {
+		return r.NotificationRepositoryInterface.CreateNotification(ctx, notification)
+	})
+}
+
+func (r *NotificationRepository) FindNotifications(
+	ctx context.Context,
+	userId string,
+	archived bool) ([]notification_entity.Notification, *internal_error.InternalError) {
+	return observe(r.instrumentation, "notification", "FindNotifications", func() ([]notification_entity.Notification, *internal_error.InternalError) {
+		return r.NotificationRepositoryInterface.FindNotifications(ctx, userId, archived)
+	})
+}
+
+func (r *NotificationRepository) MarkNotificationsRead(
+	ctx context.Context,
+	userId string,
+	ids []string,
+	readAt time.Time) (int64, *internal_error.InternalError) {
+	return observe(r.instrumentation, "notification", "MarkNotificationsRead", func() (int64, *internal_error.InternalError) {
+		return r.NotificationRepositoryInterface.MarkNotificationsRead(ctx, userId, ids, readAt)
+	})
+}
+
+func (r *NotificationRepository) ArchiveNotifications(
+	ctx context.Context,
+	cutoff, archivedAt time.Time) (int64, *internal_error.InternalError) {
+	return observe(r.instrumentation, "notification", "ArchiveNotifications", func() (int64, *internal_error.InternalError) {
+		return r.NotificationRepositoryInterface.ArchiveNotifications(ctx, cutoff, archivedAt)
+	})
+}
@@ -0,0 +1,193 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/job_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// The payload is kept as the JSON text the handlers decode.
+type JobEntityMongo struct {
+	Id          string               `bson:"_id"`
+	Kind        string               `bson:"kind"`
+	Payload     string               `bson:"payload"`
+	Status      job_entity.JobStatus `bson:"status"`
+	Attempts    int                  `bson:"attempts"`
+	MaxAttempts int                  `bson:"max_attempts"`
+	RunAt       int64                `bson:"run_at"`
+	LockedUntil int64                `bson:"locked_until"`
+	LastError   string               `bson:"last_error,omitempty"`
+	Timestamp   int64                `bson:"timestamp"`
+	UpdatedAt   int64                `bson:"updated_at"`
+}
+
+type JobRepository struct {
+	Collection *mongo.Collection
+}
+
+func NewJobRepository(database *mongo.Database) *JobRepository {
+	return &JobRepository{
+		Collection: database.Collection("jobs"),
+	}
+}
+
+func (jr *JobRepository) CreateJob(
+	ctx context.Context, job *job_entity.Job) *internal_error.InternalError {
+	jobMongo := &JobEntityMongo{
+		Id:          job.Id,
+		Kind:        job.Kind,
+		Payload:     string(job.Payload),
+		Status:      job.Status,
+		Attempts:    job.Attempts,
+		MaxAttempts: job.MaxAttempts,
+		RunAt:       job.RunAt.UnixMilli(),
+		Timestamp:   job.Timestamp.UnixMilli(),
+		UpdatedAt:   job.UpdatedAt.UnixMilli(),
+	}
+
+	if _, err := jr.Collection.InsertOne(ctx, jobMongo); err != nil {
+		logger.Error("Error trying to insert job", err)
+		return internal_error.NewInternalServerError("Error trying to insert job")
+	}
+
+	return nil
+}
+
+func (jr *JobRepository) ClaimJob(
+	ctx context.Context,
+	kinds []string,
+	now, lockedUntil time.Time) (*job_entity.Job, *internal_error.InternalError) {
+	filter := bson.M{
+		"kind": bson.M{"$in": kinds},
+		"$or": bson.A{
+			bson.M{"status": job_entity.Pending, "run_at": bson.M{"$lte": now.UnixMilli()}},
+			bson.M{"status": job_entity.Running, "locked_until": bson.M{"$lt": now.UnixMilli()}},
+		},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"status":       job_entity.Running,
+			"locked_until": lockedUntil.UnixMilli(),
+			"updated_at":   now.UnixMilli(),
+		},
+		"$inc": bson.M{"attempts": 1},
+	}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "run_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var jobMongo JobEntityMongo
+	if err := jr.Collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&jobMongo); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, internal_error.NewNotFoundError("No job is due")
+		}
+
+		logger.Error("Error trying to claim job", err)
+		return nil, internal_error.NewInternalServerError("Error trying to claim job")
+	}
+
+	return jobMongo.toJobEntity(), nil
+}
+
+func (jr *JobRepository) UpdateJob(
+	ctx context.Context, job *job_entity.Job, lockedUntil time.Time) *internal_error.InternalError {
+	filter := bson.M{"_id": job.Id, "locked_until": lockedUntil.UnixMilli()}
+	update := bson.M{"$set": bson.M{
+		"status":       job.Status,
+		"attempts":     job.Attempts,
+		"run_at":       job.RunAt.UnixMilli(),
+		"locked_until": job.LockedUntil.UnixMilli(),
+		"last_error":   job.LastError,
+		"updated_at":   job.UpdatedAt.UnixMilli(),
+	}}
+
+	result, err := jr.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		logger.Error("Error trying to update job", err)
+		return internal_error.NewInternalServerError("Error trying to update job")
+	}
+
+	if result.MatchedCount == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("Job not found with this id = %s", job.Id))
+	}
+
+	return nil
+}
+
+func (jr *JobRepository) FindJobById(
+	ctx context.Context, id string) (*job_entity.Job, *internal_error.InternalError) {
+	var jobMongo JobEntityMongo
+	if err := jr.Collection.FindOne(ctx, bson.M{"_id": id}).Decode(&jobMongo); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, internal_error.NewNotFoundError(
+				fmt.Sprintf("Job not found with this id = %s", id))
+		}
+
+		logger.Error("Error trying to find job by id", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find job by id")
+	}
+
+	return jobMongo.toJobEntity(), nil
+}
+
+func (jr *JobRepository) FindJobs(
+	ctx context.Context,
+	status job_entity.JobStatus,
+	kind string,
+	limit int) ([]job_entity.Job, *internal_error.InternalError) {
+	filter := bson.M{}
+	if status != "" {
+		filter["status"] = status
+	}
+	if kind != "" {
+		filter["kind"] = kind
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "timestamp", Value: -1}}).
+		SetLimit(int64(limit))
+	cursor, err := jr.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		logger.Error("Error finding jobs", err)
+		return nil, internal_error.NewInternalServerError("Error finding jobs")
+	}
+	defer cursor.Close(ctx)
+
+	var jobsMongo []JobEntityMongo
+	if err := cursor.All(ctx, &jobsMongo); err != nil {
+		logger.Error("Error decoding jobs", err)
+		return nil, internal_error.NewInternalServerError("Error decoding jobs")
+	}
+
+	var jobs []job_entity.Job
+	for _, jobMongo := range jobsMongo {
+		jobs = append(jobs, *jobMongo.toJobEntity())
+	}
+
+	return jobs, nil
+}
+
+func (jm *JobEntityMongo) toJobEntity() *job_entity.Job {
+	return &job_entity.Job{
+		Id:          jm.Id,
+		Kind:        jm.Kind,
+		Payload:     []byte(jm.Payload),
+		Status:      jm.Status,
+		Attempts:    jm.Attempts,
+		MaxAttempts: jm.MaxAttempts,
+		RunAt:       time.UnixMilli(jm.RunAt),
+		LockedUntil: time.UnixMilli(jm.LockedUntil),
+		LastError:   jm.LastError,
+		Timestamp:   time.UnixMilli(jm.Timestamp),
+		UpdatedAt:   time.UnixMilli(jm.UpdatedAt),
+	}
+}
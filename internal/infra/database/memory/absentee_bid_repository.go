@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"sort"
+	"sync"
+)
+
+type AbsenteeBidRepository struct {
+	// absenteeBids is keyed by auction, then by user.
+	absenteeBids      map[string]map[string]bid_entity.AbsenteeBid
+	absenteeBidsMutex *sync.RWMutex
+}
+
+func NewAbsenteeBidRepository() *AbsenteeBidRepository {
+	return &AbsenteeBidRepository{
+		absenteeBids:      make(map[string]map[string]bid_entity.AbsenteeBid),
+		absenteeBidsMutex: &sync.RWMutex{},
+	}
+}
+
+func (ar *AbsenteeBidRepository) UpsertAbsenteeBid(
+	ctx context.Context, absenteeBid *bid_entity.AbsenteeBid) *internal_error.InternalError {
+	ar.absenteeBidsMutex.Lock()
+	defer ar.absenteeBidsMutex.Unlock()
+
+	auctionBids, ok := ar.absenteeBids[absenteeBid.AuctionId]
+	if !ok {
+		auctionBids = make(map[string]bid_entity.AbsenteeBid)
+		ar.absenteeBids[absenteeBid.AuctionId] = auctionBids
+	}
+
+	stored := *absenteeBid
+	if existing, ok := auctionBids[absenteeBid.UserId]; ok {
+		stored.Id = existing.Id
+	}
+	auctionBids[absenteeBid.UserId] = stored
+
+	return nil
+}
+
+func (ar *AbsenteeBidRepository) FindAbsenteeBid(
+	ctx context.Context, auctionId, userId string) (*bid_entity.AbsenteeBid, *internal_error.InternalError) {
+	ar.absenteeBidsMutex.RLock()
+	defer ar.absenteeBidsMutex.RUnlock()
+
+	absenteeBid, ok := ar.absenteeBids[auctionId][userId]
+	if !ok {
+		return nil, internal_error.NewNotFoundError(
+			fmt.Sprintf("Absentee bid not found for auction = %s and user = %s", auctionId, userId))
+	}
+
+	return &absenteeBid, nil
+}
+
+func (ar *AbsenteeBidRepository) FindPendingAbsenteeBids(
+	ctx context.Context, auctionId string) ([]bid_entity.AbsenteeBid, *internal_error.InternalError) {
+	ar.absenteeBidsMutex.RLock()
+	defer ar.absenteeBidsMutex.RUnlock()
+
+	var absenteeBids []bid_entity.AbsenteeBid
+	for _, absenteeBid := range ar.absenteeBids[auctionId] {
+		if absenteeBid.Status == bid_entity.AbsenteePending {
+			absenteeBids = append(absenteeBids, absenteeBid)
+		}
+	}
+
+	sort.Slice(absenteeBids, func(i, j int) bool {
+		if absenteeBids[i].MaxAmount != absenteeBids[j].MaxAmount {
+			return absenteeBids[i].MaxAmount > absenteeBids[j].MaxAmount
+		}
+		return absenteeBids[i].Timestamp.Before(absenteeBids[j].Timestamp)
+	})
+
+	return absenteeBids, nil
+}
+
+func (ar *AbsenteeBidRepository) UpdateAbsenteeBidStatus(
+	ctx context.Context, id string, status bid_entity.AbsenteeBidStatus) *internal_error.InternalError {
+	ar.absenteeBidsMutex.Lock()
+	defer ar.absenteeBidsMutex.Unlock()
+
+	for _, auctionBids := range ar.absenteeBids {
+		for userId, absenteeBid := range auctionBids {
+			if absenteeBid.Id == id {
+				absenteeBid.Status = status
+				auctionBids[userId] = absenteeBid
+				return nil
+			}
+		}
+	}
+
+	return internal_error.NewNotFoundError(
+		fmt.Sprintf("Absentee bid not found with this id = %s", id))
+}
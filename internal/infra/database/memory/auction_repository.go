@@ -0,0 +1,690 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/configuration/clock"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/event_entity"
+	"fullcycle-auction_go/internal/infra/scheduler"
+	"fullcycle-auction_go/internal/internal_error"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+type AuctionRepository struct {
+	auctions        map[string]auction_entity.Auction
+	auctionsMutex   *sync.RWMutex
+	auctionInterval time.Duration
+	completionGrace time.Duration
+	publisher       event_entity.Publisher
+	scheduler       *scheduler.Scheduler
+}
+
+// NewAuctionRepository publishes the completion of every auction to
+// publisher, which may be nil.
+func NewAuctionRepository(publisher event_entity.Publisher) *AuctionRepository {
+	return &AuctionRepository{
+		publisher:       publisher,
+		auctions:        make(map[string]auction_entity.Auction),
+		auctionsMutex:   &sync.RWMutex{},
+		auctionInterval: getAuctionInterval(),
+		completionGrace: getCompletionGrace(),
+		scheduler:       scheduler.New(),
+	}
+}
+
+func (ar *AuctionRepository) CreateAuction(
+	ctx context.Context,
+	auctionEntity *auction_entity.Auction) *internal_error.InternalError {
+	if auctionEntity.EndsAt.IsZero() {
+		auctionEntity.EndsAt = auctionEntity.OpensAt().Add(ar.auctionInterval)
+	}
+
+	ar.auctionsMutex.Lock()
+	ar.auctions[auctionEntity.Id] = *auctionEntity
+	ar.auctionsMutex.Unlock()
+
+	ar.scheduleTimers(auctionEntity)
+
+	return nil
+}
+
+// scheduleTimers starts the timer of the auction's current status, drafts
+// having none until published.
+func (ar *AuctionRepository) scheduleTimers(auctionEntity *auction_entity.Auction) {
+	switch auctionEntity.Status {
+	case auction_entity.Draft:
+	case auction_entity.Scheduled:
+		ar.scheduleStart(auctionEntity.Id, auctionEntity.StartsAt, auctionEntity.EndsAt)
+	default:
+		ar.scheduleCompletion(auctionEntity.Id, auctionEntity.EndsAt)
+	}
+}
+
+func (ar *AuctionRepository) scheduleStart(auctionId string, startsAt, endsAt time.Time) {
+	ar.scheduler.Schedule(auctionId, startsAt, func() { ar.startAuction(auctionId, endsAt) })
+}
+
+// scheduleCompletion completes the auction once the completion grace has
+// passed its end too, leaving the bids accepted before the end time to be
+// flushed. It replaces the timer the auction had.
+func (ar *AuctionRepository) scheduleCompletion(auctionId string, endsAt time.Time) {
+	ar.scheduler.Schedule(auctionId, endsAt.Add(ar.completionGrace), func() { ar.completeAuction(auctionId, endsAt) })
+}
+
+// startAuction activates the scheduled auction and schedules its end.
+func (ar *AuctionRepository) startAuction(auctionId string, endsAt time.Time) {
+	ar.auctionsMutex.Lock()
+	auction, ok := ar.auctions[auctionId]
+	if !ok || auction.Status != auction_entity.Scheduled {
+		ar.auctionsMutex.Unlock()
+		return
+	}
+
+	auction.Status = auction_entity.Active
+	auction.UpdatedAt = clock.Now()
+	ar.auctions[auctionId] = auction
+	ar.auctionsMutex.Unlock()
+
+	if ar.publisher != nil {
+		ar.publisher.Publish(context.Background(), event_entity.NewEvent(event_entity.AuctionStarted, auctionId, nil))
+	}
+
+	ar.scheduleCompletion(auctionId, endsAt)
+}
+
+// completeAuction marks the auction completed, unless its end moved past
+// the one it was scheduled for, when it is scheduled again.
+func (ar *AuctionRepository) completeAuction(auctionId string, endsAt time.Time) {
+	ar.auctionsMutex.Lock()
+	auction, ok := ar.auctions[auctionId]
+	if !ok || auction.Status != auction_entity.Active {
+		ar.auctionsMutex.Unlock()
+		return
+	}
+
+	if auction.EndsAt.After(endsAt) {
+		ar.auctionsMutex.Unlock()
+		ar.scheduleCompletion(auctionId, auction.EndsAt)
+		return
+	}
+
+	auction.Status = auction_entity.Completed
+	auction.UpdatedAt = clock.Now()
+	ar.auctions[auctionId] = auction
+	ar.auctionsMutex.Unlock()
+
+	ar.publishCompleted(context.Background(), auctionId)
+}
+
+func (ar *AuctionRepository) publishCompleted(ctx context.Context, auctionId string) {
+	if ar.publisher != nil {
+		ar.publisher.Publish(ctx, event_entity.NewEvent(event_entity.AuctionCompleted, auctionId, nil))
+	}
+}
+
+func (ar *AuctionRepository) FindAuctionById(
+	ctx context.Context, id string) (*auction_entity.Auction, *internal_error.InternalError) {
+	ar.auctionsMutex.RLock()
+	defer ar.auctionsMutex.RUnlock()
+
+	auction, ok := ar.auctions[id]
+	if !ok {
+		return nil, internal_error.NewNotFoundError(
+			fmt.Sprintf("Auction not found with this id = %s", id))
+	}
+
+	return &auction, nil
+}
+
+func (ar *AuctionRepository) FindAuctionsByIds(
+	ctx context.Context, ids []string) ([]auction_entity.Auction, *internal_error.InternalError) {
+	ar.auctionsMutex.RLock()
+	defer ar.auctionsMutex.RUnlock()
+
+	var auctions []auction_entity.Auction
+	for _, id := range ids {
+		if auction, ok := ar.auctions[id]; ok {
+			auctions = append(auctions, auction)
+		}
+	}
+
+	return auctions, nil
+}
+
+func (ar *AuctionRepository) FindAuctions(
+	ctx context.Context,
+	filter auction_entity.AuctionFilter,
+	fields []string) ([]auction_entity.Auction, *internal_error.InternalError) {
+	ar.auctionsMutex.RLock()
+	defer ar.auctionsMutex.RUnlock()
+
+	var auctions []auction_entity.Auction
+	for _, auction := range ar.auctions {
+		if filter.Status != 0 && auction.Status != filter.Status {
+			continue
+		}
+
+		if len(filter.Categories) > 0 && !matchCategory(auction.Category, filter.Categories) {
+			continue
+		}
+
+		if filter.ProductName != "" &&
+			!strings.Contains(strings.ToLower(auction.ProductName), strings.ToLower(filter.ProductName)) {
+			continue
+		}
+
+		if !matchAttributes(auction.Attributes, filter.Attributes) {
+			continue
+		}
+
+		if !matchBounds(&auction, filter) {
+			continue
+		}
+
+		auctions = append(auctions, auction)
+	}
+
+	return auctions, nil
+}
+
+// SearchAuctions matches the words of text anywhere in the searched
+// fields, ignoring case, with no stemming.
+func (ar *AuctionRepository) SearchAuctions(
+	ctx context.Context, language, text string) ([]auction_entity.Auction, *internal_error.InternalError) {
+	ar.auctionsMutex.RLock()
+	defer ar.auctionsMutex.RUnlock()
+
+	auctions := make([]auction_entity.Auction, 0, len(ar.auctions))
+	for _, auction := range ar.auctions {
+		auctions = append(auctions, auction)
+	}
+
+	return auction_entity.RankSearchResults(auctions, language, text), nil
+}
+
+func (ar *AuctionRepository) FindSellerAuctions(
+	ctx context.Context,
+	sellerId string,
+	status auction_entity.AuctionStatus,
+	offset, limit int) ([]auction_entity.Auction, int, *internal_error.InternalError) {
+	ar.auctionsMutex.RLock()
+	defer ar.auctionsMutex.RUnlock()
+
+	var auctions []auction_entity.Auction
+	for _, auction := range ar.auctions {
+		if auction.SellerId == sellerId && auction.Status == status {
+			auctions = append(auctions, auction)
+		}
+	}
+
+	sort.Slice(auctions, func(i, j int) bool {
+		if !auctions[i].Timestamp.Equal(auctions[j].Timestamp) {
+			return auctions[i].Timestamp.After(auctions[j].Timestamp)
+		}
+		return auctions[i].Id < auctions[j].Id
+	})
+
+	total := len(auctions)
+	if offset >= total {
+		return nil, total, nil
+	}
+
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+
+	return auctions[offset:end], total, nil
+}
+
+func (ar *AuctionRepository) FindEstimatedAuctions(
+	ctx context.Context,
+	sellerId, category string) ([]auction_entity.Auction, *internal_error.InternalError) {
+	ar.auctionsMutex.RLock()
+	defer ar.auctionsMutex.RUnlock()
+
+	var auctions []auction_entity.Auction
+	for _, auction := range ar.auctions {
+		if auction.Status != auction_entity.Completed && auction.Status != auction_entity.ClosedNoSale {
+			continue
+		}
+		if !auction.HasEstimate() ||
+			(sellerId != "" && auction.SellerId != sellerId) ||
+			(category != "" && auction.Category != category) {
+			continue
+		}
+		auctions = append(auctions, auction)
+	}
+
+	return auctions, nil
+}
+
+func (ar *AuctionRepository) FindAuctionChanges(
+	ctx context.Context, since time.Time) ([]auction_entity.Auction, *internal_error.InternalError) {
+	ar.auctionsMutex.RLock()
+	defer ar.auctionsMutex.RUnlock()
+
+	var auctions []auction_entity.Auction
+	for _, auction := range ar.auctions {
+		// Compare at the millisecond precision the persistent backends keep.
+		if auction.UpdatedAt.UnixMilli() > since.UnixMilli() {
+			auctions = append(auctions, auction)
+		}
+	}
+
+	sort.Slice(auctions, func(i, j int) bool {
+		return auctions[i].UpdatedAt.Before(auctions[j].UpdatedAt)
+	})
+
+	return auctions, nil
+}
+
+func (ar *AuctionRepository) FindSeriesAuctions(
+	ctx context.Context, seriesId string) ([]auction_entity.Auction, *internal_error.InternalError) {
+	ar.auctionsMutex.RLock()
+	defer ar.auctionsMutex.RUnlock()
+
+	var auctions []auction_entity.Auction
+	for _, auction := range ar.auctions {
+		if auction.SeriesId == seriesId {
+			auctions = append(auctions, auction)
+		}
+	}
+
+	sort.Slice(auctions, func(i, j int) bool {
+		return auctions[i].LotNumber < auctions[j].LotNumber
+	})
+
+	return auctions, nil
+}
+
+func (ar *AuctionRepository) UpdateAuctionWinner(
+	ctx context.Context, auctionId, winnerBidId string) *internal_error.InternalError {
+	ar.auctionsMutex.Lock()
+	defer ar.auctionsMutex.Unlock()
+
+	auction, ok := ar.auctions[auctionId]
+	if !ok {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("Auction not found with this id = %s", auctionId))
+	}
+
+	auction.WinnerBidId = winnerBidId
+	auction.UpdatedAt = clock.Now()
+	ar.auctions[auctionId] = auction
+
+	return nil
+}
+
+func (ar *AuctionRepository) UpdateAuctionStatus(
+	ctx context.Context,
+	auctionId string,
+	expected, status auction_entity.AuctionStatus) *internal_error.InternalError {
+	ar.auctionsMutex.Lock()
+	defer ar.auctionsMutex.Unlock()
+
+	auction, ok := ar.auctions[auctionId]
+	if !ok {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("Auction not found with this id = %s", auctionId))
+	}
+	if auction.Status != expected {
+		return internal_error.NewConflictError(
+			fmt.Sprintf("Auction %s changed status meanwhile", auctionId))
+	}
+
+	auction.Status = status
+	auction.UpdatedAt = clock.Now()
+	ar.auctions[auctionId] = auction
+
+	if status.Ended() {
+		ar.scheduler.Cancel(auctionId)
+	}
+
+	switch status {
+	case auction_entity.Completed:
+		ar.publishCompleted(ctx, auctionId)
+	case auction_entity.Cancelled:
+		if ar.publisher != nil {
+			ar.publisher.Publish(ctx, event_entity.NewEvent(event_entity.AuctionCancelled, auctionId, nil))
+		}
+	}
+
+	return nil
+}
+
+func (ar *AuctionRepository) UpdateAuctionSeller(
+	ctx context.Context, auctionId, fromSellerId, toSellerId string) *internal_error.InternalError {
+	ar.auctionsMutex.Lock()
+	defer ar.auctionsMutex.Unlock()
+
+	auction, ok := ar.auctions[auctionId]
+	if !ok || auction.SellerId != fromSellerId {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("Auction not found with this id = %s and seller = %s", auctionId, fromSellerId))
+	}
+
+	auction.SellerId = toSellerId
+	auction.UpdatedAt = clock.Now()
+	ar.auctions[auctionId] = auction
+
+	return nil
+}
+
+func (ar *AuctionRepository) UpdateAuctionDetails(
+	ctx context.Context, auctionEntity *auction_entity.Auction) *internal_error.InternalError {
+	ar.auctionsMutex.Lock()
+	defer ar.auctionsMutex.Unlock()
+
+	auction, ok := ar.auctions[auctionEntity.Id]
+	if !ok || auction.Status.Ended() {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("No open auction found with this id = %s", auctionEntity.Id))
+	}
+
+	auction.ProductName = auctionEntity.ProductName
+	auction.Description = auctionEntity.Description
+	auction.Category = auctionEntity.Category
+	auction.Condition = auctionEntity.Condition
+	auction.Grading = auctionEntity.Grading
+	auction.UpdatedAt = auctionEntity.UpdatedAt
+	ar.auctions[auctionEntity.Id] = auction
+
+	return nil
+}
+
+func (ar *AuctionRepository) ExtendAuction(
+	ctx context.Context,
+	auctionId string,
+	endsAt time.Time) *internal_error.InternalError {
+	ar.auctionsMutex.Lock()
+	defer ar.auctionsMutex.Unlock()
+
+	auction, ok := ar.auctions[auctionId]
+	if !ok || auction.Status != auction_entity.Active || !endsAt.After(auction.EndsAt) {
+		return nil
+	}
+
+	auction.EndsAt = endsAt
+	auction.UpdatedAt = clock.Now()
+	ar.auctions[auctionId] = auction
+
+	ar.scheduleCompletion(auctionId, endsAt)
+
+	return nil
+}
+
+func (ar *AuctionRepository) OpenAuctionBidding(
+	ctx context.Context,
+	auctionId string,
+	openedAt, endsAt time.Time) *internal_error.InternalError {
+	ar.auctionsMutex.Lock()
+	defer ar.auctionsMutex.Unlock()
+
+	auction, ok := ar.auctions[auctionId]
+	if !ok || auction.Status != auction_entity.Active || !auction.Live || !auction.BiddingOpenedAt.IsZero() {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("No live auction closed to bids found with this id = %s", auctionId))
+	}
+
+	auction.BiddingOpenedAt = openedAt
+	if endsAt.After(auction.EndsAt) {
+		auction.EndsAt = endsAt
+	}
+	auction.UpdatedAt = clock.Now()
+	ar.auctions[auctionId] = auction
+
+	return nil
+}
+
+func (ar *AuctionRepository) UpdateAuctionIncrement(
+	ctx context.Context,
+	auctionId string,
+	minIncrement float64) *internal_error.InternalError {
+	ar.auctionsMutex.Lock()
+	defer ar.auctionsMutex.Unlock()
+
+	auction, ok := ar.auctions[auctionId]
+	if !ok || auction.Status != auction_entity.Active {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("No active auction found with this id = %s", auctionId))
+	}
+
+	auction.MinIncrement = minIncrement
+	auction.UpdatedAt = clock.Now()
+	ar.auctions[auctionId] = auction
+
+	return nil
+}
+
+func (ar *AuctionRepository) UpdateHeatScore(
+	ctx context.Context,
+	auctionId string,
+	heatScore float64) *internal_error.InternalError {
+	ar.auctionsMutex.Lock()
+	defer ar.auctionsMutex.Unlock()
+
+	auction, ok := ar.auctions[auctionId]
+	if !ok {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("Auction not found with this id = %s", auctionId))
+	}
+
+	auction.HeatScore = heatScore
+	ar.auctions[auctionId] = auction
+
+	return nil
+}
+
+func (ar *AuctionRepository) AddViews(
+	ctx context.Context,
+	auctionId string,
+	views int64) *internal_error.InternalError {
+	ar.auctionsMutex.Lock()
+	defer ar.auctionsMutex.Unlock()
+
+	auction, ok := ar.auctions[auctionId]
+	if !ok {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("Auction not found with this id = %s", auctionId))
+	}
+
+	auction.Views += views
+	ar.auctions[auctionId] = auction
+
+	return nil
+}
+
+func (ar *AuctionRepository) UpdateDraft(
+	ctx context.Context,
+	auctionEntity *auction_entity.Auction) *internal_error.InternalError {
+	if auctionEntity.EndsAt.IsZero() {
+		auctionEntity.EndsAt = auctionEntity.OpensAt().Add(ar.auctionInterval)
+	}
+
+	ar.auctionsMutex.Lock()
+	defer ar.auctionsMutex.Unlock()
+
+	auction, ok := ar.auctions[auctionEntity.Id]
+	if !ok || auction.Status != auction_entity.Draft {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("No draft auction found with this id = %s", auctionEntity.Id))
+	}
+
+	ar.auctions[auctionEntity.Id] = *auctionEntity
+
+	return nil
+}
+
+func (ar *AuctionRepository) PublishAuction(
+	ctx context.Context,
+	auctionEntity *auction_entity.Auction) *internal_error.InternalError {
+	ar.auctionsMutex.Lock()
+	auction, ok := ar.auctions[auctionEntity.Id]
+	if !ok || auction.Status != auction_entity.Draft {
+		ar.auctionsMutex.Unlock()
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("No draft auction found with this id = %s", auctionEntity.Id))
+	}
+
+	auction.Status = auctionEntity.Status
+	auction.StartsAt = auctionEntity.StartsAt
+	auction.EndsAt = auctionEntity.EndsAt
+	auction.PublicAt = auctionEntity.PublicAt
+	auction.UpdatedAt = auctionEntity.UpdatedAt
+	ar.auctions[auctionEntity.Id] = auction
+	ar.auctionsMutex.Unlock()
+
+	ar.scheduleTimers(auctionEntity)
+
+	return nil
+}
+
+func (ar *AuctionRepository) PauseAuction(
+	ctx context.Context,
+	auctionId string,
+	pausedAt time.Time) *internal_error.InternalError {
+	ar.auctionsMutex.Lock()
+	defer ar.auctionsMutex.Unlock()
+
+	auction, ok := ar.auctions[auctionId]
+	if !ok || auction.Status != auction_entity.Active {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("No active auction found with this id = %s", auctionId))
+	}
+
+	auction.Status = auction_entity.Paused
+	auction.PausedAt = pausedAt
+	auction.UpdatedAt = clock.Now()
+	ar.auctions[auctionId] = auction
+
+	ar.scheduler.Cancel(auctionId)
+
+	if ar.publisher != nil {
+		ar.publisher.Publish(ctx, event_entity.NewEvent(event_entity.AuctionPaused, auctionId, nil))
+	}
+
+	return nil
+}
+
+// ResumeAuction schedules the completion the pause cancelled again, at the
+// end moved by the pause.
+func (ar *AuctionRepository) ResumeAuction(
+	ctx context.Context,
+	auctionId string,
+	resumedAt time.Time) (time.Time, *internal_error.InternalError) {
+	ar.auctionsMutex.Lock()
+	defer ar.auctionsMutex.Unlock()
+
+	auction, ok := ar.auctions[auctionId]
+	if !ok || auction.Status != auction_entity.Paused {
+		return time.Time{}, internal_error.NewNotFoundError(
+			fmt.Sprintf("No paused auction found with this id = %s", auctionId))
+	}
+
+	if resumedAt.After(auction.PausedAt) {
+		auction.EndsAt = auction.EndsAt.Add(resumedAt.Sub(auction.PausedAt))
+	}
+	auction.Status = auction_entity.Active
+	auction.PausedAt = time.Time{}
+	auction.UpdatedAt = clock.Now()
+	ar.auctions[auctionId] = auction
+
+	ar.scheduleCompletion(auctionId, auction.EndsAt)
+
+	if ar.publisher != nil {
+		ar.publisher.Publish(ctx, event_entity.NewEvent(event_entity.AuctionResumed, auctionId,
+			map[string]interface{}{"ends_at": auction.EndsAt}))
+	}
+
+	return auction.EndsAt, nil
+}
+
+func matchAttributes(values, filter map[string]string) bool {
+	for name, value := range filter {
+		if values[name] != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchBounds tells whether the starting price and the end and creation
+// times of the auction are within the bounds of the filter.
+func matchBounds(auction *auction_entity.Auction, filter auction_entity.AuctionFilter) bool {
+	if auction.StartingPrice < filter.MinPrice ||
+		(filter.MaxPrice > 0 && auction.StartingPrice > filter.MaxPrice) {
+		return false
+	}
+
+	if (!filter.EndingAfter.IsZero() && !auction.EndsAt.After(filter.EndingAfter)) ||
+		(!filter.EndingBefore.IsZero() && !auction.EndsAt.Before(filter.EndingBefore)) {
+		return false
+	}
+
+	return filter.CreatedAfter.IsZero() || auction.Timestamp.After(filter.CreatedAfter)
+}
+
+func matchCategory(category string, categories []string) bool {
+	for _, candidate := range categories {
+		if candidate == category {
+			return true
+		}
+	}
+
+	return false
+}
+
+func getAuctionInterval() time.Duration {
+	auctionInterval := os.Getenv("AUCTION_INTERVAL")
+	duration, err := time.ParseDuration(auctionInterval)
+	if err != nil {
+		logger.Error("Error parsing auction interval", err)
+		return 1 * time.Minute
+	}
+	return duration
+}
+
+// getCompletionGrace reads AUCTION_COMPLETION_GRACE, how long completion
+// waits past the end for bids accepted before it to be flushed.
+func getCompletionGrace() time.Duration {
+	completionGrace := os.Getenv("AUCTION_COMPLETION_GRACE")
+	duration, err := time.ParseDuration(completionGrace)
+	if err != nil {
+		return 2 * time.Second
+	}
+	return duration
+}
+
+func (ar *AuctionRepository) StreamAuctions(
+	ctx context.Context,
+	status auction_entity.AuctionStatus,
+	category string,
+	handle func(auction_entity.Auction) error) *internal_error.InternalError {
+	var categories []string
+	if category != "" {
+		categories = []string{category}
+	}
+	auctions, _ := ar.FindAuctions(
+		ctx, auction_entity.AuctionFilter{Status: status, Categories: categories}, nil)
+
+	for _, auction := range auctions {
+		if err := ctx.Err(); err != nil {
+			return internal_error.NewInternalServerError("Error streaming auctions")
+		}
+
+		if err := handle(auction); err != nil {
+			logger.Error("Error streaming auctions", err)
+			return internal_error.NewInternalServerError("Error streaming auctions")
+		}
+	}
+
+	return nil
+}
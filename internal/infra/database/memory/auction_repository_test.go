@@ -0,0 +1,166 @@
+package memory
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/clock"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/usecase/lifecycle_usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func findStatus(t *testing.T, repository *AuctionRepository, auctionId string) auction_entity.AuctionStatus {
+	auction, err := repository.FindAuctionById(context.Background(), auctionId)
+	require.Nil(t, err)
+	return auction.Status
+}
+
+func TestExtendAuction_LateBidUnderFixedWindowPolicy(t *testing.T) {
+	t.Setenv("AUCTION_COMPLETION_GRACE", "0s")
+	t.Setenv("AUCTION_EXTENSION_POLICY", "fixed")
+	t.Setenv("AUCTION_EXTENSION_WINDOW", "1m")
+	t.Setenv("AUCTION_EXTENSION_STEP", "1s")
+
+	repository := NewAuctionRepository(nil)
+	manager := lifecycle_usecase.NewLifecycleManager(repository, lifecycle_usecase.NewExtensionPolicy(), nil)
+
+	now := clock.Now()
+	auction := &auction_entity.Auction{
+		Id: "auction", Status: auction_entity.Active, Timestamp: now, EndsAt: now.Add(100 * time.Millisecond),
+	}
+	require.Nil(t, repository.CreateAuction(context.Background(), auction))
+	scheduledEnd := auction.EndsAt
+
+	// Within the window of the end, so the end moves to a step after it.
+	bid := bid_entity.Bid{AuctionId: auction.Id, Timestamp: now}
+	endsAt := manager.OnBidAccepted(context.Background(), auction, bid)
+	assert.Equal(t, now.Add(time.Second), endsAt)
+
+	extended, err := repository.FindAuctionById(context.Background(), auction.Id)
+	require.Nil(t, err)
+	assert.Equal(t, endsAt, extended.EndsAt)
+
+	// The completion scheduled for the former end is rescheduled instead.
+	repository.completeAuction(auction.Id, scheduledEnd)
+	assert.Equal(t, auction_entity.Active, findStatus(t, repository, auction.Id))
+
+	time.Sleep(200 * time.Millisecond)
+	assert.Equal(t, auction_entity.Active, findStatus(t, repository, auction.Id), "past the former end")
+
+	assert.Eventually(t, func() bool {
+		return findStatus(t, repository, auction.Id) == auction_entity.Completed
+	}, 3*time.Second, 10*time.Millisecond)
+
+	completed, err := repository.FindAuctionById(context.Background(), auction.Id)
+	require.Nil(t, err)
+	assert.False(t, completed.UpdatedAt.Before(endsAt), "completed before the new end")
+}
+
+func TestExtendAuction_EarlyBidKeepsTheEnd(t *testing.T) {
+	t.Setenv("AUCTION_EXTENSION_POLICY", "fixed")
+	t.Setenv("AUCTION_EXTENSION_WINDOW", "1m")
+
+	repository := NewAuctionRepository(nil)
+	manager := lifecycle_usecase.NewLifecycleManager(repository, lifecycle_usecase.NewExtensionPolicy(), nil)
+
+	now := clock.Now()
+	auction := &auction_entity.Auction{
+		Id: "auction", Status: auction_entity.Active, Timestamp: now, EndsAt: now.Add(time.Hour),
+	}
+	require.Nil(t, repository.CreateAuction(context.Background(), auction))
+
+	bid := bid_entity.Bid{AuctionId: auction.Id, Timestamp: now}
+	assert.Equal(t, auction.EndsAt, manager.OnBidAccepted(context.Background(), auction, bid))
+
+	found, err := repository.FindAuctionById(context.Background(), auction.Id)
+	require.Nil(t, err)
+	assert.Equal(t, auction.EndsAt, found.EndsAt)
+}
+
+func TestPauseAuction_ShiftsTheEndByThePause(t *testing.T) {
+	t.Setenv("AUCTION_COMPLETION_GRACE", "0s")
+	repository := NewAuctionRepository(nil)
+
+	pausedAt := clock.Now()
+	auction := &auction_entity.Auction{
+		Id: "auction", Status: auction_entity.Active, Timestamp: pausedAt, EndsAt: pausedAt.Add(100 * time.Millisecond),
+	}
+	require.Nil(t, repository.CreateAuction(context.Background(), auction))
+	require.Equal(t, 1, repository.scheduler.Len())
+
+	require.Nil(t, repository.PauseAuction(context.Background(), auction.Id, pausedAt))
+	assert.Equal(t, auction_entity.Paused, findStatus(t, repository, auction.Id))
+	assert.Equal(t, 0, repository.scheduler.Len(), "the completion is cancelled")
+
+	time.Sleep(200 * time.Millisecond)
+	assert.Equal(t, auction_entity.Paused, findStatus(t, repository, auction.Id), "past the end while paused")
+
+	resumedAt := clock.Now()
+	endsAt, err := repository.ResumeAuction(context.Background(), auction.Id, resumedAt)
+	require.Nil(t, err)
+	assert.Equal(t, auction.EndsAt.Add(resumedAt.Sub(pausedAt)), endsAt)
+	assert.Equal(t, auction_entity.Active, findStatus(t, repository, auction.Id))
+	assert.Equal(t, 1, repository.scheduler.Len(), "the completion is scheduled again")
+
+	assert.Eventually(t, func() bool {
+		return findStatus(t, repository, auction.Id) == auction_entity.Completed
+	}, 3*time.Second, 10*time.Millisecond)
+
+	completed, err := repository.FindAuctionById(context.Background(), auction.Id)
+	require.Nil(t, err)
+	assert.Equal(t, endsAt, completed.EndsAt)
+	assert.False(t, completed.UpdatedAt.Before(endsAt), "completed before the shifted end")
+}
+
+func TestPauseAuction_OnlyActiveAuctions(t *testing.T) {
+	repository := NewAuctionRepository(nil)
+
+	now := clock.Now()
+	auction := &auction_entity.Auction{
+		Id: "auction", Status: auction_entity.Active, Timestamp: now, EndsAt: now.Add(time.Hour),
+	}
+	require.Nil(t, repository.CreateAuction(context.Background(), auction))
+
+	_, err := repository.ResumeAuction(context.Background(), auction.Id, now)
+	require.NotNil(t, err)
+	assert.Equal(t, "not_found", err.Err)
+
+	require.Nil(t, repository.PauseAuction(context.Background(), auction.Id, now))
+	err = repository.PauseAuction(context.Background(), auction.Id, now)
+	require.NotNil(t, err)
+	assert.Equal(t, "not_found", err.Err)
+
+	// A resume stamped before the pause leaves the end as it was.
+	endsAt, err := repository.ResumeAuction(context.Background(), auction.Id, now.Add(-time.Minute))
+	require.Nil(t, err)
+	assert.Equal(t, auction.EndsAt, endsAt)
+}
+
+func TestUpdateAuctionStatus_OnlyFromTheExpectedStatus(t *testing.T) {
+	repository := NewAuctionRepository(nil)
+
+	now := clock.Now()
+	auction := &auction_entity.Auction{
+		Id: "auction", Status: auction_entity.Active, Timestamp: now, EndsAt: now.Add(time.Hour),
+	}
+	require.Nil(t, repository.CreateAuction(context.Background(), auction))
+
+	require.Nil(t, repository.UpdateAuctionStatus(
+		context.Background(), auction.Id, auction_entity.Active, auction_entity.Completed))
+
+	// A cancellation that read the auction while still active.
+	err := repository.UpdateAuctionStatus(
+		context.Background(), auction.Id, auction_entity.Active, auction_entity.Cancelled)
+	require.NotNil(t, err)
+	assert.Equal(t, "conflict", err.Err)
+	assert.Equal(t, auction_entity.Completed, findStatus(t, repository, auction.Id))
+
+	err = repository.UpdateAuctionStatus(
+		context.Background(), "missing", auction_entity.Active, auction_entity.Cancelled)
+	require.NotNil(t, err)
+	assert.Equal(t, "not_found", err.Err)
+}
@@ -0,0 +1,238 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/entity/event_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"sync"
+	"time"
+)
+
+type BidRepository struct {
+	AuctionRepository *AuctionRepository
+	bids              map[string][]bid_entity.Bid
+	bidsMutex         *sync.RWMutex
+	publisher         event_entity.Publisher
+}
+
+// NewBidRepository publishes every stored bid to publisher, which may be nil.
+func NewBidRepository(auctionRepository *AuctionRepository, publisher event_entity.Publisher) *BidRepository {
+	return &BidRepository{
+		AuctionRepository: auctionRepository,
+		publisher:         publisher,
+		bids:              make(map[string][]bid_entity.Bid),
+		bidsMutex:         &sync.RWMutex{},
+	}
+}
+
+func (bd *BidRepository) CreateBid(
+	ctx context.Context,
+	bidEntities []bid_entity.Bid) ([]bid_entity.Bid, *internal_error.InternalError) {
+	var closed []bid_entity.Bid
+	for _, bid := range bidEntities {
+		auctionEntity, err := bd.AuctionRepository.FindAuctionById(ctx, bid.AuctionId)
+		if err != nil {
+			continue
+		}
+
+		if auctionEntity.Status.Ended() || bid.Timestamp.After(auctionEntity.EndsAt) {
+			closed = append(closed, bid)
+			continue
+		}
+
+		bd.bidsMutex.Lock()
+		bd.bids[bid.AuctionId] = append(bd.bids[bid.AuctionId], bid)
+		bd.bidsMutex.Unlock()
+
+		if bd.publisher != nil {
+			bd.publisher.Publish(ctx, bid.PlacedEvent())
+		}
+	}
+
+	return closed, nil
+}
+
+func (bd *BidRepository) FindBidByAuctionId(
+	ctx context.Context, auctionId string) ([]bid_entity.Bid, *internal_error.InternalError) {
+	bd.bidsMutex.RLock()
+	defer bd.bidsMutex.RUnlock()
+
+	bids := make([]bid_entity.Bid, 0, len(bd.bids[auctionId]))
+	for _, bid := range bd.bids[auctionId] {
+		if !bid.Retracted() {
+			bids = append(bids, bid)
+		}
+	}
+
+	return bids, nil
+}
+
+func (bd *BidRepository) FindWinningBidByAuctionId(
+	ctx context.Context, auctionId string) (*bid_entity.Bid, *internal_error.InternalError) {
+	bd.bidsMutex.RLock()
+	defer bd.bidsMutex.RUnlock()
+
+	var winningBid *bid_entity.Bid
+	for _, bid := range bd.bids[auctionId] {
+		if bid.Retracted() {
+			continue
+		}
+		if winningBid == nil || outbids(bid, *winningBid) {
+			bidValue := bid
+			winningBid = &bidValue
+		}
+	}
+
+	if winningBid == nil {
+		return nil, internal_error.NewNotFoundError(
+			fmt.Sprintf("No bids found for auction = %s", auctionId))
+	}
+
+	return winningBid, nil
+}
+
+// outbids tells whether bid beats the winning bid, equal bids going to the
+// one accepted first.
+func outbids(bid, winningBid bid_entity.Bid) bool {
+	return bid.Amount > winningBid.Amount ||
+		bid.Amount == winningBid.Amount && bid.Timestamp.Before(winningBid.Timestamp)
+}
+
+func (bd *BidRepository) FindBidById(
+	ctx context.Context, bidId string) (*bid_entity.Bid, *internal_error.InternalError) {
+	bd.bidsMutex.RLock()
+	defer bd.bidsMutex.RUnlock()
+
+	for _, bids := range bd.bids {
+		for _, bid := range bids {
+			if bid.Id == bidId {
+				return &bid, nil
+			}
+		}
+	}
+
+	return nil, internal_error.NewNotFoundError(
+		fmt.Sprintf("Bid not found with this id = %s", bidId))
+}
+
+func (bd *BidRepository) RetractBid(
+	ctx context.Context, bidId string, retractedAt time.Time) *internal_error.InternalError {
+	bd.bidsMutex.Lock()
+	var retracted *bid_entity.Bid
+	for _, bids := range bd.bids {
+		for i := range bids {
+			if bids[i].Id == bidId && !bids[i].Retracted() {
+				bids[i].RetractedAt = retractedAt
+				bidValue := bids[i]
+				retracted = &bidValue
+			}
+		}
+	}
+	bd.bidsMutex.Unlock()
+
+	if retracted == nil {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("No standing bid found with this id = %s", bidId))
+	}
+
+	if bd.publisher != nil {
+		highest, _ := bd.FindWinningBidByAuctionId(ctx, retracted.AuctionId)
+		bd.publisher.Publish(ctx, retracted.RetractedEvent(highest))
+	}
+
+	return nil
+}
+
+func (bd *BidRepository) HasBids(
+	ctx context.Context, auctionId string) (bool, *internal_error.InternalError) {
+	bd.bidsMutex.RLock()
+	defer bd.bidsMutex.RUnlock()
+
+	for _, bid := range bd.bids[auctionId] {
+		if !bid.Retracted() {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (bd *BidRepository) FindUserAuctionBids(
+	ctx context.Context, userId string) (map[string][]bid_entity.Bid, *internal_error.InternalError) {
+	bd.bidsMutex.RLock()
+	defer bd.bidsMutex.RUnlock()
+
+	auctionBids := make(map[string][]bid_entity.Bid)
+	for auctionId, bids := range bd.bids {
+		highest := make(map[string]bid_entity.Bid)
+		for _, bid := range bids {
+			if bid.Retracted() {
+				continue
+			}
+			current, ok := highest[bid.UserId]
+			if !ok || bid.Amount > current.Amount ||
+				(bid.Amount == current.Amount && bid.Timestamp.Before(current.Timestamp)) {
+				highest[bid.UserId] = bid
+			}
+		}
+
+		if _, ok := highest[userId]; !ok {
+			continue
+		}
+		for _, bid := range highest {
+			auctionBids[auctionId] = append(auctionBids[auctionId], bid)
+		}
+	}
+
+	return auctionBids, nil
+}
+
+func (bd *BidRepository) FindWinningBidsByAuctionIds(
+	ctx context.Context, auctionIds []string) (map[string]bid_entity.Bid, *internal_error.InternalError) {
+	bd.bidsMutex.RLock()
+	defer bd.bidsMutex.RUnlock()
+
+	winningBids := make(map[string]bid_entity.Bid)
+	for _, auctionId := range auctionIds {
+		for _, bid := range bd.bids[auctionId] {
+			if bid.Retracted() {
+				continue
+			}
+			if winningBid, ok := winningBids[auctionId]; !ok || outbids(bid, winningBid) {
+				winningBids[auctionId] = bid
+			}
+		}
+	}
+
+	return winningBids, nil
+}
+
+func (bd *BidRepository) StreamBids(
+	ctx context.Context,
+	auctionId string,
+	handle func(bid_entity.Bid) error) *internal_error.InternalError {
+	bd.bidsMutex.RLock()
+	var bids []bid_entity.Bid
+	for id, auctionBids := range bd.bids {
+		if auctionId == "" || id == auctionId {
+			bids = append(bids, auctionBids...)
+		}
+	}
+	bd.bidsMutex.RUnlock()
+
+	for _, bid := range bids {
+		if err := ctx.Err(); err != nil {
+			return internal_error.NewInternalServerError("Error streaming bids")
+		}
+
+		if err := handle(bid); err != nil {
+			logger.Error("Error streaming bids", err)
+			return internal_error.NewInternalServerError("Error streaming bids")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package memory
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/clock"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateBid_CutOffByAcceptanceTime(t *testing.T) {
+	t.Setenv("AUCTION_COMPLETION_GRACE", "1s")
+	auctionRepository := NewAuctionRepository(nil)
+	bidRepository := NewBidRepository(auctionRepository, nil)
+
+	now := clock.Now()
+	auction := &auction_entity.Auction{
+		Id: "auction", Status: auction_entity.Active, Timestamp: now, EndsAt: now.Add(50 * time.Millisecond),
+	}
+	require.Nil(t, auctionRepository.CreateAuction(context.Background(), auction))
+
+	// Past the end, within the grace the completion waits for the flush.
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, auction_entity.Active, findStatus(t, auctionRepository, auction.Id))
+
+	acceptedBefore := bid_entity.Bid{Id: "before", AuctionId: auction.Id, Amount: 10, Timestamp: now}
+	acceptedAfter := bid_entity.Bid{
+		Id: "after", AuctionId: auction.Id, Amount: 20, Timestamp: auction.EndsAt.Add(time.Millisecond),
+	}
+	closed, err := bidRepository.CreateBid(context.Background(), []bid_entity.Bid{acceptedBefore, acceptedAfter})
+	require.Nil(t, err)
+	require.Len(t, closed, 1)
+	assert.Equal(t, "after", closed[0].Id)
+
+	bids, err := bidRepository.FindBidByAuctionId(context.Background(), auction.Id)
+	require.Nil(t, err)
+	require.Len(t, bids, 1)
+	assert.Equal(t, "before", bids[0].Id)
+
+	// Once completed, even bids accepted before the end are too late.
+	assert.Eventually(t, func() bool {
+		return findStatus(t, auctionRepository, auction.Id) == auction_entity.Completed
+	}, 3*time.Second, 10*time.Millisecond)
+
+	late := bid_entity.Bid{Id: "late", AuctionId: auction.Id, Amount: 30, Timestamp: now}
+	closed, err = bidRepository.CreateBid(context.Background(), []bid_entity.Bid{late})
+	require.Nil(t, err)
+	assert.Len(t, closed, 1)
+
+	bids, err = bidRepository.FindBidByAuctionId(context.Background(), auction.Id)
+	require.Nil(t, err)
+	assert.Len(t, bids, 1)
+}
+
+func TestFindWinningBid_EqualBidsGoToTheFirstAccepted(t *testing.T) {
+	auctionRepository := NewAuctionRepository(nil)
+	bidRepository := NewBidRepository(auctionRepository, nil)
+
+	now := clock.Now()
+	auction := &auction_entity.Auction{
+		Id: "auction", Status: auction_entity.Active, Timestamp: now, EndsAt: now.Add(time.Hour),
+	}
+	require.Nil(t, auctionRepository.CreateAuction(context.Background(), auction))
+
+	// Flushed out of order, within the same second.
+	second := bid_entity.Bid{Id: "second", AuctionId: auction.Id, Amount: 10, Timestamp: now.Add(time.Microsecond)}
+	first := bid_entity.Bid{Id: "first", AuctionId: auction.Id, Amount: 10, Timestamp: now}
+	closed, err := bidRepository.CreateBid(context.Background(), []bid_entity.Bid{second, first})
+	require.Nil(t, err)
+	require.Empty(t, closed)
+
+	winningBid, err := bidRepository.FindWinningBidByAuctionId(context.Background(), auction.Id)
+	require.Nil(t, err)
+	assert.Equal(t, "first", winningBid.Id)
+
+	winningBids, err := bidRepository.FindWinningBidsByAuctionIds(context.Background(), []string{auction.Id})
+	require.Nil(t, err)
+	assert.Equal(t, "first", winningBids[auction.Id].Id)
+}
@@ -0,0 +1,132 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/internal/entity/category_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"sort"
+	"sync"
+)
+
+type CategorySchemaRepository struct {
+	schemas      map[string]category_entity.CategorySchema
+	schemasMutex *sync.RWMutex
+}
+
+func NewCategorySchemaRepository() *CategorySchemaRepository {
+	return &CategorySchemaRepository{
+		schemas:      make(map[string]category_entity.CategorySchema),
+		schemasMutex: &sync.RWMutex{},
+	}
+}
+
+func (cr *CategorySchemaRepository) UpsertCategorySchema(
+	ctx context.Context,
+	schema *category_entity.CategorySchema) *internal_error.InternalError {
+	cr.schemasMutex.Lock()
+	defer cr.schemasMutex.Unlock()
+
+	cr.schemas[schema.Category] = *schema
+
+	return nil
+}
+
+func (cr *CategorySchemaRepository) FindCategorySchema(
+	ctx context.Context, category string) (*category_entity.CategorySchema, *internal_error.InternalError) {
+	cr.schemasMutex.RLock()
+	defer cr.schemasMutex.RUnlock()
+
+	schema, ok := cr.schemas[category]
+	if !ok {
+		return nil, internal_error.NewNotFoundError(
+			fmt.Sprintf("Category schema not found for category = %s", category))
+	}
+
+	return &schema, nil
+}
+
+type CategoryRepository struct {
+	categories      map[string]category_entity.Category
+	categoriesMutex *sync.RWMutex
+}
+
+func NewCategoryRepository() *CategoryRepository {
+	return &CategoryRepository{
+		categories:      make(map[string]category_entity.Category),
+		categoriesMutex: &sync.RWMutex{},
+	}
+}
+
+func (cr *CategoryRepository) CreateCategory(
+	ctx context.Context, category *category_entity.Category) *internal_error.InternalError {
+	cr.categoriesMutex.Lock()
+	defer cr.categoriesMutex.Unlock()
+
+	if _, ok := cr.categories[category.Name]; ok {
+		return internal_error.NewInternalServerError("Error trying to insert category")
+	}
+	cr.categories[category.Name] = *category
+
+	return nil
+}
+
+func (cr *CategoryRepository) UpdateCategory(
+	ctx context.Context, category *category_entity.Category) *internal_error.InternalError {
+	cr.categoriesMutex.Lock()
+	defer cr.categoriesMutex.Unlock()
+
+	stored, ok := cr.categories[category.Name]
+	if !ok {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("Category not found with name = %s", category.Name))
+	}
+	stored.Parent = category.Parent
+	cr.categories[category.Name] = stored
+
+	return nil
+}
+
+func (cr *CategoryRepository) DeleteCategory(
+	ctx context.Context, name string) *internal_error.InternalError {
+	cr.categoriesMutex.Lock()
+	defer cr.categoriesMutex.Unlock()
+
+	if _, ok := cr.categories[name]; !ok {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("Category not found with name = %s", name))
+	}
+	delete(cr.categories, name)
+
+	return nil
+}
+
+func (cr *CategoryRepository) FindCategory(
+	ctx context.Context, name string) (*category_entity.Category, *internal_error.InternalError) {
+	cr.categoriesMutex.RLock()
+	defer cr.categoriesMutex.RUnlock()
+
+	category, ok := cr.categories[name]
+	if !ok {
+		return nil, internal_error.NewNotFoundError(
+			fmt.Sprintf("Category not found with name = %s", name))
+	}
+
+	return &category, nil
+}
+
+func (cr *CategoryRepository) FindCategories(
+	ctx context.Context) ([]category_entity.Category, *internal_error.InternalError) {
+	cr.categoriesMutex.RLock()
+	defer cr.categoriesMutex.RUnlock()
+
+	categories := make([]category_entity.Category, 0, len(cr.categories))
+	for _, category := range cr.categories {
+		categories = append(categories, category)
+	}
+	sort.Slice(categories, func(i, j int) bool {
+		return categories[i].Name < categories[j].Name
+	})
+
+	return categories, nil
+}
@@ -0,0 +1,56 @@
+package memory
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/fraud_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"sort"
+	"sync"
+)
+
+type FraudFlagRepository struct {
+	flags      map[string]fraud_entity.FraudFlag
+	flagsMutex *sync.RWMutex
+}
+
+func NewFraudFlagRepository() *FraudFlagRepository {
+	return &FraudFlagRepository{
+		flags:      make(map[string]fraud_entity.FraudFlag),
+		flagsMutex: &sync.RWMutex{},
+	}
+}
+
+func (fr *FraudFlagRepository) UpsertFraudFlag(
+	ctx context.Context, flag *fraud_entity.FraudFlag) *internal_error.InternalError {
+	fr.flagsMutex.Lock()
+	defer fr.flagsMutex.Unlock()
+
+	upserted := *flag
+	if existing, ok := fr.flags[flag.Id]; ok {
+		upserted.Timestamp = existing.Timestamp
+	}
+	fr.flags[flag.Id] = upserted
+
+	return nil
+}
+
+func (fr *FraudFlagRepository) FindFraudFlags(
+	ctx context.Context, auctionId string) ([]fraud_entity.FraudFlag, *internal_error.InternalError) {
+	fr.flagsMutex.RLock()
+	defer fr.flagsMutex.RUnlock()
+
+	var flags []fraud_entity.FraudFlag
+	for _, flag := range fr.flags {
+		if auctionId != "" && flag.AuctionId != auctionId {
+			continue
+		}
+
+		flags = append(flags, flag)
+	}
+
+	sort.Slice(flags, func(i, j int) bool {
+		return flags[i].Timestamp.After(flags[j].Timestamp)
+	})
+
+	return flags, nil
+}
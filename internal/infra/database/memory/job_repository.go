@@ -0,0 +1,127 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/internal/entity/job_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"sort"
+	"sync"
+	"time"
+)
+
+type JobRepository struct {
+	jobs      map[string]job_entity.Job
+	jobsMutex *sync.Mutex
+}
+
+func NewJobRepository() *JobRepository {
+	return &JobRepository{
+		jobs:      make(map[string]job_entity.Job),
+		jobsMutex: &sync.Mutex{},
+	}
+}
+
+func (jr *JobRepository) CreateJob(
+	ctx context.Context, job *job_entity.Job) *internal_error.InternalError {
+	jr.jobsMutex.Lock()
+	defer jr.jobsMutex.Unlock()
+
+	jr.jobs[job.Id] = *job
+
+	return nil
+}
+
+func (jr *JobRepository) ClaimJob(
+	ctx context.Context,
+	kinds []string,
+	now, lockedUntil time.Time) (*job_entity.Job, *internal_error.InternalError) {
+	jr.jobsMutex.Lock()
+	defer jr.jobsMutex.Unlock()
+
+	wanted := make(map[string]bool, len(kinds))
+	for _, kind := range kinds {
+		wanted[kind] = true
+	}
+
+	var claimed *job_entity.Job
+	for _, job := range jr.jobs {
+		due := job.Status == job_entity.Pending && !job.RunAt.After(now) ||
+			job.Status == job_entity.Running && job.LockedUntil.Before(now)
+		if !wanted[job.Kind] || !due {
+			continue
+		}
+
+		if claimed == nil || job.RunAt.Before(claimed.RunAt) {
+			job := job
+			claimed = &job
+		}
+	}
+
+	if claimed == nil {
+		return nil, internal_error.NewNotFoundError("No job is due")
+	}
+
+	claimed.Status = job_entity.Running
+	claimed.LockedUntil = lockedUntil
+	claimed.UpdatedAt = now
+	claimed.Attempts++
+	jr.jobs[claimed.Id] = *claimed
+
+	return claimed, nil
+}
+
+func (jr *JobRepository) UpdateJob(
+	ctx context.Context, job *job_entity.Job, lockedUntil time.Time) *internal_error.InternalError {
+	jr.jobsMutex.Lock()
+	defer jr.jobsMutex.Unlock()
+
+	if stored, ok := jr.jobs[job.Id]; !ok || !stored.LockedUntil.Equal(lockedUntil) {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("Job not found with this id = %s", job.Id))
+	}
+
+	jr.jobs[job.Id] = *job
+
+	return nil
+}
+
+func (jr *JobRepository) FindJobById(
+	ctx context.Context, id string) (*job_entity.Job, *internal_error.InternalError) {
+	jr.jobsMutex.Lock()
+	defer jr.jobsMutex.Unlock()
+
+	job, ok := jr.jobs[id]
+	if !ok {
+		return nil, internal_error.NewNotFoundError(
+			fmt.Sprintf("Job not found with this id = %s", id))
+	}
+
+	return &job, nil
+}
+
+func (jr *JobRepository) FindJobs(
+	ctx context.Context,
+	status job_entity.JobStatus,
+	kind string,
+	limit int) ([]job_entity.Job, *internal_error.InternalError) {
+	jr.jobsMutex.Lock()
+	defer jr.jobsMutex.Unlock()
+
+	var jobs []job_entity.Job
+	for _, job := range jr.jobs {
+		if (status == "" || job.Status == status) && (kind == "" || job.Kind == kind) {
+			jobs = append(jobs, job)
+		}
+	}
+
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].Timestamp.After(jobs[j].Timestamp)
+	})
+
+	if len(jobs) > limit {
+		jobs = jobs[:limit]
+	}
+
+	return jobs, nil
+}
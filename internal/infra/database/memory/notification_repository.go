@@ -0,0 +1,99 @@
+package memory
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/notification_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"sort"
+	"sync"
+	"time"
+)
+
+type NotificationRepository struct {
+	notifications      map[string]notification_entity.Notification
+	notificationsMutex *sync.RWMutex
+}
+
+func NewNotificationRepository() *NotificationRepository {
+	return &NotificationRepository{
+		notifications:      make(map[string]notification_entity.Notification),
+		notificationsMutex: &sync.RWMutex{},
+	}
+}
+
+func (nr *NotificationRepository) CreateNotification(
+	ctx context.Context, notification *notification_entity.Notification) *internal_error.InternalError {
+	nr.notificationsMutex.Lock()
+	defer nr.notificationsMutex.Unlock()
+
+	nr.notifications[notification.Id] = *notification
+
+	return nil
+}
+
+func (nr *NotificationRepository) FindNotifications(
+	ctx context.Context,
+	userId string,
+	archived bool) ([]notification_entity.Notification, *internal_error.InternalError) {
+	nr.notificationsMutex.RLock()
+	defer nr.notificationsMutex.RUnlock()
+
+	notifications := []notification_entity.Notification{}
+	for _, notification := range nr.notifications {
+		if notification.UserId == userId && notification.Archived() == archived {
+			notifications = append(notifications, notification)
+		}
+	}
+	sort.Slice(notifications, func(i, j int) bool {
+		return notifications[i].Timestamp.After(notifications[j].Timestamp)
+	})
+
+	return notifications, nil
+}
+
+func (nr *NotificationRepository) MarkNotificationsRead(
+	ctx context.Context,
+	userId string,
+	ids []string,
+	readAt time.Time) (int64, *internal_error.InternalError) {
+	nr.notificationsMutex.Lock()
+	defer nr.notificationsMutex.Unlock()
+
+	selected := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		selected[id] = true
+	}
+
+	var marked int64
+	for id, notification := range nr.notifications {
+		if notification.UserId != userId || notification.Read() || (len(ids) > 0 && !selected[id]) {
+			continue
+		}
+
+		notification.ReadAt = readAt
+		nr.notifications[id] = notification
+		marked++
+	}
+
+	return marked, nil
+}
+
+func (nr *NotificationRepository) ArchiveNotifications(
+	ctx context.Context,
+	cutoff, archivedAt time.Time) (int64, *internal_error.InternalError) {
+	nr.notificationsMutex.Lock()
+	defer nr.notificationsMutex.Unlock()
+
+	var archived int64
+	for id, notification := range nr.notifications {
+		if notification.Archived() || !notification.Timestamp.Before(cutoff) {
+			continue
+		}
+
+		notification.ArchivedAt = archivedAt
+		nr.notifications[id] = notification
+		archived++
+	}
+
+	return archived, nil
+}
@@ -0,0 +1,87 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/internal/entity/offer_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"sort"
+	"sync"
+	"time"
+)
+
+type OfferRepository struct {
+	offers      map[string]offer_entity.Offer
+	offersMutex *sync.RWMutex
+}
+
+func NewOfferRepository() *OfferRepository {
+	return &OfferRepository{
+		offers:      make(map[string]offer_entity.Offer),
+		offersMutex: &sync.RWMutex{},
+	}
+}
+
+func (or *OfferRepository) CreateOffer(
+	ctx context.Context, offer *offer_entity.Offer) *internal_error.InternalError {
+	or.offersMutex.Lock()
+	defer or.offersMutex.Unlock()
+
+	or.offers[offer.Id] = *offer
+
+	return nil
+}
+
+func (or *OfferRepository) FindOfferById(
+	ctx context.Context, id string) (*offer_entity.Offer, *internal_error.InternalError) {
+	or.offersMutex.RLock()
+	defer or.offersMutex.RUnlock()
+
+	offer, ok := or.offers[id]
+	if !ok {
+		return nil, internal_error.NewNotFoundError(
+			fmt.Sprintf("Offer not found with this id = %s", id))
+	}
+
+	return &offer, nil
+}
+
+func (or *OfferRepository) FindOffers(
+	ctx context.Context, auctionId string) ([]offer_entity.Offer, *internal_error.InternalError) {
+	or.offersMutex.RLock()
+	defer or.offersMutex.RUnlock()
+
+	var offers []offer_entity.Offer
+	for _, offer := range or.offers {
+		if offer.AuctionId == auctionId {
+			offers = append(offers, offer)
+		}
+	}
+
+	sort.Slice(offers, func(i, j int) bool {
+		return offers[i].Timestamp.Before(offers[j].Timestamp)
+	})
+
+	return offers, nil
+}
+
+func (or *OfferRepository) UpdateOfferStatus(
+	ctx context.Context,
+	id string,
+	status offer_entity.OfferStatus,
+	respondedAt time.Time) *internal_error.InternalError {
+	or.offersMutex.Lock()
+	defer or.offersMutex.Unlock()
+
+	offer, ok := or.offers[id]
+	if !ok || offer.Status != offer_entity.Pending {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("Pending offer not found with this id = %s", id))
+	}
+
+	offer.Status = status
+	offer.RespondedAt = respondedAt
+	or.offers[id] = offer
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/internal/entity/product_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"sync"
+)
+
+type ProductRepository struct {
+	products      map[string]product_entity.Product
+	productsMutex *sync.RWMutex
+}
+
+func NewProductRepository() *ProductRepository {
+	return &ProductRepository{
+		products:      make(map[string]product_entity.Product),
+		productsMutex: &sync.RWMutex{},
+	}
+}
+
+func (pr *ProductRepository) CreateProduct(
+	ctx context.Context,
+	productEntity *product_entity.Product) *internal_error.InternalError {
+	pr.productsMutex.Lock()
+	defer pr.productsMutex.Unlock()
+
+	pr.products[productEntity.Id] = *productEntity
+
+	return nil
+}
+
+func (pr *ProductRepository) FindProductById(
+	ctx context.Context, id string) (*product_entity.Product, *internal_error.InternalError) {
+	pr.productsMutex.RLock()
+	defer pr.productsMutex.RUnlock()
+
+	product, ok := pr.products[id]
+	if !ok {
+		return nil, internal_error.NewNotFoundError(
+			fmt.Sprintf("Product not found with this id = %s", id))
+	}
+
+	return &product, nil
+}
+
+func (pr *ProductRepository) FindProducts(
+	ctx context.Context,
+	category, sku string) ([]product_entity.Product, *internal_error.InternalError) {
+	pr.productsMutex.RLock()
+	defer pr.productsMutex.RUnlock()
+
+	var products []product_entity.Product
+	for _, product := range pr.products {
+		if category != "" && product.Category != category {
+			continue
+		}
+
+		if sku != "" && product.Sku != sku {
+			continue
+		}
+
+		products = append(products, product)
+	}
+
+	return products, nil
+}
+
+func (pr *ProductRepository) UpdateProduct(
+	ctx context.Context,
+	productEntity *product_entity.Product) *internal_error.InternalError {
+	pr.productsMutex.Lock()
+	defer pr.productsMutex.Unlock()
+
+	if _, ok := pr.products[productEntity.Id]; !ok {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("Product not found with this id = %s", productEntity.Id))
+	}
+	pr.products[productEntity.Id] = *productEntity
+
+	return nil
+}
+
+func (pr *ProductRepository) DeleteProduct(
+	ctx context.Context, id string) *internal_error.InternalError {
+	pr.productsMutex.Lock()
+	defer pr.productsMutex.Unlock()
+
+	if _, ok := pr.products[id]; !ok {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("Product not found with this id = %s", id))
+	}
+	delete(pr.products, id)
+
+	return nil
+}
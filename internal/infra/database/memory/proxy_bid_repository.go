@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"sort"
+	"sync"
+)
+
+type ProxyBidRepository struct {
+	// proxyBids is keyed by auction, then by user.
+	proxyBids      map[string]map[string]bid_entity.ProxyBid
+	proxyBidsMutex *sync.RWMutex
+}
+
+func NewProxyBidRepository() *ProxyBidRepository {
+	return &ProxyBidRepository{
+		proxyBids:      make(map[string]map[string]bid_entity.ProxyBid),
+		proxyBidsMutex: &sync.RWMutex{},
+	}
+}
+
+func (pr *ProxyBidRepository) UpsertProxyBid(
+	ctx context.Context, proxyBid *bid_entity.ProxyBid) *internal_error.InternalError {
+	pr.proxyBidsMutex.Lock()
+	defer pr.proxyBidsMutex.Unlock()
+
+	auctionBids, ok := pr.proxyBids[proxyBid.AuctionId]
+	if !ok {
+		auctionBids = make(map[string]bid_entity.ProxyBid)
+		pr.proxyBids[proxyBid.AuctionId] = auctionBids
+	}
+
+	stored := *proxyBid
+	if existing, ok := auctionBids[proxyBid.UserId]; ok {
+		stored.Id = existing.Id
+	}
+	auctionBids[proxyBid.UserId] = stored
+
+	return nil
+}
+
+func (pr *ProxyBidRepository) FindProxyBid(
+	ctx context.Context, auctionId, userId string) (*bid_entity.ProxyBid, *internal_error.InternalError) {
+	pr.proxyBidsMutex.RLock()
+	defer pr.proxyBidsMutex.RUnlock()
+
+	proxyBid, ok := pr.proxyBids[auctionId][userId]
+	if !ok {
+		return nil, internal_error.NewNotFoundError(
+			fmt.Sprintf("Proxy bid not found for auction = %s and user = %s", auctionId, userId))
+	}
+
+	return &proxyBid, nil
+}
+
+func (pr *ProxyBidRepository) FindActiveProxyBids(
+	ctx context.Context, auctionId string) ([]bid_entity.ProxyBid, *internal_error.InternalError) {
+	pr.proxyBidsMutex.RLock()
+	defer pr.proxyBidsMutex.RUnlock()
+
+	var proxyBids []bid_entity.ProxyBid
+	for _, proxyBid := range pr.proxyBids[auctionId] {
+		if proxyBid.Status == bid_entity.ProxyActive {
+			proxyBids = append(proxyBids, proxyBid)
+		}
+	}
+
+	sort.Slice(proxyBids, func(i, j int) bool {
+		if proxyBids[i].MaxAmount != proxyBids[j].MaxAmount {
+			return proxyBids[i].MaxAmount > proxyBids[j].MaxAmount
+		}
+		return proxyBids[i].Timestamp.Before(proxyBids[j].Timestamp)
+	})
+
+	return proxyBids, nil
+}
+
+func (pr *ProxyBidRepository) UpdateProxyBidStatus(
+	ctx context.Context, id string, status bid_entity.ProxyBidStatus) *internal_error.InternalError {
+	pr.proxyBidsMutex.Lock()
+	defer pr.proxyBidsMutex.Unlock()
+
+	for _, auctionBids := range pr.proxyBids {
+		for userId, proxyBid := range auctionBids {
+			if proxyBid.Id == id {
+				proxyBid.Status = status
+				auctionBids[userId] = proxyBid
+				return nil
+			}
+		}
+	}
+
+	return internal_error.NewNotFoundError(
+		fmt.Sprintf("Proxy bid not found with this id = %s", id))
+}
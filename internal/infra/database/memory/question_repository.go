@@ -0,0 +1,84 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/internal/entity/question_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"sort"
+	"sync"
+)
+
+type QuestionRepository struct {
+	questions      map[string]question_entity.Question
+	questionsMutex *sync.RWMutex
+}
+
+func NewQuestionRepository() *QuestionRepository {
+	return &QuestionRepository{
+		questions:      make(map[string]question_entity.Question),
+		questionsMutex: &sync.RWMutex{},
+	}
+}
+
+func (qr *QuestionRepository) CreateQuestion(
+	ctx context.Context, question *question_entity.Question) *internal_error.InternalError {
+	qr.questionsMutex.Lock()
+	defer qr.questionsMutex.Unlock()
+
+	qr.questions[question.Id] = *question
+
+	return nil
+}
+
+func (qr *QuestionRepository) FindQuestionById(
+	ctx context.Context, id string) (*question_entity.Question, *internal_error.InternalError) {
+	qr.questionsMutex.RLock()
+	defer qr.questionsMutex.RUnlock()
+
+	question, ok := qr.questions[id]
+	if !ok {
+		return nil, internal_error.NewNotFoundError(
+			fmt.Sprintf("Question not found with this id = %s", id))
+	}
+
+	return &question, nil
+}
+
+func (qr *QuestionRepository) FindQuestions(
+	ctx context.Context, auctionId string) ([]question_entity.Question, *internal_error.InternalError) {
+	qr.questionsMutex.RLock()
+	defer qr.questionsMutex.RUnlock()
+
+	var questions []question_entity.Question
+	for _, question := range qr.questions {
+		if question.AuctionId == auctionId {
+			questions = append(questions, question)
+		}
+	}
+
+	sort.Slice(questions, func(i, j int) bool {
+		return questions[i].Timestamp.Before(questions[j].Timestamp)
+	})
+
+	return questions, nil
+}
+
+func (qr *QuestionRepository) UpdateQuestion(
+	ctx context.Context, question *question_entity.Question) *internal_error.InternalError {
+	qr.questionsMutex.Lock()
+	defer qr.questionsMutex.Unlock()
+
+	stored, ok := qr.questions[question.Id]
+	if !ok {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("Question not found with this id = %s", question.Id))
+	}
+
+	stored.Answer = question.Answer
+	stored.AnsweredAt = question.AnsweredAt
+	stored.Status = question.Status
+	qr.questions[question.Id] = stored
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"sort"
+	"sync"
+	"time"
+)
+
+type RecurringAuctionRepository struct {
+	recurringAuctions      map[string]auction_entity.RecurringAuction
+	recurringAuctionsMutex *sync.RWMutex
+}
+
+func NewRecurringAuctionRepository() *RecurringAuctionRepository {
+	return &RecurringAuctionRepository{
+		recurringAuctions:      make(map[string]auction_entity.RecurringAuction),
+		recurringAuctionsMutex: &sync.RWMutex{},
+	}
+}
+
+func (rr *RecurringAuctionRepository) CreateRecurringAuction(
+	ctx context.Context, recurringAuction *auction_entity.RecurringAuction) *internal_error.InternalError {
+	rr.recurringAuctionsMutex.Lock()
+	defer rr.recurringAuctionsMutex.Unlock()
+
+	rr.recurringAuctions[recurringAuction.Id] = *recurringAuction
+
+	return nil
+}
+
+func (rr *RecurringAuctionRepository) FindRecurringAuctionById(
+	ctx context.Context, id string) (*auction_entity.RecurringAuction, *internal_error.InternalError) {
+	rr.recurringAuctionsMutex.RLock()
+	defer rr.recurringAuctionsMutex.RUnlock()
+
+	recurringAuction, ok := rr.recurringAuctions[id]
+	if !ok {
+		return nil, internal_error.NewNotFoundError(
+			fmt.Sprintf("Recurring auction not found with this id = %s", id))
+	}
+
+	return &recurringAuction, nil
+}
+
+func (rr *RecurringAuctionRepository) FindRecurringAuctions(
+	ctx context.Context, sellerId string) ([]auction_entity.RecurringAuction, *internal_error.InternalError) {
+	return rr.filter(func(recurringAuction auction_entity.RecurringAuction) bool {
+		return recurringAuction.SellerId == sellerId
+	}), nil
+}
+
+func (rr *RecurringAuctionRepository) FindDueRecurringAuctions(
+	ctx context.Context, now time.Time) ([]auction_entity.RecurringAuction, *internal_error.InternalError) {
+	return rr.filter(func(recurringAuction auction_entity.RecurringAuction) bool {
+		return recurringAuction.Active &&
+			!recurringAuction.NextRunAt.IsZero() &&
+			!recurringAuction.NextRunAt.After(now)
+	}), nil
+}
+
+func (rr *RecurringAuctionRepository) filter(
+	matches func(auction_entity.RecurringAuction) bool) []auction_entity.RecurringAuction {
+	rr.recurringAuctionsMutex.RLock()
+	defer rr.recurringAuctionsMutex.RUnlock()
+
+	var recurringAuctions []auction_entity.RecurringAuction
+	for _, recurringAuction := range rr.recurringAuctions {
+		if matches(recurringAuction) {
+			recurringAuctions = append(recurringAuctions, recurringAuction)
+		}
+	}
+
+	sort.Slice(recurringAuctions, func(i, j int) bool {
+		return recurringAuctions[i].CreatedAt.Before(recurringAuctions[j].CreatedAt)
+	})
+
+	return recurringAuctions
+}
+
+func (rr *RecurringAuctionRepository) UpdateRecurringAuctionRun(
+	ctx context.Context, id string, runAt, nextRunAt time.Time) *internal_error.InternalError {
+	rr.recurringAuctionsMutex.Lock()
+	defer rr.recurringAuctionsMutex.Unlock()
+
+	recurringAuction, ok := rr.recurringAuctions[id]
+	if !ok || !recurringAuction.NextRunAt.Equal(runAt) {
+		return internal_error.NewNotFoundError("Recurring auction not found")
+	}
+
+	recurringAuction.NextRunAt = nextRunAt
+	recurringAuction.LastRunAt = runAt
+	rr.recurringAuctions[id] = recurringAuction
+
+	return nil
+}
+
+func (rr *RecurringAuctionRepository) UpdateRecurringAuctionActive(
+	ctx context.Context, id string, active bool, nextRunAt time.Time) *internal_error.InternalError {
+	rr.recurringAuctionsMutex.Lock()
+	defer rr.recurringAuctionsMutex.Unlock()
+
+	recurringAuction, ok := rr.recurringAuctions[id]
+	if !ok {
+		return internal_error.NewNotFoundError("Recurring auction not found")
+	}
+
+	recurringAuction.Active = active
+	recurringAuction.NextRunAt = nextRunAt
+	rr.recurringAuctions[id] = recurringAuction
+
+	return nil
+}
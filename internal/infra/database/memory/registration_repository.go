@@ -0,0 +1,97 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/configuration/clock"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"sort"
+	"sync"
+)
+
+type RegistrationRepository struct {
+	// registrations is keyed by auction, then by user.
+	registrations      map[string]map[string]auction_entity.Registration
+	registrationsMutex *sync.RWMutex
+}
+
+func NewRegistrationRepository() *RegistrationRepository {
+	return &RegistrationRepository{
+		registrations:      make(map[string]map[string]auction_entity.Registration),
+		registrationsMutex: &sync.RWMutex{},
+	}
+}
+
+func (rr *RegistrationRepository) UpsertRegistration(
+	ctx context.Context, registration *auction_entity.Registration) *internal_error.InternalError {
+	rr.registrationsMutex.Lock()
+	defer rr.registrationsMutex.Unlock()
+
+	auctionRegistrations, ok := rr.registrations[registration.AuctionId]
+	if !ok {
+		auctionRegistrations = make(map[string]auction_entity.Registration)
+		rr.registrations[registration.AuctionId] = auctionRegistrations
+	}
+
+	stored := *registration
+	if existing, ok := auctionRegistrations[registration.UserId]; ok {
+		stored.Id = existing.Id
+	}
+	auctionRegistrations[registration.UserId] = stored
+
+	return nil
+}
+
+func (rr *RegistrationRepository) FindRegistration(
+	ctx context.Context, auctionId, userId string) (*auction_entity.Registration, *internal_error.InternalError) {
+	rr.registrationsMutex.RLock()
+	defer rr.registrationsMutex.RUnlock()
+
+	registration, ok := rr.registrations[auctionId][userId]
+	if !ok {
+		return nil, internal_error.NewNotFoundError(
+			fmt.Sprintf("Registration not found for auction = %s and user = %s", auctionId, userId))
+	}
+
+	return &registration, nil
+}
+
+func (rr *RegistrationRepository) FindRegistrations(
+	ctx context.Context, auctionId string) ([]auction_entity.Registration, *internal_error.InternalError) {
+	rr.registrationsMutex.RLock()
+	defer rr.registrationsMutex.RUnlock()
+
+	var registrations []auction_entity.Registration
+	for _, registration := range rr.registrations[auctionId] {
+		registrations = append(registrations, registration)
+	}
+
+	sort.Slice(registrations, func(i, j int) bool {
+		return registrations[i].Timestamp.Before(registrations[j].Timestamp)
+	})
+
+	return registrations, nil
+}
+
+func (rr *RegistrationRepository) DecideRegistration(
+	ctx context.Context,
+	auctionId, userId string,
+	status auction_entity.RegistrationStatus,
+	decidedBy string) *internal_error.InternalError {
+	rr.registrationsMutex.Lock()
+	defer rr.registrationsMutex.Unlock()
+
+	registration, ok := rr.registrations[auctionId][userId]
+	if !ok {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("Registration not found for auction = %s and user = %s", auctionId, userId))
+	}
+
+	registration.Status = status
+	registration.DecidedBy = decidedBy
+	registration.DecidedAt = clock.Now()
+	rr.registrations[auctionId][userId] = registration
+
+	return nil
+}
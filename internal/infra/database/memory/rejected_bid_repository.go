@@ -0,0 +1,45 @@
+package memory
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"sync"
+)
+
+type RejectedBidRepository struct {
+	rejectedBids      map[string][]bid_entity.RejectedBid
+	rejectedBidsMutex *sync.RWMutex
+}
+
+func NewRejectedBidRepository() *RejectedBidRepository {
+	return &RejectedBidRepository{
+		rejectedBids:      make(map[string][]bid_entity.RejectedBid),
+		rejectedBidsMutex: &sync.RWMutex{},
+	}
+}
+
+func (rr *RejectedBidRepository) CreateRejectedBid(
+	ctx context.Context, rejectedBid *bid_entity.RejectedBid) *internal_error.InternalError {
+	rr.rejectedBidsMutex.Lock()
+	defer rr.rejectedBidsMutex.Unlock()
+
+	rr.rejectedBids[rejectedBid.AuctionId] = append(rr.rejectedBids[rejectedBid.AuctionId], *rejectedBid)
+
+	return nil
+}
+
+func (rr *RejectedBidRepository) FindRejectedBids(
+	ctx context.Context, auctionId string) ([]bid_entity.RejectedBid, *internal_error.InternalError) {
+	rr.rejectedBidsMutex.RLock()
+	defer rr.rejectedBidsMutex.RUnlock()
+
+	stored := rr.rejectedBids[auctionId]
+
+	rejectedBids := make([]bid_entity.RejectedBid, 0, len(stored))
+	for i := len(stored) - 1; i >= 0; i-- {
+		rejectedBids = append(rejectedBids, stored[i])
+	}
+
+	return rejectedBids, nil
+}
@@ -0,0 +1,74 @@
+package memory
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+)
+
+func (bd *BidRepository) PurgeClientData(
+	ctx context.Context, before time.Time, dryRun bool) (int64, *internal_error.InternalError) {
+	bd.bidsMutex.Lock()
+	defer bd.bidsMutex.Unlock()
+
+	var purged int64
+	for _, bids := range bd.bids {
+		for i := range bids {
+			if purgeClientData(&bids[i], before, dryRun) {
+				purged++
+			}
+		}
+	}
+
+	return purged, nil
+}
+
+func (rr *RejectedBidRepository) PurgeClientData(
+	ctx context.Context, before time.Time, dryRun bool) (int64, *internal_error.InternalError) {
+	rr.rejectedBidsMutex.Lock()
+	defer rr.rejectedBidsMutex.Unlock()
+
+	var purged int64
+	for _, rejectedBids := range rr.rejectedBids {
+		for i := range rejectedBids {
+			if purgeClientData(&rejectedBids[i].Bid, before, dryRun) {
+				purged++
+			}
+		}
+	}
+
+	return purged, nil
+}
+
+func (bd *BidRepository) ReplaceBidders(
+	ctx context.Context,
+	auctionId string,
+	userIds map[string]string) (int64, *internal_error.InternalError) {
+	bd.bidsMutex.Lock()
+	defer bd.bidsMutex.Unlock()
+
+	var replaced int64
+	bids := bd.bids[auctionId]
+	for i := range bids {
+		if to, ok := userIds[bids[i].UserId]; ok {
+			bids[i].UserId = to
+			replaced++
+		}
+	}
+
+	return replaced, nil
+}
+
+// purgeClientData clears the client info of the bid when it was placed
+// before the time, reporting whether it had any.
+func purgeClientData(bid *bid_entity.Bid, before time.Time, dryRun bool) bool {
+	if !bid.Timestamp.Before(before) || bid.Client == (bid_entity.ClientInfo{}) {
+		return false
+	}
+
+	if !dryRun {
+		bid.Client = bid_entity.ClientInfo{}
+	}
+	return true
+}
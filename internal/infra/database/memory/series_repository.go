@@ -0,0 +1,100 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"sort"
+	"sync"
+	"time"
+)
+
+type SeriesRepository struct {
+	series      map[string]auction_entity.Series
+	seriesMutex *sync.RWMutex
+}
+
+func NewSeriesRepository() *SeriesRepository {
+	return &SeriesRepository{
+		series:      make(map[string]auction_entity.Series),
+		seriesMutex: &sync.RWMutex{},
+	}
+}
+
+func (sr *SeriesRepository) CreateSeries(
+	ctx context.Context, series *auction_entity.Series) *internal_error.InternalError {
+	sr.seriesMutex.Lock()
+	defer sr.seriesMutex.Unlock()
+
+	sr.series[series.Id] = *series
+
+	return nil
+}
+
+func (sr *SeriesRepository) FindSeriesById(
+	ctx context.Context, id string) (*auction_entity.Series, *internal_error.InternalError) {
+	sr.seriesMutex.RLock()
+	defer sr.seriesMutex.RUnlock()
+
+	series, ok := sr.series[id]
+	if !ok {
+		return nil, internal_error.NewNotFoundError(
+			fmt.Sprintf("Series not found with this id = %s", id))
+	}
+
+	return &series, nil
+}
+
+func (sr *SeriesRepository) FindSeries(
+	ctx context.Context, sellerId string) ([]auction_entity.Series, *internal_error.InternalError) {
+	sr.seriesMutex.RLock()
+	defer sr.seriesMutex.RUnlock()
+
+	series := []auction_entity.Series{}
+	for _, value := range sr.series {
+		if sellerId == "" || value.SellerId == sellerId {
+			series = append(series, value)
+		}
+	}
+
+	sort.Slice(series, func(i, j int) bool {
+		return series[i].CreatedAt.After(series[j].CreatedAt)
+	})
+
+	return series, nil
+}
+
+func (sr *SeriesRepository) NextLotNumber(
+	ctx context.Context, seriesId string) (int, *internal_error.InternalError) {
+	sr.seriesMutex.Lock()
+	defer sr.seriesMutex.Unlock()
+
+	series, ok := sr.series[seriesId]
+	if !ok {
+		return 0, internal_error.NewNotFoundError(
+			fmt.Sprintf("Series not found with this id = %s", seriesId))
+	}
+
+	series.LotCount++
+	sr.series[seriesId] = series
+
+	return series.LotCount, nil
+}
+
+func (sr *SeriesRepository) UpdateSeriesCascade(
+	ctx context.Context, seriesId string, interval time.Duration) *internal_error.InternalError {
+	sr.seriesMutex.Lock()
+	defer sr.seriesMutex.Unlock()
+
+	series, ok := sr.series[seriesId]
+	if !ok {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("Series not found with this id = %s", seriesId))
+	}
+
+	series.CascadeInterval = interval
+	sr.series[seriesId] = series
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"sync"
+)
+
+type TermsAcceptanceRepository struct {
+	acceptances      map[string]auction_entity.TermsAcceptance
+	acceptancesMutex *sync.RWMutex
+}
+
+func NewTermsAcceptanceRepository() *TermsAcceptanceRepository {
+	return &TermsAcceptanceRepository{
+		acceptances:      make(map[string]auction_entity.TermsAcceptance),
+		acceptancesMutex: &sync.RWMutex{},
+	}
+}
+
+func (tr *TermsAcceptanceRepository) CreateTermsAcceptance(
+	ctx context.Context, acceptance *auction_entity.TermsAcceptance) *internal_error.InternalError {
+	tr.acceptancesMutex.Lock()
+	defer tr.acceptancesMutex.Unlock()
+
+	tr.acceptances[acceptance.AuctionId+":"+acceptance.UserId] = *acceptance
+
+	return nil
+}
+
+func (tr *TermsAcceptanceRepository) FindTermsAcceptance(
+	ctx context.Context, auctionId, userId string) (*auction_entity.TermsAcceptance, *internal_error.InternalError) {
+	tr.acceptancesMutex.RLock()
+	defer tr.acceptancesMutex.RUnlock()
+
+	acceptance, ok := tr.acceptances[auctionId+":"+userId]
+	if !ok {
+		return nil, internal_error.NewNotFoundError(
+			fmt.Sprintf("Terms acceptance not found for auction = %s and user = %s", auctionId, userId))
+	}
+
+	return &acceptance, nil
+}
@@ -0,0 +1,44 @@
+package memory
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"sync"
+)
+
+type TransferRepository struct {
+	transfers      []auction_entity.Transfer
+	transfersMutex *sync.RWMutex
+}
+
+func NewTransferRepository() *TransferRepository {
+	return &TransferRepository{
+		transfersMutex: &sync.RWMutex{},
+	}
+}
+
+func (tr *TransferRepository) CreateTransfer(
+	ctx context.Context, transfer *auction_entity.Transfer) *internal_error.InternalError {
+	tr.transfersMutex.Lock()
+	defer tr.transfersMutex.Unlock()
+
+	tr.transfers = append(tr.transfers, *transfer)
+
+	return nil
+}
+
+func (tr *TransferRepository) FindTransfers(
+	ctx context.Context, auctionId string) ([]auction_entity.Transfer, *internal_error.InternalError) {
+	tr.transfersMutex.RLock()
+	defer tr.transfersMutex.RUnlock()
+
+	var transfers []auction_entity.Transfer
+	for _, transfer := range tr.transfers {
+		if transfer.AuctionId == auctionId {
+			transfers = append(transfers, transfer)
+		}
+	}
+
+	return transfers, nil
+}
@@ -0,0 +1,110 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/internal/entity/user_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"sync"
+)
+
+type UserRepository struct {
+	users      map[string]user_entity.User
+	usersMutex *sync.RWMutex
+}
+
+func NewUserRepository() *UserRepository {
+	return &UserRepository{
+		users:      make(map[string]user_entity.User),
+		usersMutex: &sync.RWMutex{},
+	}
+}
+
+func (ur *UserRepository) FindUserById(
+	ctx context.Context, userId string) (*user_entity.User, *internal_error.InternalError) {
+	ur.usersMutex.RLock()
+	defer ur.usersMutex.RUnlock()
+
+	user, ok := ur.users[userId]
+	if !ok {
+		return nil, internal_error.NewNotFoundError(
+			fmt.Sprintf("User not found with this id = %s", userId))
+	}
+
+	return &user, nil
+}
+
+func (ur *UserRepository) FindUserByEmail(
+	ctx context.Context, email string) (*user_entity.User, *internal_error.InternalError) {
+	ur.usersMutex.RLock()
+	defer ur.usersMutex.RUnlock()
+
+	for _, user := range ur.users {
+		if user.Email != "" && user.Email == email {
+			return &user, nil
+		}
+	}
+
+	return nil, internal_error.NewNotFoundError("User not found with this email")
+}
+
+func (ur *UserRepository) CreateUser(
+	ctx context.Context, userEntity *user_entity.User) *internal_error.InternalError {
+	ur.usersMutex.Lock()
+	defer ur.usersMutex.Unlock()
+
+	ur.users[userEntity.Id] = *userEntity
+
+	return nil
+}
+
+func (ur *UserRepository) FindUsersByIds(
+	ctx context.Context, userIds []string) ([]user_entity.User, *internal_error.InternalError) {
+	ur.usersMutex.RLock()
+	defer ur.usersMutex.RUnlock()
+
+	var users []user_entity.User
+	for _, userId := range userIds {
+		if user, ok := ur.users[userId]; ok {
+			users = append(users, user)
+		}
+	}
+
+	return users, nil
+}
+
+func (ur *UserRepository) UpdateUserProfile(
+	ctx context.Context, userEntity *user_entity.User) *internal_error.InternalError {
+	ur.usersMutex.Lock()
+	defer ur.usersMutex.Unlock()
+
+	user, ok := ur.users[userEntity.Id]
+	if !ok {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("User not found with this id = %s", userEntity.Id))
+	}
+
+	user.DisplayName = userEntity.DisplayName
+	user.AvatarUrl = userEntity.AvatarUrl
+	user.Bio = userEntity.Bio
+	ur.users[userEntity.Id] = user
+
+	return nil
+}
+
+func (ur *UserRepository) UpdateUserRole(
+	ctx context.Context, userId string, role user_entity.Role) *internal_error.InternalError {
+	ur.usersMutex.Lock()
+	defer ur.usersMutex.Unlock()
+
+	user, ok := ur.users[userId]
+	if !ok {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("User not found with this id = %s", userId))
+	}
+
+	user.Role = role
+	ur.users[userId] = user
+
+	return nil
+}
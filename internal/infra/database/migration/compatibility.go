@@ -0,0 +1,54 @@
+package migration
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// VersionsCollection holds a document per migrated collection with the
+// schema version its documents were migrated to, recorded by cmd/migrate.
+const VersionsCollection = "schema_versions"
+
+type versionDocument struct {
+	Collection string `bson:"_id"`
+	Version    int    `bson:"version"`
+}
+
+// Compatible tells whether a build reading the chain serves a collection
+// migrated to stored. It upcasts every version up to its latest, so only
+// documents migrated past what it knows are out of its range.
+func (c *Chain) Compatible(stored int) bool {
+	return stored <= c.LatestVersion()
+}
+
+// StoredVersion reads the version the collection was migrated to, zero
+// when none was recorded yet.
+func StoredVersion(ctx context.Context, database *mongo.Database, collection string) (int, error) {
+	var document versionDocument
+	err := database.Collection(VersionsCollection).
+		FindOne(ctx, bson.M{"_id": collection}).Decode(&document)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return document.Version, nil
+}
+
+// RecordVersion records that the collection was migrated to version. The
+// recorded version never goes down, as an older build may still migrate
+// during a rolling deploy.
+func RecordVersion(ctx context.Context, database *mongo.Database, collection string, version int) error {
+	_, err := database.Collection(VersionsCollection).UpdateOne(ctx,
+		bson.M{"_id": collection},
+		bson.M{"$max": bson.M{"version": version}},
+		options.Update().SetUpsert(true))
+
+	return err
+}
@@ -0,0 +1,118 @@
+package migration
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+const SchemaVersionField = "schema_version"
+
+// Upcaster upgrades a raw document by exactly one schema version.
+type Upcaster func(document bson.M) bson.M
+
+// Chain holds the ordered upcasters of a collection. Documents without a
+// schema_version field are treated as version 1, so the upcaster at index i
+// upgrades a document from version i+1 to version i+2.
+type Chain struct {
+	upcasters []Upcaster
+}
+
+func NewChain(upcasters ...Upcaster) *Chain {
+	return &Chain{
+		upcasters: upcasters,
+	}
+}
+
+func (c *Chain) LatestVersion() int {
+	return len(c.upcasters) + 1
+}
+
+func (c *Chain) Upcast(document bson.M) (bson.M, bool) {
+	latestVersion := c.LatestVersion()
+	version := documentVersion(document)
+	if version >= latestVersion {
+		return document, false
+	}
+
+	for current := version; current < latestVersion; current++ {
+		document = c.upcasters[current-1](document)
+	}
+	document[SchemaVersionField] = latestVersion
+
+	return document, true
+}
+
+// Decode upcasts the raw document to the latest version before decoding it
+// into out, so repositories only ever see the current struct shape.
+func (c *Chain) Decode(document bson.M, out interface{}) error {
+	document, _ = c.Upcast(document)
+
+	raw, err := bson.Marshal(document)
+	if err != nil {
+		return err
+	}
+
+	return bson.Unmarshal(raw, out)
+}
+
+// MigrateCollection rewrites every document of the collection that is behind
+// the latest schema version and returns how many documents were migrated.
+func (c *Chain) MigrateCollection(ctx context.Context, collection *mongo.Collection) (int64, error) {
+	filter := bson.M{"$or": []bson.M{
+		{SchemaVersionField: bson.M{"$exists": false}},
+		{SchemaVersionField: bson.M{"$lt": c.LatestVersion()}},
+	}}
+
+	cursor, err := collection.Find(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var migrated int64
+	for cursor.Next(ctx) {
+		var document bson.M
+		if err := cursor.Decode(&document); err != nil {
+			return migrated, err
+		}
+
+		document, changed := c.Upcast(document)
+		if !changed {
+			continue
+		}
+
+		if _, err := collection.ReplaceOne(ctx, bson.M{"_id": document["_id"]}, document); err != nil {
+			logger.Error("Error trying to migrate document", err,
+				zap.String("collection", collection.Name()),
+				zap.Any("id", document["_id"]))
+			return migrated, err
+		}
+		migrated++
+	}
+
+	return migrated, cursor.Err()
+}
+
+func documentVersion(document bson.M) int {
+	var version int
+	switch value := document[SchemaVersionField].(type) {
+	case int32:
+		version = int(value)
+	case int64:
+		version = int(value)
+	case int:
+		version = value
+	case float64:
+		version = int(value)
+	}
+
+	if version < 1 {
+		return 1
+	}
+
+	return version
+}
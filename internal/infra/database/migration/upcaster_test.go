@@ -0,0 +1,62 @@
+package migration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestChain_Upcast(t *testing.T) {
+	chain := NewChain(
+		func(document bson.M) bson.M {
+			document["name"] = document["title"]
+			delete(document, "title")
+			return document
+		},
+		func(document bson.M) bson.M {
+			document["active"] = true
+			return document
+		},
+	)
+
+	assert.Equal(t, 3, chain.LatestVersion())
+
+	document, changed := chain.Upcast(bson.M{"title": "legacy"})
+	assert.True(t, changed)
+	assert.Equal(t, bson.M{"name": "legacy", "active": true, SchemaVersionField: 3}, document)
+
+	document, changed = chain.Upcast(bson.M{"name": "v2", SchemaVersionField: int32(2)})
+	assert.True(t, changed)
+	assert.Equal(t, bson.M{"name": "v2", "active": true, SchemaVersionField: 3}, document)
+
+	document, changed = chain.Upcast(bson.M{"name": "current", SchemaVersionField: int32(3)})
+	assert.False(t, changed)
+	assert.Equal(t, bson.M{"name": "current", SchemaVersionField: int32(3)}, document)
+}
+
+func TestChain_Decode(t *testing.T) {
+	chain := NewChain(func(document bson.M) bson.M {
+		document["name"] = document["title"]
+		return document
+	})
+
+	var out struct {
+		Name          string `bson:"name"`
+		SchemaVersion int    `bson:"schema_version"`
+	}
+	err := chain.Decode(bson.M{"title": "legacy"}, &out)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "legacy", out.Name)
+	assert.Equal(t, 2, out.SchemaVersion)
+}
+
+func TestChain_Compatible(t *testing.T) {
+	chain := NewChain(func(document bson.M) bson.M { return document })
+
+	assert.True(t, chain.Compatible(0))
+	assert.True(t, chain.Compatible(1))
+	assert.True(t, chain.Compatible(2))
+	assert.False(t, chain.Compatible(3))
+}
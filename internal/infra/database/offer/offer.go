@@ -0,0 +1,143 @@
+package offer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/offer_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type OfferEntityMongo struct {
+	Id          string                   `bson:"_id"`
+	AuctionId   string                   `bson:"auction_id"`
+	SellerId    string                   `bson:"seller_id"`
+	UserId      string                   `bson:"user_id"`
+	Amount      float64                  `bson:"amount"`
+	Status      offer_entity.OfferStatus `bson:"status"`
+	ExpiresAt   int64                    `bson:"expires_at"`
+	RespondedAt int64                    `bson:"responded_at,omitempty"`
+	Timestamp   int64                    `bson:"timestamp"`
+}
+
+type OfferRepository struct {
+	Collection *mongo.Collection
+}
+
+func NewOfferRepository(database *mongo.Database) *OfferRepository {
+	return &OfferRepository{
+		Collection: database.Collection("second_chance_offers"),
+	}
+}
+
+func (or *OfferRepository) CreateOffer(
+	ctx context.Context, offer *offer_entity.Offer) *internal_error.InternalError {
+	offerMongo := &OfferEntityMongo{
+		Id:        offer.Id,
+		AuctionId: offer.AuctionId,
+		SellerId:  offer.SellerId,
+		UserId:    offer.UserId,
+		Amount:    offer.Amount,
+		Status:    offer.Status,
+		ExpiresAt: offer.ExpiresAt.UnixMilli(),
+		Timestamp: offer.Timestamp.UnixMilli(),
+	}
+
+	if _, err := or.Collection.InsertOne(ctx, offerMongo); err != nil {
+		logger.Error("Error trying to insert offer", err)
+		return internal_error.NewInternalServerError("Error trying to insert offer")
+	}
+
+	return nil
+}
+
+func (or *OfferRepository) FindOfferById(
+	ctx context.Context, id string) (*offer_entity.Offer, *internal_error.InternalError) {
+	var offerMongo OfferEntityMongo
+	if err := or.Collection.FindOne(ctx, bson.M{"_id": id}).Decode(&offerMongo); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, internal_error.NewNotFoundError(
+				fmt.Sprintf("Offer not found with this id = %s", id))
+		}
+
+		logger.Error("Error trying to find offer by id", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find offer by id")
+	}
+
+	return offerMongo.toOfferEntity(), nil
+}
+
+func (or *OfferRepository) FindOffers(
+	ctx context.Context, auctionId string) ([]offer_entity.Offer, *internal_error.InternalError) {
+	filter := bson.M{"auction_id": auctionId}
+
+	opts := options.Find().SetSort(bson.D{{Key: "timestamp", Value: 1}})
+	cursor, err := or.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		logger.Error("Error finding offers", err)
+		return nil, internal_error.NewInternalServerError("Error finding offers")
+	}
+	defer cursor.Close(ctx)
+
+	var offersMongo []OfferEntityMongo
+	if err := cursor.All(ctx, &offersMongo); err != nil {
+		logger.Error("Error decoding offers", err)
+		return nil, internal_error.NewInternalServerError("Error decoding offers")
+	}
+
+	var offers []offer_entity.Offer
+	for _, offerMongo := range offersMongo {
+		offers = append(offers, *offerMongo.toOfferEntity())
+	}
+
+	return offers, nil
+}
+
+func (or *OfferRepository) UpdateOfferStatus(
+	ctx context.Context,
+	id string,
+	status offer_entity.OfferStatus,
+	respondedAt time.Time) *internal_error.InternalError {
+	filter := bson.M{"_id": id, "status": offer_entity.Pending}
+	update := bson.M{"$set": bson.M{
+		"status":       status,
+		"responded_at": respondedAt.UnixMilli(),
+	}}
+
+	result, err := or.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		logger.Error("Error trying to update offer", err)
+		return internal_error.NewInternalServerError("Error trying to update offer")
+	}
+
+	if result.MatchedCount == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("Pending offer not found with this id = %s", id))
+	}
+
+	return nil
+}
+
+func (om *OfferEntityMongo) toOfferEntity() *offer_entity.Offer {
+	offer := &offer_entity.Offer{
+		Id:        om.Id,
+		AuctionId: om.AuctionId,
+		SellerId:  om.SellerId,
+		UserId:    om.UserId,
+		Amount:    om.Amount,
+		Status:    om.Status,
+		ExpiresAt: time.UnixMilli(om.ExpiresAt),
+		Timestamp: time.UnixMilli(om.Timestamp),
+	}
+	if om.RespondedAt != 0 {
+		offer.RespondedAt = time.UnixMilli(om.RespondedAt)
+	}
+
+	return offer
+}
@@ -0,0 +1,57 @@
+package product
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/product_entity"
+	"fullcycle-auction_go/internal/internal_error"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type ProductEntityMongo struct {
+	Id          string            `bson:"_id"`
+	Sku         string            `bson:"sku"`
+	Name        string            `bson:"name"`
+	Description string            `bson:"description"`
+	Category    string            `bson:"category"`
+	Attributes  map[string]string `bson:"attributes"`
+	Images      []string          `bson:"images"`
+	Timestamp   int64             `bson:"timestamp"`
+}
+
+type ProductRepository struct {
+	Collection *mongo.Collection
+}
+
+func NewProductRepository(database *mongo.Database) *ProductRepository {
+	return &ProductRepository{
+		Collection: database.Collection("products"),
+	}
+}
+
+func (pr *ProductRepository) CreateProduct(
+	ctx context.Context,
+	productEntity *product_entity.Product) *internal_error.InternalError {
+	productEntityMongo := toProductEntityMongo(productEntity)
+
+	if _, err := pr.Collection.InsertOne(ctx, productEntityMongo); err != nil {
+		logger.Error("Error trying to insert product", err)
+		return internal_error.NewInternalServerError("Error trying to insert product")
+	}
+
+	return nil
+}
+
+func toProductEntityMongo(productEntity *product_entity.Product) *ProductEntityMongo {
+	return &ProductEntityMongo{
+		Id:          productEntity.Id,
+		Sku:         productEntity.Sku,
+		Name:        productEntity.Name,
+		Description: productEntity.Description,
+		Category:    productEntity.Category,
+		Attributes:  productEntity.Attributes,
+		Images:      productEntity.Images,
+		Timestamp:   productEntity.Timestamp.Unix(),
+	}
+}
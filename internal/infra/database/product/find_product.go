@@ -0,0 +1,80 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/product_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func (pr *ProductRepository) FindProductById(
+	ctx context.Context, id string) (*product_entity.Product, *internal_error.InternalError) {
+	filter := bson.M{"_id": id}
+
+	var productEntityMongo ProductEntityMongo
+	if err := pr.Collection.FindOne(ctx, filter).Decode(&productEntityMongo); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			logger.Error(fmt.Sprintf("Product not found with this id = %s", id), err)
+			return nil, internal_error.NewNotFoundError(
+				fmt.Sprintf("Product not found with this id = %s", id))
+		}
+
+		logger.Error(fmt.Sprintf("Error trying to find product by id = %s", id), err)
+		return nil, internal_error.NewInternalServerError("Error trying to find product by id")
+	}
+
+	return toProductEntity(productEntityMongo), nil
+}
+
+func (pr *ProductRepository) FindProducts(
+	ctx context.Context,
+	category, sku string) ([]product_entity.Product, *internal_error.InternalError) {
+	filter := bson.M{}
+
+	if category != "" {
+		filter["category"] = category
+	}
+
+	if sku != "" {
+		filter["sku"] = sku
+	}
+
+	cursor, err := pr.Collection.Find(ctx, filter)
+	if err != nil {
+		logger.Error("Error finding products", err)
+		return nil, internal_error.NewInternalServerError("Error finding products")
+	}
+	defer cursor.Close(ctx)
+
+	var productsMongo []ProductEntityMongo
+	if err := cursor.All(ctx, &productsMongo); err != nil {
+		logger.Error("Error decoding products", err)
+		return nil, internal_error.NewInternalServerError("Error decoding products")
+	}
+
+	var productsEntity []product_entity.Product
+	for _, product := range productsMongo {
+		productsEntity = append(productsEntity, *toProductEntity(product))
+	}
+
+	return productsEntity, nil
+}
+
+func toProductEntity(productEntityMongo ProductEntityMongo) *product_entity.Product {
+	return &product_entity.Product{
+		Id:          productEntityMongo.Id,
+		Sku:         productEntityMongo.Sku,
+		Name:        productEntityMongo.Name,
+		Description: productEntityMongo.Description,
+		Category:    productEntityMongo.Category,
+		Attributes:  productEntityMongo.Attributes,
+		Images:      productEntityMongo.Images,
+		Timestamp:   time.Unix(productEntityMongo.Timestamp, 0),
+	}
+}
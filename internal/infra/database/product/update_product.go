@@ -0,0 +1,56 @@
+package product
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/product_entity"
+	"fullcycle-auction_go/internal/internal_error"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func (pr *ProductRepository) UpdateProduct(
+	ctx context.Context,
+	productEntity *product_entity.Product) *internal_error.InternalError {
+	filter := bson.M{"_id": productEntity.Id}
+	update := bson.M{"$set": bson.M{
+		"sku":         productEntity.Sku,
+		"name":        productEntity.Name,
+		"description": productEntity.Description,
+		"category":    productEntity.Category,
+		"attributes":  productEntity.Attributes,
+		"images":      productEntity.Images,
+	}}
+
+	result, err := pr.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		logger.Error("Error trying to update product", err)
+		return internal_error.NewInternalServerError("Error trying to update product")
+	}
+
+	if result.MatchedCount == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("Product not found with this id = %s", productEntity.Id))
+	}
+
+	return nil
+}
+
+func (pr *ProductRepository) DeleteProduct(
+	ctx context.Context, id string) *internal_error.InternalError {
+	filter := bson.M{"_id": id}
+
+	result, err := pr.Collection.DeleteOne(ctx, filter)
+	if err != nil {
+		logger.Error("Error trying to delete product", err)
+		return internal_error.NewInternalServerError("Error trying to delete product")
+	}
+
+	if result.DeletedCount == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("Product not found with this id = %s", id))
+	}
+
+	return nil
+}
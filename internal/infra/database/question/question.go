@@ -0,0 +1,144 @@
+package question
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/question_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Timestamps are kept in milliseconds so questions asked within the same
+// second keep their order in the thread.
+type QuestionEntityMongo struct {
+	Id         string                         `bson:"_id"`
+	AuctionId  string                         `bson:"auction_id"`
+	UserId     string                         `bson:"user_id"`
+	Text       string                         `bson:"text"`
+	Answer     string                         `bson:"answer,omitempty"`
+	AnsweredAt int64                          `bson:"answered_at,omitempty"`
+	Status     question_entity.QuestionStatus `bson:"status"`
+	Timestamp  int64                          `bson:"timestamp"`
+}
+
+type QuestionRepository struct {
+	Collection *mongo.Collection
+}
+
+func NewQuestionRepository(database *mongo.Database) *QuestionRepository {
+	return &QuestionRepository{
+		Collection: database.Collection("auction_questions"),
+	}
+}
+
+func (qr *QuestionRepository) CreateQuestion(
+	ctx context.Context, question *question_entity.Question) *internal_error.InternalError {
+	questionMongo := &QuestionEntityMongo{
+		Id:        question.Id,
+		AuctionId: question.AuctionId,
+		UserId:    question.UserId,
+		Text:      question.Text,
+		Status:    question.Status,
+		Timestamp: question.Timestamp.UnixMilli(),
+	}
+
+	if _, err := qr.Collection.InsertOne(ctx, questionMongo); err != nil {
+		logger.Error("Error trying to insert question", err)
+		return internal_error.NewInternalServerError("Error trying to insert question")
+	}
+
+	return nil
+}
+
+func (qr *QuestionRepository) FindQuestionById(
+	ctx context.Context, id string) (*question_entity.Question, *internal_error.InternalError) {
+	var questionMongo QuestionEntityMongo
+	if err := qr.Collection.FindOne(ctx, bson.M{"_id": id}).Decode(&questionMongo); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, internal_error.NewNotFoundError(
+				fmt.Sprintf("Question not found with this id = %s", id))
+		}
+
+		logger.Error("Error trying to find question by id", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find question by id")
+	}
+
+	return questionMongo.toQuestionEntity(), nil
+}
+
+func (qr *QuestionRepository) FindQuestions(
+	ctx context.Context, auctionId string) ([]question_entity.Question, *internal_error.InternalError) {
+	filter := bson.M{"auction_id": auctionId}
+
+	opts := options.Find().SetSort(bson.D{{Key: "timestamp", Value: 1}})
+	cursor, err := qr.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		logger.Error("Error finding questions", err)
+		return nil, internal_error.NewInternalServerError("Error finding questions")
+	}
+	defer cursor.Close(ctx)
+
+	var questionsMongo []QuestionEntityMongo
+	if err := cursor.All(ctx, &questionsMongo); err != nil {
+		logger.Error("Error decoding questions", err)
+		return nil, internal_error.NewInternalServerError("Error decoding questions")
+	}
+
+	var questions []question_entity.Question
+	for _, questionMongo := range questionsMongo {
+		questions = append(questions, *questionMongo.toQuestionEntity())
+	}
+
+	return questions, nil
+}
+
+func (qr *QuestionRepository) UpdateQuestion(
+	ctx context.Context, question *question_entity.Question) *internal_error.InternalError {
+	var answeredAt int64
+	if question.Answered() {
+		answeredAt = question.AnsweredAt.UnixMilli()
+	}
+
+	filter := bson.M{"_id": question.Id}
+	update := bson.M{"$set": bson.M{
+		"answer":      question.Answer,
+		"answered_at": answeredAt,
+		"status":      question.Status,
+	}}
+
+	result, err := qr.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		logger.Error("Error trying to update question", err)
+		return internal_error.NewInternalServerError("Error trying to update question")
+	}
+
+	if result.MatchedCount == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("Question not found with this id = %s", question.Id))
+	}
+
+	return nil
+}
+
+func (qm *QuestionEntityMongo) toQuestionEntity() *question_entity.Question {
+	question := &question_entity.Question{
+		Id:        qm.Id,
+		AuctionId: qm.AuctionId,
+		UserId:    qm.UserId,
+		Text:      qm.Text,
+		Answer:    qm.Answer,
+		Status:    qm.Status,
+		Timestamp: time.UnixMilli(qm.Timestamp),
+	}
+	if qm.AnsweredAt != 0 {
+		question.AnsweredAt = time.UnixMilli(qm.AnsweredAt)
+	}
+
+	return question
+}
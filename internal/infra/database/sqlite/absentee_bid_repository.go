@@ -0,0 +1,122 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+)
+
+const absenteeBidColumns = `id, auction_id, user_id, max_amount, terms_version, status, timestamp`
+
+type AbsenteeBidRepository struct {
+	Database *sql.DB
+}
+
+func NewAbsenteeBidRepository(database *sql.DB) *AbsenteeBidRepository {
+	return &AbsenteeBidRepository{
+		Database: database,
+	}
+}
+
+func (ar *AbsenteeBidRepository) UpsertAbsenteeBid(
+	ctx context.Context, absenteeBid *bid_entity.AbsenteeBid) *internal_error.InternalError {
+	_, err := ar.Database.ExecContext(ctx,
+		`INSERT INTO absentee_bids (`+absenteeBidColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (auction_id, user_id) DO UPDATE
+			SET max_amount = excluded.max_amount, terms_version = excluded.terms_version,
+				status = excluded.status, timestamp = excluded.timestamp`,
+		absenteeBid.Id, absenteeBid.AuctionId, absenteeBid.UserId, absenteeBid.MaxAmount,
+		absenteeBid.TermsVersion, absenteeBid.Status, absenteeBid.Timestamp.UnixMilli())
+	if err != nil {
+		logger.Error("Error trying to save absentee bid", err)
+		return internal_error.NewInternalServerError("Error trying to save absentee bid")
+	}
+
+	return nil
+}
+
+func (ar *AbsenteeBidRepository) FindAbsenteeBid(
+	ctx context.Context, auctionId, userId string) (*bid_entity.AbsenteeBid, *internal_error.InternalError) {
+	absenteeBid, err := scanAbsenteeBid(ar.Database.QueryRowContext(ctx,
+		`SELECT `+absenteeBidColumns+` FROM absentee_bids WHERE auction_id = ? AND user_id = ?`,
+		auctionId, userId))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, internal_error.NewNotFoundError(
+				fmt.Sprintf("Absentee bid not found for auction = %s and user = %s", auctionId, userId))
+		}
+
+		logger.Error("Error trying to find absentee bid", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find absentee bid")
+	}
+
+	return absenteeBid, nil
+}
+
+func (ar *AbsenteeBidRepository) FindPendingAbsenteeBids(
+	ctx context.Context, auctionId string) ([]bid_entity.AbsenteeBid, *internal_error.InternalError) {
+	rows, err := ar.Database.QueryContext(ctx,
+		`SELECT `+absenteeBidColumns+` FROM absentee_bids WHERE auction_id = ? AND status = ?
+			ORDER BY max_amount DESC, timestamp`,
+		auctionId, bid_entity.AbsenteePending)
+	if err != nil {
+		logger.Error("Error finding absentee bids", err)
+		return nil, internal_error.NewInternalServerError("Error finding absentee bids")
+	}
+	defer rows.Close()
+
+	var absenteeBids []bid_entity.AbsenteeBid
+	for rows.Next() {
+		absenteeBid, err := scanAbsenteeBid(rows)
+		if err != nil {
+			logger.Error("Error decoding absentee bids", err)
+			return nil, internal_error.NewInternalServerError("Error decoding absentee bids")
+		}
+
+		absenteeBids = append(absenteeBids, *absenteeBid)
+	}
+
+	return absenteeBids, nil
+}
+
+func (ar *AbsenteeBidRepository) UpdateAbsenteeBidStatus(
+	ctx context.Context, id string, status bid_entity.AbsenteeBidStatus) *internal_error.InternalError {
+	result, err := ar.Database.ExecContext(ctx,
+		`UPDATE absentee_bids SET status = ? WHERE id = ?`, status, id)
+	if err != nil {
+		logger.Error("Error trying to update absentee bid", err)
+		return internal_error.NewInternalServerError("Error trying to update absentee bid")
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("Absentee bid not found with this id = %s", id))
+	}
+
+	return nil
+}
+
+func scanAbsenteeBid(row scanner) (*bid_entity.AbsenteeBid, error) {
+	var absenteeBid bid_entity.AbsenteeBid
+	var timestamp int64
+
+	if err := row.Scan(
+		&absenteeBid.Id,
+		&absenteeBid.AuctionId,
+		&absenteeBid.UserId,
+		&absenteeBid.MaxAmount,
+		&absenteeBid.TermsVersion,
+		&absenteeBid.Status,
+		&timestamp); err != nil {
+		return nil, err
+	}
+
+	absenteeBid.Timestamp = time.UnixMilli(timestamp)
+
+	return &absenteeBid, nil
+}
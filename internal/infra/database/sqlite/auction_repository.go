@@ -0,0 +1,988 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/configuration/clock"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/event_entity"
+	"fullcycle-auction_go/internal/infra/scheduler"
+	"fullcycle-auction_go/internal/internal_error"
+	"os"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const auctionColumns = `id, seller_id, product_id, product_name, category, description,
+	condition, grading, attributes, status, timestamp, updated_at, ends_at, winner_bid_id, bidder_visibility, terms,
+	recurring_auction_id, series_id, lot_number, live, bidding_opened_at, starts_at, reserve_price, min_increment, sealed, buy_now_price, paused_at,
+	descriptions, registration_required, public_at, invitees, heat_score, relisted_from, starting_price, views,
+	quantity, uniform_price, estimate_low, estimate_high`
+
+type AuctionRepository struct {
+	Database        *sql.DB
+	auctionInterval time.Duration
+	completionGrace time.Duration
+	publisher       event_entity.Publisher
+	scheduler       *scheduler.Scheduler
+}
+
+// NewAuctionRepository publishes the completion of every auction to
+// publisher, which may be nil.
+func NewAuctionRepository(database *sql.DB, publisher event_entity.Publisher) *AuctionRepository {
+	return &AuctionRepository{
+		Database:        database,
+		publisher:       publisher,
+		auctionInterval: getAuctionInterval(),
+		completionGrace: getCompletionGrace(),
+		scheduler:       scheduler.New(),
+	}
+}
+
+func (ar *AuctionRepository) CreateAuction(
+	ctx context.Context,
+	auctionEntity *auction_entity.Auction) *internal_error.InternalError {
+	if auctionEntity.EndsAt.IsZero() {
+		auctionEntity.EndsAt = auctionEntity.OpensAt().Add(ar.auctionInterval)
+	}
+
+	_, err := ar.Database.ExecContext(ctx,
+		`INSERT INTO auctions (`+auctionColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		auctionArgs(auctionEntity)...)
+	if err != nil {
+		logger.Error("Error trying to insert auction", err)
+		return internal_error.NewInternalServerError("Error trying to insert auction")
+	}
+
+	ar.scheduleTimers(auctionEntity)
+
+	return nil
+}
+
+// auctionArgs lists the values of auctionColumns.
+func auctionArgs(auctionEntity *auction_entity.Auction) []interface{} {
+	grading, _ := json.Marshal(auctionEntity.Grading)
+	attributes, _ := json.Marshal(auctionEntity.Attributes)
+	descriptions, _ := json.Marshal(auctionEntity.Descriptions)
+	invitees, _ := json.Marshal(auctionEntity.Invitees)
+	var terms []byte
+	if auctionEntity.Terms != nil {
+		terms, _ = json.Marshal(auctionEntity.Terms)
+	}
+
+	return []interface{}{
+		auctionEntity.Id,
+		auctionEntity.SellerId,
+		auctionEntity.ProductId,
+		auctionEntity.ProductName,
+		auctionEntity.Category,
+		auctionEntity.Description,
+		auctionEntity.Condition,
+		string(grading),
+		string(attributes),
+		auctionEntity.Status,
+		auctionEntity.Timestamp.Unix(),
+		auctionEntity.UpdatedAt.UnixMilli(),
+		auctionEntity.EndsAt.UnixMilli(),
+		auctionEntity.WinnerBidId,
+		auctionEntity.BidderVisibility,
+		string(terms),
+		auctionEntity.RecurringAuctionId,
+		auctionEntity.SeriesId,
+		auctionEntity.LotNumber,
+		auctionEntity.Live,
+		toUnixMilli(auctionEntity.BiddingOpenedAt),
+		toUnixMilli(auctionEntity.StartsAt),
+		auctionEntity.ReservePrice,
+		auctionEntity.MinIncrement,
+		auctionEntity.Sealed,
+		auctionEntity.BuyNowPrice,
+		toUnixMilli(auctionEntity.PausedAt),
+		string(descriptions),
+		auctionEntity.RegistrationRequired,
+		toUnixMilli(auctionEntity.PublicAt),
+		string(invitees),
+		auctionEntity.HeatScore,
+		auctionEntity.RelistedFrom,
+		auctionEntity.StartingPrice,
+		auctionEntity.Views,
+		auctionEntity.Quantity,
+		auctionEntity.UniformPrice,
+		auctionEntity.EstimateLow,
+		auctionEntity.EstimateHigh,
+	}
+}
+
+// scheduleTimers starts the timer of the auction's current status, drafts
+// having none until published.
+func (ar *AuctionRepository) scheduleTimers(auctionEntity *auction_entity.Auction) {
+	switch auctionEntity.Status {
+	case auction_entity.Draft:
+	case auction_entity.Scheduled:
+		ar.scheduleStart(auctionEntity.Id, auctionEntity.StartsAt, auctionEntity.EndsAt)
+	default:
+		ar.scheduleCompletion(auctionEntity.Id, auctionEntity.EndsAt)
+	}
+}
+
+func (ar *AuctionRepository) scheduleStart(auctionId string, startsAt, endsAt time.Time) {
+	ar.scheduler.Schedule(auctionId, startsAt, func() { ar.startAuction(auctionId, endsAt) })
+}
+
+// scheduleCompletion completes the auction once the completion grace has
+// passed its end too, leaving the bids accepted before the end time to be
+// flushed. It replaces the timer the auction had.
+func (ar *AuctionRepository) scheduleCompletion(auctionId string, endsAt time.Time) {
+	ar.scheduler.Schedule(auctionId, endsAt.Add(ar.completionGrace), func() { ar.completeAuction(auctionId, endsAt) })
+}
+
+// startAuction activates the scheduled auction and schedules its end.
+func (ar *AuctionRepository) startAuction(auctionId string, endsAt time.Time) {
+	result, err := ar.Database.ExecContext(context.Background(),
+		`UPDATE auctions SET status = ?, updated_at = ? WHERE id = ? AND status = ?`,
+		auction_entity.Active, clock.Now().UnixMilli(), auctionId, auction_entity.Scheduled)
+	if err != nil {
+		logger.Error("Error trying to start auction", err)
+		return
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return
+	}
+
+	if ar.publisher != nil {
+		ar.publisher.Publish(context.Background(), event_entity.NewEvent(event_entity.AuctionStarted, auctionId, nil))
+	}
+
+	ar.scheduleCompletion(auctionId, endsAt)
+}
+
+// completeAuction marks the auction completed, unless its end moved past
+// the one it was scheduled for, when it is scheduled again.
+func (ar *AuctionRepository) completeAuction(auctionId string, endsAt time.Time) {
+	auctionEntity, err := ar.FindAuctionById(context.Background(), auctionId)
+	if err != nil || auctionEntity.Status != auction_entity.Active {
+		return
+	}
+
+	if auctionEntity.EndsAt.After(endsAt) {
+		ar.scheduleCompletion(auctionId, auctionEntity.EndsAt)
+		return
+	}
+
+	result, errExec := ar.Database.ExecContext(context.Background(),
+		`UPDATE auctions SET status = ?, updated_at = ? WHERE id = ? AND status = ?`,
+		auction_entity.Completed, clock.Now().UnixMilli(), auctionId, auction_entity.Active)
+	if errExec != nil {
+		logger.Error("Error trying to update auction status", errExec)
+		return
+	}
+
+	if affected, _ := result.RowsAffected(); affected > 0 {
+		ar.publishCompleted(context.Background(), auctionId)
+	}
+}
+
+// RecoverAuctions re-arms the timers of the scheduled and active auctions,
+// which live in memory and are lost when the process stops. Auctions that ended
+// while it was down are completed right away, and the ones that should have
+// started meanwhile are started first.
+func (ar *AuctionRepository) RecoverAuctions(ctx context.Context) error {
+	rows, err := ar.Database.QueryContext(ctx,
+		`SELECT id, status, starts_at, ends_at FROM auctions WHERE status IN (?, ?)`,
+		auction_entity.Active, auction_entity.Scheduled)
+	if err != nil {
+		logger.Error("Error trying to find auctions to recover", err)
+		return err
+	}
+	defer rows.Close()
+
+	recovered := 0
+	for rows.Next() {
+		var auctionId string
+		var status auction_entity.AuctionStatus
+		var startsAt, endsAt int64
+		if err := rows.Scan(&auctionId, &status, &startsAt, &endsAt); err != nil {
+			logger.Error("Error trying to decode auctions to recover", err)
+			return err
+		}
+
+		if status == auction_entity.Scheduled {
+			ar.scheduleStart(auctionId, fromUnixMilli(startsAt), time.UnixMilli(endsAt))
+		} else {
+			ar.scheduleCompletion(auctionId, time.UnixMilli(endsAt))
+		}
+		recovered++
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("Error trying to find auctions to recover", err)
+		return err
+	}
+
+	logger.Info("Auction timers recovered", zap.Int("auctions", recovered))
+
+	return nil
+}
+
+func (ar *AuctionRepository) publishCompleted(ctx context.Context, auctionId string) {
+	if ar.publisher != nil {
+		ar.publisher.Publish(ctx, event_entity.NewEvent(event_entity.AuctionCompleted, auctionId, nil))
+	}
+}
+
+func (ar *AuctionRepository) FindAuctionById(
+	ctx context.Context, id string) (*auction_entity.Auction, *internal_error.InternalError) {
+	row := ar.Database.QueryRowContext(ctx,
+		`SELECT `+auctionColumns+` FROM auctions WHERE id = ?`, id)
+
+	auctionEntity, err := scanAuction(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, internal_error.NewNotFoundError(
+				fmt.Sprintf("Auction not found with this id = %s", id))
+		}
+
+		logger.Error(fmt.Sprintf("Error trying to find auction by id = %s", id), err)
+		return nil, internal_error.NewInternalServerError("Error trying to find auction by id")
+	}
+
+	return auctionEntity, nil
+}
+
+func (ar *AuctionRepository) FindAuctionsByIds(
+	ctx context.Context, ids []string) ([]auction_entity.Auction, *internal_error.InternalError) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+
+	rows, err := ar.Database.QueryContext(ctx,
+		`SELECT `+auctionColumns+` FROM auctions WHERE id IN (?`+strings.Repeat(", ?", len(ids)-1)+`)`,
+		args...)
+	if err != nil {
+		logger.Error("Error trying to find auctions by ids", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find auctions by ids")
+	}
+	defer rows.Close()
+
+	var auctionsEntity []auction_entity.Auction
+	for rows.Next() {
+		auctionEntity, err := scanAuction(rows)
+		if err != nil {
+			logger.Error("Error trying to decode auctions by ids", err)
+			return nil, internal_error.NewInternalServerError("Error trying to find auctions by ids")
+		}
+
+		auctionsEntity = append(auctionsEntity, *auctionEntity)
+	}
+
+	return auctionsEntity, nil
+}
+
+func (ar *AuctionRepository) FindAuctions(
+	ctx context.Context,
+	filter auction_entity.AuctionFilter,
+	fields []string) ([]auction_entity.Auction, *internal_error.InternalError) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.Status != 0 {
+		conditions = append(conditions, "status = ?")
+		args = append(args, filter.Status)
+	}
+
+	if len(filter.Categories) > 0 {
+		conditions = append(conditions, "category IN (?"+strings.Repeat(", ?", len(filter.Categories)-1)+")")
+		for _, category := range filter.Categories {
+			args = append(args, category)
+		}
+	}
+
+	if filter.ProductName != "" {
+		conditions = append(conditions, "product_name LIKE ?")
+		args = append(args, "%"+filter.ProductName+"%")
+	}
+
+	for name, value := range filter.Attributes {
+		conditions = append(conditions, "json_extract(attributes, ?) = ?")
+		args = append(args, `$."`+name+`"`, value)
+	}
+
+	if filter.MinPrice > 0 {
+		conditions = append(conditions, "starting_price >= ?")
+		args = append(args, filter.MinPrice)
+	}
+	if filter.MaxPrice > 0 {
+		conditions = append(conditions, "starting_price <= ?")
+		args = append(args, filter.MaxPrice)
+	}
+	if !filter.EndingAfter.IsZero() {
+		conditions = append(conditions, "ends_at > ?")
+		args = append(args, filter.EndingAfter.UnixMilli())
+	}
+	if !filter.EndingBefore.IsZero() {
+		conditions = append(conditions, "ends_at < ?")
+		args = append(args, filter.EndingBefore.UnixMilli())
+	}
+	if !filter.CreatedAfter.IsZero() {
+		conditions = append(conditions, "timestamp > ?")
+		args = append(args, filter.CreatedAfter.Unix())
+	}
+
+	query := `SELECT ` + auctionColumns + ` FROM auctions`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	rows, err := ar.Database.QueryContext(ctx, query, args...)
+	if err != nil {
+		logger.Error("Error finding auctions", err)
+		return nil, internal_error.NewInternalServerError("Error finding auctions")
+	}
+	defer rows.Close()
+
+	var auctionsEntity []auction_entity.Auction
+	for rows.Next() {
+		auctionEntity, err := scanAuction(rows)
+		if err != nil {
+			logger.Error("Error decoding auctions", err)
+			return nil, internal_error.NewInternalServerError("Error decoding auctions")
+		}
+
+		auctionsEntity = append(auctionsEntity, *auctionEntity)
+	}
+
+	return auctionsEntity, nil
+}
+
+func (ar *AuctionRepository) FindAuctionChanges(
+	ctx context.Context, since time.Time) ([]auction_entity.Auction, *internal_error.InternalError) {
+	rows, err := ar.Database.QueryContext(ctx,
+		`SELECT `+auctionColumns+` FROM auctions WHERE updated_at > ? ORDER BY updated_at`,
+		since.UnixMilli())
+	if err != nil {
+		logger.Error("Error finding auction changes", err)
+		return nil, internal_error.NewInternalServerError("Error finding auction changes")
+	}
+	defer rows.Close()
+
+	var auctionsEntity []auction_entity.Auction
+	for rows.Next() {
+		auctionEntity, err := scanAuction(rows)
+		if err != nil {
+			logger.Error("Error decoding auction changes", err)
+			return nil, internal_error.NewInternalServerError("Error decoding auction changes")
+		}
+
+		auctionsEntity = append(auctionsEntity, *auctionEntity)
+	}
+
+	return auctionsEntity, nil
+}
+
+func (ar *AuctionRepository) FindSeriesAuctions(
+	ctx context.Context, seriesId string) ([]auction_entity.Auction, *internal_error.InternalError) {
+	rows, err := ar.Database.QueryContext(ctx,
+		`SELECT `+auctionColumns+` FROM auctions WHERE series_id = ? ORDER BY lot_number`,
+		seriesId)
+	if err != nil {
+		logger.Error("Error finding series auctions", err)
+		return nil, internal_error.NewInternalServerError("Error finding series auctions")
+	}
+	defer rows.Close()
+
+	var auctionsEntity []auction_entity.Auction
+	for rows.Next() {
+		auctionEntity, err := scanAuction(rows)
+		if err != nil {
+			logger.Error("Error decoding series auctions", err)
+			return nil, internal_error.NewInternalServerError("Error decoding series auctions")
+		}
+
+		auctionsEntity = append(auctionsEntity, *auctionEntity)
+	}
+
+	return auctionsEntity, nil
+}
+
+func (ar *AuctionRepository) UpdateAuctionWinner(
+	ctx context.Context, auctionId, winnerBidId string) *internal_error.InternalError {
+	result, err := ar.Database.ExecContext(ctx,
+		`UPDATE auctions SET winner_bid_id = ?, updated_at = ? WHERE id = ?`,
+		winnerBidId, clock.Now().UnixMilli(), auctionId)
+	if err != nil {
+		logger.Error("Error trying to update auction winner", err)
+		return internal_error.NewInternalServerError("Error trying to update auction winner")
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("Auction not found with this id = %s", auctionId))
+	}
+
+	return nil
+}
+
+func (ar *AuctionRepository) UpdateAuctionStatus(
+	ctx context.Context,
+	auctionId string,
+	expected, status auction_entity.AuctionStatus) *internal_error.InternalError {
+	result, err := ar.Database.ExecContext(ctx,
+		`UPDATE auctions SET status = ?, updated_at = ? WHERE id = ? AND status = ?`,
+		status, clock.Now().UnixMilli(), auctionId, expected)
+	if err != nil {
+		logger.Error("Error trying to update auction status", err)
+		return internal_error.NewInternalServerError("Error trying to update auction status")
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		if _, err := ar.FindAuctionById(ctx, auctionId); err != nil {
+			return err
+		}
+		return internal_error.NewConflictError(
+			fmt.Sprintf("Auction %s changed status meanwhile", auctionId))
+	}
+
+	if status.Ended() {
+		ar.scheduler.Cancel(auctionId)
+	}
+
+	switch status {
+	case auction_entity.Completed:
+		ar.publishCompleted(ctx, auctionId)
+	case auction_entity.Cancelled:
+		if ar.publisher != nil {
+			ar.publisher.Publish(ctx, event_entity.NewEvent(event_entity.AuctionCancelled, auctionId, nil))
+		}
+	}
+
+	return nil
+}
+
+func (ar *AuctionRepository) UpdateAuctionSeller(
+	ctx context.Context, auctionId, fromSellerId, toSellerId string) *internal_error.InternalError {
+	result, err := ar.Database.ExecContext(ctx,
+		`UPDATE auctions SET seller_id = ?, updated_at = ? WHERE id = ? AND seller_id = ?`,
+		toSellerId, clock.Now().UnixMilli(), auctionId, fromSellerId)
+	if err != nil {
+		logger.Error("Error trying to update auction seller", err)
+		return internal_error.NewInternalServerError("Error trying to update auction seller")
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("Auction not found with this id = %s and seller = %s", auctionId, fromSellerId))
+	}
+
+	return nil
+}
+
+func (ar *AuctionRepository) UpdateAuctionDetails(
+	ctx context.Context, auctionEntity *auction_entity.Auction) *internal_error.InternalError {
+	grading, _ := json.Marshal(auctionEntity.Grading)
+
+	result, err := ar.Database.ExecContext(ctx,
+		`UPDATE auctions SET product_name = ?, description = ?, category = ?, condition = ?, grading = ?, updated_at = ?
+			WHERE id = ? AND status NOT IN (?, ?, ?)`,
+		auctionEntity.ProductName,
+		auctionEntity.Description,
+		auctionEntity.Category,
+		auctionEntity.Condition,
+		string(grading),
+		auctionEntity.UpdatedAt.UnixMilli(),
+		auctionEntity.Id,
+		auction_entity.Completed, auction_entity.ClosedNoSale, auction_entity.Cancelled)
+	if err != nil {
+		logger.Error("Error trying to update auction details", err)
+		return internal_error.NewInternalServerError("Error trying to update auction details")
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("No open auction found with this id = %s", auctionEntity.Id))
+	}
+
+	return nil
+}
+
+func (ar *AuctionRepository) ExtendAuction(
+	ctx context.Context,
+	auctionId string,
+	endsAt time.Time) *internal_error.InternalError {
+	result, err := ar.Database.ExecContext(ctx,
+		`UPDATE auctions SET ends_at = ?, updated_at = ?
+			WHERE id = ? AND status = ? AND ends_at < ?`,
+		endsAt.UnixMilli(), clock.Now().UnixMilli(), auctionId, auction_entity.Active, endsAt.UnixMilli())
+	if err != nil {
+		logger.Error("Error trying to extend auction", err)
+		return internal_error.NewInternalServerError("Error trying to extend auction")
+	}
+
+	if affected, _ := result.RowsAffected(); affected > 0 {
+		ar.scheduleCompletion(auctionId, endsAt)
+	}
+
+	return nil
+}
+
+func (ar *AuctionRepository) OpenAuctionBidding(
+	ctx context.Context,
+	auctionId string,
+	openedAt, endsAt time.Time) *internal_error.InternalError {
+	result, err := ar.Database.ExecContext(ctx,
+		`UPDATE auctions SET bidding_opened_at = ?, ends_at = MAX(ends_at, ?), updated_at = ?
+			WHERE id = ? AND status = ? AND live = 1 AND bidding_opened_at = 0`,
+		openedAt.UnixMilli(), endsAt.UnixMilli(), clock.Now().UnixMilli(), auctionId, auction_entity.Active)
+	if err != nil {
+		logger.Error("Error trying to open auction bidding", err)
+		return internal_error.NewInternalServerError("Error trying to open auction bidding")
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("No live auction closed to bids found with this id = %s", auctionId))
+	}
+
+	return nil
+}
+
+func (ar *AuctionRepository) UpdateAuctionIncrement(
+	ctx context.Context,
+	auctionId string,
+	minIncrement float64) *internal_error.InternalError {
+	result, err := ar.Database.ExecContext(ctx,
+		"UPDATE auctions SET min_increment = ?, updated_at = ? WHERE id = ? AND status = ?",
+		minIncrement, clock.Now().UnixMilli(), auctionId, auction_entity.Active)
+	if err != nil {
+		logger.Error("Error trying to update auction increment", err)
+		return internal_error.NewInternalServerError("Error trying to update auction increment")
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("No active auction found with this id = %s", auctionId))
+	}
+
+	return nil
+}
+
+func (ar *AuctionRepository) UpdateHeatScore(
+	ctx context.Context,
+	auctionId string,
+	heatScore float64) *internal_error.InternalError {
+	result, err := ar.Database.ExecContext(ctx,
+		"UPDATE auctions SET heat_score = ? WHERE id = ?", heatScore, auctionId)
+	if err != nil {
+		logger.Error("Error trying to update auction heat score", err)
+		return internal_error.NewInternalServerError("Error trying to update auction heat score")
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("Auction not found with this id = %s", auctionId))
+	}
+
+	return nil
+}
+
+func (ar *AuctionRepository) AddViews(
+	ctx context.Context,
+	auctionId string,
+	views int64) *internal_error.InternalError {
+	result, err := ar.Database.ExecContext(ctx,
+		"UPDATE auctions SET views = views + ? WHERE id = ?", views, auctionId)
+	if err != nil {
+		logger.Error("Error trying to add auction views", err)
+		return internal_error.NewInternalServerError("Error trying to add auction views")
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("Auction not found with this id = %s", auctionId))
+	}
+
+	return nil
+}
+
+func (ar *AuctionRepository) UpdateDraft(
+	ctx context.Context,
+	auctionEntity *auction_entity.Auction) *internal_error.InternalError {
+	if auctionEntity.EndsAt.IsZero() {
+		auctionEntity.EndsAt = auctionEntity.OpensAt().Add(ar.auctionInterval)
+	}
+
+	args := append(auctionArgs(auctionEntity), auctionEntity.Id, auction_entity.Draft)
+	result, err := ar.Database.ExecContext(ctx,
+		`UPDATE auctions SET (`+auctionColumns+`) = (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) WHERE id = ? AND status = ?`,
+		args...)
+	if err != nil {
+		logger.Error("Error trying to update draft auction", err)
+		return internal_error.NewInternalServerError("Error trying to update draft auction")
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("No draft auction found with this id = %s", auctionEntity.Id))
+	}
+
+	return nil
+}
+
+func (ar *AuctionRepository) PublishAuction(
+	ctx context.Context,
+	auctionEntity *auction_entity.Auction) *internal_error.InternalError {
+	result, err := ar.Database.ExecContext(ctx,
+		`UPDATE auctions SET status = ?, starts_at = ?, ends_at = ?, public_at = ?, updated_at = ?
+		WHERE id = ? AND status = ?`,
+		auctionEntity.Status,
+		toUnixMilli(auctionEntity.StartsAt),
+		auctionEntity.EndsAt.UnixMilli(),
+		toUnixMilli(auctionEntity.PublicAt),
+		auctionEntity.UpdatedAt.UnixMilli(),
+		auctionEntity.Id,
+		auction_entity.Draft)
+	if err != nil {
+		logger.Error("Error trying to publish auction", err)
+		return internal_error.NewInternalServerError("Error trying to publish auction")
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("No draft auction found with this id = %s", auctionEntity.Id))
+	}
+
+	ar.scheduleTimers(auctionEntity)
+
+	return nil
+}
+
+// SearchAuctions matches the words of text anywhere in the searched
+// fields, with no stemming, SQLite lacking text indexes for most
+// languages. The matches are ranked here rather than in SQL.
+func (ar *AuctionRepository) SearchAuctions(
+	ctx context.Context, language, text string) ([]auction_entity.Auction, *internal_error.InternalError) {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return nil, nil
+	}
+
+	conditions := make([]string, 0, len(words))
+	args := make([]interface{}, 0, 4*len(words))
+	for _, word := range words {
+		pattern := "%" + word + "%"
+		conditions = append(conditions,
+			"product_name LIKE ? OR description LIKE ? OR json_extract(descriptions, ?) LIKE ?")
+		args = append(args, pattern, pattern, `$."`+language+`"`, pattern)
+	}
+
+	rows, err := ar.Database.QueryContext(ctx,
+		`SELECT `+auctionColumns+` FROM auctions WHERE `+strings.Join(conditions, " OR "), args...)
+	if err != nil {
+		logger.Error("Error searching auctions", err)
+		return nil, internal_error.NewInternalServerError("Error searching auctions")
+	}
+	defer rows.Close()
+
+	var auctionsEntity []auction_entity.Auction
+	for rows.Next() {
+		auctionEntity, err := scanAuction(rows)
+		if err != nil {
+			logger.Error("Error decoding auctions", err)
+			return nil, internal_error.NewInternalServerError("Error decoding auctions")
+		}
+
+		auctionsEntity = append(auctionsEntity, *auctionEntity)
+	}
+
+	return auction_entity.RankSearchResults(auctionsEntity, language, text), nil
+}
+
+func (ar *AuctionRepository) PauseAuction(
+	ctx context.Context,
+	auctionId string,
+	pausedAt time.Time) *internal_error.InternalError {
+	result, err := ar.Database.ExecContext(ctx,
+		"UPDATE auctions SET status = ?, paused_at = ?, updated_at = ? WHERE id = ? AND status = ?",
+		auction_entity.Paused, pausedAt.UnixMilli(), clock.Now().UnixMilli(), auctionId, auction_entity.Active)
+	if err != nil {
+		logger.Error("Error trying to pause auction", err)
+		return internal_error.NewInternalServerError("Error trying to pause auction")
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("No active auction found with this id = %s", auctionId))
+	}
+
+	ar.scheduler.Cancel(auctionId)
+
+	if ar.publisher != nil {
+		ar.publisher.Publish(ctx, event_entity.NewEvent(event_entity.AuctionPaused, auctionId, nil))
+	}
+
+	return nil
+}
+
+// ResumeAuction schedules the completion the pause cancelled again, at the
+// end moved by the pause.
+func (ar *AuctionRepository) ResumeAuction(
+	ctx context.Context,
+	auctionId string,
+	resumedAt time.Time) (time.Time, *internal_error.InternalError) {
+	var endsAt int64
+	err := ar.Database.QueryRowContext(ctx,
+		`UPDATE auctions SET status = ?, ends_at = ends_at + MAX(? - paused_at, 0), paused_at = 0, updated_at = ?
+			WHERE id = ? AND status = ? RETURNING ends_at`,
+		auction_entity.Active, resumedAt.UnixMilli(), clock.Now().UnixMilli(), auctionId, auction_entity.Paused).
+		Scan(&endsAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, internal_error.NewNotFoundError(
+			fmt.Sprintf("No paused auction found with this id = %s", auctionId))
+	}
+	if err != nil {
+		logger.Error("Error trying to resume auction", err)
+		return time.Time{}, internal_error.NewInternalServerError("Error trying to resume auction")
+	}
+
+	ar.scheduleCompletion(auctionId, time.UnixMilli(endsAt))
+
+	if ar.publisher != nil {
+		ar.publisher.Publish(ctx, event_entity.NewEvent(event_entity.AuctionResumed, auctionId,
+			map[string]interface{}{"ends_at": time.UnixMilli(endsAt)}))
+	}
+
+	return time.UnixMilli(endsAt), nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanAuction(row scanner) (*auction_entity.Auction, error) {
+	var auctionEntity auction_entity.Auction
+	var grading, attributes, terms, descriptions, invitees string
+	var timestamp, updatedAt, endsAt, biddingOpenedAt, startsAt, pausedAt, publicAt int64
+
+	if err := row.Scan(
+		&auctionEntity.Id,
+		&auctionEntity.SellerId,
+		&auctionEntity.ProductId,
+		&auctionEntity.ProductName,
+		&auctionEntity.Category,
+		&auctionEntity.Description,
+		&auctionEntity.Condition,
+		&grading,
+		&attributes,
+		&auctionEntity.Status,
+		&timestamp,
+		&updatedAt,
+		&endsAt,
+		&auctionEntity.WinnerBidId,
+		&auctionEntity.BidderVisibility,
+		&terms,
+		&auctionEntity.RecurringAuctionId,
+		&auctionEntity.SeriesId,
+		&auctionEntity.LotNumber,
+		&auctionEntity.Live,
+		&biddingOpenedAt,
+		&startsAt,
+		&auctionEntity.ReservePrice,
+		&auctionEntity.MinIncrement,
+		&auctionEntity.Sealed,
+		&auctionEntity.BuyNowPrice,
+		&pausedAt,
+		&descriptions,
+		&auctionEntity.RegistrationRequired,
+		&publicAt,
+		&invitees,
+		&auctionEntity.HeatScore,
+		&auctionEntity.RelistedFrom,
+		&auctionEntity.StartingPrice,
+		&auctionEntity.Views,
+		&auctionEntity.Quantity,
+		&auctionEntity.UniformPrice,
+		&auctionEntity.EstimateLow,
+		&auctionEntity.EstimateHigh); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(grading), &auctionEntity.Grading); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(attributes), &auctionEntity.Attributes); err != nil {
+		return nil, err
+	}
+	if terms != "" {
+		if err := json.Unmarshal([]byte(terms), &auctionEntity.Terms); err != nil {
+			return nil, err
+		}
+	}
+	if err := json.Unmarshal([]byte(descriptions), &auctionEntity.Descriptions); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(invitees), &auctionEntity.Invitees); err != nil {
+		return nil, err
+	}
+	auctionEntity.Timestamp = time.Unix(timestamp, 0)
+	auctionEntity.UpdatedAt = time.UnixMilli(updatedAt)
+	auctionEntity.EndsAt = time.UnixMilli(endsAt)
+	auctionEntity.BiddingOpenedAt = fromUnixMilli(biddingOpenedAt)
+	auctionEntity.StartsAt = fromUnixMilli(startsAt)
+	auctionEntity.PausedAt = fromUnixMilli(pausedAt)
+	auctionEntity.PublicAt = fromUnixMilli(publicAt)
+
+	return &auctionEntity, nil
+}
+
+func getAuctionInterval() time.Duration {
+	auctionInterval := os.Getenv("AUCTION_INTERVAL")
+	duration, err := time.ParseDuration(auctionInterval)
+	if err != nil {
+		logger.Error("Error parsing auction interval", err)
+		return 1 * time.Minute
+	}
+	return duration
+}
+
+// getCompletionGrace reads AUCTION_COMPLETION_GRACE, how long completion
+// waits past the end for bids accepted before it to be flushed.
+func getCompletionGrace() time.Duration {
+	completionGrace := os.Getenv("AUCTION_COMPLETION_GRACE")
+	duration, err := time.ParseDuration(completionGrace)
+	if err != nil {
+		return 2 * time.Second
+	}
+	return duration
+}
+
+func (ar *AuctionRepository) StreamAuctions(
+	ctx context.Context,
+	status auction_entity.AuctionStatus,
+	category string,
+	handle func(auction_entity.Auction) error) *internal_error.InternalError {
+	var conditions []string
+	var args []interface{}
+
+	if status != 0 {
+		conditions = append(conditions, "status = ?")
+		args = append(args, status)
+	}
+
+	if category != "" {
+		conditions = append(conditions, "category = ?")
+		args = append(args, category)
+	}
+
+	query := `SELECT ` + auctionColumns + ` FROM auctions`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	rows, err := ar.Database.QueryContext(ctx, query, args...)
+	if err != nil {
+		logger.Error("Error streaming auctions", err)
+		return internal_error.NewInternalServerError("Error streaming auctions")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		auctionEntity, err := scanAuction(rows)
+		if err != nil {
+			logger.Error("Error decoding auctions", err)
+			return internal_error.NewInternalServerError("Error streaming auctions")
+		}
+
+		if err := handle(*auctionEntity); err != nil {
+			logger.Error("Error streaming auctions", err)
+			return internal_error.NewInternalServerError("Error streaming auctions")
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		logger.Error("Error streaming auctions", err)
+		return internal_error.NewInternalServerError("Error streaming auctions")
+	}
+
+	return nil
+}
+
+func (ar *AuctionRepository) FindEstimatedAuctions(
+	ctx context.Context,
+	sellerId, category string) ([]auction_entity.Auction, *internal_error.InternalError) {
+	query := `SELECT ` + auctionColumns + ` FROM auctions WHERE status IN (?, ?) AND estimate_high > 0`
+	args := []interface{}{auction_entity.Completed, auction_entity.ClosedNoSale}
+	if sellerId != "" {
+		query += " AND seller_id = ?"
+		args = append(args, sellerId)
+	}
+	if category != "" {
+		query += " AND category = ?"
+		args = append(args, category)
+	}
+
+	rows, err := ar.Database.QueryContext(ctx, query, args...)
+	if err != nil {
+		logger.Error("Error finding estimated auctions", err)
+		return nil, internal_error.NewInternalServerError("Error finding estimated auctions")
+	}
+	defer rows.Close()
+
+	var auctionsEntity []auction_entity.Auction
+	for rows.Next() {
+		auctionEntity, err := scanAuction(rows)
+		if err != nil {
+			logger.Error("Error decoding estimated auctions", err)
+			return nil, internal_error.NewInternalServerError("Error decoding estimated auctions")
+		}
+
+		auctionsEntity = append(auctionsEntity, *auctionEntity)
+	}
+
+	return auctionsEntity, nil
+}
+
+func (ar *AuctionRepository) FindSellerAuctions(
+	ctx context.Context,
+	sellerId string,
+	status auction_entity.AuctionStatus,
+	offset, limit int) ([]auction_entity.Auction, int, *internal_error.InternalError) {
+	var total int
+	if err := ar.Database.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM auctions WHERE seller_id = ? AND status = ?`,
+		sellerId, status).Scan(&total); err != nil {
+		logger.Error("Error counting seller auctions", err)
+		return nil, 0, internal_error.NewInternalServerError("Error finding seller auctions")
+	}
+
+	rows, err := ar.Database.QueryContext(ctx,
+		`SELECT `+auctionColumns+` FROM auctions WHERE seller_id = ? AND status = ?
+			ORDER BY timestamp DESC, id LIMIT ? OFFSET ?`,
+		sellerId, status, limit, offset)
+	if err != nil {
+		logger.Error("Error finding seller auctions", err)
+		return nil, 0, internal_error.NewInternalServerError("Error finding seller auctions")
+	}
+	defer rows.Close()
+
+	var auctionsEntity []auction_entity.Auction
+	for rows.Next() {
+		auctionEntity, err := scanAuction(rows)
+		if err != nil {
+			logger.Error("Error decoding seller auctions", err)
+			return nil, 0, internal_error.NewInternalServerError("Error decoding seller auctions")
+		}
+
+		auctionsEntity = append(auctionsEntity, *auctionEntity)
+	}
+
+	return auctionsEntity, total, nil
+}
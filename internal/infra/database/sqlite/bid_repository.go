@@ -0,0 +1,313 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/entity/event_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"strings"
+	"time"
+)
+
+const bidColumns = `id, user_id, auction_id, amount, source,
+	client_ip, device_fingerprint, timestamp, placed_by, retracted_at`
+
+type BidRepository struct {
+	Database          *sql.DB
+	AuctionRepository *AuctionRepository
+	publisher         event_entity.Publisher
+}
+
+// NewBidRepository publishes every stored bid to publisher, which may be nil.
+func NewBidRepository(
+	database *sql.DB, auctionRepository *AuctionRepository, publisher event_entity.Publisher) *BidRepository {
+	return &BidRepository{
+		Database:          database,
+		AuctionRepository: auctionRepository,
+		publisher:         publisher,
+	}
+}
+
+func (bd *BidRepository) CreateBid(
+	ctx context.Context,
+	bidEntities []bid_entity.Bid) ([]bid_entity.Bid, *internal_error.InternalError) {
+	var closed []bid_entity.Bid
+	for _, bid := range bidEntities {
+		auctionEntity, err := bd.AuctionRepository.FindAuctionById(ctx, bid.AuctionId)
+		if err != nil {
+			logger.Error("Error trying to find auction by id", err)
+			continue
+		}
+
+		if auctionEntity.Status.Ended() || bid.Timestamp.After(auctionEntity.EndsAt) {
+			closed = append(closed, bid)
+			continue
+		}
+
+		if _, err := bd.Database.ExecContext(ctx,
+			`INSERT INTO bids (`+bidColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, 0)`,
+			bid.Id, bid.UserId, bid.AuctionId, bid.Amount, bid.Source,
+			bid.Client.Ip, bid.Client.DeviceFingerprint, bid.Timestamp.UnixMicro(), bid.PlacedBy); err != nil {
+			logger.Error("Error trying to insert bid", err)
+			continue
+		}
+
+		if bd.publisher != nil {
+			bd.publisher.Publish(ctx, bid.PlacedEvent())
+		}
+	}
+
+	return closed, nil
+}
+
+func (bd *BidRepository) FindBidByAuctionId(
+	ctx context.Context, auctionId string) ([]bid_entity.Bid, *internal_error.InternalError) {
+	rows, err := bd.Database.QueryContext(ctx,
+		`SELECT `+bidColumns+` FROM bids WHERE auction_id = ? AND retracted_at = 0`, auctionId)
+	if err != nil {
+		logger.Error(
+			fmt.Sprintf("Error trying to find bids by auctionId %s", auctionId), err)
+		return nil, internal_error.NewInternalServerError(
+			fmt.Sprintf("Error trying to find bids by auctionId %s", auctionId))
+	}
+	defer rows.Close()
+
+	var bidEntities []bid_entity.Bid
+	for rows.Next() {
+		bidEntity, err := scanBid(rows)
+		if err != nil {
+			logger.Error(
+				fmt.Sprintf("Error trying to find bids by auctionId %s", auctionId), err)
+			return nil, internal_error.NewInternalServerError(
+				fmt.Sprintf("Error trying to find bids by auctionId %s", auctionId))
+		}
+
+		bidEntities = append(bidEntities, *bidEntity)
+	}
+
+	return bidEntities, nil
+}
+
+func (bd *BidRepository) FindWinningBidByAuctionId(
+	ctx context.Context, auctionId string) (*bid_entity.Bid, *internal_error.InternalError) {
+	row := bd.Database.QueryRowContext(ctx,
+		`SELECT `+bidColumns+` FROM bids WHERE auction_id = ? AND retracted_at = 0
+			ORDER BY amount DESC, timestamp LIMIT 1`, auctionId)
+
+	bidEntity, err := scanBid(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, internal_error.NewNotFoundError(
+				fmt.Sprintf("No bids found for auction = %s", auctionId))
+		}
+
+		logger.Error("Error trying to find the auction winner", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find the auction winner")
+	}
+
+	return bidEntity, nil
+}
+
+func (bd *BidRepository) FindBidById(
+	ctx context.Context, bidId string) (*bid_entity.Bid, *internal_error.InternalError) {
+	row := bd.Database.QueryRowContext(ctx,
+		`SELECT `+bidColumns+` FROM bids WHERE id = ?`, bidId)
+
+	bidEntity, err := scanBid(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, internal_error.NewNotFoundError(
+				fmt.Sprintf("Bid not found with this id = %s", bidId))
+		}
+
+		logger.Error(fmt.Sprintf("Error trying to find bid by id = %s", bidId), err)
+		return nil, internal_error.NewInternalServerError("Error trying to find bid by id")
+	}
+
+	return bidEntity, nil
+}
+
+func (bd *BidRepository) RetractBid(
+	ctx context.Context, bidId string, retractedAt time.Time) *internal_error.InternalError {
+	result, err := bd.Database.ExecContext(ctx,
+		`UPDATE bids SET retracted_at = ? WHERE id = ? AND retracted_at = 0`, retractedAt.Unix(), bidId)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Error trying to retract bid = %s", bidId), err)
+		return internal_error.NewInternalServerError("Error trying to retract bid")
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("No standing bid found with this id = %s", bidId))
+	}
+
+	if bd.publisher != nil {
+		bd.publishRetraction(ctx, bidId)
+	}
+
+	return nil
+}
+
+// publishRetraction looks the retracted bid and the new highest bid up for
+// the event, only logging a failed lookup.
+func (bd *BidRepository) publishRetraction(ctx context.Context, bidId string) {
+	bid, err := bd.FindBidById(ctx, bidId)
+	if err != nil {
+		logger.Error("Error trying to publish bid retraction", err)
+		return
+	}
+
+	highest, err := bd.FindWinningBidByAuctionId(ctx, bid.AuctionId)
+	if err != nil && err.Err != "not_found" {
+		logger.Error("Error trying to publish bid retraction", err)
+		return
+	}
+
+	bd.publisher.Publish(ctx, bid.RetractedEvent(highest))
+}
+
+func (bd *BidRepository) HasBids(
+	ctx context.Context, auctionId string) (bool, *internal_error.InternalError) {
+	var hasBids bool
+	if err := bd.Database.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM bids WHERE auction_id = ? AND retracted_at = 0)`, auctionId).Scan(&hasBids); err != nil {
+		logger.Error("Error trying to check auction bids", err)
+		return false, internal_error.NewInternalServerError("Error trying to check auction bids")
+	}
+
+	return hasBids, nil
+}
+
+func (bd *BidRepository) FindUserAuctionBids(
+	ctx context.Context, userId string) (map[string][]bid_entity.Bid, *internal_error.InternalError) {
+	rows, err := bd.Database.QueryContext(ctx,
+		`SELECT `+bidColumns+` FROM (
+			SELECT *, ROW_NUMBER() OVER (PARTITION BY auction_id, user_id ORDER BY amount DESC, timestamp) AS position
+			FROM bids WHERE retracted_at = 0 AND auction_id IN (
+				SELECT auction_id FROM bids WHERE user_id = ? AND retracted_at = 0)
+		) WHERE position = 1`,
+		userId)
+	if err != nil {
+		logger.Error("Error trying to find the user auction bids", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find the user auction bids")
+	}
+	defer rows.Close()
+
+	auctionBids := make(map[string][]bid_entity.Bid)
+	for rows.Next() {
+		bidEntity, err := scanBid(rows)
+		if err != nil {
+			logger.Error("Error trying to decode the user auction bids", err)
+			return nil, internal_error.NewInternalServerError("Error trying to find the user auction bids")
+		}
+
+		auctionBids[bidEntity.AuctionId] = append(auctionBids[bidEntity.AuctionId], *bidEntity)
+	}
+
+	return auctionBids, nil
+}
+
+func (bd *BidRepository) FindWinningBidsByAuctionIds(
+	ctx context.Context, auctionIds []string) (map[string]bid_entity.Bid, *internal_error.InternalError) {
+	winningBids := make(map[string]bid_entity.Bid)
+	if len(auctionIds) == 0 {
+		return winningBids, nil
+	}
+
+	args := make([]interface{}, len(auctionIds))
+	for i, auctionId := range auctionIds {
+		args[i] = auctionId
+	}
+
+	rows, err := bd.Database.QueryContext(ctx,
+		`SELECT `+bidColumns+` FROM (
+			SELECT *, ROW_NUMBER() OVER (PARTITION BY auction_id ORDER BY amount DESC, timestamp) AS position
+			FROM bids WHERE retracted_at = 0 AND auction_id IN (?`+strings.Repeat(", ?", len(auctionIds)-1)+`)
+		) WHERE position = 1`,
+		args...)
+	if err != nil {
+		logger.Error("Error trying to find the auction winners", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find the auction winners")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		bidEntity, err := scanBid(rows)
+		if err != nil {
+			logger.Error("Error trying to decode the auction winners", err)
+			return nil, internal_error.NewInternalServerError("Error trying to find the auction winners")
+		}
+
+		winningBids[bidEntity.AuctionId] = *bidEntity
+	}
+
+	return winningBids, nil
+}
+
+func scanBid(row scanner) (*bid_entity.Bid, error) {
+	var bidEntity bid_entity.Bid
+	var timestamp, retractedAt int64
+
+	if err := row.Scan(
+		&bidEntity.Id,
+		&bidEntity.UserId,
+		&bidEntity.AuctionId,
+		&bidEntity.Amount,
+		&bidEntity.Source,
+		&bidEntity.Client.Ip,
+		&bidEntity.Client.DeviceFingerprint,
+		&timestamp,
+		&bidEntity.PlacedBy,
+		&retractedAt); err != nil {
+		return nil, err
+	}
+	bidEntity.Timestamp = time.UnixMicro(timestamp)
+	if retractedAt != 0 {
+		bidEntity.RetractedAt = time.Unix(retractedAt, 0)
+	}
+
+	return &bidEntity, nil
+}
+
+func (bd *BidRepository) StreamBids(
+	ctx context.Context,
+	auctionId string,
+	handle func(bid_entity.Bid) error) *internal_error.InternalError {
+	query := `SELECT ` + bidColumns + ` FROM bids`
+	var args []interface{}
+
+	if auctionId != "" {
+		query += ` WHERE auction_id = ?`
+		args = append(args, auctionId)
+	}
+
+	rows, err := bd.Database.QueryContext(ctx, query, args...)
+	if err != nil {
+		logger.Error("Error streaming bids", err)
+		return internal_error.NewInternalServerError("Error streaming bids")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		bidEntity, err := scanBid(rows)
+		if err != nil {
+			logger.Error("Error decoding bids", err)
+			return internal_error.NewInternalServerError("Error streaming bids")
+		}
+
+		if err := handle(*bidEntity); err != nil {
+			logger.Error("Error streaming bids", err)
+			return internal_error.NewInternalServerError("Error streaming bids")
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		logger.Error("Error streaming bids", err)
+		return internal_error.NewInternalServerError("Error streaming bids")
+	}
+
+	return nil
+}
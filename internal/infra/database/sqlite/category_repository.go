@@ -0,0 +1,174 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/category_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+)
+
+type CategorySchemaRepository struct {
+	Database *sql.DB
+}
+
+func NewCategorySchemaRepository(database *sql.DB) *CategorySchemaRepository {
+	return &CategorySchemaRepository{
+		Database: database,
+	}
+}
+
+func (cr *CategorySchemaRepository) UpsertCategorySchema(
+	ctx context.Context,
+	schema *category_entity.CategorySchema) *internal_error.InternalError {
+	attributes, _ := json.Marshal(schema.Attributes)
+	labels, _ := json.Marshal(schema.Labels)
+
+	_, err := cr.Database.ExecContext(ctx,
+		`INSERT INTO category_schemas (category, attributes, labels) VALUES (?, ?, ?)
+			ON CONFLICT (category) DO UPDATE SET attributes = excluded.attributes, labels = excluded.labels`,
+		schema.Category, string(attributes), string(labels))
+	if err != nil {
+		logger.Error("Error trying to upsert category schema", err)
+		return internal_error.NewInternalServerError("Error trying to upsert category schema")
+	}
+
+	return nil
+}
+
+func (cr *CategorySchemaRepository) FindCategorySchema(
+	ctx context.Context, category string) (*category_entity.CategorySchema, *internal_error.InternalError) {
+	var attributes, labels string
+
+	err := cr.Database.QueryRowContext(ctx,
+		`SELECT attributes, labels FROM category_schemas WHERE category = ?`, category).Scan(&attributes, &labels)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, internal_error.NewNotFoundError(
+				fmt.Sprintf("Category schema not found for category = %s", category))
+		}
+
+		logger.Error(fmt.Sprintf("Error trying to find category schema for category = %s", category), err)
+		return nil, internal_error.NewInternalServerError("Error trying to find category schema")
+	}
+
+	schema := &category_entity.CategorySchema{
+		Category: category,
+	}
+	if err := json.Unmarshal([]byte(attributes), &schema.Attributes); err != nil {
+		logger.Error(fmt.Sprintf("Error trying to decode category schema for category = %s", category), err)
+		return nil, internal_error.NewInternalServerError("Error trying to find category schema")
+	}
+	if err := json.Unmarshal([]byte(labels), &schema.Labels); err != nil {
+		logger.Error(fmt.Sprintf("Error trying to decode category schema for category = %s", category), err)
+		return nil, internal_error.NewInternalServerError("Error trying to find category schema")
+	}
+
+	return schema, nil
+}
+
+type CategoryRepository struct {
+	Database *sql.DB
+}
+
+func NewCategoryRepository(database *sql.DB) *CategoryRepository {
+	return &CategoryRepository{
+		Database: database,
+	}
+}
+
+func (cr *CategoryRepository) CreateCategory(
+	ctx context.Context, category *category_entity.Category) *internal_error.InternalError {
+	_, err := cr.Database.ExecContext(ctx,
+		`INSERT INTO categories (name, parent, timestamp) VALUES (?, ?, ?)`,
+		category.Name, category.Parent, category.Timestamp.Unix())
+	if err != nil {
+		logger.Error("Error trying to insert category", err)
+		return internal_error.NewInternalServerError("Error trying to insert category")
+	}
+
+	return nil
+}
+
+func (cr *CategoryRepository) UpdateCategory(
+	ctx context.Context, category *category_entity.Category) *internal_error.InternalError {
+	result, err := cr.Database.ExecContext(ctx,
+		`UPDATE categories SET parent = ? WHERE name = ?`, category.Parent, category.Name)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Error trying to update category = %s", category.Name), err)
+		return internal_error.NewInternalServerError("Error trying to update category")
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("Category not found with name = %s", category.Name))
+	}
+
+	return nil
+}
+
+func (cr *CategoryRepository) DeleteCategory(
+	ctx context.Context, name string) *internal_error.InternalError {
+	result, err := cr.Database.ExecContext(ctx, `DELETE FROM categories WHERE name = ?`, name)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Error trying to delete category = %s", name), err)
+		return internal_error.NewInternalServerError("Error trying to delete category")
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("Category not found with name = %s", name))
+	}
+
+	return nil
+}
+
+func (cr *CategoryRepository) FindCategory(
+	ctx context.Context, name string) (*category_entity.Category, *internal_error.InternalError) {
+	category := &category_entity.Category{Name: name}
+	var timestamp int64
+
+	err := cr.Database.QueryRowContext(ctx,
+		`SELECT parent, timestamp FROM categories WHERE name = ?`, name).Scan(&category.Parent, &timestamp)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, internal_error.NewNotFoundError(
+				fmt.Sprintf("Category not found with name = %s", name))
+		}
+
+		logger.Error(fmt.Sprintf("Error trying to find category = %s", name), err)
+		return nil, internal_error.NewInternalServerError("Error trying to find category")
+	}
+	category.Timestamp = time.Unix(timestamp, 0)
+
+	return category, nil
+}
+
+func (cr *CategoryRepository) FindCategories(
+	ctx context.Context) ([]category_entity.Category, *internal_error.InternalError) {
+	rows, err := cr.Database.QueryContext(ctx,
+		`SELECT name, parent, timestamp FROM categories ORDER BY name`)
+	if err != nil {
+		logger.Error("Error finding categories", err)
+		return nil, internal_error.NewInternalServerError("Error finding categories")
+	}
+	defer rows.Close()
+
+	categories := []category_entity.Category{}
+	for rows.Next() {
+		var category category_entity.Category
+		var timestamp int64
+
+		if err := rows.Scan(&category.Name, &category.Parent, &timestamp); err != nil {
+			logger.Error("Error decoding categories", err)
+			return nil, internal_error.NewInternalServerError("Error decoding categories")
+		}
+		category.Timestamp = time.Unix(timestamp, 0)
+
+		categories = append(categories, category)
+	}
+
+	return categories, nil
+}
@@ -0,0 +1,75 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/fraud_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+)
+
+type FraudFlagRepository struct {
+	Database *sql.DB
+}
+
+func NewFraudFlagRepository(database *sql.DB) *FraudFlagRepository {
+	return &FraudFlagRepository{
+		Database: database,
+	}
+}
+
+func (fr *FraudFlagRepository) UpsertFraudFlag(
+	ctx context.Context, flag *fraud_entity.FraudFlag) *internal_error.InternalError {
+	_, err := fr.Database.ExecContext(ctx,
+		`INSERT INTO fraud_flags (id, auction_id, user_id, signal, details, timestamp)
+			VALUES (?, ?, ?, ?, ?, ?)
+			ON CONFLICT (id) DO UPDATE SET details = excluded.details`,
+		flag.Id, flag.AuctionId, flag.UserId, flag.Signal, flag.Details, flag.Timestamp.Unix())
+	if err != nil {
+		logger.Error("Error trying to upsert fraud flag", err)
+		return internal_error.NewInternalServerError("Error trying to upsert fraud flag")
+	}
+
+	return nil
+}
+
+func (fr *FraudFlagRepository) FindFraudFlags(
+	ctx context.Context, auctionId string) ([]fraud_entity.FraudFlag, *internal_error.InternalError) {
+	query := `SELECT id, auction_id, user_id, signal, details, timestamp FROM fraud_flags`
+	var args []interface{}
+	if auctionId != "" {
+		query += ` WHERE auction_id = ?`
+		args = append(args, auctionId)
+	}
+	query += ` ORDER BY timestamp DESC`
+
+	rows, err := fr.Database.QueryContext(ctx, query, args...)
+	if err != nil {
+		logger.Error("Error finding fraud flags", err)
+		return nil, internal_error.NewInternalServerError("Error finding fraud flags")
+	}
+	defer rows.Close()
+
+	var flags []fraud_entity.FraudFlag
+	for rows.Next() {
+		var flag fraud_entity.FraudFlag
+		var timestamp int64
+
+		if err := rows.Scan(
+			&flag.Id,
+			&flag.AuctionId,
+			&flag.UserId,
+			&flag.Signal,
+			&flag.Details,
+			&timestamp); err != nil {
+			logger.Error("Error decoding fraud flags", err)
+			return nil, internal_error.NewInternalServerError("Error decoding fraud flags")
+		}
+		flag.Timestamp = time.Unix(timestamp, 0)
+
+		flags = append(flags, flag)
+	}
+
+	return flags, nil
+}
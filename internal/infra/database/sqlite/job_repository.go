@@ -0,0 +1,182 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/job_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"strings"
+	"time"
+)
+
+const jobColumns = `id, kind, payload, status, attempts, max_attempts, run_at, locked_until, last_error, timestamp, updated_at`
+
+type JobRepository struct {
+	Database *sql.DB
+}
+
+func NewJobRepository(database *sql.DB) *JobRepository {
+	return &JobRepository{
+		Database: database,
+	}
+}
+
+func (jr *JobRepository) CreateJob(
+	ctx context.Context, job *job_entity.Job) *internal_error.InternalError {
+	_, err := jr.Database.ExecContext(ctx,
+		`INSERT INTO jobs (`+jobColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		job.Id, job.Kind, string(job.Payload), job.Status, job.Attempts, job.MaxAttempts,
+		job.RunAt.UnixMilli(), 0, job.LastError, job.Timestamp.UnixMilli(), job.UpdatedAt.UnixMilli())
+	if err != nil {
+		logger.Error("Error trying to insert job", err)
+		return internal_error.NewInternalServerError("Error trying to insert job")
+	}
+
+	return nil
+}
+
+func (jr *JobRepository) ClaimJob(
+	ctx context.Context,
+	kinds []string,
+	now, lockedUntil time.Time) (*job_entity.Job, *internal_error.InternalError) {
+	if len(kinds) == 0 {
+		return nil, internal_error.NewNotFoundError("No job is due")
+	}
+
+	args := []interface{}{job_entity.Running, lockedUntil.UnixMilli(), now.UnixMilli()}
+	for _, kind := range kinds {
+		args = append(args, kind)
+	}
+	args = append(args,
+		job_entity.Pending, now.UnixMilli(), job_entity.Running, now.UnixMilli())
+
+	// The single statement keeps concurrent workers from claiming the same
+	// job.
+	job, err := scanJob(jr.Database.QueryRowContext(ctx,
+		`UPDATE jobs SET status = ?, locked_until = ?, updated_at = ?, attempts = attempts + 1
+			WHERE id = (
+				SELECT id FROM jobs
+				WHERE kind IN (?`+strings.Repeat(", ?", len(kinds)-1)+`)
+					AND ((status = ? AND run_at <= ?) OR (status = ? AND locked_until < ?))
+				ORDER BY run_at LIMIT 1)
+			RETURNING `+jobColumns,
+		args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, internal_error.NewNotFoundError("No job is due")
+		}
+
+		logger.Error("Error trying to claim job", err)
+		return nil, internal_error.NewInternalServerError("Error trying to claim job")
+	}
+
+	return job, nil
+}
+
+func (jr *JobRepository) UpdateJob(
+	ctx context.Context, job *job_entity.Job, lockedUntil time.Time) *internal_error.InternalError {
+	result, err := jr.Database.ExecContext(ctx,
+		`UPDATE jobs SET status = ?, attempts = ?, run_at = ?, locked_until = ?, last_error = ?, updated_at = ?
+			WHERE id = ? AND locked_until = ?`,
+		job.Status, job.Attempts, job.RunAt.UnixMilli(), job.LockedUntil.UnixMilli(),
+		job.LastError, job.UpdatedAt.UnixMilli(), job.Id, lockedUntil.UnixMilli())
+	if err != nil {
+		logger.Error("Error trying to update job", err)
+		return internal_error.NewInternalServerError("Error trying to update job")
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("Job not found with this id = %s", job.Id))
+	}
+
+	return nil
+}
+
+func (jr *JobRepository) FindJobById(
+	ctx context.Context, id string) (*job_entity.Job, *internal_error.InternalError) {
+	job, err := scanJob(jr.Database.QueryRowContext(ctx,
+		`SELECT `+jobColumns+` FROM jobs WHERE id = ?`, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, internal_error.NewNotFoundError(
+				fmt.Sprintf("Job not found with this id = %s", id))
+		}
+
+		logger.Error("Error trying to find job by id", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find job by id")
+	}
+
+	return job, nil
+}
+
+func (jr *JobRepository) FindJobs(
+	ctx context.Context,
+	status job_entity.JobStatus,
+	kind string,
+	limit int) ([]job_entity.Job, *internal_error.InternalError) {
+	query := `SELECT ` + jobColumns + ` FROM jobs WHERE 1 = 1`
+	var args []interface{}
+	if status != "" {
+		query += ` AND status = ?`
+		args = append(args, status)
+	}
+	if kind != "" {
+		query += ` AND kind = ?`
+		args = append(args, kind)
+	}
+	query += ` ORDER BY timestamp DESC LIMIT ?`
+	args = append(args, limit)
+
+	rows, err := jr.Database.QueryContext(ctx, query, args...)
+	if err != nil {
+		logger.Error("Error finding jobs", err)
+		return nil, internal_error.NewInternalServerError("Error finding jobs")
+	}
+	defer rows.Close()
+
+	var jobs []job_entity.Job
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			logger.Error("Error decoding jobs", err)
+			return nil, internal_error.NewInternalServerError("Error decoding jobs")
+		}
+
+		jobs = append(jobs, *job)
+	}
+
+	return jobs, nil
+}
+
+func scanJob(row scanner) (*job_entity.Job, error) {
+	var job job_entity.Job
+	var payload string
+	var runAt, lockedUntil, timestamp, updatedAt int64
+
+	if err := row.Scan(
+		&job.Id,
+		&job.Kind,
+		&payload,
+		&job.Status,
+		&job.Attempts,
+		&job.MaxAttempts,
+		&runAt,
+		&lockedUntil,
+		&job.LastError,
+		&timestamp,
+		&updatedAt); err != nil {
+		return nil, err
+	}
+
+	job.Payload = []byte(payload)
+	job.RunAt = time.UnixMilli(runAt)
+	job.LockedUntil = time.UnixMilli(lockedUntil)
+	job.Timestamp = time.UnixMilli(timestamp)
+	job.UpdatedAt = time.UnixMilli(updatedAt)
+
+	return &job, nil
+}
@@ -0,0 +1,134 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/notification_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"strings"
+	"time"
+)
+
+const notificationColumns = `id, user_id, auction_id, kind, message, timestamp, read_at, archived_at`
+
+type NotificationRepository struct {
+	Database *sql.DB
+}
+
+func NewNotificationRepository(database *sql.DB) *NotificationRepository {
+	return &NotificationRepository{
+		Database: database,
+	}
+}
+
+func (nr *NotificationRepository) CreateNotification(
+	ctx context.Context, notification *notification_entity.Notification) *internal_error.InternalError {
+	_, err := nr.Database.ExecContext(ctx,
+		`INSERT INTO notifications (id, user_id, auction_id, kind, message, timestamp) VALUES (?, ?, ?, ?, ?, ?)`,
+		notification.Id, notification.UserId, notification.AuctionId, notification.Kind, notification.Message,
+		notification.Timestamp.UnixMilli())
+	if err != nil {
+		logger.Error("Error trying to insert notification", err)
+		return internal_error.NewInternalServerError("Error trying to insert notification")
+	}
+
+	return nil
+}
+
+func (nr *NotificationRepository) FindNotifications(
+	ctx context.Context,
+	userId string,
+	archived bool) ([]notification_entity.Notification, *internal_error.InternalError) {
+	query := `SELECT ` + notificationColumns + ` FROM notifications WHERE user_id = ? AND archived_at = 0`
+	if archived {
+		query = `SELECT ` + notificationColumns + ` FROM notifications WHERE user_id = ? AND archived_at != 0`
+	}
+
+	rows, err := nr.Database.QueryContext(ctx, query+` ORDER BY timestamp DESC`, userId)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Error finding notifications of user = %s", userId), err)
+		return nil, internal_error.NewInternalServerError("Error finding notifications")
+	}
+	defer rows.Close()
+
+	notifications := []notification_entity.Notification{}
+	for rows.Next() {
+		notification, err := scanNotification(rows)
+		if err != nil {
+			logger.Error("Error decoding notifications", err)
+			return nil, internal_error.NewInternalServerError("Error decoding notifications")
+		}
+
+		notifications = append(notifications, *notification)
+	}
+
+	return notifications, nil
+}
+
+func (nr *NotificationRepository) MarkNotificationsRead(
+	ctx context.Context,
+	userId string,
+	ids []string,
+	readAt time.Time) (int64, *internal_error.InternalError) {
+	query := `UPDATE notifications SET read_at = ? WHERE user_id = ? AND read_at = 0`
+	args := []interface{}{readAt.UnixMilli(), userId}
+	if len(ids) > 0 {
+		query += ` AND id IN (?` + strings.Repeat(", ?", len(ids)-1) + `)`
+		for _, id := range ids {
+			args = append(args, id)
+		}
+	}
+
+	result, err := nr.Database.ExecContext(ctx, query, args...)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Error trying to mark notifications of user = %s as read", userId), err)
+		return 0, internal_error.NewInternalServerError("Error trying to mark notifications as read")
+	}
+
+	marked, _ := result.RowsAffected()
+	return marked, nil
+}
+
+func (nr *NotificationRepository) ArchiveNotifications(
+	ctx context.Context,
+	cutoff, archivedAt time.Time) (int64, *internal_error.InternalError) {
+	result, err := nr.Database.ExecContext(ctx,
+		`UPDATE notifications SET archived_at = ? WHERE timestamp < ? AND archived_at = 0`,
+		archivedAt.UnixMilli(), cutoff.UnixMilli())
+	if err != nil {
+		logger.Error("Error trying to archive notifications", err)
+		return 0, internal_error.NewInternalServerError("Error trying to archive notifications")
+	}
+
+	archived, _ := result.RowsAffected()
+	return archived, nil
+}
+
+func scanNotification(row scanner) (*notification_entity.Notification, error) {
+	var notification notification_entity.Notification
+	var timestamp, readAt, archivedAt int64
+
+	if err := row.Scan(
+		&notification.Id,
+		&notification.UserId,
+		&notification.AuctionId,
+		&notification.Kind,
+		&notification.Message,
+		&timestamp,
+		&readAt,
+		&archivedAt); err != nil {
+		return nil, err
+	}
+
+	notification.Timestamp = time.UnixMilli(timestamp)
+	if readAt != 0 {
+		notification.ReadAt = time.UnixMilli(readAt)
+	}
+	if archivedAt != 0 {
+		notification.ArchivedAt = time.UnixMilli(archivedAt)
+	}
+
+	return &notification, nil
+}
@@ -0,0 +1,127 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/offer_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+)
+
+const offerColumns = `id, auction_id, seller_id, user_id, amount, status, expires_at, responded_at, timestamp`
+
+type OfferRepository struct {
+	Database *sql.DB
+}
+
+func NewOfferRepository(database *sql.DB) *OfferRepository {
+	return &OfferRepository{
+		Database: database,
+	}
+}
+
+func (or *OfferRepository) CreateOffer(
+	ctx context.Context, offer *offer_entity.Offer) *internal_error.InternalError {
+	_, err := or.Database.ExecContext(ctx,
+		`INSERT INTO second_chance_offers (`+offerColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		offer.Id, offer.AuctionId, offer.SellerId, offer.UserId, offer.Amount,
+		offer.Status, offer.ExpiresAt.UnixMilli(), 0, offer.Timestamp.UnixMilli())
+	if err != nil {
+		logger.Error("Error trying to insert offer", err)
+		return internal_error.NewInternalServerError("Error trying to insert offer")
+	}
+
+	return nil
+}
+
+func (or *OfferRepository) FindOfferById(
+	ctx context.Context, id string) (*offer_entity.Offer, *internal_error.InternalError) {
+	offer, err := scanOffer(or.Database.QueryRowContext(ctx,
+		`SELECT `+offerColumns+` FROM second_chance_offers WHERE id = ?`, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, internal_error.NewNotFoundError(
+				fmt.Sprintf("Offer not found with this id = %s", id))
+		}
+
+		logger.Error("Error trying to find offer by id", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find offer by id")
+	}
+
+	return offer, nil
+}
+
+func (or *OfferRepository) FindOffers(
+	ctx context.Context, auctionId string) ([]offer_entity.Offer, *internal_error.InternalError) {
+	rows, err := or.Database.QueryContext(ctx,
+		`SELECT `+offerColumns+` FROM second_chance_offers WHERE auction_id = ? ORDER BY timestamp`,
+		auctionId)
+	if err != nil {
+		logger.Error("Error finding offers", err)
+		return nil, internal_error.NewInternalServerError("Error finding offers")
+	}
+	defer rows.Close()
+
+	var offers []offer_entity.Offer
+	for rows.Next() {
+		offer, err := scanOffer(rows)
+		if err != nil {
+			logger.Error("Error decoding offers", err)
+			return nil, internal_error.NewInternalServerError("Error decoding offers")
+		}
+
+		offers = append(offers, *offer)
+	}
+
+	return offers, nil
+}
+
+func (or *OfferRepository) UpdateOfferStatus(
+	ctx context.Context,
+	id string,
+	status offer_entity.OfferStatus,
+	respondedAt time.Time) *internal_error.InternalError {
+	result, err := or.Database.ExecContext(ctx,
+		`UPDATE second_chance_offers SET status = ?, responded_at = ? WHERE id = ? AND status = ?`,
+		status, respondedAt.UnixMilli(), id, offer_entity.Pending)
+	if err != nil {
+		logger.Error("Error trying to update offer", err)
+		return internal_error.NewInternalServerError("Error trying to update offer")
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("Pending offer not found with this id = %s", id))
+	}
+
+	return nil
+}
+
+func scanOffer(row scanner) (*offer_entity.Offer, error) {
+	var offer offer_entity.Offer
+	var expiresAt, respondedAt, timestamp int64
+
+	if err := row.Scan(
+		&offer.Id,
+		&offer.AuctionId,
+		&offer.SellerId,
+		&offer.UserId,
+		&offer.Amount,
+		&offer.Status,
+		&expiresAt,
+		&respondedAt,
+		&timestamp); err != nil {
+		return nil, err
+	}
+
+	offer.ExpiresAt = time.UnixMilli(expiresAt)
+	if respondedAt != 0 {
+		offer.RespondedAt = time.UnixMilli(respondedAt)
+	}
+	offer.Timestamp = time.UnixMilli(timestamp)
+
+	return &offer, nil
+}
@@ -0,0 +1,184 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/product_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"strings"
+	"time"
+)
+
+const productColumns = `id, sku, name, description, category, attributes, images, timestamp`
+
+type ProductRepository struct {
+	Database *sql.DB
+}
+
+func NewProductRepository(database *sql.DB) *ProductRepository {
+	return &ProductRepository{
+		Database: database,
+	}
+}
+
+func (pr *ProductRepository) CreateProduct(
+	ctx context.Context,
+	productEntity *product_entity.Product) *internal_error.InternalError {
+	attributes, _ := json.Marshal(productEntity.Attributes)
+	images, _ := json.Marshal(productEntity.Images)
+
+	_, err := pr.Database.ExecContext(ctx,
+		`INSERT INTO products (`+productColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		productEntity.Id,
+		productEntity.Sku,
+		productEntity.Name,
+		productEntity.Description,
+		productEntity.Category,
+		string(attributes),
+		string(images),
+		productEntity.Timestamp.Unix())
+	if err != nil {
+		logger.Error("Error trying to insert product", err)
+		return internal_error.NewInternalServerError("Error trying to insert product")
+	}
+
+	return nil
+}
+
+func (pr *ProductRepository) FindProductById(
+	ctx context.Context, id string) (*product_entity.Product, *internal_error.InternalError) {
+	row := pr.Database.QueryRowContext(ctx,
+		`SELECT `+productColumns+` FROM products WHERE id = ?`, id)
+
+	productEntity, err := scanProduct(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, internal_error.NewNotFoundError(
+				fmt.Sprintf("Product not found with this id = %s", id))
+		}
+
+		logger.Error(fmt.Sprintf("Error trying to find product by id = %s", id), err)
+		return nil, internal_error.NewInternalServerError("Error trying to find product by id")
+	}
+
+	return productEntity, nil
+}
+
+func (pr *ProductRepository) FindProducts(
+	ctx context.Context,
+	category, sku string) ([]product_entity.Product, *internal_error.InternalError) {
+	var conditions []string
+	var args []interface{}
+
+	if category != "" {
+		conditions = append(conditions, "category = ?")
+		args = append(args, category)
+	}
+
+	if sku != "" {
+		conditions = append(conditions, "sku = ?")
+		args = append(args, sku)
+	}
+
+	query := `SELECT ` + productColumns + ` FROM products`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	rows, err := pr.Database.QueryContext(ctx, query, args...)
+	if err != nil {
+		logger.Error("Error finding products", err)
+		return nil, internal_error.NewInternalServerError("Error finding products")
+	}
+	defer rows.Close()
+
+	var productsEntity []product_entity.Product
+	for rows.Next() {
+		productEntity, err := scanProduct(rows)
+		if err != nil {
+			logger.Error("Error decoding products", err)
+			return nil, internal_error.NewInternalServerError("Error decoding products")
+		}
+
+		productsEntity = append(productsEntity, *productEntity)
+	}
+
+	return productsEntity, nil
+}
+
+func (pr *ProductRepository) UpdateProduct(
+	ctx context.Context,
+	productEntity *product_entity.Product) *internal_error.InternalError {
+	attributes, _ := json.Marshal(productEntity.Attributes)
+	images, _ := json.Marshal(productEntity.Images)
+
+	result, err := pr.Database.ExecContext(ctx,
+		`UPDATE products SET sku = ?, name = ?, description = ?, category = ?,
+			attributes = ?, images = ? WHERE id = ?`,
+		productEntity.Sku,
+		productEntity.Name,
+		productEntity.Description,
+		productEntity.Category,
+		string(attributes),
+		string(images),
+		productEntity.Id)
+	if err != nil {
+		logger.Error("Error trying to update product", err)
+		return internal_error.NewInternalServerError("Error trying to update product")
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("Product not found with this id = %s", productEntity.Id))
+	}
+
+	return nil
+}
+
+func (pr *ProductRepository) DeleteProduct(
+	ctx context.Context, id string) *internal_error.InternalError {
+	result, err := pr.Database.ExecContext(ctx, `DELETE FROM products WHERE id = ?`, id)
+	if err != nil {
+		logger.Error("Error trying to delete product", err)
+		return internal_error.NewInternalServerError("Error trying to delete product")
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("Product not found with this id = %s", id))
+	}
+
+	return nil
+}
+
+func scanProduct(row scanner) (*product_entity.Product, error) {
+	var productEntity product_entity.Product
+	var attributes, images string
+	var timestamp int64
+
+	if err := row.Scan(
+		&productEntity.Id,
+		&productEntity.Sku,
+		&productEntity.Name,
+		&productEntity.Description,
+		&productEntity.Category,
+		&attributes,
+		&images,
+		&timestamp); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(attributes), &productEntity.Attributes); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(images), &productEntity.Images); err != nil {
+		return nil, err
+	}
+	productEntity.Timestamp = time.Unix(timestamp, 0)
+
+	return &productEntity, nil
+}
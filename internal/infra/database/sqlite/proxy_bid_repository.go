@@ -0,0 +1,122 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+)
+
+const proxyBidColumns = `id, auction_id, user_id, max_amount, terms_version, status, timestamp`
+
+type ProxyBidRepository struct {
+	Database *sql.DB
+}
+
+func NewProxyBidRepository(database *sql.DB) *ProxyBidRepository {
+	return &ProxyBidRepository{
+		Database: database,
+	}
+}
+
+func (pr *ProxyBidRepository) UpsertProxyBid(
+	ctx context.Context, proxyBid *bid_entity.ProxyBid) *internal_error.InternalError {
+	_, err := pr.Database.ExecContext(ctx,
+		`INSERT INTO proxy_bids (`+proxyBidColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (auction_id, user_id) DO UPDATE
+			SET max_amount = excluded.max_amount, terms_version = excluded.terms_version,
+				status = excluded.status, timestamp = excluded.timestamp`,
+		proxyBid.Id, proxyBid.AuctionId, proxyBid.UserId, proxyBid.MaxAmount,
+		proxyBid.TermsVersion, proxyBid.Status, proxyBid.Timestamp.UnixMilli())
+	if err != nil {
+		logger.Error("Error trying to save proxy bid", err)
+		return internal_error.NewInternalServerError("Error trying to save proxy bid")
+	}
+
+	return nil
+}
+
+func (pr *ProxyBidRepository) FindProxyBid(
+	ctx context.Context, auctionId, userId string) (*bid_entity.ProxyBid, *internal_error.InternalError) {
+	proxyBid, err := scanProxyBid(pr.Database.QueryRowContext(ctx,
+		`SELECT `+proxyBidColumns+` FROM proxy_bids WHERE auction_id = ? AND user_id = ?`,
+		auctionId, userId))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, internal_error.NewNotFoundError(
+				fmt.Sprintf("Proxy bid not found for auction = %s and user = %s", auctionId, userId))
+		}
+
+		logger.Error("Error trying to find proxy bid", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find proxy bid")
+	}
+
+	return proxyBid, nil
+}
+
+func (pr *ProxyBidRepository) FindActiveProxyBids(
+	ctx context.Context, auctionId string) ([]bid_entity.ProxyBid, *internal_error.InternalError) {
+	rows, err := pr.Database.QueryContext(ctx,
+		`SELECT `+proxyBidColumns+` FROM proxy_bids WHERE auction_id = ? AND status = ?
+			ORDER BY max_amount DESC, timestamp`,
+		auctionId, bid_entity.ProxyActive)
+	if err != nil {
+		logger.Error("Error finding proxy bids", err)
+		return nil, internal_error.NewInternalServerError("Error finding proxy bids")
+	}
+	defer rows.Close()
+
+	var proxyBids []bid_entity.ProxyBid
+	for rows.Next() {
+		proxyBid, err := scanProxyBid(rows)
+		if err != nil {
+			logger.Error("Error decoding proxy bids", err)
+			return nil, internal_error.NewInternalServerError("Error decoding proxy bids")
+		}
+
+		proxyBids = append(proxyBids, *proxyBid)
+	}
+
+	return proxyBids, nil
+}
+
+func (pr *ProxyBidRepository) UpdateProxyBidStatus(
+	ctx context.Context, id string, status bid_entity.ProxyBidStatus) *internal_error.InternalError {
+	result, err := pr.Database.ExecContext(ctx,
+		`UPDATE proxy_bids SET status = ? WHERE id = ?`, status, id)
+	if err != nil {
+		logger.Error("Error trying to update proxy bid", err)
+		return internal_error.NewInternalServerError("Error trying to update proxy bid")
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("Proxy bid not found with this id = %s", id))
+	}
+
+	return nil
+}
+
+func scanProxyBid(row scanner) (*bid_entity.ProxyBid, error) {
+	var proxyBid bid_entity.ProxyBid
+	var timestamp int64
+
+	if err := row.Scan(
+		&proxyBid.Id,
+		&proxyBid.AuctionId,
+		&proxyBid.UserId,
+		&proxyBid.MaxAmount,
+		&proxyBid.TermsVersion,
+		&proxyBid.Status,
+		&timestamp); err != nil {
+		return nil, err
+	}
+
+	proxyBid.Timestamp = time.UnixMilli(timestamp)
+
+	return &proxyBid, nil
+}
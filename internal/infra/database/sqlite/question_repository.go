@@ -0,0 +1,127 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/question_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+)
+
+const questionColumns = `id, auction_id, user_id, text, answer, answered_at, status, timestamp`
+
+type QuestionRepository struct {
+	Database *sql.DB
+}
+
+func NewQuestionRepository(database *sql.DB) *QuestionRepository {
+	return &QuestionRepository{
+		Database: database,
+	}
+}
+
+func (qr *QuestionRepository) CreateQuestion(
+	ctx context.Context, question *question_entity.Question) *internal_error.InternalError {
+	_, err := qr.Database.ExecContext(ctx,
+		`INSERT INTO auction_questions (`+questionColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		question.Id, question.AuctionId, question.UserId, question.Text,
+		question.Answer, 0, question.Status, question.Timestamp.UnixMilli())
+	if err != nil {
+		logger.Error("Error trying to insert question", err)
+		return internal_error.NewInternalServerError("Error trying to insert question")
+	}
+
+	return nil
+}
+
+func (qr *QuestionRepository) FindQuestionById(
+	ctx context.Context, id string) (*question_entity.Question, *internal_error.InternalError) {
+	question, err := scanQuestion(qr.Database.QueryRowContext(ctx,
+		`SELECT `+questionColumns+` FROM auction_questions WHERE id = ?`, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, internal_error.NewNotFoundError(
+				fmt.Sprintf("Question not found with this id = %s", id))
+		}
+
+		logger.Error("Error trying to find question by id", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find question by id")
+	}
+
+	return question, nil
+}
+
+func (qr *QuestionRepository) FindQuestions(
+	ctx context.Context, auctionId string) ([]question_entity.Question, *internal_error.InternalError) {
+	rows, err := qr.Database.QueryContext(ctx,
+		`SELECT `+questionColumns+` FROM auction_questions WHERE auction_id = ? ORDER BY timestamp`,
+		auctionId)
+	if err != nil {
+		logger.Error("Error finding questions", err)
+		return nil, internal_error.NewInternalServerError("Error finding questions")
+	}
+	defer rows.Close()
+
+	var questions []question_entity.Question
+	for rows.Next() {
+		question, err := scanQuestion(rows)
+		if err != nil {
+			logger.Error("Error decoding questions", err)
+			return nil, internal_error.NewInternalServerError("Error decoding questions")
+		}
+
+		questions = append(questions, *question)
+	}
+
+	return questions, nil
+}
+
+func (qr *QuestionRepository) UpdateQuestion(
+	ctx context.Context, question *question_entity.Question) *internal_error.InternalError {
+	var answeredAt int64
+	if question.Answered() {
+		answeredAt = question.AnsweredAt.UnixMilli()
+	}
+
+	result, err := qr.Database.ExecContext(ctx,
+		`UPDATE auction_questions SET answer = ?, answered_at = ?, status = ? WHERE id = ?`,
+		question.Answer, answeredAt, question.Status, question.Id)
+	if err != nil {
+		logger.Error("Error trying to update question", err)
+		return internal_error.NewInternalServerError("Error trying to update question")
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("Question not found with this id = %s", question.Id))
+	}
+
+	return nil
+}
+
+func scanQuestion(row scanner) (*question_entity.Question, error) {
+	var question question_entity.Question
+	var answeredAt, timestamp int64
+
+	if err := row.Scan(
+		&question.Id,
+		&question.AuctionId,
+		&question.UserId,
+		&question.Text,
+		&question.Answer,
+		&answeredAt,
+		&question.Status,
+		&timestamp); err != nil {
+		return nil, err
+	}
+
+	if answeredAt != 0 {
+		question.AnsweredAt = time.UnixMilli(answeredAt)
+	}
+	question.Timestamp = time.UnixMilli(timestamp)
+
+	return &question, nil
+}
@@ -0,0 +1,177 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+)
+
+const recurringAuctionColumns = `id, seller_id, schedule, timezone, template, active,
+	next_run_at, last_run_at, created_at`
+
+type RecurringAuctionRepository struct {
+	Database *sql.DB
+}
+
+func NewRecurringAuctionRepository(database *sql.DB) *RecurringAuctionRepository {
+	return &RecurringAuctionRepository{
+		Database: database,
+	}
+}
+
+func (rr *RecurringAuctionRepository) CreateRecurringAuction(
+	ctx context.Context, recurringAuction *auction_entity.RecurringAuction) *internal_error.InternalError {
+	template, _ := json.Marshal(recurringAuction.Template)
+
+	_, err := rr.Database.ExecContext(ctx,
+		`INSERT INTO recurring_auctions (`+recurringAuctionColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		recurringAuction.Id,
+		recurringAuction.SellerId,
+		recurringAuction.Schedule,
+		recurringAuction.Timezone,
+		string(template),
+		recurringAuction.Active,
+		toUnixMilli(recurringAuction.NextRunAt),
+		toUnixMilli(recurringAuction.LastRunAt),
+		recurringAuction.CreatedAt.UnixMilli())
+	if err != nil {
+		logger.Error("Error trying to insert recurring auction", err)
+		return internal_error.NewInternalServerError("Error trying to insert recurring auction")
+	}
+
+	return nil
+}
+
+func (rr *RecurringAuctionRepository) FindRecurringAuctionById(
+	ctx context.Context, id string) (*auction_entity.RecurringAuction, *internal_error.InternalError) {
+	row := rr.Database.QueryRowContext(ctx,
+		`SELECT `+recurringAuctionColumns+` FROM recurring_auctions WHERE id = ?`, id)
+
+	recurringAuction, err := scanRecurringAuction(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, internal_error.NewNotFoundError(
+				fmt.Sprintf("Recurring auction not found with this id = %s", id))
+		}
+
+		logger.Error(fmt.Sprintf("Error trying to find recurring auction by id = %s", id), err)
+		return nil, internal_error.NewInternalServerError("Error trying to find recurring auction by id")
+	}
+
+	return recurringAuction, nil
+}
+
+func (rr *RecurringAuctionRepository) FindRecurringAuctions(
+	ctx context.Context, sellerId string) ([]auction_entity.RecurringAuction, *internal_error.InternalError) {
+	return rr.findRecurringAuctions(ctx,
+		`SELECT `+recurringAuctionColumns+` FROM recurring_auctions WHERE seller_id = ? ORDER BY created_at`,
+		sellerId)
+}
+
+func (rr *RecurringAuctionRepository) FindDueRecurringAuctions(
+	ctx context.Context, now time.Time) ([]auction_entity.RecurringAuction, *internal_error.InternalError) {
+	return rr.findRecurringAuctions(ctx,
+		`SELECT `+recurringAuctionColumns+` FROM recurring_auctions
+		WHERE active = 1 AND next_run_at > 0 AND next_run_at <= ?`,
+		now.UnixMilli())
+}
+
+func (rr *RecurringAuctionRepository) findRecurringAuctions(
+	ctx context.Context, query string, args ...interface{}) ([]auction_entity.RecurringAuction, *internal_error.InternalError) {
+	rows, err := rr.Database.QueryContext(ctx, query, args...)
+	if err != nil {
+		logger.Error("Error finding recurring auctions", err)
+		return nil, internal_error.NewInternalServerError("Error finding recurring auctions")
+	}
+	defer rows.Close()
+
+	var recurringAuctions []auction_entity.RecurringAuction
+	for rows.Next() {
+		recurringAuction, err := scanRecurringAuction(rows)
+		if err != nil {
+			logger.Error("Error decoding recurring auctions", err)
+			return nil, internal_error.NewInternalServerError("Error decoding recurring auctions")
+		}
+
+		recurringAuctions = append(recurringAuctions, *recurringAuction)
+	}
+
+	return recurringAuctions, nil
+}
+
+func (rr *RecurringAuctionRepository) UpdateRecurringAuctionRun(
+	ctx context.Context, id string, runAt, nextRunAt time.Time) *internal_error.InternalError {
+	return rr.update(ctx,
+		`UPDATE recurring_auctions SET next_run_at = ?, last_run_at = ? WHERE id = ? AND next_run_at = ?`,
+		toUnixMilli(nextRunAt), runAt.UnixMilli(), id, runAt.UnixMilli())
+}
+
+func (rr *RecurringAuctionRepository) UpdateRecurringAuctionActive(
+	ctx context.Context, id string, active bool, nextRunAt time.Time) *internal_error.InternalError {
+	return rr.update(ctx,
+		`UPDATE recurring_auctions SET active = ?, next_run_at = ? WHERE id = ?`,
+		active, toUnixMilli(nextRunAt), id)
+}
+
+func (rr *RecurringAuctionRepository) update(
+	ctx context.Context, query string, args ...interface{}) *internal_error.InternalError {
+	result, err := rr.Database.ExecContext(ctx, query, args...)
+	if err != nil {
+		logger.Error("Error trying to update recurring auction", err)
+		return internal_error.NewInternalServerError("Error trying to update recurring auction")
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return internal_error.NewNotFoundError("Recurring auction not found")
+	}
+
+	return nil
+}
+
+func scanRecurringAuction(row scanner) (*auction_entity.RecurringAuction, error) {
+	var recurringAuction auction_entity.RecurringAuction
+	var template string
+	var nextRunAt, lastRunAt, createdAt int64
+
+	if err := row.Scan(
+		&recurringAuction.Id,
+		&recurringAuction.SellerId,
+		&recurringAuction.Schedule,
+		&recurringAuction.Timezone,
+		&template,
+		&recurringAuction.Active,
+		&nextRunAt,
+		&lastRunAt,
+		&createdAt); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(template), &recurringAuction.Template); err != nil {
+		return nil, err
+	}
+	recurringAuction.NextRunAt = fromUnixMilli(nextRunAt)
+	recurringAuction.LastRunAt = fromUnixMilli(lastRunAt)
+	recurringAuction.CreatedAt = time.UnixMilli(createdAt)
+
+	return &recurringAuction, nil
+}
+
+// Unset times are stored as 0 rather than the zero time.
+func toUnixMilli(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMilli()
+}
+
+func fromUnixMilli(millis int64) time.Time {
+	if millis == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(millis)
+}
@@ -0,0 +1,129 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/configuration/clock"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+)
+
+const registrationColumns = `id, auction_id, user_id, deposit, status, timestamp, decided_by, decided_at`
+
+type RegistrationRepository struct {
+	Database *sql.DB
+}
+
+func NewRegistrationRepository(database *sql.DB) *RegistrationRepository {
+	return &RegistrationRepository{
+		Database: database,
+	}
+}
+
+func (rr *RegistrationRepository) UpsertRegistration(
+	ctx context.Context, registration *auction_entity.Registration) *internal_error.InternalError {
+	_, err := rr.Database.ExecContext(ctx,
+		`INSERT INTO registrations (`+registrationColumns+`) VALUES (?, ?, ?, ?, ?, ?, '', 0)
+			ON CONFLICT (auction_id, user_id) DO UPDATE
+			SET deposit = excluded.deposit, status = excluded.status, timestamp = excluded.timestamp,
+				decided_by = '', decided_at = 0`,
+		registration.Id, registration.AuctionId, registration.UserId, registration.Deposit,
+		registration.Status, registration.Timestamp.UnixMilli())
+	if err != nil {
+		logger.Error("Error trying to save registration", err)
+		return internal_error.NewInternalServerError("Error trying to save registration")
+	}
+
+	return nil
+}
+
+func (rr *RegistrationRepository) FindRegistration(
+	ctx context.Context, auctionId, userId string) (*auction_entity.Registration, *internal_error.InternalError) {
+	registration, err := scanRegistration(rr.Database.QueryRowContext(ctx,
+		`SELECT `+registrationColumns+` FROM registrations WHERE auction_id = ? AND user_id = ?`,
+		auctionId, userId))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, internal_error.NewNotFoundError(
+				fmt.Sprintf("Registration not found for auction = %s and user = %s", auctionId, userId))
+		}
+
+		logger.Error("Error trying to find registration", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find registration")
+	}
+
+	return registration, nil
+}
+
+func (rr *RegistrationRepository) FindRegistrations(
+	ctx context.Context, auctionId string) ([]auction_entity.Registration, *internal_error.InternalError) {
+	rows, err := rr.Database.QueryContext(ctx,
+		`SELECT `+registrationColumns+` FROM registrations WHERE auction_id = ? ORDER BY timestamp`,
+		auctionId)
+	if err != nil {
+		logger.Error("Error finding registrations", err)
+		return nil, internal_error.NewInternalServerError("Error finding registrations")
+	}
+	defer rows.Close()
+
+	var registrations []auction_entity.Registration
+	for rows.Next() {
+		registration, err := scanRegistration(rows)
+		if err != nil {
+			logger.Error("Error decoding registrations", err)
+			return nil, internal_error.NewInternalServerError("Error decoding registrations")
+		}
+
+		registrations = append(registrations, *registration)
+	}
+
+	return registrations, nil
+}
+
+func (rr *RegistrationRepository) DecideRegistration(
+	ctx context.Context,
+	auctionId, userId string,
+	status auction_entity.RegistrationStatus,
+	decidedBy string) *internal_error.InternalError {
+	result, err := rr.Database.ExecContext(ctx,
+		`UPDATE registrations SET status = ?, decided_by = ?, decided_at = ?
+			WHERE auction_id = ? AND user_id = ?`,
+		status, decidedBy, clock.Now().UnixMilli(), auctionId, userId)
+	if err != nil {
+		logger.Error("Error trying to update registration", err)
+		return internal_error.NewInternalServerError("Error trying to update registration")
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("Registration not found for auction = %s and user = %s", auctionId, userId))
+	}
+
+	return nil
+}
+
+func scanRegistration(row scanner) (*auction_entity.Registration, error) {
+	var registration auction_entity.Registration
+	var timestamp, decidedAt int64
+
+	if err := row.Scan(
+		&registration.Id,
+		&registration.AuctionId,
+		&registration.UserId,
+		&registration.Deposit,
+		&registration.Status,
+		&timestamp,
+		&registration.DecidedBy,
+		&decidedAt); err != nil {
+		return nil, err
+	}
+
+	registration.Timestamp = time.UnixMilli(timestamp)
+	registration.DecidedAt = fromUnixMilli(decidedAt)
+
+	return &registration, nil
+}
@@ -0,0 +1,75 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+)
+
+type RejectedBidRepository struct {
+	Database *sql.DB
+}
+
+func NewRejectedBidRepository(database *sql.DB) *RejectedBidRepository {
+	return &RejectedBidRepository{
+		Database: database,
+	}
+}
+
+func (rr *RejectedBidRepository) CreateRejectedBid(
+	ctx context.Context, rejectedBid *bid_entity.RejectedBid) *internal_error.InternalError {
+	_, err := rr.Database.ExecContext(ctx,
+		`INSERT INTO rejected_bids (id, user_id, auction_id, amount, source, client_ip,
+			device_fingerprint, reason, timestamp, placed_by) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		rejectedBid.Id, rejectedBid.UserId, rejectedBid.AuctionId, rejectedBid.Amount,
+		rejectedBid.Source, rejectedBid.Client.Ip, rejectedBid.Client.DeviceFingerprint,
+		rejectedBid.Reason, rejectedBid.Timestamp.Unix(), rejectedBid.PlacedBy)
+	if err != nil {
+		logger.Error("Error trying to insert rejected bid", err)
+		return internal_error.NewInternalServerError("Error trying to insert rejected bid")
+	}
+
+	return nil
+}
+
+func (rr *RejectedBidRepository) FindRejectedBids(
+	ctx context.Context, auctionId string) ([]bid_entity.RejectedBid, *internal_error.InternalError) {
+	rows, err := rr.Database.QueryContext(ctx,
+		`SELECT id, user_id, auction_id, amount, source, client_ip, device_fingerprint,
+			reason, timestamp, placed_by FROM rejected_bids WHERE auction_id = ? ORDER BY timestamp DESC, rowid DESC`,
+		auctionId)
+	if err != nil {
+		logger.Error("Error finding rejected bids", err)
+		return nil, internal_error.NewInternalServerError("Error finding rejected bids")
+	}
+	defer rows.Close()
+
+	var rejectedBids []bid_entity.RejectedBid
+	for rows.Next() {
+		var rejectedBid bid_entity.RejectedBid
+		var timestamp int64
+
+		if err := rows.Scan(
+			&rejectedBid.Id,
+			&rejectedBid.UserId,
+			&rejectedBid.AuctionId,
+			&rejectedBid.Amount,
+			&rejectedBid.Source,
+			&rejectedBid.Client.Ip,
+			&rejectedBid.Client.DeviceFingerprint,
+			&rejectedBid.Reason,
+			&timestamp,
+			&rejectedBid.PlacedBy); err != nil {
+			logger.Error("Error decoding rejected bids", err)
+			return nil, internal_error.NewInternalServerError("Error decoding rejected bids")
+		}
+		rejectedBid.Timestamp = time.Unix(timestamp, 0)
+
+		rejectedBids = append(rejectedBids, rejectedBid)
+	}
+
+	return rejectedBids, nil
+}
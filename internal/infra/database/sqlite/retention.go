@@ -0,0 +1,71 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+)
+
+func (bd *BidRepository) PurgeClientData(
+	ctx context.Context, before time.Time, dryRun bool) (int64, *internal_error.InternalError) {
+	return purgeClientData(ctx, bd.Database, "bids", before.UnixMicro(), dryRun)
+}
+
+func (rr *RejectedBidRepository) PurgeClientData(
+	ctx context.Context, before time.Time, dryRun bool) (int64, *internal_error.InternalError) {
+	return purgeClientData(ctx, rr.Database, "rejected_bids", before.Unix(), dryRun)
+}
+
+func (bd *BidRepository) ReplaceBidders(
+	ctx context.Context,
+	auctionId string,
+	userIds map[string]string) (int64, *internal_error.InternalError) {
+	var replaced int64
+	for from, to := range userIds {
+		result, err := bd.Database.ExecContext(ctx,
+			`UPDATE bids SET user_id = ? WHERE auction_id = ? AND user_id = ?`, to, auctionId, from)
+		if err != nil {
+			logger.Error("Error trying to replace bidders", err)
+			return replaced, internal_error.NewInternalServerError("Error trying to replace bidders")
+		}
+
+		affected, _ := result.RowsAffected()
+		replaced += affected
+	}
+
+	return replaced, nil
+}
+
+// purgeClientData clears the client columns of the bids of table, which
+// is one of the bid tables, never user input, stamped before the cutoff in
+// the unit of the table.
+func purgeClientData(
+	ctx context.Context,
+	database *sql.DB,
+	table string,
+	before int64,
+	dryRun bool) (int64, *internal_error.InternalError) {
+	condition := ` WHERE timestamp < ? AND (client_ip != '' OR device_fingerprint != '')`
+
+	if dryRun {
+		var count int64
+		if err := database.QueryRowContext(ctx,
+			`SELECT COUNT(*) FROM `+table+condition, before).Scan(&count); err != nil {
+			logger.Error("Error trying to count bid client data", err)
+			return 0, internal_error.NewInternalServerError("Error trying to count bid client data")
+		}
+		return count, nil
+	}
+
+	result, err := database.ExecContext(ctx,
+		`UPDATE `+table+` SET client_ip = '', device_fingerprint = ''`+condition, before)
+	if err != nil {
+		logger.Error("Error trying to purge bid client data", err)
+		return 0, internal_error.NewInternalServerError("Error trying to purge bid client data")
+	}
+
+	affected, _ := result.RowsAffected()
+	return affected, nil
+}
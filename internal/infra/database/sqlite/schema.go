@@ -0,0 +1,260 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fullcycle-auction_go/configuration/logger"
+)
+
+var schema = []string{
+	`CREATE TABLE IF NOT EXISTS auctions (
+		id TEXT PRIMARY KEY,
+		seller_id TEXT NOT NULL DEFAULT '',
+		product_id TEXT NOT NULL DEFAULT '',
+		product_name TEXT NOT NULL,
+		category TEXT NOT NULL,
+		description TEXT NOT NULL,
+		condition INTEGER NOT NULL,
+		grading TEXT NOT NULL DEFAULT '{}',
+		attributes TEXT NOT NULL DEFAULT '{}',
+		status INTEGER NOT NULL,
+		timestamp INTEGER NOT NULL,
+		updated_at INTEGER NOT NULL DEFAULT 0,
+		ends_at INTEGER NOT NULL DEFAULT 0,
+		winner_bid_id TEXT NOT NULL DEFAULT '',
+		bidder_visibility TEXT NOT NULL DEFAULT 'public',
+		terms TEXT NOT NULL DEFAULT '',
+		recurring_auction_id TEXT NOT NULL DEFAULT '',
+		series_id TEXT NOT NULL DEFAULT '',
+		lot_number INTEGER NOT NULL DEFAULT 0,
+		live INTEGER NOT NULL DEFAULT 0,
+		bidding_opened_at INTEGER NOT NULL DEFAULT 0,
+		starts_at INTEGER NOT NULL DEFAULT 0,
+		reserve_price REAL NOT NULL DEFAULT 0,
+		min_increment REAL NOT NULL DEFAULT 0,
+		sealed INTEGER NOT NULL DEFAULT 0,
+		buy_now_price REAL NOT NULL DEFAULT 0,
+		paused_at INTEGER NOT NULL DEFAULT 0,
+		descriptions TEXT NOT NULL DEFAULT '{}',
+		registration_required INTEGER NOT NULL DEFAULT 0,
+		public_at INTEGER NOT NULL DEFAULT 0,
+		invitees TEXT NOT NULL DEFAULT '[]',
+		heat_score REAL NOT NULL DEFAULT 0,
+		relisted_from TEXT NOT NULL DEFAULT '',
+		starting_price REAL NOT NULL DEFAULT 0,
+		views INTEGER NOT NULL DEFAULT 0,
+		quantity INTEGER NOT NULL DEFAULT 0,
+		uniform_price INTEGER NOT NULL DEFAULT 0,
+		estimate_low REAL NOT NULL DEFAULT 0,
+		estimate_high REAL NOT NULL DEFAULT 0
+	)`,
+	`CREATE INDEX IF NOT EXISTS auctions_series_id_lot_number ON auctions (series_id, lot_number) WHERE series_id != ''`,
+	`CREATE INDEX IF NOT EXISTS auctions_status_category ON auctions (status, category)`,
+	`CREATE INDEX IF NOT EXISTS auctions_updated_at ON auctions (updated_at)`,
+	`CREATE INDEX IF NOT EXISTS auctions_starting_price ON auctions (starting_price)`,
+	`CREATE INDEX IF NOT EXISTS auctions_ends_at ON auctions (ends_at)`,
+	`CREATE INDEX IF NOT EXISTS auctions_timestamp ON auctions (timestamp)`,
+	`CREATE INDEX IF NOT EXISTS auctions_seller_id_status ON auctions (seller_id, status, timestamp)`,
+	`CREATE TABLE IF NOT EXISTS terms_acceptances (
+		auction_id TEXT NOT NULL,
+		user_id TEXT NOT NULL,
+		terms_version INTEGER NOT NULL,
+		timestamp INTEGER NOT NULL,
+		PRIMARY KEY (auction_id, user_id)
+	)`,
+	`CREATE TABLE IF NOT EXISTS bids (
+		id TEXT PRIMARY KEY,
+		user_id TEXT NOT NULL,
+		auction_id TEXT NOT NULL,
+		amount REAL NOT NULL,
+		source TEXT NOT NULL DEFAULT 'web',
+		client_ip TEXT NOT NULL DEFAULT '',
+		device_fingerprint TEXT NOT NULL DEFAULT '',
+		timestamp INTEGER NOT NULL,
+		placed_by TEXT NOT NULL DEFAULT '',
+		retracted_at INTEGER NOT NULL DEFAULT 0
+	)`,
+	`CREATE INDEX IF NOT EXISTS bids_auction_id_amount ON bids (auction_id, amount DESC)`,
+	// Bid timestamps were kept in seconds before microseconds. Seconds stay
+	// below 1e11 until the year 5138, microseconds went past it in 1970.
+	`UPDATE bids SET timestamp = timestamp * 1000000 WHERE timestamp < 100000000000`,
+	`CREATE TABLE IF NOT EXISTS rejected_bids (
+		id TEXT PRIMARY KEY,
+		user_id TEXT NOT NULL,
+		auction_id TEXT NOT NULL,
+		amount REAL NOT NULL,
+		source TEXT NOT NULL,
+		client_ip TEXT NOT NULL DEFAULT '',
+		device_fingerprint TEXT NOT NULL DEFAULT '',
+		reason TEXT NOT NULL,
+		timestamp INTEGER NOT NULL,
+		placed_by TEXT NOT NULL DEFAULT ''
+	)`,
+	`CREATE INDEX IF NOT EXISTS rejected_bids_auction_id ON rejected_bids (auction_id)`,
+	`CREATE TABLE IF NOT EXISTS users (
+		id TEXT PRIMARY KEY,
+		name TEXT NOT NULL,
+		banned INTEGER NOT NULL DEFAULT 0,
+		display_name TEXT NOT NULL DEFAULT '',
+		avatar_url TEXT NOT NULL DEFAULT '',
+		bio TEXT NOT NULL DEFAULT '',
+		email TEXT NOT NULL DEFAULT '',
+		password_hash TEXT NOT NULL DEFAULT '',
+		role TEXT NOT NULL DEFAULT ''
+	)`,
+	`CREATE UNIQUE INDEX IF NOT EXISTS users_email ON users (email) WHERE email != ''`,
+	`CREATE TABLE IF NOT EXISTS products (
+		id TEXT PRIMARY KEY,
+		sku TEXT NOT NULL UNIQUE,
+		name TEXT NOT NULL,
+		description TEXT NOT NULL,
+		category TEXT NOT NULL,
+		attributes TEXT NOT NULL DEFAULT '{}',
+		images TEXT NOT NULL DEFAULT '[]',
+		timestamp INTEGER NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS category_schemas (
+		category TEXT PRIMARY KEY,
+		attributes TEXT NOT NULL DEFAULT '[]',
+		labels TEXT NOT NULL DEFAULT '{}'
+	)`,
+	`CREATE TABLE IF NOT EXISTS categories (
+		name TEXT PRIMARY KEY,
+		parent TEXT NOT NULL DEFAULT '',
+		timestamp INTEGER NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS fraud_flags (
+		id TEXT PRIMARY KEY,
+		auction_id TEXT NOT NULL,
+		user_id TEXT NOT NULL,
+		signal TEXT NOT NULL,
+		details TEXT NOT NULL,
+		timestamp INTEGER NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS fraud_flags_auction_id ON fraud_flags (auction_id)`,
+	`CREATE TABLE IF NOT EXISTS auction_questions (
+		id TEXT PRIMARY KEY,
+		auction_id TEXT NOT NULL,
+		user_id TEXT NOT NULL,
+		text TEXT NOT NULL,
+		answer TEXT NOT NULL DEFAULT '',
+		answered_at INTEGER NOT NULL DEFAULT 0,
+		status TEXT NOT NULL,
+		timestamp INTEGER NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS auction_questions_auction_id ON auction_questions (auction_id, timestamp)`,
+	`CREATE TABLE IF NOT EXISTS notifications (
+		id TEXT PRIMARY KEY,
+		user_id TEXT NOT NULL,
+		auction_id TEXT NOT NULL,
+		kind TEXT NOT NULL,
+		message TEXT NOT NULL,
+		timestamp INTEGER NOT NULL,
+		read_at INTEGER NOT NULL DEFAULT 0,
+		archived_at INTEGER NOT NULL DEFAULT 0
+	)`,
+	`CREATE INDEX IF NOT EXISTS notifications_user_id ON notifications (user_id, archived_at, timestamp)`,
+	`CREATE TABLE IF NOT EXISTS second_chance_offers (
+		id TEXT PRIMARY KEY,
+		auction_id TEXT NOT NULL,
+		seller_id TEXT NOT NULL,
+		user_id TEXT NOT NULL,
+		amount REAL NOT NULL,
+		status TEXT NOT NULL,
+		expires_at INTEGER NOT NULL,
+		responded_at INTEGER NOT NULL DEFAULT 0,
+		timestamp INTEGER NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS second_chance_offers_auction_id ON second_chance_offers (auction_id, timestamp)`,
+	`CREATE TABLE IF NOT EXISTS auction_transfers (
+		id TEXT PRIMARY KEY,
+		auction_id TEXT NOT NULL,
+		from_seller_id TEXT NOT NULL,
+		to_seller_id TEXT NOT NULL,
+		admin_id TEXT NOT NULL,
+		reason TEXT NOT NULL,
+		timestamp INTEGER NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS auction_transfers_auction_id ON auction_transfers (auction_id, timestamp)`,
+	`CREATE TABLE IF NOT EXISTS jobs (
+		id TEXT PRIMARY KEY,
+		kind TEXT NOT NULL,
+		payload TEXT NOT NULL,
+		status TEXT NOT NULL,
+		attempts INTEGER NOT NULL,
+		max_attempts INTEGER NOT NULL,
+		run_at INTEGER NOT NULL,
+		locked_until INTEGER NOT NULL DEFAULT 0,
+		last_error TEXT NOT NULL DEFAULT '',
+		timestamp INTEGER NOT NULL,
+		updated_at INTEGER NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS jobs_status_run_at ON jobs (status, run_at)`,
+	`CREATE TABLE IF NOT EXISTS recurring_auctions (
+		id TEXT PRIMARY KEY,
+		seller_id TEXT NOT NULL,
+		schedule TEXT NOT NULL,
+		timezone TEXT NOT NULL DEFAULT '',
+		template TEXT NOT NULL,
+		active INTEGER NOT NULL,
+		next_run_at INTEGER NOT NULL,
+		last_run_at INTEGER NOT NULL,
+		created_at INTEGER NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS recurring_auctions_seller_id ON recurring_auctions (seller_id, created_at)`,
+	`CREATE INDEX IF NOT EXISTS recurring_auctions_next_run_at ON recurring_auctions (active, next_run_at)`,
+	`CREATE TABLE IF NOT EXISTS auction_series (
+		id TEXT PRIMARY KEY,
+		seller_id TEXT NOT NULL,
+		name TEXT NOT NULL,
+		description TEXT NOT NULL DEFAULT '',
+		lot_count INTEGER NOT NULL DEFAULT 0,
+		cascade_interval INTEGER NOT NULL DEFAULT 0,
+		created_at INTEGER NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS auction_series_seller_id ON auction_series (seller_id, created_at)`,
+	`CREATE TABLE IF NOT EXISTS absentee_bids (
+		id TEXT PRIMARY KEY,
+		auction_id TEXT NOT NULL,
+		user_id TEXT NOT NULL,
+		max_amount REAL NOT NULL,
+		terms_version INTEGER NOT NULL DEFAULT 0,
+		status TEXT NOT NULL,
+		timestamp INTEGER NOT NULL,
+		UNIQUE (auction_id, user_id)
+	)`,
+	`CREATE TABLE IF NOT EXISTS proxy_bids (
+		id TEXT PRIMARY KEY,
+		auction_id TEXT NOT NULL,
+		user_id TEXT NOT NULL,
+		max_amount REAL NOT NULL,
+		terms_version INTEGER NOT NULL DEFAULT 0,
+		status TEXT NOT NULL,
+		timestamp INTEGER NOT NULL,
+		UNIQUE (auction_id, user_id)
+	)`,
+	`CREATE TABLE IF NOT EXISTS registrations (
+		id TEXT PRIMARY KEY,
+		auction_id TEXT NOT NULL,
+		user_id TEXT NOT NULL,
+		deposit REAL NOT NULL DEFAULT 0,
+		status TEXT NOT NULL,
+		timestamp INTEGER NOT NULL,
+		decided_by TEXT NOT NULL DEFAULT '',
+		decided_at INTEGER NOT NULL DEFAULT 0,
+		UNIQUE (auction_id, user_id)
+	)`,
+}
+
+// Migrate creates the tables used by the sqlite repositories when they do
+// not exist yet.
+func Migrate(ctx context.Context, database *sql.DB) error {
+	for _, statement := range schema {
+		if _, err := database.ExecContext(ctx, statement); err != nil {
+			logger.Error("Error trying to create sqlite schema", err)
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+)
+
+const seriesColumns = `id, seller_id, name, description, lot_count, cascade_interval, created_at`
+
+type SeriesRepository struct {
+	Database *sql.DB
+}
+
+func NewSeriesRepository(database *sql.DB) *SeriesRepository {
+	return &SeriesRepository{
+		Database: database,
+	}
+}
+
+func (sr *SeriesRepository) CreateSeries(
+	ctx context.Context, series *auction_entity.Series) *internal_error.InternalError {
+	_, err := sr.Database.ExecContext(ctx,
+		`INSERT INTO auction_series (`+seriesColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		series.Id,
+		series.SellerId,
+		series.Name,
+		series.Description,
+		series.LotCount,
+		series.CascadeInterval.Milliseconds(),
+		series.CreatedAt.UnixMilli())
+	if err != nil {
+		logger.Error("Error trying to insert series", err)
+		return internal_error.NewInternalServerError("Error trying to insert series")
+	}
+
+	return nil
+}
+
+func (sr *SeriesRepository) FindSeriesById(
+	ctx context.Context, id string) (*auction_entity.Series, *internal_error.InternalError) {
+	series, err := scanSeries(sr.Database.QueryRowContext(ctx,
+		`SELECT `+seriesColumns+` FROM auction_series WHERE id = ?`, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, internal_error.NewNotFoundError(
+				fmt.Sprintf("Series not found with this id = %s", id))
+		}
+
+		logger.Error(fmt.Sprintf("Error trying to find series by id = %s", id), err)
+		return nil, internal_error.NewInternalServerError("Error trying to find series by id")
+	}
+
+	return series, nil
+}
+
+func (sr *SeriesRepository) FindSeries(
+	ctx context.Context, sellerId string) ([]auction_entity.Series, *internal_error.InternalError) {
+	rows, err := sr.Database.QueryContext(ctx,
+		`SELECT `+seriesColumns+` FROM auction_series WHERE ? = '' OR seller_id = ? ORDER BY created_at DESC`,
+		sellerId, sellerId)
+	if err != nil {
+		logger.Error("Error trying to find series", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find series")
+	}
+	defer rows.Close()
+
+	series := []auction_entity.Series{}
+	for rows.Next() {
+		value, err := scanSeries(rows)
+		if err != nil {
+			logger.Error("Error trying to decode series", err)
+			return nil, internal_error.NewInternalServerError("Error trying to find series")
+		}
+
+		series = append(series, *value)
+	}
+
+	return series, nil
+}
+
+func (sr *SeriesRepository) NextLotNumber(
+	ctx context.Context, seriesId string) (int, *internal_error.InternalError) {
+	var lotNumber int
+	err := sr.Database.QueryRowContext(ctx,
+		`UPDATE auction_series SET lot_count = lot_count + 1 WHERE id = ? RETURNING lot_count`,
+		seriesId).Scan(&lotNumber)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, internal_error.NewNotFoundError(
+				fmt.Sprintf("Series not found with this id = %s", seriesId))
+		}
+
+		logger.Error("Error trying to number series lot", err)
+		return 0, internal_error.NewInternalServerError("Error trying to number series lot")
+	}
+
+	return lotNumber, nil
+}
+
+func (sr *SeriesRepository) UpdateSeriesCascade(
+	ctx context.Context, seriesId string, interval time.Duration) *internal_error.InternalError {
+	result, err := sr.Database.ExecContext(ctx,
+		`UPDATE auction_series SET cascade_interval = ? WHERE id = ?`,
+		interval.Milliseconds(), seriesId)
+	if err != nil {
+		logger.Error("Error trying to update series cascade", err)
+		return internal_error.NewInternalServerError("Error trying to update series cascade")
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("Series not found with this id = %s", seriesId))
+	}
+
+	return nil
+}
+
+func scanSeries(row scanner) (*auction_entity.Series, error) {
+	var series auction_entity.Series
+	var cascadeInterval, createdAt int64
+
+	if err := row.Scan(
+		&series.Id,
+		&series.SellerId,
+		&series.Name,
+		&series.Description,
+		&series.LotCount,
+		&cascadeInterval,
+		&createdAt); err != nil {
+		return nil, err
+	}
+	series.CascadeInterval = time.Duration(cascadeInterval) * time.Millisecond
+	series.CreatedAt = time.UnixMilli(createdAt)
+
+	return &series, nil
+}
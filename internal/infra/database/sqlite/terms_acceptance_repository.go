@@ -0,0 +1,59 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+)
+
+type TermsAcceptanceRepository struct {
+	Database *sql.DB
+}
+
+func NewTermsAcceptanceRepository(database *sql.DB) *TermsAcceptanceRepository {
+	return &TermsAcceptanceRepository{
+		Database: database,
+	}
+}
+
+func (tr *TermsAcceptanceRepository) CreateTermsAcceptance(
+	ctx context.Context, acceptance *auction_entity.TermsAcceptance) *internal_error.InternalError {
+	_, err := tr.Database.ExecContext(ctx,
+		`INSERT INTO terms_acceptances (auction_id, user_id, terms_version, timestamp) VALUES (?, ?, ?, ?)
+			ON CONFLICT (auction_id, user_id) DO UPDATE
+			SET terms_version = excluded.terms_version, timestamp = excluded.timestamp`,
+		acceptance.AuctionId, acceptance.UserId, acceptance.TermsVersion, acceptance.Timestamp.Unix())
+	if err != nil {
+		logger.Error("Error trying to save terms acceptance", err)
+		return internal_error.NewInternalServerError("Error trying to save terms acceptance")
+	}
+
+	return nil
+}
+
+func (tr *TermsAcceptanceRepository) FindTermsAcceptance(
+	ctx context.Context, auctionId, userId string) (*auction_entity.TermsAcceptance, *internal_error.InternalError) {
+	acceptance := auction_entity.TermsAcceptance{AuctionId: auctionId, UserId: userId}
+	var timestamp int64
+
+	err := tr.Database.QueryRowContext(ctx,
+		`SELECT terms_version, timestamp FROM terms_acceptances WHERE auction_id = ? AND user_id = ?`,
+		auctionId, userId).Scan(&acceptance.TermsVersion, &timestamp)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, internal_error.NewNotFoundError(
+				fmt.Sprintf("Terms acceptance not found for auction = %s and user = %s", auctionId, userId))
+		}
+
+		logger.Error("Error trying to find terms acceptance", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find terms acceptance")
+	}
+	acceptance.Timestamp = time.Unix(timestamp, 0)
+
+	return &acceptance, nil
+}
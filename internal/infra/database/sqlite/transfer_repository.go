@@ -0,0 +1,71 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+)
+
+const transferColumns = `id, auction_id, from_seller_id, to_seller_id, admin_id, reason, timestamp`
+
+type TransferRepository struct {
+	Database *sql.DB
+}
+
+func NewTransferRepository(database *sql.DB) *TransferRepository {
+	return &TransferRepository{
+		Database: database,
+	}
+}
+
+func (tr *TransferRepository) CreateTransfer(
+	ctx context.Context, transfer *auction_entity.Transfer) *internal_error.InternalError {
+	_, err := tr.Database.ExecContext(ctx,
+		`INSERT INTO auction_transfers (`+transferColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		transfer.Id, transfer.AuctionId, transfer.FromSellerId, transfer.ToSellerId,
+		transfer.AdminId, transfer.Reason, transfer.Timestamp.UnixMilli())
+	if err != nil {
+		logger.Error("Error trying to insert auction transfer", err)
+		return internal_error.NewInternalServerError("Error trying to insert auction transfer")
+	}
+
+	return nil
+}
+
+func (tr *TransferRepository) FindTransfers(
+	ctx context.Context, auctionId string) ([]auction_entity.Transfer, *internal_error.InternalError) {
+	rows, err := tr.Database.QueryContext(ctx,
+		`SELECT `+transferColumns+` FROM auction_transfers WHERE auction_id = ? ORDER BY timestamp`,
+		auctionId)
+	if err != nil {
+		logger.Error("Error finding auction transfers", err)
+		return nil, internal_error.NewInternalServerError("Error finding auction transfers")
+	}
+	defer rows.Close()
+
+	var transfers []auction_entity.Transfer
+	for rows.Next() {
+		var transfer auction_entity.Transfer
+		var timestamp int64
+
+		if err := rows.Scan(
+			&transfer.Id,
+			&transfer.AuctionId,
+			&transfer.FromSellerId,
+			&transfer.ToSellerId,
+			&transfer.AdminId,
+			&transfer.Reason,
+			&timestamp); err != nil {
+			logger.Error("Error decoding auction transfers", err)
+			return nil, internal_error.NewInternalServerError("Error decoding auction transfers")
+		}
+
+		transfer.Timestamp = time.UnixMilli(timestamp)
+		transfers = append(transfers, transfer)
+	}
+
+	return transfers, nil
+}
@@ -0,0 +1,167 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/user_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"strings"
+)
+
+const userColumns = `id, name, banned, display_name, avatar_url, bio, email, password_hash, role`
+
+type UserRepository struct {
+	Database *sql.DB
+}
+
+func NewUserRepository(database *sql.DB) *UserRepository {
+	return &UserRepository{
+		Database: database,
+	}
+}
+
+func (ur *UserRepository) FindUserById(
+	ctx context.Context, userId string) (*user_entity.User, *internal_error.InternalError) {
+	userEntity, err := scanUser(ur.Database.QueryRowContext(ctx,
+		`SELECT `+userColumns+` FROM users WHERE id = ?`, userId))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Error(fmt.Sprintf("User not found with this id = %s", userId), err)
+			return nil, internal_error.NewNotFoundError(
+				fmt.Sprintf("User not found with this id = %s", userId))
+		}
+
+		logger.Error("Error trying to find user by userId", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find user by userId")
+	}
+
+	return userEntity, nil
+}
+
+func (ur *UserRepository) FindUserByEmail(
+	ctx context.Context, email string) (*user_entity.User, *internal_error.InternalError) {
+	userEntity, err := scanUser(ur.Database.QueryRowContext(ctx,
+		`SELECT `+userColumns+` FROM users WHERE email = ?`, email))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, internal_error.NewNotFoundError("User not found with this email")
+		}
+
+		logger.Error("Error trying to find user by email", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find user by email")
+	}
+
+	return userEntity, nil
+}
+
+func (ur *UserRepository) CreateUser(
+	ctx context.Context, userEntity *user_entity.User) *internal_error.InternalError {
+	_, err := ur.Database.ExecContext(ctx,
+		`INSERT INTO users (`+userColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		userEntity.Id,
+		userEntity.Name,
+		userEntity.Banned,
+		userEntity.DisplayName,
+		userEntity.AvatarUrl,
+		userEntity.Bio,
+		userEntity.Email,
+		userEntity.PasswordHash,
+		userEntity.Role)
+	if err != nil {
+		logger.Error("Error trying to insert user", err)
+		return internal_error.NewInternalServerError("Error trying to insert user")
+	}
+
+	return nil
+}
+
+func (ur *UserRepository) FindUsersByIds(
+	ctx context.Context, userIds []string) ([]user_entity.User, *internal_error.InternalError) {
+	if len(userIds) == 0 {
+		return nil, nil
+	}
+
+	args := make([]interface{}, len(userIds))
+	for i, userId := range userIds {
+		args[i] = userId
+	}
+
+	rows, err := ur.Database.QueryContext(ctx,
+		`SELECT `+userColumns+` FROM users WHERE id IN (?`+strings.Repeat(", ?", len(userIds)-1)+`)`,
+		args...)
+	if err != nil {
+		logger.Error("Error trying to find users by ids", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find users by ids")
+	}
+	defer rows.Close()
+
+	var userEntities []user_entity.User
+	for rows.Next() {
+		userEntity, err := scanUser(rows)
+		if err != nil {
+			logger.Error("Error trying to decode users by ids", err)
+			return nil, internal_error.NewInternalServerError("Error trying to find users by ids")
+		}
+
+		userEntities = append(userEntities, *userEntity)
+	}
+
+	return userEntities, nil
+}
+
+func (ur *UserRepository) UpdateUserProfile(
+	ctx context.Context, userEntity *user_entity.User) *internal_error.InternalError {
+	result, err := ur.Database.ExecContext(ctx,
+		`UPDATE users SET display_name = ?, avatar_url = ?, bio = ? WHERE id = ?`,
+		userEntity.DisplayName, userEntity.AvatarUrl, userEntity.Bio, userEntity.Id)
+	if err != nil {
+		logger.Error("Error trying to update user profile", err)
+		return internal_error.NewInternalServerError("Error trying to update user profile")
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("User not found with this id = %s", userEntity.Id))
+	}
+
+	return nil
+}
+
+func (ur *UserRepository) UpdateUserRole(
+	ctx context.Context, userId string, role user_entity.Role) *internal_error.InternalError {
+	result, err := ur.Database.ExecContext(ctx,
+		`UPDATE users SET role = ? WHERE id = ?`, role, userId)
+	if err != nil {
+		logger.Error("Error trying to update user role", err)
+		return internal_error.NewInternalServerError("Error trying to update user role")
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("User not found with this id = %s", userId))
+	}
+
+	return nil
+}
+
+func scanUser(row scanner) (*user_entity.User, error) {
+	var userEntity user_entity.User
+
+	if err := row.Scan(
+		&userEntity.Id,
+		&userEntity.Name,
+		&userEntity.Banned,
+		&userEntity.DisplayName,
+		&userEntity.AvatarUrl,
+		&userEntity.Bio,
+		&userEntity.Email,
+		&userEntity.PasswordHash,
+		&userEntity.Role); err != nil {
+		return nil, err
+	}
+
+	return &userEntity, nil
+}
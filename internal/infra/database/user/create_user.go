@@ -0,0 +1,30 @@
+package user
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/user_entity"
+	"fullcycle-auction_go/internal/internal_error"
+)
+
+func (ur *UserRepository) CreateUser(
+	ctx context.Context, userEntity *user_entity.User) *internal_error.InternalError {
+	userEntityMongo := &UserEntityMongo{
+		Id:            userEntity.Id,
+		Name:          userEntity.Name,
+		DisplayName:   userEntity.DisplayName,
+		AvatarUrl:     userEntity.AvatarUrl,
+		Bio:           userEntity.Bio,
+		Email:         userEntity.Email,
+		PasswordHash:  userEntity.PasswordHash,
+		Role:          string(userEntity.Role),
+		SchemaVersion: UserUpcasters.LatestVersion(),
+	}
+
+	if _, err := ur.Collection.InsertOne(ctx, userEntityMongo); err != nil {
+		logger.Error("Error trying to insert user", err)
+		return internal_error.NewInternalServerError("Error trying to insert user")
+	}
+
+	return nil
+}
@@ -12,8 +12,16 @@ import (
 )
 
 type UserEntityMongo struct {
-	Id   string `bson:"_id"`
-	Name string `bson:"name"`
+	Id            string `bson:"_id"`
+	Name          string `bson:"name"`
+	Banned        bool   `bson:"banned,omitempty"`
+	DisplayName   string `bson:"display_name,omitempty"`
+	AvatarUrl     string `bson:"avatar_url,omitempty"`
+	Bio           string `bson:"bio,omitempty"`
+	Email         string `bson:"email,omitempty"`
+	PasswordHash  string `bson:"password_hash,omitempty"`
+	Role          string `bson:"role,omitempty"`
+	SchemaVersion int    `bson:"schema_version,omitempty"`
 }
 
 type UserRepository struct {
@@ -30,23 +38,96 @@ func (ur *UserRepository) FindUserById(
 	ctx context.Context, userId string) (*user_entity.User, *internal_error.InternalError) {
 	filter := bson.M{"_id": userId}
 
-	var userEntityMongo UserEntityMongo
-	err := ur.Collection.FindOne(ctx, filter).Decode(&userEntityMongo)
+	var document bson.M
+	err := ur.Collection.FindOne(ctx, filter).Decode(&document)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
-			logger.Error(fmt.Sprintf("User not found with this id = %d", userId), err)
+			logger.Error(fmt.Sprintf("User not found with this id = %s", userId), err)
 			return nil, internal_error.NewNotFoundError(
-				fmt.Sprintf("User not found with this id = %d", userId))
+				fmt.Sprintf("User not found with this id = %s", userId))
 		}
 
 		logger.Error("Error trying to find user by userId", err)
 		return nil, internal_error.NewInternalServerError("Error trying to find user by userId")
 	}
 
-	userEntity := &user_entity.User{
-		Id:   userEntityMongo.Id,
-		Name: userEntityMongo.Name,
+	var userEntityMongo UserEntityMongo
+	if err := UserUpcasters.Decode(document, &userEntityMongo); err != nil {
+		logger.Error("Error trying to decode user by userId", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find user by userId")
+	}
+
+	return userEntityMongo.toUserEntity(), nil
+}
+
+func (ur *UserRepository) FindUserByEmail(
+	ctx context.Context, email string) (*user_entity.User, *internal_error.InternalError) {
+	filter := bson.M{"email": email}
+
+	var document bson.M
+	err := ur.Collection.FindOne(ctx, filter).Decode(&document)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, internal_error.NewNotFoundError("User not found with this email")
+		}
+
+		logger.Error("Error trying to find user by email", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find user by email")
+	}
+
+	var userEntityMongo UserEntityMongo
+	if err := UserUpcasters.Decode(document, &userEntityMongo); err != nil {
+		logger.Error("Error trying to decode user by email", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find user by email")
+	}
+
+	return userEntityMongo.toUserEntity(), nil
+}
+
+func (ur *UserRepository) FindUsersByIds(
+	ctx context.Context, userIds []string) ([]user_entity.User, *internal_error.InternalError) {
+	if len(userIds) == 0 {
+		return nil, nil
+	}
+
+	filter := bson.M{"_id": bson.M{"$in": userIds}}
+
+	cursor, err := ur.Collection.Find(ctx, filter)
+	if err != nil {
+		logger.Error("Error trying to find users by ids", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find users by ids")
+	}
+
+	var documents []bson.M
+	if err := cursor.All(ctx, &documents); err != nil {
+		logger.Error("Error trying to find users by ids", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find users by ids")
+	}
+
+	var userEntities []user_entity.User
+	for _, document := range documents {
+		var userEntityMongo UserEntityMongo
+		if err := UserUpcasters.Decode(document, &userEntityMongo); err != nil {
+			logger.Error("Error trying to decode users by ids", err)
+			return nil, internal_error.NewInternalServerError("Error trying to find users by ids")
+		}
+
+		userEntities = append(userEntities, *userEntityMongo.toUserEntity())
 	}
 
-	return userEntity, nil
+	return userEntities, nil
+}
+
+func (um *UserEntityMongo) toUserEntity() *user_entity.User {
+	return &user_entity.User{
+		Id:           um.Id,
+		Name:         um.Name,
+		Banned:       um.Banned,
+		DisplayName:  um.DisplayName,
+		AvatarUrl:    um.AvatarUrl,
+		Bio:          um.Bio,
+		Email:        um.Email,
+		PasswordHash: um.PasswordHash,
+		Role:         user_entity.Role(um.Role),
+	}
 }
@@ -0,0 +1,7 @@
+package user
+
+import (
+	"fullcycle-auction_go/internal/infra/database/migration"
+)
+
+var UserUpcasters = migration.NewChain()
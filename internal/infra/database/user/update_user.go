@@ -0,0 +1,53 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/user_entity"
+	"fullcycle-auction_go/internal/internal_error"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func (ur *UserRepository) UpdateUserProfile(
+	ctx context.Context, userEntity *user_entity.User) *internal_error.InternalError {
+	filter := bson.M{"_id": userEntity.Id}
+	update := bson.M{"$set": bson.M{
+		"display_name": userEntity.DisplayName,
+		"avatar_url":   userEntity.AvatarUrl,
+		"bio":          userEntity.Bio,
+	}}
+
+	result, err := ur.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		logger.Error("Error trying to update user profile", err)
+		return internal_error.NewInternalServerError("Error trying to update user profile")
+	}
+
+	if result.MatchedCount == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("User not found with this id = %s", userEntity.Id))
+	}
+
+	return nil
+}
+
+func (ur *UserRepository) UpdateUserRole(
+	ctx context.Context, userId string, role user_entity.Role) *internal_error.InternalError {
+	filter := bson.M{"_id": userId}
+	update := bson.M{"$set": bson.M{"role": role}}
+
+	result, err := ur.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		logger.Error("Error trying to update user role", err)
+		return internal_error.NewInternalServerError("Error trying to update user role")
+	}
+
+	if result.MatchedCount == 0 {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("User not found with this id = %s", userId))
+	}
+
+	return nil
+}
@@ -0,0 +1,25 @@
+package diagnostics
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/redis"
+	"os"
+)
+
+// checkBroker pings the Redis server relaying events between instances
+// when REDIS_URL is set. Without it the events stay in the process.
+func checkBroker(ctx context.Context, report *Report) {
+	if os.Getenv(redis.REDIS_URL) == "" {
+		report.add("broker", OK, "in-process event broker, REDIS_URL is not set")
+		return
+	}
+
+	client, err := redis.NewRedisConnection(ctx)
+	if err != nil {
+		report.add("broker", Fail, "cannot connect to redis: %v", err)
+		return
+	}
+	defer client.Close()
+
+	report.add("broker", OK, "relaying events through redis at %s", client.Options().Addr)
+}
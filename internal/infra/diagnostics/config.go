@@ -0,0 +1,155 @@
+package diagnostics
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The settings read from the environment across the API. Invalid values do
+// not stop it, each reader falls back to its default, so they are only
+// warned about.
+var (
+	durationSettings = []string{
+		"AUCTION_INTERVAL", "AUCTION_COMPLETION_GRACE", "AUCTION_WARNING_SCAN_INTERVAL",
+		"AUCTION_EXTENSION_WINDOW", "AUCTION_EXTENSION_STEP", "AUCTION_EXTENSION_MAX",
+		"BATCH_INSERT_INTERVAL", "BATCH_INSERT_INTERVAL_MIN", "BID_PRIORITY_WINDOW",
+		"BID_MAX_CLOCK_SKEW", "FRAUD_RAPID_BID_WINDOW", "FRAUD_SCAN_INTERVAL",
+		"JOB_POLL_INTERVAL", "JOB_LOCK_DURATION", "JOB_RETRY_BACKOFF", "RECURRING_AUCTION_SCAN_INTERVAL",
+		"SECOND_CHANCE_OFFER_TTL", "SLOW_QUERY_THRESHOLD",
+		"MONGODB_MAX_CONN_IDLE_TIME", "MONGODB_OPERATION_TIMEOUT",
+		"PPROF_EXPORT_INTERVAL", "PPROF_EXPORT_CPU_DURATION", "JWT_TTL",
+		"PUBLIC_CACHE_TTL", "RETENTION_INTERVAL", "RETENTION_BID_CLIENT_DATA", "RETENTION_LOSING_BIDDERS",
+		"AUCTION_VISIBILITY_DELAY", "AUCTION_HEAT_INTERVAL", "VIEW_FLUSH_INTERVAL",
+	}
+
+	intSettings = []string{
+		"MAX_BATCH_SIZE", "BATCH_SIZE_MIN", "FRAUD_RAPID_BID_THRESHOLD",
+		"JOB_WORKERS", "JOB_MAX_ATTEMPTS",
+		"MONGODB_MIN_POOL_SIZE", "MONGODB_MAX_POOL_SIZE", "PPROF_EXPORT_KEEP",
+		"PUBLIC_RATE_LIMIT",
+	}
+
+	choiceSettings = []struct {
+		name    string
+		choices []string
+	}{
+		{"STORAGE", []string{"memory", "sqlite"}},
+		{"AUCTION_EXTENSION_POLICY", []string{"fixed", "velocity"}},
+		{"SCHEMA_INCOMPATIBLE", []string{"refuse", "read_only"}},
+	}
+)
+
+func checkConfig(report *Report) {
+	problems := 0
+
+	for _, name := range durationSettings {
+		if value, ok := os.LookupEnv(name); ok && value != "" {
+			if duration, err := time.ParseDuration(value); err != nil || duration < 0 {
+				report.add("config", Warn, "%s=%q is not a valid duration, the default is used", name, value)
+				problems++
+			}
+		}
+	}
+
+	for _, name := range intSettings {
+		if value, ok := os.LookupEnv(name); ok && value != "" {
+			if number, err := strconv.Atoi(value); err != nil || number < 0 {
+				report.add("config", Warn, "%s=%q is not a valid number, the default is used", name, value)
+				problems++
+			}
+		}
+	}
+
+	for _, setting := range choiceSettings {
+		if value := os.Getenv(setting.name); value != "" && !contains(setting.choices, value) {
+			report.add("config", Warn, "%s=%q is not one of %s, the default is used",
+				setting.name, value, strings.Join(setting.choices, ", "))
+			problems++
+		}
+	}
+
+	if value := os.Getenv("CLOCK_SPEED"); value != "" {
+		if speed, err := strconv.ParseFloat(value, 64); err != nil || speed <= 0 {
+			report.add("config", Warn, "CLOCK_SPEED=%q is not a positive number, the real clock is used", value)
+			problems++
+		}
+	}
+
+	if value := os.Getenv("ABSENTEE_BID_INCREMENT"); value != "" {
+		if increment, err := strconv.ParseFloat(value, 64); err != nil || increment <= 0 {
+			report.add("config", Warn, "ABSENTEE_BID_INCREMENT=%q is not a positive number, the default is used", value)
+			problems++
+		}
+	}
+
+	if value := os.Getenv("PROXY_BID_INCREMENT"); value != "" {
+		if increment, err := strconv.ParseFloat(value, 64); err != nil || increment <= 0 {
+			report.add("config", Warn, "PROXY_BID_INCREMENT=%q is not a positive number, the default is used", value)
+			problems++
+		}
+	}
+
+	if value := os.Getenv("LOT_REGISTRATION_THRESHOLD"); value != "" {
+		if threshold, err := strconv.ParseFloat(value, 64); err != nil || threshold < 0 {
+			report.add("config", Warn, "LOT_REGISTRATION_THRESHOLD=%q is not a number, registration is only required when asked", value)
+			problems++
+		}
+	}
+
+	for _, bounds := range [][2]string{
+		{"BATCH_SIZE_MIN", "MAX_BATCH_SIZE"},
+		{"BATCH_INSERT_INTERVAL_MIN", "BATCH_INSERT_INTERVAL"},
+		{"MONGODB_MIN_POOL_SIZE", "MONGODB_MAX_POOL_SIZE"},
+	} {
+		if exceeds(os.Getenv(bounds[0]), os.Getenv(bounds[1])) {
+			report.add("config", Warn, "%s is above %s", bounds[0], bounds[1])
+			problems++
+		}
+	}
+
+	if os.Getenv("STORAGE") == "" && os.Getenv("MONGODB_URL") == "" {
+		report.add("config", Fail, "MONGODB_URL is not set")
+		problems++
+	}
+
+	if os.Getenv("JWT_SECRET") == "" {
+		report.add("config", Warn, "JWT_SECRET is not set, tokens do not survive a restart")
+		problems++
+	}
+
+	if problems == 0 {
+		report.add("config", OK, "environment settings are valid")
+	}
+}
+
+// exceeds reports whether the lower bound is above the upper one, when both
+// are set and valid numbers or durations.
+func exceeds(lower, upper string) bool {
+	if lower == "" || upper == "" {
+		return false
+	}
+
+	if lowerNumber, err := strconv.Atoi(lower); err == nil {
+		upperNumber, err := strconv.Atoi(upper)
+		return err == nil && lowerNumber > upperNumber
+	}
+
+	lowerDuration, err := time.ParseDuration(lower)
+	if err != nil {
+		return false
+	}
+	upperDuration, err := time.ParseDuration(upper)
+	return err == nil && lowerDuration > upperDuration
+}
+
+func contains(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+
+	return false
+}
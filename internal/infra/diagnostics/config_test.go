@@ -0,0 +1,35 @@
+package diagnostics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckConfig(t *testing.T) {
+	t.Setenv("STORAGE", "memory")
+	t.Setenv("AUCTION_INTERVAL", "20s")
+	t.Setenv("JOB_WORKERS", "many")
+	t.Setenv("BATCH_SIZE_MIN", "10")
+	t.Setenv("MAX_BATCH_SIZE", "5")
+	t.Setenv("JWT_SECRET", "secret")
+
+	report := &Report{}
+	checkConfig(report)
+
+	assert.Equal(t, []Check{
+		{Name: "config", Status: Warn, Detail: `JOB_WORKERS="many" is not a valid number, the default is used`},
+		{Name: "config", Status: Warn, Detail: "BATCH_SIZE_MIN is above MAX_BATCH_SIZE"},
+	}, report.Checks)
+	assert.False(t, report.Failed())
+}
+
+func TestCheckConfig_MissingMongoDBURL(t *testing.T) {
+	t.Setenv("STORAGE", "")
+	t.Setenv("MONGODB_URL", "")
+
+	report := &Report{}
+	checkConfig(report)
+
+	assert.True(t, report.Failed())
+}
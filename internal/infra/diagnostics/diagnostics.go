@@ -0,0 +1,109 @@
+// Package diagnostics checks the environment the API runs in: the
+// configuration, the storage and Redis it connects to and the clock. It backs the
+// --check flag and the startup self-check.
+package diagnostics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"io"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type Status string
+
+const (
+	OK   Status = "OK"
+	Warn Status = "WARN"
+	Fail Status = "FAIL"
+)
+
+type Check struct {
+	Name   string
+	Status Status
+	Detail string
+}
+
+type Report struct {
+	Checks []Check
+
+	// ReadOnly is set when the data was migrated past the schema versions
+	// this build reads and SCHEMA_INCOMPATIBLE=read_only lets it start
+	// anyway, refusing writes.
+	ReadOnly bool
+}
+
+func (r *Report) add(name string, status Status, format string, args ...any) {
+	r.Checks = append(r.Checks, Check{Name: name, Status: status, Detail: fmt.Sprintf(format, args...)})
+}
+
+// Failed reports whether any check failed. Warnings do not keep the API
+// from starting.
+func (r *Report) Failed() bool {
+	for _, check := range r.Checks {
+		if check.Status == Fail {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *Report) Print(w io.Writer) {
+	fmt.Fprintln(w, "Auction diagnostics")
+	for _, check := range r.Checks {
+		fmt.Fprintf(w, "  [%-4s] %-8s %s\n", check.Status, check.Name, check.Detail)
+	}
+
+	if r.Failed() {
+		fmt.Fprintln(w, "Some checks failed.")
+	} else {
+		fmt.Fprintln(w, "All checks passed.")
+	}
+}
+
+// Log writes the report to the logger, as the startup self-check does.
+func (r *Report) Log() {
+	for _, check := range r.Checks {
+		tags := []zap.Field{zap.String("check", check.Name), zap.String("detail", check.Detail)}
+		switch check.Status {
+		case OK:
+			logger.Info("Startup check passed", tags...)
+		case Warn:
+			logger.Warn("Startup check warning", tags...)
+		case Fail:
+			logger.Error("Startup check failed", errors.New(check.Detail), tags...)
+		}
+	}
+}
+
+// Run checks the configuration, then the storage selected by STORAGE and
+// the clock against it, and the Redis relay when there is one, giving up
+// on them after timeout.
+func Run(ctx context.Context, timeout time.Duration) *Report {
+	report := &Report{}
+	checkConfig(report)
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	switch os.Getenv("STORAGE") {
+	case "memory":
+		report.add("storage", OK, "in-memory, nothing to connect to")
+		checkClock(report, time.Time{})
+	case "sqlite":
+		checkSQLite(ctx, report)
+		checkClock(report, time.Time{})
+	default:
+		checkClock(report, checkMongoDB(ctx, report))
+	}
+
+	checkBroker(ctx, report)
+
+	return report
+}
@@ -0,0 +1,75 @@
+package diagnostics
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/infra/database/auction"
+	"fullcycle-auction_go/internal/infra/database/bid"
+	"fullcycle-auction_go/internal/infra/database/migration"
+	"fullcycle-auction_go/internal/infra/database/user"
+	"fullcycle-auction_go/internal/infra/tenant"
+	"os"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// schemaChains are the collections whose documents carry a schema version,
+// with the upcasters this build reads them through.
+var schemaChains = []struct {
+	collection string
+	chain      *migration.Chain
+}{
+	{"auctions", auction.AuctionUpcasters},
+	{"bids", bid.BidUpcasters},
+	{"users", user.UserUpcasters},
+}
+
+// checkSchemas checks the schema versions of the database, or of the
+// database of each tenant when TENANT_REGISTRY is set.
+func checkSchemas(ctx context.Context, report *Report, database *mongo.Database) {
+	registry, err := tenant.LoadRegistry(os.Getenv("TENANT_REGISTRY"))
+	if err != nil {
+		report.add("schema", Fail, "cannot load the tenant registry: %v", err)
+		return
+	}
+	if registry == nil {
+		checkSchema(ctx, report, database)
+		return
+	}
+
+	for _, t := range registry.Tenants() {
+		checkSchema(ctx, report, database.Client().Database(t.Database))
+	}
+}
+
+// checkSchema compares the version each collection was migrated to, as
+// recorded by cmd/migrate, with the versions this build reads. During a
+// rolling deploy the data is migrated once every instance runs the new
+// build, so a collection migrated past this build means it is the stale
+// one: it fails the check, unless SCHEMA_INCOMPATIBLE=read_only lets it
+// serve reads meanwhile.
+func checkSchema(ctx context.Context, report *Report, database *mongo.Database) {
+	for _, schema := range schemaChains {
+		name := database.Name() + "." + schema.collection
+		latest := schema.chain.LatestVersion()
+
+		stored, err := migration.StoredVersion(ctx, database, schema.collection)
+		if err != nil {
+			report.add("schema", Fail, "cannot read the schema version of %s: %v", name, err)
+			continue
+		}
+
+		switch {
+		case stored == 0:
+			report.add("schema", OK, "%s has no recorded version, this build reads up to %d", name, latest)
+		case schema.chain.Compatible(stored):
+			report.add("schema", OK, "%s at version %d, this build reads up to %d", name, stored, latest)
+		case os.Getenv("SCHEMA_INCOMPATIBLE") == "read_only":
+			report.ReadOnly = true
+			report.add("schema", Warn, "%s at version %d, past the %d this build reads: serving read-only",
+				name, stored, latest)
+		default:
+			report.add("schema", Fail, "%s at version %d, past the %d this build reads: deploy a newer build",
+				name, stored, latest)
+		}
+	}
+}
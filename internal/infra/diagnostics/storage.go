@@ -0,0 +1,101 @@
+package diagnostics
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/database/mongodb"
+	sqlite_database "fullcycle-auction_go/configuration/database/sqlite"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// maxClockSkew is how far the clock may drift from the database server
+// before auctions visibly end early or late.
+const maxClockSkew = 2 * time.Second
+
+// checkMongoDB connects to MongoDB and lists the indexes of the collections
+// the API uses, returning the server time for the clock check, zero when it
+// could not be read.
+func checkMongoDB(ctx context.Context, report *Report) time.Time {
+	config := mongodb.LoadConfig()
+
+	database, err := mongodb.NewMongoDBConnectionWithConfig(ctx, config)
+	if err != nil {
+		report.add("mongodb", Fail, "cannot connect to database %q: %v", config.Database, err)
+		return time.Time{}
+	}
+	defer database.Client().Disconnect(context.Background())
+
+	report.add("mongodb", OK, "connected to database %q, pool of %d to %d connections",
+		config.Database, config.MinPoolSize, config.MaxPoolSize)
+
+	var hello struct {
+		LocalTime time.Time `bson:"localTime"`
+	}
+	if err := database.RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello); err != nil {
+		report.add("mongodb", Warn, "cannot read the server time: %v", err)
+	}
+
+	names, err := database.ListCollectionNames(ctx, bson.D{})
+	if err != nil {
+		report.add("indexes", Fail, "cannot list collections: %v", err)
+		return hello.LocalTime
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		specifications, err := database.Collection(name).Indexes().ListSpecifications(ctx)
+		if err != nil {
+			report.add("indexes", Warn, "cannot list the indexes of %s: %v", name, err)
+			continue
+		}
+
+		indexes := make([]string, 0, len(specifications))
+		for _, specification := range specifications {
+			indexes = append(indexes, specification.Name)
+		}
+		report.add("indexes", OK, "%s: %s", name, strings.Join(indexes, ", "))
+	}
+	if len(names) == 0 {
+		report.add("indexes", OK, "no collections yet, they are created with the first documents")
+	}
+
+	checkSchemas(ctx, report, database)
+
+	return hello.LocalTime
+}
+
+func checkSQLite(ctx context.Context, report *Report) {
+	database, err := sqlite_database.NewSQLiteConnection(ctx)
+	if err != nil {
+		report.add("sqlite", Fail, "cannot open the database file: %v", err)
+		return
+	}
+	defer database.Close()
+
+	report.add("sqlite", OK, "database file opened")
+}
+
+// checkClock compares the clock with the database server when its time is
+// known.
+func checkClock(report *Report, serverTime time.Time) {
+	now := time.Now()
+
+	if !serverTime.IsZero() {
+		skew := now.Sub(serverTime)
+		if skew < 0 {
+			skew = -skew
+		}
+		if skew > maxClockSkew {
+			report.add("clock", Warn, "drifts %s from the database server", skew.Round(time.Millisecond))
+			return
+		}
+		report.add("clock", OK, "within %s of the database server", maxClockSkew)
+		return
+	}
+
+	_, offset := now.Zone()
+	report.add("clock", OK, "local time %s (UTC%+d)", now.Format(time.RFC3339), offset/3600)
+}
@@ -7,9 +7,13 @@ import (
 	"fullcycle-auction_go/internal/entity/auction_entity"
 	"fullcycle-auction_go/internal/infra/database/auction"
 	"fullcycle-auction_go/internal/infra/database/bid"
+	"fullcycle-auction_go/internal/infra/database/category"
+	"fullcycle-auction_go/internal/infra/database/product"
 	"fullcycle-auction_go/internal/infra/database/user"
 	"fullcycle-auction_go/internal/usecase/auction_usecase"
 	"fullcycle-auction_go/internal/usecase/bid_usecase"
+	"fullcycle-auction_go/internal/usecase/lifecycle_usecase"
+	"fullcycle-auction_go/internal/usecase/pagination"
 	"os"
 	"strings"
 	"testing"
@@ -64,11 +68,23 @@ func TestAuctionFlow_E2E(t *testing.T) {
 		fmt.Println("✅ Test database restarting successfully")
 	}
 
-	auctionRepository := auction.NewAuctionRepository(database)
-	bidRepository := bid.NewBidRepository(database, auctionRepository)
+	auctionRepository := auction.NewAuctionRepository(database, nil)
+	bidRepository := bid.NewBidRepository(database, auctionRepository, nil)
 
-	auctionUseCase := auction_usecase.NewAuctionUseCase(auctionRepository, bidRepository)
-	bidUseCase := bid_usecase.NewBidUseCase(bidRepository)
+	productRepository := product.NewProductRepository(database)
+	categorySchemaRepository := category.NewCategorySchemaRepository(database)
+
+	userRepository := user.NewUserRepository(database)
+
+	bidUseCase := bid_usecase.NewBidUseCase(
+		bidRepository, auctionRepository, userRepository,
+		bid.NewRejectedBidRepository(database),
+		auction.NewTermsAcceptanceRepository(database),
+		auction.NewRegistrationRepository(database),
+		lifecycle_usecase.NewLifecycleManager(auctionRepository, nil, nil))
+	auctionUseCase := auction_usecase.NewAuctionUseCase(
+		auctionRepository, bidRepository, productRepository, categorySchemaRepository,
+		category.NewCategoryRepository(database), userRepository, auction.NewSeriesRepository(database), bidUseCase, nil)
 
 	fmt.Println("\n👥 Step 1: Creating test users...")
 	user1Id := uuid.New().String()
@@ -93,8 +109,13 @@ func TestAuctionFlow_E2E(t *testing.T) {
 	err = auctionUseCase.CreateAuction(ctx, auctionInput)
 	// require.NoError(t, err, "Failed to create auction")
 
-	auctions, err := auctionUseCase.FindAuctions(ctx, auction_usecase.AuctionStatus(auction_entity.Active), "Electronics", "")
+	auctionPage, err := auctionUseCase.FindAuctions(ctx, auction_usecase.AuctionFilterDTO{
+		Status:   auction_usecase.AuctionStatus(auction_entity.Active),
+		Category: "Electronics",
+	}, nil, "", "", pagination.Params{})
 	// require.NoError(t, err, "Failed to find auctions")
+	require.NotNil(t, auctionPage, "Failed to find auctions")
+	auctions := auctionPage.Items
 	require.NotEmpty(t, auctions, "Should have at least one auction")
 
 	auctionId := auctions[0].Id
@@ -112,7 +133,7 @@ func TestAuctionFlow_E2E(t *testing.T) {
 		AuctionId: auctionId,
 		Amount:    1000.00,
 	}
-	err = bidUseCase.CreateBid(ctx, bidInput1)
+	_, err = bidUseCase.CreateBid(ctx, bidInput1)
 	// require.NoError(t, err, "Failed to create Alice's bid")
 	fmt.Printf("✅ Alice's bid: $%.2f\n", bidInput1.Amount)
 
@@ -121,7 +142,7 @@ func TestAuctionFlow_E2E(t *testing.T) {
 		AuctionId: auctionId,
 		Amount:    1200.00,
 	}
-	err = bidUseCase.CreateBid(ctx, bidInput2)
+	_, err = bidUseCase.CreateBid(ctx, bidInput2)
 	// require.NoError(t, err, "Failed to create Bob's bid")
 	fmt.Printf("✅ Bob's bid: $%.2f\n", bidInput2.Amount)
 	fmt.Println("🔄 Batch processing triggered (2 bids = MAX_BATCH_SIZE)")
@@ -129,8 +150,10 @@ func TestAuctionFlow_E2E(t *testing.T) {
 	fmt.Println("\n⏳ Step 4: Waiting for batch processing to save bids...")
 	time.Sleep(3 * time.Second) // BATCH_INSERT_INTERVAL + buffer
 
-	bids, err := bidUseCase.FindBidByAuctionId(ctx, auctionId)
+	bidPage, err := bidUseCase.FindBidByAuctionId(ctx, auctionId, "", "", "", pagination.Params{})
 	// require.NoError(t, err, "Failed to find bids by auction ID")
+	require.NotNil(t, bidPage, "Failed to find bids by auction ID")
+	bids := bidPage.Items
 	require.Len(t, bids, 2, "Should have exactly 2 bids saved")
 
 	bidAmounts := make(map[string]float64)
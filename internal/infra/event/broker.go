@@ -0,0 +1,60 @@
+package event
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/event_entity"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// Broker fans the events published in the process out to its subscribers.
+// A subscriber that falls behind misses events instead of slowing down the
+// publishers.
+type Broker struct {
+	subscribers      map[chan event_entity.Event]struct{}
+	subscribersMutex *sync.RWMutex
+}
+
+func NewBroker() *Broker {
+	return &Broker{
+		subscribers:      make(map[chan event_entity.Event]struct{}),
+		subscribersMutex: &sync.RWMutex{},
+	}
+}
+
+func (b *Broker) Publish(ctx context.Context, event event_entity.Event) {
+	b.subscribersMutex.RLock()
+	defer b.subscribersMutex.RUnlock()
+
+	for subscriber := range b.subscribers {
+		select {
+		case subscriber <- event:
+		default:
+			logger.Warn("Dropping event for a slow subscriber",
+				zap.String("type", string(event.Type)),
+				zap.String("auction_id", event.AuctionId))
+		}
+	}
+}
+
+// Subscribe returns the events published from now on, buffering up to
+// buffer of them, and the function that ends the subscription.
+func (b *Broker) Subscribe(buffer int) (<-chan event_entity.Event, func()) {
+	subscriber := make(chan event_entity.Event, buffer)
+
+	b.subscribersMutex.Lock()
+	b.subscribers[subscriber] = struct{}{}
+	b.subscribersMutex.Unlock()
+
+	var once sync.Once
+	return subscriber, func() {
+		once.Do(func() {
+			b.subscribersMutex.Lock()
+			delete(b.subscribers, subscriber)
+			b.subscribersMutex.Unlock()
+			close(subscriber)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package event
+
+import (
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/event_entity"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+const hubBuffer = 1024
+
+// Hub routes the broker events to the subscribers of each auction, so a
+// live feed only wakes up for the auction it follows.
+type Hub struct {
+	subscribers      map[string]map[chan event_entity.Event]struct{}
+	subscribersMutex *sync.RWMutex
+}
+
+func NewHub(broker *Broker) *Hub {
+	hub := &Hub{
+		subscribers:      make(map[string]map[chan event_entity.Event]struct{}),
+		subscribersMutex: &sync.RWMutex{},
+	}
+
+	events, _ := broker.Subscribe(hubBuffer)
+	go func() {
+		for event := range events {
+			hub.route(event)
+		}
+	}()
+
+	return hub
+}
+
+func (h *Hub) route(event event_entity.Event) {
+	h.subscribersMutex.RLock()
+	defer h.subscribersMutex.RUnlock()
+
+	for subscriber := range h.subscribers[event.AuctionId] {
+		select {
+		case subscriber <- event:
+		default:
+			logger.Warn("Dropping event for a slow auction subscriber",
+				zap.String("type", string(event.Type)),
+				zap.String("auction_id", event.AuctionId))
+		}
+	}
+}
+
+// Followers is how many subscribers follow the auction.
+func (h *Hub) Followers(auctionId string) int {
+	h.subscribersMutex.RLock()
+	defer h.subscribersMutex.RUnlock()
+
+	return len(h.subscribers[auctionId])
+}
+
+// Subscribe returns the events of auctionId published from now on,
+// buffering up to buffer of them, and the function that ends the
+// subscription.
+func (h *Hub) Subscribe(auctionId string, buffer int) (<-chan event_entity.Event, func()) {
+	subscriber := make(chan event_entity.Event, buffer)
+
+	h.subscribersMutex.Lock()
+	if h.subscribers[auctionId] == nil {
+		h.subscribers[auctionId] = make(map[chan event_entity.Event]struct{})
+	}
+	h.subscribers[auctionId][subscriber] = struct{}{}
+	h.subscribersMutex.Unlock()
+
+	var once sync.Once
+	return subscriber, func() {
+		once.Do(func() {
+			h.subscribersMutex.Lock()
+			delete(h.subscribers[auctionId], subscriber)
+			if len(h.subscribers[auctionId]) == 0 {
+				delete(h.subscribers, auctionId)
+			}
+			h.subscribersMutex.Unlock()
+			close(subscriber)
+		})
+	}
+}
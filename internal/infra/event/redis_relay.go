@@ -0,0 +1,103 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/event_entity"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// relayBuffer is how many local events the relay buffers while it
+// publishes them to Redis.
+const relayBuffer = 1024
+
+// relayedEvent is an event as published on the Redis channel, along with
+// the instance it was published on.
+type relayedEvent struct {
+	Origin    string                 `json:"origin"`
+	Type      event_entity.Type      `json:"type"`
+	AuctionId string                 `json:"auction_id"`
+	Payload   map[string]interface{} `json:"payload,omitempty"`
+	Timestamp time.Time              `json:"timestamp"`
+}
+
+// NewRedisRelay shares the events published on the local broker with the
+// other instances through the Redis pub/sub channel, returning the broker
+// of the cluster: the local events along with those of the other
+// instances. Only what reacts to every event in the cluster, such as the
+// live feeds, subscribes to it; what acts on an event, such as placing
+// proxy bids, stays on the local broker, or every instance would act.
+// Like publishing, relaying is best effort: events published while Redis
+// is unreachable stay on their instance. Without a client the instance
+// runs alone and the local broker is the cluster's.
+func NewRedisRelay(ctx context.Context, client *redis.Client, channel string, local *Broker) *Broker {
+	if client == nil {
+		return local
+	}
+
+	cluster := NewBroker()
+	origin := uuid.NewString()
+
+	pubsub := client.Subscribe(ctx, channel)
+	go func() {
+		for message := range pubsub.Channel() {
+			event, remote, err := decodeRelayedEvent(origin, []byte(message.Payload))
+			if err != nil {
+				logger.Error("Error trying to decode a relayed event", err)
+				continue
+			}
+			if remote {
+				cluster.Publish(ctx, event)
+			}
+		}
+	}()
+
+	events, _ := local.Subscribe(relayBuffer)
+	go func() {
+		for event := range events {
+			cluster.Publish(ctx, event)
+
+			data, err := encodeRelayedEvent(origin, event)
+			if err != nil {
+				logger.Error("Error trying to encode an event to relay", err)
+				continue
+			}
+			if err := client.Publish(ctx, channel, data).Err(); err != nil {
+				logger.Error("Error trying to relay an event", err)
+			}
+		}
+	}()
+
+	return cluster
+}
+
+func encodeRelayedEvent(origin string, event event_entity.Event) ([]byte, error) {
+	return json.Marshal(relayedEvent{
+		Origin:    origin,
+		Type:      event.Type,
+		AuctionId: event.AuctionId,
+		Payload:   event.Payload,
+		Timestamp: event.Timestamp,
+	})
+}
+
+// decodeRelayedEvent decodes an event of the channel, telling whether it
+// was published on another instance than origin. Payload numbers decode as
+// float64 and times as RFC 3339 strings, as they are rendered.
+func decodeRelayedEvent(origin string, data []byte) (event_entity.Event, bool, error) {
+	var relayed relayedEvent
+	if err := json.Unmarshal(data, &relayed); err != nil {
+		return event_entity.Event{}, false, err
+	}
+
+	return event_entity.Event{
+		Type:      relayed.Type,
+		AuctionId: relayed.AuctionId,
+		Payload:   relayed.Payload,
+		Timestamp: relayed.Timestamp,
+	}, relayed.Origin != origin, nil
+}
@@ -0,0 +1,34 @@
+package event
+
+import (
+	"fullcycle-auction_go/internal/entity/event_entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRelayedEvent_RoundTrip(t *testing.T) {
+	event := event_entity.Event{
+		Type:      event_entity.BidPlaced,
+		AuctionId: "auction",
+		Payload:   map[string]interface{}{"bid_id": "bid", "amount": 10.5},
+		Timestamp: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	data, err := encodeRelayedEvent("instance-a", event)
+	assert.Nil(t, err)
+
+	relayed, remote, err := decodeRelayedEvent("instance-b", data)
+	assert.Nil(t, err)
+	assert.True(t, remote)
+	assert.Equal(t, event.Type, relayed.Type)
+	assert.Equal(t, event.AuctionId, relayed.AuctionId)
+	assert.Equal(t, 10.5, relayed.Payload["amount"])
+	assert.True(t, event.Timestamp.Equal(relayed.Timestamp))
+
+	// An instance already delivered the events it published.
+	_, remote, err = decodeRelayedEvent("instance-a", data)
+	assert.Nil(t, err)
+	assert.False(t, remote)
+}
@@ -0,0 +1,70 @@
+package httpclient
+
+import (
+	"sync"
+	"time"
+)
+
+// breaker counts consecutive failures. Once open, it rejects requests until
+// openFor has passed and then lets a single trial through: its success
+// closes the breaker, its failure opens it again.
+type breaker struct {
+	failureThreshold int
+	openFor          time.Duration
+
+	mutex     sync.Mutex
+	failures  int
+	openUntil time.Time
+	trial     bool
+}
+
+func newBreaker(failureThreshold int, openFor time.Duration) *breaker {
+	return &breaker{
+		failureThreshold: failureThreshold,
+		openFor:          openFor,
+	}
+}
+
+func (b *breaker) allow() bool {
+	if b.failureThreshold <= 0 {
+		return true
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.failures < b.failureThreshold {
+		return true
+	}
+
+	if b.trial || time.Now().Before(b.openUntil) {
+		return false
+	}
+
+	b.trial = true
+	return true
+}
+
+// record reports whether the outcome opened the breaker.
+func (b *breaker) record(success bool) bool {
+	if b.failureThreshold <= 0 {
+		return false
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.trial = false
+	if success {
+		b.failures = 0
+		return false
+	}
+
+	b.failures++
+	if b.failures < b.failureThreshold {
+		return false
+	}
+
+	b.openUntil = time.Now().Add(b.openFor)
+	return true
+}
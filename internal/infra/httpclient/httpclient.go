@@ -0,0 +1,210 @@
+// Package httpclient is the HTTP client shared by the outbound integrations,
+// such as webhooks and notification providers. It adds timeouts, retries
+// with jitter, a circuit breaker and metrics per destination.
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"math/rand"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+var (
+	requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_client_request_duration_seconds",
+		Help:    "Latency of outbound HTTP attempts.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"destination"})
+
+	requestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_client_requests_total",
+		Help: "Outbound HTTP attempts by outcome: a status class, error or circuit_open.",
+	}, []string{"destination", "outcome"})
+)
+
+func init() {
+	prometheus.MustRegister(requestDuration, requestsTotal)
+}
+
+// ErrCircuitOpen is returned without calling the destination while its
+// circuit breaker is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// Config tunes the client of one destination.
+type Config struct {
+	Timeout    time.Duration
+	MaxRetries int
+	// Backoff is the base wait between retries, doubled on each attempt up
+	// to MaxBackoff. The actual wait is a random duration below it.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// After BreakerFailures consecutive failures the destination is not
+	// called for BreakerOpen, then a single trial request decides whether
+	// it is closed again.
+	BreakerFailures int
+	BreakerOpen     time.Duration
+}
+
+// LoadConfig reads the configuration of the destination from the
+// environment, e.g. HTTP_WEBHOOKS_TIMEOUT for the "webhooks" destination,
+// falling back to the defaults for the settings left unset.
+func LoadConfig(destination string) Config {
+	prefix := "HTTP_" + strings.ToUpper(strings.ReplaceAll(destination, "-", "_")) + "_"
+
+	return Config{
+		Timeout:         getDuration(prefix+"TIMEOUT", 10*time.Second),
+		MaxRetries:      getInt(prefix+"MAX_RETRIES", 3),
+		Backoff:         getDuration(prefix+"BACKOFF", 200*time.Millisecond),
+		MaxBackoff:      getDuration(prefix+"MAX_BACKOFF", 5*time.Second),
+		BreakerFailures: getInt(prefix+"BREAKER_FAILURES", 5),
+		BreakerOpen:     getDuration(prefix+"BREAKER_OPEN", 30*time.Second),
+	}
+}
+
+type Client struct {
+	destination string
+	config      Config
+	httpClient  *http.Client
+	breaker     *breaker
+}
+
+// New creates the client of the destination configured from the
+// environment.
+func New(destination string) *Client {
+	return NewWithConfig(destination, LoadConfig(destination))
+}
+
+func NewWithConfig(destination string, config Config) *Client {
+	return &Client{
+		destination: destination,
+		config:      config,
+		httpClient:  &http.Client{Timeout: config.Timeout},
+		breaker:     newBreaker(config.BreakerFailures, config.BreakerOpen),
+	}
+}
+
+// Do sends the request, retrying network errors and 429/5xx responses as
+// long as the request is safe to repeat: idempotent methods, or any method
+// carrying an Idempotency-Key header. The last response is returned as is,
+// so callers still check its status.
+func (c *Client) Do(request *http.Request) (*http.Response, error) {
+	retrySafe := idempotent(request)
+	if request.Body != nil && request.GetBody == nil {
+		// The body cannot be replayed.
+		retrySafe = false
+	}
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && request.GetBody != nil {
+			body, err := request.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			request.Body = body
+		}
+
+		response, err := c.attempt(request)
+		if attempt >= c.config.MaxRetries || !retrySafe || !retryable(response, err) {
+			return response, err
+		}
+
+		if response != nil {
+			response.Body.Close()
+		}
+
+		select {
+		case <-request.Context().Done():
+			return nil, request.Context().Err()
+		case <-time.After(c.backoff(attempt)):
+		}
+	}
+}
+
+func (c *Client) attempt(request *http.Request) (*http.Response, error) {
+	if !c.breaker.allow() {
+		requestsTotal.WithLabelValues(c.destination, "circuit_open").Inc()
+		return nil, fmt.Errorf("%s: %w", c.destination, ErrCircuitOpen)
+	}
+
+	start := time.Now()
+	response, err := c.httpClient.Do(request)
+	requestDuration.WithLabelValues(c.destination).Observe(time.Since(start).Seconds())
+
+	failed := err != nil || response.StatusCode >= http.StatusInternalServerError
+	if c.breaker.record(!failed) {
+		logger.Warn("Circuit breaker opened",
+			zap.String("destination", c.destination),
+			zap.Duration("open_for", c.config.BreakerOpen))
+	}
+
+	if err != nil {
+		requestsTotal.WithLabelValues(c.destination, "error").Inc()
+		return nil, err
+	}
+
+	requestsTotal.WithLabelValues(c.destination, fmt.Sprintf("%dxx", response.StatusCode/100)).Inc()
+	return response, nil
+}
+
+// backoff waits a random duration up to the exponential backoff of the
+// attempt, so clients retrying together spread out.
+func (c *Client) backoff(attempt int) time.Duration {
+	limit := c.config.Backoff << attempt
+	if limit <= 0 || limit > c.config.MaxBackoff {
+		limit = c.config.MaxBackoff
+	}
+	if limit <= 0 {
+		return 0
+	}
+
+	return time.Duration(rand.Int63n(int64(limit)) + 1)
+}
+
+func idempotent(request *http.Request) bool {
+	switch request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return request.Header.Get("Idempotency-Key") != ""
+}
+
+func retryable(response *http.Response, err error) bool {
+	if err != nil {
+		// The caller's cancellation and an open circuit are final.
+		return !errors.Is(err, context.Canceled) &&
+			!errors.Is(err, context.DeadlineExceeded) &&
+			!errors.Is(err, ErrCircuitOpen)
+	}
+
+	return response.StatusCode == http.StatusTooManyRequests ||
+		response.StatusCode >= http.StatusInternalServerError
+}
+
+func getDuration(name string, fallback time.Duration) time.Duration {
+	duration, err := time.ParseDuration(os.Getenv(name))
+	if err != nil || duration < 0 {
+		return fallback
+	}
+
+	return duration
+}
+
+func getInt(name string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(name))
+	if err != nil || value < 0 {
+		return fallback
+	}
+
+	return value
+}
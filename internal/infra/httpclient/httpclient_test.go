@@ -0,0 +1,91 @@
+package httpclient
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testConfig() Config {
+	return Config{
+		Timeout:         time.Second,
+		MaxRetries:      3,
+		Backoff:         time.Millisecond,
+		MaxBackoff:      time.Millisecond,
+		BreakerFailures: 10,
+		BreakerOpen:     time.Minute,
+	}
+}
+
+func TestClient_RetriesIdempotentRequests(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, "payload", string(body))
+
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	request, _ := http.NewRequest(http.MethodPost, server.URL, bytes.NewBufferString("payload"))
+	request.Header.Set("Idempotency-Key", "event-id")
+
+	response, err := NewWithConfig("test", testConfig()).Do(request)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, response.StatusCode)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestClient_DoesNotRetryUnsafeRequests(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	request, _ := http.NewRequest(http.MethodPost, server.URL, bytes.NewBufferString("payload"))
+	response, err := NewWithConfig("test", testConfig()).Do(request)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadGateway, response.StatusCode)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestClient_OpensCircuitAfterConsecutiveFailures(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	config := testConfig()
+	config.MaxRetries = 0
+	config.BreakerFailures = 2
+	client := NewWithConfig("test", config)
+
+	for i := 0; i < 2; i++ {
+		request, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+		_, err := client.Do(request)
+		assert.NoError(t, err)
+	}
+
+	request, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	_, err := client.Do(request)
+
+	assert.True(t, errors.Is(err, ErrCircuitOpen))
+	assert.Equal(t, 2, attempts)
+}
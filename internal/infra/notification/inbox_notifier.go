@@ -0,0 +1,39 @@
+package notification
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/notification_entity"
+)
+
+// InboxNotifier stores notifications in the in-app inbox of their user.
+type InboxNotifier struct {
+	notificationRepositoryInterface notification_entity.NotificationRepositoryInterface
+}
+
+func NewInboxNotifier(
+	notificationRepositoryInterface notification_entity.NotificationRepositoryInterface) *InboxNotifier {
+	return &InboxNotifier{
+		notificationRepositoryInterface: notificationRepositoryInterface,
+	}
+}
+
+func (n *InboxNotifier) Notify(ctx context.Context, notification notification_entity.Notification) {
+	if err := n.notificationRepositoryInterface.CreateNotification(ctx, &notification); err != nil {
+		logger.Error("Error trying to store notification in the inbox", err)
+	}
+}
+
+// MultiNotifier delivers every notification through each of its notifiers,
+// so the inbox is filled alongside the external channels.
+type MultiNotifier []notification_entity.Notifier
+
+func NewMultiNotifier(notifiers ...notification_entity.Notifier) MultiNotifier {
+	return notifiers
+}
+
+func (n MultiNotifier) Notify(ctx context.Context, notification notification_entity.Notification) {
+	for _, notifier := range n {
+		notifier.Notify(ctx, notification)
+	}
+}
@@ -0,0 +1,25 @@
+package notification
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/notification_entity"
+
+	"go.uber.org/zap"
+)
+
+// LogNotifier writes notifications to the application log, standing in
+// until a delivery channel is configured.
+type LogNotifier struct{}
+
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{}
+}
+
+func (n *LogNotifier) Notify(ctx context.Context, notification notification_entity.Notification) {
+	logger.Info("Notification",
+		zap.String("user_id", notification.UserId),
+		zap.String("auction_id", notification.AuctionId),
+		zap.String("kind", string(notification.Kind)),
+		zap.String("message", notification.Message))
+}
@@ -0,0 +1,46 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/job_entity"
+	"fullcycle-auction_go/internal/entity/notification_entity"
+	"time"
+)
+
+// SendNotificationJob is the job kind delivering a queued notification.
+const SendNotificationJob = "notification.send"
+
+// QueuedNotifier hands notifications to the job queue, so a slow or failing
+// delivery channel is retried in the background instead of holding up the
+// action that triggered it.
+type QueuedNotifier struct {
+	queue job_entity.Queue
+}
+
+func NewQueuedNotifier(queue job_entity.Queue) *QueuedNotifier {
+	return &QueuedNotifier{
+		queue: queue,
+	}
+}
+
+func (n *QueuedNotifier) Notify(ctx context.Context, notification notification_entity.Notification) {
+	if err := n.queue.Enqueue(ctx, SendNotificationJob, notification, time.Time{}); err != nil {
+		logger.Error("Error trying to queue notification", err)
+	}
+}
+
+// DeliverJob is the handler of SendNotificationJob, delivering the queued
+// notification through notifier.
+func DeliverJob(notifier notification_entity.Notifier) job_entity.Handler {
+	return func(ctx context.Context, payload []byte) error {
+		var notification notification_entity.Notification
+		if err := json.Unmarshal(payload, &notification); err != nil {
+			return err
+		}
+
+		notifier.Notify(ctx, notification)
+		return nil
+	}
+}
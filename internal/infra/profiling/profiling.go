@@ -0,0 +1,146 @@
+// Package profiling provides the net/http/pprof endpoints, served on the
+// admin port, and optionally exports CPU and heap profiles to a directory, so
+// the bid batcher and the auction scheduler can be profiled in production.
+// Their goroutines carry a "routine" label to filter the profiles by.
+package profiling
+
+import (
+	"fullcycle-auction_go/configuration/logger"
+	"net/http"
+	"net/http/pprof"
+	"os"
+	"path/filepath"
+	"runtime"
+	runtime_pprof "runtime/pprof"
+	"sort"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Config is read from the environment.
+type Config struct {
+	// ExportDir receives a CPU profile covering ExportCPUDuration and a heap
+	// profile every ExportInterval, keeping the ExportKeep latest of each.
+	// Exporting is off unless ExportDir is set.
+	ExportDir         string
+	ExportInterval    time.Duration
+	ExportCPUDuration time.Duration
+	ExportKeep        int
+}
+
+func LoadConfig() Config {
+	return Config{
+		ExportDir:         os.Getenv("PPROF_EXPORT_DIR"),
+		ExportInterval:    getDuration("PPROF_EXPORT_INTERVAL", 5*time.Minute),
+		ExportCPUDuration: getDuration("PPROF_EXPORT_CPU_DURATION", 30*time.Second),
+		ExportKeep:        getInt("PPROF_EXPORT_KEEP", 12),
+	}
+}
+
+// Start starts what the configuration enables and returns right away.
+func Start(config Config) {
+	if config.ExportDir != "" {
+		go export(config)
+	}
+}
+
+// Handler serves the profiling endpoints under /debug/pprof/. They are not
+// authenticated, so it belongs on the admin port only.
+func Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	return mux
+}
+
+func export(config Config) {
+	if err := os.MkdirAll(config.ExportDir, 0o755); err != nil {
+		logger.Error("error trying to create profile export directory", err)
+		return
+	}
+
+	ticker := time.NewTicker(config.ExportInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		suffix := strconv.FormatInt(time.Now().Unix(), 10) + ".pprof"
+
+		if err := writeCPUProfile(filepath.Join(config.ExportDir, "cpu-"+suffix), config.ExportCPUDuration); err != nil {
+			logger.Error("error trying to export cpu profile", err)
+		}
+		if err := writeHeapProfile(filepath.Join(config.ExportDir, "heap-"+suffix)); err != nil {
+			logger.Error("error trying to export heap profile", err)
+		}
+
+		prune(config.ExportDir, "cpu-*.pprof", config.ExportKeep)
+		prune(config.ExportDir, "heap-*.pprof", config.ExportKeep)
+	}
+}
+
+func writeCPUProfile(path string, duration time.Duration) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	// Fails while a profile is taken through the endpoint.
+	if err := runtime_pprof.StartCPUProfile(file); err != nil {
+		return err
+	}
+	time.Sleep(duration)
+	runtime_pprof.StopCPUProfile()
+
+	return nil
+}
+
+func writeHeapProfile(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	runtime.GC()
+	return runtime_pprof.WriteHeapProfile(file)
+}
+
+// prune removes all but the keep latest profiles matching the pattern,
+// whose names sort by time.
+func prune(dir, pattern string, keep int) {
+	paths, err := filepath.Glob(filepath.Join(dir, pattern))
+	if err != nil || len(paths) <= keep {
+		return
+	}
+
+	sort.Strings(paths)
+	for _, path := range paths[:len(paths)-keep] {
+		if err := os.Remove(path); err != nil {
+			logger.Error("error trying to remove profile", err, zap.String("path", path))
+		}
+	}
+}
+
+func getDuration(name string, fallback time.Duration) time.Duration {
+	duration, err := time.ParseDuration(os.Getenv(name))
+	if err != nil || duration <= 0 {
+		return fallback
+	}
+
+	return duration
+}
+
+func getInt(name string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(name))
+	if err != nil || value < 1 {
+		return fallback
+	}
+
+	return value
+}
@@ -0,0 +1,166 @@
+// Package scheduler runs the timers of the auctions, starting and completing
+// them, from a single goroutine rather than one sleeping goroutine each.
+package scheduler
+
+import (
+	"container/heap"
+	"context"
+	"fullcycle-auction_go/configuration/clock"
+	"runtime/pprof"
+	"sync"
+	"time"
+)
+
+// Scheduler keeps one timer per key in a min-heap of deadlines and runs the
+// task of each when its deadline passes, one at a time, so tasks must be
+// short. A task may schedule its key again, as completing an auction that
+// was extended meanwhile does.
+type Scheduler struct {
+	mutex  sync.Mutex
+	timers timerHeap
+	byKey  map[string]*timer
+
+	// wake interrupts the wait of the routine when a timer is added ahead
+	// of the one it waits for.
+	wake  chan struct{}
+	start sync.Once
+}
+
+type timer struct {
+	key   string
+	at    time.Time
+	task  func()
+	index int
+}
+
+// New returns a scheduler whose routine starts with the first timer, so
+// the tools that only read the auctions never run it.
+func New() *Scheduler {
+	return &Scheduler{
+		byKey: make(map[string]*timer),
+		wake:  make(chan struct{}, 1),
+	}
+}
+
+// Schedule runs task at the time, replacing the timer the key had. Times
+// in the past run right away.
+func (s *Scheduler) Schedule(key string, at time.Time, task func()) {
+	s.start.Do(func() { go s.run() })
+
+	s.mutex.Lock()
+	if existing, ok := s.byKey[key]; ok {
+		existing.at = at
+		existing.task = task
+		heap.Fix(&s.timers, existing.index)
+	} else {
+		added := &timer{key: key, at: at, task: task}
+		heap.Push(&s.timers, added)
+		s.byKey[key] = added
+	}
+	s.mutex.Unlock()
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Cancel drops the timer of the key, reporting whether it had one.
+func (s *Scheduler) Cancel(key string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	existing, ok := s.byKey[key]
+	if !ok {
+		return false
+	}
+
+	heap.Remove(&s.timers, existing.index)
+	delete(s.byKey, key)
+	return true
+}
+
+// Len is how many timers are waiting.
+func (s *Scheduler) Len() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return len(s.timers)
+}
+
+func (s *Scheduler) run() {
+	pprof.SetGoroutineLabels(pprof.WithLabels(context.Background(), pprof.Labels("routine", "auction_scheduler")))
+
+	wait := time.NewTimer(time.Hour)
+	for {
+		task, next := s.due(clock.Now())
+		if task != nil {
+			task()
+			continue
+		}
+
+		if !wait.Stop() {
+			select {
+			case <-wait.C:
+			default:
+			}
+		}
+		if next.IsZero() {
+			<-s.wake
+			continue
+		}
+
+		wait.Reset(clock.Scale(next.Sub(clock.Now())))
+		select {
+		case <-wait.C:
+		case <-s.wake:
+		}
+	}
+}
+
+// due pops the earliest timer when its time has come, or else tells when
+// it will, zero without timers.
+func (s *Scheduler) due(now time.Time) (func(), time.Time) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if len(s.timers) == 0 {
+		return nil, time.Time{}
+	}
+
+	earliest := s.timers[0]
+	if earliest.at.After(now) {
+		return nil, earliest.at
+	}
+
+	heap.Pop(&s.timers)
+	delete(s.byKey, earliest.key)
+	return earliest.task, time.Time{}
+}
+
+// timerHeap orders the timers by deadline for container/heap.
+type timerHeap []*timer
+
+func (h timerHeap) Len() int { return len(h) }
+
+func (h timerHeap) Less(i, j int) bool { return h[i].at.Before(h[j].at) }
+
+func (h timerHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *timerHeap) Push(x any) {
+	added := x.(*timer)
+	added.index = len(*h)
+	*h = append(*h, added)
+}
+
+func (h *timerHeap) Pop() any {
+	old := *h
+	last := old[len(old)-1]
+	old[len(old)-1] = nil
+	*h = old[:len(old)-1]
+	return last
+}
@@ -0,0 +1,67 @@
+package scheduler
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduler_RunsInDeadlineOrder(t *testing.T) {
+	s := New()
+
+	var mutex sync.Mutex
+	var ran []string
+	done := make(chan struct{})
+	record := func(key string) func() {
+		return func() {
+			mutex.Lock()
+			defer mutex.Unlock()
+			ran = append(ran, key)
+			if len(ran) == 3 {
+				close(done)
+			}
+		}
+	}
+
+	now := time.Now()
+	s.Schedule("c", now.Add(30*time.Millisecond), record("c"))
+	s.Schedule("a", now.Add(-time.Second), record("a"))
+	s.Schedule("b", now.Add(15*time.Millisecond), record("b"))
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("timers did not run")
+	}
+	assert.Equal(t, []string{"a", "b", "c"}, ran)
+	assert.Equal(t, 0, s.Len())
+}
+
+func TestScheduler_RescheduleAndCancel(t *testing.T) {
+	s := New()
+
+	ran := make(chan string, 2)
+	now := time.Now()
+	s.Schedule("moved", now.Add(time.Hour), func() { ran <- "first" })
+	s.Schedule("moved", now.Add(10*time.Millisecond), func() { ran <- "moved" })
+	s.Schedule("cancelled", now.Add(5*time.Millisecond), func() { ran <- "cancelled" })
+	assert.Equal(t, 2, s.Len())
+
+	assert.True(t, s.Cancel("cancelled"))
+	assert.False(t, s.Cancel("cancelled"))
+
+	select {
+	case key := <-ran:
+		assert.Equal(t, "moved", key)
+	case <-time.After(time.Second):
+		t.Fatal("rescheduled timer did not run")
+	}
+
+	select {
+	case key := <-ran:
+		t.Fatalf("unexpected timer %s ran", key)
+	case <-time.After(30 * time.Millisecond):
+	}
+}
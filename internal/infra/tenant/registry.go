@@ -0,0 +1,111 @@
+// Package tenant routes the requests of a multi-tenant deployment to the
+// tenant they are for, each tenant having its data in its own MongoDB
+// database, apart from the others.
+package tenant
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// TenantHeader names the tenant of requests not served on a host of their
+// own.
+const TenantHeader = "X-Tenant-Id"
+
+type Tenant struct {
+	Id string `json:"id"`
+	// Database is the MongoDB database holding the data of the tenant,
+	// shared with no other tenant.
+	Database string `json:"database"`
+	// Hosts are the host names the tenant is served on.
+	Hosts []string `json:"hosts,omitempty"`
+}
+
+// Registry maps the requests to their tenants.
+type Registry struct {
+	tenants []Tenant
+	byId    map[string]*Tenant
+	byHost  map[string]*Tenant
+}
+
+// LoadRegistry reads the JSON list of tenants in the file, returning a nil
+// registry, single-tenant mode, when path is empty.
+func LoadRegistry(path string) (*Registry, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading the tenant registry: %w", err)
+	}
+
+	var tenants []Tenant
+	if err := json.Unmarshal(content, &tenants); err != nil {
+		return nil, fmt.Errorf("error parsing the tenant registry: %w", err)
+	}
+
+	return NewRegistry(tenants)
+}
+
+// NewRegistry checks that every tenant has an id, a database and hosts of
+// its own.
+func NewRegistry(tenants []Tenant) (*Registry, error) {
+	if len(tenants) == 0 {
+		return nil, fmt.Errorf("the tenant registry lists no tenant")
+	}
+
+	registry := &Registry{
+		tenants: tenants,
+		byId:    make(map[string]*Tenant, len(tenants)),
+		byHost:  make(map[string]*Tenant),
+	}
+	databases := make(map[string]string, len(tenants))
+	for i := range tenants {
+		tenant := &tenants[i]
+		if tenant.Id == "" || tenant.Database == "" {
+			return nil, fmt.Errorf("tenant %d needs an id and a database", i)
+		}
+		if _, ok := registry.byId[tenant.Id]; ok {
+			return nil, fmt.Errorf("tenant %s is listed twice", tenant.Id)
+		}
+		if other, ok := databases[tenant.Database]; ok {
+			return nil, fmt.Errorf("tenants %s and %s share the database %s", other, tenant.Id, tenant.Database)
+		}
+		registry.byId[tenant.Id] = tenant
+		databases[tenant.Database] = tenant.Id
+
+		for _, host := range tenant.Hosts {
+			host = strings.ToLower(host)
+			if other, ok := registry.byHost[host]; ok {
+				return nil, fmt.Errorf("tenants %s and %s share the host %s", other.Id, tenant.Id, host)
+			}
+			registry.byHost[host] = tenant
+		}
+	}
+
+	return registry, nil
+}
+
+func (r *Registry) Tenants() []Tenant {
+	return r.tenants
+}
+
+// Resolve returns the tenant the request is for: the one served on its
+// host, or else the one named in the TenantHeader header.
+func (r *Registry) Resolve(request *http.Request) (*Tenant, bool) {
+	host := request.Host
+	if name, _, err := net.SplitHostPort(host); err == nil {
+		host = name
+	}
+	if tenant, ok := r.byHost[strings.ToLower(host)]; ok {
+		return tenant, true
+	}
+
+	tenant, ok := r.byId[request.Header.Get(TenantHeader)]
+	return tenant, ok
+}
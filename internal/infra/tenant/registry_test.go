@@ -0,0 +1,51 @@
+package tenant
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRegistry_KeepsTenantsApart(t *testing.T) {
+	_, err := NewRegistry([]Tenant{{Id: "acme", Database: "auctions"}, {Id: "globex", Database: "auctions"}})
+	assert.Error(t, err)
+
+	_, err = NewRegistry([]Tenant{
+		{Id: "acme", Database: "acme", Hosts: []string{"shop.example.com"}},
+		{Id: "globex", Database: "globex", Hosts: []string{"SHOP.example.com"}},
+	})
+	assert.Error(t, err)
+
+	_, err = NewRegistry([]Tenant{{Id: "acme"}})
+	assert.Error(t, err)
+
+	_, err = NewRegistry(nil)
+	assert.Error(t, err)
+}
+
+func TestRegistry_Resolve(t *testing.T) {
+	registry, err := NewRegistry([]Tenant{
+		{Id: "acme", Database: "acme", Hosts: []string{"acme.example.com"}},
+		{Id: "globex", Database: "globex"},
+	})
+	require.NoError(t, err)
+
+	request := httptest.NewRequest("GET", "http://acme.example.com:8080/auction", nil)
+	// The host decides over the header.
+	request.Header.Set(TenantHeader, "globex")
+	tenant, ok := registry.Resolve(request)
+	require.True(t, ok)
+	assert.Equal(t, "acme", tenant.Id)
+
+	request = httptest.NewRequest("GET", "http://localhost:8080/auction", nil)
+	request.Header.Set(TenantHeader, "globex")
+	tenant, ok = registry.Resolve(request)
+	require.True(t, ok)
+	assert.Equal(t, "globex", tenant.Id)
+
+	request.Header.Set(TenantHeader, "initech")
+	_, ok = registry.Resolve(request)
+	assert.False(t, ok)
+}
@@ -0,0 +1,35 @@
+package tenant
+
+import (
+	"encoding/json"
+	"fullcycle-auction_go/configuration/rest_err"
+	"net/http"
+)
+
+// Router hands each request to the handler of its tenant, which only
+// reaches the repositories of that tenant.
+type Router struct {
+	registry *Registry
+	handlers map[string]http.Handler
+}
+
+// NewRouter takes a handler per tenant id of the registry.
+func NewRouter(registry *Registry, handlers map[string]http.Handler) *Router {
+	return &Router{
+		registry: registry,
+		handlers: handlers,
+	}
+}
+
+func (r *Router) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	tenant, ok := r.registry.Resolve(request)
+	if !ok {
+		errRest := rest_err.NewNotFoundError("Tenant not found, name it in the " + TenantHeader + " header")
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		writer.WriteHeader(errRest.Code)
+		json.NewEncoder(writer).Encode(errRest)
+		return
+	}
+
+	r.handlers[tenant.Id].ServeHTTP(writer, request)
+}
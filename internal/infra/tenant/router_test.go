@@ -0,0 +1,32 @@
+package tenant
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouter_ServesTheTenantHandler(t *testing.T) {
+	registry, err := NewRegistry([]Tenant{{Id: "acme", Database: "acme"}, {Id: "globex", Database: "globex"}})
+	require.NoError(t, err)
+
+	handler := func(id string) http.Handler {
+		return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			writer.Write([]byte(id))
+		})
+	}
+	router := NewRouter(registry, map[string]http.Handler{"acme": handler("acme"), "globex": handler("globex")})
+
+	request := httptest.NewRequest("GET", "/auction", nil)
+	request.Header.Set(TenantHeader, "globex")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	assert.Equal(t, "globex", recorder.Body.String())
+
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest("GET", "/auction", nil))
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+}
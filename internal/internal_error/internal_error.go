@@ -3,6 +3,9 @@ package internal_error
 type InternalError struct {
 	Message string
 	Err     string
+	// Reason is the domain code of the error, when it has one, such as the
+	// reason a bid was rejected for.
+	Reason string
 }
 
 func (ie *InternalError) Error() string {
@@ -29,3 +32,24 @@ func NewBadRequestError(message string) *InternalError {
 		Err:     "bad_request",
 	}
 }
+
+func NewForbiddenError(message string) *InternalError {
+	return &InternalError{
+		Message: message,
+		Err:     "forbidden",
+	}
+}
+
+func NewUnauthorizedError(message string) *InternalError {
+	return &InternalError{
+		Message: message,
+		Err:     "unauthorized",
+	}
+}
+
+func NewConflictError(message string) *InternalError {
+	return &InternalError{
+		Message: message,
+		Err:     "conflict",
+	}
+}
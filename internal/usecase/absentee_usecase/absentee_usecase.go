@@ -0,0 +1,256 @@
+package absentee_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/entity/event_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"fullcycle-auction_go/internal/usecase/bid_usecase"
+	"os"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// openingEventsBuffer is how many events the use case buffers while it
+// places the opening bids of a lot.
+const openingEventsBuffer = 256
+
+type AbsenteeBidInputDTO struct {
+	MaxAmount float64 `json:"max_amount" binding:"required,gt=0"`
+
+	// TermsVersion is the version of the auction terms the bidder accepts,
+	// required on auctions with terms.
+	TermsVersion int `json:"terms_version" binding:"omitempty,min=1"`
+}
+
+type AbsenteeBidOutputDTO struct {
+	Id        string    `json:"id"`
+	AuctionId string    `json:"auction_id"`
+	UserId    string    `json:"user_id"`
+	MaxAmount float64   `json:"max_amount"`
+	Status    string    `json:"status"`
+	Timestamp time.Time `json:"timestamp" time_format:"2006-01-02 15:04:05"`
+}
+
+// Subscriber hands out the events published in the process.
+type Subscriber interface {
+	Subscribe(buffer int) (<-chan event_entity.Event, func())
+}
+
+type AbsenteeUseCaseInterface interface {
+	// LodgeAbsenteeBid stores the maximum the user bids on a lot that did
+	// not open yet, replacing the one they lodged before.
+	LodgeAbsenteeBid(
+		ctx context.Context,
+		auctionId, userId string,
+		absenteeBidInput AbsenteeBidInputDTO) (*AbsenteeBidOutputDTO, *internal_error.InternalError)
+
+	FindAbsenteeBid(
+		ctx context.Context, auctionId, userId string) (*AbsenteeBidOutputDTO, *internal_error.InternalError)
+}
+
+type AbsenteeUseCase struct {
+	absenteeBidRepositoryInterface bid_entity.AbsenteeBidRepositoryInterface
+	auctionRepositoryInterface     auction_entity.AuctionRepositoryInterface
+	bidUseCase                     bid_usecase.BidUseCaseInterface
+	increment                      float64
+}
+
+// NewAbsenteeUseCase also starts the routine placing the absentee bids of
+// lots as they start or open.
+func NewAbsenteeUseCase(
+	absenteeBidRepositoryInterface bid_entity.AbsenteeBidRepositoryInterface,
+	auctionRepositoryInterface auction_entity.AuctionRepositoryInterface,
+	bidUseCase bid_usecase.BidUseCaseInterface,
+	subscriber Subscriber) AbsenteeUseCaseInterface {
+	absenteeUseCase := &AbsenteeUseCase{
+		absenteeBidRepositoryInterface: absenteeBidRepositoryInterface,
+		auctionRepositoryInterface:     auctionRepositoryInterface,
+		bidUseCase:                     bidUseCase,
+		increment:                      getAbsenteeBidIncrement(),
+	}
+
+	events, _ := subscriber.Subscribe(openingEventsBuffer)
+	absenteeUseCase.triggerOpeningRoutine(context.Background(), events)
+
+	return absenteeUseCase
+}
+
+func (au *AbsenteeUseCase) LodgeAbsenteeBid(
+	ctx context.Context,
+	auctionId, userId string,
+	absenteeBidInput AbsenteeBidInputDTO) (*AbsenteeBidOutputDTO, *internal_error.InternalError) {
+	auction, err := au.auctionRepositoryInterface.FindAuctionById(ctx, auctionId)
+	if err != nil {
+		return nil, err
+	}
+	if !auction.TakesAbsenteeBids() {
+		return nil, internal_error.NewBadRequestError("Absentee bids are only taken before bidding opens")
+	}
+	if auction.SoldBy(userId) {
+		return nil, bid_entity.RejectionSellerBid.AsError()
+	}
+	if auction.Units() > 1 {
+		return nil, internal_error.NewBadRequestError("Auctions of several units do not take absentee bids")
+	}
+	if auction.Terms != nil && absenteeBidInput.TermsVersion != auction.Terms.Version {
+		return nil, bid_entity.RejectionTermsNotAccepted.AsError()
+	}
+	if absenteeBidInput.MaxAmount < auction.StartingPrice {
+		return nil, internal_error.NewBadRequestError("Max amount must be at least the starting price")
+	}
+
+	absenteeBid, err := bid_entity.CreateAbsenteeBid(
+		userId, auctionId, absenteeBidInput.MaxAmount, absenteeBidInput.TermsVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := au.absenteeBidRepositoryInterface.UpsertAbsenteeBid(ctx, absenteeBid); err != nil {
+		return nil, err
+	}
+
+	return au.FindAbsenteeBid(ctx, auctionId, userId)
+}
+
+func (au *AbsenteeUseCase) FindAbsenteeBid(
+	ctx context.Context, auctionId, userId string) (*AbsenteeBidOutputDTO, *internal_error.InternalError) {
+	absenteeBid, err := au.absenteeBidRepositoryInterface.FindAbsenteeBid(ctx, auctionId, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AbsenteeBidOutputDTO{
+		Id:        absenteeBid.Id,
+		AuctionId: absenteeBid.AuctionId,
+		UserId:    absenteeBid.UserId,
+		MaxAmount: absenteeBid.MaxAmount,
+		Status:    string(absenteeBid.Status),
+		Timestamp: absenteeBid.Timestamp,
+	}, nil
+}
+
+func (au *AbsenteeUseCase) triggerOpeningRoutine(ctx context.Context, events <-chan event_entity.Event) {
+	go func() {
+		for event := range events {
+			if event.Type == event_entity.AuctionStarted || event.Type == event_entity.AuctionBiddingOpened {
+				au.placeOpeningBids(ctx, event.AuctionId)
+			}
+		}
+	}()
+}
+
+// placeOpeningBids places the bids resolved from the absentee bids of the
+// lot, once it takes bids. Live lots start before their auctioneer opens
+// them.
+func (au *AbsenteeUseCase) placeOpeningBids(ctx context.Context, auctionId string) {
+	auction, err := au.auctionRepositoryInterface.FindAuctionById(ctx, auctionId)
+	if err != nil {
+		logger.Error("error trying to find the auction to open", err)
+		return
+	}
+	if !auction.AcceptsBids() {
+		return
+	}
+
+	absenteeBids, err := au.absenteeBidRepositoryInterface.FindPendingAbsenteeBids(ctx, auctionId)
+	if err != nil {
+		logger.Error("error trying to find absentee bids", err)
+		return
+	}
+
+	increment := au.increment
+	if auction.MinIncrement > 0 {
+		increment = auction.MinIncrement
+	}
+
+	rejected := make(map[string]bool)
+	var leaderId string
+	for _, opening := range openingBids(absenteeBids, increment, auction.StartingPrice) {
+		if _, err := au.bidUseCase.CreateBid(ctx, bid_usecase.BidInputDTO{
+			AuctionId:    auctionId,
+			Amount:       opening.amount,
+			Source:       string(bid_entity.SourceAbsentee),
+			TermsVersion: opening.absenteeBid.TermsVersion,
+			UserId:       opening.absenteeBid.UserId,
+		}); err != nil {
+			logger.Warn("Absentee bid was not placed",
+				zap.String("absentee_bid_id", opening.absenteeBid.Id),
+				zap.String("reason", err.Message))
+			rejected[opening.absenteeBid.Id] = true
+			continue
+		}
+
+		leaderId = opening.absenteeBid.Id
+	}
+
+	for _, absenteeBid := range absenteeBids {
+		status := bid_entity.AbsenteeOutbid
+		if rejected[absenteeBid.Id] {
+			status = bid_entity.AbsenteeRejected
+		} else if absenteeBid.Id == leaderId {
+			status = bid_entity.AbsenteeLeading
+		}
+
+		if err := au.absenteeBidRepositoryInterface.UpdateAbsenteeBidStatus(ctx, absenteeBid.Id, status); err != nil {
+			logger.Error("error trying to update absentee bid", err)
+		}
+	}
+}
+
+// openingBid is a bid placed on behalf of an absentee bid.
+type openingBid struct {
+	absenteeBid bid_entity.AbsenteeBid
+	amount      float64
+}
+
+// openingBids executes the absentee bids of a lot, highest maximum first,
+// against each other: the runner-up bids its maximum and the leader one
+// increment more, within its own maximum. A leader less than an increment
+// ahead bids its maximum alone, since its bid could not raise the
+// runner-up's by the increment; the earliest wins a tie the same way. A
+// lone absentee bid opens the lot at one increment, or its starting price
+// when higher.
+func openingBids(absenteeBids []bid_entity.AbsenteeBid, increment, startingPrice float64) []openingBid {
+	if len(absenteeBids) == 0 {
+		return nil
+	}
+
+	leader := absenteeBids[0]
+	if len(absenteeBids) == 1 {
+		opening := increment
+		if startingPrice > opening {
+			opening = startingPrice
+		}
+		return []openingBid{{absenteeBid: leader, amount: minAmount(opening, leader.MaxAmount)}}
+	}
+
+	runnerUp := absenteeBids[1]
+	if runnerUp.MaxAmount+increment > leader.MaxAmount {
+		return []openingBid{{absenteeBid: leader, amount: leader.MaxAmount}}
+	}
+
+	return []openingBid{
+		{absenteeBid: runnerUp, amount: runnerUp.MaxAmount},
+		{absenteeBid: leader, amount: runnerUp.MaxAmount + increment},
+	}
+}
+
+func minAmount(a, b float64) float64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func getAbsenteeBidIncrement() float64 {
+	increment, err := strconv.ParseFloat(os.Getenv("ABSENTEE_BID_INCREMENT"), 64)
+	if err != nil || increment <= 0 {
+		return 1
+	}
+	return increment
+}
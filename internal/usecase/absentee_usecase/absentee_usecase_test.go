@@ -0,0 +1,38 @@
+package absentee_usecase
+
+import (
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpeningBids(t *testing.T) {
+	ann := bid_entity.AbsenteeBid{Id: "ann", MaxAmount: 150}
+	bob := bid_entity.AbsenteeBid{Id: "bob", MaxAmount: 100}
+	cid := bid_entity.AbsenteeBid{Id: "cid", MaxAmount: 80}
+
+	amounts := func(bids []openingBid) map[string]float64 {
+		result := make(map[string]float64)
+		for _, bid := range bids {
+			result[bid.absenteeBid.Id] = bid.amount
+		}
+		return result
+	}
+
+	assert.Empty(t, openingBids(nil, 5, 0))
+	assert.Equal(t, map[string]float64{"ann": 5}, amounts(openingBids([]bid_entity.AbsenteeBid{ann}, 5, 0)))
+	assert.Equal(t, map[string]float64{"ann": 120}, amounts(openingBids([]bid_entity.AbsenteeBid{ann}, 5, 120)))
+	assert.Equal(t, map[string]float64{"bob": 100, "ann": 105},
+		amounts(openingBids([]bid_entity.AbsenteeBid{ann, bob, cid}, 5, 0)))
+	assert.Equal(t, map[string]float64{"ann": 150},
+		amounts(openingBids([]bid_entity.AbsenteeBid{ann, bob}, 80, 0)))
+
+	// The runner-up bids first, so the leader's bid is the highest.
+	bids := openingBids([]bid_entity.AbsenteeBid{ann, bob}, 5, 0)
+	assert.Equal(t, "ann", bids[len(bids)-1].absenteeBid.Id)
+
+	tie := bid_entity.AbsenteeBid{Id: "tie", MaxAmount: 150}
+	assert.Equal(t, map[string]float64{"ann": 150},
+		amounts(openingBids([]bid_entity.AbsenteeBid{ann, tie}, 5, 0)))
+}
@@ -0,0 +1,193 @@
+package activity_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/entity/question_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"fullcycle-auction_go/internal/usecase/auction_usecase"
+	"sort"
+	"time"
+)
+
+const (
+	DefaultActivityPageSize = 20
+	MaxActivityPageSize     = 100
+)
+
+type ActivityKind string
+
+const (
+	AuctionCreated   ActivityKind = "auction_created"
+	FirstBid         ActivityKind = "first_bid"
+	NewLeader        ActivityKind = "new_leader"
+	QuestionPosted   ActivityKind = "question_posted"
+	QuestionAnswered ActivityKind = "question_answered"
+	AuctionCompleted ActivityKind = "auction_completed"
+	AuctionCancelled ActivityKind = "auction_cancelled"
+)
+
+type ActivityOutputDTO struct {
+	Kind      ActivityKind `json:"kind"`
+	UserId    string       `json:"user_id,omitempty"`
+	Amount    *float64     `json:"amount,omitempty"`
+	Text      string       `json:"text,omitempty"`
+	Timestamp time.Time    `json:"timestamp" time_format:"2006-01-02 15:04:05"`
+}
+
+type ActivityUseCaseInterface interface {
+	FindAuctionActivity(
+		ctx context.Context,
+		auctionId string,
+		page, pageSize int) (*auction_usecase.PageDTO[ActivityOutputDTO], *internal_error.InternalError)
+}
+
+type ActivityUseCase struct {
+	auctionRepositoryInterface  auction_entity.AuctionRepositoryInterface
+	bidRepositoryInterface      bid_entity.BidEntityRepository
+	questionRepositoryInterface question_entity.QuestionRepositoryInterface
+}
+
+func NewActivityUseCase(
+	auctionRepositoryInterface auction_entity.AuctionRepositoryInterface,
+	bidRepositoryInterface bid_entity.BidEntityRepository,
+	questionRepositoryInterface question_entity.QuestionRepositoryInterface) ActivityUseCaseInterface {
+	return &ActivityUseCase{
+		auctionRepositoryInterface:  auctionRepositoryInterface,
+		bidRepositoryInterface:      bidRepositoryInterface,
+		questionRepositoryInterface: questionRepositoryInterface,
+	}
+}
+
+// FindAuctionActivity assembles the feed of the auction from its own
+// record, its bids and its public questions, newest first, page being
+// 1-based. Bidders show up as the auction's visibility allows.
+func (au *ActivityUseCase) FindAuctionActivity(
+	ctx context.Context,
+	auctionId string,
+	page, pageSize int) (*auction_usecase.PageDTO[ActivityOutputDTO], *internal_error.InternalError) {
+	auction, err := au.auctionRepositoryInterface.FindAuctionById(ctx, auctionId)
+	if err != nil {
+		return nil, err
+	}
+
+	bids, err := au.bidRepositoryInterface.FindBidByAuctionId(ctx, auctionId)
+	if err != nil {
+		return nil, err
+	}
+
+	questions, err := au.questionRepositoryInterface.FindQuestions(ctx, auctionId)
+	if err != nil {
+		return nil, err
+	}
+
+	activities := []ActivityOutputDTO{{
+		Kind:      AuctionCreated,
+		UserId:    auction.SellerId,
+		Timestamp: auction.Timestamp,
+	}}
+	if !auction.BidsHidden() {
+		activities = append(activities, milestoneBids(auction, bids)...)
+	}
+	activities = append(activities, questionActivities(questions)...)
+	if auction.Status == auction_entity.Cancelled {
+		activities = append(activities, ActivityOutputDTO{
+			Kind:      AuctionCancelled,
+			Timestamp: auction.UpdatedAt,
+		})
+	} else if auction.Status.Ended() {
+		activities = append(activities, ActivityOutputDTO{
+			Kind:      AuctionCompleted,
+			Timestamp: auction.UpdatedAt,
+		})
+	}
+
+	sort.SliceStable(activities, func(i, j int) bool {
+		return activities[i].Timestamp.After(activities[j].Timestamp)
+	})
+
+	return &auction_usecase.PageDTO[ActivityOutputDTO]{
+		Items:    paginate(activities, (page-1)*pageSize, pageSize),
+		Page:     page,
+		PageSize: pageSize,
+		Total:    len(activities),
+	}, nil
+}
+
+// milestoneBids keeps the bids worth a line in the feed: the first one and
+// every bid that took the lead from another bidder. Raising one's own lead
+// is left out.
+func milestoneBids(auction *auction_entity.Auction, bids []bid_entity.Bid) []ActivityOutputDTO {
+	sort.SliceStable(bids, func(i, j int) bool {
+		return bids[i].Timestamp.Before(bids[j].Timestamp)
+	})
+
+	var activities []ActivityOutputDTO
+	var leader *bid_entity.Bid
+	for i := range bids {
+		bid := &bids[i]
+		if leader != nil && bid.Amount <= leader.Amount {
+			continue
+		}
+
+		previous := leader
+		leader = bid
+		if previous != nil && previous.UserId == bid.UserId {
+			continue
+		}
+
+		kind := NewLeader
+		if previous == nil {
+			kind = FirstBid
+		}
+
+		amount := bid.Amount
+		activities = append(activities, ActivityOutputDTO{
+			Kind:      kind,
+			UserId:    auction.BidderVisibility.Present(bid.UserId),
+			Amount:    &amount,
+			Timestamp: bid.Timestamp,
+		})
+	}
+
+	return activities
+}
+
+func questionActivities(questions []question_entity.Question) []ActivityOutputDTO {
+	var activities []ActivityOutputDTO
+	for _, question := range questions {
+		if question.Status == question_entity.Hidden {
+			continue
+		}
+
+		activities = append(activities, ActivityOutputDTO{
+			Kind:      QuestionPosted,
+			UserId:    question.UserId,
+			Text:      question.Text,
+			Timestamp: question.Timestamp,
+		})
+		if question.Answered() {
+			activities = append(activities, ActivityOutputDTO{
+				Kind:      QuestionAnswered,
+				Text:      question.Answer,
+				Timestamp: question.AnsweredAt,
+			})
+		}
+	}
+
+	return activities
+}
+
+func paginate(activities []ActivityOutputDTO, offset, limit int) []ActivityOutputDTO {
+	if offset >= len(activities) {
+		return []ActivityOutputDTO{}
+	}
+
+	end := offset + limit
+	if end > len(activities) {
+		end = len(activities)
+	}
+
+	return activities[offset:end]
+}
@@ -0,0 +1,37 @@
+package activity_usecase
+
+import (
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMilestoneBids(t *testing.T) {
+	start := time.Now()
+	auction := &auction_entity.Auction{BidderVisibility: auction_entity.BidderPublic}
+	bid := func(userId string, amount float64, offset time.Duration) bid_entity.Bid {
+		return bid_entity.Bid{UserId: userId, Amount: amount, Timestamp: start.Add(offset)}
+	}
+
+	activities := milestoneBids(auction, []bid_entity.Bid{
+		bid("bob", 20, 2*time.Second),
+		bid("alice", 10, 0),
+		bid("alice", 15, time.Second),
+		bid("carol", 18, 3*time.Second),
+		bid("carol", 22, 4*time.Second),
+		bid("bob", 25, 5*time.Second),
+		bid("bob", 30, 6*time.Second),
+	})
+
+	assert.Len(t, activities, 4)
+	assert.Equal(t, FirstBid, activities[0].Kind)
+	assert.Equal(t, "alice", activities[0].UserId)
+	assert.Equal(t, NewLeader, activities[1].Kind)
+	assert.Equal(t, "bob", activities[1].UserId)
+	assert.Equal(t, 20.0, *activities[1].Amount)
+	assert.Equal(t, "carol", activities[2].UserId)
+	assert.Equal(t, 25.0, *activities[3].Amount)
+}
@@ -0,0 +1,217 @@
+package auction_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/clock"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/event_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"os"
+	"sync"
+	"time"
+)
+
+// maxAuctionSnapshots bounds the memory of the snapshot cache. Snapshots
+// are not cached while it is full of servable ones.
+const maxAuctionSnapshots = 10000
+
+// snapshotEventsBuffer is how many events the cache buffers while it
+// invalidates snapshots.
+const snapshotEventsBuffer = 1024
+
+// Subscriber hands out the events published in the process.
+type Subscriber interface {
+	Subscribe(buffer int) (<-chan event_entity.Event, func())
+}
+
+// auctionSnapshotCache keeps the auction details served by FindAuctionById.
+// A snapshot is fresh for ttl; after that it is still served, up to
+// maxStale, while a single background load per auction refreshes it.
+type auctionSnapshotCache struct {
+	mutex     *sync.Mutex
+	ttl       time.Duration
+	maxStale  time.Duration
+	snapshots map[string]auctionSnapshot
+	loads     map[string]*snapshotLoad
+}
+
+type auctionSnapshot struct {
+	auction   AuctionOutputDTO
+	fetchedAt time.Time
+}
+
+// snapshotLoad is a load in flight, whose result is dropped when the
+// auction changed meanwhile.
+type snapshotLoad struct {
+	invalidated bool
+}
+
+func newAuctionSnapshotCache(ttl, maxStale time.Duration) *auctionSnapshotCache {
+	return &auctionSnapshotCache{
+		mutex:     &sync.Mutex{},
+		ttl:       ttl,
+		maxStale:  maxStale,
+		snapshots: make(map[string]auctionSnapshot),
+		loads:     make(map[string]*snapshotLoad),
+	}
+}
+
+// get returns the snapshot of the auction still servable at now, telling
+// whether it is stale.
+func (sc *auctionSnapshotCache) get(auctionId string, now time.Time) (AuctionOutputDTO, bool, bool) {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+
+	snapshot, ok := sc.snapshots[auctionId]
+	if !ok {
+		return AuctionOutputDTO{}, false, false
+	}
+
+	age := now.Sub(snapshot.fetchedAt)
+	if age > sc.ttl+sc.maxStale {
+		delete(sc.snapshots, auctionId)
+		return AuctionOutputDTO{}, false, false
+	}
+
+	return snapshot.auction, age > sc.ttl, true
+}
+
+// begin starts a load of the auction, returning nil when one is already in
+// flight.
+func (sc *auctionSnapshotCache) begin(auctionId string) *snapshotLoad {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+
+	if _, ok := sc.loads[auctionId]; ok {
+		return nil
+	}
+
+	load := &snapshotLoad{}
+	sc.loads[auctionId] = load
+	return load
+}
+
+// finish ends the load, keeping the auction loaded unless it failed or the
+// auction was invalidated since the load began.
+func (sc *auctionSnapshotCache) finish(
+	auctionId string, load *snapshotLoad, auction *AuctionOutputDTO, fetchedAt time.Time) {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+
+	if sc.loads[auctionId] == load {
+		delete(sc.loads, auctionId)
+	}
+	if auction == nil || load.invalidated {
+		return
+	}
+
+	if _, ok := sc.snapshots[auctionId]; !ok && len(sc.snapshots) >= maxAuctionSnapshots {
+		for cachedId, snapshot := range sc.snapshots {
+			if fetchedAt.Sub(snapshot.fetchedAt) > sc.ttl+sc.maxStale {
+				delete(sc.snapshots, cachedId)
+			}
+		}
+		if len(sc.snapshots) >= maxAuctionSnapshots {
+			return
+		}
+	}
+
+	sc.snapshots[auctionId] = auctionSnapshot{auction: *auction, fetchedAt: fetchedAt}
+}
+
+// invalidate drops the snapshot of the auction, and the result of the
+// load in flight for it.
+func (sc *auctionSnapshotCache) invalidate(auctionId string) {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+
+	delete(sc.snapshots, auctionId)
+	if load, ok := sc.loads[auctionId]; ok {
+		load.invalidated = true
+	}
+}
+
+// invalidateOn drops the snapshot of each auction an event is published
+// for: bids written by a flush, the auction completing or any other change
+// of its state. With the Redis relay the subscriber is the cluster broker,
+// so a change made on another instance invalidates the snapshot here too;
+// events lost while Redis is unreachable leave it until it expires.
+func (sc *auctionSnapshotCache) invalidateOn(subscriber Subscriber) {
+	events, _ := subscriber.Subscribe(snapshotEventsBuffer)
+	go func() {
+		for event := range events {
+			if event.AuctionId != "" {
+				sc.invalidate(event.AuctionId)
+			}
+		}
+	}()
+}
+
+// findAuctionSnapshot serves the auction from the snapshot cache, loading
+// it on a miss and refreshing it in the background once stale.
+func (au *AuctionUseCase) findAuctionSnapshot(
+	ctx context.Context, id string) (*AuctionOutputDTO, *internal_error.InternalError) {
+	auction, stale, ok := au.snapshots.get(id, clock.Now())
+	if ok {
+		if stale {
+			if load := au.snapshots.begin(id); load != nil {
+				go au.loadAuctionSnapshot(context.Background(), id, load)
+			}
+		}
+		return &auction, nil
+	}
+
+	// Concurrent misses each load the auction, only one of them keeping it.
+	load := au.snapshots.begin(id)
+	if load == nil {
+		return au.loadAuction(ctx, id)
+	}
+
+	return au.loadAuctionSnapshot(ctx, id, load)
+}
+
+func (au *AuctionUseCase) loadAuctionSnapshot(
+	ctx context.Context, id string, load *snapshotLoad) (*AuctionOutputDTO, *internal_error.InternalError) {
+	fetchedAt := clock.Now()
+	auction, err := au.loadAuction(ctx, id)
+	au.snapshots.finish(id, load, auction, fetchedAt)
+	if err != nil {
+		// A stale snapshot outlives a failed refresh, not its auction.
+		if err.Err == "not_found" {
+			au.snapshots.invalidate(id)
+		} else {
+			logger.Error("error trying to refresh the auction snapshot", err)
+		}
+	}
+
+	return auction, err
+}
+
+// invalidateSnapshot drops the snapshot of an auction this use case changed
+// without publishing an event.
+func (au *AuctionUseCase) invalidateSnapshot(auctionId string) {
+	if au.snapshots != nil {
+		au.snapshots.invalidate(auctionId)
+	}
+}
+
+// getAuctionSnapshotTTL reads AUCTION_SNAPSHOT_TTL, how long the details
+// of an auction are served from memory before they are refreshed, zero
+// disabling the cache.
+func getAuctionSnapshotTTL() time.Duration {
+	ttl, err := time.ParseDuration(os.Getenv("AUCTION_SNAPSHOT_TTL"))
+	if err != nil || ttl < 0 {
+		return 2 * time.Second
+	}
+	return ttl
+}
+
+// getAuctionSnapshotMaxStale reads AUCTION_SNAPSHOT_MAX_STALE, how long
+// past its ttl a snapshot is still served while it is refreshed.
+func getAuctionSnapshotMaxStale() time.Duration {
+	maxStale, err := time.ParseDuration(os.Getenv("AUCTION_SNAPSHOT_MAX_STALE"))
+	if err != nil || maxStale < 0 {
+		return 30 * time.Second
+	}
+	return maxStale
+}
@@ -0,0 +1,37 @@
+package auction_usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuctionSnapshotCache(t *testing.T) {
+	cache := newAuctionSnapshotCache(time.Second, 10*time.Second)
+	now := time.Unix(1700000000, 0)
+
+	load := cache.begin("a")
+	assert.Nil(t, cache.begin("a"), "a single load per auction")
+	cache.finish("a", load, &AuctionOutputDTO{Id: "a", ProductName: "Painting"}, now)
+
+	auction, stale, ok := cache.get("a", now.Add(500*time.Millisecond))
+	assert.True(t, ok)
+	assert.False(t, stale)
+	assert.Equal(t, "Painting", auction.ProductName)
+
+	// Past its ttl the snapshot is still served, stale, until maxStale.
+	_, stale, ok = cache.get("a", now.Add(5*time.Second))
+	assert.True(t, ok)
+	assert.True(t, stale)
+	_, _, ok = cache.get("a", now.Add(12*time.Second))
+	assert.False(t, ok)
+
+	// A load the auction changed during is not kept.
+	load = cache.begin("a")
+	cache.invalidate("a")
+	cache.finish("a", load, &AuctionOutputDTO{Id: "a", ProductName: "Outdated"}, now)
+	_, _, ok = cache.get("a", now)
+	assert.False(t, ok)
+	assert.NotNil(t, cache.begin("a"), "the load is over")
+}
@@ -0,0 +1,41 @@
+package auction_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"fullcycle-auction_go/internal/usecase/authorization"
+)
+
+// CancelAuction lets the seller or an admin call off an auction that has
+// not ended. The completion routine finds it no longer active and stops,
+// and the bids still waiting in the batch buffer are voided rather than
+// stored, so nobody wins it. An auction that changed status since it was
+// read, completed meanwhile for instance, is left as it is with a conflict
+// error.
+func (au *AuctionUseCase) CancelAuction(
+	ctx context.Context,
+	auctionId, userId string) *internal_error.InternalError {
+	auction, err := au.auctionRepositoryInterface.FindAuctionById(ctx, auctionId)
+	if err != nil {
+		return err
+	}
+
+	if err := authorization.CheckSellerOrAdmin(ctx, au.userRepositoryInterface, auction, userId, "cancel"); err != nil {
+		return err
+	}
+
+	if auction.Status != auction_entity.Active && auction.Status != auction_entity.Scheduled &&
+		auction.Status != auction_entity.Paused {
+		return internal_error.NewBadRequestError("Only active, paused or scheduled auctions can be cancelled")
+	}
+
+	if err := au.auctionRepositoryInterface.UpdateAuctionStatus(
+		ctx, auctionId, auction.Status, auction_entity.Cancelled); err != nil {
+		return err
+	}
+
+	au.bidUseCase.VoidAuctionBids(ctx, auctionId)
+
+	return nil
+}
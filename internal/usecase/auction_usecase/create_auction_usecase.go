@@ -2,42 +2,220 @@ package auction_usecase
 
 import (
 	"context"
+	"fmt"
+	"fullcycle-auction_go/configuration/clock"
 	"fullcycle-auction_go/internal/entity/auction_entity"
 	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/entity/category_entity"
+	"fullcycle-auction_go/internal/entity/product_entity"
+	"fullcycle-auction_go/internal/entity/user_entity"
 	"fullcycle-auction_go/internal/internal_error"
 	"fullcycle-auction_go/internal/usecase/bid_usecase"
+	"fullcycle-auction_go/internal/usecase/pagination"
+	"fullcycle-auction_go/internal/usecase/user_usecase"
+	"os"
+	"strconv"
 	"time"
 )
 
+type ConditionGradingDTO struct {
+	Grade           string   `json:"grade" binding:"required,oneof=new like_new good fair poor refurbished"`
+	Defects         []string `json:"defects,omitempty"`
+	InspectionNotes string   `json:"inspection_notes,omitempty" binding:"max=500"`
+	GraderId        string   `json:"grader_id,omitempty"`
+}
+
+type TermsInputDTO struct {
+	ReturnsPolicy       string `json:"returns_policy" binding:"required,max=2000"`
+	PaymentDeadlineDays int    `json:"payment_deadline_days" binding:"required,min=1,max=90"`
+}
+
+type TermsOutputDTO struct {
+	ReturnsPolicy       string `json:"returns_policy"`
+	PaymentDeadlineDays int    `json:"payment_deadline_days"`
+	Version             int    `json:"version"`
+}
+
 type AuctionInputDTO struct {
-	ProductName string           `json:"product_name" binding:"required,min=1"`
-	Category    string           `json:"category" binding:"required,min=2"`
-	Description string           `json:"description" binding:"required,min=10,max=200"`
-	Condition   ProductCondition `json:"condition" binding:"oneof=0 1 2"`
+	SellerId    string               `json:"seller_id" binding:"omitempty,uuid"`
+	ProductId   string               `json:"product_id" binding:"omitempty,uuid"`
+	ProductName string               `json:"product_name" binding:"required_without=ProductId,omitempty,min=1"`
+	Category    string               `json:"category" binding:"required_without=ProductId,omitempty,min=2"`
+	Description string               `json:"description" binding:"required_without=ProductId,omitempty,min=10,max=200"`
+	Condition   ProductCondition     `json:"condition"`
+	Grading     *ConditionGradingDTO `json:"grading"`
+	Attributes  map[string]string    `json:"attributes"`
+
+	// Descriptions translates the description, by language.
+	Descriptions map[string]string `json:"descriptions" binding:"omitempty,dive,keys,oneof=en pt es,endkeys,min=10,max=200"`
+
+	BidderVisibility string         `json:"bidder_visibility" binding:"omitempty,oneof=public masked anonymous"`
+	Terms            *TermsInputDTO `json:"terms"`
+
+	// SeriesId adds the auction as the next lot of a series of the seller.
+	SeriesId string `json:"series_id" binding:"omitempty,uuid"`
+
+	// Live lots wait for an auctioneer to open bidding and hammer them.
+	Live bool `json:"live"`
+
+	// StartsAt schedules the auction to start later, it starts right away
+	// when missing or past.
+	StartsAt *time.Time `json:"starts_at"`
+
+	// Duration is how long the auction runs from its start, such as "2h" or
+	// "90m", AUCTION_INTERVAL when empty.
+	Duration string `json:"duration"`
+
+	// StartingPrice is the lowest amount the first bid can be.
+	StartingPrice float64 `json:"starting_price" binding:"omitempty,gt=0"`
+
+	// ReservePrice is the lowest amount the lot sells for, kept from the
+	// bidders.
+	ReservePrice float64 `json:"reserve_price" binding:"omitempty,gt=0"`
+
+	// MinIncrement is how much each bid must raise the highest bid by.
+	MinIncrement float64 `json:"min_increment" binding:"omitempty,gt=0"`
+
+	// Sealed auctions hide the bids until they end.
+	Sealed bool `json:"sealed"`
+
+	// BuyNowPrice completes the auction with the first bid meeting it.
+	BuyNowPrice float64 `json:"buy_now_price" binding:"omitempty,gt=0"`
+
+	// Quantity sells that many identical units to the highest bidders, one
+	// each, a single one when zero. With UniformPrice every winner pays the
+	// lowest winning bid.
+	Quantity     int  `json:"quantity" binding:"omitempty,min=1,max=1000"`
+	UniformPrice bool `json:"uniform_price"`
+
+	// EstimateLow and EstimateHigh are the range the seller expects the lot
+	// to sell within, shown on listings.
+	EstimateLow  float64 `json:"estimate_low" binding:"required_with=EstimateHigh,omitempty,gt=0"`
+	EstimateHigh float64 `json:"estimate_high" binding:"required_with=EstimateLow,omitempty,gtefield=EstimateLow"`
+
+	// RegistrationRequired makes bidders register for the lot first, which
+	// lots worth LOT_REGISTRATION_THRESHOLD or more always do.
+	RegistrationRequired bool `json:"registration_required"`
+
+	// Invitees are the users who see the auction listed before
+	// AUCTION_VISIBILITY_DELAY makes it public.
+	Invitees []string `json:"invitees" binding:"omitempty,max=100,dive,uuid"`
+
+	// Draft creates the auction as a draft, which the seller can still edit
+	// and which takes no bids until published.
+	Draft bool `json:"draft"`
+
+	// Filled by the recurring auction scheduler, never from the payload.
+	RecurringAuctionId string `json:"-"`
 }
 
 type AuctionOutputDTO struct {
-	Id          string           `json:"id"`
-	ProductName string           `json:"product_name"`
-	Category    string           `json:"category"`
-	Description string           `json:"description"`
-	Condition   ProductCondition `json:"condition"`
-	Status      AuctionStatus    `json:"status"`
-	Timestamp   time.Time        `json:"timestamp" time_format:"2006-01-02 15:04:05"`
+	Id          string              `json:"id"`
+	SellerId    string              `json:"seller_id,omitempty"`
+	ProductId   string              `json:"product_id,omitempty"`
+	ProductName string              `json:"product_name"`
+	Category    string              `json:"category"`
+	Description string              `json:"description"`
+	Condition   ProductCondition    `json:"condition"`
+	Grading     ConditionGradingDTO `json:"grading"`
+	Attributes  map[string]string   `json:"attributes,omitempty"`
+	Status      AuctionStatus       `json:"status"`
+	Timestamp   time.Time           `json:"timestamp" time_format:"2006-01-02 15:04:05"`
+	UpdatedAt   time.Time           `json:"updated_at" time_format:"2006-01-02 15:04:05"`
+	StartsAt    *time.Time          `json:"starts_at,omitempty" time_format:"2006-01-02 15:04:05"`
+	EndsAt      time.Time           `json:"ends_at" time_format:"2006-01-02 15:04:05"`
+	WinnerBidId string              `json:"winner_bid_id,omitempty"`
+
+	BidderVisibility string            `json:"bidder_visibility"`
+	Terms            *TermsOutputDTO   `json:"terms,omitempty"`
+	Descriptions     map[string]string `json:"descriptions,omitempty"`
+
+	RecurringAuctionId string `json:"recurring_auction_id,omitempty"`
+	SeriesId           string `json:"series_id,omitempty"`
+	LotNumber          int    `json:"lot_number,omitempty"`
+
+	Live            bool       `json:"live,omitempty"`
+	BiddingOpenedAt *time.Time `json:"bidding_opened_at,omitempty" time_format:"2006-01-02 15:04:05"`
+	PausedAt        *time.Time `json:"paused_at,omitempty" time_format:"2006-01-02 15:04:05"`
+	PublicAt        *time.Time `json:"public_at,omitempty" time_format:"2006-01-02 15:04:05"`
+
+	RelistedFrom string  `json:"relisted_from,omitempty"`
+	HeatScore    float64 `json:"heat_score"`
+	// BidVelocity is the bids per minute of the trending window, only
+	// filled in the trending listing.
+	BidVelocity float64 `json:"bid_velocity,omitempty"`
+
+	StartingPrice float64 `json:"starting_price,omitempty"`
+	HasReserve    bool    `json:"has_reserve,omitempty"`
+	MinIncrement  float64 `json:"min_increment,omitempty"`
+	Sealed        bool    `json:"sealed,omitempty"`
+	BuyNowPrice   float64 `json:"buy_now_price,omitempty"`
+
+	Quantity     int  `json:"quantity,omitempty"`
+	UniformPrice bool `json:"uniform_price,omitempty"`
+
+	EstimateLow  float64 `json:"estimate_low,omitempty"`
+	EstimateHigh float64 `json:"estimate_high,omitempty"`
+
+	RegistrationRequired bool `json:"registration_required,omitempty"`
+
+	Labels *AuctionLabelsDTO `json:"labels,omitempty"`
+
+	Seller *user_usecase.PublicProfileDTO `json:"seller,omitempty"`
 }
 
 type WinningInfoOutputDTO struct {
 	Auction AuctionOutputDTO          `json:"auction"`
 	Bid     *bid_usecase.BidOutputDTO `json:"bid,omitempty"`
+
+	// ReserveNotMet is set when the highest bid is below the reserve price,
+	// the bid being left out once the auction ended without a sale.
+	ReserveNotMet bool `json:"reserve_not_met,omitempty"`
+
+	// Winners lists the bids winning the units sold, highest first, Bid
+	// being the first of them. Bids below the reserve price win no unit.
+	Winners []UnitWinnerDTO `json:"winners,omitempty"`
+}
+
+type UnitWinnerDTO struct {
+	Unit int                      `json:"unit"`
+	Bid  bid_usecase.BidOutputDTO `json:"bid"`
+	// Price is what the winner pays for the unit, their bid or, with a
+	// uniform price, the lowest winning bid.
+	Price float64 `json:"price"`
 }
 
 func NewAuctionUseCase(
 	auctionRepositoryInterface auction_entity.AuctionRepositoryInterface,
-	bidRepositoryInterface bid_entity.BidEntityRepository) AuctionUseCaseInterface {
-	return &AuctionUseCase{
-		auctionRepositoryInterface: auctionRepositoryInterface,
-		bidRepositoryInterface:     bidRepositoryInterface,
+	bidRepositoryInterface bid_entity.BidEntityRepository,
+	productRepositoryInterface product_entity.ProductRepositoryInterface,
+	categorySchemaRepositoryInterface category_entity.CategorySchemaRepositoryInterface,
+	categoryRepositoryInterface category_entity.CategoryRepositoryInterface,
+	userRepositoryInterface user_entity.UserRepositoryInterface,
+	seriesRepositoryInterface auction_entity.SeriesRepositoryInterface,
+	bidUseCase bid_usecase.BidUseCaseInterface,
+	subscriber Subscriber) AuctionUseCaseInterface {
+	auctionUseCase := &AuctionUseCase{
+		auctionRepositoryInterface:        auctionRepositoryInterface,
+		bidRepositoryInterface:            bidRepositoryInterface,
+		productRepositoryInterface:        productRepositoryInterface,
+		categorySchemaRepositoryInterface: categorySchemaRepositoryInterface,
+		categoryRepositoryInterface:       categoryRepositoryInterface,
+		userRepositoryInterface:           userRepositoryInterface,
+		seriesRepositoryInterface:         seriesRepositoryInterface,
+		bidUseCase:                        bidUseCase,
+		registrationThreshold:             getRegistrationThreshold(),
+		visibilityDelay:                   getVisibilityDelay(),
+	}
+
+	// Without a subscriber snapshots would only be dropped as they expire,
+	// so the auction details are always read through.
+	if ttl := getAuctionSnapshotTTL(); ttl > 0 && subscriber != nil {
+		auctionUseCase.snapshots = newAuctionSnapshotCache(ttl, getAuctionSnapshotMaxStale())
+		auctionUseCase.snapshots.invalidateOn(subscriber)
 	}
+
+	return auctionUseCase
 }
 
 type AuctionUseCaseInterface interface {
@@ -45,43 +223,389 @@ type AuctionUseCaseInterface interface {
 		ctx context.Context,
 		auctionInput AuctionInputDTO) *internal_error.InternalError
 
+	ValidateAuction(
+		ctx context.Context,
+		auctionInput AuctionInputDTO) *internal_error.InternalError
+
 	FindAuctionById(
 		ctx context.Context, id string) (*AuctionOutputDTO, *internal_error.InternalError)
 
+	// FindAuctions pages through the auctions matching the filter listed
+	// for the user, empty for anonymous requests, in the order sortBy
+	// names, SortNewest when empty.
 	FindAuctions(
 		ctx context.Context,
-		status AuctionStatus,
-		category, productName string) ([]AuctionOutputDTO, *internal_error.InternalError)
+		filter AuctionFilterDTO,
+		fields []string,
+		sortBy, userId string,
+		page pagination.Params) (*pagination.Page[AuctionOutputDTO], *internal_error.InternalError)
+
+	// FindEndingSoonAuctions pages through the active auctions ending
+	// within the window, DefaultEndingSoonWindow when zero, soonest first.
+	FindEndingSoonAuctions(
+		ctx context.Context,
+		within time.Duration,
+		fields []string,
+		userId string,
+		page pagination.Params) (*pagination.Page[AuctionOutputDTO], *internal_error.InternalError)
+
+	// FindTrendingAuctions pages through the active auctions with bids in
+	// the trending window, the highest bid velocity first.
+	FindTrendingAuctions(
+		ctx context.Context,
+		fields []string,
+		userId string,
+		page pagination.Params) (*pagination.Page[AuctionOutputDTO], *internal_error.InternalError)
 
 	FindWinningBidByAuctionId(
 		ctx context.Context,
 		auctionId string) (*WinningInfoOutputDTO, *internal_error.InternalError)
-}
 
-type ProductCondition int64
-type AuctionStatus int64
+	ExportAuctions(
+		ctx context.Context,
+		status AuctionStatus,
+		category string,
+		handle func(AuctionOutputDTO) error) *internal_error.InternalError
+
+	FindAuctionChanges(
+		ctx context.Context,
+		since time.Time) (*AuctionChangesOutputDTO, *internal_error.InternalError)
+
+	ResolveAuctions(
+		ctx context.Context,
+		status AuctionStatus,
+		missingWinner bool,
+		progress func(AuctionResolutionDTO)) (*AuctionResolutionSummaryDTO, *internal_error.InternalError)
+
+	EndAuctionEarly(
+		ctx context.Context,
+		auctionId, sellerId string) *internal_error.InternalError
+
+	CancelAuction(
+		ctx context.Context,
+		auctionId, userId string) *internal_error.InternalError
+
+	PauseAuction(
+		ctx context.Context,
+		auctionId, userId string) *internal_error.InternalError
+
+	ResumeAuction(
+		ctx context.Context,
+		auctionId, userId string) *internal_error.InternalError
+
+	RelistAuction(
+		ctx context.Context,
+		auctionId, sellerId string,
+		relistInput RelistAuctionInputDTO) (*AuctionOutputDTO, *internal_error.InternalError)
+
+	UpdateDraft(
+		ctx context.Context,
+		auctionId, sellerId string,
+		auctionInput AuctionInputDTO) (*AuctionOutputDTO, *internal_error.InternalError)
+
+	PublishAuction(
+		ctx context.Context,
+		auctionId, sellerId string) (*AuctionOutputDTO, *internal_error.InternalError)
+
+	UpdateAuctionDetails(
+		ctx context.Context,
+		auctionId, sellerId string,
+		detailsInput UpdateAuctionDetailsInputDTO) (*AuctionOutputDTO, *internal_error.InternalError)
+
+	FindSellerStorefront(
+		ctx context.Context,
+		sellerId string,
+		page, pageSize int) (*StorefrontOutputDTO, *internal_error.InternalError)
+
+	FindRecentResults(
+		ctx context.Context, category string) ([]SoldItemDTO, *internal_error.InternalError)
+
+	FindEstimateAccuracy(
+		ctx context.Context,
+		sellerId, category string) (*EstimateAccuracyOutputDTO, *internal_error.InternalError)
+
+	FindAuctionMetadata(
+		ctx context.Context, auctionId, pageUrl string) (*AuctionMetadataDTO, *internal_error.InternalError)
+
+	LocalizeAuctions(
+		ctx context.Context, language string, auctions []AuctionOutputDTO)
+
+	SearchAuctions(
+		ctx context.Context, language, text, userId string) ([]AuctionOutputDTO, *internal_error.InternalError)
+
+	FindAuctionStatuses(
+		ctx context.Context,
+		input AuctionStatusBatchInputDTO) (*AuctionStatusBatchOutputDTO, *internal_error.InternalError)
+}
 
 type AuctionUseCase struct {
-	auctionRepositoryInterface auction_entity.AuctionRepositoryInterface
-	bidRepositoryInterface     bid_entity.BidEntityRepository
+	auctionRepositoryInterface        auction_entity.AuctionRepositoryInterface
+	bidRepositoryInterface            bid_entity.BidEntityRepository
+	productRepositoryInterface        product_entity.ProductRepositoryInterface
+	categorySchemaRepositoryInterface category_entity.CategorySchemaRepositoryInterface
+	categoryRepositoryInterface       category_entity.CategoryRepositoryInterface
+	userRepositoryInterface           user_entity.UserRepositoryInterface
+	seriesRepositoryInterface         auction_entity.SeriesRepositoryInterface
+	bidUseCase                        bid_usecase.BidUseCaseInterface
+	registrationThreshold             float64
+	visibilityDelay                   time.Duration
+	snapshots                         *auctionSnapshotCache
 }
 
 func (au *AuctionUseCase) CreateAuction(
 	ctx context.Context,
 	auctionInput AuctionInputDTO) *internal_error.InternalError {
+	auction, err := au.newAuction(ctx, auctionInput)
+	if err != nil {
+		return err
+	}
+
+	if auction.SeriesId != "" {
+		if auction.LotNumber, err = au.seriesRepositoryInterface.NextLotNumber(ctx, auction.SeriesId); err != nil {
+			return err
+		}
+	}
+
+	if err := au.auctionRepositoryInterface.CreateAuction(
+		ctx, auction); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ValidateAuction checks the input as CreateAuction does, without creating
+// the auction.
+func (au *AuctionUseCase) ValidateAuction(
+	ctx context.Context,
+	auctionInput AuctionInputDTO) *internal_error.InternalError {
+	_, err := au.newAuction(ctx, auctionInput)
+	return err
+}
+
+func (au *AuctionUseCase) newAuction(
+	ctx context.Context,
+	auctionInput AuctionInputDTO) (*auction_entity.Auction, *internal_error.InternalError) {
+	if auctionInput.ProductId != "" {
+		product, err := au.productRepositoryInterface.FindProductById(ctx, auctionInput.ProductId)
+		if err != nil {
+			return nil, err
+		}
+
+		auctionInput.ProductName = product.Name
+		auctionInput.Category = product.Category
+		auctionInput.Description = product.Description
+		auctionInput.Attributes = mergeAttributes(product.Attributes, auctionInput.Attributes)
+	}
+
+	if err := au.validateCategory(ctx, auctionInput.Category); err != nil {
+		return nil, err
+	}
+
+	if err := au.validateAttributes(ctx, auctionInput.Category, auctionInput.Attributes); err != nil {
+		return nil, err
+	}
+
+	if auctionInput.Sealed && auctionInput.Live {
+		return nil, internal_error.NewBadRequestError("Live lots cannot be sealed")
+	}
+	if auctionInput.BuyNowPrice > 0 && auctionInput.BuyNowPrice < auctionInput.ReservePrice {
+		return nil, internal_error.NewBadRequestError("Buy now price cannot be below the reserve price")
+	}
+	if auctionInput.BuyNowPrice > 0 && auctionInput.BuyNowPrice < auctionInput.StartingPrice {
+		return nil, internal_error.NewBadRequestError("Buy now price cannot be below the starting price")
+	}
+	if auctionInput.Quantity > 1 && (auctionInput.BuyNowPrice > 0 || auctionInput.Live) {
+		return nil, internal_error.NewBadRequestError("Auctions of several units cannot be live or bought outright")
+	}
+	if auctionInput.UniformPrice && auctionInput.Quantity <= 1 {
+		return nil, internal_error.NewBadRequestError("Uniform price only applies to auctions of several units")
+	}
+
+	if auctionInput.SeriesId != "" {
+		series, err := au.seriesRepositoryInterface.FindSeriesById(ctx, auctionInput.SeriesId)
+		if err != nil {
+			return nil, err
+		}
+		if series.SellerId != auctionInput.SellerId {
+			return nil, internal_error.NewForbiddenError("Only the seller of the series can add lots to it")
+		}
+	}
+
 	auction, err := auction_entity.CreateAuction(
+		auctionInput.SellerId,
+		auctionInput.ProductId,
 		auctionInput.ProductName,
 		auctionInput.Category,
 		auctionInput.Description,
-		auction_entity.ProductCondition(auctionInput.Condition))
+		toConditionGrading(auctionInput),
+		auctionInput.Attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	if auctionInput.BidderVisibility != "" {
+		auction.BidderVisibility = auction_entity.BidderVisibility(auctionInput.BidderVisibility)
+	}
+
+	auction.Descriptions = auctionInput.Descriptions
+	auction.RecurringAuctionId = auctionInput.RecurringAuctionId
+	auction.SeriesId = auctionInput.SeriesId
+	auction.Live = auctionInput.Live
+	auction.StartingPrice = auctionInput.StartingPrice
+	auction.ReservePrice = auctionInput.ReservePrice
+	auction.MinIncrement = auctionInput.MinIncrement
+	auction.Sealed = auctionInput.Sealed
+	auction.BuyNowPrice = auctionInput.BuyNowPrice
+	auction.Quantity = auctionInput.Quantity
+	auction.UniformPrice = auctionInput.UniformPrice
+	auction.EstimateLow = auctionInput.EstimateLow
+	auction.EstimateHigh = auctionInput.EstimateHigh
+	auction.RegistrationRequired = auctionInput.RegistrationRequired ||
+		exceedsRegistrationThreshold(auction, au.registrationThreshold)
+	auction.Invitees = auctionInput.Invitees
+	if au.visibilityDelay > 0 {
+		auction.PublicAt = auction.Timestamp.Add(au.visibilityDelay)
+	}
+	if auctionInput.StartsAt != nil && auctionInput.StartsAt.After(clock.Now()) {
+		auction.Schedule(*auctionInput.StartsAt)
+	}
+	if auctionInput.Duration != "" {
+		duration, errParse := time.ParseDuration(auctionInput.Duration)
+		if errParse != nil || duration <= 0 {
+			return nil, internal_error.NewBadRequestError("Duration must be a positive duration, such as 90m or 2h")
+		}
+		auction.EndsAt = auction.OpensAt().Add(duration)
+	}
+
+	if auctionInput.Terms != nil {
+		auction.Terms = &auction_entity.Terms{
+			ReturnsPolicy:       auctionInput.Terms.ReturnsPolicy,
+			PaymentDeadlineDays: auctionInput.Terms.PaymentDeadlineDays,
+			Version:             1,
+		}
+	}
+	if auctionInput.Draft {
+		auction.Status = auction_entity.Draft
+	}
+
+	return auction, nil
+}
+
+func (au *AuctionUseCase) validateAttributes(
+	ctx context.Context,
+	category string,
+	attributes map[string]string) *internal_error.InternalError {
+	schema, err := au.categorySchemaRepositoryInterface.FindCategorySchema(ctx, category)
 	if err != nil {
+		if err.Err == "not_found" {
+			return nil
+		}
 		return err
 	}
 
-	if err := au.auctionRepositoryInterface.CreateAuction(
-		ctx, auction); err != nil {
+	return schema.ValidateAttributes(attributes)
+}
+
+// validateCategory rejects categories missing from the category tree. Until
+// the first category is created any category is accepted.
+func (au *AuctionUseCase) validateCategory(
+	ctx context.Context, category string) *internal_error.InternalError {
+	categories, err := au.categoryRepositoryInterface.FindCategories(ctx)
+	if err != nil {
 		return err
 	}
+	if len(categories) == 0 {
+		return nil
+	}
 
-	return nil
+	for _, managed := range categories {
+		if managed.Name == category {
+			return nil
+		}
+	}
+
+	return internal_error.NewBadRequestError(fmt.Sprintf("Category %s does not exist", category))
+}
+
+// categoryTree returns the category and every category below it, nil when
+// no category is given.
+func (au *AuctionUseCase) categoryTree(
+	ctx context.Context, category string) ([]string, *internal_error.InternalError) {
+	if category == "" {
+		return nil, nil
+	}
+
+	categories, err := au.categoryRepositoryInterface.FindCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return category_entity.Subtree(categories, category), nil
+}
+
+func mergeAttributes(base, overrides map[string]string) map[string]string {
+	if len(base) == 0 {
+		return overrides
+	}
+
+	merged := make(map[string]string, len(base)+len(overrides))
+	for name, value := range base {
+		merged[name] = value
+	}
+	for name, value := range overrides {
+		merged[name] = value
+	}
+
+	return merged
+}
+
+func toConditionGrading(auctionInput AuctionInputDTO) auction_entity.ConditionGrading {
+	if auctionInput.Grading == nil {
+		return auction_entity.GradingFromCondition(
+			auction_entity.ProductCondition(auctionInput.Condition))
+	}
+
+	return auction_entity.ConditionGrading{
+		Grade:           auction_entity.Grade(auctionInput.Grading.Grade),
+		Defects:         auctionInput.Grading.Defects,
+		InspectionNotes: auctionInput.Grading.InspectionNotes,
+		GraderId:        auctionInput.Grading.GraderId,
+	}
+}
+
+func toConditionGradingDTO(grading auction_entity.ConditionGrading) ConditionGradingDTO {
+	return ConditionGradingDTO{
+		Grade:           string(grading.Grade),
+		Defects:         grading.Defects,
+		InspectionNotes: grading.InspectionNotes,
+		GraderId:        grading.GraderId,
+	}
+}
+
+// exceedsRegistrationThreshold tells whether the lot is worth the threshold
+// by its reserve or buy now price, a zero threshold disabling it.
+func exceedsRegistrationThreshold(auction *auction_entity.Auction, threshold float64) bool {
+	if threshold <= 0 {
+		return false
+	}
+	return auction.ReservePrice >= threshold || auction.BuyNowPrice >= threshold
+}
+
+func getRegistrationThreshold() float64 {
+	threshold, err := strconv.ParseFloat(os.Getenv("LOT_REGISTRATION_THRESHOLD"), 64)
+	if err != nil || threshold < 0 {
+		return 0
+	}
+	return threshold
+}
+
+// getVisibilityDelay reads AUCTION_VISIBILITY_DELAY, how long new auctions
+// are only listed for their seller and invitees.
+func getVisibilityDelay() time.Duration {
+	delay, err := time.ParseDuration(os.Getenv("AUCTION_VISIBILITY_DELAY"))
+	if err != nil || delay < 0 {
+		return 0
+	}
+	return delay
 }
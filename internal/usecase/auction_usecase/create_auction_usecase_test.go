@@ -0,0 +1,101 @@
+package auction_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/category_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExceedsRegistrationThreshold(t *testing.T) {
+	auction := &auction_entity.Auction{ReservePrice: 5000, BuyNowPrice: 20000}
+
+	assert.False(t, exceedsRegistrationThreshold(auction, 0))
+	assert.True(t, exceedsRegistrationThreshold(auction, 5000))
+	assert.True(t, exceedsRegistrationThreshold(auction, 10000))
+	assert.False(t, exceedsRegistrationThreshold(auction, 50000))
+	assert.False(t, exceedsRegistrationThreshold(&auction_entity.Auction{}, 1))
+}
+
+// stubCategoryRepository has neither categories nor schemas, so any
+// category is accepted.
+type stubCategoryRepository struct {
+	category_entity.CategoryRepositoryInterface
+	category_entity.CategorySchemaRepositoryInterface
+}
+
+func (stubCategoryRepository) FindCategories(
+	ctx context.Context) ([]category_entity.Category, *internal_error.InternalError) {
+	return nil, nil
+}
+
+func (stubCategoryRepository) FindCategorySchema(
+	ctx context.Context, category string) (*category_entity.CategorySchema, *internal_error.InternalError) {
+	return nil, internal_error.NewNotFoundError("Category schema not found")
+}
+
+func newValidatingAuctionUseCase() *AuctionUseCase {
+	return &AuctionUseCase{
+		categoryRepositoryInterface:       stubCategoryRepository{},
+		categorySchemaRepositoryInterface: stubCategoryRepository{},
+	}
+}
+
+func lampInput(duration string) AuctionInputDTO {
+	return AuctionInputDTO{
+		ProductName: "Lamp",
+		Category:    "home",
+		Description: "An old brass lamp",
+		Condition:   ProductCondition(auction_entity.New),
+		Duration:    duration,
+	}
+}
+
+func TestNewAuction_Duration(t *testing.T) {
+	auctionUseCase := newValidatingAuctionUseCase()
+
+	auction, err := auctionUseCase.newAuction(context.Background(), lampInput("90m"))
+	require.Nil(t, err)
+	assert.Equal(t, auction_entity.Active, auction.Status)
+	assert.Equal(t, auction.OpensAt().Add(90*time.Minute), auction.EndsAt)
+
+	// Without a duration the repository ends it after AUCTION_INTERVAL.
+	auction, err = auctionUseCase.newAuction(context.Background(), lampInput(""))
+	require.Nil(t, err)
+	assert.True(t, auction.EndsAt.IsZero())
+
+	for _, duration := range []string{"soon", "2", "0s", "-1h"} {
+		_, err := auctionUseCase.newAuction(context.Background(), lampInput(duration))
+		require.NotNil(t, err, duration)
+		assert.Equal(t, "bad_request", err.Err)
+		assert.Equal(t, "Duration must be a positive duration, such as 90m or 2h", err.Message)
+	}
+}
+
+func TestNewAuction_DurationFromStart(t *testing.T) {
+	auctionUseCase := newValidatingAuctionUseCase()
+
+	startsAt := time.Now().Add(24 * time.Hour)
+	auctionInput := lampInput("2h")
+	auctionInput.StartsAt = &startsAt
+
+	auction, err := auctionUseCase.newAuction(context.Background(), auctionInput)
+	require.Nil(t, err)
+	assert.Equal(t, auction_entity.Scheduled, auction.Status)
+	assert.Equal(t, startsAt.Add(2*time.Hour), auction.EndsAt)
+
+	// A start already past starts the auction right away.
+	past := time.Now().Add(-time.Hour)
+	auctionInput.StartsAt = &past
+
+	auction, err = auctionUseCase.newAuction(context.Background(), auctionInput)
+	require.Nil(t, err)
+	assert.Equal(t, auction_entity.Active, auction.Status)
+	assert.True(t, auction.OpensAt().After(past))
+	assert.Equal(t, auction.OpensAt().Add(2*time.Hour), auction.EndsAt)
+}
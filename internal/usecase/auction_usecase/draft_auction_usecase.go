@@ -0,0 +1,106 @@
+package auction_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/clock"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+)
+
+// UpdateDraft replaces the draft of the seller with the input, validated as
+// on creation. The draft keeps its id, seller, creation time and lot number
+// in its series.
+func (au *AuctionUseCase) UpdateDraft(
+	ctx context.Context,
+	auctionId, sellerId string,
+	auctionInput AuctionInputDTO) (*AuctionOutputDTO, *internal_error.InternalError) {
+	draft, err := au.findSellerDraft(ctx, auctionId, sellerId, "edit")
+	if err != nil {
+		return nil, err
+	}
+
+	auctionInput.SellerId = draft.SellerId
+	auctionInput.Draft = true
+	auction, err := au.newAuction(ctx, auctionInput)
+	if err != nil {
+		return nil, err
+	}
+
+	// The end follows the creation time kept, so the duration set holds.
+	duration := auction.EndsAt.Sub(auction.OpensAt())
+	auction.Id, auction.Timestamp = draft.Id, draft.Timestamp
+	if !auction.EndsAt.IsZero() {
+		auction.EndsAt = auction.OpensAt().Add(duration)
+	}
+
+	if auction.SeriesId != "" {
+		if auction.SeriesId == draft.SeriesId {
+			auction.LotNumber = draft.LotNumber
+		} else if auction.LotNumber, err = au.seriesRepositoryInterface.NextLotNumber(ctx, auction.SeriesId); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := au.auctionRepositoryInterface.UpdateDraft(ctx, auction); err != nil {
+		if err.Err == "not_found" {
+			return nil, internal_error.NewBadRequestError("Only draft auctions can be edited")
+		}
+		return nil, err
+	}
+	au.invalidateSnapshot(auction.Id)
+
+	auctionOutputs := []AuctionOutputDTO{toAuctionOutputDTO(auction)}
+	au.presentSellers(ctx, auctionOutputs)
+
+	return &auctionOutputs[0], nil
+}
+
+// PublishAuction starts the draft, or schedules it when its start time is
+// still ahead, running it for the duration it was set to. Invitees see it
+// listed for AUCTION_VISIBILITY_DELAY from then.
+func (au *AuctionUseCase) PublishAuction(
+	ctx context.Context,
+	auctionId, sellerId string) (*AuctionOutputDTO, *internal_error.InternalError) {
+	auction, err := au.findSellerDraft(ctx, auctionId, sellerId, "publish")
+	if err != nil {
+		return nil, err
+	}
+
+	now := clock.Now()
+	auction.Publish(now)
+	if au.visibilityDelay > 0 {
+		auction.PublicAt = now.Add(au.visibilityDelay)
+	}
+
+	if err := au.auctionRepositoryInterface.PublishAuction(ctx, auction); err != nil {
+		if err.Err == "not_found" {
+			return nil, internal_error.NewBadRequestError("Only draft auctions can be published")
+		}
+		return nil, err
+	}
+	au.invalidateSnapshot(auction.Id)
+
+	auctionOutputs := []AuctionOutputDTO{toAuctionOutputDTO(auction)}
+	au.presentSellers(ctx, auctionOutputs)
+
+	return &auctionOutputs[0], nil
+}
+
+func (au *AuctionUseCase) findSellerDraft(
+	ctx context.Context,
+	auctionId, sellerId, action string) (*auction_entity.Auction, *internal_error.InternalError) {
+	auction, err := au.auctionRepositoryInterface.FindAuctionById(ctx, auctionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if auction.SellerId == "" || auction.SellerId != sellerId {
+		return nil, internal_error.NewForbiddenError("Only the seller can " + action + " this auction")
+	}
+
+	if auction.Status != auction_entity.Draft {
+		return nil, internal_error.NewBadRequestError("Only draft auctions can be " + action + "ed")
+	}
+
+	return auction, nil
+}
@@ -0,0 +1,48 @@
+package auction_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+)
+
+// EndAuctionEarly lets the seller close an active auction before its
+// interval elapses, as long as nobody has bid on it, including bids still
+// waiting in the batch buffer.
+func (au *AuctionUseCase) EndAuctionEarly(
+	ctx context.Context,
+	auctionId, sellerId string) *internal_error.InternalError {
+	auction, err := au.auctionRepositoryInterface.FindAuctionById(ctx, auctionId)
+	if err != nil {
+		return err
+	}
+
+	if auction.SellerId == "" || auction.SellerId != sellerId {
+		return internal_error.NewForbiddenError("Only the seller can end this auction")
+	}
+
+	if auction.Status != auction_entity.Active {
+		return internal_error.NewBadRequestError("Auction is not active")
+	}
+
+	if au.bidUseCase.HasPendingBids(auctionId) {
+		return internal_error.NewBadRequestError("Auction already has bids")
+	}
+
+	bids, err := au.bidRepositoryInterface.FindBidByAuctionId(ctx, auctionId)
+	if err != nil {
+		return err
+	}
+
+	if len(bids) > 0 {
+		return internal_error.NewBadRequestError("Auction already has bids")
+	}
+
+	if err := au.auctionRepositoryInterface.UpdateAuctionStatus(
+		ctx, auctionId, auction_entity.Active, auction_entity.Completed); err != nil {
+		return err
+	}
+	au.invalidateSnapshot(auctionId)
+
+	return nil
+}
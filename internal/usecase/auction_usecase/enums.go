@@ -0,0 +1,59 @@
+package auction_usecase
+
+import (
+	"fullcycle-auction_go/configuration/enum"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+)
+
+// ProductCondition and AuctionStatus are written to JSON by name. Their
+// legacy numeric values are still read while enum accepts them.
+type ProductCondition int64
+type AuctionStatus int64
+
+var productConditions = enum.New("condition", map[ProductCondition]string{
+	ProductCondition(auction_entity.New):         "new",
+	ProductCondition(auction_entity.Used):        "used",
+	ProductCondition(auction_entity.Refurbished): "refurbished",
+})
+
+var auctionStatuses = enum.New("status", map[AuctionStatus]string{
+	AuctionStatus(auction_entity.Active):       "active",
+	AuctionStatus(auction_entity.Completed):    "completed",
+	AuctionStatus(auction_entity.Scheduled):    "scheduled",
+	AuctionStatus(auction_entity.ClosedNoSale): "closed_no_sale",
+	AuctionStatus(auction_entity.Cancelled):    "cancelled",
+	AuctionStatus(auction_entity.Paused):       "paused",
+	AuctionStatus(auction_entity.Draft):        "draft",
+})
+
+func (pc ProductCondition) String() string {
+	return productConditions.Name(pc)
+}
+
+func (pc ProductCondition) MarshalJSON() ([]byte, error) {
+	return productConditions.Marshal(pc)
+}
+
+func (pc *ProductCondition) UnmarshalJSON(data []byte) (err error) {
+	*pc, err = productConditions.Unmarshal(data)
+	return err
+}
+
+func (as AuctionStatus) String() string {
+	return auctionStatuses.Name(as)
+}
+
+func (as AuctionStatus) MarshalJSON() ([]byte, error) {
+	return auctionStatuses.Marshal(as)
+}
+
+func (as *AuctionStatus) UnmarshalJSON(data []byte) (err error) {
+	*as, err = auctionStatuses.Unmarshal(data)
+	return err
+}
+
+// ParseAuctionStatus parses a status query param, by name or, while
+// accepted, by number.
+func ParseAuctionStatus(text string) (AuctionStatus, error) {
+	return auctionStatuses.Parse(text)
+}
@@ -0,0 +1,154 @@
+package auction_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"sort"
+)
+
+// EstimateAccuracyDTO tells how the ended lots with an estimate did against
+// it, for a seller or a category when either is set.
+type EstimateAccuracyDTO struct {
+	SellerId string `json:"seller_id,omitempty"`
+	Category string `json:"category,omitempty"`
+
+	Lots   int `json:"lots"`
+	Unsold int `json:"unsold"`
+	Below  int `json:"below"`
+	Within int `json:"within"`
+	Above  int `json:"above"`
+
+	// AccuracyRate is the share of the sold lots that sold within their
+	// estimate.
+	AccuracyRate float64 `json:"accuracy_rate"`
+
+	// MeanDeviation averages how far the sold lots went from the middle of
+	// their estimate, relative to it, negative when they sold below it.
+	MeanDeviation float64 `json:"mean_deviation"`
+}
+
+type EstimateAccuracyOutputDTO struct {
+	Overall    EstimateAccuracyDTO   `json:"overall"`
+	BySeller   []EstimateAccuracyDTO `json:"by_seller,omitempty"`
+	ByCategory []EstimateAccuracyDTO `json:"by_category"`
+}
+
+// FindEstimateAccuracy measures the estimates of the ended auctions against
+// the prices they sold for, of the seller and in the category when not
+// empty. It breaks the figures down by seller unless sellerId is set.
+func (au *AuctionUseCase) FindEstimateAccuracy(
+	ctx context.Context,
+	sellerId, category string) (*EstimateAccuracyOutputDTO, *internal_error.InternalError) {
+	auctions, err := au.auctionRepositoryInterface.FindEstimatedAuctions(ctx, sellerId, category)
+	if err != nil {
+		return nil, err
+	}
+
+	auctionIds := make([]string, 0, len(auctions))
+	for _, auction := range auctions {
+		auctionIds = append(auctionIds, auction.Id)
+	}
+
+	winningBids, err := au.bidRepositoryInterface.FindWinningBidsByAuctionIds(ctx, auctionIds)
+	if err != nil {
+		return nil, err
+	}
+
+	prices := make(map[string]float64, len(winningBids))
+	for auctionId, bid := range winningBids {
+		prices[auctionId] = bid.Amount
+	}
+
+	accuracy := estimateAccuracy(auctions, prices, sellerId == "")
+	return &accuracy, nil
+}
+
+// estimateAccuracy tallies the auctions, prices mapping the ones sold for
+// enough to meet their reserve to their highest bid.
+func estimateAccuracy(
+	auctions []auction_entity.Auction,
+	prices map[string]float64,
+	bySeller bool) EstimateAccuracyOutputDTO {
+	overall := &estimateTally{}
+	sellers := make(map[string]*estimateTally)
+	categories := make(map[string]*estimateTally)
+
+	for _, auction := range auctions {
+		seller, ok := sellers[auction.SellerId]
+		if !ok {
+			seller = &estimateTally{EstimateAccuracyDTO: EstimateAccuracyDTO{SellerId: auction.SellerId}}
+			sellers[auction.SellerId] = seller
+		}
+		category, ok := categories[auction.Category]
+		if !ok {
+			category = &estimateTally{EstimateAccuracyDTO: EstimateAccuracyDTO{Category: auction.Category}}
+			categories[auction.Category] = category
+		}
+
+		price, sold := prices[auction.Id]
+		sold = sold && auction.Status == auction_entity.Completed && auction.ReserveMet(price)
+		for _, tally := range []*estimateTally{overall, seller, category} {
+			tally.add(&auction, price, sold)
+		}
+	}
+
+	output := EstimateAccuracyOutputDTO{
+		Overall:    overall.summed(),
+		ByCategory: sortedAccuracies(categories),
+	}
+	if bySeller {
+		output.BySeller = sortedAccuracies(sellers)
+	}
+
+	return output
+}
+
+type estimateTally struct {
+	EstimateAccuracyDTO
+	deviations float64
+}
+
+func (et *estimateTally) add(auction *auction_entity.Auction, price float64, sold bool) {
+	et.Lots++
+	if !sold {
+		et.Unsold++
+		return
+	}
+
+	switch auction.EstimateOutcome(price) {
+	case auction_entity.EstimateBelow:
+		et.Below++
+	case auction_entity.EstimateAbove:
+		et.Above++
+	default:
+		et.Within++
+	}
+	et.deviations += auction.EstimateDeviation(price)
+}
+
+func (et *estimateTally) summed() EstimateAccuracyDTO {
+	accuracy := et.EstimateAccuracyDTO
+	if sold := accuracy.Lots - accuracy.Unsold; sold > 0 {
+		accuracy.AccuracyRate = float64(accuracy.Within) / float64(sold)
+		accuracy.MeanDeviation = et.deviations / float64(sold)
+	}
+	return accuracy
+}
+
+// sortedAccuracies orders the groups by lots, most first.
+func sortedAccuracies(groups map[string]*estimateTally) []EstimateAccuracyDTO {
+	accuracies := make([]EstimateAccuracyDTO, 0, len(groups))
+	for _, tally := range groups {
+		accuracies = append(accuracies, tally.summed())
+	}
+
+	sort.Slice(accuracies, func(i, j int) bool {
+		if accuracies[i].Lots != accuracies[j].Lots {
+			return accuracies[i].Lots > accuracies[j].Lots
+		}
+		return accuracies[i].SellerId+accuracies[i].Category < accuracies[j].SellerId+accuracies[j].Category
+	})
+
+	return accuracies
+}
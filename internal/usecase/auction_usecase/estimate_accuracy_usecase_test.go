@@ -0,0 +1,42 @@
+package auction_usecase
+
+import (
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateAccuracy(t *testing.T) {
+	estimated := func(id, sellerId, category string, status auction_entity.AuctionStatus) auction_entity.Auction {
+		return auction_entity.Auction{
+			Id: id, SellerId: sellerId, Category: category, Status: status,
+			EstimateLow: 80, EstimateHigh: 120, ReservePrice: 50,
+		}
+	}
+	auctions := []auction_entity.Auction{
+		estimated("a1", "ann", "art", auction_entity.Completed),
+		estimated("a2", "ann", "art", auction_entity.Completed),
+		estimated("a3", "ann", "home", auction_entity.Completed),
+		estimated("a4", "bob", "art", auction_entity.ClosedNoSale),
+		estimated("a5", "bob", "art", auction_entity.Completed),
+	}
+	prices := map[string]float64{"a1": 100, "a2": 150, "a3": 90, "a4": 40, "a5": 30}
+
+	accuracy := estimateAccuracy(auctions, prices, true)
+
+	// The lot closed unsold and the one below its reserve count as unsold.
+	assert.Equal(t, 5, accuracy.Overall.Lots)
+	assert.Equal(t, 2, accuracy.Overall.Unsold)
+	assert.Equal(t, 2, accuracy.Overall.Within)
+	assert.Equal(t, 1, accuracy.Overall.Above)
+	assert.InDelta(t, 2.0/3, accuracy.Overall.AccuracyRate, 1e-9)
+	assert.InDelta(t, (0+0.5-0.1)/3, accuracy.Overall.MeanDeviation, 1e-9)
+
+	assert.Equal(t, []string{"ann", "bob"}, []string{accuracy.BySeller[0].SellerId, accuracy.BySeller[1].SellerId})
+	assert.Equal(t, 0, accuracy.BySeller[1].Within+accuracy.BySeller[1].Below+accuracy.BySeller[1].Above)
+	assert.Equal(t, "art", accuracy.ByCategory[0].Category)
+	assert.Equal(t, 4, accuracy.ByCategory[0].Lots)
+
+	assert.Nil(t, estimateAccuracy(auctions, prices, false).BySeller)
+}
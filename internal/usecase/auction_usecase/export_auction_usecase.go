@@ -0,0 +1,19 @@
+package auction_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+)
+
+func (au *AuctionUseCase) ExportAuctions(
+	ctx context.Context,
+	status AuctionStatus,
+	category string,
+	handle func(AuctionOutputDTO) error) *internal_error.InternalError {
+	return au.auctionRepositoryInterface.StreamAuctions(
+		ctx, auction_entity.AuctionStatus(status), category,
+		func(auction auction_entity.Auction) error {
+			return handle(toAuctionOutputDTO(&auction))
+		})
+}
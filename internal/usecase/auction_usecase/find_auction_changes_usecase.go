@@ -0,0 +1,54 @@
+package auction_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/internal_error"
+	"strconv"
+	"time"
+)
+
+type AuctionChangesOutputDTO struct {
+	Auctions []AuctionOutputDTO `json:"auctions"`
+	Cursor   string             `json:"cursor"`
+}
+
+// ParseChangesCursor accepts either a cursor returned by a previous changes
+// listing or an RFC 3339 timestamp.
+func ParseChangesCursor(value string) (time.Time, error) {
+	if since, err := time.Parse(time.RFC3339, value); err == nil {
+		return since, nil
+	}
+
+	milliseconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.UnixMilli(milliseconds), nil
+}
+
+func formatChangesCursor(since time.Time) string {
+	return strconv.FormatInt(since.UnixMilli(), 10)
+}
+
+func (au *AuctionUseCase) FindAuctionChanges(
+	ctx context.Context,
+	since time.Time) (*AuctionChangesOutputDTO, *internal_error.InternalError) {
+	auctionEntities, err := au.auctionRepositoryInterface.FindAuctionChanges(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+
+	auctionOutputs := make([]AuctionOutputDTO, 0, len(auctionEntities))
+	for _, value := range auctionEntities {
+		auctionOutputs = append(auctionOutputs, toAuctionOutputDTO(&value))
+		if value.UpdatedAt.After(since) {
+			since = value.UpdatedAt
+		}
+	}
+
+	return &AuctionChangesOutputDTO{
+		Auctions: auctionOutputs,
+		Cursor:   formatChangesCursor(since),
+	}, nil
+}
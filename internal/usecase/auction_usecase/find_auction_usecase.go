@@ -2,54 +2,234 @@ package auction_usecase
 
 import (
 	"context"
+	"fmt"
+	"fullcycle-auction_go/configuration/clock"
 	"fullcycle-auction_go/configuration/logger"
 	"fullcycle-auction_go/internal/entity/auction_entity"
 	"fullcycle-auction_go/internal/internal_error"
 	"fullcycle-auction_go/internal/usecase/bid_usecase"
+	"fullcycle-auction_go/internal/usecase/pagination"
+	"fullcycle-auction_go/internal/usecase/user_usecase"
+	"time"
 )
 
+// AuctionFilterDTO selects the auctions listed. Zero values leave their
+// criteria open; the time bounds are exclusive.
+type AuctionFilterDTO struct {
+	Status      AuctionStatus
+	Category    string
+	ProductName string
+	Attributes  map[string]string
+
+	// MinPrice and MaxPrice bound the starting price.
+	MinPrice, MaxPrice float64
+
+	EndingBefore, EndingAfter time.Time
+	CreatedAfter              time.Time
+}
+
+// The orders auction listings are sorted in, SortNewest by default.
+const (
+	SortNewest     = "newest"
+	SortEndingSoon = "ending_soon"
+	// SortHighestBid lists the auctions with the highest stored bid first,
+	// counting sealed auctions as without bids until they end.
+	SortHighestBid = "highest_bid"
+	// SortHeat lists the most popular auctions first, by heat score.
+	SortHeat = "heat"
+)
+
+// sortFields lists the stored fields each order needs.
+var sortFields = map[string][]string{
+	SortNewest:     {"timestamp"},
+	SortEndingSoon: {"ends_at"},
+	SortHighestBid: {"sealed", "status"},
+	SortHeat:       {"heat_score"},
+}
+
 func (au *AuctionUseCase) FindAuctionById(
+	ctx context.Context, id string) (*AuctionOutputDTO, *internal_error.InternalError) {
+	if au.snapshots != nil {
+		return au.findAuctionSnapshot(ctx, id)
+	}
+
+	return au.loadAuction(ctx, id)
+}
+
+func (au *AuctionUseCase) loadAuction(
 	ctx context.Context, id string) (*AuctionOutputDTO, *internal_error.InternalError) {
 	auctionEntity, err := au.auctionRepositoryInterface.FindAuctionById(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	return &AuctionOutputDTO{
-		Id:          auctionEntity.Id,
-		ProductName: auctionEntity.ProductName,
-		Category:    auctionEntity.Category,
-		Description: auctionEntity.Description,
-		Condition:   ProductCondition(auctionEntity.Condition),
-		Status:      AuctionStatus(auctionEntity.Status),
-		Timestamp:   auctionEntity.Timestamp,
-	}, nil
+	auctionOutputs := []AuctionOutputDTO{toAuctionOutputDTO(auctionEntity)}
+	au.presentSellers(ctx, auctionOutputs)
+
+	return &auctionOutputs[0], nil
 }
 
 func (au *AuctionUseCase) FindAuctions(
 	ctx context.Context,
-	status AuctionStatus,
-	category, productName string) ([]AuctionOutputDTO, *internal_error.InternalError) {
-	auctionEntities, err := au.auctionRepositoryInterface.FindAuctions(
-		ctx, auction_entity.AuctionStatus(status), category, productName)
+	filter AuctionFilterDTO,
+	fields []string,
+	sortBy, userId string,
+	page pagination.Params) (*pagination.Page[AuctionOutputDTO], *internal_error.InternalError) {
+	if sortBy == "" {
+		sortBy = SortNewest
+	}
+	if _, ok := sortFields[sortBy]; !ok {
+		return nil, internal_error.NewBadRequestError(fmt.Sprintf(
+			"Auctions can only be sorted by %s, %s, %s or %s", SortNewest, SortEndingSoon, SortHighestBid, SortHeat))
+	}
+	if filter.MaxPrice > 0 && filter.MaxPrice < filter.MinPrice {
+		return nil, internal_error.NewBadRequestError("Max price cannot be below the min price")
+	}
+	if !filter.EndingAfter.IsZero() && !filter.EndingBefore.IsZero() &&
+		!filter.EndingAfter.Before(filter.EndingBefore) {
+		return nil, internal_error.NewBadRequestError("The ending after bound must be before the ending before one")
+	}
+
+	stored := storedFields(fields)
+	if len(stored) > 0 {
+		stored = append(stored, listingFields...)
+		stored = append(stored, sortFields[sortBy]...)
+	}
+
+	categories, err := au.categoryTree(ctx, filter.Category)
+	if err != nil {
+		return nil, err
+	}
+
+	auctionEntities, err := au.auctionRepositoryInterface.FindAuctions(ctx, auction_entity.AuctionFilter{
+		Status:       auction_entity.AuctionStatus(filter.Status),
+		Categories:   categories,
+		ProductName:  filter.ProductName,
+		Attributes:   filter.Attributes,
+		MinPrice:     filter.MinPrice,
+		MaxPrice:     filter.MaxPrice,
+		EndingBefore: filter.EndingBefore,
+		EndingAfter:  filter.EndingAfter,
+		CreatedAfter: filter.CreatedAfter,
+	}, stored)
 	if err != nil {
 		return nil, err
 	}
 
+	now := clock.Now()
+	var listed []auction_entity.Auction
 	var auctionOutputs []AuctionOutputDTO
 	for _, value := range auctionEntities {
-		auctionOutputs = append(auctionOutputs, AuctionOutputDTO{
-			Id:          value.Id,
-			ProductName: value.ProductName,
-			Category:    value.Category,
-			Description: value.Description,
-			Condition:   ProductCondition(value.Condition),
-			Status:      AuctionStatus(value.Status),
-			Timestamp:   value.Timestamp,
-		})
-	}
-
-	return auctionOutputs, nil
+		if value.ListedFor(userId, now) {
+			listed = append(listed, value)
+			auctionOutputs = append(auctionOutputs, toAuctionOutputDTO(&value))
+		}
+	}
+
+	order, err := au.auctionOrder(ctx, sortBy, listed)
+	if err != nil {
+		return nil, err
+	}
+
+	auctionPage, err := pagination.Paginate(auctionOutputs, order, page)
+	if err != nil {
+		return nil, err
+	}
+
+	if selected(fields, "seller") {
+		au.presentSellers(ctx, auctionPage.Items)
+	}
+
+	return auctionPage, nil
+}
+
+// auctionOrder sorts the listed auctions as sortBy names, looking their
+// highest bids up when sorted by them.
+func (au *AuctionUseCase) auctionOrder(
+	ctx context.Context,
+	sortBy string,
+	listed []auction_entity.Auction) (pagination.Order[AuctionOutputDTO], *internal_error.InternalError) {
+	order := pagination.Order[AuctionOutputDTO]{Name: sortBy}
+
+	switch sortBy {
+	case SortEndingSoon:
+		order.Key = func(auction AuctionOutputDTO) pagination.Key {
+			return pagination.Key{Value: float64(auction.EndsAt.UnixMicro()), Id: auction.Id}
+		}
+	case SortHeat:
+		order.Descending = true
+		order.Key = func(auction AuctionOutputDTO) pagination.Key {
+			return pagination.Key{Value: auction.HeatScore, Id: auction.Id}
+		}
+	case SortHighestBid:
+		var auctionIds []string
+		for _, auction := range listed {
+			if !auction.BidsHidden() {
+				auctionIds = append(auctionIds, auction.Id)
+			}
+		}
+
+		winningBids, err := au.bidRepositoryInterface.FindWinningBidsByAuctionIds(ctx, auctionIds)
+		if err != nil {
+			return order, err
+		}
+
+		order.Descending = true
+		order.Key = func(auction AuctionOutputDTO) pagination.Key {
+			return pagination.Key{Value: winningBids[auction.Id].Amount, Id: auction.Id}
+		}
+	default:
+		order.Descending = true
+		order.Key = func(auction AuctionOutputDTO) pagination.Key {
+			return pagination.Key{Value: float64(auction.Timestamp.UnixMicro()), Id: auction.Id}
+		}
+	}
+
+	return order, nil
+}
+
+// listingFields are the stored fields deciding who the auctions are listed
+// for, loaded whatever the fields requested.
+var listingFields = []string{"status", "seller_id", "public_at", "invitees"}
+
+// computedFieldSources lists the stored fields the computed output fields
+// are made of.
+var computedFieldSources = map[string][]string{
+	"seller": {"seller_id"},
+	"labels": {"category", "status", "condition"},
+	// The description is served in the language of the request.
+	"description": {"description", "descriptions"},
+	// The bid velocity is counted by the bid use case, not stored.
+	"bid_velocity": nil,
+}
+
+// storedFields translates the output fields requested into the stored
+// fields needed to build them.
+func storedFields(fields []string) []string {
+	var stored []string
+	for _, field := range fields {
+		if sources, ok := computedFieldSources[field]; ok {
+			stored = append(stored, sources...)
+			continue
+		}
+		stored = append(stored, field)
+	}
+
+	return stored
+}
+
+func selected(fields []string, field string) bool {
+	if len(fields) == 0 {
+		return true
+	}
+
+	for _, value := range fields {
+		if value == field {
+			return true
+		}
+	}
+
+	return false
 }
 
 func (au *AuctionUseCase) FindWinningBidByAuctionId(
@@ -60,35 +240,140 @@ func (au *AuctionUseCase) FindWinningBidByAuctionId(
 		return nil, err
 	}
 
-	auctionOutputDTO := AuctionOutputDTO{
-		Id:          auction.Id,
-		ProductName: auction.ProductName,
-		Category:    auction.Category,
-		Description: auction.Description,
-		Condition:   ProductCondition(auction.Condition),
-		Status:      AuctionStatus(auction.Status),
-		Timestamp:   auction.Timestamp,
+	auctionOutputs := []AuctionOutputDTO{toAuctionOutputDTO(auction)}
+	au.presentSellers(ctx, auctionOutputs)
+	auctionOutputDTO := auctionOutputs[0]
+
+	if auction.BidsHidden() || auction.Status == auction_entity.Cancelled {
+		return &WinningInfoOutputDTO{Auction: auctionOutputDTO}, nil
 	}
 
-	bidWinning, err := au.bidRepositoryInterface.FindWinningBidByAuctionId(ctx, auction.Id)
+	bidOutputDTO, err := au.bidUseCase.FindWinningBidByAuctionId(ctx, auction.Id)
 	if err != nil {
 		logger.Error("", err)
 		return &WinningInfoOutputDTO{
-			Auction: auctionOutputDTO,
-			Bid:     nil,
+			Auction:       auctionOutputDTO,
+			Bid:           nil,
+			ReserveNotMet: auction.ReservePrice > 0,
 		}, nil
 	}
 
-	bidOutputDTO := &bid_usecase.BidOutputDTO{
-		Id:        bidWinning.Id,
-		UserId:    bidWinning.UserId,
-		AuctionId: bidWinning.AuctionId,
-		Amount:    bidWinning.Amount,
-		Timestamp: bidWinning.Timestamp,
+	if !auction.ReserveMet(bidOutputDTO.Amount) {
+		winningInfo := &WinningInfoOutputDTO{
+			Auction:       auctionOutputDTO,
+			Bid:           bidOutputDTO,
+			ReserveNotMet: true,
+		}
+		if auction.Status.Ended() {
+			winningInfo.Bid = nil
+		}
+		return winningInfo, nil
+	}
+
+	winningBids, err := au.bidUseCase.FindWinningBids(ctx, auction.Id)
+	if err != nil {
+		return nil, err
 	}
 
 	return &WinningInfoOutputDTO{
 		Auction: auctionOutputDTO,
 		Bid:     bidOutputDTO,
+		Winners: unitWinners(auction, winningBids),
 	}, nil
 }
+
+// unitWinners prices the winning bids meeting the reserve, one unit each.
+func unitWinners(auction *auction_entity.Auction, winningBids []bid_usecase.BidOutputDTO) []UnitWinnerDTO {
+	var winners []UnitWinnerDTO
+	for _, bid := range winningBids {
+		if !auction.ReserveMet(bid.Amount) {
+			break
+		}
+		winners = append(winners, UnitWinnerDTO{Unit: len(winners) + 1, Bid: bid, Price: bid.Amount})
+	}
+
+	if auction.UniformPrice && len(winners) > 0 {
+		lowest := winners[len(winners)-1].Price
+		for i := range winners {
+			winners[i].Price = lowest
+		}
+	}
+
+	return winners
+}
+
+// presentSellers embeds the public profile of the sellers in the auctions.
+func (au *AuctionUseCase) presentSellers(ctx context.Context, auctions []AuctionOutputDTO) {
+	sellerIds := make([]string, len(auctions))
+	for i, auction := range auctions {
+		sellerIds[i] = auction.SellerId
+	}
+
+	profiles := user_usecase.FindPublicProfiles(ctx, au.userRepositoryInterface, sellerIds)
+	for i := range auctions {
+		if profile, ok := profiles[auctions[i].SellerId]; ok {
+			auctions[i].Seller = &profile
+		}
+	}
+}
+
+func toAuctionOutputDTO(auction *auction_entity.Auction) AuctionOutputDTO {
+	output := AuctionOutputDTO{
+		Id:          auction.Id,
+		SellerId:    auction.SellerId,
+		ProductId:   auction.ProductId,
+		ProductName: auction.ProductName,
+		Category:    auction.Category,
+		Description: auction.Description,
+		Condition:   ProductCondition(auction.Condition),
+		Grading:     toConditionGradingDTO(auction.Grading),
+		Attributes:  auction.Attributes,
+		Status:      AuctionStatus(auction.Status),
+		Timestamp:   auction.Timestamp,
+		UpdatedAt:   auction.UpdatedAt,
+		EndsAt:      auction.EndsAt,
+		WinnerBidId: auction.WinnerBidId,
+
+		BidderVisibility:   string(auction.BidderVisibility),
+		Descriptions:       auction.Descriptions,
+		RecurringAuctionId: auction.RecurringAuctionId,
+		SeriesId:           auction.SeriesId,
+		LotNumber:          auction.LotNumber,
+		Live:               auction.Live,
+		StartingPrice:      auction.StartingPrice,
+		HasReserve:         auction.ReservePrice > 0,
+		MinIncrement:       auction.MinIncrement,
+		Sealed:             auction.Sealed,
+		BuyNowPrice:        auction.BuyNowPrice,
+		Quantity:           auction.Quantity,
+		UniformPrice:       auction.UniformPrice,
+		EstimateLow:        auction.EstimateLow,
+		EstimateHigh:       auction.EstimateHigh,
+
+		RegistrationRequired: auction.RegistrationRequired,
+		RelistedFrom:         auction.RelistedFrom,
+		HeatScore:            auction.HeatScore,
+	}
+	if !auction.StartsAt.IsZero() {
+		output.StartsAt = &auction.StartsAt
+	}
+	if !auction.BiddingOpenedAt.IsZero() {
+		output.BiddingOpenedAt = &auction.BiddingOpenedAt
+	}
+	if !auction.PausedAt.IsZero() {
+		output.PausedAt = &auction.PausedAt
+	}
+	if !auction.PublicAt.IsZero() {
+		output.PublicAt = &auction.PublicAt
+	}
+
+	if auction.Terms != nil {
+		output.Terms = &TermsOutputDTO{
+			ReturnsPolicy:       auction.Terms.ReturnsPolicy,
+			PaymentDeadlineDays: auction.Terms.PaymentDeadlineDays,
+			Version:             auction.Terms.Version,
+		}
+	}
+
+	return output
+}
@@ -0,0 +1,29 @@
+package auction_usecase
+
+import (
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/usecase/bid_usecase"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitWinners(t *testing.T) {
+	bids := []bid_usecase.BidOutputDTO{{Id: "ann", Amount: 30}, {Id: "bob", Amount: 20}, {Id: "cid", Amount: 10}}
+	prices := func(winners []UnitWinnerDTO) []float64 {
+		var result []float64
+		for _, winner := range winners {
+			result = append(result, winner.Price)
+		}
+		return result
+	}
+
+	assert.Equal(t, []float64{30, 20, 10}, prices(unitWinners(&auction_entity.Auction{Quantity: 3}, bids)))
+	assert.Equal(t, []float64{10, 10, 10},
+		prices(unitWinners(&auction_entity.Auction{Quantity: 3, UniformPrice: true}, bids)))
+
+	// Bids below the reserve win no unit, nor set the uniform price.
+	winners := unitWinners(&auction_entity.Auction{Quantity: 3, UniformPrice: true, ReservePrice: 15}, bids)
+	assert.Equal(t, []float64{20, 20}, prices(winners))
+	assert.Equal(t, 2, winners[1].Unit)
+}
@@ -0,0 +1,72 @@
+package auction_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/i18n"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+)
+
+// AuctionLabelsDTO carries the display labels of the auction codes in the
+// requested language. The codes themselves never change.
+type AuctionLabelsDTO struct {
+	Category  string `json:"category"`
+	Status    string `json:"status"`
+	Condition string `json:"condition"`
+}
+
+var statusLabelKeys = map[AuctionStatus]string{
+	AuctionStatus(auction_entity.Active):       "status.active",
+	AuctionStatus(auction_entity.Completed):    "status.completed",
+	AuctionStatus(auction_entity.Scheduled):    "status.scheduled",
+	AuctionStatus(auction_entity.ClosedNoSale): "status.closed_no_sale",
+	AuctionStatus(auction_entity.Cancelled):    "status.cancelled",
+	AuctionStatus(auction_entity.Paused):       "status.paused",
+	AuctionStatus(auction_entity.Draft):        "status.draft",
+}
+
+var conditionLabelKeys = map[ProductCondition]string{
+	ProductCondition(auction_entity.New):         "condition.new",
+	ProductCondition(auction_entity.Used):        "condition.used",
+	ProductCondition(auction_entity.Refurbished): "condition.refurbished",
+}
+
+// LocalizeAuctions fills the labels of the auctions in the language and
+// serves the description the seller translated to it, if any. Categories
+// without a label in it keep their code as label.
+func (au *AuctionUseCase) LocalizeAuctions(
+	ctx context.Context, language string, auctions []AuctionOutputDTO) {
+	categoryLabels := make(map[string]string)
+
+	for i := range auctions {
+		category := auctions[i].Category
+		if _, ok := categoryLabels[category]; !ok {
+			categoryLabels[category] = au.categoryLabel(ctx, language, category)
+		}
+
+		auctions[i].Labels = &AuctionLabelsDTO{
+			Category:  categoryLabels[category],
+			Status:    i18n.Label(language, statusLabelKeys[auctions[i].Status]),
+			Condition: i18n.Label(language, conditionLabelKeys[auctions[i].Condition]),
+		}
+		if description, ok := auctions[i].Descriptions[language]; ok {
+			auctions[i].Description = description
+		}
+	}
+}
+
+func (au *AuctionUseCase) categoryLabel(ctx context.Context, language, category string) string {
+	schema, err := au.categorySchemaRepositoryInterface.FindCategorySchema(ctx, category)
+	if err != nil {
+		if err.Err != "not_found" {
+			logger.Error("error trying to find category labels", err)
+		}
+		return category
+	}
+
+	if label, ok := schema.Labels[language]; ok {
+		return label
+	}
+
+	return category
+}
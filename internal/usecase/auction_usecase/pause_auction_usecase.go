@@ -0,0 +1,66 @@
+package auction_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/clock"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"fullcycle-auction_go/internal/usecase/authorization"
+)
+
+// PauseAuction lets the seller or an admin pause an active auction. Bids
+// are rejected while it is paused and the completion routine stops on
+// finding it paused, so it does not end meanwhile.
+func (au *AuctionUseCase) PauseAuction(
+	ctx context.Context,
+	auctionId, userId string) *internal_error.InternalError {
+	auction, err := au.auctionRepositoryInterface.FindAuctionById(ctx, auctionId)
+	if err != nil {
+		return err
+	}
+
+	if err := authorization.CheckSellerOrAdmin(ctx, au.userRepositoryInterface, auction, userId, "pause"); err != nil {
+		return err
+	}
+
+	if auction.Status != auction_entity.Active {
+		return internal_error.NewBadRequestError("Only active auctions can be paused")
+	}
+
+	if err := au.auctionRepositoryInterface.PauseAuction(ctx, auctionId, clock.Now()); err != nil {
+		if err.Err == "not_found" {
+			return internal_error.NewBadRequestError("Only active auctions can be paused")
+		}
+		return err
+	}
+
+	return nil
+}
+
+// ResumeAuction lets the seller or an admin resume a paused auction, which
+// ends as much later as it spent paused, so it keeps the time it had left.
+func (au *AuctionUseCase) ResumeAuction(
+	ctx context.Context,
+	auctionId, userId string) *internal_error.InternalError {
+	auction, err := au.auctionRepositoryInterface.FindAuctionById(ctx, auctionId)
+	if err != nil {
+		return err
+	}
+
+	if err := authorization.CheckSellerOrAdmin(ctx, au.userRepositoryInterface, auction, userId, "resume"); err != nil {
+		return err
+	}
+
+	if auction.Status != auction_entity.Paused {
+		return internal_error.NewBadRequestError("Only paused auctions can be resumed")
+	}
+
+	if _, err := au.auctionRepositoryInterface.ResumeAuction(ctx, auctionId, clock.Now()); err != nil {
+		if err.Err == "not_found" {
+			return internal_error.NewBadRequestError("Only paused auctions can be resumed")
+		}
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,313 @@
+package auction_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/clock"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type RecurringAuctionInputDTO struct {
+	// Schedule is a cron expression, e.g. "0 10 * * MON" for every Monday
+	// at 10:00.
+	Schedule string          `json:"schedule" binding:"required"`
+	Timezone string          `json:"timezone"`
+	Auction  AuctionInputDTO `json:"auction" binding:"required"`
+}
+
+type RecurringAuctionActiveInputDTO struct {
+	Active *bool `json:"active" binding:"required"`
+}
+
+type AuctionTemplateOutputDTO struct {
+	ProductId        string              `json:"product_id,omitempty"`
+	ProductName      string              `json:"product_name,omitempty"`
+	Category         string              `json:"category,omitempty"`
+	Description      string              `json:"description,omitempty"`
+	Grading          ConditionGradingDTO `json:"grading"`
+	Attributes       map[string]string   `json:"attributes,omitempty"`
+	BidderVisibility string              `json:"bidder_visibility,omitempty"`
+	Terms            *TermsOutputDTO     `json:"terms,omitempty"`
+}
+
+type RecurringAuctionOutputDTO struct {
+	Id        string                   `json:"id"`
+	SellerId  string                   `json:"seller_id"`
+	Schedule  string                   `json:"schedule"`
+	Timezone  string                   `json:"timezone,omitempty"`
+	Auction   AuctionTemplateOutputDTO `json:"auction"`
+	Active    bool                     `json:"active"`
+	NextRunAt *time.Time               `json:"next_run_at,omitempty"`
+	LastRunAt *time.Time               `json:"last_run_at,omitempty"`
+	CreatedAt time.Time                `json:"created_at"`
+}
+
+type RecurringAuctionUseCaseInterface interface {
+	CreateRecurringAuction(
+		ctx context.Context,
+		sellerId string,
+		input RecurringAuctionInputDTO) (*RecurringAuctionOutputDTO, *internal_error.InternalError)
+
+	FindRecurringAuctions(
+		ctx context.Context, sellerId string) ([]RecurringAuctionOutputDTO, *internal_error.InternalError)
+
+	FindRecurringAuctionById(
+		ctx context.Context, id string) (*RecurringAuctionOutputDTO, *internal_error.InternalError)
+
+	UpdateRecurringAuctionActive(
+		ctx context.Context,
+		id, sellerId string,
+		input RecurringAuctionActiveInputDTO) (*RecurringAuctionOutputDTO, *internal_error.InternalError)
+}
+
+// RecurringAuctionUseCase manages the recurring auctions of sellers and
+// creates their auctions as their schedules fire.
+type RecurringAuctionUseCase struct {
+	recurringAuctionRepositoryInterface auction_entity.RecurringAuctionRepositoryInterface
+	auctionUseCase                      AuctionUseCaseInterface
+	scanInterval                        time.Duration
+}
+
+func NewRecurringAuctionUseCase(
+	recurringAuctionRepositoryInterface auction_entity.RecurringAuctionRepositoryInterface,
+	auctionUseCase AuctionUseCaseInterface) RecurringAuctionUseCaseInterface {
+	recurringAuctionUseCase := &RecurringAuctionUseCase{
+		recurringAuctionRepositoryInterface: recurringAuctionRepositoryInterface,
+		auctionUseCase:                      auctionUseCase,
+		scanInterval:                        clock.Scale(getRecurringAuctionScanInterval()),
+	}
+
+	recurringAuctionUseCase.triggerScanRoutine(context.Background())
+
+	return recurringAuctionUseCase
+}
+
+func (ru *RecurringAuctionUseCase) CreateRecurringAuction(
+	ctx context.Context,
+	sellerId string,
+	input RecurringAuctionInputDTO) (*RecurringAuctionOutputDTO, *internal_error.InternalError) {
+	input.Auction.SellerId = sellerId
+	if err := ru.auctionUseCase.ValidateAuction(ctx, input.Auction); err != nil {
+		return nil, err
+	}
+
+	recurringAuction, err := auction_entity.CreateRecurringAuction(
+		sellerId, input.Schedule, input.Timezone, toAuctionTemplate(input.Auction))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ru.recurringAuctionRepositoryInterface.CreateRecurringAuction(ctx, recurringAuction); err != nil {
+		return nil, err
+	}
+
+	output := toRecurringAuctionOutputDTO(recurringAuction)
+	return &output, nil
+}
+
+func (ru *RecurringAuctionUseCase) FindRecurringAuctions(
+	ctx context.Context, sellerId string) ([]RecurringAuctionOutputDTO, *internal_error.InternalError) {
+	recurringAuctions, err := ru.recurringAuctionRepositoryInterface.FindRecurringAuctions(ctx, sellerId)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs := make([]RecurringAuctionOutputDTO, 0, len(recurringAuctions))
+	for i := range recurringAuctions {
+		outputs = append(outputs, toRecurringAuctionOutputDTO(&recurringAuctions[i]))
+	}
+
+	return outputs, nil
+}
+
+func (ru *RecurringAuctionUseCase) FindRecurringAuctionById(
+	ctx context.Context, id string) (*RecurringAuctionOutputDTO, *internal_error.InternalError) {
+	recurringAuction, err := ru.recurringAuctionRepositoryInterface.FindRecurringAuctionById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	output := toRecurringAuctionOutputDTO(recurringAuction)
+	return &output, nil
+}
+
+// UpdateRecurringAuctionActive pauses or resumes the series. A resumed
+// series skips the runs missed while paused.
+func (ru *RecurringAuctionUseCase) UpdateRecurringAuctionActive(
+	ctx context.Context,
+	id, sellerId string,
+	input RecurringAuctionActiveInputDTO) (*RecurringAuctionOutputDTO, *internal_error.InternalError) {
+	recurringAuction, err := ru.recurringAuctionRepositoryInterface.FindRecurringAuctionById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if recurringAuction.SellerId != sellerId {
+		return nil, internal_error.NewForbiddenError("Only the seller can change this recurring auction")
+	}
+
+	recurringAuction.Active = *input.Active
+	recurringAuction.NextRunAt = time.Time{}
+	if recurringAuction.Active {
+		if recurringAuction.NextRunAt, err = recurringAuction.NextRun(clock.Now()); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := ru.recurringAuctionRepositoryInterface.UpdateRecurringAuctionActive(
+		ctx, id, recurringAuction.Active, recurringAuction.NextRunAt); err != nil {
+		return nil, err
+	}
+
+	output := toRecurringAuctionOutputDTO(recurringAuction)
+	return &output, nil
+}
+
+func (ru *RecurringAuctionUseCase) triggerScanRoutine(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(ru.scanInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			ru.scan(ctx)
+		}
+	}()
+}
+
+// scan creates the auctions of the series that are due. Each run is first
+// claimed by moving the series to its next run, so with several instances
+// only one creates the auction, and a series behind by several runs, after
+// downtime, creates a single auction.
+func (ru *RecurringAuctionUseCase) scan(ctx context.Context) {
+	now := clock.Now()
+
+	recurringAuctions, err := ru.recurringAuctionRepositoryInterface.FindDueRecurringAuctions(ctx, now)
+	if err != nil {
+		logger.Error("error trying to find due recurring auctions", err)
+		return
+	}
+
+	for _, recurringAuction := range recurringAuctions {
+		nextRunAt, err := recurringAuction.NextRun(now)
+		if err != nil {
+			logger.Error("error trying to schedule recurring auction", err,
+				zap.String("recurring_auction_id", recurringAuction.Id))
+			continue
+		}
+
+		if err := ru.recurringAuctionRepositoryInterface.UpdateRecurringAuctionRun(
+			ctx, recurringAuction.Id, recurringAuction.NextRunAt, nextRunAt); err != nil {
+			if err.Err != "not_found" {
+				logger.Error("error trying to claim recurring auction run", err,
+					zap.String("recurring_auction_id", recurringAuction.Id))
+			}
+			continue
+		}
+
+		auctionInput := toAuctionInputDTO(recurringAuction)
+		if err := ru.auctionUseCase.CreateAuction(ctx, auctionInput); err != nil {
+			logger.Error("error trying to create recurring auction run", err,
+				zap.String("recurring_auction_id", recurringAuction.Id))
+		}
+	}
+}
+
+func toAuctionTemplate(auctionInput AuctionInputDTO) auction_entity.AuctionTemplate {
+	template := auction_entity.AuctionTemplate{
+		ProductId:        auctionInput.ProductId,
+		ProductName:      auctionInput.ProductName,
+		Category:         auctionInput.Category,
+		Description:      auctionInput.Description,
+		Grading:          toConditionGrading(auctionInput),
+		Attributes:       auctionInput.Attributes,
+		BidderVisibility: auction_entity.BidderVisibility(auctionInput.BidderVisibility),
+	}
+
+	if auctionInput.Terms != nil {
+		template.Terms = &auction_entity.Terms{
+			ReturnsPolicy:       auctionInput.Terms.ReturnsPolicy,
+			PaymentDeadlineDays: auctionInput.Terms.PaymentDeadlineDays,
+			Version:             1,
+		}
+	}
+
+	return template
+}
+
+func toAuctionInputDTO(recurringAuction auction_entity.RecurringAuction) AuctionInputDTO {
+	template := recurringAuction.Template
+	grading := toConditionGradingDTO(template.Grading)
+
+	auctionInput := AuctionInputDTO{
+		SellerId:         recurringAuction.SellerId,
+		ProductId:        template.ProductId,
+		ProductName:      template.ProductName,
+		Category:         template.Category,
+		Description:      template.Description,
+		Grading:          &grading,
+		Attributes:       template.Attributes,
+		BidderVisibility: string(template.BidderVisibility),
+
+		RecurringAuctionId: recurringAuction.Id,
+	}
+
+	if template.Terms != nil {
+		auctionInput.Terms = &TermsInputDTO{
+			ReturnsPolicy:       template.Terms.ReturnsPolicy,
+			PaymentDeadlineDays: template.Terms.PaymentDeadlineDays,
+		}
+	}
+
+	return auctionInput
+}
+
+func toRecurringAuctionOutputDTO(recurringAuction *auction_entity.RecurringAuction) RecurringAuctionOutputDTO {
+	template := recurringAuction.Template
+	output := RecurringAuctionOutputDTO{
+		Id:       recurringAuction.Id,
+		SellerId: recurringAuction.SellerId,
+		Schedule: recurringAuction.Schedule,
+		Timezone: recurringAuction.Timezone,
+		Auction: AuctionTemplateOutputDTO{
+			ProductId:        template.ProductId,
+			ProductName:      template.ProductName,
+			Category:         template.Category,
+			Description:      template.Description,
+			Grading:          toConditionGradingDTO(template.Grading),
+			Attributes:       template.Attributes,
+			BidderVisibility: string(template.BidderVisibility),
+		},
+		Active:    recurringAuction.Active,
+		CreatedAt: recurringAuction.CreatedAt,
+	}
+
+	if template.Terms != nil {
+		output.Auction.Terms = &TermsOutputDTO{
+			ReturnsPolicy:       template.Terms.ReturnsPolicy,
+			PaymentDeadlineDays: template.Terms.PaymentDeadlineDays,
+			Version:             template.Terms.Version,
+		}
+	}
+	if !recurringAuction.NextRunAt.IsZero() {
+		output.NextRunAt = &recurringAuction.NextRunAt
+	}
+	if !recurringAuction.LastRunAt.IsZero() {
+		output.LastRunAt = &recurringAuction.LastRunAt
+	}
+
+	return output
+}
+
+func getRecurringAuctionScanInterval() time.Duration {
+	duration, err := time.ParseDuration(os.Getenv("RECURRING_AUCTION_SCAN_INTERVAL"))
+	if err != nil || duration <= 0 {
+		return time.Minute
+	}
+
+	return duration
+}
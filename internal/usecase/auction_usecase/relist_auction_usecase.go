@@ -0,0 +1,106 @@
+package auction_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+)
+
+type RelistAuctionInputDTO struct {
+	// Duration is how long the new auction runs, AUCTION_INTERVAL when
+	// empty.
+	Duration string `json:"duration"`
+
+	// The prices replace the ones of the original auction when set, zero
+	// removing them.
+	StartingPrice *float64 `json:"starting_price" binding:"omitempty,gte=0"`
+	ReservePrice  *float64 `json:"reserve_price" binding:"omitempty,gte=0"`
+	BuyNowPrice   *float64 `json:"buy_now_price" binding:"omitempty,gte=0"`
+}
+
+// RelistAuction lists the lot of a completed or unsold auction again as a
+// new active auction of the same seller, linked to the original by
+// RelistedFrom. Series, schedule and invitees are left behind.
+func (au *AuctionUseCase) RelistAuction(
+	ctx context.Context,
+	auctionId, sellerId string,
+	relistInput RelistAuctionInputDTO) (*AuctionOutputDTO, *internal_error.InternalError) {
+	original, err := au.auctionRepositoryInterface.FindAuctionById(ctx, auctionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if original.SellerId == "" || original.SellerId != sellerId {
+		return nil, internal_error.NewForbiddenError("Only the seller can relist this auction")
+	}
+
+	if original.Status != auction_entity.Completed && original.Status != auction_entity.ClosedNoSale {
+		return nil, internal_error.NewBadRequestError("Only completed or unsold auctions can be relisted")
+	}
+
+	auction, err := au.newAuction(ctx, relistInputOf(original, relistInput))
+	if err != nil {
+		return nil, err
+	}
+
+	// The product is kept without being looked up again, so the lot is
+	// described as it was even if the product changed since.
+	auction.ProductId = original.ProductId
+	auction.RelistedFrom = original.Id
+
+	if err := au.auctionRepositoryInterface.CreateAuction(ctx, auction); err != nil {
+		return nil, err
+	}
+
+	auctionOutputs := []AuctionOutputDTO{toAuctionOutputDTO(auction)}
+	au.presentSellers(ctx, auctionOutputs)
+
+	return &auctionOutputs[0], nil
+}
+
+// relistInputOf carries the product details and bidding rules of the
+// original auction over to the new one.
+func relistInputOf(original *auction_entity.Auction, relistInput RelistAuctionInputDTO) AuctionInputDTO {
+	grading := toConditionGradingDTO(original.Grading)
+	auctionInput := AuctionInputDTO{
+		SellerId:             original.SellerId,
+		ProductName:          original.ProductName,
+		Category:             original.Category,
+		Description:          original.Description,
+		Condition:            ProductCondition(original.Condition),
+		Grading:              &grading,
+		Attributes:           original.Attributes,
+		Descriptions:         original.Descriptions,
+		BidderVisibility:     string(original.BidderVisibility),
+		Live:                 original.Live,
+		Duration:             relistInput.Duration,
+		StartingPrice:        original.StartingPrice,
+		ReservePrice:         original.ReservePrice,
+		MinIncrement:         original.MinIncrement,
+		Sealed:               original.Sealed,
+		BuyNowPrice:          original.BuyNowPrice,
+		Quantity:             original.Quantity,
+		UniformPrice:         original.UniformPrice,
+		EstimateLow:          original.EstimateLow,
+		EstimateHigh:         original.EstimateHigh,
+		RegistrationRequired: original.RegistrationRequired,
+	}
+
+	if relistInput.StartingPrice != nil {
+		auctionInput.StartingPrice = *relistInput.StartingPrice
+	}
+	if relistInput.ReservePrice != nil {
+		auctionInput.ReservePrice = *relistInput.ReservePrice
+	}
+	if relistInput.BuyNowPrice != nil {
+		auctionInput.BuyNowPrice = *relistInput.BuyNowPrice
+	}
+	if original.Terms != nil {
+		auctionInput.Terms = &TermsInputDTO{
+			ReturnsPolicy:       original.Terms.ReturnsPolicy,
+			PaymentDeadlineDays: original.Terms.PaymentDeadlineDays,
+		}
+	}
+
+	return auctionInput
+}
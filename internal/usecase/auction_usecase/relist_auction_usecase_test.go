@@ -0,0 +1,33 @@
+package auction_usecase
+
+import (
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRelistInputOf(t *testing.T) {
+	original := &auction_entity.Auction{
+		SellerId:     "seller",
+		ProductName:  "Lamp",
+		Category:     "home",
+		Description:  "An old brass lamp",
+		Grading:      auction_entity.ConditionGrading{Grade: auction_entity.Grade("good")},
+		SeriesId:     "series",
+		ReservePrice: 100,
+		BuyNowPrice:  300,
+		Terms:        &auction_entity.Terms{ReturnsPolicy: "none", PaymentDeadlineDays: 7, Version: 3},
+	}
+
+	lowered := 0.0
+	auctionInput := relistInputOf(original, RelistAuctionInputDTO{ReservePrice: &lowered, Duration: "2h"})
+
+	assert.Equal(t, "Lamp", auctionInput.ProductName)
+	assert.Equal(t, "good", auctionInput.Grading.Grade)
+	assert.Equal(t, 0.0, auctionInput.ReservePrice)
+	assert.Equal(t, 300.0, auctionInput.BuyNowPrice)
+	assert.Equal(t, "2h", auctionInput.Duration)
+	assert.Equal(t, 7, auctionInput.Terms.PaymentDeadlineDays)
+	assert.Empty(t, auctionInput.SeriesId)
+}
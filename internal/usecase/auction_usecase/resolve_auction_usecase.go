@@ -0,0 +1,127 @@
+package auction_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+
+	"go.uber.org/zap"
+)
+
+type ResolutionOutcome string
+
+const (
+	Resolved ResolutionOutcome = "resolved"
+	NoBids   ResolutionOutcome = "no_bids"
+	Failed   ResolutionOutcome = "failed"
+	// ReserveNotMet auctions have no winner, their highest bid being below
+	// the reserve price.
+	ReserveNotMet ResolutionOutcome = "reserve_not_met"
+)
+
+type AuctionResolutionDTO struct {
+	AuctionId   string            `json:"auction_id"`
+	Outcome     ResolutionOutcome `json:"outcome"`
+	WinnerBidId string            `json:"winner_bid_id,omitempty"`
+	Error       string            `json:"error,omitempty"`
+	Processed   int               `json:"processed"`
+	Total       int               `json:"total"`
+}
+
+type AuctionResolutionSummaryDTO struct {
+	Total         int `json:"total"`
+	Resolved      int `json:"resolved"`
+	NoBids        int `json:"no_bids"`
+	ReserveNotMet int `json:"reserve_not_met"`
+	Failed        int `json:"failed"`
+}
+
+// ResolveAuctions records the winning bid of every auction with the given
+// status, optionally only those with no winner recorded yet, reporting each
+// auction to progress as it goes. Failures are reported and skipped so one
+// broken auction does not stop the batch.
+func (au *AuctionUseCase) ResolveAuctions(
+	ctx context.Context,
+	status AuctionStatus,
+	missingWinner bool,
+	progress func(AuctionResolutionDTO)) (*AuctionResolutionSummaryDTO, *internal_error.InternalError) {
+	auctionEntities, err := au.auctionRepositoryInterface.FindAuctions(
+		ctx, auction_entity.AuctionFilter{Status: auction_entity.AuctionStatus(status)}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []auction_entity.Auction
+	for _, auction := range auctionEntities {
+		if missingWinner && auction.WinnerBidId != "" {
+			continue
+		}
+		pending = append(pending, auction)
+	}
+
+	summary := &AuctionResolutionSummaryDTO{Total: len(pending)}
+	for i, auction := range pending {
+		if ctx.Err() != nil {
+			return summary, internal_error.NewInternalServerError("Auction resolution interrupted")
+		}
+
+		resolution := au.resolveAuction(ctx, auction)
+		resolution.Processed = i + 1
+		resolution.Total = len(pending)
+
+		switch resolution.Outcome {
+		case Resolved:
+			summary.Resolved++
+		case NoBids:
+			summary.NoBids++
+		case ReserveNotMet:
+			summary.ReserveNotMet++
+		default:
+			summary.Failed++
+		}
+
+		progress(resolution)
+	}
+
+	logger.Info("Auctions resolved",
+		zap.Int("total", summary.Total),
+		zap.Int("resolved", summary.Resolved),
+		zap.Int("no_bids", summary.NoBids),
+		zap.Int("reserve_not_met", summary.ReserveNotMet),
+		zap.Int("failed", summary.Failed))
+
+	return summary, nil
+}
+
+func (au *AuctionUseCase) resolveAuction(ctx context.Context, auction auction_entity.Auction) AuctionResolutionDTO {
+	resolution := AuctionResolutionDTO{AuctionId: auction.Id}
+
+	winningBid, err := au.bidRepositoryInterface.FindWinningBidByAuctionId(ctx, auction.Id)
+	if err != nil {
+		if err.Err == "not_found" {
+			resolution.Outcome = NoBids
+			return resolution
+		}
+
+		resolution.Outcome = Failed
+		resolution.Error = err.Error()
+		return resolution
+	}
+
+	if !auction.ReserveMet(winningBid.Amount) {
+		resolution.Outcome = ReserveNotMet
+		return resolution
+	}
+
+	if err := au.auctionRepositoryInterface.UpdateAuctionWinner(ctx, auction.Id, winningBid.Id); err != nil {
+		resolution.Outcome = Failed
+		resolution.Error = err.Error()
+		return resolution
+	}
+	au.invalidateSnapshot(auction.Id)
+
+	resolution.Outcome = Resolved
+	resolution.WinnerBidId = winningBid.Id
+	return resolution
+}
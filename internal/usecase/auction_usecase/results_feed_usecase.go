@@ -0,0 +1,53 @@
+package auction_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"sort"
+)
+
+// ResultsFeedSize is how many of the latest results the feed carries.
+const ResultsFeedSize = 50
+
+// FindRecentResults returns the most recently completed auctions, of the
+// category or its subcategories when one is given, with their winning amount
+// when they sold.
+func (au *AuctionUseCase) FindRecentResults(
+	ctx context.Context, category string) ([]SoldItemDTO, *internal_error.InternalError) {
+	categories, err := au.categoryTree(ctx, category)
+	if err != nil {
+		return nil, err
+	}
+
+	auctions, err := au.auctionRepositoryInterface.FindAuctions(
+		ctx, auction_entity.AuctionFilter{Status: auction_entity.Completed, Categories: categories}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Completing an auction is its last update.
+	sort.Slice(auctions, func(i, j int) bool {
+		return auctions[i].UpdatedAt.After(auctions[j].UpdatedAt)
+	})
+	if len(auctions) > ResultsFeedSize {
+		auctions = auctions[:ResultsFeedSize]
+	}
+
+	results := make([]SoldItemDTO, 0, len(auctions))
+	for _, auction := range auctions {
+		result := SoldItemDTO{Auction: toAuctionOutputDTO(&auction)}
+
+		winningBid, err := au.bidRepositoryInterface.FindWinningBidByAuctionId(ctx, auction.Id)
+		if err != nil && err.Err != "not_found" {
+			return nil, err
+		}
+		if winningBid != nil {
+			result.FinalPrice = &winningBid.Amount
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,29 @@
+package auction_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/clock"
+	"fullcycle-auction_go/internal/internal_error"
+)
+
+// SearchAuctions finds the auctions listed for the user whose product name
+// or description, default or in the language, matches the text, most
+// relevant first as the storage ranks them.
+func (au *AuctionUseCase) SearchAuctions(
+	ctx context.Context, language, text, userId string) ([]AuctionOutputDTO, *internal_error.InternalError) {
+	auctionEntities, err := au.auctionRepositoryInterface.SearchAuctions(ctx, language, text)
+	if err != nil {
+		return nil, err
+	}
+
+	now := clock.Now()
+	auctionOutputs := []AuctionOutputDTO{}
+	for _, value := range auctionEntities {
+		if value.ListedFor(userId, now) {
+			auctionOutputs = append(auctionOutputs, toAuctionOutputDTO(&value))
+		}
+	}
+	au.presentSellers(ctx, auctionOutputs)
+
+	return auctionOutputs, nil
+}
@@ -0,0 +1,68 @@
+package auction_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"strconv"
+	"time"
+)
+
+// AuctionMetadataDTO describes an auction page for crawlers and link
+// previews, OpenGraph holding the meta tags ready to render.
+type AuctionMetadataDTO struct {
+	Title        string            `json:"title"`
+	Description  string            `json:"description"`
+	Url          string            `json:"url"`
+	Category     string            `json:"category"`
+	Status       AuctionStatus     `json:"status"`
+	EndsAt       time.Time         `json:"ends_at" time_format:"2006-01-02 15:04:05"`
+	CurrentPrice *float64          `json:"current_price,omitempty"`
+	OpenGraph    map[string]string `json:"open_graph"`
+}
+
+// FindAuctionMetadata builds the metadata of the auction page at pageUrl.
+// The current price is the highest bid, left out while bids are sealed and
+// once the auction ended without a sale.
+func (au *AuctionUseCase) FindAuctionMetadata(
+	ctx context.Context, auctionId, pageUrl string) (*AuctionMetadataDTO, *internal_error.InternalError) {
+	auction, err := au.auctionRepositoryInterface.FindAuctionById(ctx, auctionId)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := &AuctionMetadataDTO{
+		Title:       auction.ProductName,
+		Description: auction.Description,
+		Url:         pageUrl,
+		Category:    auction.Category,
+		Status:      AuctionStatus(auction.Status),
+		EndsAt:      auction.EndsAt,
+		OpenGraph: map[string]string{
+			"og:title":             auction.ProductName,
+			"og:description":       auction.Description,
+			"og:type":              "product",
+			"og:url":               pageUrl,
+			"product:category":     auction.Category,
+			"product:availability": "out of stock",
+		},
+	}
+	if auction.AcceptsBids() {
+		metadata.OpenGraph["product:availability"] = "in stock"
+	}
+
+	if auction.BidsHidden() {
+		return metadata, nil
+	}
+
+	winningBid, err := au.bidRepositoryInterface.FindWinningBidByAuctionId(ctx, auction.Id)
+	if err != nil && err.Err != "not_found" {
+		return nil, err
+	}
+	if winningBid != nil && (auction.Status == auction_entity.Active || auction.Status == auction_entity.Completed) {
+		metadata.CurrentPrice = &winningBid.Amount
+		metadata.OpenGraph["product:price:amount"] = strconv.FormatFloat(winningBid.Amount, 'f', 2, 64)
+	}
+
+	return metadata, nil
+}
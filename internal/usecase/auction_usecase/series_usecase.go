@@ -0,0 +1,292 @@
+package auction_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/clock"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+)
+
+type SeriesInputDTO struct {
+	Name        string `json:"name" binding:"required,min=2,max=100"`
+	Description string `json:"description" binding:"max=500"`
+}
+
+// SeriesScheduleInputDTO has the active lots of the series end one after
+// the other, the first at EndsAt and each next one IntervalSeconds later.
+// With an interval the series is cascaded, extending a lot pushes back the
+// lots after it.
+type SeriesScheduleInputDTO struct {
+	EndsAt          time.Time `json:"ends_at" binding:"required"`
+	IntervalSeconds int       `json:"interval_seconds" binding:"min=0"`
+}
+
+type SeriesOutputDTO struct {
+	Id          string    `json:"id"`
+	SellerId    string    `json:"seller_id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	LotCount    int       `json:"lot_count"`
+	CreatedAt   time.Time `json:"created_at"`
+
+	CascadeIntervalSeconds int `json:"cascade_interval_seconds,omitempty"`
+
+	Stats *SeriesStatsDTO `json:"stats,omitempty"`
+}
+
+// SeriesStatsDTO aggregates the lots of a series. A lot is sold once it is
+// completed with a winning bid, GrossSales adding up those bids, while
+// CurrentBids adds up the highest bids of the lots still active.
+type SeriesStatsDTO struct {
+	Lots          int        `json:"lots"`
+	ActiveLots    int        `json:"active_lots"`
+	CompletedLots int        `json:"completed_lots"`
+	SoldLots      int        `json:"sold_lots"`
+	GrossSales    float64    `json:"gross_sales"`
+	CurrentBids   float64    `json:"current_bids"`
+	FirstEndsAt   *time.Time `json:"first_ends_at,omitempty"`
+	LastEndsAt    *time.Time `json:"last_ends_at,omitempty"`
+}
+
+type SeriesLotScheduleDTO struct {
+	AuctionId string    `json:"auction_id"`
+	LotNumber int       `json:"lot_number"`
+	EndsAt    time.Time `json:"ends_at"`
+}
+
+type SeriesUseCaseInterface interface {
+	CreateSeries(
+		ctx context.Context,
+		sellerId string,
+		input SeriesInputDTO) (*SeriesOutputDTO, *internal_error.InternalError)
+
+	// FindSeries lists the series of the seller, or every series when
+	// sellerId is empty.
+	FindSeries(
+		ctx context.Context, sellerId string) ([]SeriesOutputDTO, *internal_error.InternalError)
+
+	// FindSeriesById returns the series along with the stats of its lots.
+	FindSeriesById(
+		ctx context.Context, id string) (*SeriesOutputDTO, *internal_error.InternalError)
+
+	FindSeriesAuctions(
+		ctx context.Context, id string) ([]AuctionOutputDTO, *internal_error.InternalError)
+
+	ScheduleSeries(
+		ctx context.Context,
+		id, sellerId string,
+		input SeriesScheduleInputDTO) ([]SeriesLotScheduleDTO, *internal_error.InternalError)
+}
+
+type SeriesUseCase struct {
+	seriesRepositoryInterface  auction_entity.SeriesRepositoryInterface
+	auctionRepositoryInterface auction_entity.AuctionRepositoryInterface
+	bidRepositoryInterface     bid_entity.BidEntityRepository
+}
+
+func NewSeriesUseCase(
+	seriesRepositoryInterface auction_entity.SeriesRepositoryInterface,
+	auctionRepositoryInterface auction_entity.AuctionRepositoryInterface,
+	bidRepositoryInterface bid_entity.BidEntityRepository) SeriesUseCaseInterface {
+	return &SeriesUseCase{
+		seriesRepositoryInterface:  seriesRepositoryInterface,
+		auctionRepositoryInterface: auctionRepositoryInterface,
+		bidRepositoryInterface:     bidRepositoryInterface,
+	}
+}
+
+func (su *SeriesUseCase) CreateSeries(
+	ctx context.Context,
+	sellerId string,
+	input SeriesInputDTO) (*SeriesOutputDTO, *internal_error.InternalError) {
+	series, err := auction_entity.CreateSeries(sellerId, input.Name, input.Description)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := su.seriesRepositoryInterface.CreateSeries(ctx, series); err != nil {
+		return nil, err
+	}
+
+	output := toSeriesOutputDTO(series)
+	return &output, nil
+}
+
+func (su *SeriesUseCase) FindSeries(
+	ctx context.Context, sellerId string) ([]SeriesOutputDTO, *internal_error.InternalError) {
+	series, err := su.seriesRepositoryInterface.FindSeries(ctx, sellerId)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs := make([]SeriesOutputDTO, 0, len(series))
+	for i := range series {
+		outputs = append(outputs, toSeriesOutputDTO(&series[i]))
+	}
+
+	return outputs, nil
+}
+
+func (su *SeriesUseCase) FindSeriesById(
+	ctx context.Context, id string) (*SeriesOutputDTO, *internal_error.InternalError) {
+	series, err := su.seriesRepositoryInterface.FindSeriesById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	auctions, err := su.auctionRepositoryInterface.FindSeriesAuctions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := su.seriesStats(ctx, auctions)
+	if err != nil {
+		return nil, err
+	}
+
+	output := toSeriesOutputDTO(series)
+	output.Stats = stats
+	return &output, nil
+}
+
+func (su *SeriesUseCase) FindSeriesAuctions(
+	ctx context.Context, id string) ([]AuctionOutputDTO, *internal_error.InternalError) {
+	if _, err := su.seriesRepositoryInterface.FindSeriesById(ctx, id); err != nil {
+		return nil, err
+	}
+
+	auctions, err := su.auctionRepositoryInterface.FindSeriesAuctions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs := make([]AuctionOutputDTO, 0, len(auctions))
+	for i := range auctions {
+		outputs = append(outputs, toAuctionOutputDTO(&auctions[i]))
+	}
+
+	return outputs, nil
+}
+
+// ScheduleSeries staggers the ends of the active lots in lot order. Lots
+// are extended but never shortened, so a lot already ending after its slot
+// keeps its end and the lots after it follow from there.
+func (su *SeriesUseCase) ScheduleSeries(
+	ctx context.Context,
+	id, sellerId string,
+	input SeriesScheduleInputDTO) ([]SeriesLotScheduleDTO, *internal_error.InternalError) {
+	series, err := su.seriesRepositoryInterface.FindSeriesById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if series.SellerId != sellerId {
+		return nil, internal_error.NewForbiddenError("Only the seller can schedule this series")
+	}
+
+	if !input.EndsAt.After(clock.Now()) {
+		return nil, internal_error.NewBadRequestError("The series has to end in the future")
+	}
+
+	auctions, err := su.auctionRepositoryInterface.FindSeriesAuctions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	interval := time.Duration(input.IntervalSeconds) * time.Second
+	if err := su.seriesRepositoryInterface.UpdateSeriesCascade(ctx, id, interval); err != nil {
+		return nil, err
+	}
+
+	endsAt := input.EndsAt
+
+	schedule := []SeriesLotScheduleDTO{}
+	for _, auction := range auctions {
+		if auction.Status != auction_entity.Active {
+			continue
+		}
+
+		if endsAt.After(auction.EndsAt) {
+			if err := su.auctionRepositoryInterface.ExtendAuction(ctx, auction.Id, endsAt); err != nil {
+				return nil, err
+			}
+			auction.EndsAt = endsAt
+		}
+		endsAt = auction.EndsAt
+
+		schedule = append(schedule, SeriesLotScheduleDTO{
+			AuctionId: auction.Id,
+			LotNumber: auction.LotNumber,
+			EndsAt:    auction.EndsAt,
+		})
+		endsAt = endsAt.Add(interval)
+	}
+
+	return schedule, nil
+}
+
+func (su *SeriesUseCase) seriesStats(
+	ctx context.Context, auctions []auction_entity.Auction) (*SeriesStatsDTO, *internal_error.InternalError) {
+	stats := &SeriesStatsDTO{Lots: len(auctions)}
+	if len(auctions) == 0 {
+		return stats, nil
+	}
+
+	auctionIds := make([]string, len(auctions))
+	for i, auction := range auctions {
+		auctionIds[i] = auction.Id
+	}
+
+	winningBids, err := su.bidRepositoryInterface.FindWinningBidsByAuctionIds(ctx, auctionIds)
+	if err != nil {
+		return nil, err
+	}
+
+	firstEndsAt, lastEndsAt := auctions[0].EndsAt, auctions[0].EndsAt
+	for _, auction := range auctions {
+		winningBid, hasBid := winningBids[auction.Id]
+
+		switch auction.Status {
+		case auction_entity.Active:
+			stats.ActiveLots++
+			if hasBid && !auction.Sealed {
+				stats.CurrentBids += winningBid.Amount
+			}
+		case auction_entity.Completed:
+			stats.CompletedLots++
+			if hasBid {
+				stats.SoldLots++
+				stats.GrossSales += winningBid.Amount
+			}
+		case auction_entity.ClosedNoSale:
+			stats.CompletedLots++
+		}
+
+		if auction.EndsAt.Before(firstEndsAt) {
+			firstEndsAt = auction.EndsAt
+		}
+		if auction.EndsAt.After(lastEndsAt) {
+			lastEndsAt = auction.EndsAt
+		}
+	}
+
+	stats.FirstEndsAt = &firstEndsAt
+	stats.LastEndsAt = &lastEndsAt
+
+	return stats, nil
+}
+
+func toSeriesOutputDTO(series *auction_entity.Series) SeriesOutputDTO {
+	return SeriesOutputDTO{
+		Id:          series.Id,
+		SellerId:    series.SellerId,
+		Name:        series.Name,
+		Description: series.Description,
+		LotCount:    series.LotCount,
+		CreatedAt:   series.CreatedAt,
+
+		CascadeIntervalSeconds: int(series.CascadeInterval / time.Second),
+	}
+}
@@ -0,0 +1,55 @@
+package auction_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubBidRepository struct {
+	bid_entity.BidEntityRepository
+	winningBids map[string]bid_entity.Bid
+	hasBids     bool
+}
+
+func (r *stubBidRepository) HasBids(
+	ctx context.Context, auctionId string) (bool, *internal_error.InternalError) {
+	return r.hasBids, nil
+}
+
+func (r *stubBidRepository) FindWinningBidsByAuctionIds(
+	ctx context.Context, auctionIds []string) (map[string]bid_entity.Bid, *internal_error.InternalError) {
+	return r.winningBids, nil
+}
+
+func TestSeriesStats(t *testing.T) {
+	now := time.Date(2026, 4, 1, 10, 0, 0, 0, time.UTC)
+	seriesUseCase := &SeriesUseCase{bidRepositoryInterface: &stubBidRepository{
+		winningBids: map[string]bid_entity.Bid{
+			"sold":   {Amount: 120},
+			"active": {Amount: 40},
+		},
+	}}
+
+	stats, err := seriesUseCase.seriesStats(context.Background(), []auction_entity.Auction{
+		{Id: "sold", Status: auction_entity.Completed, EndsAt: now},
+		{Id: "unsold", Status: auction_entity.Completed, EndsAt: now.Add(2 * time.Minute)},
+		{Id: "active", Status: auction_entity.Active, EndsAt: now.Add(4 * time.Minute)},
+	})
+	require.Nil(t, err)
+
+	assert.Equal(t, 3, stats.Lots)
+	assert.Equal(t, 1, stats.ActiveLots)
+	assert.Equal(t, 2, stats.CompletedLots)
+	assert.Equal(t, 1, stats.SoldLots)
+	assert.Equal(t, 120.0, stats.GrossSales)
+	assert.Equal(t, 40.0, stats.CurrentBids)
+	assert.Equal(t, now, *stats.FirstEndsAt)
+	assert.Equal(t, now.Add(4*time.Minute), *stats.LastEndsAt)
+}
@@ -0,0 +1,94 @@
+package auction_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/clock"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+)
+
+type AuctionStatusBatchInputDTO struct {
+	AuctionIds []string `json:"auction_ids" binding:"required,min=1,max=100,dive,uuid"`
+}
+
+type AuctionStatusDTO struct {
+	AuctionId        string        `json:"auction_id"`
+	Status           AuctionStatus `json:"status"`
+	HighestBid       *float64      `json:"highest_bid,omitempty"`
+	EndsAt           time.Time     `json:"ends_at" time_format:"2006-01-02 15:04:05"`
+	RemainingSeconds int64         `json:"remaining_seconds"`
+}
+
+type AuctionStatusBatchOutputDTO struct {
+	Auctions []AuctionStatusDTO `json:"auctions"`
+	NotFound []string           `json:"not_found"`
+}
+
+// FindAuctionStatuses reports the status, highest bid and remaining time of
+// many auctions at once, in the order asked, for bidders watching them.
+// Bids still waiting in the batch buffer are not counted yet.
+func (au *AuctionUseCase) FindAuctionStatuses(
+	ctx context.Context,
+	input AuctionStatusBatchInputDTO) (*AuctionStatusBatchOutputDTO, *internal_error.InternalError) {
+	auctionIds := unique(input.AuctionIds)
+
+	auctions, err := au.auctionRepositoryInterface.FindAuctionsByIds(ctx, auctionIds)
+	if err != nil {
+		return nil, err
+	}
+
+	winningBids, err := au.bidRepositoryInterface.FindWinningBidsByAuctionIds(ctx, auctionIds)
+	if err != nil {
+		return nil, err
+	}
+
+	auctionsById := make(map[string]auction_entity.Auction, len(auctions))
+	for _, auction := range auctions {
+		auctionsById[auction.Id] = auction
+	}
+
+	output := &AuctionStatusBatchOutputDTO{
+		Auctions: []AuctionStatusDTO{},
+		NotFound: []string{},
+	}
+	now := clock.Now()
+	for _, auctionId := range auctionIds {
+		auction, ok := auctionsById[auctionId]
+		if !ok {
+			output.NotFound = append(output.NotFound, auctionId)
+			continue
+		}
+
+		status := AuctionStatusDTO{
+			AuctionId: auction.Id,
+			Status:    AuctionStatus(auction.Status),
+			EndsAt:    auction.EndsAt,
+		}
+		if bid, ok := winningBids[auctionId]; ok && !auction.BidsHidden() {
+			amount := bid.Amount
+			status.HighestBid = &amount
+		}
+		if auction.Status == auction_entity.Active && auction.EndsAt.After(now) {
+			status.RemainingSeconds = int64(auction.EndsAt.Sub(now) / time.Second)
+		}
+
+		output.Auctions = append(output.Auctions, status)
+	}
+
+	return output, nil
+}
+
+func unique(values []string) []string {
+	seen := make(map[string]bool, len(values))
+
+	var uniqueValues []string
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			uniqueValues = append(uniqueValues, value)
+		}
+	}
+
+	return uniqueValues
+}
@@ -0,0 +1,117 @@
+package auction_usecase
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"fullcycle-auction_go/internal/usecase/user_usecase"
+)
+
+const (
+	DefaultStorefrontPageSize = 20
+	MaxStorefrontPageSize     = 100
+)
+
+type PageDTO[T any] struct {
+	Items    []T `json:"items"`
+	Page     int `json:"page"`
+	PageSize int `json:"page_size"`
+	Total    int `json:"total"`
+}
+
+// SoldItemDTO is a completed auction of the seller, with the winning amount
+// when it closed with bids.
+type SoldItemDTO struct {
+	Auction    AuctionOutputDTO `json:"auction"`
+	FinalPrice *float64         `json:"final_price,omitempty"`
+}
+
+type StorefrontSummaryDTO struct {
+	ActiveAuctions    int `json:"active_auctions"`
+	CompletedAuctions int `json:"completed_auctions"`
+}
+
+type StorefrontOutputDTO struct {
+	Seller         user_usecase.PublicProfileDTO `json:"seller"`
+	Summary        StorefrontSummaryDTO          `json:"summary"`
+	ActiveAuctions PageDTO[AuctionOutputDTO]     `json:"active_auctions"`
+	History        PageDTO[SoldItemDTO]          `json:"history"`
+
+	// EstimateAccuracy tells how the seller's lots sold against their
+	// estimates, missing until one with an estimate ends.
+	EstimateAccuracy *EstimateAccuracyOutputDTO `json:"estimate_accuracy,omitempty"`
+}
+
+// FindSellerStorefront pages the active auctions and the sale history of
+// the seller together, page being 1-based. Sellers nobody knows about, with
+// no profile and no auctions, are not found.
+func (au *AuctionUseCase) FindSellerStorefront(
+	ctx context.Context,
+	sellerId string,
+	page, pageSize int) (*StorefrontOutputDTO, *internal_error.InternalError) {
+	offset := (page - 1) * pageSize
+
+	activeAuctions, activeTotal, err := au.auctionRepositoryInterface.FindSellerAuctions(
+		ctx, sellerId, auction_entity.Active, offset, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	completedAuctions, completedTotal, err := au.auctionRepositoryInterface.FindSellerAuctions(
+		ctx, sellerId, auction_entity.Completed, offset, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	seller, ok := user_usecase.FindPublicProfiles(ctx, au.userRepositoryInterface, []string{sellerId})[sellerId]
+	if !ok {
+		if activeTotal == 0 && completedTotal == 0 {
+			return nil, internal_error.NewNotFoundError(
+				fmt.Sprintf("Seller not found with this id = %s", sellerId))
+		}
+		seller = user_usecase.PublicProfileDTO{Id: sellerId}
+	}
+
+	storefront := &StorefrontOutputDTO{
+		Seller: seller,
+		Summary: StorefrontSummaryDTO{
+			ActiveAuctions:    activeTotal,
+			CompletedAuctions: completedTotal,
+		},
+		ActiveAuctions: PageDTO[AuctionOutputDTO]{
+			Items: make([]AuctionOutputDTO, 0, len(activeAuctions)), Page: page, PageSize: pageSize, Total: activeTotal,
+		},
+		History: PageDTO[SoldItemDTO]{
+			Items: make([]SoldItemDTO, 0, len(completedAuctions)), Page: page, PageSize: pageSize, Total: completedTotal,
+		},
+	}
+
+	estimateAccuracy, err := au.FindEstimateAccuracy(ctx, sellerId, "")
+	if err != nil {
+		return nil, err
+	}
+	if estimateAccuracy.Overall.Lots > 0 {
+		storefront.EstimateAccuracy = estimateAccuracy
+	}
+
+	for _, auction := range activeAuctions {
+		storefront.ActiveAuctions.Items = append(storefront.ActiveAuctions.Items, toAuctionOutputDTO(&auction))
+	}
+
+	for _, auction := range completedAuctions {
+		soldItem := SoldItemDTO{Auction: toAuctionOutputDTO(&auction)}
+
+		winningBid, err := au.bidRepositoryInterface.FindWinningBidByAuctionId(ctx, auction.Id)
+		if err != nil && err.Err != "not_found" {
+			return nil, err
+		}
+		if winningBid != nil {
+			soldItem.FinalPrice = &winningBid.Amount
+		}
+
+		storefront.History.Items = append(storefront.History.Items, soldItem)
+	}
+
+	return storefront, nil
+}
@@ -0,0 +1,88 @@
+package auction_usecase
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/configuration/clock"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"fullcycle-auction_go/internal/usecase/pagination"
+	"time"
+)
+
+const (
+	DefaultEndingSoonWindow = time.Hour
+	MaxEndingSoonWindow     = 7 * 24 * time.Hour
+)
+
+// sortTrending names the order of the trending listing, for its cursors.
+const sortTrending = "trending"
+
+func (au *AuctionUseCase) FindEndingSoonAuctions(
+	ctx context.Context,
+	within time.Duration,
+	fields []string,
+	userId string,
+	page pagination.Params) (*pagination.Page[AuctionOutputDTO], *internal_error.InternalError) {
+	if within == 0 {
+		within = DefaultEndingSoonWindow
+	}
+	if within < 0 || within > MaxEndingSoonWindow {
+		return nil, internal_error.NewBadRequestError(fmt.Sprintf(
+			"The window must be positive and at most %s", MaxEndingSoonWindow))
+	}
+
+	now := clock.Now()
+	return au.FindAuctions(ctx, AuctionFilterDTO{
+		Status:       AuctionStatus(auction_entity.Active),
+		EndingAfter:  now,
+		EndingBefore: now.Add(within),
+	}, fields, SortEndingSoon, userId, page)
+}
+
+func (au *AuctionUseCase) FindTrendingAuctions(
+	ctx context.Context,
+	fields []string,
+	userId string,
+	page pagination.Params) (*pagination.Page[AuctionOutputDTO], *internal_error.InternalError) {
+	velocities := au.bidUseCase.BidVelocities()
+
+	stored := storedFields(fields)
+	if len(stored) > 0 {
+		stored = append(stored, listingFields...)
+	}
+
+	auctionEntities, err := au.auctionRepositoryInterface.FindAuctions(
+		ctx, auction_entity.AuctionFilter{Status: auction_entity.Active}, stored)
+	if err != nil {
+		return nil, err
+	}
+
+	now := clock.Now()
+	var auctionOutputs []AuctionOutputDTO
+	for _, value := range auctionEntities {
+		velocity := velocities[value.Id]
+		if velocity > 0 && value.ListedFor(userId, now) {
+			auctionOutput := toAuctionOutputDTO(&value)
+			auctionOutput.BidVelocity = velocity
+			auctionOutputs = append(auctionOutputs, auctionOutput)
+		}
+	}
+
+	auctionPage, err := pagination.Paginate(auctionOutputs, pagination.Order[AuctionOutputDTO]{
+		Name:       sortTrending,
+		Descending: true,
+		Key: func(auction AuctionOutputDTO) pagination.Key {
+			return pagination.Key{Value: auction.BidVelocity, Id: auction.Id}
+		},
+	}, page)
+	if err != nil {
+		return nil, err
+	}
+
+	if selected(fields, "seller") {
+		au.presentSellers(ctx, auctionPage.Items)
+	}
+
+	return auctionPage, nil
+}
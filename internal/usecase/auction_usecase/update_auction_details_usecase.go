@@ -0,0 +1,85 @@
+package auction_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/clock"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+)
+
+// UpdateAuctionDetailsInputDTO changes the fields set, leaving the others
+// as they are.
+type UpdateAuctionDetailsInputDTO struct {
+	ProductName *string           `json:"product_name" binding:"omitempty,min=1"`
+	Category    *string           `json:"category" binding:"omitempty,min=2"`
+	Description *string           `json:"description" binding:"omitempty,min=10,max=200"`
+	Condition   *ProductCondition `json:"condition"`
+}
+
+// UpdateAuctionDetails lets the seller correct the product details of an
+// auction nobody bid on yet, bids already placed on them standing for what
+// was listed. A new condition replaces the grading with the one it maps to.
+func (au *AuctionUseCase) UpdateAuctionDetails(
+	ctx context.Context,
+	auctionId, sellerId string,
+	detailsInput UpdateAuctionDetailsInputDTO) (*AuctionOutputDTO, *internal_error.InternalError) {
+	auction, err := au.auctionRepositoryInterface.FindAuctionById(ctx, auctionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if auction.SellerId == "" || auction.SellerId != sellerId {
+		return nil, internal_error.NewForbiddenError("Only the seller can edit this auction")
+	}
+
+	if auction.Status.Ended() {
+		return nil, internal_error.NewBadRequestError("Ended auctions cannot be edited")
+	}
+
+	hasBids, err := au.bidRepositoryInterface.HasBids(ctx, auctionId)
+	if err != nil {
+		return nil, err
+	}
+	if hasBids || au.bidUseCase.HasPendingBids(auctionId) {
+		return nil, internal_error.NewBadRequestError("Auctions with bids cannot be edited")
+	}
+
+	if detailsInput.ProductName != nil {
+		auction.ProductName = *detailsInput.ProductName
+	}
+	if detailsInput.Description != nil {
+		auction.Description = *detailsInput.Description
+	}
+	if detailsInput.Category != nil && *detailsInput.Category != auction.Category {
+		if err := au.validateCategory(ctx, *detailsInput.Category); err != nil {
+			return nil, err
+		}
+		if err := au.validateAttributes(ctx, *detailsInput.Category, auction.Attributes); err != nil {
+			return nil, err
+		}
+		auction.Category = *detailsInput.Category
+	}
+	if detailsInput.Condition != nil &&
+		auction_entity.ProductCondition(*detailsInput.Condition) != auction.Condition {
+		auction.Grading = auction_entity.GradingFromCondition(auction_entity.ProductCondition(*detailsInput.Condition))
+		auction.Condition = auction.Grading.Condition()
+	}
+
+	if err := auction.Validate(); err != nil {
+		return nil, err
+	}
+	auction.UpdatedAt = clock.Now()
+
+	if err := au.auctionRepositoryInterface.UpdateAuctionDetails(ctx, auction); err != nil {
+		if err.Err == "not_found" {
+			return nil, internal_error.NewBadRequestError("Ended auctions cannot be edited")
+		}
+		return nil, err
+	}
+	au.invalidateSnapshot(auction.Id)
+
+	auctionOutputs := []AuctionOutputDTO{toAuctionOutputDTO(auction)}
+	au.presentSellers(ctx, auctionOutputs)
+
+	return &auctionOutputs[0], nil
+}
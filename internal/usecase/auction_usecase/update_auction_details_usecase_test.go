@@ -0,0 +1,43 @@
+package auction_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubAuctionRepository struct {
+	auction_entity.AuctionRepositoryInterface
+	auction auction_entity.Auction
+}
+
+func (r *stubAuctionRepository) FindAuctionById(
+	ctx context.Context, id string) (*auction_entity.Auction, *internal_error.InternalError) {
+	auction := r.auction
+	return &auction, nil
+}
+
+func TestUpdateAuctionDetails_RefusesOnceBidOn(t *testing.T) {
+	auctionUseCase := &AuctionUseCase{
+		auctionRepositoryInterface: &stubAuctionRepository{auction: auction_entity.Auction{
+			Id: "auction", SellerId: "seller", Status: auction_entity.Active,
+		}},
+		bidRepositoryInterface: &stubBidRepository{hasBids: true},
+	}
+	name := "Brass lamp"
+
+	_, err := auctionUseCase.UpdateAuctionDetails(context.Background(), "auction", "other",
+		UpdateAuctionDetailsInputDTO{ProductName: &name})
+	require.NotNil(t, err)
+	assert.Equal(t, "forbidden", err.Err)
+
+	_, err = auctionUseCase.UpdateAuctionDetails(context.Background(), "auction", "seller",
+		UpdateAuctionDetailsInputDTO{ProductName: &name})
+	require.NotNil(t, err)
+	assert.Equal(t, "bad_request", err.Err)
+	assert.Equal(t, "Auctions with bids cannot be edited", err.Message)
+}
@@ -0,0 +1,182 @@
+package auctioneer_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/clock"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/event_entity"
+	"fullcycle-auction_go/internal/entity/user_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"fullcycle-auction_go/internal/usecase/bid_usecase"
+	"os"
+	"time"
+)
+
+type Call string
+
+const (
+	GoingOnce  Call = "going_once"
+	GoingTwice Call = "going_twice"
+)
+
+type CallInputDTO struct {
+	Call Call `json:"call" binding:"required,oneof=going_once going_twice"`
+}
+
+type IncrementInputDTO struct {
+	MinIncrement float64 `json:"min_increment" binding:"required,gt=0"`
+}
+
+type AuctioneerUseCaseInterface interface {
+	// OpenBidding starts taking bids on a live lot.
+	OpenBidding(
+		ctx context.Context, auctionId, auctioneerId string) *internal_error.InternalError
+
+	// Call warns the room that the lot is about to be hammered.
+	Call(
+		ctx context.Context, auctionId, auctioneerId string, call Call) *internal_error.InternalError
+
+	// SetIncrement changes the asking increment of the lot, enforced on the
+	// bids placed from then on.
+	SetIncrement(
+		ctx context.Context, auctionId, auctioneerId string, minIncrement float64) *internal_error.InternalError
+
+	// Hammer closes the lot right away, whatever its end time.
+	Hammer(
+		ctx context.Context, auctionId, auctioneerId string) *internal_error.InternalError
+}
+
+type AuctioneerUseCase struct {
+	auctionRepositoryInterface auction_entity.AuctionRepositoryInterface
+	userRepositoryInterface    user_entity.UserRepositoryInterface
+	bidUseCase                 bid_usecase.BidUseCaseInterface
+	publisher                  event_entity.Publisher
+	lotInterval                time.Duration
+}
+
+func NewAuctioneerUseCase(
+	auctionRepositoryInterface auction_entity.AuctionRepositoryInterface,
+	userRepositoryInterface user_entity.UserRepositoryInterface,
+	bidUseCase bid_usecase.BidUseCaseInterface,
+	publisher event_entity.Publisher) AuctioneerUseCaseInterface {
+	return &AuctioneerUseCase{
+		auctionRepositoryInterface: auctionRepositoryInterface,
+		userRepositoryInterface:    userRepositoryInterface,
+		bidUseCase:                 bidUseCase,
+		publisher:                  publisher,
+		lotInterval:                getAuctionInterval(),
+	}
+}
+
+// OpenBidding gives the lot the duration it was created with, from now, as
+// its fallback end, in case the auctioneer never hammers it. Lots stored
+// without an end get a full auction interval.
+func (au *AuctioneerUseCase) OpenBidding(
+	ctx context.Context, auctionId, auctioneerId string) *internal_error.InternalError {
+	auction, err := au.findLiveLot(ctx, auctionId, auctioneerId)
+	if err != nil {
+		return err
+	}
+	if !auction.BiddingOpenedAt.IsZero() {
+		return internal_error.NewBadRequestError("Bidding is already open")
+	}
+
+	duration := auction.EndsAt.Sub(auction.OpensAt())
+	if duration <= 0 {
+		duration = au.lotInterval
+	}
+
+	now := clock.Now()
+	if err := au.auctionRepositoryInterface.OpenAuctionBidding(ctx, auctionId, now, now.Add(duration)); err != nil {
+		return err
+	}
+
+	au.publisher.Publish(ctx, event_entity.NewEvent(event_entity.AuctionBiddingOpened, auctionId, nil))
+	return nil
+}
+
+func (au *AuctioneerUseCase) Call(
+	ctx context.Context, auctionId, auctioneerId string, call Call) *internal_error.InternalError {
+	auction, err := au.findLiveLot(ctx, auctionId, auctioneerId)
+	if err != nil {
+		return err
+	}
+	if !auction.AcceptsBids() {
+		return internal_error.NewBadRequestError("Bidding is not open yet")
+	}
+
+	eventType := event_entity.AuctionGoingOnce
+	if call == GoingTwice {
+		eventType = event_entity.AuctionGoingTwice
+	}
+
+	au.publisher.Publish(ctx, event_entity.NewEvent(eventType, auctionId, nil))
+	return nil
+}
+
+func (au *AuctioneerUseCase) SetIncrement(
+	ctx context.Context, auctionId, auctioneerId string, minIncrement float64) *internal_error.InternalError {
+	if _, err := au.findLiveLot(ctx, auctionId, auctioneerId); err != nil {
+		return err
+	}
+
+	if err := au.auctionRepositoryInterface.UpdateAuctionIncrement(ctx, auctionId, minIncrement); err != nil {
+		return err
+	}
+
+	au.publisher.Publish(ctx, event_entity.NewEvent(event_entity.AuctionIncrementChanged, auctionId,
+		map[string]interface{}{"min_increment": minIncrement}))
+	return nil
+}
+
+// Hammer stores the bids still buffered for the lot before completing it,
+// so every bid placed before the hammer fell counts.
+func (au *AuctioneerUseCase) Hammer(
+	ctx context.Context, auctionId, auctioneerId string) *internal_error.InternalError {
+	auction, err := au.findLiveLot(ctx, auctionId, auctioneerId)
+	if err != nil {
+		return err
+	}
+	if !auction.AcceptsBids() {
+		return internal_error.NewBadRequestError("Bidding is not open yet")
+	}
+
+	au.bidUseCase.FlushAuction(auctionId)
+
+	return au.auctionRepositoryInterface.UpdateAuctionStatus(
+		ctx, auctionId, auction_entity.Active, auction_entity.Completed)
+}
+
+// findLiveLot loads the active live lot, once the user is known to be an
+// auctioneer.
+func (au *AuctioneerUseCase) findLiveLot(
+	ctx context.Context, auctionId, auctioneerId string) (*auction_entity.Auction, *internal_error.InternalError) {
+	user, err := au.userRepositoryInterface.FindUserById(ctx, auctioneerId)
+	if err != nil && err.Err != "not_found" {
+		return nil, err
+	}
+	if user == nil || user.Role != user_entity.RoleAuctioneer {
+		return nil, internal_error.NewForbiddenError("Only auctioneers can run live lots")
+	}
+
+	auction, err := au.auctionRepositoryInterface.FindAuctionById(ctx, auctionId)
+	if err != nil {
+		return nil, err
+	}
+	if !auction.Live {
+		return nil, internal_error.NewBadRequestError("Auction is not a live lot")
+	}
+	if auction.Status != auction_entity.Active {
+		return nil, internal_error.NewBadRequestError("Auction is not active")
+	}
+
+	return auction, nil
+}
+
+func getAuctionInterval() time.Duration {
+	duration, err := time.ParseDuration(os.Getenv("AUCTION_INTERVAL"))
+	if err != nil {
+		return time.Minute
+	}
+	return duration
+}
@@ -0,0 +1,116 @@
+package auctioneer_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/event_entity"
+	"fullcycle-auction_go/internal/entity/user_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"fullcycle-auction_go/internal/usecase/bid_usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type stubUserRepository struct {
+	user_entity.UserRepositoryInterface
+	user *user_entity.User
+}
+
+func (s stubUserRepository) FindUserById(
+	ctx context.Context, userId string) (*user_entity.User, *internal_error.InternalError) {
+	return s.user, nil
+}
+
+type stubAuctionRepository struct {
+	auction_entity.AuctionRepositoryInterface
+	auction *auction_entity.Auction
+	steps   *[]string
+}
+
+func (s stubAuctionRepository) FindAuctionById(
+	ctx context.Context, id string) (*auction_entity.Auction, *internal_error.InternalError) {
+	return s.auction, nil
+}
+
+func (s stubAuctionRepository) UpdateAuctionStatus(
+	ctx context.Context, auctionId string, expected, status auction_entity.AuctionStatus) *internal_error.InternalError {
+	*s.steps = append(*s.steps, "complete")
+	return nil
+}
+
+type stubBidUseCase struct {
+	bid_usecase.BidUseCaseInterface
+	steps *[]string
+}
+
+func (s stubBidUseCase) FlushAuction(auctionId string) {
+	*s.steps = append(*s.steps, "flush")
+}
+
+type stubPublisher struct{}
+
+func (stubPublisher) Publish(ctx context.Context, event event_entity.Event) {}
+
+func newTestUseCase(role user_entity.Role, auction *auction_entity.Auction, steps *[]string) AuctioneerUseCaseInterface {
+	return NewAuctioneerUseCase(
+		stubAuctionRepository{auction: auction, steps: steps},
+		stubUserRepository{user: &user_entity.User{Role: role}},
+		stubBidUseCase{steps: steps},
+		stubPublisher{})
+}
+
+func TestHammer(t *testing.T) {
+	var steps []string
+	auction := &auction_entity.Auction{Live: true, BiddingOpenedAt: time.Now()}
+
+	err := newTestUseCase(user_entity.RoleNone, auction, &steps).Hammer(context.Background(), "lot", "user")
+	assert.Equal(t, "forbidden", err.Err)
+	assert.Empty(t, steps)
+
+	err = newTestUseCase(user_entity.RoleAuctioneer, auction, &steps).Hammer(context.Background(), "lot", "user")
+	assert.Nil(t, err)
+	// Buffered bids are stored before the lot completes.
+	assert.Equal(t, []string{"flush", "complete"}, steps)
+}
+
+type openingAuctionRepository struct {
+	stubAuctionRepository
+	openedAt, endsAt time.Time
+}
+
+func (r *openingAuctionRepository) OpenAuctionBidding(
+	ctx context.Context, auctionId string, openedAt, endsAt time.Time) *internal_error.InternalError {
+	r.openedAt, r.endsAt = openedAt, endsAt
+	return nil
+}
+
+func TestOpenBidding_RunsForTheLotDuration(t *testing.T) {
+	createdAt := time.Now().Add(-time.Hour)
+	for _, tc := range []struct {
+		name     string
+		endsAt   time.Time
+		duration time.Duration
+	}{
+		{"requested duration", createdAt.Add(90 * time.Minute), 90 * time.Minute},
+		{"stored without an end", time.Time{}, 5 * time.Minute},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			repository := &openingAuctionRepository{stubAuctionRepository: stubAuctionRepository{
+				auction: &auction_entity.Auction{
+					Live: true, Status: auction_entity.Active, StartsAt: createdAt, EndsAt: tc.endsAt,
+				},
+			}}
+			auctioneerUseCase := &AuctioneerUseCase{
+				auctionRepositoryInterface: repository,
+				userRepositoryInterface:    stubUserRepository{user: &user_entity.User{Role: user_entity.RoleAuctioneer}},
+				publisher:                  stubPublisher{},
+				lotInterval:                5 * time.Minute,
+			}
+
+			assert.Nil(t, auctioneerUseCase.OpenBidding(context.Background(), "lot", "user"))
+			assert.Equal(t, tc.duration, repository.endsAt.Sub(repository.openedAt))
+		})
+	}
+}
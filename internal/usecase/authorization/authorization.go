@@ -0,0 +1,34 @@
+// Package authorization checks who may manage an auction, beyond the token
+// telling who the user is.
+package authorization
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/user_entity"
+	"fullcycle-auction_go/internal/internal_error"
+)
+
+// CheckSellerOrAdmin returns a forbidden error unless the user is the
+// seller of the auction or an admin, naming the action refused.
+func CheckSellerOrAdmin(
+	ctx context.Context,
+	userRepository user_entity.UserRepositoryInterface,
+	auction *auction_entity.Auction,
+	userId, action string) *internal_error.InternalError {
+	if auction.SellerId != "" && auction.SellerId == userId {
+		return nil
+	}
+
+	user, err := userRepository.FindUserById(ctx, userId)
+	if err != nil && err.Err != "not_found" {
+		return err
+	}
+	if user == nil || user.Role != user_entity.RoleAdmin {
+		return internal_error.NewForbiddenError(
+			fmt.Sprintf("Only the seller or an admin can %s this auction", action))
+	}
+
+	return nil
+}
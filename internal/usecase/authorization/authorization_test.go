@@ -0,0 +1,45 @@
+package authorization
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/user_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type stubUserRepository struct {
+	user_entity.UserRepositoryInterface
+	users map[string]*user_entity.User
+}
+
+func (r stubUserRepository) FindUserById(
+	ctx context.Context, userId string) (*user_entity.User, *internal_error.InternalError) {
+	if user, ok := r.users[userId]; ok {
+		return user, nil
+	}
+	return nil, internal_error.NewNotFoundError("User not found")
+}
+
+func TestCheckSellerOrAdmin(t *testing.T) {
+	users := stubUserRepository{users: map[string]*user_entity.User{
+		"admin":  {Id: "admin", Role: user_entity.RoleAdmin},
+		"bidder": {Id: "bidder"},
+	}}
+	auction := &auction_entity.Auction{Id: "auction", SellerId: "seller"}
+
+	assert.Nil(t, CheckSellerOrAdmin(context.Background(), users, auction, "seller", "cancel"))
+	assert.Nil(t, CheckSellerOrAdmin(context.Background(), users, auction, "admin", "cancel"))
+
+	err := CheckSellerOrAdmin(context.Background(), users, auction, "bidder", "cancel")
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "forbidden", err.Err)
+		assert.Equal(t, "Only the seller or an admin can cancel this auction", err.Message)
+	}
+
+	// An auction without a seller is managed by admins only.
+	err = CheckSellerOrAdmin(context.Background(), users, &auction_entity.Auction{Id: "auction"}, "", "cancel")
+	assert.NotNil(t, err)
+}
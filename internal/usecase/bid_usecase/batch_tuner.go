@@ -0,0 +1,186 @@
+package bid_usecase
+
+import (
+	"math"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ewmaWeight is the weight of the latest observation in the moving averages
+// of the ingest rate and write latency.
+const ewmaWeight = 0.3
+
+var (
+	effectiveBatchSize = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "bid_batch_effective_size",
+		Help: "Number of buffered bids that triggers a flush.",
+	})
+
+	effectiveFlushInterval = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "bid_batch_effective_flush_interval_seconds",
+		Help: "Longest time a bid waits in the buffer before a flush.",
+	})
+
+	observedIngestRate = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "bid_batch_ingest_rate",
+		Help: "Moving average of accepted bids per second.",
+	})
+
+	observedWriteLatency = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "bid_batch_write_latency_seconds",
+		Help: "Moving average of the latency of batch writes.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(effectiveBatchSize, effectiveFlushInterval, observedIngestRate, observedWriteLatency)
+}
+
+// batchTuner adapts the batch size and flush interval to the load, within
+// the configured bounds. Batches hold twice the bids accepted while a write
+// takes, so writes keep up with ingest, and the interval is the time to
+// fill a batch, so bids do not wait longer than needed when the load is low.
+// With equal bounds, as by default, the batching is fixed.
+type batchTuner struct {
+	mutex *sync.Mutex
+
+	minSize, maxSize         int
+	minInterval, maxInterval time.Duration
+
+	size     int
+	interval time.Duration
+
+	rate     float64 // bids per second
+	latency  time.Duration
+	received int
+	since    time.Time
+}
+
+func newBatchTuner(
+	minSize, maxSize int,
+	minInterval, maxInterval time.Duration,
+	now time.Time) *batchTuner {
+	tuner := &batchTuner{
+		mutex:       &sync.Mutex{},
+		minSize:     minSize,
+		maxSize:     maxSize,
+		minInterval: minInterval,
+		maxInterval: maxInterval,
+		size:        maxSize,
+		interval:    maxInterval,
+		since:       now,
+	}
+	tuner.publish()
+
+	return tuner
+}
+
+func (bt *batchTuner) current() (int, time.Duration) {
+	bt.mutex.Lock()
+	defer bt.mutex.Unlock()
+
+	return bt.size, bt.interval
+}
+
+func (bt *batchTuner) observeBid() {
+	bt.mutex.Lock()
+	defer bt.mutex.Unlock()
+
+	bt.received++
+}
+
+// observeFlush folds the bids received since the last flush and the latency
+// of this write into the averages and adapts the batching to them.
+func (bt *batchTuner) observeFlush(latency time.Duration, now time.Time) {
+	bt.mutex.Lock()
+	defer bt.mutex.Unlock()
+
+	if elapsed := now.Sub(bt.since).Seconds(); elapsed > 0 {
+		bt.rate = ewma(bt.rate, float64(bt.received)/elapsed)
+	}
+	bt.latency = time.Duration(ewma(float64(bt.latency), float64(latency)))
+	bt.received = 0
+	bt.since = now
+
+	size := int(math.Ceil(2 * bt.rate * bt.latency.Seconds()))
+	if size < bt.minSize {
+		size = bt.minSize
+	}
+	if size > bt.maxSize {
+		size = bt.maxSize
+	}
+	bt.size = size
+
+	bt.interval = bt.maxInterval
+	if bt.rate > 0 {
+		interval := time.Duration(float64(size) / bt.rate * float64(time.Second))
+		if interval < bt.interval {
+			bt.interval = interval
+		}
+		if bt.interval < bt.minInterval {
+			bt.interval = bt.minInterval
+		}
+	}
+
+	bt.publish()
+}
+
+type batchTunerStats struct {
+	size       int
+	interval   time.Duration
+	ingestRate float64
+	latency    time.Duration
+}
+
+func (bt *batchTuner) stats() batchTunerStats {
+	bt.mutex.Lock()
+	defer bt.mutex.Unlock()
+
+	return batchTunerStats{
+		size:       bt.size,
+		interval:   bt.interval,
+		ingestRate: bt.rate,
+		latency:    bt.latency,
+	}
+}
+
+func (bt *batchTuner) publish() {
+	effectiveBatchSize.Set(float64(bt.size))
+	effectiveFlushInterval.Set(bt.interval.Seconds())
+	observedIngestRate.Set(bt.rate)
+	observedWriteLatency.Set(bt.latency.Seconds())
+}
+
+func ewma(average, observation float64) float64 {
+	if average == 0 {
+		return observation
+	}
+
+	return ewmaWeight*observation + (1-ewmaWeight)*average
+}
+
+// getMinBatchSize reads BATCH_SIZE_MIN, the smallest batch the tuner may
+// choose, defaulting to MAX_BATCH_SIZE.
+func getMinBatchSize(maxBatchSize int) int {
+	value, err := strconv.Atoi(os.Getenv("BATCH_SIZE_MIN"))
+	if err != nil || value < 1 || value > maxBatchSize {
+		return maxBatchSize
+	}
+
+	return value
+}
+
+// getMinBatchInsertInterval reads BATCH_INSERT_INTERVAL_MIN, the shortest
+// flush interval the tuner may choose, defaulting to BATCH_INSERT_INTERVAL.
+func getMinBatchInsertInterval(maxInterval time.Duration) time.Duration {
+	duration, err := time.ParseDuration(os.Getenv("BATCH_INSERT_INTERVAL_MIN"))
+	if err != nil || duration <= 0 || duration > maxInterval {
+		return maxInterval
+	}
+
+	return duration
+}
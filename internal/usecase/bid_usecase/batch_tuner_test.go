@@ -0,0 +1,46 @@
+package bid_usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchTuner_AdaptsToLoad(t *testing.T) {
+	start := time.Now()
+	tuner := newBatchTuner(1, 100, 100*time.Millisecond, 10*time.Second, start)
+
+	// 200 bids/s with 100ms writes: batches of 40 filled every 200ms.
+	for i := 0; i < 200; i++ {
+		tuner.observeBid()
+	}
+	tuner.observeFlush(100*time.Millisecond, start.Add(time.Second))
+
+	size, interval := tuner.current()
+	assert.Equal(t, 40, size)
+	assert.Equal(t, 200*time.Millisecond, interval)
+
+	// Quiet periods shrink the batch and cap the wait.
+	quiet := newBatchTuner(1, 100, 100*time.Millisecond, 10*time.Second, start)
+	quiet.observeBid()
+	quiet.observeFlush(10*time.Millisecond, start.Add(time.Minute))
+
+	size, interval = quiet.current()
+	assert.Equal(t, 1, size)
+	assert.Equal(t, 10*time.Second, interval)
+}
+
+func TestBatchTuner_FixedWithEqualBounds(t *testing.T) {
+	start := time.Now()
+	tuner := newBatchTuner(5, 5, time.Second, time.Second, start)
+
+	for i := 0; i < 1000; i++ {
+		tuner.observeBid()
+	}
+	tuner.observeFlush(time.Second, start.Add(time.Second))
+
+	size, interval := tuner.current()
+	assert.Equal(t, 5, size)
+	assert.Equal(t, time.Second, interval)
+}
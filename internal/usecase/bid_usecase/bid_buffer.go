@@ -0,0 +1,127 @@
+package bid_usecase
+
+import (
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"sort"
+	"time"
+)
+
+// bidBuffer holds the accepted bids waiting to be flushed, per auction, so
+// the bids of auctions about to end can be flushed ahead of the rest. It is
+// only used from the create routine.
+//
+// The buffer sits on the bid hot path, so it keeps its slices between
+// flushes instead of allocating them per bid or per batch. The batch it
+// returns is reused by the next take and must not be kept past the flush.
+type bidBuffer struct {
+	auctions map[string]*auctionBids
+	size     int
+
+	// spare holds emptied auction buffers for the next auctions to get bids.
+	spare []*auctionBids
+	order auctionOrder
+	batch []bid_entity.Bid
+}
+
+type auctionBids struct {
+	bids []bid_entity.Bid
+	// flushBy is the earliest deadline of the bids, zero when none is known.
+	flushBy time.Time
+}
+
+func newBidBuffer() *bidBuffer {
+	buffer := &bidBuffer{
+		auctions: make(map[string]*auctionBids),
+	}
+	buffer.order.auctions = buffer.auctions
+
+	return buffer
+}
+
+func (bb *bidBuffer) add(accepted acceptedBid) {
+	auction, ok := bb.auctions[accepted.bid.AuctionId]
+	if !ok {
+		if last := len(bb.spare) - 1; last >= 0 {
+			auction = bb.spare[last]
+			bb.spare = bb.spare[:last]
+		} else {
+			auction = &auctionBids{}
+		}
+		bb.auctions[accepted.bid.AuctionId] = auction
+	}
+
+	auction.bids = append(auction.bids, accepted.bid)
+	if !accepted.flushBy.IsZero() && (auction.flushBy.IsZero() || accepted.flushBy.Before(auction.flushBy)) {
+		auction.flushBy = accepted.flushBy
+	}
+	bb.size++
+}
+
+// take removes and returns the bids of the auctions.
+func (bb *bidBuffer) take(auctionIds []string) []bid_entity.Bid {
+	bb.batch = bb.batch[:0]
+	for _, auctionId := range auctionIds {
+		if auction, ok := bb.auctions[auctionId]; ok {
+			bb.batch = append(bb.batch, auction.bids...)
+			bb.size -= len(auction.bids)
+			delete(bb.auctions, auctionId)
+
+			auction.bids = auction.bids[:0]
+			auction.flushBy = time.Time{}
+			bb.spare = append(bb.spare, auction)
+		}
+	}
+
+	return bb.batch
+}
+
+// appendUserBids appends the buffered bids of the user on the auction to
+// bids, leaving them in the buffer.
+func (bb *bidBuffer) appendUserBids(bids []bid_entity.Bid, auctionId, userId string) []bid_entity.Bid {
+	if auction, ok := bb.auctions[auctionId]; ok {
+		for _, bid := range auction.bids {
+			if bid.UserId == userId {
+				bids = append(bids, bid)
+			}
+		}
+	}
+
+	return bids
+}
+
+// takeAll removes and returns every bid, those of the auctions ending first
+// at the front, so they are written first when the batch is split.
+func (bb *bidBuffer) takeAll() []bid_entity.Bid {
+	bb.order.ids = bb.order.ids[:0]
+	for auctionId := range bb.auctions {
+		bb.order.ids = append(bb.order.ids, auctionId)
+	}
+
+	sort.Sort(&bb.order)
+
+	return bb.take(bb.order.ids)
+}
+
+// auctionOrder sorts auction ids by the deadline of their bids, unknown
+// deadlines last. It implements sort.Interface, which unlike sort.Slice does
+// not allocate.
+type auctionOrder struct {
+	ids      []string
+	auctions map[string]*auctionBids
+}
+
+func (ao *auctionOrder) Len() int {
+	return len(ao.ids)
+}
+
+func (ao *auctionOrder) Less(i, j int) bool {
+	first, second := ao.auctions[ao.ids[i]].flushBy, ao.auctions[ao.ids[j]].flushBy
+	if first.IsZero() || second.IsZero() {
+		return !first.IsZero() && second.IsZero()
+	}
+	return first.Before(second)
+}
+
+func (ao *auctionOrder) Swap(i, j int) {
+	ao.ids[i], ao.ids[j] = ao.ids[j], ao.ids[i]
+}
@@ -0,0 +1,44 @@
+package bid_usecase
+
+import (
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBidBuffer_TakeAllOrdersByDeadline(t *testing.T) {
+	now := time.Now()
+	buffer := newBidBuffer()
+	buffer.add(acceptedBid{bid: bid_entity.Bid{Id: "unknown", AuctionId: "a1"}})
+	buffer.add(acceptedBid{bid: bid_entity.Bid{Id: "later", AuctionId: "a2"}, flushBy: now.Add(time.Hour)})
+	buffer.add(acceptedBid{bid: bid_entity.Bid{Id: "soon", AuctionId: "a3"}, flushBy: now.Add(time.Minute)})
+	buffer.add(acceptedBid{bid: bid_entity.Bid{Id: "soon-2", AuctionId: "a3"}, flushBy: now.Add(time.Minute)})
+
+	assert.Equal(t, 4, buffer.size)
+
+	var ids []string
+	for _, bid := range buffer.takeAll() {
+		ids = append(ids, bid.Id)
+	}
+
+	assert.Equal(t, []string{"soon", "soon-2", "later", "unknown"}, ids)
+	assert.Equal(t, 0, buffer.size)
+	assert.Empty(t, buffer.auctions)
+}
+
+func TestBidBuffer_AppendUserBids(t *testing.T) {
+	buffer := newBidBuffer()
+	buffer.add(acceptedBid{bid: bid_entity.Bid{Id: "own", AuctionId: "a1", UserId: "alice"}})
+	buffer.add(acceptedBid{bid: bid_entity.Bid{Id: "other", AuctionId: "a1", UserId: "bob"}})
+	buffer.add(acceptedBid{bid: bid_entity.Bid{Id: "elsewhere", AuctionId: "a2", UserId: "alice"}})
+
+	bids := buffer.appendUserBids(nil, "a1", "alice")
+	assert.Len(t, bids, 1)
+	assert.Equal(t, "own", bids[0].Id)
+	assert.Empty(t, buffer.appendUserBids(nil, "a3", "alice"))
+
+	// The bids stay buffered.
+	assert.Equal(t, 3, buffer.size)
+}
@@ -0,0 +1,60 @@
+package bid_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"sort"
+)
+
+type BidSourceStatsOutputDTO struct {
+	Source        string  `json:"source"`
+	Bids          int     `json:"bids"`
+	Bidders       int     `json:"bidders"`
+	TotalAmount   float64 `json:"total_amount"`
+	AverageAmount float64 `json:"average_amount"`
+}
+
+// FindBidSourceStats aggregates bids per source channel, across every
+// auction when auctionId is empty.
+func (bu *BidUseCase) FindBidSourceStats(
+	ctx context.Context, auctionId string) ([]BidSourceStatsOutputDTO, *internal_error.InternalError) {
+	stats := make(map[string]*BidSourceStatsOutputDTO)
+	bidders := make(map[string]map[string]struct{})
+
+	err := bu.BidRepository.StreamBids(ctx, auctionId, func(bid bid_entity.Bid) error {
+		source := string(bid.Source)
+
+		stat, ok := stats[source]
+		if !ok {
+			stat = &BidSourceStatsOutputDTO{Source: source}
+			stats[source] = stat
+			bidders[source] = make(map[string]struct{})
+		}
+
+		stat.Bids++
+		stat.TotalAmount += bid.Amount
+		bidders[source][bid.UserId] = struct{}{}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	statsOutput := make([]BidSourceStatsOutputDTO, 0, len(stats))
+	for source, stat := range stats {
+		stat.Bidders = len(bidders[source])
+		stat.AverageAmount = stat.TotalAmount / float64(stat.Bids)
+		statsOutput = append(statsOutput, *stat)
+	}
+
+	sort.Slice(statsOutput, func(i, j int) bool {
+		if statsOutput[i].Bids != statsOutput[j].Bids {
+			return statsOutput[i].Bids > statsOutput[j].Bids
+		}
+		return statsOutput[i].Source < statsOutput[j].Source
+	})
+
+	return statsOutput, nil
+}
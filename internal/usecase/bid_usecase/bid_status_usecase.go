@@ -0,0 +1,123 @@
+package bid_usecase
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+)
+
+type BidStatus string
+
+const (
+	// BidPending is a bid accepted and waiting in the batch buffer.
+	BidPending   BidStatus = "pending"
+	BidPersisted BidStatus = "persisted"
+	// BidRejected is a bid rejected after being accepted, as the buffered
+	// bids of a cancelled auction are, and those of an auction that ended
+	// before their batch was written.
+	BidRejected BidStatus = "rejected"
+	// BidRetracted is a stored bid its bidder took back.
+	BidRetracted BidStatus = "retracted"
+)
+
+type BidAcceptedOutputDTO struct {
+	Id        string    `json:"id"`
+	AuctionId string    `json:"auction_id"`
+	Status    BidStatus `json:"status"`
+
+	// EstimatedPersistenceMs is how long the bid is expected to wait in the
+	// buffer before it is written, given the bids buffered with it.
+	EstimatedPersistenceMs int64 `json:"estimated_persistence_ms"`
+}
+
+type BidStatusOutputDTO struct {
+	Id        string    `json:"id"`
+	AuctionId string    `json:"auction_id"`
+	Status    BidStatus `json:"status"`
+	Reason    string    `json:"reason,omitempty"`
+
+	EstimatedPersistenceMs int64 `json:"estimated_persistence_ms,omitempty"`
+}
+
+func (bu *BidUseCase) FindBidStatus(
+	ctx context.Context, auctionId, bidId string) (*BidStatusOutputDTO, *internal_error.InternalError) {
+	output := &BidStatusOutputDTO{Id: bidId, AuctionId: auctionId}
+
+	bu.pendingBidsMutex.Lock()
+	pendingAuctionId, pending := bu.pendingBidIds[bidId]
+	bu.pendingBidsMutex.Unlock()
+
+	if pending && pendingAuctionId == auctionId {
+		output.Status = BidPending
+		output.EstimatedPersistenceMs = bu.estimatePersistence(false).Milliseconds()
+		return output, nil
+	}
+
+	bid, err := bu.BidRepository.FindBidById(ctx, bidId)
+	if err != nil && err.Err != "not_found" {
+		return nil, err
+	}
+	if bid != nil && bid.AuctionId == auctionId {
+		output.Status = BidPersisted
+		if bid.Retracted() {
+			output.Status = BidRetracted
+		}
+		return output, nil
+	}
+
+	rejectedBids, err := bu.RejectedBidRepository.FindRejectedBids(ctx, auctionId)
+	if err != nil {
+		return nil, err
+	}
+	for _, rejectedBid := range rejectedBids {
+		if rejectedBid.Id == bidId {
+			output.Status = BidRejected
+			output.Reason = string(rejectedBid.Reason)
+			return output, nil
+		}
+	}
+
+	return nil, internal_error.NewNotFoundError(
+		fmt.Sprintf("Bid not found with this id = %s", bidId))
+}
+
+// estimatePersistence estimates the wait of a bid accepted now from the
+// current buffer depth and batching.
+func (bu *BidUseCase) estimatePersistence(urgent bool) time.Duration {
+	bu.pendingBidsMutex.Lock()
+	depth := len(bu.pendingBidIds)
+	bu.pendingBidsMutex.Unlock()
+
+	return persistenceDelay(depth, urgent, bu.tuner.stats())
+}
+
+// persistenceDelay estimates how long a bid waits to be written with depth
+// bids buffered, itself included. Its batch is flushed once filled at the
+// ingest rate or after the flush interval, whichever comes first, and each
+// batch ahead of it takes a write. Urgent bids are written right away.
+func persistenceDelay(depth int, urgent bool, stats batchTunerStats) time.Duration {
+	if urgent || stats.size <= 0 {
+		return stats.latency
+	}
+
+	batches := (depth + stats.size - 1) / stats.size
+	if batches < 1 {
+		batches = 1
+	}
+	delay := time.Duration(batches) * stats.latency
+
+	missing := batches*stats.size - depth
+	if missing <= 0 {
+		return delay
+	}
+
+	wait := stats.interval
+	if stats.ingestRate > 0 {
+		if fill := time.Duration(float64(missing) / stats.ingestRate * float64(time.Second)); fill < wait {
+			wait = fill
+		}
+	}
+
+	return wait + delay
+}
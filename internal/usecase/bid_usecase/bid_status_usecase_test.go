@@ -0,0 +1,29 @@
+package bid_usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPersistenceDelay(t *testing.T) {
+	stats := batchTunerStats{
+		size:       10,
+		interval:   5 * time.Second,
+		ingestRate: 4,
+		latency:    100 * time.Millisecond,
+	}
+
+	// 8 bids missing at 4 bids/s fill the batch in 2s, before the interval.
+	assert.Equal(t, 2100*time.Millisecond, persistenceDelay(2, false, stats))
+
+	// A full batch is written right away, after the batch ahead of it.
+	assert.Equal(t, 200*time.Millisecond, persistenceDelay(20, false, stats))
+
+	// Without bids coming in, the interval flushes the batch.
+	stats.ingestRate = 0
+	assert.Equal(t, 5100*time.Millisecond, persistenceDelay(1, false, stats))
+
+	assert.Equal(t, 100*time.Millisecond, persistenceDelay(15, true, stats))
+}
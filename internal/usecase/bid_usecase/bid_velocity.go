@@ -0,0 +1,99 @@
+package bid_usecase
+
+import (
+	"fullcycle-auction_go/configuration/clock"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"sync"
+	"time"
+)
+
+// velocitySlots is how many slots the velocity window is counted in, the
+// oldest slot leaving the window as a new one starts.
+const velocitySlots = 15
+
+// bidVelocity counts the bids written per auction over a sliding window,
+// updated by the batch writes and read by the trending listing. Counts are
+// kept per instance, covering the bids this instance wrote.
+type bidVelocity struct {
+	mutex  *sync.Mutex
+	window time.Duration
+	slot   time.Duration
+	counts map[string]*velocityCounts
+}
+
+type velocityCounts struct {
+	slots [velocitySlots]int
+	// last is the number of the newest slot counted, from the Unix epoch.
+	last int64
+}
+
+func newBidVelocity(window time.Duration) *bidVelocity {
+	return &bidVelocity{
+		mutex:  &sync.Mutex{},
+		window: window,
+		slot:   window / velocitySlots,
+		counts: make(map[string]*velocityCounts),
+	}
+}
+
+// add counts the bids written, at the time they were placed.
+func (bv *bidVelocity) add(bids []bid_entity.Bid) {
+	bv.mutex.Lock()
+	defer bv.mutex.Unlock()
+
+	for i := range bids {
+		counts, ok := bv.counts[bids[i].AuctionId]
+		if !ok {
+			counts = &velocityCounts{}
+			bv.counts[bids[i].AuctionId] = counts
+		}
+
+		slot := bids[i].Timestamp.UnixNano() / int64(bv.slot)
+		counts.advance(slot)
+		if slot > counts.last-velocitySlots {
+			counts.slots[slot%velocitySlots]++
+		}
+	}
+}
+
+// rates returns the bids per minute of each auction with bids in the
+// window ending at now, forgetting the others.
+func (bv *bidVelocity) rates(now time.Time) map[string]float64 {
+	bv.mutex.Lock()
+	defer bv.mutex.Unlock()
+
+	slot := now.UnixNano() / int64(bv.slot)
+	rates := make(map[string]float64, len(bv.counts))
+	for auctionId, counts := range bv.counts {
+		counts.advance(slot)
+
+		total := 0
+		for _, count := range counts.slots {
+			total += count
+		}
+		if total == 0 {
+			delete(bv.counts, auctionId)
+			continue
+		}
+
+		rates[auctionId] = float64(total) / bv.window.Minutes()
+	}
+
+	return rates
+}
+
+// advance moves the newest slot to slot, emptying the slots it reuses.
+func (vc *velocityCounts) advance(slot int64) {
+	if slot <= vc.last {
+		return
+	}
+
+	for s := vc.last + 1; s <= slot && s <= vc.last+velocitySlots; s++ {
+		vc.slots[s%velocitySlots] = 0
+	}
+	vc.last = slot
+}
+
+func (bu *BidUseCase) BidVelocities() map[string]float64 {
+	return bu.velocity.rates(clock.Now())
+}
@@ -0,0 +1,34 @@
+package bid_usecase
+
+import (
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBidVelocity_CountsOverTheWindow(t *testing.T) {
+	start := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	velocity := newBidVelocity(15 * time.Minute)
+
+	bidAt := func(auctionId string, offset time.Duration) bid_entity.Bid {
+		return bid_entity.Bid{AuctionId: auctionId, Timestamp: start.Add(offset)}
+	}
+	velocity.add([]bid_entity.Bid{
+		bidAt("hot", 0), bidAt("hot", time.Minute), bidAt("hot", 2*time.Minute), bidAt("cold", 0),
+	})
+
+	rates := velocity.rates(start.Add(5 * time.Minute))
+	assert.InDelta(t, 0.2, rates["hot"], 1e-9)
+	assert.InDelta(t, 1.0/15, rates["cold"], 1e-9)
+
+	// The bids of the first minute leave the window, then every bid.
+	rates = velocity.rates(start.Add(15*time.Minute + 30*time.Second))
+	assert.InDelta(t, 2.0/15, rates["hot"], 1e-9)
+	assert.NotContains(t, rates, "cold")
+
+	velocity.add([]bid_entity.Bid{bidAt("hot", 20*time.Minute)})
+	rates = velocity.rates(start.Add(time.Hour))
+	assert.Empty(t, rates)
+}
@@ -0,0 +1,102 @@
+package bid_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/clock"
+	"fullcycle-auction_go/internal/entity/user_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"os"
+	"sync"
+	"time"
+)
+
+// maxCachedBidders bounds the memory of the bidder cache. Bidders are not
+// cached while it is full of unexpired ones.
+const maxCachedBidders = 10000
+
+// bidderCache keeps the users placing bids for ttl, so a bidder's bids are
+// validated without a query each. A bidder banned meanwhile has their bids
+// accepted until it expires.
+type bidderCache struct {
+	mutex   *sync.Mutex
+	ttl     time.Duration
+	bidders map[string]cachedBidder
+}
+
+type cachedBidder struct {
+	user      *user_entity.User
+	expiresAt time.Time
+}
+
+func newBidderCache(ttl time.Duration) *bidderCache {
+	return &bidderCache{
+		mutex:   &sync.Mutex{},
+		ttl:     ttl,
+		bidders: make(map[string]cachedBidder),
+	}
+}
+
+func (bc *bidderCache) get(userId string, now time.Time) (*user_entity.User, bool) {
+	bc.mutex.Lock()
+	defer bc.mutex.Unlock()
+
+	bidder, ok := bc.bidders[userId]
+	if !ok || now.After(bidder.expiresAt) {
+		return nil, false
+	}
+
+	return bidder.user, true
+}
+
+func (bc *bidderCache) put(userId string, user *user_entity.User, now time.Time) {
+	bc.mutex.Lock()
+	defer bc.mutex.Unlock()
+
+	if _, ok := bc.bidders[userId]; !ok && len(bc.bidders) >= maxCachedBidders {
+		for cachedId, bidder := range bc.bidders {
+			if now.After(bidder.expiresAt) {
+				delete(bc.bidders, cachedId)
+			}
+		}
+		if len(bc.bidders) >= maxCachedBidders {
+			return
+		}
+	}
+
+	bc.bidders[userId] = cachedBidder{user: user, expiresAt: now.Add(bc.ttl)}
+}
+
+// findBidder looks the bidder up, through the cache when it is enabled. A
+// token outlives its user when the user is deleted, so an unknown bidder
+// is not found rather than rejected.
+func (bu *BidUseCase) findBidder(
+	ctx context.Context, userId string) (*user_entity.User, *internal_error.InternalError) {
+	if bu.bidders != nil {
+		if user, ok := bu.bidders.get(userId, clock.Now()); ok {
+			return user, nil
+		}
+	}
+
+	user, err := bu.UserRepository.FindUserById(ctx, userId)
+	if err != nil {
+		if err.Err == "not_found" {
+			return nil, internal_error.NewNotFoundError("Bidder not found")
+		}
+		return nil, err
+	}
+
+	if bu.bidders != nil {
+		bu.bidders.put(userId, user, clock.Now())
+	}
+	return user, nil
+}
+
+// getBidderCacheTTL reads BIDDER_CACHE_TTL, how long a bidder is validated
+// from memory, zero disabling the cache.
+func getBidderCacheTTL() time.Duration {
+	ttl, err := time.ParseDuration(os.Getenv("BIDDER_CACHE_TTL"))
+	if err != nil || ttl < 0 {
+		return 10 * time.Second
+	}
+	return ttl
+}
@@ -0,0 +1,45 @@
+package bid_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/user_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type countingUserRepository struct {
+	user_entity.UserRepositoryInterface
+	users   map[string]*user_entity.User
+	lookups int
+}
+
+func (r *countingUserRepository) FindUserById(
+	ctx context.Context, userId string) (*user_entity.User, *internal_error.InternalError) {
+	r.lookups++
+	if user, ok := r.users[userId]; ok {
+		return user, nil
+	}
+	return nil, internal_error.NewNotFoundError("user not found")
+}
+
+func TestFindBidder(t *testing.T) {
+	users := &countingUserRepository{users: map[string]*user_entity.User{"ann": {Id: "ann"}}}
+	bidUseCase := &BidUseCase{UserRepository: users, bidders: newBidderCache(time.Minute)}
+
+	for i := 0; i < 3; i++ {
+		user, err := bidUseCase.findBidder(context.Background(), "ann")
+		assert.Nil(t, err)
+		assert.Equal(t, "ann", user.Id)
+	}
+	assert.Equal(t, 1, users.lookups, "known bidders are cached")
+
+	// Unknown bidders are not cached, as they may still register.
+	for i := 0; i < 2; i++ {
+		_, err := bidUseCase.findBidder(context.Background(), "bob")
+		assert.Equal(t, "not_found", err.Err)
+	}
+	assert.Equal(t, 3, users.lookups)
+}
@@ -0,0 +1,38 @@
+package bid_usecase
+
+type BufferStatsOutputDTO struct {
+	PendingBids              int     `json:"pending_bids"`
+	EffectiveBatchSize       int     `json:"effective_batch_size"`
+	EffectiveFlushIntervalMs int64   `json:"effective_flush_interval_ms"`
+	IngestRate               float64 `json:"ingest_rate"`
+	WriteLatencyMs           float64 `json:"write_latency_ms"`
+	MinBatchSize             int     `json:"min_batch_size"`
+	MaxBatchSize             int     `json:"max_batch_size"`
+	MinFlushIntervalMs       int64   `json:"min_flush_interval_ms"`
+	MaxFlushIntervalMs       int64   `json:"max_flush_interval_ms"`
+}
+
+// FindBufferStats reports the bids waiting to be written and the batching
+// the tuner currently applies.
+func (bu *BidUseCase) FindBufferStats() BufferStatsOutputDTO {
+	bu.pendingBidsMutex.Lock()
+	pendingBids := 0
+	for _, pending := range bu.pendingBids {
+		pendingBids += pending.count
+	}
+	bu.pendingBidsMutex.Unlock()
+
+	stats := bu.tuner.stats()
+
+	return BufferStatsOutputDTO{
+		PendingBids:              pendingBids,
+		EffectiveBatchSize:       stats.size,
+		EffectiveFlushIntervalMs: stats.interval.Milliseconds(),
+		IngestRate:               stats.ingestRate,
+		WriteLatencyMs:           float64(stats.latency.Microseconds()) / 1000,
+		MinBatchSize:             bu.tuner.minSize,
+		MaxBatchSize:             bu.tuner.maxSize,
+		MinFlushIntervalMs:       bu.tuner.minInterval.Milliseconds(),
+		MaxFlushIntervalMs:       bu.tuner.maxInterval.Milliseconds(),
+	}
+}
@@ -0,0 +1,82 @@
+package bid_usecase
+
+import (
+	"context"
+)
+
+type BulkBidItemDTO struct {
+	AuctionId    string  `json:"auction_id" binding:"required,uuid"`
+	Amount       float64 `json:"amount" binding:"required,gt=0"`
+	TermsVersion int     `json:"terms_version" binding:"omitempty,min=1"`
+}
+
+type BulkBidInputDTO struct {
+	Source string           `json:"source" binding:"omitempty,oneof=web mobile api_key"`
+	Bids   []BulkBidItemDTO `json:"bids" binding:"required,min=1,max=50,dive"`
+
+	// Filled by the controller from the request, never from the payload.
+	// UserId is the authenticated bidder.
+	UserId            string `json:"-"`
+	ClientIp          string `json:"-"`
+	DeviceFingerprint string `json:"-"`
+}
+
+type BulkBidStatus string
+
+const (
+	BulkBidAccepted BulkBidStatus = "accepted"
+	BulkBidRejected BulkBidStatus = "rejected"
+	// BulkBidFailed is a bid that could not be processed, worth retrying.
+	BulkBidFailed BulkBidStatus = "failed"
+)
+
+type BulkBidResultDTO struct {
+	AuctionId string        `json:"auction_id"`
+	Amount    float64       `json:"amount"`
+	Status    BulkBidStatus `json:"status"`
+	Reason    string        `json:"reason,omitempty"`
+	Message   string        `json:"message,omitempty"`
+}
+
+// CreateBids places each bid on its own, as CreateBid would, so one bid
+// being rejected does not affect the others. Results follow the order of
+// the bids.
+func (bu *BidUseCase) CreateBids(
+	ctx context.Context,
+	bulkBidInputDTO BulkBidInputDTO) []BulkBidResultDTO {
+	results := make([]BulkBidResultDTO, 0, len(bulkBidInputDTO.Bids))
+
+	for _, item := range bulkBidInputDTO.Bids {
+		result := BulkBidResultDTO{
+			AuctionId: item.AuctionId,
+			Amount:    item.Amount,
+			Status:    BulkBidAccepted,
+		}
+
+		_, reason, err := bu.placeBid(ctx, BidInputDTO{
+			UserId:            bulkBidInputDTO.UserId,
+			AuctionId:         item.AuctionId,
+			Amount:            item.Amount,
+			Source:            bulkBidInputDTO.Source,
+			TermsVersion:      item.TermsVersion,
+			ClientIp:          bulkBidInputDTO.ClientIp,
+			DeviceFingerprint: bulkBidInputDTO.DeviceFingerprint,
+		})
+		switch {
+		case err != nil && err.Err == "internal_server_error":
+			result.Status = BulkBidFailed
+			result.Message = err.Message
+		case err != nil:
+			result.Status = BulkBidRejected
+			result.Message = err.Message
+		case reason != "":
+			result.Status = BulkBidRejected
+			result.Reason = string(reason)
+			result.Message = reason.Message()
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
@@ -2,18 +2,50 @@ package bid_usecase
 
 import (
 	"context"
+	"fullcycle-auction_go/configuration/clock"
 	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
 	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/entity/user_entity"
 	"fullcycle-auction_go/internal/internal_error"
+	"fullcycle-auction_go/internal/usecase/lifecycle_usecase"
+	"fullcycle-auction_go/internal/usecase/pagination"
+	"fullcycle-auction_go/internal/usecase/user_usecase"
 	"os"
+	"runtime/pprof"
+	"sort"
 	"strconv"
+	"sync"
 	"time"
 )
 
+// incrementTolerance absorbs the float error of amounts in cents when
+// comparing a raise with the minimum increment.
+const incrementTolerance = 1e-9
+
 type BidInputDTO struct {
-	UserId    string  `json:"user_id"`
 	AuctionId string  `json:"auction_id"`
 	Amount    float64 `json:"amount"`
+	Source    string  `json:"source" binding:"omitempty,oneof=web mobile api_key"`
+
+	// TermsVersion is the version of the auction terms the bidder accepts,
+	// required on the first bid of auctions with terms.
+	TermsVersion int `json:"terms_version" binding:"omitempty,min=1"`
+
+	// ClientTimestamp is when the client placed the bid, optional. It only
+	// guards against skewed clocks and replays, the bids are ordered by
+	// the time the server accepted them at.
+	ClientTimestamp *time.Time `json:"client_timestamp,omitempty"`
+
+	// Filled by the controller from the request, never from the payload.
+	// UserId is the authenticated bidder.
+	UserId            string `json:"-"`
+	ClientIp          string `json:"-"`
+	DeviceFingerprint string `json:"-"`
+	// PlacedBy is the staff user placing the bid on behalf of UserId.
+	PlacedBy string `json:"-"`
+	// Sync waits for the batch the bid is written in.
+	Sync bool `json:"-"`
 }
 
 type BidOutputDTO struct {
@@ -21,30 +53,136 @@ type BidOutputDTO struct {
 	UserId    string    `json:"user_id"`
 	AuctionId string    `json:"auction_id"`
 	Amount    float64   `json:"amount"`
+	Source    string    `json:"source"`
 	Timestamp time.Time `json:"timestamp" time_format:"2006-01-02 15:04:05"`
+	PlacedBy  string    `json:"placed_by,omitempty"`
+
+	// Pending bids are accepted but still buffered, only listed for their
+	// bidder.
+	Pending bool `json:"pending,omitempty"`
+
+	// Sealed bids have their amount hidden until the auction ends.
+	Sealed bool `json:"sealed,omitempty"`
+
+	Bidder *user_usecase.PublicProfileDTO `json:"bidder,omitempty"`
 }
 
 type BidUseCase struct {
-	BidRepository bid_entity.BidEntityRepository
+	BidRepository          bid_entity.BidEntityRepository
+	AuctionRepository      auction_entity.AuctionRepositoryInterface
+	UserRepository         user_entity.UserRepositoryInterface
+	RejectedBidRepository  bid_entity.RejectedBidRepositoryInterface
+	TermsRepository        auction_entity.TermsAcceptanceRepositoryInterface
+	RegistrationRepository auction_entity.RegistrationRepositoryInterface
+	lifecycleManager       *lifecycle_usecase.LifecycleManager
+
+	timer         *time.Timer
+	tuner         *batchTuner
+	bidChannel    chan acceptedBid
+	buffer        *bidBuffer // Instance-specific batch
+	batchDeadline time.Time  // When the timer flushes the batch
 
-	timer               *time.Timer
-	maxBatchSize        int
-	batchInsertInterval time.Duration
-	bidChannel          chan bid_entity.Bid
-	bidBatch            []bid_entity.Bid // Instance-specific batch
+	// Bids of auctions ending within priorityWindow go through
+	// priorityChannel and are flushed right away.
+	priorityChannel  chan acceptedBid
+	priorityWindow   time.Duration
+	urgentAuctionIds []string // Reused by flushUrgent
+
+	flushRequests   chan flushRequest
+	pendingRequests chan pendingRequest
+
+	// pendingBids tracks, per auction, bids accepted but not persisted yet,
+	// pendingBidIds maps each of them to its auction, and highestBids the
+	// highest bid accepted, stored or not, so bids are checked without a
+	// query each. boughtAuctions holds the auctions a
+	// bid bought outright, which take no bids after it even before they
+	// are completed. clientTimestamps holds, per auction and bidder, the
+	// client timestamp of their last accepted bid, and lastAcceptedAt the
+	// acceptance time of the last bid. unitBids holds, per auction selling
+	// several units and bidder, their highest bid. All are guarded by
+	// pendingBidsMutex.
+	pendingBids      map[string]pendingBids
+	pendingBidIds    map[string]string
+	highestBids      map[string]float64
+	unitBids         map[string]map[string]float64
+	boughtAuctions   map[string]bool
+	clientTimestamps map[string]map[string]time.Time
+	lastAcceptedAt   time.Time
+	pendingBidsMutex *sync.Mutex
+
+	// maxClockSkew is how far a client timestamp may be from the server
+	// time, any distance being allowed when zero.
+	maxClockSkew time.Duration
+
+	// velocity counts the bids written per auction, for the trending
+	// listing.
+	velocity *bidVelocity
+
+	// retractionWindow is how long after placing a bid its bidder may
+	// retract it, retractions being disabled when zero.
+	retractionWindow time.Duration
+
+	// bidders caches the bidders looked up, nil when disabled.
+	bidders *bidderCache
+
+	// flushWatchers holds, for each bid placed synchronously and still
+	// buffered, the channel closed once it leaves the buffer. It is guarded
+	// by pendingBidsMutex.
+	flushWatchers map[string]chan struct{}
+	syncTimeout   time.Duration
 }
 
-func NewBidUseCase(bidRepository bid_entity.BidEntityRepository) BidUseCaseInterface {
+type pendingBids struct {
+	count int
+}
+
+func NewBidUseCase(
+	bidRepository bid_entity.BidEntityRepository,
+	auctionRepository auction_entity.AuctionRepositoryInterface,
+	userRepository user_entity.UserRepositoryInterface,
+	rejectedBidRepository bid_entity.RejectedBidRepositoryInterface,
+	termsRepository auction_entity.TermsAcceptanceRepositoryInterface,
+	registrationRepository auction_entity.RegistrationRepositoryInterface,
+	lifecycleManager *lifecycle_usecase.LifecycleManager) BidUseCaseInterface {
 	maxSizeInterval := getMaxBatchSizeInterval()
 	maxBatchSize := getMaxBatchSize()
+	tuner := newBatchTuner(
+		getMinBatchSize(maxBatchSize), maxBatchSize,
+		getMinBatchInsertInterval(maxSizeInterval), maxSizeInterval,
+		clock.Now())
 
 	bidUseCase := &BidUseCase{
-		BidRepository:       bidRepository,
-		maxBatchSize:        maxBatchSize,
-		batchInsertInterval: maxSizeInterval,
-		timer:               time.NewTimer(maxSizeInterval),
-		bidChannel:          make(chan bid_entity.Bid, maxBatchSize),
-		bidBatch:            make([]bid_entity.Bid, 0),
+		BidRepository:          bidRepository,
+		AuctionRepository:      auctionRepository,
+		UserRepository:         userRepository,
+		RejectedBidRepository:  rejectedBidRepository,
+		TermsRepository:        termsRepository,
+		RegistrationRepository: registrationRepository,
+		lifecycleManager:       lifecycleManager,
+		tuner:                  tuner,
+		timer:                  time.NewTimer(clock.Scale(maxSizeInterval)),
+		batchDeadline:          clock.Now().Add(maxSizeInterval),
+		bidChannel:             make(chan acceptedBid, maxBatchSize),
+		buffer:                 newBidBuffer(),
+		priorityChannel:        make(chan acceptedBid, maxBatchSize),
+		priorityWindow:         getPriorityWindow(),
+		pendingBids:            make(map[string]pendingBids),
+		pendingBidIds:          make(map[string]string),
+		highestBids:            make(map[string]float64),
+		unitBids:               make(map[string]map[string]float64),
+		boughtAuctions:         make(map[string]bool),
+		clientTimestamps:       make(map[string]map[string]time.Time),
+		pendingBidsMutex:       &sync.Mutex{},
+		maxClockSkew:           getMaxClockSkew(),
+		flushRequests:          make(chan flushRequest),
+		pendingRequests:        make(chan pendingRequest),
+		velocity:               newBidVelocity(getTrendingWindow()),
+		retractionWindow:       getRetractionWindow(),
+		flushWatchers:          make(map[string]chan struct{}),
+		syncTimeout:            getSyncTimeout(),
+	}
+	if ttl := getBidderCacheTTL(); ttl > 0 {
+		bidUseCase.bidders = newBidderCache(ttl)
 	}
 
 	bidUseCase.triggerCreateRoutine(context.Background())
@@ -52,71 +190,705 @@ func NewBidUseCase(bidRepository bid_entity.BidEntityRepository) BidUseCaseInter
 	return bidUseCase
 }
 
+// acceptedBid is a bid waiting in the buffer along with the end of its
+// auction, by which it has to be flushed. flushBy is zero when unknown.
+type acceptedBid struct {
+	bid     bid_entity.Bid
+	flushBy time.Time
+}
+
+// flushRequest asks the create routine to store the buffered bids of an
+// auction right away, closing done once they are. With voided set, the bids
+// are handed back there instead of stored.
+type flushRequest struct {
+	auctionId string
+	voided    *[]bid_entity.Bid
+	done      chan struct{}
+}
+
+// pendingRequest asks the create routine for the buffered bids of a bidder
+// on an auction, appended to bids before done is closed.
+type pendingRequest struct {
+	auctionId string
+	userId    string
+	bids      *[]bid_entity.Bid
+	done      chan struct{}
+}
+
 type BidUseCaseInterface interface {
+	// CreateBid accepts the bid into the batch buffer, which writes it
+	// asynchronously. With Sync set, it waits for the write and tells its
+	// outcome, answering pending if the batch takes too long.
 	CreateBid(
 		ctx context.Context,
-		bidInputDTO BidInputDTO) *internal_error.InternalError
+		bidInputDTO BidInputDTO) (BidAcceptedOutputDTO, *internal_error.InternalError)
+
+	// FindBidStatus tells whether the bid is still buffered, was written or
+	// was rejected after being accepted.
+	FindBidStatus(
+		ctx context.Context, auctionId, bidId string) (*BidStatusOutputDTO, *internal_error.InternalError)
+
+	CreateBids(
+		ctx context.Context,
+		bulkBidInputDTO BulkBidInputDTO) []BulkBidResultDTO
+
+	CreatePhoneBid(
+		ctx context.Context,
+		phoneBidInputDTO PhoneBidInputDTO) *internal_error.InternalError
+
+	// RetractBid takes back a bid of the user placed within the last
+	// BID_RETRACTION_WINDOW, unless a higher bid superseded it.
+	RetractBid(
+		ctx context.Context, bidId, userId string) *internal_error.InternalError
 
 	FindWinningBidByAuctionId(
 		ctx context.Context, auctionId string) (*BidOutputDTO, *internal_error.InternalError)
 
-	FindBidByAuctionId(
+	// FindWinningBids lists the bids winning the units of the auction,
+	// highest first, the winning bid alone for single-unit auctions.
+	FindWinningBids(
 		ctx context.Context, auctionId string) ([]BidOutputDTO, *internal_error.InternalError)
+
+	// FindBidByAuctionId lists the stored bids of the auction, along with
+	// the bids of pendingUserId still buffered when it is not empty.
+	FindBidByAuctionId(
+		ctx context.Context,
+		auctionId, source, sortBy, pendingUserId string,
+		page pagination.Params) (*pagination.Page[BidOutputDTO], *internal_error.InternalError)
+
+	FindWinningStatus(
+		ctx context.Context, userId string) ([]WinningStatusOutputDTO, *internal_error.InternalError)
+
+	FindRejectedBids(
+		ctx context.Context, auctionId, sellerId string) ([]RejectedBidOutputDTO, *internal_error.InternalError)
+
+	FindBidSourceStats(
+		ctx context.Context, auctionId string) ([]BidSourceStatsOutputDTO, *internal_error.InternalError)
+
+	FindSuggestedBids(
+		ctx context.Context, auctionId string) (*SuggestedBidsOutputDTO, *internal_error.InternalError)
+
+	ExportBids(
+		ctx context.Context,
+		auctionId string,
+		handle func(BidOutputDTO) error) *internal_error.InternalError
+
+	HasPendingBids(auctionId string) bool
+
+	// FlushAuction stores the buffered bids of the auction before returning.
+	FlushAuction(auctionId string)
+
+	// VoidAuctionBids drops the buffered bids of a cancelled auction
+	// instead of storing them, recording each as rejected.
+	VoidAuctionBids(ctx context.Context, auctionId string)
+
+	FindBufferStats() BufferStatsOutputDTO
+
+	// BidVelocities returns the bids per minute written for each auction
+	// over the last TRENDING_WINDOW, leaving out auctions without any.
+	BidVelocities() map[string]float64
 }
 
 func (bu *BidUseCase) triggerCreateRoutine(ctx context.Context) {
 	go func() {
 		defer close(bu.bidChannel)
+		pprof.SetGoroutineLabels(pprof.WithLabels(ctx, pprof.Labels("routine", "bid_batcher")))
 
 		for {
+			// Bids of auctions about to end are taken first, so a backlog
+			// of other bids does not hold them up.
+			select {
+			case accepted := <-bu.priorityChannel:
+				bu.flushUrgent(ctx, accepted)
+				continue
+			default:
+			}
+
 			select {
-			case bidEntity, ok := <-bu.bidChannel:
+			case accepted := <-bu.priorityChannel:
+				bu.flushUrgent(ctx, accepted)
+			case accepted, ok := <-bu.bidChannel:
 				if !ok {
-					if len(bu.bidBatch) > 0 {
-						if err := bu.BidRepository.CreateBid(ctx, bu.bidBatch); err != nil {
-							logger.Error("error trying to process bid batch list", err)
-						}
-					}
+					bu.flush(ctx, bu.buffer.takeAll())
 					return
 				}
 
-				bu.bidBatch = append(bu.bidBatch, bidEntity)
+				bu.buffer.add(accepted)
 
-				if len(bu.bidBatch) >= bu.maxBatchSize {
-					if err := bu.BidRepository.CreateBid(ctx, bu.bidBatch); err != nil {
-						logger.Error("error trying to process bid batch list", err)
-					}
+				if size, interval := bu.tuner.current(); bu.buffer.size >= size {
+					bu.flush(ctx, bu.buffer.takeAll())
+					bu.resetTimer(clock.Now().Add(interval))
+					continue
+				}
 
-					bu.bidBatch = nil
-					bu.timer.Reset(bu.batchInsertInterval)
+				// Bids are flushed no later than their auction ends, so
+				// completion finds them stored after the grace period.
+				if !accepted.flushBy.IsZero() && accepted.flushBy.Before(bu.batchDeadline) {
+					bu.resetTimer(accepted.flushBy)
 				}
-			case <-bu.timer.C:
-				if len(bu.bidBatch) > 0 {
-					if err := bu.BidRepository.CreateBid(ctx, bu.bidBatch); err != nil {
-						logger.Error("error trying to process bid batch list", err)
-					}
+			case request := <-bu.flushRequests:
+				bu.drainQueued()
+				bids := bu.buffer.take([]string{request.auctionId})
+				if request.voided != nil {
+					*request.voided = append(*request.voided, bids...)
+					bu.releasePending(bids)
+				} else {
+					bu.flush(ctx, bids)
 				}
-				bu.bidBatch = nil
-				bu.timer.Reset(bu.batchInsertInterval)
+				close(request.done)
+			case request := <-bu.pendingRequests:
+				bu.drainQueued()
+				*request.bids = bu.buffer.appendUserBids(*request.bids, request.auctionId, request.userId)
+				close(request.done)
+			case <-bu.timer.C:
+				bu.flush(ctx, bu.buffer.takeAll())
+				_, interval := bu.tuner.current()
+				bu.batchDeadline = clock.Now().Add(interval)
+				bu.timer.Reset(clock.Scale(interval))
 			}
 		}
 	}()
 }
 
+// flushUrgent flushes the auction of the urgent bid right away, along with
+// the other urgent bids already queued, leaving the rest of the buffer for
+// the next batch.
+func (bu *BidUseCase) flushUrgent(ctx context.Context, accepted acceptedBid) {
+	bu.buffer.add(accepted)
+	bu.urgentAuctionIds = append(bu.urgentAuctionIds[:0], accepted.bid.AuctionId)
+
+	for drained := false; !drained; {
+		select {
+		case accepted := <-bu.priorityChannel:
+			bu.buffer.add(accepted)
+			bu.urgentAuctionIds = append(bu.urgentAuctionIds, accepted.bid.AuctionId)
+		default:
+			drained = true
+		}
+	}
+
+	bu.flush(ctx, bu.buffer.take(bu.urgentAuctionIds))
+}
+
+// drainQueued moves the bids queued on the channels into the buffer, so a
+// flush sees every bid accepted so far.
+func (bu *BidUseCase) drainQueued() {
+	for {
+		select {
+		case accepted := <-bu.priorityChannel:
+			bu.buffer.add(accepted)
+		case accepted, ok := <-bu.bidChannel:
+			if !ok {
+				return
+			}
+			bu.buffer.add(accepted)
+		default:
+			return
+		}
+	}
+}
+
+func (bu *BidUseCase) flush(ctx context.Context, bids []bid_entity.Bid) {
+	if len(bids) == 0 {
+		return
+	}
+
+	start := clock.Now()
+	closed, err := bu.BidRepository.CreateBid(ctx, bids)
+	if err != nil {
+		logger.Error("error trying to process bid batch list", err)
+	} else {
+		bu.velocity.add(bids)
+	}
+	now := clock.Now()
+	bu.tuner.observeFlush(now.Sub(start), now)
+
+	// Bids left out because their auction ended are recorded before they
+	// are released, for their status to tell why.
+	for i := range closed {
+		bu.reject(ctx, &closed[i], bid_entity.RejectionAuctionClosed)
+	}
+	bu.releasePending(bids)
+}
+
+// resetTimer moves the next batch flush to deadline. It must only be called
+// from the create routine.
+func (bu *BidUseCase) resetTimer(deadline time.Time) {
+	if !bu.timer.Stop() {
+		select {
+		case <-bu.timer.C:
+		default:
+		}
+	}
+
+	bu.batchDeadline = deadline
+	bu.timer.Reset(clock.Scale(deadline.Sub(clock.Now())))
+}
+
+// CreateBid returns the accepted bid by value, keeping the hot path within
+// its allocation budget.
 func (bu *BidUseCase) CreateBid(
 	ctx context.Context,
-	bidInputDTO BidInputDTO) *internal_error.InternalError {
+	bidInputDTO BidInputDTO) (BidAcceptedOutputDTO, *internal_error.InternalError) {
+	accepted, reason, err := bu.placeBid(ctx, bidInputDTO)
+	if err != nil {
+		return BidAcceptedOutputDTO{}, err
+	}
+	if reason != "" {
+		return BidAcceptedOutputDTO{}, reason.AsError()
+	}
+	if bidInputDTO.Sync && accepted.Status == BidPending {
+		return bu.acknowledge(ctx, accepted)
+	}
+
+	return accepted, nil
+}
+
+// placeBid validates the bid and hands it to the batch pipeline, returning
+// the reason when it is rejected.
+func (bu *BidUseCase) placeBid(
+	ctx context.Context,
+	bidInputDTO BidInputDTO) (BidAcceptedOutputDTO, bid_entity.RejectionReason, *internal_error.InternalError) {
+	source := bid_entity.BidSource(bidInputDTO.Source)
+	if source == "" {
+		source = bid_entity.SourceWeb
+	}
+
+	bidEntity, err := bid_entity.CreateBid(
+		bidInputDTO.UserId, bidInputDTO.AuctionId, bidInputDTO.Amount, source,
+		bid_entity.ClientInfo{
+			Ip:                bidInputDTO.ClientIp,
+			DeviceFingerprint: bidInputDTO.DeviceFingerprint,
+		})
+	if err != nil {
+		return BidAcceptedOutputDTO{}, "", err
+	}
+	bidEntity.PlacedBy = bidInputDTO.PlacedBy
+
+	auctionEntity, err := bu.AuctionRepository.FindAuctionById(ctx, bidEntity.AuctionId)
+	if err != nil {
+		return BidAcceptedOutputDTO{}, "", err
+	}
+	bidder, err := bu.findBidder(ctx, bidEntity.UserId)
+	if err != nil {
+		return BidAcceptedOutputDTO{}, "", err
+	}
 
-	bidEntity, err := bid_entity.CreateBid(bidInputDTO.UserId, bidInputDTO.AuctionId, bidInputDTO.Amount)
+	reason, err := bu.rejectionReason(ctx, auctionEntity, bidder, bidEntity)
 	if err != nil {
+		return BidAcceptedOutputDTO{}, "", err
+	}
+	if reason == "" {
+		if reason, err = bu.acceptTerms(ctx, auctionEntity, bidEntity.UserId, bidInputDTO.TermsVersion); err != nil {
+			return BidAcceptedOutputDTO{}, "", err
+		}
+	}
+	if reason == "" {
+		if err := bu.seedHighestBid(ctx, bidEntity.AuctionId); err != nil {
+			return BidAcceptedOutputDTO{}, "", err
+		}
+		if err := bu.seedUnitBids(ctx, auctionEntity); err != nil {
+			return BidAcceptedOutputDTO{}, "", err
+		}
+		var clientTimestamp time.Time
+		if bidInputDTO.ClientTimestamp != nil {
+			clientTimestamp = *bidInputDTO.ClientTimestamp
+		}
+		reason = bu.addPending(bidEntity, auctionEntity, clientTimestamp)
+	}
+	if reason != "" {
+		bu.reject(ctx, bidEntity, reason)
+		return BidAcceptedOutputDTO{}, reason, nil
+	}
+
+	flushBy := bu.lifecycleManager.OnBidAccepted(ctx, auctionEntity, *bidEntity)
+	if bidInputDTO.Sync {
+		bu.watchFlush(bidEntity.Id)
+	}
+
+	bu.tuner.observeBid()
+	accepted := acceptedBid{bid: *bidEntity, flushBy: flushBy}
+	urgent := !flushBy.IsZero() && flushBy.Sub(clock.Now()) <= bu.priorityWindow
+	if urgent {
+		bu.priorityChannel <- accepted
+	} else {
+		bu.bidChannel <- accepted
+	}
+
+	output := BidAcceptedOutputDTO{
+		Id:        bidEntity.Id,
+		AuctionId: bidEntity.AuctionId,
+		Status:    BidPending,
+	}
+
+	if auctionEntity.BuysNow(bidEntity.Amount) {
+		output.Status = BidPersisted
+		return output, "", bu.completeBoughtAuction(ctx, auctionEntity.Id, bidEntity.Id)
+	}
+
+	output.EstimatedPersistenceMs = bu.estimatePersistence(urgent).Milliseconds()
+	return output, "", nil
+}
+
+// completeBoughtAuction stores the buffered bids of the auction, the buying
+// bid included, then completes it with that bid as the winner. An auction
+// cancelled or paused meanwhile is left as it is, without a winner.
+func (bu *BidUseCase) completeBoughtAuction(
+	ctx context.Context, auctionId, bidId string) *internal_error.InternalError {
+	bu.FlushAuction(auctionId)
+
+	if err := bu.AuctionRepository.UpdateAuctionStatus(
+		ctx, auctionId, auction_entity.Active, auction_entity.Completed); err != nil {
 		return err
 	}
 
-	bu.bidChannel <- *bidEntity
+	return bu.AuctionRepository.UpdateAuctionWinner(ctx, auctionId, bidId)
+}
+
+// HasPendingBids reports whether bids for the auction are still waiting in
+// the batch buffer.
+func (bu *BidUseCase) HasPendingBids(auctionId string) bool {
+	bu.pendingBidsMutex.Lock()
+	defer bu.pendingBidsMutex.Unlock()
+
+	return bu.pendingBids[auctionId].count > 0
+}
+
+func (bu *BidUseCase) FlushAuction(auctionId string) {
+	if !bu.HasPendingBids(auctionId) {
+		return
+	}
+
+	done := make(chan struct{})
+	bu.flushRequests <- flushRequest{auctionId: auctionId, done: done}
+	<-done
+}
+
+// findPendingUserBids returns the bids of the user on the auction still
+// waiting in the buffer.
+func (bu *BidUseCase) findPendingUserBids(auctionId, userId string) []bid_entity.Bid {
+	if !bu.HasPendingBids(auctionId) {
+		return nil
+	}
+
+	var bids []bid_entity.Bid
+	done := make(chan struct{})
+	bu.pendingRequests <- pendingRequest{auctionId: auctionId, userId: userId, bids: &bids, done: done}
+	<-done
+
+	return bids
+}
+
+func (bu *BidUseCase) VoidAuctionBids(ctx context.Context, auctionId string) {
+	defer bu.forgetHighestBid(auctionId)
+	if !bu.HasPendingBids(auctionId) {
+		return
+	}
+
+	var voided []bid_entity.Bid
+	done := make(chan struct{})
+	bu.flushRequests <- flushRequest{auctionId: auctionId, voided: &voided, done: done}
+	<-done
+
+	for i := range voided {
+		bu.reject(ctx, &voided[i], bid_entity.RejectionAuctionCancelled)
+	}
+}
+
+// rejectionReason tells why the bid cannot be accepted against the stored
+// state, or returns an empty reason. The amount is checked by addPending.
+func (bu *BidUseCase) rejectionReason(
+	ctx context.Context,
+	auctionEntity *auction_entity.Auction,
+	bidder *user_entity.User,
+	bidEntity *bid_entity.Bid) (bid_entity.RejectionReason, *internal_error.InternalError) {
+	if auctionEntity.Status == auction_entity.Scheduled || auctionEntity.Status == auction_entity.Draft {
+		return bid_entity.RejectionNotStarted, nil
+	}
+	if auctionEntity.Status == auction_entity.Cancelled {
+		bu.forgetHighestBid(auctionEntity.Id)
+		return bid_entity.RejectionAuctionCancelled, nil
+	}
+	if auctionEntity.Status == auction_entity.Paused {
+		return bid_entity.RejectionAuctionPaused, nil
+	}
+	if auctionEntity.Status != auction_entity.Active || bidEntity.Timestamp.After(auctionEntity.EndsAt) {
+		bu.forgetHighestBid(auctionEntity.Id)
+		return bid_entity.RejectionAuctionClosed, nil
+	}
+	if !auctionEntity.AcceptsBids() {
+		return bid_entity.RejectionBiddingNotOpen, nil
+	}
+	if auctionEntity.SoldBy(bidEntity.UserId) {
+		return bid_entity.RejectionSellerBid, nil
+	}
+	if bidEntity.Amount < auctionEntity.StartingPrice {
+		return bid_entity.RejectionBelowStartingPrice, nil
+	}
+	if bidder.Banned {
+		return bid_entity.RejectionUserBanned, nil
+	}
+
+	if auctionEntity.RegistrationRequired {
+		registration, err := bu.RegistrationRepository.FindRegistration(ctx, auctionEntity.Id, bidEntity.UserId)
+		if err != nil && err.Err != "not_found" {
+			return "", err
+		}
+		if registration == nil || registration.Status != auction_entity.RegistrationApproved {
+			return bid_entity.RejectionNotRegistered, nil
+		}
+	}
+
+	return "", nil
+}
+
+// seedHighestBid caches the highest stored bid of the auction, querying the
+// repository only until the cache knows a bid of it.
+func (bu *BidUseCase) seedHighestBid(ctx context.Context, auctionId string) *internal_error.InternalError {
+	bu.pendingBidsMutex.Lock()
+	_, ok := bu.highestBids[auctionId]
+	bu.pendingBidsMutex.Unlock()
+	if ok {
+		return nil
+	}
+
+	winningBid, err := bu.BidRepository.FindWinningBidByAuctionId(ctx, auctionId)
+	if err != nil {
+		if err.Err == "not_found" {
+			return nil
+		}
+		return err
+	}
+
+	bu.pendingBidsMutex.Lock()
+	defer bu.pendingBidsMutex.Unlock()
+
+	// A bid accepted meanwhile may already be higher.
+	if highest, ok := bu.highestBids[auctionId]; !ok || winningBid.Amount > highest {
+		bu.highestBids[auctionId] = winningBid.Amount
+	}
+
+	return nil
+}
+
+// seedUnitBids caches the highest stored bid of each bidder of an auction
+// selling several units, querying the repository only until the cache
+// knows the auction.
+func (bu *BidUseCase) seedUnitBids(
+	ctx context.Context, auctionEntity *auction_entity.Auction) *internal_error.InternalError {
+	if auctionEntity.Units() == 1 || auctionEntity.Sealed {
+		return nil
+	}
+
+	bu.pendingBidsMutex.Lock()
+	_, ok := bu.unitBids[auctionEntity.Id]
+	bu.pendingBidsMutex.Unlock()
+	if ok {
+		return nil
+	}
+
+	bids, err := bu.BidRepository.FindBidByAuctionId(ctx, auctionEntity.Id)
+	if err != nil {
+		return err
+	}
+
+	bu.pendingBidsMutex.Lock()
+	defer bu.pendingBidsMutex.Unlock()
+
+	// Bids accepted meanwhile may already be cached.
+	bidders, ok := bu.unitBids[auctionEntity.Id]
+	if !ok {
+		bidders = make(map[string]float64)
+		bu.unitBids[auctionEntity.Id] = bidders
+	}
+	for _, bid := range bids {
+		if bid.Amount > bidders[bid.UserId] {
+			bidders[bid.UserId] = bid.Amount
+		}
+	}
 
 	return nil
 }
 
+// forgetHighestBid drops the cached state of an auction that no longer
+// takes bids.
+func (bu *BidUseCase) forgetHighestBid(auctionId string) {
+	bu.pendingBidsMutex.Lock()
+	defer bu.pendingBidsMutex.Unlock()
+
+	delete(bu.highestBids, auctionId)
+	delete(bu.unitBids, auctionId)
+	delete(bu.boughtAuctions, auctionId)
+	delete(bu.clientTimestamps, auctionId)
+}
+
+// addPending counts the bid as pending unless it does not beat the highest
+// accepted bid by the minimum increment, checking and counting under one
+// lock so two concurrent bids cannot both pass as the highest. On auctions
+// selling several units the bid must beat the lowest winning bid instead,
+// or the bidder's own when they are winning already. Bids on
+// sealed auctions are never checked against each other, as rejecting them
+// would give the highest bid away. Once a bid buys the auction, every bid
+// after it is rejected.
+//
+// The bid is stamped with its acceptance time, moved past the one of the
+// bid accepted before it when the clock did not advance or went back, so
+// the timestamps order the bids as they were accepted.
+func (bu *BidUseCase) addPending(
+	bidEntity *bid_entity.Bid,
+	auctionEntity *auction_entity.Auction,
+	clientTimestamp time.Time) bid_entity.RejectionReason {
+	bu.pendingBidsMutex.Lock()
+	defer bu.pendingBidsMutex.Unlock()
+
+	if bu.boughtAuctions[bidEntity.AuctionId] {
+		return bid_entity.RejectionAuctionClosed
+	}
+
+	acceptedAt := clock.Now()
+	if !acceptedAt.After(bu.lastAcceptedAt) {
+		acceptedAt = bu.lastAcceptedAt.Add(time.Microsecond)
+	}
+
+	lastClientTimestamp := bu.clientTimestamps[bidEntity.AuctionId][bidEntity.UserId]
+	if reason := clientTimestampRejection(
+		clientTimestamp, lastClientTimestamp, acceptedAt, bu.maxClockSkew); reason != "" {
+		return reason
+	}
+
+	highest, ok := bu.highestBids[bidEntity.AuctionId]
+	bidders := bu.unitBids[bidEntity.AuctionId]
+	if bidders != nil {
+		if threshold, ok := unitThreshold(bidders, bidEntity.UserId, auctionEntity.Units()); ok {
+			if reason := amountRejection(bidEntity.Amount, threshold, auctionEntity.MinIncrement); reason != "" {
+				return reason
+			}
+		}
+	} else if ok && !auctionEntity.Sealed {
+		if reason := amountRejection(bidEntity.Amount, highest, auctionEntity.MinIncrement); reason != "" {
+			return reason
+		}
+	}
+
+	pending := bu.pendingBids[bidEntity.AuctionId]
+	pending.count++
+	bu.pendingBids[bidEntity.AuctionId] = pending
+	bu.pendingBidIds[bidEntity.Id] = bidEntity.AuctionId
+	if !ok || bidEntity.Amount > highest {
+		bu.highestBids[bidEntity.AuctionId] = bidEntity.Amount
+	}
+	if bidders != nil && bidEntity.Amount > bidders[bidEntity.UserId] {
+		bidders[bidEntity.UserId] = bidEntity.Amount
+	}
+	if auctionEntity.BuysNow(bidEntity.Amount) {
+		bu.boughtAuctions[bidEntity.AuctionId] = true
+	}
+
+	bidEntity.Timestamp = acceptedAt
+	bu.lastAcceptedAt = acceptedAt
+	if !clientTimestamp.IsZero() {
+		if bu.clientTimestamps[bidEntity.AuctionId] == nil {
+			bu.clientTimestamps[bidEntity.AuctionId] = make(map[string]time.Time)
+		}
+		bu.clientTimestamps[bidEntity.AuctionId][bidEntity.UserId] = clientTimestamp
+	}
+
+	return ""
+}
+
+// clientTimestampRejection tells why a bid with the client timestamp is
+// suspicious, or returns an empty reason, bids without one never being.
+// The timestamp must be within maxSkew of the acceptance time and later
+// than the one of the last bid the bidder placed on the auction, which a
+// replayed request repeats.
+func clientTimestampRejection(
+	clientTimestamp, lastClientTimestamp, acceptedAt time.Time,
+	maxSkew time.Duration) bid_entity.RejectionReason {
+	if clientTimestamp.IsZero() {
+		return ""
+	}
+
+	skew := acceptedAt.Sub(clientTimestamp)
+	if skew < 0 {
+		skew = -skew
+	}
+	if maxSkew > 0 && skew > maxSkew {
+		return bid_entity.RejectionClockSkew
+	}
+	if !lastClientTimestamp.IsZero() && !clientTimestamp.After(lastClientTimestamp) {
+		return bid_entity.RejectionReplayed
+	}
+
+	return ""
+}
+
+// unitThreshold is the bid a bidder must beat on an auction selling units,
+// given the highest bid of each bidder: their own while they hold one of
+// the units, else the lowest winning bid. It reports false while units are
+// left for any bid.
+func unitThreshold(bidders map[string]float64, userId string, units int) (float64, bool) {
+	own, bidding := bidders[userId]
+	if len(bidders) < units {
+		return own, bidding
+	}
+
+	amounts := make([]float64, 0, len(bidders))
+	for _, amount := range bidders {
+		amounts = append(amounts, amount)
+	}
+	sort.Sort(sort.Reverse(sort.Float64Slice(amounts)))
+
+	lowestWinning := amounts[units-1]
+	if bidding && own >= lowestWinning {
+		return own, true
+	}
+	return lowestWinning, true
+}
+
+// amountRejection tells why amount cannot outbid highest with the minimum
+// increment, or returns an empty reason.
+func amountRejection(amount, highest, minIncrement float64) bid_entity.RejectionReason {
+	if amount <= highest {
+		return bid_entity.RejectionTooLow
+	}
+	if amount-highest < minIncrement-incrementTolerance {
+		return bid_entity.RejectionBelowIncrement
+	}
+
+	return ""
+}
+
+// reject records the rejected bid. Failing to record it is only logged, the
+// bidder gets the rejection either way.
+func (bu *BidUseCase) reject(
+	ctx context.Context, bidEntity *bid_entity.Bid, reason bid_entity.RejectionReason) {
+	rejectedBid := &bid_entity.RejectedBid{Bid: *bidEntity, Reason: reason}
+
+	if err := bu.RejectedBidRepository.CreateRejectedBid(ctx, rejectedBid); err != nil {
+		logger.Error("error trying to record rejected bid", err)
+	}
+}
+
+func (bu *BidUseCase) releasePending(bids []bid_entity.Bid) {
+	bu.pendingBidsMutex.Lock()
+	defer bu.pendingBidsMutex.Unlock()
+
+	for _, bid := range bids {
+		delete(bu.pendingBidIds, bid.Id)
+		if flushed, ok := bu.flushWatchers[bid.Id]; ok {
+			close(flushed)
+			delete(bu.flushWatchers, bid.Id)
+		}
+		pending := bu.pendingBids[bid.AuctionId]
+		pending.count--
+		if pending.count <= 0 {
+			delete(bu.pendingBids, bid.AuctionId)
+			continue
+		}
+		bu.pendingBids[bid.AuctionId] = pending
+	}
+}
+
 func getMaxBatchSizeInterval() time.Duration {
 	batchInsertInterval := os.Getenv("BATCH_INSERT_INTERVAL")
 	duration, err := time.ParseDuration(batchInsertInterval)
@@ -127,6 +899,37 @@ func getMaxBatchSizeInterval() time.Duration {
 	return duration
 }
 
+// getPriorityWindow reads BID_PRIORITY_WINDOW, how close to its end an
+// auction has its bids flushed ahead of the batch.
+func getPriorityWindow() time.Duration {
+	duration, err := time.ParseDuration(os.Getenv("BID_PRIORITY_WINDOW"))
+	if err != nil {
+		return time.Minute
+	}
+
+	return duration
+}
+
+// getTrendingWindow reads TRENDING_WINDOW, how far back bids count towards
+// the velocity of their auction.
+func getTrendingWindow() time.Duration {
+	duration, err := time.ParseDuration(os.Getenv("TRENDING_WINDOW"))
+	if err != nil || duration < time.Minute {
+		return 15 * time.Minute
+	}
+
+	return duration
+}
+
+func getMaxClockSkew() time.Duration {
+	duration, err := time.ParseDuration(os.Getenv("BID_MAX_CLOCK_SKEW"))
+	if err != nil || duration < 0 {
+		return 30 * time.Second
+	}
+
+	return duration
+}
+
 func getMaxBatchSize() int {
 	value, err := strconv.Atoi(os.Getenv("MAX_BATCH_SIZE"))
 	if err != nil {
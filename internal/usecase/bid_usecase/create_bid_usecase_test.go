@@ -0,0 +1,279 @@
+package bid_usecase
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/entity/user_entity"
+	"fullcycle-auction_go/internal/infra/database/memory"
+	"fullcycle-auction_go/internal/internal_error"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Performance budget of the bid hot path. Placing a bid allocates only its
+// id and the entity, buffering it allocates nothing, and a single instance
+// accepts at least targetBidsPerSecond bids. Throughput depends on the
+// machine and its load, so it is only checked with BID_THROUGHPUT_BUDGET
+// set, on a machine of its own.
+const (
+	targetBidsPerSecond = 50000
+	maxCreateBidAllocs  = 2
+	maxBidBufferAllocs  = 0
+)
+
+// The stubs answer the lookups of the hot path from memory, so the
+// benchmarks measure the bid use case rather than a database.
+var (
+	errBidNotFound = internal_error.NewNotFoundError("bid not found")
+	bidder         = &user_entity.User{Id: "bidder", Name: "Bidder"}
+)
+
+type stubAuctionRepository struct {
+	auction_entity.AuctionRepositoryInterface
+	auction *auction_entity.Auction
+}
+
+func (r stubAuctionRepository) FindAuctionById(
+	ctx context.Context, id string) (*auction_entity.Auction, *internal_error.InternalError) {
+	return r.auction, nil
+}
+
+type stubUserRepository struct {
+	user_entity.UserRepositoryInterface
+}
+
+func (stubUserRepository) FindUserById(
+	ctx context.Context, userId string) (*user_entity.User, *internal_error.InternalError) {
+	return bidder, nil
+}
+
+type stubBidRepository struct {
+	bid_entity.BidEntityRepository
+}
+
+func (stubBidRepository) CreateBid(
+	ctx context.Context, bidEntities []bid_entity.Bid) ([]bid_entity.Bid, *internal_error.InternalError) {
+	return nil, nil
+}
+
+func (stubBidRepository) FindWinningBidByAuctionId(
+	ctx context.Context, auctionId string) (*bid_entity.Bid, *internal_error.InternalError) {
+	return nil, errBidNotFound
+}
+
+func newBenchmarkBidUseCase() (*BidUseCase, *auction_entity.Auction) {
+	// As main does.
+	uuid.EnableRandPool()
+
+	auction := &auction_entity.Auction{
+		Id:     uuid.NewString(),
+		Status: auction_entity.Active,
+		EndsAt: time.Now().Add(time.Hour),
+	}
+
+	return NewBidUseCase(
+		stubBidRepository{},
+		stubAuctionRepository{auction: auction},
+		stubUserRepository{},
+		nil, nil, nil, nil).(*BidUseCase), auction
+}
+
+func BenchmarkCreateBid(b *testing.B) {
+	bidUseCase, auction := newBenchmarkBidUseCase()
+	input := BidInputDTO{UserId: uuid.NewString(), AuctionId: auction.Id}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// Each bid outbids the previous one, so none is rejected.
+		input.Amount = float64(i + 1)
+		if _, err := bidUseCase.CreateBid(context.Background(), input); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestCreateBid_AllocationBudget(t *testing.T) {
+	if testing.Short() {
+		t.Skip("benchmarks are skipped in short mode")
+	}
+
+	createBid := testing.Benchmark(BenchmarkCreateBid)
+	assert.LessOrEqual(t, createBid.AllocsPerOp(), int64(maxCreateBidAllocs))
+
+	bidBuffer := testing.Benchmark(BenchmarkBidBuffer)
+	assert.LessOrEqual(t, bidBuffer.AllocsPerOp(), int64(maxBidBufferAllocs))
+}
+
+func TestCreateBid_ThroughputBudget(t *testing.T) {
+	if os.Getenv("BID_THROUGHPUT_BUDGET") == "" {
+		t.Skip("set BID_THROUGHPUT_BUDGET to check the throughput budget")
+	}
+
+	createBid := testing.Benchmark(BenchmarkCreateBid)
+	assert.GreaterOrEqual(t, float64(createBid.N)/createBid.T.Seconds(), float64(targetBidsPerSecond))
+}
+
+func BenchmarkBidBuffer(b *testing.B) {
+	buffer := newBidBuffer()
+	auctionIds := make([]string, 16)
+	for i := range auctionIds {
+		auctionIds[i] = fmt.Sprintf("auction-%d", i)
+	}
+	flushBy := time.Now().Add(time.Hour)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buffer.add(acceptedBid{
+			bid:     bid_entity.Bid{AuctionId: auctionIds[i%len(auctionIds)]},
+			flushBy: flushBy,
+		})
+		if buffer.size == 64 {
+			buffer.takeAll()
+		}
+	}
+}
+
+func TestAmountRejection(t *testing.T) {
+	assert.Equal(t, bid_entity.RejectionTooLow, amountRejection(10, 10, 0))
+	assert.Equal(t, bid_entity.RejectionReason(""), amountRejection(10.01, 10, 0))
+	assert.Equal(t, bid_entity.RejectionBelowIncrement, amountRejection(10.5, 10, 1))
+	assert.Equal(t, bid_entity.RejectionReason(""), amountRejection(11, 10, 1))
+	// Amounts in cents that float arithmetic cannot represent exactly.
+	assert.Equal(t, bid_entity.RejectionReason(""), amountRejection(10.3, 10.1, 0.2))
+}
+
+func TestClientTimestampRejection(t *testing.T) {
+	now := time.Now()
+	assert.Equal(t, bid_entity.RejectionReason(""), clientTimestampRejection(time.Time{}, now, now, time.Second))
+	assert.Equal(t, bid_entity.RejectionReason(""), clientTimestampRejection(now.Add(-time.Second), time.Time{}, now, time.Second))
+	assert.Equal(t, bid_entity.RejectionClockSkew, clientTimestampRejection(now.Add(2*time.Second), time.Time{}, now, time.Second))
+	assert.Equal(t, bid_entity.RejectionReason(""), clientTimestampRejection(now.Add(-time.Hour), time.Time{}, now, 0))
+	// The same request sent twice.
+	assert.Equal(t, bid_entity.RejectionReplayed, clientTimestampRejection(now, now, now, time.Second))
+}
+
+func TestAddPending_BuyNow(t *testing.T) {
+	bidUseCase := &BidUseCase{
+		pendingBids:      make(map[string]pendingBids),
+		pendingBidIds:    make(map[string]string),
+		highestBids:      make(map[string]float64),
+		boughtAuctions:   make(map[string]bool),
+		pendingBidsMutex: &sync.Mutex{},
+	}
+	auction := &auction_entity.Auction{Id: "auction", BuyNowPrice: 100}
+	bid := func(amount float64) *bid_entity.Bid {
+		return &bid_entity.Bid{AuctionId: auction.Id, Amount: amount}
+	}
+
+	assert.Equal(t, bid_entity.RejectionReason(""), bidUseCase.addPending(bid(50), auction, time.Time{}))
+	assert.Equal(t, bid_entity.RejectionReason(""), bidUseCase.addPending(bid(100), auction, time.Time{}))
+	// The auction is bought, even a higher bid comes too late.
+	assert.Equal(t, bid_entity.RejectionAuctionClosed, bidUseCase.addPending(bid(150), auction, time.Time{}))
+}
+
+func TestRejectionReason_SellerBid(t *testing.T) {
+	now := time.Now()
+	auction := &auction_entity.Auction{
+		Id: "auction", SellerId: "seller", Status: auction_entity.Active, EndsAt: now.Add(time.Hour)}
+
+	reason, err := (&BidUseCase{}).rejectionReason(context.Background(), auction, bidder,
+		&bid_entity.Bid{UserId: "seller", AuctionId: auction.Id, Amount: 10, Timestamp: now})
+	assert.Nil(t, err)
+	assert.Equal(t, bid_entity.RejectionSellerBid, reason)
+	assert.Equal(t, "seller_bid", reason.AsError().Reason)
+}
+
+func TestUnitThreshold(t *testing.T) {
+	bidders := map[string]float64{"ann": 30, "bob": 20}
+
+	// A unit is left, only bidders raising their own bid are checked.
+	_, ok := unitThreshold(bidders, "cid", 3)
+	assert.False(t, ok)
+	threshold, _ := unitThreshold(bidders, "ann", 3)
+	assert.Equal(t, 30.0, threshold)
+
+	threshold, _ = unitThreshold(bidders, "cid", 2)
+	assert.Equal(t, 20.0, threshold)
+	threshold, _ = unitThreshold(bidders, "bob", 2)
+	assert.Equal(t, 20.0, threshold)
+
+	bidders["cid"] = 25
+	threshold, _ = unitThreshold(bidders, "bob", 2)
+	assert.Equal(t, 25.0, threshold)
+}
+
+// recordingBidRepository keeps the bids written, to be found by id.
+type recordingBidRepository struct {
+	stubBidRepository
+	mutex *sync.Mutex
+	bids  map[string]bid_entity.Bid
+}
+
+func (r recordingBidRepository) CreateBid(
+	ctx context.Context, bidEntities []bid_entity.Bid) ([]bid_entity.Bid, *internal_error.InternalError) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, bid := range bidEntities {
+		r.bids[bid.Id] = bid
+	}
+	return nil, nil
+}
+
+func (r recordingBidRepository) FindBidById(
+	ctx context.Context, bidId string) (*bid_entity.Bid, *internal_error.InternalError) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	bid, ok := r.bids[bidId]
+	if !ok {
+		return nil, errBidNotFound
+	}
+	return &bid, nil
+}
+
+func TestVoidAuctionBids_RejectsBufferedBids(t *testing.T) {
+	// The batch is neither full nor due while the auction is cancelled.
+	t.Setenv("MAX_BATCH_SIZE", "100")
+	t.Setenv("BATCH_INSERT_INTERVAL", "1h")
+
+	auction := &auction_entity.Auction{Id: uuid.NewString(), Status: auction_entity.Active, EndsAt: time.Now().Add(time.Hour)}
+	bidRepository := recordingBidRepository{mutex: &sync.Mutex{}, bids: make(map[string]bid_entity.Bid)}
+	bidUseCase := NewBidUseCase(
+		bidRepository,
+		stubAuctionRepository{auction: auction},
+		stubUserRepository{},
+		memory.NewRejectedBidRepository(),
+		nil, nil, nil).(*BidUseCase)
+
+	accepted, err := bidUseCase.CreateBid(context.Background(),
+		BidInputDTO{UserId: uuid.NewString(), AuctionId: auction.Id, Amount: 10})
+	require.Nil(t, err)
+
+	status, err := bidUseCase.FindBidStatus(context.Background(), auction.Id, accepted.Id)
+	require.Nil(t, err)
+	assert.Equal(t, BidPending, status.Status)
+
+	bidUseCase.VoidAuctionBids(context.Background(), auction.Id)
+	assert.False(t, bidUseCase.HasPendingBids(auction.Id))
+
+	status, err = bidUseCase.FindBidStatus(context.Background(), auction.Id, accepted.Id)
+	require.Nil(t, err)
+	assert.Equal(t, BidRejected, status.Status)
+	assert.Equal(t, string(bid_entity.RejectionAuctionCancelled), status.Reason)
+
+	// Flushing the auction afterwards writes nothing.
+	bidUseCase.FlushAuction(auction.Id)
+	_, err = bidRepository.FindBidById(context.Background(), accepted.Id)
+	assert.Equal(t, errBidNotFound, err)
+}
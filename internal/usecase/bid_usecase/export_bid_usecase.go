@@ -0,0 +1,16 @@
+package bid_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/internal_error"
+)
+
+func (bu *BidUseCase) ExportBids(
+	ctx context.Context,
+	auctionId string,
+	handle func(BidOutputDTO) error) *internal_error.InternalError {
+	return bu.BidRepository.StreamBids(ctx, auctionId, func(bid bid_entity.Bid) error {
+		return handle(toBidOutputDTO(&bid))
+	})
+}
@@ -2,44 +2,199 @@ package bid_usecase
 
 import (
 	"context"
+	"fmt"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
 	"fullcycle-auction_go/internal/internal_error"
+	"fullcycle-auction_go/internal/usecase/pagination"
+	"fullcycle-auction_go/internal/usecase/user_usecase"
 )
 
+// The orders bid listings are sorted in, SortPlaced by default.
+const (
+	SortPlaced     = "placed"
+	SortNewest     = "newest"
+	SortHighestBid = "highest_bid"
+)
+
+// FindBidByAuctionId pages through the auction bids, only those placed
+// through source when it is not empty, in the order sortBy names. Bidders
+// are shown as the auction allows, and sealed bids cannot be sorted by
+// amount before the auction ends. The buffered bids of pendingUserId are
+// merged in, so bidders see their own bids before they are written.
 func (bu *BidUseCase) FindBidByAuctionId(
-	ctx context.Context, auctionId string) ([]BidOutputDTO, *internal_error.InternalError) {
+	ctx context.Context,
+	auctionId, source, sortBy, pendingUserId string,
+	page pagination.Params) (*pagination.Page[BidOutputDTO], *internal_error.InternalError) {
+	auction, err := bu.AuctionRepository.FindAuctionById(ctx, auctionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if sortBy == "" {
+		sortBy = SortPlaced
+	}
+	order := pagination.Order[BidOutputDTO]{Name: sortBy}
+	switch sortBy {
+	case SortPlaced, SortNewest:
+		order.Descending = sortBy == SortNewest
+		order.Key = func(bid BidOutputDTO) pagination.Key {
+			return pagination.Key{Value: float64(bid.Timestamp.UnixMicro()), Id: bid.Id}
+		}
+	case SortHighestBid:
+		if auction.BidsHidden() {
+			return nil, internal_error.NewBadRequestError("Sealed bids cannot be sorted by amount before the auction ends")
+		}
+		order.Descending = true
+		order.Key = func(bid BidOutputDTO) pagination.Key {
+			return pagination.Key{Value: bid.Amount, Id: bid.Id}
+		}
+	default:
+		return nil, internal_error.NewBadRequestError(fmt.Sprintf(
+			"Bids can only be sorted by %s, %s or %s", SortPlaced, SortNewest, SortHighestBid))
+	}
+
+	// The buffer is read before the stored bids, so a bid written in
+	// between shows up twice rather than not at all.
+	var pendingBids []bid_entity.Bid
+	if pendingUserId != "" {
+		pendingBids = bu.findPendingUserBids(auctionId, pendingUserId)
+	}
+
 	bidList, err := bu.BidRepository.FindBidByAuctionId(ctx, auctionId)
 	if err != nil {
 		return nil, err
 	}
 
 	var bidOutputList []BidOutputDTO
+	stored := make(map[string]bool, len(bidList))
 	for _, bid := range bidList {
-		bidOutputList = append(bidOutputList, BidOutputDTO{
-			Id:        bid.Id,
-			UserId:    bid.UserId,
-			AuctionId: bid.AuctionId,
-			Amount:    bid.Amount,
-			Timestamp: bid.Timestamp,
-		})
+		stored[bid.Id] = true
+		if source != "" && string(bid.Source) != source {
+			continue
+		}
+
+		bidOutputList = append(bidOutputList, toBidOutputDTO(&bid))
 	}
 
-	return bidOutputList, nil
+	for _, bid := range pendingBids {
+		if stored[bid.Id] || (source != "" && string(bid.Source) != source) {
+			continue
+		}
+
+		bidOutput := toBidOutputDTO(&bid)
+		bidOutput.Pending = true
+		bidOutputList = append(bidOutputList, bidOutput)
+	}
+
+	bidPage, err := pagination.Paginate(bidOutputList, order, page)
+	if err != nil {
+		return nil, err
+	}
+
+	bu.presentBidders(ctx, auction, bidPage.Items)
+	sealBids(auction, bidPage.Items)
+
+	return bidPage, nil
 }
 
 func (bu *BidUseCase) FindWinningBidByAuctionId(
 	ctx context.Context, auctionId string) (*BidOutputDTO, *internal_error.InternalError) {
+	auction, err := bu.AuctionRepository.FindAuctionById(ctx, auctionId)
+	if err != nil {
+		return nil, err
+	}
+
 	bidEntity, err := bu.BidRepository.FindWinningBidByAuctionId(ctx, auctionId)
 	if err != nil {
 		return nil, err
 	}
 
-	bidOutput := &BidOutputDTO{
-		Id:        bidEntity.Id,
-		UserId:    bidEntity.UserId,
-		AuctionId: bidEntity.AuctionId,
-		Amount:    bidEntity.Amount,
-		Timestamp: bidEntity.Timestamp,
+	bidOutputList := []BidOutputDTO{toBidOutputDTO(bidEntity)}
+	bu.presentBidders(ctx, auction, bidOutputList)
+	sealBids(auction, bidOutputList)
+
+	return &bidOutputList[0], nil
+}
+
+func (bu *BidUseCase) FindWinningBids(
+	ctx context.Context, auctionId string) ([]BidOutputDTO, *internal_error.InternalError) {
+	auction, err := bu.AuctionRepository.FindAuctionById(ctx, auctionId)
+	if err != nil {
+		return nil, err
+	}
+
+	bids, err := bu.BidRepository.FindBidByAuctionId(ctx, auctionId)
+	if err != nil {
+		return nil, err
+	}
+
+	winners := bid_entity.UnitWinners(bids, auction.Units())
+	bidOutputList := make([]BidOutputDTO, 0, len(winners))
+	for i := range winners {
+		bidOutputList = append(bidOutputList, toBidOutputDTO(&winners[i]))
 	}
 
-	return bidOutput, nil
+	bu.presentBidders(ctx, auction, bidOutputList)
+	sealBids(auction, bidOutputList)
+
+	return bidOutputList, nil
+}
+
+// presentBidders identifies the bidders of the bids as the auction allows:
+// with their public profile, masked, or not at all.
+func (bu *BidUseCase) presentBidders(
+	ctx context.Context, auction *auction_entity.Auction, bids []BidOutputDTO) {
+	visibility := auction.BidderVisibility
+
+	var profiles map[string]user_usecase.PublicProfileDTO
+	if visibility != auction_entity.BidderAnonymous {
+		userIds := make([]string, len(bids))
+		for i, bid := range bids {
+			userIds[i] = bid.UserId
+		}
+		profiles = user_usecase.FindPublicProfiles(ctx, bu.UserRepository, userIds)
+	}
+
+	for i := range bids {
+		profile, ok := profiles[bids[i].UserId]
+		bids[i].UserId = visibility.Present(bids[i].UserId)
+		bids[i].PlacedBy = ""
+		if !ok {
+			continue
+		}
+
+		if visibility == auction_entity.BidderMasked {
+			profile = user_usecase.PublicProfileDTO{
+				Id:          bids[i].UserId,
+				DisplayName: visibility.Present(profile.DisplayName),
+			}
+		}
+		bids[i].Bidder = &profile
+	}
+}
+
+// sealBids hides the amounts of the bids while the auction keeps them
+// sealed.
+func sealBids(auction *auction_entity.Auction, bids []BidOutputDTO) {
+	if !auction.BidsHidden() {
+		return
+	}
+
+	for i := range bids {
+		bids[i].Amount = 0
+		bids[i].Sealed = true
+	}
+}
+
+func toBidOutputDTO(bid *bid_entity.Bid) BidOutputDTO {
+	return BidOutputDTO{
+		Id:        bid.Id,
+		UserId:    bid.UserId,
+		AuctionId: bid.AuctionId,
+		Amount:    bid.Amount,
+		Source:    string(bid.Source),
+		Timestamp: bid.Timestamp,
+		PlacedBy:  bid.PlacedBy,
+	}
 }
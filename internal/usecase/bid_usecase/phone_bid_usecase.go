@@ -0,0 +1,67 @@
+package bid_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/entity/user_entity"
+	"fullcycle-auction_go/internal/internal_error"
+
+	"go.uber.org/zap"
+)
+
+type PhoneBidInputDTO struct {
+	AuctionId    string  `json:"auction_id" binding:"required,uuid"`
+	UserId       string  `json:"user_id" binding:"required,uuid"`
+	Amount       float64 `json:"amount" binding:"required,gt=0"`
+	TermsVersion int     `json:"terms_version" binding:"omitempty,min=1"`
+
+	// StaffId is the authenticated staff user, filled by the controller.
+	StaffId string `json:"-"`
+}
+
+// CreatePhoneBid places a bid for a bidder on the phone. The bid is the
+// bidder's, flagged with the phone source and the staff user who placed it,
+// and every attempt is written to the audit log.
+func (bu *BidUseCase) CreatePhoneBid(
+	ctx context.Context,
+	phoneBidInputDTO PhoneBidInputDTO) *internal_error.InternalError {
+	staff, err := bu.UserRepository.FindUserById(ctx, phoneBidInputDTO.StaffId)
+	if err != nil && err.Err != "not_found" {
+		return err
+	}
+	if staff == nil || staff.Role != user_entity.RoleStaff {
+		return internal_error.NewForbiddenError("Only staff can place phone bids")
+	}
+
+	if _, err := bu.UserRepository.FindUserById(ctx, phoneBidInputDTO.UserId); err != nil {
+		return err
+	}
+
+	_, reason, err := bu.placeBid(ctx, BidInputDTO{
+		UserId:       phoneBidInputDTO.UserId,
+		AuctionId:    phoneBidInputDTO.AuctionId,
+		Amount:       phoneBidInputDTO.Amount,
+		Source:       string(bid_entity.SourcePhone),
+		TermsVersion: phoneBidInputDTO.TermsVersion,
+		PlacedBy:     phoneBidInputDTO.StaffId,
+	})
+
+	fields := []zap.Field{
+		zap.String("staff_id", phoneBidInputDTO.StaffId),
+		zap.String("user_id", phoneBidInputDTO.UserId),
+		zap.String("auction_id", phoneBidInputDTO.AuctionId),
+		zap.Float64("amount", phoneBidInputDTO.Amount),
+	}
+	switch {
+	case err != nil:
+		logger.Info("Phone bid failed", append(fields, zap.String("error", err.Message))...)
+		return err
+	case reason != "":
+		logger.Info("Phone bid rejected", append(fields, zap.String("reason", string(reason)))...)
+		return reason.AsError()
+	}
+
+	logger.Info("Phone bid placed", fields...)
+	return nil
+}
@@ -0,0 +1,53 @@
+package bid_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+)
+
+type RejectedBidOutputDTO struct {
+	Id        string    `json:"id"`
+	UserId    string    `json:"user_id"`
+	AuctionId string    `json:"auction_id"`
+	Amount    float64   `json:"amount"`
+	Source    string    `json:"source"`
+	Reason    string    `json:"reason"`
+	Timestamp time.Time `json:"timestamp" time_format:"2006-01-02 15:04:05"`
+	PlacedBy  string    `json:"placed_by,omitempty"`
+}
+
+// FindRejectedBids lists the bids rejected on the auction. Only its seller
+// can see them, unless sellerId is empty, which is reserved to admins.
+func (bu *BidUseCase) FindRejectedBids(
+	ctx context.Context, auctionId, sellerId string) ([]RejectedBidOutputDTO, *internal_error.InternalError) {
+	auction, err := bu.AuctionRepository.FindAuctionById(ctx, auctionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if sellerId != "" && auction.SellerId != sellerId {
+		return nil, internal_error.NewForbiddenError("Only the seller can see the rejected bids of this auction")
+	}
+
+	rejectedBids, err := bu.RejectedBidRepository.FindRejectedBids(ctx, auctionId)
+	if err != nil {
+		return nil, err
+	}
+
+	rejectedBidOutputList := make([]RejectedBidOutputDTO, 0, len(rejectedBids))
+	for _, rejectedBid := range rejectedBids {
+		rejectedBidOutputList = append(rejectedBidOutputList, RejectedBidOutputDTO{
+			Id:        rejectedBid.Id,
+			UserId:    rejectedBid.UserId,
+			AuctionId: rejectedBid.AuctionId,
+			Amount:    rejectedBid.Amount,
+			Source:    string(rejectedBid.Source),
+			Reason:    string(rejectedBid.Reason),
+			Timestamp: rejectedBid.Timestamp,
+			PlacedBy:  rejectedBid.PlacedBy,
+		})
+	}
+
+	return rejectedBidOutputList, nil
+}
@@ -0,0 +1,127 @@
+package bid_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/clock"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"os"
+	"time"
+)
+
+// RetractBid takes the bid back for its bidder, within the retraction
+// window of its placement and as long as no higher bid superseded it.
+// Retracting a bid still buffered writes it first.
+func (bu *BidUseCase) RetractBid(
+	ctx context.Context, bidId, userId string) *internal_error.InternalError {
+	if bu.retractionWindow == 0 {
+		return internal_error.NewBadRequestError("Bids cannot be retracted")
+	}
+
+	bu.pendingBidsMutex.Lock()
+	auctionId, pending := bu.pendingBidIds[bidId]
+	bu.pendingBidsMutex.Unlock()
+	if pending {
+		bu.FlushAuction(auctionId)
+	}
+
+	bid, err := bu.BidRepository.FindBidById(ctx, bidId)
+	if err != nil {
+		return err
+	}
+	if bid.UserId != userId {
+		return internal_error.NewForbiddenError("Only the bidder can retract the bid")
+	}
+	if bid.Retracted() {
+		return internal_error.NewBadRequestError("The bid was already retracted")
+	}
+	now := clock.Now()
+	if now.Sub(bid.Timestamp) > bu.retractionWindow {
+		return internal_error.NewBadRequestError("The bid can no longer be retracted")
+	}
+
+	auctionEntity, err := bu.AuctionRepository.FindAuctionById(ctx, bid.AuctionId)
+	if err != nil {
+		return err
+	}
+	if auctionEntity.Status != auction_entity.Active || now.After(auctionEntity.EndsAt) {
+		return internal_error.NewBadRequestError("The auction no longer takes bids")
+	}
+	// Whether a higher bid superseded a sealed bid is not to be told.
+	if auctionEntity.Sealed {
+		return internal_error.NewBadRequestError("Sealed bids cannot be retracted")
+	}
+
+	if err := bu.seedHighestBid(ctx, bid.AuctionId); err != nil {
+		return err
+	}
+
+	// Bids accepted while the retraction is written still have to beat the
+	// retracted one, so a cache that changed meanwhile holds a higher bid
+	// and is kept, only an unchanged one being lowered to the stored
+	// highest. A bid pending on the auction beat the retracted one, which
+	// is then superseded, so the stored bids tell the new highest.
+	bu.pendingBidsMutex.Lock()
+	highest, ok := bu.highestBids[bid.AuctionId]
+	bu.pendingBidsMutex.Unlock()
+	if ok && highest > bid.Amount {
+		return internal_error.NewBadRequestError("A higher bid superseded the bid")
+	}
+
+	if err := bu.BidRepository.RetractBid(ctx, bid.Id, now); err != nil {
+		return err
+	}
+
+	winningBid, errWinning := bu.BidRepository.FindWinningBidByAuctionId(ctx, bid.AuctionId)
+
+	bu.pendingBidsMutex.Lock()
+	_, unitAuction := bu.unitBids[bid.AuctionId]
+	if current, ok := bu.highestBids[bid.AuctionId]; ok && current == bid.Amount {
+		// A cache that cannot be recomputed is dropped, for the next bid to
+		// seed it again.
+		if errWinning == nil {
+			bu.highestBids[bid.AuctionId] = winningBid.Amount
+		} else {
+			delete(bu.highestBids, bid.AuctionId)
+		}
+	}
+	bu.pendingBidsMutex.Unlock()
+
+	if !unitAuction {
+		return nil
+	}
+
+	bids, err := bu.BidRepository.FindBidByAuctionId(ctx, bid.AuctionId)
+
+	bu.pendingBidsMutex.Lock()
+	defer bu.pendingBidsMutex.Unlock()
+
+	bidders := bu.unitBids[bid.AuctionId]
+	if bidders == nil || bidders[bid.UserId] != bid.Amount {
+		return nil
+	}
+	if err != nil {
+		delete(bu.unitBids, bid.AuctionId)
+		return nil
+	}
+
+	delete(bidders, bid.UserId)
+	for _, standing := range bids {
+		if standing.UserId == bid.UserId && standing.Amount > bidders[bid.UserId] {
+			bidders[bid.UserId] = standing.Amount
+		}
+	}
+
+	return nil
+}
+
+// getRetractionWindow reads BID_RETRACTION_WINDOW, how long after placing a
+// bid its bidder may retract it, zero disabling retractions.
+func getRetractionWindow() time.Duration {
+	duration, err := time.ParseDuration(os.Getenv("BID_RETRACTION_WINDOW"))
+	if err != nil || duration < 0 {
+		return time.Minute
+	}
+
+	return duration
+}
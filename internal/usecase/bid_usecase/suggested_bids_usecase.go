@@ -0,0 +1,101 @@
+package bid_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/internal_error"
+	"math"
+)
+
+type SuggestedBidsOutputDTO struct {
+	AuctionId  string    `json:"auction_id"`
+	HighestBid float64   `json:"highest_bid"`
+	Increment  float64   `json:"increment"`
+	Amounts    []float64 `json:"amounts"`
+}
+
+// incrementTiers are the increments suggested from each price up, the
+// auction minimum increment raising them.
+var incrementTiers = []struct {
+	from      float64
+	increment float64
+}{
+	{0, 1},
+	{100, 5},
+	{500, 10},
+	{1000, 50},
+	{5000, 100},
+	{10000, 250},
+}
+
+// FindSuggestedBids suggests the amounts to bid on the auction from its
+// highest accepted bid, buffered ones included, so clients offer the same
+// ones. Sealed auctions suggest nothing, as it would give the highest bid
+// away.
+func (bu *BidUseCase) FindSuggestedBids(
+	ctx context.Context, auctionId string) (*SuggestedBidsOutputDTO, *internal_error.InternalError) {
+	auction, err := bu.AuctionRepository.FindAuctionById(ctx, auctionId)
+	if err != nil {
+		return nil, err
+	}
+	if auction.Status.Ended() {
+		return nil, internal_error.NewBadRequestError("Bids are not suggested for ended auctions")
+	}
+	if auction.Sealed {
+		return nil, internal_error.NewBadRequestError("Sealed auctions do not suggest bids")
+	}
+
+	if err := bu.seedHighestBid(ctx, auctionId); err != nil {
+		return nil, err
+	}
+
+	bu.pendingBidsMutex.Lock()
+	highest := bu.highestBids[auctionId]
+	bu.pendingBidsMutex.Unlock()
+
+	increment := suggestedIncrement(highest)
+	if auction.MinIncrement > increment {
+		increment = auction.MinIncrement
+	}
+
+	// Without bids the suggestions open at the starting price.
+	from := highest
+	if highest == 0 && auction.StartingPrice > 0 {
+		from = auction.StartingPrice - increment
+	}
+
+	return &SuggestedBidsOutputDTO{
+		AuctionId:  auctionId,
+		HighestBid: highest,
+		Increment:  increment,
+		Amounts:    suggestBids(from, increment),
+	}, nil
+}
+
+func suggestedIncrement(price float64) float64 {
+	increment := incrementTiers[0].increment
+	for _, tier := range incrementTiers {
+		if price >= tier.from {
+			increment = tier.increment
+		}
+	}
+	return increment
+}
+
+// suggestBids suggests one and two increments over the highest bid, then
+// the next two round numbers past those, multiples of ten increments.
+func suggestBids(highest, increment float64) []float64 {
+	next := highest + increment
+	second := highest + 2*increment
+
+	unit := 10 * increment
+	round := math.Ceil(second/unit) * unit
+	if round <= second {
+		round += unit
+	}
+
+	return []float64{roundCents(next), roundCents(second), roundCents(round), roundCents(round + unit)}
+}
+
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,25 @@
+package bid_usecase
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuggestedIncrement_FollowsTiers(t *testing.T) {
+	assert.Equal(t, 1.0, suggestedIncrement(0))
+	assert.Equal(t, 1.0, suggestedIncrement(99.99))
+	assert.Equal(t, 5.0, suggestedIncrement(100))
+	assert.Equal(t, 50.0, suggestedIncrement(4999))
+	assert.Equal(t, 250.0, suggestedIncrement(1e6))
+}
+
+func TestSuggestBids(t *testing.T) {
+	assert.Equal(t, []float64{142, 147, 150, 200}, suggestBids(137, 5))
+
+	// A round second suggestion moves the round ones past it.
+	assert.Equal(t, []float64{495, 500, 550, 600}, suggestBids(490, 5))
+	assert.Equal(t, []float64{1, 2, 10, 20}, suggestBids(0, 1))
+
+	assert.Equal(t, []float64{10.35, 10.6, 12.5, 15}, suggestBids(10.1, 0.25))
+}
@@ -0,0 +1,90 @@
+package bid_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"os"
+	"time"
+)
+
+// watchFlush has releasePending tell when the bid leaves the buffer. It is
+// called before the bid is handed to the create routine, so the flush
+// cannot release it unseen.
+func (bu *BidUseCase) watchFlush(bidId string) {
+	bu.pendingBidsMutex.Lock()
+	defer bu.pendingBidsMutex.Unlock()
+
+	bu.flushWatchers[bidId] = make(chan struct{})
+}
+
+// awaitFlush waits for the watched bid to leave the buffer, up to the sync
+// timeout, telling whether it did.
+func (bu *BidUseCase) awaitFlush(ctx context.Context, bidId string) bool {
+	bu.pendingBidsMutex.Lock()
+	flushed, ok := bu.flushWatchers[bidId]
+	bu.pendingBidsMutex.Unlock()
+	if !ok {
+		return true
+	}
+
+	timer := time.NewTimer(bu.syncTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-flushed:
+		return true
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+
+	bu.pendingBidsMutex.Lock()
+	delete(bu.flushWatchers, bidId)
+	bu.pendingBidsMutex.Unlock()
+
+	return false
+}
+
+// acknowledge waits for the flush of the accepted bid and tells its
+// outcome: persisted, rejected with its reason while buffered or by the
+// flush, or lost to a failed write. A bid still buffered at the sync timeout is answered
+// as pending.
+func (bu *BidUseCase) acknowledge(
+	ctx context.Context, accepted BidAcceptedOutputDTO) (BidAcceptedOutputDTO, *internal_error.InternalError) {
+	if !bu.awaitFlush(ctx, accepted.Id) {
+		accepted.EstimatedPersistenceMs = bu.estimatePersistence(false).Milliseconds()
+		return accepted, nil
+	}
+
+	status, err := bu.FindBidStatus(ctx, accepted.AuctionId, accepted.Id)
+	if err != nil {
+		if err.Err == "not_found" {
+			return BidAcceptedOutputDTO{}, internal_error.NewInternalServerError(
+				"The bid was accepted but could not be written")
+		}
+		return BidAcceptedOutputDTO{}, err
+	}
+
+	switch status.Status {
+	case BidRejected:
+		return BidAcceptedOutputDTO{}, bid_entity.RejectionReason(status.Reason).AsError()
+	case BidPending:
+		accepted.EstimatedPersistenceMs = status.EstimatedPersistenceMs
+	default:
+		accepted.Status = status.Status
+		accepted.EstimatedPersistenceMs = 0
+	}
+
+	return accepted, nil
+}
+
+// getSyncTimeout reads BID_SYNC_TIMEOUT, how long a synchronous bid waits
+// for its batch to be written before it is answered as pending.
+func getSyncTimeout() time.Duration {
+	duration, err := time.ParseDuration(os.Getenv("BID_SYNC_TIMEOUT"))
+	if err != nil || duration <= 0 {
+		return 10 * time.Second
+	}
+
+	return duration
+}
@@ -0,0 +1,72 @@
+package bid_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/infra/database/memory"
+	"fullcycle-auction_go/internal/internal_error"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAwaitFlush(t *testing.T) {
+	bidUseCase := &BidUseCase{
+		pendingBids:      map[string]pendingBids{"auction": {count: 2}},
+		pendingBidIds:    map[string]string{"flushed": "auction", "stuck": "auction"},
+		flushWatchers:    make(map[string]chan struct{}),
+		pendingBidsMutex: &sync.Mutex{},
+		syncTimeout:      50 * time.Millisecond,
+	}
+
+	bidUseCase.watchFlush("flushed")
+	go bidUseCase.releasePending([]bid_entity.Bid{{Id: "flushed", AuctionId: "auction"}})
+	assert.True(t, bidUseCase.awaitFlush(context.Background(), "flushed"))
+
+	// A bid whose batch outlasts the timeout stops being watched.
+	bidUseCase.watchFlush("stuck")
+	assert.False(t, bidUseCase.awaitFlush(context.Background(), "stuck"))
+	assert.Empty(t, bidUseCase.flushWatchers)
+
+	// A bid released before it is awaited is not waited for.
+	assert.True(t, bidUseCase.awaitFlush(context.Background(), "unwatched"))
+}
+
+// closedBidRepository leaves every bid out, as if its auction ended
+// before the flush.
+type closedBidRepository struct {
+	stubBidRepository
+}
+
+func (closedBidRepository) CreateBid(
+	ctx context.Context, bidEntities []bid_entity.Bid) ([]bid_entity.Bid, *internal_error.InternalError) {
+	return bidEntities, nil
+}
+
+func (closedBidRepository) FindBidById(
+	ctx context.Context, bidId string) (*bid_entity.Bid, *internal_error.InternalError) {
+	return nil, errBidNotFound
+}
+
+func TestAcknowledge_BidOfAnAuctionEndedBeforeTheFlush(t *testing.T) {
+	t.Setenv("MAX_BATCH_SIZE", "1")
+
+	auction := &auction_entity.Auction{Id: uuid.NewString(), Status: auction_entity.Active, EndsAt: time.Now().Add(time.Hour)}
+	bidUseCase := NewBidUseCase(
+		closedBidRepository{},
+		stubAuctionRepository{auction: auction},
+		stubUserRepository{},
+		memory.NewRejectedBidRepository(),
+		nil, nil, nil).(*BidUseCase)
+
+	_, err := bidUseCase.CreateBid(context.Background(),
+		BidInputDTO{UserId: uuid.NewString(), AuctionId: auction.Id, Amount: 10, Sync: true})
+	require.NotNil(t, err)
+	assert.Equal(t, "bad_request", err.Err)
+	assert.Equal(t, string(bid_entity.RejectionAuctionClosed), err.Reason)
+}
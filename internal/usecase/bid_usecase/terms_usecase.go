@@ -0,0 +1,45 @@
+package bid_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/clock"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/internal_error"
+)
+
+// acceptTerms makes sure the bidder accepted the current terms of the
+// auction, recording the acceptance that comes with the bid. Bidders only
+// have to accept again when the terms change.
+func (bu *BidUseCase) acceptTerms(
+	ctx context.Context,
+	auctionEntity *auction_entity.Auction,
+	userId string,
+	termsVersion int) (bid_entity.RejectionReason, *internal_error.InternalError) {
+	if auctionEntity.Terms == nil {
+		return "", nil
+	}
+
+	acceptance, err := bu.TermsRepository.FindTermsAcceptance(ctx, auctionEntity.Id, userId)
+	if err != nil && err.Err != "not_found" {
+		return "", err
+	}
+	if acceptance != nil && acceptance.TermsVersion >= auctionEntity.Terms.Version {
+		return "", nil
+	}
+
+	if termsVersion != auctionEntity.Terms.Version {
+		return bid_entity.RejectionTermsNotAccepted, nil
+	}
+
+	if err := bu.TermsRepository.CreateTermsAcceptance(ctx, &auction_entity.TermsAcceptance{
+		AuctionId:    auctionEntity.Id,
+		UserId:       userId,
+		TermsVersion: termsVersion,
+		Timestamp:    clock.Now(),
+	}); err != nil {
+		return "", err
+	}
+
+	return "", nil
+}
@@ -0,0 +1,110 @@
+package bid_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/clock"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"sort"
+	"time"
+)
+
+type WinningStatusOutputDTO struct {
+	AuctionId   string `json:"auction_id"`
+	ProductName string `json:"product_name"`
+
+	// Amount is the user's highest bid.
+	Amount float64 `json:"amount"`
+
+	// Leading tells whether the user's bid is winning, or winning a unit of
+	// the auctions selling several. Both it and LeadingAmount are missing
+	// while sealed bids stay hidden.
+	Leading       *bool    `json:"leading,omitempty"`
+	LeadingAmount *float64 `json:"leading_amount,omitempty"`
+
+	Ended            bool      `json:"ended"`
+	EndsAt           time.Time `json:"ends_at" time_format:"2006-01-02 15:04:05"`
+	RemainingSeconds int64     `json:"remaining_seconds"`
+}
+
+// FindWinningStatus tells where the user stands on each auction they bid
+// on, the open ones first, ending soonest first, then the ended ones, most
+// recent first. Bids still waiting in the batch buffer are not counted yet.
+func (bu *BidUseCase) FindWinningStatus(
+	ctx context.Context, userId string) ([]WinningStatusOutputDTO, *internal_error.InternalError) {
+	auctionBids, err := bu.BidRepository.FindUserAuctionBids(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	auctionIds := make([]string, 0, len(auctionBids))
+	for auctionId := range auctionBids {
+		auctionIds = append(auctionIds, auctionId)
+	}
+
+	auctions, err := bu.AuctionRepository.FindAuctionsByIds(ctx, auctionIds)
+	if err != nil {
+		return nil, err
+	}
+
+	now := clock.Now()
+	statuses := make([]WinningStatusOutputDTO, 0, len(auctions))
+	for _, auction := range auctions {
+		statuses = append(statuses, winningStatus(&auction, auctionBids[auction.Id], userId, now))
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].Ended != statuses[j].Ended {
+			return !statuses[i].Ended
+		}
+		if statuses[i].Ended {
+			return statuses[i].EndsAt.After(statuses[j].EndsAt)
+		}
+		return statuses[i].EndsAt.Before(statuses[j].EndsAt)
+	})
+
+	return statuses, nil
+}
+
+// winningStatus places the user among the bidders of the auction, bids
+// holding the highest bid of each.
+func winningStatus(
+	auction *auction_entity.Auction,
+	bids []bid_entity.Bid,
+	userId string,
+	now time.Time) WinningStatusOutputDTO {
+	status := WinningStatusOutputDTO{
+		AuctionId:   auction.Id,
+		ProductName: auction.ProductName,
+		Ended:       auction.Status.Ended(),
+		EndsAt:      auction.EndsAt,
+	}
+	if !status.Ended && auction.EndsAt.After(now) {
+		status.RemainingSeconds = int64(auction.EndsAt.Sub(now) / time.Second)
+	}
+
+	for _, bid := range bids {
+		if bid.UserId == userId {
+			status.Amount = bid.Amount
+		}
+	}
+
+	if auction.BidsHidden() {
+		return status
+	}
+
+	winners := bid_entity.UnitWinners(bids, auction.Units())
+	leading := false
+	for _, winner := range winners {
+		if winner.UserId == userId {
+			leading = true
+		}
+	}
+	status.Leading = &leading
+	if len(winners) > 0 {
+		status.LeadingAmount = &winners[0].Amount
+	}
+
+	return status
+}
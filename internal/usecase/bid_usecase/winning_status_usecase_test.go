@@ -0,0 +1,41 @@
+package bid_usecase
+
+import (
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWinningStatus(t *testing.T) {
+	now := time.Date(2026, 10, 15, 10, 0, 0, 0, time.UTC)
+	auction := &auction_entity.Auction{Id: "a1", Status: auction_entity.Active, EndsAt: now.Add(90 * time.Second)}
+	bids := []bid_entity.Bid{
+		{UserId: "ann", Amount: 30, Timestamp: now},
+		{UserId: "bob", Amount: 20, Timestamp: now},
+	}
+
+	status := winningStatus(auction, bids, "bob", now)
+	require.NotNil(t, status.Leading)
+	assert.False(t, *status.Leading)
+	assert.Equal(t, 30.0, *status.LeadingAmount)
+	assert.Equal(t, 20.0, status.Amount)
+	assert.Equal(t, int64(90), status.RemainingSeconds)
+
+	// Bob wins the second unit.
+	auction.Quantity = 2
+	assert.True(t, *winningStatus(auction, bids, "bob", now).Leading)
+
+	auction.Sealed = true
+	status = winningStatus(auction, bids, "bob", now)
+	assert.Nil(t, status.Leading)
+	assert.Nil(t, status.LeadingAmount)
+
+	auction.Status = auction_entity.Completed
+	status = winningStatus(auction, bids, "ann", now)
+	assert.True(t, *status.Leading)
+	assert.Zero(t, status.RemainingSeconds)
+}
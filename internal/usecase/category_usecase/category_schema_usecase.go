@@ -0,0 +1,117 @@
+package category_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/category_entity"
+	"fullcycle-auction_go/internal/internal_error"
+)
+
+type AttributeDefinitionDTO struct {
+	Name     string `json:"name" binding:"required"`
+	Type     string `json:"type" binding:"required,oneof=string number boolean"`
+	Required bool   `json:"required"`
+}
+
+type CategorySchemaInputDTO struct {
+	Attributes []AttributeDefinitionDTO `json:"attributes" binding:"dive"`
+	Labels     map[string]string        `json:"labels" binding:"dive,keys,oneof=en pt es,endkeys,required,max=100"`
+}
+
+type CategorySchemaOutputDTO struct {
+	Category   string                   `json:"category"`
+	Attributes []AttributeDefinitionDTO `json:"attributes"`
+	Labels     map[string]string        `json:"labels,omitempty"`
+}
+
+func NewCategoryUseCase(
+	categorySchemaRepositoryInterface category_entity.CategorySchemaRepositoryInterface,
+	categoryRepositoryInterface category_entity.CategoryRepositoryInterface) CategoryUseCaseInterface {
+	return &CategoryUseCase{
+		categorySchemaRepositoryInterface: categorySchemaRepositoryInterface,
+		categoryRepositoryInterface:       categoryRepositoryInterface,
+	}
+}
+
+type CategoryUseCaseInterface interface {
+	UpsertCategorySchema(
+		ctx context.Context,
+		category string,
+		schemaInput CategorySchemaInputDTO) (*CategorySchemaOutputDTO, *internal_error.InternalError)
+
+	FindCategorySchema(
+		ctx context.Context, category string) (*CategorySchemaOutputDTO, *internal_error.InternalError)
+
+	CreateCategory(
+		ctx context.Context, categoryInput CategoryInputDTO) (*CategoryOutputDTO, *internal_error.InternalError)
+
+	FindCategories(
+		ctx context.Context) ([]CategoryOutputDTO, *internal_error.InternalError)
+
+	FindCategory(
+		ctx context.Context, name string) (*CategoryOutputDTO, *internal_error.InternalError)
+
+	UpdateCategory(
+		ctx context.Context,
+		name string,
+		categoryInput UpdateCategoryInputDTO) (*CategoryOutputDTO, *internal_error.InternalError)
+
+	DeleteCategory(
+		ctx context.Context, name string) *internal_error.InternalError
+}
+
+type CategoryUseCase struct {
+	categorySchemaRepositoryInterface category_entity.CategorySchemaRepositoryInterface
+	categoryRepositoryInterface       category_entity.CategoryRepositoryInterface
+}
+
+func (cu *CategoryUseCase) UpsertCategorySchema(
+	ctx context.Context,
+	category string,
+	schemaInput CategorySchemaInputDTO) (*CategorySchemaOutputDTO, *internal_error.InternalError) {
+	var attributes []category_entity.AttributeDefinition
+	for _, attribute := range schemaInput.Attributes {
+		attributes = append(attributes, category_entity.AttributeDefinition{
+			Name:     attribute.Name,
+			Type:     category_entity.AttributeType(attribute.Type),
+			Required: attribute.Required,
+		})
+	}
+
+	schema, err := category_entity.CreateCategorySchema(category, attributes, schemaInput.Labels)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cu.categorySchemaRepositoryInterface.UpsertCategorySchema(ctx, schema); err != nil {
+		return nil, err
+	}
+
+	return toCategorySchemaOutputDTO(schema), nil
+}
+
+func (cu *CategoryUseCase) FindCategorySchema(
+	ctx context.Context, category string) (*CategorySchemaOutputDTO, *internal_error.InternalError) {
+	schema, err := cu.categorySchemaRepositoryInterface.FindCategorySchema(ctx, category)
+	if err != nil {
+		return nil, err
+	}
+
+	return toCategorySchemaOutputDTO(schema), nil
+}
+
+func toCategorySchemaOutputDTO(schema *category_entity.CategorySchema) *CategorySchemaOutputDTO {
+	output := &CategorySchemaOutputDTO{
+		Category:   schema.Category,
+		Attributes: []AttributeDefinitionDTO{},
+		Labels:     schema.Labels,
+	}
+	for _, attribute := range schema.Attributes {
+		output.Attributes = append(output.Attributes, AttributeDefinitionDTO{
+			Name:     attribute.Name,
+			Type:     string(attribute.Type),
+			Required: attribute.Required,
+		})
+	}
+
+	return output
+}
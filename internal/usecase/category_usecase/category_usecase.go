@@ -0,0 +1,168 @@
+package category_usecase
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/internal/entity/category_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+)
+
+type CategoryInputDTO struct {
+	Name   string `json:"name" binding:"required,min=3"`
+	Parent string `json:"parent"`
+}
+
+type UpdateCategoryInputDTO struct {
+	// Parent moves the category, empty moves it to the top level.
+	Parent string `json:"parent"`
+}
+
+type CategoryOutputDTO struct {
+	Name      string              `json:"name"`
+	Parent    string              `json:"parent,omitempty"`
+	Children  []CategoryOutputDTO `json:"children"`
+	Timestamp time.Time           `json:"timestamp" time_format:"2006-01-02 15:04:05"`
+}
+
+func (cu *CategoryUseCase) CreateCategory(
+	ctx context.Context, categoryInput CategoryInputDTO) (*CategoryOutputDTO, *internal_error.InternalError) {
+	category, err := category_entity.CreateCategory(categoryInput.Name, categoryInput.Parent)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := cu.categoryRepositoryInterface.FindCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if findCategory(categories, category.Name) != nil {
+		return nil, internal_error.NewBadRequestError(
+			fmt.Sprintf("Category %s already exists", category.Name))
+	}
+	if category.Parent != "" && findCategory(categories, category.Parent) == nil {
+		return nil, internal_error.NewBadRequestError(
+			fmt.Sprintf("Parent category %s does not exist", category.Parent))
+	}
+
+	if err := cu.categoryRepositoryInterface.CreateCategory(ctx, category); err != nil {
+		return nil, err
+	}
+
+	output := toCategoryOutputDTO(nil, *category)
+	return &output, nil
+}
+
+// FindCategories returns the category tree, one entry per top-level
+// category.
+func (cu *CategoryUseCase) FindCategories(
+	ctx context.Context) ([]CategoryOutputDTO, *internal_error.InternalError) {
+	categories, err := cu.categoryRepositoryInterface.FindCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs := []CategoryOutputDTO{}
+	for _, category := range categories {
+		if category.Parent == "" {
+			outputs = append(outputs, toCategoryOutputDTO(categories, category))
+		}
+	}
+
+	return outputs, nil
+}
+
+// FindCategory returns the category with the tree below it.
+func (cu *CategoryUseCase) FindCategory(
+	ctx context.Context, name string) (*CategoryOutputDTO, *internal_error.InternalError) {
+	categories, err := cu.categoryRepositoryInterface.FindCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	category := findCategory(categories, name)
+	if category == nil {
+		return nil, internal_error.NewNotFoundError(
+			fmt.Sprintf("Category not found with name = %s", name))
+	}
+
+	output := toCategoryOutputDTO(categories, *category)
+	return &output, nil
+}
+
+func (cu *CategoryUseCase) UpdateCategory(
+	ctx context.Context,
+	name string,
+	categoryInput UpdateCategoryInputDTO) (*CategoryOutputDTO, *internal_error.InternalError) {
+	categories, err := cu.categoryRepositoryInterface.FindCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	category := findCategory(categories, name)
+	if category == nil {
+		return nil, internal_error.NewNotFoundError(
+			fmt.Sprintf("Category not found with name = %s", name))
+	}
+	if categoryInput.Parent != "" && findCategory(categories, categoryInput.Parent) == nil {
+		return nil, internal_error.NewBadRequestError(
+			fmt.Sprintf("Parent category %s does not exist", categoryInput.Parent))
+	}
+	if category_entity.CreatesCycle(categories, name, categoryInput.Parent) {
+		return nil, internal_error.NewBadRequestError("A category cannot be moved under itself")
+	}
+
+	category.Parent = categoryInput.Parent
+	if err := cu.categoryRepositoryInterface.UpdateCategory(ctx, category); err != nil {
+		return nil, err
+	}
+
+	output := toCategoryOutputDTO(categories, *category)
+	return &output, nil
+}
+
+// DeleteCategory removes a category without subcategories. Auctions filed
+// under it keep their category.
+func (cu *CategoryUseCase) DeleteCategory(
+	ctx context.Context, name string) *internal_error.InternalError {
+	categories, err := cu.categoryRepositoryInterface.FindCategories(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, category := range categories {
+		if category.Parent == name {
+			return internal_error.NewBadRequestError("Categories with subcategories cannot be deleted")
+		}
+	}
+
+	return cu.categoryRepositoryInterface.DeleteCategory(ctx, name)
+}
+
+// findCategory returns a pointer into categories, nil when name is missing.
+func findCategory(categories []category_entity.Category, name string) *category_entity.Category {
+	for i := range categories {
+		if categories[i].Name == name {
+			return &categories[i]
+		}
+	}
+
+	return nil
+}
+
+func toCategoryOutputDTO(
+	categories []category_entity.Category, category category_entity.Category) CategoryOutputDTO {
+	output := CategoryOutputDTO{
+		Name:      category.Name,
+		Parent:    category.Parent,
+		Children:  []CategoryOutputDTO{},
+		Timestamp: category.Timestamp,
+	}
+	for _, child := range categories {
+		if child.Parent == category.Name {
+			output.Children = append(output.Children, toCategoryOutputDTO(categories, child))
+		}
+	}
+
+	return output
+}
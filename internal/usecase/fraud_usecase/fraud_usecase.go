@@ -0,0 +1,141 @@
+package fraud_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/clock"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/entity/fraud_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"os"
+	"strconv"
+	"time"
+)
+
+type FraudFlagOutputDTO struct {
+	Id        string    `json:"id"`
+	AuctionId string    `json:"auction_id"`
+	UserId    string    `json:"user_id"`
+	Signal    string    `json:"signal"`
+	Details   string    `json:"details"`
+	Timestamp time.Time `json:"timestamp" time_format:"2006-01-02 15:04:05"`
+}
+
+type FraudUseCaseInterface interface {
+	FindFraudFlags(
+		ctx context.Context, auctionId string) ([]FraudFlagOutputDTO, *internal_error.InternalError)
+}
+
+type FraudUseCase struct {
+	auctionRepositoryInterface   auction_entity.AuctionRepositoryInterface
+	bidRepositoryInterface       bid_entity.BidEntityRepository
+	fraudFlagRepositoryInterface fraud_entity.FraudFlagRepositoryInterface
+
+	scanInterval time.Duration
+	rules        rules
+}
+
+// NewFraudUseCase also starts the worker that periodically scans the bids
+// of active auctions for shill-bidding patterns.
+func NewFraudUseCase(
+	auctionRepositoryInterface auction_entity.AuctionRepositoryInterface,
+	bidRepositoryInterface bid_entity.BidEntityRepository,
+	fraudFlagRepositoryInterface fraud_entity.FraudFlagRepositoryInterface) FraudUseCaseInterface {
+	fraudUseCase := &FraudUseCase{
+		auctionRepositoryInterface:   auctionRepositoryInterface,
+		bidRepositoryInterface:       bidRepositoryInterface,
+		fraudFlagRepositoryInterface: fraudFlagRepositoryInterface,
+		scanInterval:                 clock.Scale(getScanInterval()),
+		rules: rules{
+			rapidBidWindow:    clock.Scale(getRapidBidWindow()),
+			rapidBidThreshold: getRapidBidThreshold(),
+		},
+	}
+
+	fraudUseCase.triggerScanRoutine(context.Background())
+
+	return fraudUseCase
+}
+
+func (fu *FraudUseCase) FindFraudFlags(
+	ctx context.Context, auctionId string) ([]FraudFlagOutputDTO, *internal_error.InternalError) {
+	flags, err := fu.fraudFlagRepositoryInterface.FindFraudFlags(ctx, auctionId)
+	if err != nil {
+		return nil, err
+	}
+
+	flagsOutput := make([]FraudFlagOutputDTO, 0, len(flags))
+	for _, flag := range flags {
+		flagsOutput = append(flagsOutput, FraudFlagOutputDTO{
+			Id:        flag.Id,
+			AuctionId: flag.AuctionId,
+			UserId:    flag.UserId,
+			Signal:    string(flag.Signal),
+			Details:   flag.Details,
+			Timestamp: flag.Timestamp,
+		})
+	}
+
+	return flagsOutput, nil
+}
+
+func (fu *FraudUseCase) triggerScanRoutine(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(fu.scanInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			fu.scan(ctx)
+		}
+	}()
+}
+
+func (fu *FraudUseCase) scan(ctx context.Context) {
+	auctions, err := fu.auctionRepositoryInterface.FindAuctions(ctx, auction_entity.AuctionFilter{Status: auction_entity.Active}, nil)
+	if err != nil {
+		logger.Error("error trying to find auctions to scan for fraud", err)
+		return
+	}
+
+	for _, auction := range auctions {
+		bids, err := fu.bidRepositoryInterface.FindBidByAuctionId(ctx, auction.Id)
+		if err != nil {
+			logger.Error("error trying to find bids to scan for fraud", err)
+			continue
+		}
+
+		for _, flag := range fu.rules.analyze(auction, bids) {
+			if err := fu.fraudFlagRepositoryInterface.UpsertFraudFlag(ctx, flag); err != nil {
+				logger.Error("error trying to save fraud flag", err)
+			}
+		}
+	}
+}
+
+func getScanInterval() time.Duration {
+	duration, err := time.ParseDuration(os.Getenv("FRAUD_SCAN_INTERVAL"))
+	if err != nil {
+		return time.Minute
+	}
+
+	return duration
+}
+
+func getRapidBidWindow() time.Duration {
+	duration, err := time.ParseDuration(os.Getenv("FRAUD_RAPID_BID_WINDOW"))
+	if err != nil {
+		return 30 * time.Second
+	}
+
+	return duration
+}
+
+func getRapidBidThreshold() int {
+	value, err := strconv.Atoi(os.Getenv("FRAUD_RAPID_BID_THRESHOLD"))
+	if err != nil {
+		return 3
+	}
+
+	return value
+}
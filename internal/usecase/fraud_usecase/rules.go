@@ -0,0 +1,125 @@
+package fraud_usecase
+
+import (
+	"fmt"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/entity/fraud_entity"
+	"sort"
+	"time"
+)
+
+type rules struct {
+	// rapidBidWindow is the longest gap between two bids of the leading
+	// bidder that still counts as rapid self-outbidding.
+	rapidBidWindow time.Duration
+	// rapidBidThreshold is how many rapid raises over their own leading bid
+	// a bidder needs before being flagged.
+	rapidBidThreshold int
+}
+
+func (r rules) analyze(auction auction_entity.Auction, bids []bid_entity.Bid) []*fraud_entity.FraudFlag {
+	sorted := make([]bid_entity.Bid, len(bids))
+	copy(sorted, bids)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if !sorted[i].Timestamp.Equal(sorted[j].Timestamp) {
+			return sorted[i].Timestamp.Before(sorted[j].Timestamp)
+		}
+		return sorted[i].Amount < sorted[j].Amount
+	})
+
+	var flags []*fraud_entity.FraudFlag
+	selfBids := 0
+	selfRaises := make(map[string]int)
+
+	var leader *bid_entity.Bid
+	for i := range sorted {
+		bid := &sorted[i]
+
+		if auction.SellerId != "" && bid.UserId == auction.SellerId {
+			selfBids++
+		}
+
+		if leader != nil && leader.UserId == bid.UserId &&
+			bid.Timestamp.Sub(leader.Timestamp) <= r.rapidBidWindow {
+			selfRaises[bid.UserId]++
+		}
+
+		if leader == nil || bid.Amount > leader.Amount {
+			leader = bid
+		}
+	}
+
+	if selfBids > 0 {
+		flags = append(flags, fraud_entity.CreateFraudFlag(
+			auction.Id, auction.SellerId, fraud_entity.SellerSelfBid,
+			fmt.Sprintf("seller placed %d bids on their own auction", selfBids)))
+	}
+
+	flags = append(flags, r.sharedClients(auction, sorted)...)
+
+	for userId, raises := range selfRaises {
+		if raises < r.rapidBidThreshold {
+			continue
+		}
+
+		flags = append(flags, fraud_entity.CreateFraudFlag(
+			auction.Id, userId, fraud_entity.RapidSelfOutbid,
+			fmt.Sprintf("raised their own leading bid %d times within %s", raises, r.rapidBidWindow)))
+	}
+
+	return flags
+}
+
+// sharedClients flags bidders that share an IP address or a device
+// fingerprint with other accounts bidding on the auction. Bids without
+// client information, stored before it was captured, are ignored.
+func (r rules) sharedClients(auction auction_entity.Auction, bids []bid_entity.Bid) []*fraud_entity.FraudFlag {
+	usersByClient := make(map[string]map[string]struct{})
+	addUser := func(key, userId string) {
+		if _, ok := usersByClient[key]; !ok {
+			usersByClient[key] = make(map[string]struct{})
+		}
+		usersByClient[key][userId] = struct{}{}
+	}
+
+	for _, bid := range bids {
+		if bid.Client.Ip != "" {
+			addUser("ip:"+bid.Client.Ip, bid.UserId)
+		}
+		if bid.Client.DeviceFingerprint != "" {
+			addUser("device:"+bid.Client.DeviceFingerprint, bid.UserId)
+		}
+	}
+
+	sharedWith := make(map[string]map[string]struct{})
+	for _, users := range usersByClient {
+		if len(users) < 2 {
+			continue
+		}
+
+		for userId := range users {
+			if _, ok := sharedWith[userId]; !ok {
+				sharedWith[userId] = make(map[string]struct{})
+			}
+			for otherId := range users {
+				if otherId != userId {
+					sharedWith[userId][otherId] = struct{}{}
+				}
+			}
+		}
+	}
+
+	var flags []*fraud_entity.FraudFlag
+	for userId, others := range sharedWith {
+		details := fmt.Sprintf("shares an IP address or device with %d other bidders", len(others))
+		if _, ok := others[auction.SellerId]; ok && auction.SellerId != "" {
+			details += ", including the seller"
+		}
+
+		flags = append(flags, fraud_entity.CreateFraudFlag(
+			auction.Id, userId, fraud_entity.SharedClient, details))
+	}
+
+	return flags
+}
@@ -0,0 +1,68 @@
+package fraud_usecase
+
+import (
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/entity/fraud_entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRules_Analyze(t *testing.T) {
+	start := time.Now()
+	auction := auction_entity.Auction{Id: "auction", SellerId: "seller"}
+	bid := func(userId string, amount float64, offset time.Duration) bid_entity.Bid {
+		return bid_entity.Bid{UserId: userId, Amount: amount, Timestamp: start.Add(offset)}
+	}
+
+	r := rules{rapidBidWindow: 10 * time.Second, rapidBidThreshold: 2}
+
+	flags := r.analyze(auction, []bid_entity.Bid{
+		bid("alice", 10, 0),
+		bid("seller", 15, time.Second),
+		bid("bob", 20, 2*time.Second),
+		bid("bob", 25, 3*time.Second),
+		bid("bob", 30, 4*time.Second),
+		bid("alice", 35, time.Minute),
+		bid("alice", 40, 2*time.Minute),
+	})
+
+	signals := make(map[fraud_entity.Signal]string)
+	for _, flag := range flags {
+		signals[flag.Signal] = flag.UserId
+	}
+
+	assert.Len(t, flags, 2)
+	assert.Equal(t, "seller", signals[fraud_entity.SellerSelfBid])
+	assert.Equal(t, "bob", signals[fraud_entity.RapidSelfOutbid])
+}
+
+func TestRules_AnalyzeSharedClients(t *testing.T) {
+	auction := auction_entity.Auction{Id: "auction", SellerId: "seller"}
+	bid := func(userId, ip, device string) bid_entity.Bid {
+		return bid_entity.Bid{
+			UserId: userId,
+			Amount: 10,
+			Client: bid_entity.ClientInfo{Ip: ip, DeviceFingerprint: device},
+		}
+	}
+
+	r := rules{rapidBidWindow: time.Second, rapidBidThreshold: 10}
+
+	flags := r.analyze(auction, []bid_entity.Bid{
+		bid("alice", "10.0.0.1", ""),
+		bid("bob", "10.0.0.1", "device-1"),
+		bid("carol", "10.0.0.2", "device-1"),
+		bid("dave", "10.0.0.3", ""),
+	})
+
+	flagged := make(map[string]bool)
+	for _, flag := range flags {
+		assert.Equal(t, fraud_entity.SharedClient, flag.Signal)
+		flagged[flag.UserId] = true
+	}
+
+	assert.Equal(t, map[string]bool{"alice": true, "bob": true, "carol": true}, flagged)
+}
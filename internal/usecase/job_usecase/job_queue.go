@@ -0,0 +1,224 @@
+package job_usecase
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/configuration/clock"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/job_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const maxRetryBackoff = 30 * time.Minute
+
+// JobQueue stores jobs in the job repository and runs them with a pool of
+// workers polling for due jobs. Jobs of kinds with no registered handler
+// are left for other instances.
+type JobQueue struct {
+	jobRepositoryInterface job_entity.JobRepositoryInterface
+
+	handlers      map[string]job_entity.Handler
+	handlersMutex *sync.RWMutex
+
+	workers      int
+	pollInterval time.Duration
+	lockDuration time.Duration
+	retryBackoff time.Duration
+	maxAttempts  int
+}
+
+// NewJobQueue also starts the workers, which pick up jobs as soon as their
+// kind is registered.
+func NewJobQueue(jobRepositoryInterface job_entity.JobRepositoryInterface) *JobQueue {
+	jobQueue := &JobQueue{
+		jobRepositoryInterface: jobRepositoryInterface,
+		handlers:               make(map[string]job_entity.Handler),
+		handlersMutex:          &sync.RWMutex{},
+		workers:                getWorkers(),
+		pollInterval:           clock.Scale(getPollInterval()),
+		lockDuration:           getLockDuration(),
+		retryBackoff:           getRetryBackoff(),
+		maxAttempts:            getMaxAttempts(),
+	}
+
+	jobQueue.triggerWorkers(context.Background())
+
+	return jobQueue
+}
+
+// Register sets the handler running the jobs of the kind.
+func (jq *JobQueue) Register(kind string, handler job_entity.Handler) {
+	jq.handlersMutex.Lock()
+	defer jq.handlersMutex.Unlock()
+
+	jq.handlers[kind] = handler
+}
+
+func (jq *JobQueue) Enqueue(
+	ctx context.Context,
+	kind string,
+	payload interface{},
+	runAt time.Time) *internal_error.InternalError {
+	job, err := job_entity.CreateJob(kind, payload, runAt, jq.maxAttempts)
+	if err != nil {
+		return err
+	}
+
+	return jq.jobRepositoryInterface.CreateJob(ctx, job)
+}
+
+func (jq *JobQueue) triggerWorkers(ctx context.Context) {
+	for i := 0; i < jq.workers; i++ {
+		go func() {
+			ticker := time.NewTicker(jq.pollInterval)
+			defer ticker.Stop()
+
+			for range ticker.C {
+				for jq.runNext(ctx) {
+				}
+			}
+		}()
+	}
+}
+
+// runNext claims and runs one due job, reporting whether there was one.
+func (jq *JobQueue) runNext(ctx context.Context) bool {
+	kinds := jq.kinds()
+	if len(kinds) == 0 {
+		return false
+	}
+
+	now := clock.Now()
+	job, err := jq.jobRepositoryInterface.ClaimJob(ctx, kinds, now, now.Add(jq.lockDuration))
+	if err != nil {
+		return false
+	}
+
+	jq.handlersMutex.RLock()
+	handler := jq.handlers[job.Kind]
+	jq.handlersMutex.RUnlock()
+
+	runErr := run(ctx, handler, job.Payload)
+
+	lockedUntil := job.LockedUntil
+	job.UpdatedAt = clock.Now()
+	job.LockedUntil = time.Time{}
+	switch {
+	case runErr == nil:
+		job.Status = job_entity.Succeeded
+		job.LastError = ""
+	case job.Attempts >= job.MaxAttempts:
+		job.Status = job_entity.Failed
+		job.LastError = runErr.Error()
+		logger.Error("Job failed after its last attempt", runErr,
+			zap.String("job_id", job.Id),
+			zap.String("kind", job.Kind),
+			zap.Int("attempts", job.Attempts))
+	default:
+		job.Status = job_entity.Pending
+		job.LastError = runErr.Error()
+		job.RunAt = job.UpdatedAt.Add(retryDelay(jq.retryBackoff, job.Attempts))
+	}
+
+	if err := jq.jobRepositoryInterface.UpdateJob(ctx, job, lockedUntil); err != nil {
+		if err.Err == "not_found" {
+			logger.Warn("Job was claimed again after its lock expired, dropping its result",
+				zap.String("job_id", job.Id),
+				zap.String("kind", job.Kind))
+		} else {
+			logger.Error("Error trying to save job result", err)
+		}
+	}
+
+	return true
+}
+
+func (jq *JobQueue) kinds() []string {
+	jq.handlersMutex.RLock()
+	defer jq.handlersMutex.RUnlock()
+
+	kinds := make([]string, 0, len(jq.handlers))
+	for kind := range jq.handlers {
+		kinds = append(kinds, kind)
+	}
+
+	return kinds
+}
+
+// run turns a panicking handler into a failed attempt.
+func run(ctx context.Context, handler job_entity.Handler, payload []byte) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("job handler panicked: %v", recovered)
+		}
+	}()
+
+	return handler(ctx, payload)
+}
+
+// retryDelay doubles the backoff with every attempt already made.
+func retryDelay(backoff time.Duration, attempts int) time.Duration {
+	delay := backoff
+	for i := 1; i < attempts && delay < maxRetryBackoff; i++ {
+		delay *= 2
+	}
+
+	if delay > maxRetryBackoff {
+		return maxRetryBackoff
+	}
+
+	return delay
+}
+
+func getWorkers() int {
+	workers, err := strconv.Atoi(os.Getenv("JOB_WORKERS"))
+	if err != nil || workers <= 0 {
+		return 2
+	}
+
+	return workers
+}
+
+func getPollInterval() time.Duration {
+	duration, err := time.ParseDuration(os.Getenv("JOB_POLL_INTERVAL"))
+	if err != nil || duration <= 0 {
+		return time.Second
+	}
+
+	return duration
+}
+
+// getLockDuration reads JOB_LOCK_DURATION, how long a job may run before
+// another worker assumes its worker died and claims it again.
+func getLockDuration() time.Duration {
+	duration, err := time.ParseDuration(os.Getenv("JOB_LOCK_DURATION"))
+	if err != nil || duration <= 0 {
+		return 5 * time.Minute
+	}
+
+	return duration
+}
+
+func getRetryBackoff() time.Duration {
+	duration, err := time.ParseDuration(os.Getenv("JOB_RETRY_BACKOFF"))
+	if err != nil || duration <= 0 {
+		return 10 * time.Second
+	}
+
+	return duration
+}
+
+func getMaxAttempts() int {
+	attempts, err := strconv.Atoi(os.Getenv("JOB_MAX_ATTEMPTS"))
+	if err != nil || attempts <= 0 {
+		return 5
+	}
+
+	return attempts
+}
@@ -0,0 +1,153 @@
+package job_usecase
+
+import (
+	"context"
+	"errors"
+	"fullcycle-auction_go/configuration/clock"
+	"fullcycle-auction_go/internal/entity/job_entity"
+	"fullcycle-auction_go/internal/infra/database/memory"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryDelay(t *testing.T) {
+	assert.Equal(t, 10*time.Second, retryDelay(10*time.Second, 1))
+	assert.Equal(t, 40*time.Second, retryDelay(10*time.Second, 3))
+	assert.Equal(t, maxRetryBackoff, retryDelay(10*time.Second, 40))
+}
+
+// newTestJobQueue returns a queue without workers, its jobs run by calling
+// runNext.
+func newTestJobQueue(repository job_entity.JobRepositoryInterface, maxAttempts int) *JobQueue {
+	return &JobQueue{
+		jobRepositoryInterface: repository,
+		handlers:               make(map[string]job_entity.Handler),
+		handlersMutex:          &sync.RWMutex{},
+		lockDuration:           time.Minute,
+		retryBackoff:           time.Minute,
+		maxAttempts:            maxAttempts,
+	}
+}
+
+func enqueueJob(t *testing.T, jobQueue *JobQueue, repository *memory.JobRepository) string {
+	require.Nil(t, jobQueue.Enqueue(context.Background(), "test", map[string]string{"key": "value"}, time.Time{}))
+
+	jobs, err := repository.FindJobs(context.Background(), "", "test", 1)
+	require.Nil(t, err)
+	require.Len(t, jobs, 1)
+	return jobs[0].Id
+}
+
+func findJob(t *testing.T, repository *memory.JobRepository, id string) *job_entity.Job {
+	job, err := repository.FindJobById(context.Background(), id)
+	require.Nil(t, err)
+	return job
+}
+
+func TestRunNext_Succeeds(t *testing.T) {
+	repository := memory.NewJobRepository()
+	jobQueue := newTestJobQueue(repository, 3)
+	id := enqueueJob(t, jobQueue, repository)
+
+	assert.False(t, jobQueue.runNext(context.Background()), "no handler claims the job")
+
+	var payload string
+	jobQueue.Register("test", func(ctx context.Context, data []byte) error {
+		payload = string(data)
+		return nil
+	})
+	assert.True(t, jobQueue.runNext(context.Background()))
+	assert.JSONEq(t, `{"key":"value"}`, payload)
+
+	job := findJob(t, repository, id)
+	assert.Equal(t, job_entity.Succeeded, job.Status)
+	assert.Equal(t, 1, job.Attempts)
+	assert.True(t, job.LockedUntil.IsZero())
+
+	assert.False(t, jobQueue.runNext(context.Background()), "the job ran once")
+}
+
+func TestRunNext_SchedulesRetry(t *testing.T) {
+	repository := memory.NewJobRepository()
+	jobQueue := newTestJobQueue(repository, 3)
+	id := enqueueJob(t, jobQueue, repository)
+
+	jobQueue.Register("test", func(ctx context.Context, data []byte) error {
+		return errors.New("unreachable")
+	})
+	assert.True(t, jobQueue.runNext(context.Background()))
+
+	job := findJob(t, repository, id)
+	assert.Equal(t, job_entity.Pending, job.Status)
+	assert.Equal(t, "unreachable", job.LastError)
+	assert.Equal(t, job.UpdatedAt.Add(time.Minute), job.RunAt)
+
+	assert.False(t, jobQueue.runNext(context.Background()), "the retry is not due yet")
+}
+
+func TestRunNext_FailsAfterLastAttempt(t *testing.T) {
+	repository := memory.NewJobRepository()
+	jobQueue := newTestJobQueue(repository, 2)
+	jobQueue.retryBackoff = time.Nanosecond
+	id := enqueueJob(t, jobQueue, repository)
+
+	jobQueue.Register("test", func(ctx context.Context, data []byte) error {
+		return errors.New("unreachable")
+	})
+	assert.True(t, jobQueue.runNext(context.Background()))
+	assert.Equal(t, job_entity.Pending, findJob(t, repository, id).Status)
+
+	assert.True(t, jobQueue.runNext(context.Background()))
+	job := findJob(t, repository, id)
+	assert.Equal(t, job_entity.Failed, job.Status)
+	assert.Equal(t, 2, job.Attempts)
+	assert.Equal(t, "unreachable", job.LastError)
+
+	assert.False(t, jobQueue.runNext(context.Background()), "failed jobs are not retried")
+}
+
+func TestRunNext_RecoversPanickingHandler(t *testing.T) {
+	repository := memory.NewJobRepository()
+	jobQueue := newTestJobQueue(repository, 3)
+	id := enqueueJob(t, jobQueue, repository)
+
+	jobQueue.Register("test", func(ctx context.Context, data []byte) error {
+		panic("nil map")
+	})
+	assert.True(t, jobQueue.runNext(context.Background()))
+
+	job := findJob(t, repository, id)
+	assert.Equal(t, job_entity.Pending, job.Status)
+	assert.Equal(t, "job handler panicked: nil map", job.LastError)
+}
+
+func TestRunNext_ReclaimsExpiredLock(t *testing.T) {
+	repository := memory.NewJobRepository()
+	jobQueue := newTestJobQueue(repository, 3)
+	id := enqueueJob(t, jobQueue, repository)
+
+	// A worker claims the job and stalls past its lock.
+	now := clock.Now()
+	stalled, err := repository.ClaimJob(context.Background(), []string{"test"}, now, now.Add(-time.Millisecond))
+	require.Nil(t, err)
+	stalledLock := stalled.LockedUntil
+
+	jobQueue.Register("test", func(ctx context.Context, data []byte) error { return nil })
+	assert.True(t, jobQueue.runNext(context.Background()))
+
+	job := findJob(t, repository, id)
+	assert.Equal(t, job_entity.Succeeded, job.Status)
+	assert.Equal(t, 2, job.Attempts)
+
+	// The stalled worker's result does not overwrite the new one.
+	stalled.Status = job_entity.Failed
+	stalled.LockedUntil = time.Time{}
+	err = repository.UpdateJob(context.Background(), stalled, stalledLock)
+	require.NotNil(t, err)
+	assert.Equal(t, "not_found", err.Err)
+	assert.Equal(t, job_entity.Succeeded, findJob(t, repository, id).Status)
+}
@@ -0,0 +1,129 @@
+package job_usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"fullcycle-auction_go/configuration/clock"
+	"fullcycle-auction_go/internal/entity/job_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+)
+
+const (
+	DefaultJobsLimit = 50
+	MaxJobsLimit     = 200
+)
+
+type JobOutputDTO struct {
+	Id          string          `json:"id"`
+	Kind        string          `json:"kind"`
+	Payload     json.RawMessage `json:"payload"`
+	Status      string          `json:"status"`
+	Attempts    int             `json:"attempts"`
+	MaxAttempts int             `json:"max_attempts"`
+	RunAt       time.Time       `json:"run_at" time_format:"2006-01-02 15:04:05"`
+	LastError   string          `json:"last_error,omitempty"`
+	Timestamp   time.Time       `json:"timestamp" time_format:"2006-01-02 15:04:05"`
+	UpdatedAt   time.Time       `json:"updated_at" time_format:"2006-01-02 15:04:05"`
+}
+
+type JobUseCaseInterface interface {
+	FindJobs(
+		ctx context.Context,
+		status, kind string,
+		limit int) ([]JobOutputDTO, *internal_error.InternalError)
+
+	FindJobById(
+		ctx context.Context, id string) (*JobOutputDTO, *internal_error.InternalError)
+
+	RetryJob(
+		ctx context.Context, id string) (*JobOutputDTO, *internal_error.InternalError)
+}
+
+type JobUseCase struct {
+	jobRepositoryInterface job_entity.JobRepositoryInterface
+}
+
+func NewJobUseCase(jobRepositoryInterface job_entity.JobRepositoryInterface) JobUseCaseInterface {
+	return &JobUseCase{
+		jobRepositoryInterface: jobRepositoryInterface,
+	}
+}
+
+func (ju *JobUseCase) FindJobs(
+	ctx context.Context,
+	status, kind string,
+	limit int) ([]JobOutputDTO, *internal_error.InternalError) {
+	switch job_entity.JobStatus(status) {
+	case "", job_entity.Pending, job_entity.Running, job_entity.Succeeded, job_entity.Failed:
+	default:
+		return nil, internal_error.NewBadRequestError(fmt.Sprintf("Unknown job status %s", status))
+	}
+
+	if limit < 1 || limit > MaxJobsLimit {
+		return nil, internal_error.NewBadRequestError(
+			fmt.Sprintf("limit must be between 1 and %d", MaxJobsLimit))
+	}
+
+	jobs, err := ju.jobRepositoryInterface.FindJobs(ctx, job_entity.JobStatus(status), kind, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	jobOutputList := make([]JobOutputDTO, 0, len(jobs))
+	for _, job := range jobs {
+		jobOutputList = append(jobOutputList, *toJobOutputDTO(&job))
+	}
+
+	return jobOutputList, nil
+}
+
+func (ju *JobUseCase) FindJobById(
+	ctx context.Context, id string) (*JobOutputDTO, *internal_error.InternalError) {
+	job, err := ju.jobRepositoryInterface.FindJobById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toJobOutputDTO(job), nil
+}
+
+// RetryJob gives a failed job a fresh set of attempts, starting now.
+func (ju *JobUseCase) RetryJob(
+	ctx context.Context, id string) (*JobOutputDTO, *internal_error.InternalError) {
+	job, err := ju.jobRepositoryInterface.FindJobById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if job.Status != job_entity.Failed {
+		return nil, internal_error.NewBadRequestError("Only failed jobs can be retried")
+	}
+
+	job.Status = job_entity.Pending
+	job.Attempts = 0
+	job.RunAt = clock.Now()
+	job.UpdatedAt = job.RunAt
+
+	if err := ju.jobRepositoryInterface.UpdateJob(ctx, job, job.LockedUntil); err != nil {
+		return nil, err
+	}
+
+	return toJobOutputDTO(job), nil
+}
+
+func toJobOutputDTO(job *job_entity.Job) *JobOutputDTO {
+	return &JobOutputDTO{
+		Id:          job.Id,
+		Kind:        job.Kind,
+		Payload:     json.RawMessage(job.Payload),
+		Status:      string(job.Status),
+		Attempts:    job.Attempts,
+		MaxAttempts: job.MaxAttempts,
+		RunAt:       job.RunAt,
+		LastError:   job.LastError,
+		Timestamp:   job.Timestamp,
+		UpdatedAt:   job.UpdatedAt,
+	}
+}
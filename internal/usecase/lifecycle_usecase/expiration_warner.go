@@ -0,0 +1,158 @@
+package lifecycle_usecase
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/configuration/clock"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/entity/event_entity"
+	"fullcycle-auction_go/internal/entity/notification_entity"
+	"time"
+)
+
+type expirationWarning struct {
+	before    time.Duration
+	eventType event_entity.Type
+	label     string
+}
+
+// expirationWarnings are sent as auctions get close to their end, from the
+// earliest to the latest.
+var expirationWarnings = []expirationWarning{
+	{before: 5 * time.Minute, eventType: event_entity.AuctionEndingIn5m, label: "5 minutes"},
+	{before: time.Minute, eventType: event_entity.AuctionEndingIn1m, label: "1 minute"},
+}
+
+// ExpirationWarner periodically looks for active auctions about to end,
+// publishing a warning event and notifying the seller and the bidders.
+type ExpirationWarner struct {
+	auctionRepositoryInterface auction_entity.AuctionRepositoryInterface
+	bidRepositoryInterface     bid_entity.BidEntityRepository
+	publisher                  event_entity.Publisher
+	notifier                   notification_entity.Notifier
+
+	scanInterval time.Duration
+	// sent holds, per auction, the end each warning was sent for, so an
+	// extension past a warning arms it again. Only the scan routine uses it.
+	sent map[string]map[event_entity.Type]time.Time
+}
+
+// NewExpirationWarner also starts the scan routine, every
+// AUCTION_WARNING_SCAN_INTERVAL.
+func NewExpirationWarner(
+	auctionRepositoryInterface auction_entity.AuctionRepositoryInterface,
+	bidRepositoryInterface bid_entity.BidEntityRepository,
+	publisher event_entity.Publisher,
+	notifier notification_entity.Notifier) *ExpirationWarner {
+	expirationWarner := &ExpirationWarner{
+		auctionRepositoryInterface: auctionRepositoryInterface,
+		bidRepositoryInterface:     bidRepositoryInterface,
+		publisher:                  publisher,
+		notifier:                   notifier,
+		scanInterval:               clock.Scale(getDuration("AUCTION_WARNING_SCAN_INTERVAL", 15*time.Second)),
+		sent:                       make(map[string]map[event_entity.Type]time.Time),
+	}
+
+	expirationWarner.triggerScanRoutine(context.Background())
+
+	return expirationWarner
+}
+
+func (ew *ExpirationWarner) triggerScanRoutine(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(ew.scanInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			ew.scan(ctx)
+		}
+	}()
+}
+
+func (ew *ExpirationWarner) scan(ctx context.Context) {
+	auctions, err := ew.auctionRepositoryInterface.FindAuctions(
+		ctx, auction_entity.AuctionFilter{Status: auction_entity.Active}, []string{"id", "seller_id", "product_name", "status", "ends_at"})
+	if err != nil {
+		logger.Error("error trying to find auctions to warn about", err)
+		return
+	}
+
+	now := clock.Now()
+	active := make(map[string]bool, len(auctions))
+	for _, auction := range auctions {
+		if auction.Status != auction_entity.Active {
+			continue
+		}
+		active[auction.Id] = true
+
+		due := dueWarnings(auction.EndsAt, now, ew.sent[auction.Id])
+		if len(due) == 0 {
+			continue
+		}
+
+		// A scan late enough to find several warnings due sends the latest
+		// one only.
+		ew.warn(ctx, auction, due[len(due)-1], now)
+
+		if ew.sent[auction.Id] == nil {
+			ew.sent[auction.Id] = make(map[event_entity.Type]time.Time)
+		}
+		for _, warning := range due {
+			ew.sent[auction.Id][warning.eventType] = auction.EndsAt
+		}
+	}
+
+	for auctionId := range ew.sent {
+		if !active[auctionId] {
+			delete(ew.sent, auctionId)
+		}
+	}
+}
+
+func (ew *ExpirationWarner) warn(
+	ctx context.Context, auction auction_entity.Auction, warning expirationWarning, now time.Time) {
+	ew.publisher.Publish(ctx, event_entity.NewEvent(warning.eventType, auction.Id, map[string]interface{}{
+		"ends_at":           auction.EndsAt,
+		"remaining_seconds": int64(auction.EndsAt.Sub(now) / time.Second),
+	}))
+
+	bids, err := ew.bidRepositoryInterface.FindBidByAuctionId(ctx, auction.Id)
+	if err != nil {
+		logger.Error("error trying to find the bidders to warn", err)
+	}
+
+	recipients := map[string]bool{}
+	if auction.SellerId != "" {
+		recipients[auction.SellerId] = true
+	}
+	for _, bid := range bids {
+		recipients[bid.UserId] = true
+	}
+
+	message := fmt.Sprintf("%s ends in %s", auction.ProductName, warning.label)
+	for userId := range recipients {
+		ew.notifier.Notify(ctx, notification_entity.NewNotification(
+			userId, auction.Id, notification_entity.AuctionEnding, message))
+	}
+}
+
+// dueWarnings lists the warnings whose time has come for an auction ending
+// at endsAt and that were not sent for that end yet.
+func dueWarnings(
+	endsAt, now time.Time, sent map[event_entity.Type]time.Time) []expirationWarning {
+	remaining := endsAt.Sub(now)
+	if remaining <= 0 {
+		return nil
+	}
+
+	var due []expirationWarning
+	for _, warning := range expirationWarnings {
+		if remaining <= warning.before && !sent[warning.eventType].Equal(endsAt) {
+			due = append(due, warning)
+		}
+	}
+
+	return due
+}
@@ -0,0 +1,31 @@
+package lifecycle_usecase
+
+import (
+	"fullcycle-auction_go/internal/entity/event_entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDueWarnings(t *testing.T) {
+	endsAt := time.Now()
+
+	assert.Empty(t, dueWarnings(endsAt, endsAt.Add(-10*time.Minute), nil))
+
+	due := dueWarnings(endsAt, endsAt.Add(-4*time.Minute), nil)
+	assert.Len(t, due, 1)
+	assert.Equal(t, event_entity.AuctionEndingIn5m, due[0].eventType)
+
+	sent := map[event_entity.Type]time.Time{event_entity.AuctionEndingIn5m: endsAt}
+	assert.Empty(t, dueWarnings(endsAt, endsAt.Add(-4*time.Minute), sent))
+
+	due = dueWarnings(endsAt, endsAt.Add(-30*time.Second), nil)
+	assert.Len(t, due, 2)
+	assert.Equal(t, event_entity.AuctionEndingIn1m, due[1].eventType)
+
+	extended := endsAt.Add(10 * time.Minute)
+	assert.Len(t, dueWarnings(extended, extended.Add(-4*time.Minute), sent), 1)
+
+	assert.Empty(t, dueWarnings(endsAt, endsAt.Add(time.Second), nil))
+}
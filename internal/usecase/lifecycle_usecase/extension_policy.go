@@ -0,0 +1,86 @@
+package lifecycle_usecase
+
+import (
+	"os"
+	"time"
+)
+
+// ExtensionPolicy decides how long an auction is extended when a bid
+// arrives near its end. The returned duration is counted from the bid, and
+// zero leaves the end untouched.
+type ExtensionPolicy interface {
+	Extension(endsAt, bidAt time.Time, recentBids []time.Time) time.Duration
+}
+
+// FixedWindowPolicy is the classic anti-sniping rule: a bid within Window of
+// the end pushes it to Duration after the bid.
+type FixedWindowPolicy struct {
+	Window   time.Duration
+	Duration time.Duration
+}
+
+func (p FixedWindowPolicy) Extension(endsAt, bidAt time.Time, recentBids []time.Time) time.Duration {
+	if endsAt.Sub(bidAt) > p.Window {
+		return 0
+	}
+
+	return p.Duration
+}
+
+// VelocityPolicy scales the extension with how many bids arrived within
+// Window of the bid: every one of them adds Step, up to Max. A bidding war
+// in the final minutes keeps the auction open longer than a lone late bid.
+type VelocityPolicy struct {
+	Window time.Duration
+	Step   time.Duration
+	Max    time.Duration
+}
+
+func (p VelocityPolicy) Extension(endsAt, bidAt time.Time, recentBids []time.Time) time.Duration {
+	if endsAt.Sub(bidAt) > p.Window {
+		return 0
+	}
+
+	velocity := 0
+	for _, recentBid := range recentBids {
+		if bidAt.Sub(recentBid) <= p.Window {
+			velocity++
+		}
+	}
+
+	extension := time.Duration(velocity) * p.Step
+	if extension > p.Max {
+		return p.Max
+	}
+
+	return extension
+}
+
+// NewExtensionPolicy builds the policy selected by AUCTION_EXTENSION_POLICY,
+// fixed or velocity. Auctions are never extended when it is not set.
+func NewExtensionPolicy() ExtensionPolicy {
+	window := getDuration("AUCTION_EXTENSION_WINDOW", 2*time.Minute)
+	step := getDuration("AUCTION_EXTENSION_STEP", time.Minute)
+
+	switch os.Getenv("AUCTION_EXTENSION_POLICY") {
+	case "fixed":
+		return FixedWindowPolicy{Window: window, Duration: step}
+	case "velocity":
+		return VelocityPolicy{
+			Window: window,
+			Step:   step,
+			Max:    getDuration("AUCTION_EXTENSION_MAX", 10*time.Minute),
+		}
+	}
+
+	return nil
+}
+
+func getDuration(name string, fallback time.Duration) time.Duration {
+	duration, err := time.ParseDuration(os.Getenv(name))
+	if err != nil {
+		return fallback
+	}
+
+	return duration
+}
@@ -0,0 +1,39 @@
+package lifecycle_usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVelocityPolicy_Extension(t *testing.T) {
+	endsAt := time.Now()
+	policy := VelocityPolicy{Window: 2 * time.Minute, Step: 30 * time.Second, Max: 2 * time.Minute}
+
+	early := endsAt.Add(-10 * time.Minute)
+	assert.Zero(t, policy.Extension(endsAt, early, []time.Time{early}))
+
+	lone := endsAt.Add(-time.Minute)
+	assert.Equal(t, 30*time.Second, policy.Extension(endsAt, lone, []time.Time{early, lone}))
+
+	war := []time.Time{
+		endsAt.Add(-90 * time.Second),
+		endsAt.Add(-60 * time.Second),
+		endsAt.Add(-30 * time.Second),
+	}
+	assert.Equal(t, 90*time.Second, policy.Extension(endsAt, war[2], war))
+
+	for i := 0; i < 5; i++ {
+		war = append(war, endsAt.Add(-10*time.Second))
+	}
+	assert.Equal(t, 2*time.Minute, policy.Extension(endsAt, war[len(war)-1], war))
+}
+
+func TestFixedWindowPolicy_Extension(t *testing.T) {
+	endsAt := time.Now()
+	policy := FixedWindowPolicy{Window: time.Minute, Duration: time.Minute}
+
+	assert.Zero(t, policy.Extension(endsAt, endsAt.Add(-2*time.Minute), nil))
+	assert.Equal(t, time.Minute, policy.Extension(endsAt, endsAt.Add(-10*time.Second), nil))
+}
@@ -0,0 +1,157 @@
+package lifecycle_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/clock"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"math"
+	"time"
+)
+
+const (
+	// heatBidWeight is how many watchers a bid of the last heatBidWindow
+	// weighs as.
+	heatBidWeight = 3
+	heatBidWindow = time.Hour
+
+	// heatViewWeight is how many watchers a viewer of the last hour weighs
+	// as.
+	heatViewWeight = 0.5
+
+	// heatUrgencyWindow is how close to their end auctions start heating
+	// up, up to twice their score as they end.
+	heatUrgencyWindow = 24 * time.Hour
+)
+
+// Followers tells how many users follow an auction live.
+type Followers interface {
+	Followers(auctionId string) int
+}
+
+// Viewers tells how many users viewed each auction in the last hour.
+type Viewers interface {
+	RecentViews() map[string]int
+}
+
+// HeatScorer periodically scores how popular the active auctions are from
+// their watchers, the users following them live, their viewers and bids of
+// the last hour and the time they have left.
+type HeatScorer struct {
+	auctionRepositoryInterface auction_entity.AuctionRepositoryInterface
+	bidRepositoryInterface     bid_entity.BidEntityRepository
+	followers                  Followers
+	viewers                    Viewers
+
+	scanInterval time.Duration
+	// scores holds the score stored for each active auction, so unchanged
+	// scores are not written again. Only the scan routine uses it.
+	scores map[string]float64
+}
+
+// NewHeatScorer also starts the scan routine, every AUCTION_HEAT_INTERVAL,
+// which 0 disables.
+func NewHeatScorer(
+	auctionRepositoryInterface auction_entity.AuctionRepositoryInterface,
+	bidRepositoryInterface bid_entity.BidEntityRepository,
+	followers Followers,
+	viewers Viewers) *HeatScorer {
+	heatScorer := &HeatScorer{
+		auctionRepositoryInterface: auctionRepositoryInterface,
+		bidRepositoryInterface:     bidRepositoryInterface,
+		followers:                  followers,
+		viewers:                    viewers,
+		scanInterval:               getDuration("AUCTION_HEAT_INTERVAL", time.Minute),
+		scores:                     make(map[string]float64),
+	}
+
+	if heatScorer.scanInterval > 0 {
+		heatScorer.triggerScanRoutine(context.Background())
+	}
+
+	return heatScorer
+}
+
+func (hs *HeatScorer) triggerScanRoutine(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(clock.Scale(hs.scanInterval))
+		defer ticker.Stop()
+
+		for range ticker.C {
+			hs.scan(ctx)
+		}
+	}()
+}
+
+func (hs *HeatScorer) scan(ctx context.Context) {
+	auctions, err := hs.auctionRepositoryInterface.FindAuctions(
+		ctx, auction_entity.AuctionFilter{Status: auction_entity.Active}, []string{"id", "status", "ends_at"})
+	if err != nil {
+		logger.Error("error trying to find auctions to score", err)
+		return
+	}
+
+	now := clock.Now()
+	recentViews := hs.viewers.RecentViews()
+	active := make(map[string]bool, len(auctions))
+	for _, auction := range auctions {
+		if auction.Status != auction_entity.Active {
+			continue
+		}
+		active[auction.Id] = true
+
+		bids, err := hs.bidRepositoryInterface.FindBidByAuctionId(ctx, auction.Id)
+		if err != nil {
+			logger.Error("error trying to find the bids to score", err)
+			continue
+		}
+
+		var recentBids int
+		for _, bid := range bids {
+			if now.Sub(bid.Timestamp) <= heatBidWindow {
+				recentBids++
+			}
+		}
+
+		score := heatScore(
+			hs.followers.Followers(auction.Id), recentViews[auction.Id], recentBids, auction.EndsAt.Sub(now))
+		hs.store(ctx, auction.Id, score)
+	}
+
+	// Auctions no longer active cool down, so they do not rank among the
+	// popular ones.
+	for auctionId := range hs.scores {
+		if !active[auctionId] {
+			hs.store(ctx, auctionId, 0)
+			delete(hs.scores, auctionId)
+		}
+	}
+}
+
+func (hs *HeatScorer) store(ctx context.Context, auctionId string, score float64) {
+	if stored, ok := hs.scores[auctionId]; ok && stored == score {
+		return
+	}
+
+	if err := hs.auctionRepositoryInterface.UpdateHeatScore(ctx, auctionId, score); err != nil {
+		logger.Error("error trying to store the auction heat score", err)
+		return
+	}
+	hs.scores[auctionId] = score
+}
+
+// heatScore adds the watchers to the recent viewers, which weigh less, and
+// bids, which weigh more, and raises the sum as the auction nears its end.
+func heatScore(watchers, recentViews, recentBids int, remaining time.Duration) float64 {
+	score := float64(watchers) + heatViewWeight*float64(recentViews) + heatBidWeight*float64(recentBids)
+
+	if remaining < 0 {
+		remaining = 0
+	}
+	if remaining < heatUrgencyWindow {
+		score *= 2 - float64(remaining)/float64(heatUrgencyWindow)
+	}
+
+	return math.Round(score*100) / 100
+}
@@ -0,0 +1,20 @@
+package lifecycle_usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeatScore(t *testing.T) {
+	assert.Equal(t, 0.0, heatScore(0, 0, 0, time.Hour))
+	assert.Equal(t, 7.0, heatScore(1, 0, 2, 48*time.Hour))
+
+	// The score doubles as the auction ends.
+	assert.Equal(t, 10.5, heatScore(1, 0, 2, 12*time.Hour))
+	assert.Equal(t, 14.0, heatScore(1, 0, 2, 0))
+	assert.Equal(t, 14.0, heatScore(1, 0, 2, -time.Minute))
+
+	assert.Equal(t, 9.0, heatScore(1, 4, 2, 48*time.Hour))
+}
@@ -0,0 +1,106 @@
+package lifecycle_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"sync"
+	"time"
+)
+
+// recentBidsHorizon bounds how far back bid times are kept to measure the
+// bidding velocity.
+const recentBidsHorizon = time.Hour
+
+// LifecycleManager applies the extension policy to auctions as bids are
+// accepted. The repositories complete an auction once its end passes, so
+// moving EndsAt is all an extension takes.
+type LifecycleManager struct {
+	auctionRepositoryInterface auction_entity.AuctionRepositoryInterface
+	policy                     ExtensionPolicy
+	cascade                    *SeriesCascade
+
+	recentBids      map[string][]time.Time
+	recentBidsMutex *sync.Mutex
+	lastSweep       time.Time
+}
+
+// NewLifecycleManager returns a manager that never extends auctions when
+// policy is nil. Extended lots of cascaded series push back the lots after
+// them unless cascade is nil.
+func NewLifecycleManager(
+	auctionRepositoryInterface auction_entity.AuctionRepositoryInterface,
+	policy ExtensionPolicy,
+	cascade *SeriesCascade) *LifecycleManager {
+	return &LifecycleManager{
+		auctionRepositoryInterface: auctionRepositoryInterface,
+		policy:                     policy,
+		cascade:                    cascade,
+		recentBids:                 make(map[string][]time.Time),
+		recentBidsMutex:            &sync.Mutex{},
+	}
+}
+
+// OnBidAccepted extends the auction the bid was accepted for when the
+// policy asks for it and returns its end, by which the bid has to reach
+// storage.
+func (lm *LifecycleManager) OnBidAccepted(
+	ctx context.Context, auction *auction_entity.Auction, bid bid_entity.Bid) time.Time {
+	if lm == nil || lm.policy == nil {
+		return auction.EndsAt
+	}
+
+	recentBids := lm.recordBid(bid)
+
+	extension := lm.policy.Extension(auction.EndsAt, bid.Timestamp, recentBids)
+	if extension <= 0 {
+		return auction.EndsAt
+	}
+
+	endsAt := bid.Timestamp.Add(extension)
+	if !endsAt.After(auction.EndsAt) {
+		return auction.EndsAt
+	}
+
+	if err := lm.auctionRepositoryInterface.ExtendAuction(ctx, auction.Id, endsAt); err != nil {
+		logger.Error("error trying to extend auction", err)
+		return auction.EndsAt
+	}
+
+	if err := lm.cascade.OnAuctionExtended(ctx, auction, endsAt); err != nil {
+		logger.Error("error trying to push back series lots", err)
+	}
+
+	return endsAt
+}
+
+// recordBid keeps the bid times of the last recentBidsHorizon per auction
+// for velocity based policies, sweeping auctions without recent bids once
+// per horizon.
+func (lm *LifecycleManager) recordBid(bid bid_entity.Bid) []time.Time {
+	lm.recentBidsMutex.Lock()
+	defer lm.recentBidsMutex.Unlock()
+
+	horizon := bid.Timestamp.Add(-recentBidsHorizon)
+
+	if lm.lastSweep.Before(horizon) {
+		for auctionId, bids := range lm.recentBids {
+			if bids[len(bids)-1].Before(horizon) {
+				delete(lm.recentBids, auctionId)
+			}
+		}
+		lm.lastSweep = bid.Timestamp
+	}
+
+	var recentBids []time.Time
+	for _, recentBid := range lm.recentBids[bid.AuctionId] {
+		if !recentBid.Before(horizon) {
+			recentBids = append(recentBids, recentBid)
+		}
+	}
+	recentBids = append(recentBids, bid.Timestamp)
+	lm.recentBids[bid.AuctionId] = recentBids
+
+	return append([]time.Time(nil), recentBids...)
+}
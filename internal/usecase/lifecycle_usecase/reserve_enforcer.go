@@ -0,0 +1,78 @@
+package lifecycle_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/entity/event_entity"
+)
+
+// completionEventsBuffer is how many events the enforcer buffers while it
+// checks the reserve of completed auctions.
+const completionEventsBuffer = 256
+
+// Subscriber hands out the events published in the process.
+type Subscriber interface {
+	Subscribe(buffer int) (<-chan event_entity.Event, func())
+}
+
+// ReserveEnforcer closes the auctions completing with their highest bid
+// below the reserve price, or with no bid at all, as ClosedNoSale.
+type ReserveEnforcer struct {
+	auctionRepositoryInterface auction_entity.AuctionRepositoryInterface
+	bidRepositoryInterface     bid_entity.BidEntityRepository
+}
+
+// NewReserveEnforcer also starts the routine checking auctions as they
+// complete.
+func NewReserveEnforcer(
+	auctionRepositoryInterface auction_entity.AuctionRepositoryInterface,
+	bidRepositoryInterface bid_entity.BidEntityRepository,
+	subscriber Subscriber) *ReserveEnforcer {
+	reserveEnforcer := &ReserveEnforcer{
+		auctionRepositoryInterface: auctionRepositoryInterface,
+		bidRepositoryInterface:     bidRepositoryInterface,
+	}
+
+	events, _ := subscriber.Subscribe(completionEventsBuffer)
+	go func() {
+		for event := range events {
+			if event.Type == event_entity.AuctionCompleted {
+				reserveEnforcer.enforce(context.Background(), event.AuctionId)
+			}
+		}
+	}()
+
+	return reserveEnforcer
+}
+
+func (re *ReserveEnforcer) enforce(ctx context.Context, auctionId string) {
+	auction, err := re.auctionRepositoryInterface.FindAuctionById(ctx, auctionId)
+	if err != nil {
+		logger.Error("error trying to find the completed auction", err)
+		return
+	}
+	if auction.Status != auction_entity.Completed || auction.ReservePrice <= 0 {
+		return
+	}
+
+	var highest float64
+	winningBid, err := re.bidRepositoryInterface.FindWinningBidByAuctionId(ctx, auctionId)
+	if err != nil && err.Err != "not_found" {
+		logger.Error("error trying to find the winning bid", err)
+		return
+	}
+	if winningBid != nil {
+		highest = winningBid.Amount
+	}
+
+	if auction.ReserveMet(highest) {
+		return
+	}
+
+	if err := re.auctionRepositoryInterface.UpdateAuctionStatus(
+		ctx, auctionId, auction_entity.Completed, auction_entity.ClosedNoSale); err != nil {
+		logger.Error("error trying to close the auction without a sale", err)
+	}
+}
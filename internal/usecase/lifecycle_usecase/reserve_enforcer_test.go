@@ -0,0 +1,69 @@
+package lifecycle_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type stubReserveAuctionRepository struct {
+	auction_entity.AuctionRepositoryInterface
+	auction *auction_entity.Auction
+}
+
+func (s *stubReserveAuctionRepository) FindAuctionById(
+	ctx context.Context, id string) (*auction_entity.Auction, *internal_error.InternalError) {
+	auction := *s.auction
+	return &auction, nil
+}
+
+func (s *stubReserveAuctionRepository) UpdateAuctionStatus(
+	ctx context.Context, auctionId string, expected, status auction_entity.AuctionStatus) *internal_error.InternalError {
+	s.auction.Status = status
+	return nil
+}
+
+type stubWinningBidRepository struct {
+	bid_entity.BidEntityRepository
+	winningBid *bid_entity.Bid
+}
+
+func (s stubWinningBidRepository) FindWinningBidByAuctionId(
+	ctx context.Context, auctionId string) (*bid_entity.Bid, *internal_error.InternalError) {
+	if s.winningBid == nil {
+		return nil, internal_error.NewNotFoundError("no bids")
+	}
+	return s.winningBid, nil
+}
+
+func TestReserveEnforcerEnforce(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		reservePrice float64
+		winningBid   *bid_entity.Bid
+		expected     auction_entity.AuctionStatus
+	}{
+		{name: "no reserve", winningBid: &bid_entity.Bid{Amount: 10}, expected: auction_entity.Completed},
+		{name: "reserve met", reservePrice: 100, winningBid: &bid_entity.Bid{Amount: 100}, expected: auction_entity.Completed},
+		{name: "below reserve", reservePrice: 100, winningBid: &bid_entity.Bid{Amount: 99}, expected: auction_entity.ClosedNoSale},
+		{name: "no bids", reservePrice: 100, expected: auction_entity.ClosedNoSale},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			auctions := &stubReserveAuctionRepository{auction: &auction_entity.Auction{
+				Id: "auction", Status: auction_entity.Completed, ReservePrice: tc.reservePrice,
+			}}
+			enforcer := &ReserveEnforcer{
+				auctionRepositoryInterface: auctions,
+				bidRepositoryInterface:     stubWinningBidRepository{winningBid: tc.winningBid},
+			}
+
+			enforcer.enforce(context.Background(), "auction")
+
+			assert.Equal(t, tc.expected, auctions.auction.Status)
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package lifecycle_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// SeriesCascade keeps the lots of a cascaded series closing in lot order:
+// when a lot is extended, the lots after it are pushed back so each still
+// ends the series interval after the one before it.
+type SeriesCascade struct {
+	seriesRepositoryInterface  auction_entity.SeriesRepositoryInterface
+	auctionRepositoryInterface auction_entity.AuctionRepositoryInterface
+}
+
+func NewSeriesCascade(
+	seriesRepositoryInterface auction_entity.SeriesRepositoryInterface,
+	auctionRepositoryInterface auction_entity.AuctionRepositoryInterface) *SeriesCascade {
+	return &SeriesCascade{
+		seriesRepositoryInterface:  seriesRepositoryInterface,
+		auctionRepositoryInterface: auctionRepositoryInterface,
+	}
+}
+
+// OnAuctionExtended pushes back the lots after the auction, now ending at
+// endsAt, when its series is cascaded.
+func (sc *SeriesCascade) OnAuctionExtended(
+	ctx context.Context, auction *auction_entity.Auction, endsAt time.Time) *internal_error.InternalError {
+	if sc == nil || auction.SeriesId == "" {
+		return nil
+	}
+
+	series, err := sc.seriesRepositoryInterface.FindSeriesById(ctx, auction.SeriesId)
+	if err != nil {
+		return err
+	}
+	if series.CascadeInterval <= 0 {
+		return nil
+	}
+
+	lots, err := sc.auctionRepositoryInterface.FindSeriesAuctions(ctx, auction.SeriesId)
+	if err != nil {
+		return err
+	}
+
+	for _, pushback := range cascadePushbacks(lots, auction.LotNumber, endsAt, series.CascadeInterval) {
+		if err := sc.auctionRepositoryInterface.ExtendAuction(ctx, pushback.Id, pushback.EndsAt); err != nil {
+			return err
+		}
+
+		logger.Info("Series lot pushed back",
+			zap.String("auction_id", pushback.Id),
+			zap.Time("ends_at", pushback.EndsAt))
+	}
+
+	return nil
+}
+
+// cascadePushbacks returns the active lots after lotNumber, in lot order,
+// that end less than interval after the lot before them, with the end they
+// are pushed back to. The lot lotNumber is taken to end at endsAt.
+func cascadePushbacks(
+	lots []auction_entity.Auction,
+	lotNumber int,
+	endsAt time.Time,
+	interval time.Duration) []auction_entity.Auction {
+	var pushbacks []auction_entity.Auction
+
+	previousEndsAt := endsAt
+	for _, lot := range lots {
+		if lot.LotNumber <= lotNumber || lot.Status != auction_entity.Active {
+			continue
+		}
+
+		if earliest := previousEndsAt.Add(interval); lot.EndsAt.Before(earliest) {
+			lot.EndsAt = earliest
+			pushbacks = append(pushbacks, lot)
+		}
+		previousEndsAt = lot.EndsAt
+	}
+
+	return pushbacks
+}
@@ -0,0 +1,33 @@
+package lifecycle_usecase
+
+import (
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCascadePushbacks(t *testing.T) {
+	start := time.Date(2026, 4, 1, 20, 0, 0, 0, time.UTC)
+	lots := []auction_entity.Auction{
+		{Id: "lot1", LotNumber: 1, EndsAt: start},
+		{Id: "lot2", LotNumber: 2, EndsAt: start.Add(2 * time.Minute)},
+		{Id: "lot3", LotNumber: 3, EndsAt: start.Add(4 * time.Minute), Status: auction_entity.Completed},
+		{Id: "lot4", LotNumber: 4, EndsAt: start.Add(6 * time.Minute)},
+		{Id: "lot5", LotNumber: 5, EndsAt: start.Add(20 * time.Minute)},
+	}
+
+	// Lot 1 extended by 3 minutes pushes lot 2 and, through it, lot 4. The
+	// completed lot 3 is skipped and lot 5 already ends late enough.
+	pushbacks := cascadePushbacks(lots, 1, start.Add(3*time.Minute), 2*time.Minute)
+
+	var ends []time.Time
+	for _, pushback := range pushbacks {
+		ends = append(ends, pushback.EndsAt)
+	}
+	assert.Equal(t, []string{"lot2", "lot4"}, []string{pushbacks[0].Id, pushbacks[1].Id})
+	assert.Equal(t, []time.Time{start.Add(5 * time.Minute), start.Add(7 * time.Minute)}, ends)
+
+	assert.Empty(t, cascadePushbacks(lots, 1, start, 2*time.Minute))
+}
@@ -0,0 +1,194 @@
+package live_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/clock"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/entity/event_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"fullcycle-auction_go/internal/usecase/auction_usecase"
+	"time"
+)
+
+// AuctionSnapshot is the first message of a feed, with the state of the
+// auction when it was opened.
+const AuctionSnapshot event_entity.Type = "auction.snapshot"
+
+const feedBuffer = 64
+
+type MessageDTO struct {
+	Type      event_entity.Type      `json:"type"`
+	AuctionId string                 `json:"auction_id"`
+	Payload   map[string]interface{} `json:"payload,omitempty"`
+	Timestamp time.Time              `json:"timestamp"`
+}
+
+// Subscriber hands out the events of a single auction.
+type Subscriber interface {
+	Subscribe(auctionId string, buffer int) (<-chan event_entity.Event, func())
+}
+
+type LiveUseCaseInterface interface {
+	FollowAuction(ctx context.Context, auctionId string) (*Feed, *internal_error.InternalError)
+}
+
+type LiveUseCase struct {
+	auctionRepositoryInterface auction_entity.AuctionRepositoryInterface
+	bidRepositoryInterface     bid_entity.BidEntityRepository
+	subscriber                 Subscriber
+}
+
+func NewLiveUseCase(
+	auctionRepositoryInterface auction_entity.AuctionRepositoryInterface,
+	bidRepositoryInterface bid_entity.BidEntityRepository,
+	subscriber Subscriber) LiveUseCaseInterface {
+	return &LiveUseCase{
+		auctionRepositoryInterface: auctionRepositoryInterface,
+		bidRepositoryInterface:     bidRepositoryInterface,
+		subscriber:                 subscriber,
+	}
+}
+
+// Feed follows one auction. Events must be read until Stop is called,
+// turning each into messages with Translate.
+type Feed struct {
+	Snapshot MessageDTO
+	Events   <-chan event_entity.Event
+	Stop     func()
+
+	visibility   auction_entity.BidderVisibility
+	reservePrice float64
+	// sealed feeds keep bid amounts to themselves until the completion.
+	sealed     bool
+	highestBid map[string]interface{}
+	highest    float64
+}
+
+// FollowAuction subscribes before reading the auction, so no bid stored in
+// between is missed; one may show up twice, which Translate tolerates.
+func (lu *LiveUseCase) FollowAuction(
+	ctx context.Context, auctionId string) (*Feed, *internal_error.InternalError) {
+	events, stop := lu.subscriber.Subscribe(auctionId, feedBuffer)
+
+	auction, err := lu.auctionRepositoryInterface.FindAuctionById(ctx, auctionId)
+	if err != nil {
+		stop()
+		return nil, err
+	}
+
+	feed := &Feed{
+		Events:       events,
+		Stop:         stop,
+		visibility:   auction.BidderVisibility,
+		reservePrice: auction.ReservePrice,
+		sealed:       auction.BidsHidden(),
+	}
+
+	winningBid, err := lu.bidRepositoryInterface.FindWinningBidByAuctionId(ctx, auctionId)
+	if err != nil && err.Err != "not_found" {
+		stop()
+		return nil, err
+	}
+	if winningBid != nil {
+		feed.highest = winningBid.Amount
+		feed.highestBid = feed.presentBid(winningBid.Id, winningBid.UserId, winningBid.Amount)
+	}
+
+	highestBid := feed.highestBid
+	if feed.sealed {
+		highestBid = nil
+	}
+
+	feed.Snapshot = MessageDTO{
+		Type:      AuctionSnapshot,
+		AuctionId: auctionId,
+		Payload: map[string]interface{}{
+			"status":        auction_usecase.AuctionStatus(auction.Status),
+			"ends_at":       auction.EndsAt,
+			"highest_bid":   highestBid,
+			"min_increment": auction.MinIncrement,
+		},
+		Timestamp: clock.Now(),
+	}
+
+	return feed, nil
+}
+
+// Translate turns an event into the messages sent to the client: a placed
+// bid is followed by the new highest bid when it beats the previous one,
+// and the completion carries the winning bid, if any and at or above the
+// reserve price. A retracted bid is followed by the highest bid left.
+func (f *Feed) Translate(event event_entity.Event) []MessageDTO {
+	switch event.Type {
+	case event_entity.BidPlaced:
+		amount, _ := event.Payload["amount"].(float64)
+		bidId, _ := event.Payload["bid_id"].(string)
+		userId, _ := event.Payload["user_id"].(string)
+
+		bid := f.presentBid(bidId, userId, amount)
+		bid["source"] = event.Payload["source"]
+		bid["timestamp"] = event.Payload["timestamp"]
+		if f.sealed {
+			delete(bid, "amount")
+		}
+		messages := []MessageDTO{f.message(event, bid)}
+
+		if f.highestBid == nil || amount > f.highest {
+			f.highest = amount
+			f.highestBid = f.presentBid(bidId, userId, amount)
+			if f.sealed {
+				return messages
+			}
+			messages = append(messages, f.message(
+				event_entity.NewEvent(event_entity.AuctionHighestBid, event.AuctionId, nil), f.highestBid))
+		}
+		return messages
+	case event_entity.BidRetracted:
+		bidId, _ := event.Payload["bid_id"].(string)
+		userId, _ := event.Payload["user_id"].(string)
+		amount, _ := event.Payload["amount"].(float64)
+
+		bid := f.presentBid(bidId, userId, amount)
+		if f.sealed {
+			delete(bid, "amount")
+		}
+		messages := []MessageDTO{f.message(event, bid)}
+
+		f.highest, f.highestBid = 0, nil
+		if highestId, ok := event.Payload["highest_bid_id"].(string); ok {
+			highestUserId, _ := event.Payload["highest_user_id"].(string)
+			f.highest, _ = event.Payload["highest_amount"].(float64)
+			f.highestBid = f.presentBid(highestId, highestUserId, f.highest)
+		}
+		if f.sealed {
+			return messages
+		}
+		return append(messages, f.message(
+			event_entity.NewEvent(event_entity.AuctionHighestBid, event.AuctionId, nil), f.highestBid))
+	case event_entity.AuctionCompleted:
+		if f.reservePrice > 0 && f.highest < f.reservePrice {
+			return []MessageDTO{f.message(event, map[string]interface{}{"winning_bid": nil, "reserve_not_met": true})}
+		}
+		return []MessageDTO{f.message(event, map[string]interface{}{"winning_bid": f.highestBid})}
+	default:
+		return []MessageDTO{f.message(event, event.Payload)}
+	}
+}
+
+func (f *Feed) presentBid(bidId, userId string, amount float64) map[string]interface{} {
+	return map[string]interface{}{
+		"bid_id":  bidId,
+		"user_id": f.visibility.Present(userId),
+		"amount":  amount,
+	}
+}
+
+func (f *Feed) message(event event_entity.Event, payload map[string]interface{}) MessageDTO {
+	return MessageDTO{
+		Type:      event.Type,
+		AuctionId: event.AuctionId,
+		Payload:   payload,
+		Timestamp: event.Timestamp,
+	}
+}
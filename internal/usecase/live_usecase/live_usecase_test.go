@@ -0,0 +1,61 @@
+package live_usecase
+
+import (
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/entity/event_entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeedTranslate(t *testing.T) {
+	feed := &Feed{visibility: auction_entity.BidderAnonymous}
+	bid := func(id string, amount float64) event_entity.Event {
+		return (&bid_entity.Bid{Id: id, UserId: "alice", AuctionId: "auction", Amount: amount}).PlacedEvent()
+	}
+
+	messages := feed.Translate(bid("first", 10))
+	assert.Len(t, messages, 2)
+	assert.Equal(t, event_entity.BidPlaced, messages[0].Type)
+	assert.Equal(t, "", messages[0].Payload["user_id"])
+	assert.Equal(t, event_entity.AuctionHighestBid, messages[1].Type)
+	assert.Equal(t, 10.0, messages[1].Payload["amount"])
+
+	// A lower bid stored late does not take the lead.
+	assert.Len(t, feed.Translate(bid("late", 8)), 1)
+
+	messages = feed.Translate(event_entity.NewEvent(event_entity.AuctionCompleted, "auction", nil))
+	assert.Len(t, messages, 1)
+	assert.Equal(t, "first", messages[0].Payload["winning_bid"].(map[string]interface{})["bid_id"])
+}
+
+func TestSealedFeedTranslate(t *testing.T) {
+	feed := &Feed{visibility: auction_entity.BidderPublic, sealed: true}
+	bid := (&bid_entity.Bid{Id: "first", UserId: "alice", AuctionId: "auction", Amount: 10}).PlacedEvent()
+
+	messages := feed.Translate(bid)
+	assert.Len(t, messages, 1)
+	assert.NotContains(t, messages[0].Payload, "amount")
+
+	// The winner is revealed on completion.
+	messages = feed.Translate(event_entity.NewEvent(event_entity.AuctionCompleted, "auction", nil))
+	assert.Equal(t, 10.0, messages[0].Payload["winning_bid"].(map[string]interface{})["amount"])
+}
+
+func TestFeedTranslate_Retraction(t *testing.T) {
+	feed := &Feed{visibility: auction_entity.BidderPublic}
+	first := &bid_entity.Bid{Id: "first", UserId: "alice", AuctionId: "auction", Amount: 10}
+	second := &bid_entity.Bid{Id: "second", UserId: "bob", AuctionId: "auction", Amount: 12}
+	feed.Translate(first.PlacedEvent())
+	feed.Translate(second.PlacedEvent())
+
+	messages := feed.Translate(second.RetractedEvent(first))
+	assert.Len(t, messages, 2)
+	assert.Equal(t, event_entity.BidRetracted, messages[0].Type)
+	assert.Equal(t, "first", messages[1].Payload["bid_id"])
+	assert.Equal(t, 10.0, messages[1].Payload["amount"])
+
+	// A bid above the one left leading takes the lead again.
+	assert.Len(t, feed.Translate(second.PlacedEvent()), 2)
+}
@@ -0,0 +1,152 @@
+package notification_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/clock"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/notification_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type MarkNotificationsInputDTO struct {
+	// Ids are the notifications to mark as read, every unread one when
+	// empty.
+	Ids []string `json:"ids" binding:"dive,uuid"`
+}
+
+type MarkNotificationsOutputDTO struct {
+	Marked int64 `json:"marked"`
+}
+
+type NotificationOutputDTO struct {
+	Id         string     `json:"id"`
+	AuctionId  string     `json:"auction_id"`
+	Kind       string     `json:"kind"`
+	Message    string     `json:"message"`
+	Read       bool       `json:"read"`
+	ReadAt     *time.Time `json:"read_at,omitempty" time_format:"2006-01-02 15:04:05"`
+	ArchivedAt *time.Time `json:"archived_at,omitempty" time_format:"2006-01-02 15:04:05"`
+	Timestamp  time.Time  `json:"timestamp" time_format:"2006-01-02 15:04:05"`
+}
+
+type NotificationUseCaseInterface interface {
+	// FindNotifications lists the inbox of the user, newest first, or the
+	// notifications archived from it.
+	FindNotifications(
+		ctx context.Context, userId string, archived bool) ([]NotificationOutputDTO, *internal_error.InternalError)
+
+	MarkNotificationsRead(
+		ctx context.Context,
+		userId string,
+		markInput MarkNotificationsInputDTO) (*MarkNotificationsOutputDTO, *internal_error.InternalError)
+}
+
+type NotificationUseCase struct {
+	notificationRepositoryInterface notification_entity.NotificationRepositoryInterface
+	archiveAfter                    time.Duration
+}
+
+// NewNotificationUseCase also starts the routine archiving, every
+// NOTIFICATION_ARCHIVE_INTERVAL, the notifications older than
+// NOTIFICATION_ARCHIVE_AFTER.
+func NewNotificationUseCase(
+	notificationRepositoryInterface notification_entity.NotificationRepositoryInterface) NotificationUseCaseInterface {
+	notificationUseCase := &NotificationUseCase{
+		notificationRepositoryInterface: notificationRepositoryInterface,
+		archiveAfter:                    getDuration("NOTIFICATION_ARCHIVE_AFTER", 30*24*time.Hour),
+	}
+
+	notificationUseCase.triggerArchiveRoutine(context.Background())
+
+	return notificationUseCase
+}
+
+func (nu *NotificationUseCase) FindNotifications(
+	ctx context.Context, userId string, archived bool) ([]NotificationOutputDTO, *internal_error.InternalError) {
+	notifications, err := nu.notificationRepositoryInterface.FindNotifications(ctx, userId, archived)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs := make([]NotificationOutputDTO, 0, len(notifications))
+	for _, notification := range notifications {
+		outputs = append(outputs, toNotificationOutputDTO(notification))
+	}
+
+	return outputs, nil
+}
+
+func (nu *NotificationUseCase) MarkNotificationsRead(
+	ctx context.Context,
+	userId string,
+	markInput MarkNotificationsInputDTO) (*MarkNotificationsOutputDTO, *internal_error.InternalError) {
+	marked, err := nu.notificationRepositoryInterface.MarkNotificationsRead(ctx, userId, markInput.Ids, clock.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return &MarkNotificationsOutputDTO{Marked: marked}, nil
+}
+
+// archive moves the notifications older than archiveAfter out of the inbox,
+// read or not.
+func (nu *NotificationUseCase) archive(ctx context.Context) {
+	now := clock.Now()
+
+	archived, err := nu.notificationRepositoryInterface.ArchiveNotifications(ctx, now.Add(-nu.archiveAfter), now)
+	if err != nil {
+		logger.Error("error trying to archive notifications", err)
+		return
+	}
+
+	if archived > 0 {
+		logger.Info("Notifications archived", zap.Int64("archived", archived))
+	}
+}
+
+func (nu *NotificationUseCase) triggerArchiveRoutine(ctx context.Context) {
+	interval := getDuration("NOTIFICATION_ARCHIVE_INTERVAL", time.Hour)
+	if nu.archiveAfter <= 0 || interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(clock.Scale(interval))
+		defer ticker.Stop()
+
+		for range ticker.C {
+			nu.archive(ctx)
+		}
+	}()
+}
+
+func toNotificationOutputDTO(notification notification_entity.Notification) NotificationOutputDTO {
+	output := NotificationOutputDTO{
+		Id:        notification.Id,
+		AuctionId: notification.AuctionId,
+		Kind:      string(notification.Kind),
+		Message:   notification.Message,
+		Read:      notification.Read(),
+		Timestamp: notification.Timestamp,
+	}
+	if notification.Read() {
+		output.ReadAt = &notification.ReadAt
+	}
+	if notification.Archived() {
+		output.ArchivedAt = &notification.ArchivedAt
+	}
+
+	return output
+}
+
+func getDuration(name string, fallback time.Duration) time.Duration {
+	duration, err := time.ParseDuration(os.Getenv(name))
+	if err != nil || duration < 0 {
+		return fallback
+	}
+	return duration
+}
@@ -0,0 +1,49 @@
+package notification_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/notification_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type stubNotificationRepository struct {
+	notification_entity.NotificationRepositoryInterface
+	cutoff, archivedAt time.Time
+}
+
+func (s *stubNotificationRepository) ArchiveNotifications(
+	ctx context.Context, cutoff, archivedAt time.Time) (int64, *internal_error.InternalError) {
+	s.cutoff, s.archivedAt = cutoff, archivedAt
+	return 1, nil
+}
+
+func TestNotificationUseCase_ArchivesPastArchiveAfter(t *testing.T) {
+	repository := &stubNotificationRepository{}
+	notificationUseCase := &NotificationUseCase{
+		notificationRepositoryInterface: repository,
+		archiveAfter:                    7 * 24 * time.Hour,
+	}
+
+	notificationUseCase.archive(context.Background())
+
+	assert.Equal(t, 7*24*time.Hour, repository.archivedAt.Sub(repository.cutoff))
+}
+
+func TestToNotificationOutputDTO(t *testing.T) {
+	notification := notification_entity.NewNotification(
+		"user", "auction", notification_entity.OfferReceived, "You received an offer")
+
+	output := toNotificationOutputDTO(notification)
+	assert.False(t, output.Read)
+	assert.Nil(t, output.ReadAt)
+	assert.Nil(t, output.ArchivedAt)
+
+	notification.ReadAt = notification.Timestamp.Add(time.Minute)
+	output = toNotificationOutputDTO(notification)
+	assert.True(t, output.Read)
+	assert.Equal(t, notification.ReadAt, *output.ReadAt)
+}
@@ -0,0 +1,486 @@
+package offer_usecase
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/configuration/clock"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/entity/notification_entity"
+	"fullcycle-auction_go/internal/entity/offer_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"os"
+	"sort"
+	"time"
+)
+
+type OfferInputDTO struct {
+	UserIds []string `json:"user_ids" binding:"required,min=1,max=20,dive,uuid"`
+	// ExpiresInHours overrides SECOND_CHANCE_OFFER_TTL for these offers.
+	ExpiresInHours int `json:"expires_in_hours" binding:"omitempty,min=1,max=168"`
+}
+
+type RunnerUpOfferInputDTO struct {
+	// ExpiresInHours overrides SECOND_CHANCE_OFFER_TTL for the offer.
+	ExpiresInHours int `json:"expires_in_hours" binding:"omitempty,min=1,max=168"`
+}
+
+type OfferOutputDTO struct {
+	Id          string     `json:"id"`
+	AuctionId   string     `json:"auction_id"`
+	SellerId    string     `json:"seller_id"`
+	UserId      string     `json:"user_id"`
+	Amount      float64    `json:"amount"`
+	Status      string     `json:"status"`
+	ExpiresAt   time.Time  `json:"expires_at" time_format:"2006-01-02 15:04:05"`
+	RespondedAt *time.Time `json:"responded_at,omitempty" time_format:"2006-01-02 15:04:05"`
+	Timestamp   time.Time  `json:"timestamp" time_format:"2006-01-02 15:04:05"`
+}
+
+// BidderRankOutputDTO places a bidder of an auction by their highest bid,
+// with the status of the last offer made to them, if any.
+type BidderRankOutputDTO struct {
+	Rank        int       `json:"rank"`
+	UserId      string    `json:"user_id"`
+	HighestBid  float64   `json:"highest_bid"`
+	BidAt       time.Time `json:"bid_at" time_format:"2006-01-02 15:04:05"`
+	Winner      bool      `json:"winner"`
+	OfferStatus string    `json:"offer_status,omitempty"`
+}
+
+type OfferUseCaseInterface interface {
+	CreateOffers(
+		ctx context.Context, auctionId, sellerId string, offerInput OfferInputDTO) ([]OfferOutputDTO, *internal_error.InternalError)
+
+	// OfferRunnerUp makes a second-chance offer to the highest bidder after
+	// the winner who was not made one yet, for when the winner fails to pay
+	// or the seller wants another sale.
+	OfferRunnerUp(
+		ctx context.Context, auctionId, sellerId string, runnerUpInput RunnerUpOfferInputDTO) (*OfferOutputDTO, *internal_error.InternalError)
+
+	// FindBidderRanking lists the bidders of the auction by their highest
+	// bid, the order runner-up offers follow.
+	FindBidderRanking(
+		ctx context.Context, auctionId, sellerId string) ([]BidderRankOutputDTO, *internal_error.InternalError)
+
+	FindOffers(
+		ctx context.Context, auctionId, sellerId string) ([]OfferOutputDTO, *internal_error.InternalError)
+
+	AcceptOffer(
+		ctx context.Context, offerId, userId string) (*OfferOutputDTO, *internal_error.InternalError)
+
+	DeclineOffer(
+		ctx context.Context, offerId, userId string) (*OfferOutputDTO, *internal_error.InternalError)
+}
+
+type OfferUseCase struct {
+	offerRepositoryInterface   offer_entity.OfferRepositoryInterface
+	auctionRepositoryInterface auction_entity.AuctionRepositoryInterface
+	bidRepositoryInterface     bid_entity.BidEntityRepository
+	notifier                   notification_entity.Notifier
+	offerTTL                   time.Duration
+}
+
+func NewOfferUseCase(
+	offerRepositoryInterface offer_entity.OfferRepositoryInterface,
+	auctionRepositoryInterface auction_entity.AuctionRepositoryInterface,
+	bidRepositoryInterface bid_entity.BidEntityRepository,
+	notifier notification_entity.Notifier) OfferUseCaseInterface {
+	return &OfferUseCase{
+		offerRepositoryInterface:   offerRepositoryInterface,
+		auctionRepositoryInterface: auctionRepositoryInterface,
+		bidRepositoryInterface:     bidRepositoryInterface,
+		notifier:                   notifier,
+		offerTTL:                   getOfferTTL(),
+	}
+}
+
+// CreateOffers sends second-chance offers from the seller of a completed
+// auction to the given losing bidders, each at the amount of their highest
+// bid. Nothing is sent unless every bidder can receive an offer.
+func (ou *OfferUseCase) CreateOffers(
+	ctx context.Context, auctionId, sellerId string, offerInput OfferInputDTO) ([]OfferOutputDTO, *internal_error.InternalError) {
+	auction, err := ou.findCompletedAuction(ctx, auctionId, sellerId)
+	if err != nil {
+		return nil, err
+	}
+
+	bids, err := ou.bidRepositoryInterface.FindBidByAuctionId(ctx, auctionId)
+	if err != nil {
+		return nil, err
+	}
+
+	winnerId := winningBidder(auction, bids)
+	highestBids := make(map[string]float64)
+	for _, bid := range bids {
+		if bid.Amount > highestBids[bid.UserId] {
+			highestBids[bid.UserId] = bid.Amount
+		}
+	}
+
+	existing, err := ou.offerRepositoryInterface.FindOffers(ctx, auctionId)
+	if err != nil {
+		return nil, err
+	}
+
+	now := clock.Now()
+	open := make(map[string]bool)
+	for _, offer := range existing {
+		if status := offer.CurrentStatus(now); status == offer_entity.Pending || status == offer_entity.Accepted {
+			open[offer.UserId] = true
+		}
+	}
+
+	expiresIn := ou.expiresIn(offerInput.ExpiresInHours)
+
+	var offers []*offer_entity.Offer
+	seen := make(map[string]bool)
+	for _, userId := range offerInput.UserIds {
+		if seen[userId] {
+			continue
+		}
+		seen[userId] = true
+
+		amount, ok := highestBids[userId]
+		switch {
+		case !ok:
+			return nil, internal_error.NewBadRequestError(
+				fmt.Sprintf("User %s did not bid on this auction", userId))
+		case userId == winnerId:
+			return nil, internal_error.NewBadRequestError(
+				fmt.Sprintf("User %s won this auction", userId))
+		case open[userId]:
+			return nil, internal_error.NewBadRequestError(
+				fmt.Sprintf("User %s already has an open offer on this auction", userId))
+		}
+
+		offer, err := offer_entity.CreateOffer(auctionId, auction.SellerId, userId, amount, expiresIn)
+		if err != nil {
+			return nil, err
+		}
+
+		offers = append(offers, offer)
+	}
+
+	offerOutputList := make([]OfferOutputDTO, 0, len(offers))
+	for _, offer := range offers {
+		if err := ou.sendOffer(ctx, auction, offer); err != nil {
+			return nil, err
+		}
+
+		offerOutputList = append(offerOutputList, toOfferOutputDTO(offer, now))
+	}
+
+	return offerOutputList, nil
+}
+
+// OfferRunnerUp waits for the open offer of the auction to be settled, so
+// the runner-ups are offered the lot one at a time, and stops once one of
+// them accepts. Bidders who declined
+// or let an offer expire are skipped, the next one in the ranking being
+// offered it instead.
+func (ou *OfferUseCase) OfferRunnerUp(
+	ctx context.Context,
+	auctionId, sellerId string,
+	runnerUpInput RunnerUpOfferInputDTO) (*OfferOutputDTO, *internal_error.InternalError) {
+	auction, err := ou.findCompletedAuction(ctx, auctionId, sellerId)
+	if err != nil {
+		return nil, err
+	}
+
+	ranking, err := ou.rankBidders(ctx, auction)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, bidder := range ranking {
+		switch offer_entity.OfferStatus(bidder.OfferStatus) {
+		case offer_entity.Pending:
+			return nil, internal_error.NewBadRequestError(
+				fmt.Sprintf("User %s has yet to answer their offer on this auction", bidder.UserId))
+		case offer_entity.Accepted:
+			return nil, internal_error.NewBadRequestError(
+				fmt.Sprintf("User %s already accepted an offer on this auction", bidder.UserId))
+		}
+	}
+
+	for _, bidder := range ranking {
+		if bidder.Winner || bidder.OfferStatus != "" {
+			continue
+		}
+
+		offer, err := offer_entity.CreateOffer(
+			auctionId, auction.SellerId, bidder.UserId, bidder.HighestBid, ou.expiresIn(runnerUpInput.ExpiresInHours))
+		if err != nil {
+			return nil, err
+		}
+
+		if err := ou.sendOffer(ctx, auction, offer); err != nil {
+			return nil, err
+		}
+
+		output := toOfferOutputDTO(offer, clock.Now())
+		return &output, nil
+	}
+
+	return nil, internal_error.NewBadRequestError("No runner-up bidder is left to make an offer to")
+}
+
+func (ou *OfferUseCase) FindBidderRanking(
+	ctx context.Context, auctionId, sellerId string) ([]BidderRankOutputDTO, *internal_error.InternalError) {
+	auction, err := ou.findSellerAuction(ctx, auctionId, sellerId)
+	if err != nil {
+		return nil, err
+	}
+
+	return ou.rankBidders(ctx, auction)
+}
+
+func (ou *OfferUseCase) rankBidders(
+	ctx context.Context, auction *auction_entity.Auction) ([]BidderRankOutputDTO, *internal_error.InternalError) {
+	bids, err := ou.bidRepositoryInterface.FindBidByAuctionId(ctx, auction.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	offers, err := ou.offerRepositoryInterface.FindOffers(ctx, auction.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Offers are listed oldest first, so the last one of each bidder wins.
+	now := clock.Now()
+	offerStatuses := make(map[string]offer_entity.OfferStatus)
+	for _, offer := range offers {
+		offerStatuses[offer.UserId] = offer.CurrentStatus(now)
+	}
+
+	ranking := rankBidders(bids)
+	winnerId := winningBidder(auction, bids)
+	if !auction.Status.Ended() {
+		winnerId = ""
+	}
+	for i := range ranking {
+		ranking[i].Winner = ranking[i].UserId == winnerId
+		ranking[i].OfferStatus = string(offerStatuses[ranking[i].UserId])
+	}
+
+	return ranking, nil
+}
+
+func (ou *OfferUseCase) sendOffer(
+	ctx context.Context, auction *auction_entity.Auction, offer *offer_entity.Offer) *internal_error.InternalError {
+	if err := ou.offerRepositoryInterface.CreateOffer(ctx, offer); err != nil {
+		return err
+	}
+
+	ou.notifier.Notify(ctx, notification_entity.NewNotification(
+		offer.UserId, auction.Id, notification_entity.OfferReceived,
+		fmt.Sprintf("The seller of %s offers it to you for %.2f until %s",
+			auction.ProductName, offer.Amount, offer.ExpiresAt.Format(time.RFC3339))))
+
+	return nil
+}
+
+func (ou *OfferUseCase) expiresIn(expiresInHours int) time.Duration {
+	if expiresInHours != 0 {
+		return time.Duration(expiresInHours) * time.Hour
+	}
+	return ou.offerTTL
+}
+
+// FindOffers lists the offers the seller made on the auction.
+func (ou *OfferUseCase) FindOffers(
+	ctx context.Context, auctionId, sellerId string) ([]OfferOutputDTO, *internal_error.InternalError) {
+	if _, err := ou.findSellerAuction(ctx, auctionId, sellerId); err != nil {
+		return nil, err
+	}
+
+	offers, err := ou.offerRepositoryInterface.FindOffers(ctx, auctionId)
+	if err != nil {
+		return nil, err
+	}
+
+	now := clock.Now()
+	offerOutputList := make([]OfferOutputDTO, 0, len(offers))
+	for _, offer := range offers {
+		offerOutputList = append(offerOutputList, toOfferOutputDTO(&offer, now))
+	}
+
+	return offerOutputList, nil
+}
+
+func (ou *OfferUseCase) AcceptOffer(
+	ctx context.Context, offerId, userId string) (*OfferOutputDTO, *internal_error.InternalError) {
+	return ou.respond(ctx, offerId, userId, offer_entity.Accepted)
+}
+
+func (ou *OfferUseCase) DeclineOffer(
+	ctx context.Context, offerId, userId string) (*OfferOutputDTO, *internal_error.InternalError) {
+	return ou.respond(ctx, offerId, userId, offer_entity.Declined)
+}
+
+// respond settles a pending offer for the bidder it was made to. Offers
+// found past their expiry are stored as expired instead.
+func (ou *OfferUseCase) respond(
+	ctx context.Context,
+	offerId, userId string,
+	status offer_entity.OfferStatus) (*OfferOutputDTO, *internal_error.InternalError) {
+	offer, err := ou.offerRepositoryInterface.FindOfferById(ctx, offerId)
+	if err != nil {
+		return nil, err
+	}
+
+	if offer.UserId != userId {
+		return nil, internal_error.NewForbiddenError("Only the bidder the offer was made to can respond to it")
+	}
+
+	now := clock.Now()
+	switch offer.CurrentStatus(now) {
+	case offer_entity.Pending:
+	case offer_entity.Expired:
+		if offer.Status == offer_entity.Pending {
+			ou.offerRepositoryInterface.UpdateOfferStatus(ctx, offer.Id, offer_entity.Expired, offer.ExpiresAt)
+		}
+		return nil, internal_error.NewBadRequestError("Offer has expired")
+	default:
+		return nil, internal_error.NewBadRequestError(
+			fmt.Sprintf("Offer was already %s", offer.Status))
+	}
+
+	if err := ou.offerRepositoryInterface.UpdateOfferStatus(ctx, offer.Id, status, now); err != nil {
+		return nil, err
+	}
+
+	offer.Status = status
+	offer.RespondedAt = now
+
+	// The answer goes to the current seller, who may have taken the auction
+	// over since the offer was made.
+	sellerId := offer.SellerId
+	if auction, err := ou.auctionRepositoryInterface.FindAuctionById(ctx, offer.AuctionId); err == nil {
+		sellerId = auction.SellerId
+	}
+
+	ou.notifier.Notify(ctx, notification_entity.NewNotification(
+		sellerId, offer.AuctionId, notification_entity.OfferAnswered,
+		fmt.Sprintf("Your second-chance offer of %.2f was %s", offer.Amount, status)))
+
+	output := toOfferOutputDTO(offer, now)
+	return &output, nil
+}
+
+func (ou *OfferUseCase) findCompletedAuction(
+	ctx context.Context, auctionId, sellerId string) (*auction_entity.Auction, *internal_error.InternalError) {
+	auction, err := ou.findSellerAuction(ctx, auctionId, sellerId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !auction.Status.Ended() || auction.Status == auction_entity.Cancelled {
+		return nil, internal_error.NewBadRequestError("Second-chance offers can only be made on completed auctions")
+	}
+	if auction.Units() > 1 {
+		return nil, internal_error.NewBadRequestError("Second-chance offers are only made on single-unit auctions")
+	}
+
+	return auction, nil
+}
+
+func (ou *OfferUseCase) findSellerAuction(
+	ctx context.Context, auctionId, sellerId string) (*auction_entity.Auction, *internal_error.InternalError) {
+	auction, err := ou.auctionRepositoryInterface.FindAuctionById(ctx, auctionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if auction.SellerId != sellerId {
+		return nil, internal_error.NewForbiddenError("Only the seller can manage offers on this auction")
+	}
+
+	return auction, nil
+}
+
+// winningBidder is the user holding the recorded winning bid, falling back
+// to the highest bid for auctions completed before winners were recorded.
+func winningBidder(auction *auction_entity.Auction, bids []bid_entity.Bid) string {
+	var highest *bid_entity.Bid
+	for i, bid := range bids {
+		if auction.WinnerBidId != "" && bid.Id == auction.WinnerBidId {
+			return bid.UserId
+		}
+
+		if highest == nil || bid.Amount > highest.Amount {
+			highest = &bids[i]
+		}
+	}
+
+	if highest == nil {
+		return ""
+	}
+
+	return highest.UserId
+}
+
+// rankBidders orders the bidders by their highest bid, the one who bid it
+// first ahead on a tie.
+func rankBidders(bids []bid_entity.Bid) []BidderRankOutputDTO {
+	highest := make(map[string]*BidderRankOutputDTO)
+	for _, bid := range bids {
+		bidder, ok := highest[bid.UserId]
+		switch {
+		case !ok:
+			highest[bid.UserId] = &BidderRankOutputDTO{UserId: bid.UserId, HighestBid: bid.Amount, BidAt: bid.Timestamp}
+		case bid.Amount > bidder.HighestBid,
+			bid.Amount == bidder.HighestBid && bid.Timestamp.Before(bidder.BidAt):
+			bidder.HighestBid, bidder.BidAt = bid.Amount, bid.Timestamp
+		}
+	}
+
+	ranking := make([]BidderRankOutputDTO, 0, len(highest))
+	for _, bidder := range highest {
+		ranking = append(ranking, *bidder)
+	}
+	sort.Slice(ranking, func(i, j int) bool {
+		if ranking[i].HighestBid != ranking[j].HighestBid {
+			return ranking[i].HighestBid > ranking[j].HighestBid
+		}
+		return ranking[i].BidAt.Before(ranking[j].BidAt)
+	})
+
+	for i := range ranking {
+		ranking[i].Rank = i + 1
+	}
+
+	return ranking
+}
+
+func toOfferOutputDTO(offer *offer_entity.Offer, now time.Time) OfferOutputDTO {
+	output := OfferOutputDTO{
+		Id:        offer.Id,
+		AuctionId: offer.AuctionId,
+		SellerId:  offer.SellerId,
+		UserId:    offer.UserId,
+		Amount:    offer.Amount,
+		Status:    string(offer.CurrentStatus(now)),
+		ExpiresAt: offer.ExpiresAt,
+		Timestamp: offer.Timestamp,
+	}
+	if !offer.RespondedAt.IsZero() {
+		respondedAt := offer.RespondedAt
+		output.RespondedAt = &respondedAt
+	}
+
+	return output
+}
+
+// getOfferTTL reads SECOND_CHANCE_OFFER_TTL, how long a bidder has to
+// answer a second-chance offer.
+func getOfferTTL() time.Duration {
+	duration, err := time.ParseDuration(os.Getenv("SECOND_CHANCE_OFFER_TTL"))
+	if err != nil || duration <= 0 {
+		return 48 * time.Hour
+	}
+
+	return duration
+}
@@ -0,0 +1,44 @@
+package offer_usecase
+
+import (
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWinningBidder(t *testing.T) {
+	bids := []bid_entity.Bid{
+		{Id: "b1", UserId: "u1", Amount: 10},
+		{Id: "b2", UserId: "u2", Amount: 30},
+		{Id: "b3", UserId: "u3", Amount: 20},
+	}
+
+	assert.Equal(t, "u2", winningBidder(&auction_entity.Auction{}, bids))
+	assert.Equal(t, "u3", winningBidder(&auction_entity.Auction{WinnerBidId: "b3"}, bids))
+	assert.Equal(t, "", winningBidder(&auction_entity.Auction{}, nil))
+}
+
+func TestRankBidders(t *testing.T) {
+	start := time.Now()
+	bids := []bid_entity.Bid{
+		{UserId: "u1", Amount: 10, Timestamp: start},
+		{UserId: "u2", Amount: 20, Timestamp: start.Add(time.Second)},
+		{UserId: "u1", Amount: 30, Timestamp: start.Add(2 * time.Second)},
+		{UserId: "u3", Amount: 20, Timestamp: start.Add(3 * time.Second)},
+		{UserId: "u2", Amount: 20, Timestamp: start.Add(4 * time.Second)},
+	}
+
+	ranking := rankBidders(bids)
+
+	// u2 bid 20 before u3 did, and keeps the time of its first 20.
+	assert.Len(t, ranking, 3)
+	assert.Equal(t, []string{"u1", "u2", "u3"},
+		[]string{ranking[0].UserId, ranking[1].UserId, ranking[2].UserId})
+	assert.Equal(t, []int{1, 2, 3}, []int{ranking[0].Rank, ranking[1].Rank, ranking[2].Rank})
+	assert.Equal(t, 30.0, ranking[0].HighestBid)
+	assert.Equal(t, start.Add(time.Second), ranking[1].BidAt)
+	assert.Empty(t, rankBidders(nil))
+}
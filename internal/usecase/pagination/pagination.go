@@ -0,0 +1,123 @@
+// Package pagination pages through listings sorted in memory, by offset or
+// by an opaque cursor naming the last item seen.
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fullcycle-auction_go/internal/internal_error"
+	"sort"
+)
+
+const (
+	DefaultLimit = 50
+	MaxLimit     = 200
+)
+
+// Params selects a page. A cursor continues after the item it was issued
+// for and excludes an offset. A zero limit returns every item.
+type Params struct {
+	Limit  int
+	Offset int
+	Cursor string
+}
+
+type Page[T any] struct {
+	Items []T `json:"items"`
+	// Total counts the items of every page.
+	Total int `json:"total"`
+	// Offset is the position of the first item, also when paging by cursor.
+	Offset     int    `json:"offset"`
+	Limit      int    `json:"limit,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// Key positions an item in its listing: by Value, descending in descending
+// orders, then by Id, so every item has its own position.
+type Key struct {
+	Value float64 `json:"v"`
+	Id    string  `json:"id"`
+}
+
+// Order sorts a listing by the key of its items. Name is carried by its
+// cursors, so a cursor issued for an order is refused by another.
+type Order[T any] struct {
+	Name       string
+	Key        func(T) Key
+	Descending bool
+}
+
+type cursor struct {
+	Order string `json:"o"`
+	Key
+}
+
+func (o Order[T]) before(a, b Key) bool {
+	if a.Value != b.Value {
+		return (a.Value < b.Value) != o.Descending
+	}
+	return a.Id < b.Id
+}
+
+// Paginate sorts the items in the order and returns the page selected.
+func Paginate[T any](items []T, order Order[T], params Params) (*Page[T], *internal_error.InternalError) {
+	sort.SliceStable(items, func(i, j int) bool {
+		return order.before(order.Key(items[i]), order.Key(items[j]))
+	})
+
+	start := params.Offset
+	if params.Cursor != "" {
+		after, err := decodeCursor(params.Cursor, order.Name)
+		if err != nil {
+			return nil, err
+		}
+		start = sort.Search(len(items), func(i int) bool {
+			return order.before(after, order.Key(items[i]))
+		})
+	}
+	if start > len(items) {
+		start = len(items)
+	}
+
+	end := len(items)
+	if params.Limit > 0 && start+params.Limit < end {
+		end = start + params.Limit
+	}
+
+	page := &Page[T]{
+		Items:  items[start:end],
+		Total:  len(items),
+		Offset: start,
+		Limit:  params.Limit,
+	}
+	if page.Items == nil {
+		page.Items = []T{}
+	}
+	if end < len(items) {
+		page.NextCursor = encodeCursor(order.Name, order.Key(items[end-1]))
+	}
+
+	return page, nil
+}
+
+func encodeCursor(order string, key Key) string {
+	value, _ := json.Marshal(cursor{Order: order, Key: key})
+	return base64.RawURLEncoding.EncodeToString(value)
+}
+
+func decodeCursor(value, order string) (Key, *internal_error.InternalError) {
+	var decoded cursor
+
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err == nil {
+		err = json.Unmarshal(raw, &decoded)
+	}
+	if err != nil {
+		return Key{}, internal_error.NewBadRequestError("Invalid cursor")
+	}
+	if decoded.Order != order {
+		return Key{}, internal_error.NewBadRequestError("The cursor was issued for another sort")
+	}
+
+	return decoded.Key, nil
+}
@@ -0,0 +1,71 @@
+package pagination
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type item struct {
+	id    string
+	price float64
+}
+
+var byPrice = Order[item]{
+	Name:       "price",
+	Key:        func(i item) Key { return Key{Value: i.price, Id: i.id} },
+	Descending: true,
+}
+
+func items() []item {
+	return []item{{"a", 10}, {"b", 30}, {"c", 20}, {"d", 20}, {"e", 5}}
+}
+
+func TestPaginate_ByOffset(t *testing.T) {
+	page, err := Paginate(items(), byPrice, Params{Limit: 2, Offset: 1})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []item{{"c", 20}, {"d", 20}}, page.Items)
+	assert.Equal(t, 5, page.Total)
+	assert.Equal(t, 1, page.Offset)
+	assert.NotEmpty(t, page.NextCursor)
+
+	page, _ = Paginate(items(), byPrice, Params{Limit: 2, Offset: 10})
+	assert.Empty(t, page.Items)
+	assert.Empty(t, page.NextCursor)
+}
+
+func TestPaginate_ByCursor(t *testing.T) {
+	var seen []string
+	params := Params{Limit: 2}
+	for {
+		page, err := Paginate(items(), byPrice, params)
+		assert.Nil(t, err)
+		for _, i := range page.Items {
+			seen = append(seen, i.id)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		params.Cursor = page.NextCursor
+	}
+	assert.Equal(t, []string{"b", "c", "d", "a", "e"}, seen)
+
+	// Items removed between pages do not shift the next one.
+	page, _ := Paginate(items(), byPrice, Params{Limit: 2})
+	remaining := []item{{"a", 10}, {"e", 5}, {"d", 20}}
+	next, _ := Paginate(remaining, byPrice, Params{Limit: 2, Cursor: page.NextCursor})
+	assert.Equal(t, []item{{"d", 20}, {"a", 10}}, next.Items)
+	assert.Equal(t, 3, next.Total)
+}
+
+func TestPaginate_RejectsForeignCursors(t *testing.T) {
+	page, _ := Paginate(items(), byPrice, Params{Limit: 1})
+
+	byId := Order[item]{Name: "id", Key: func(i item) Key { return Key{Id: i.id} }}
+	_, err := Paginate(items(), byId, Params{Cursor: page.NextCursor})
+	assert.NotNil(t, err)
+
+	_, err = Paginate(items(), byPrice, Params{Cursor: "not a cursor"})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,103 @@
+package product_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/product_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+)
+
+type ProductInputDTO struct {
+	Sku         string            `json:"sku" binding:"required,min=1"`
+	Name        string            `json:"name" binding:"required,min=1"`
+	Description string            `json:"description" binding:"required,min=10,max=200"`
+	Category    string            `json:"category" binding:"required,min=2"`
+	Attributes  map[string]string `json:"attributes"`
+	Images      []string          `json:"images" binding:"dive,url"`
+}
+
+type ProductOutputDTO struct {
+	Id          string            `json:"id"`
+	Sku         string            `json:"sku"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Category    string            `json:"category"`
+	Attributes  map[string]string `json:"attributes,omitempty"`
+	Images      []string          `json:"images,omitempty"`
+	Timestamp   time.Time         `json:"timestamp" time_format:"2006-01-02 15:04:05"`
+}
+
+func NewProductUseCase(
+	productRepositoryInterface product_entity.ProductRepositoryInterface) ProductUseCaseInterface {
+	return &ProductUseCase{
+		productRepositoryInterface: productRepositoryInterface,
+	}
+}
+
+type ProductUseCaseInterface interface {
+	CreateProduct(
+		ctx context.Context,
+		productInput ProductInputDTO) (*ProductOutputDTO, *internal_error.InternalError)
+
+	FindProductById(
+		ctx context.Context, id string) (*ProductOutputDTO, *internal_error.InternalError)
+
+	FindProducts(
+		ctx context.Context,
+		category, sku string) ([]ProductOutputDTO, *internal_error.InternalError)
+
+	UpdateProduct(
+		ctx context.Context,
+		id string,
+		productInput ProductInputDTO) (*ProductOutputDTO, *internal_error.InternalError)
+
+	DeleteProduct(
+		ctx context.Context, id string) *internal_error.InternalError
+}
+
+type ProductUseCase struct {
+	productRepositoryInterface product_entity.ProductRepositoryInterface
+}
+
+func (pu *ProductUseCase) CreateProduct(
+	ctx context.Context,
+	productInput ProductInputDTO) (*ProductOutputDTO, *internal_error.InternalError) {
+	existing, err := pu.productRepositoryInterface.FindProducts(ctx, "", productInput.Sku)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(existing) > 0 {
+		return nil, internal_error.NewBadRequestError("a product with this sku already exists")
+	}
+
+	product, err := product_entity.CreateProduct(
+		productInput.Sku,
+		productInput.Name,
+		productInput.Description,
+		productInput.Category,
+		productInput.Attributes,
+		productInput.Images)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := pu.productRepositoryInterface.CreateProduct(ctx, product); err != nil {
+		return nil, err
+	}
+
+	return toProductOutputDTO(product), nil
+}
+
+func toProductOutputDTO(product *product_entity.Product) *ProductOutputDTO {
+	return &ProductOutputDTO{
+		Id:          product.Id,
+		Sku:         product.Sku,
+		Name:        product.Name,
+		Description: product.Description,
+		Category:    product.Category,
+		Attributes:  product.Attributes,
+		Images:      product.Images,
+		Timestamp:   product.Timestamp,
+	}
+}
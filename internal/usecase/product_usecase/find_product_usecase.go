@@ -0,0 +1,32 @@
+package product_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/internal_error"
+)
+
+func (pu *ProductUseCase) FindProductById(
+	ctx context.Context, id string) (*ProductOutputDTO, *internal_error.InternalError) {
+	productEntity, err := pu.productRepositoryInterface.FindProductById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toProductOutputDTO(productEntity), nil
+}
+
+func (pu *ProductUseCase) FindProducts(
+	ctx context.Context,
+	category, sku string) ([]ProductOutputDTO, *internal_error.InternalError) {
+	productEntities, err := pu.productRepositoryInterface.FindProducts(ctx, category, sku)
+	if err != nil {
+		return nil, err
+	}
+
+	var productOutputs []ProductOutputDTO
+	for _, value := range productEntities {
+		productOutputs = append(productOutputs, *toProductOutputDTO(&value))
+	}
+
+	return productOutputs, nil
+}
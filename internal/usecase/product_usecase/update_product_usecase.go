@@ -0,0 +1,49 @@
+package product_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/internal_error"
+)
+
+func (pu *ProductUseCase) UpdateProduct(
+	ctx context.Context,
+	id string,
+	productInput ProductInputDTO) (*ProductOutputDTO, *internal_error.InternalError) {
+	productEntity, err := pu.productRepositoryInterface.FindProductById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if productInput.Sku != productEntity.Sku {
+		existing, err := pu.productRepositoryInterface.FindProducts(ctx, "", productInput.Sku)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(existing) > 0 {
+			return nil, internal_error.NewBadRequestError("a product with this sku already exists")
+		}
+	}
+
+	productEntity.Sku = productInput.Sku
+	productEntity.Name = productInput.Name
+	productEntity.Description = productInput.Description
+	productEntity.Category = productInput.Category
+	productEntity.Attributes = productInput.Attributes
+	productEntity.Images = productInput.Images
+
+	if err := productEntity.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := pu.productRepositoryInterface.UpdateProduct(ctx, productEntity); err != nil {
+		return nil, err
+	}
+
+	return toProductOutputDTO(productEntity), nil
+}
+
+func (pu *ProductUseCase) DeleteProduct(
+	ctx context.Context, id string) *internal_error.InternalError {
+	return pu.productRepositoryInterface.DeleteProduct(ctx, id)
+}
@@ -0,0 +1,329 @@
+package proxy_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/entity/event_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"fullcycle-auction_go/internal/usecase/bid_usecase"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// bidEventsBuffer is how many events the use case buffers while it resolves
+// the proxy bids of a lot.
+const bidEventsBuffer = 1024
+
+type ProxyBidInputDTO struct {
+	MaxAmount float64 `json:"max_amount" binding:"required,gt=0"`
+
+	// TermsVersion is the version of the auction terms the bidder accepts,
+	// required on auctions with terms.
+	TermsVersion int `json:"terms_version" binding:"omitempty,min=1"`
+}
+
+type ProxyBidOutputDTO struct {
+	Id        string    `json:"id"`
+	AuctionId string    `json:"auction_id"`
+	UserId    string    `json:"user_id"`
+	MaxAmount float64   `json:"max_amount"`
+	Status    string    `json:"status"`
+	Timestamp time.Time `json:"timestamp" time_format:"2006-01-02 15:04:05"`
+}
+
+// Subscriber hands out the events published in the process.
+type Subscriber interface {
+	Subscribe(buffer int) (<-chan event_entity.Event, func())
+}
+
+type ProxyUseCaseInterface interface {
+	// SetProxyBid stores the maximum the user bids on an open lot, replacing
+	// the one they set before, and bids for them right away when they are
+	// not leading.
+	SetProxyBid(
+		ctx context.Context,
+		auctionId, userId string,
+		proxyBidInput ProxyBidInputDTO) (*ProxyBidOutputDTO, *internal_error.InternalError)
+
+	FindProxyBid(
+		ctx context.Context, auctionId, userId string) (*ProxyBidOutputDTO, *internal_error.InternalError)
+}
+
+type ProxyUseCase struct {
+	proxyBidRepositoryInterface bid_entity.ProxyBidRepositoryInterface
+	auctionRepositoryInterface  auction_entity.AuctionRepositoryInterface
+	bidRepositoryInterface      bid_entity.BidEntityRepository
+	bidUseCase                  bid_usecase.BidUseCaseInterface
+	increment                   float64
+
+	// resolveMutex keeps the routine and SetProxyBid from bidding for the
+	// same proxy bids at once.
+	resolveMutex *sync.Mutex
+}
+
+// NewProxyUseCase also starts the routine answering the bids placed on lots
+// with the proxy bids set on them.
+func NewProxyUseCase(
+	proxyBidRepositoryInterface bid_entity.ProxyBidRepositoryInterface,
+	auctionRepositoryInterface auction_entity.AuctionRepositoryInterface,
+	bidRepositoryInterface bid_entity.BidEntityRepository,
+	bidUseCase bid_usecase.BidUseCaseInterface,
+	subscriber Subscriber) ProxyUseCaseInterface {
+	proxyUseCase := &ProxyUseCase{
+		proxyBidRepositoryInterface: proxyBidRepositoryInterface,
+		auctionRepositoryInterface:  auctionRepositoryInterface,
+		bidRepositoryInterface:      bidRepositoryInterface,
+		bidUseCase:                  bidUseCase,
+		increment:                   getProxyBidIncrement(),
+		resolveMutex:                &sync.Mutex{},
+	}
+
+	events, _ := subscriber.Subscribe(bidEventsBuffer)
+	proxyUseCase.triggerResolutionRoutine(context.Background(), events)
+
+	return proxyUseCase
+}
+
+func (pu *ProxyUseCase) SetProxyBid(
+	ctx context.Context,
+	auctionId, userId string,
+	proxyBidInput ProxyBidInputDTO) (*ProxyBidOutputDTO, *internal_error.InternalError) {
+	auction, err := pu.auctionRepositoryInterface.FindAuctionById(ctx, auctionId)
+	if err != nil {
+		return nil, err
+	}
+	if !auction.AcceptsBids() {
+		return nil, internal_error.NewBadRequestError("Proxy bids are only taken while bidding is open")
+	}
+	if auction.SoldBy(userId) {
+		return nil, bid_entity.RejectionSellerBid.AsError()
+	}
+	if auction.Sealed {
+		return nil, internal_error.NewBadRequestError("Sealed auctions do not take proxy bids")
+	}
+	if auction.Units() > 1 {
+		return nil, internal_error.NewBadRequestError("Auctions of several units do not take proxy bids")
+	}
+	if auction.Terms != nil && proxyBidInput.TermsVersion != auction.Terms.Version {
+		return nil, bid_entity.RejectionTermsNotAccepted.AsError()
+	}
+
+	highestBid, err := pu.findHighestBid(ctx, auctionId)
+	if err != nil {
+		return nil, err
+	}
+	if highestBid != nil && proxyBidInput.MaxAmount <= highestBid.Amount {
+		return nil, internal_error.NewBadRequestError("Max amount must be higher than the current highest bid")
+	}
+	if highestBid == nil && proxyBidInput.MaxAmount < auction.StartingPrice {
+		return nil, internal_error.NewBadRequestError("Max amount must be at least the starting price")
+	}
+
+	proxyBid, err := bid_entity.CreateProxyBid(
+		userId, auctionId, proxyBidInput.MaxAmount, proxyBidInput.TermsVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := pu.proxyBidRepositoryInterface.UpsertProxyBid(ctx, proxyBid); err != nil {
+		return nil, err
+	}
+
+	pu.resolve(ctx, auctionId)
+
+	return pu.FindProxyBid(ctx, auctionId, userId)
+}
+
+func (pu *ProxyUseCase) FindProxyBid(
+	ctx context.Context, auctionId, userId string) (*ProxyBidOutputDTO, *internal_error.InternalError) {
+	proxyBid, err := pu.proxyBidRepositoryInterface.FindProxyBid(ctx, auctionId, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProxyBidOutputDTO{
+		Id:        proxyBid.Id,
+		AuctionId: proxyBid.AuctionId,
+		UserId:    proxyBid.UserId,
+		MaxAmount: proxyBid.MaxAmount,
+		Status:    string(proxyBid.Status),
+		Timestamp: proxyBid.Timestamp,
+	}, nil
+}
+
+// triggerResolutionRoutine resolves the proxy bids of a lot on every bid
+// stored for it, the ones placed for proxy bids included, until no proxy
+// bid can outbid the leader. A retraction resolves the lot again, against
+// the leader left.
+func (pu *ProxyUseCase) triggerResolutionRoutine(ctx context.Context, events <-chan event_entity.Event) {
+	go func() {
+		for event := range events {
+			if event.Type == event_entity.BidPlaced || event.Type == event_entity.BidRetracted {
+				pu.resolve(ctx, event.AuctionId)
+			}
+		}
+	}()
+}
+
+// resolve places the bid the proxy bids of the lot answer its highest bid
+// with, if any, and retires the proxy bids it outbid past their maximum.
+func (pu *ProxyUseCase) resolve(ctx context.Context, auctionId string) {
+	pu.resolveMutex.Lock()
+	defer pu.resolveMutex.Unlock()
+
+	proxyBids, err := pu.proxyBidRepositoryInterface.FindActiveProxyBids(ctx, auctionId)
+	if err != nil {
+		logger.Error("error trying to find proxy bids", err)
+		return
+	}
+	if len(proxyBids) == 0 {
+		return
+	}
+
+	auction, err := pu.auctionRepositoryInterface.FindAuctionById(ctx, auctionId)
+	if err != nil {
+		logger.Error("error trying to find the auction of proxy bids", err)
+		return
+	}
+	if !auction.AcceptsBids() || auction.Sealed {
+		return
+	}
+
+	highestBid, err := pu.findHighestBid(ctx, auctionId)
+	if err != nil {
+		logger.Error("error trying to find the highest bid of proxy bids", err)
+		return
+	}
+
+	increment := pu.increment
+	if auction.MinIncrement > 0 {
+		increment = auction.MinIncrement
+	}
+
+	answer, exhausted := resolveProxyBids(proxyBids, highestBid, increment, auction.StartingPrice)
+	for _, proxyBid := range exhausted {
+		if err := pu.proxyBidRepositoryInterface.UpdateProxyBidStatus(
+			ctx, proxyBid.Id, bid_entity.ProxyExhausted); err != nil {
+			logger.Error("error trying to update proxy bid", err)
+		}
+	}
+	if answer == nil {
+		return
+	}
+
+	// A bid turned down keeps the proxy bid active, the bids still pending
+	// in the batch pipeline may be the reason and the next one stored
+	// resolves the lot again.
+	if _, err := pu.bidUseCase.CreateBid(ctx, bid_usecase.BidInputDTO{
+		AuctionId:    auctionId,
+		Amount:       answer.amount,
+		Source:       string(bid_entity.SourceProxy),
+		TermsVersion: answer.proxyBid.TermsVersion,
+		UserId:       answer.proxyBid.UserId,
+	}); err != nil {
+		logger.Warn("Proxy bid was not placed",
+			zap.String("proxy_bid_id", answer.proxyBid.Id),
+			zap.String("reason", err.Message))
+	}
+}
+
+func (pu *ProxyUseCase) findHighestBid(
+	ctx context.Context, auctionId string) (*bid_entity.Bid, *internal_error.InternalError) {
+	highestBid, err := pu.bidRepositoryInterface.FindWinningBidByAuctionId(ctx, auctionId)
+	if err != nil {
+		if err.Err == "not_found" {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return highestBid, nil
+}
+
+// proxyAnswer is a bid placed on behalf of a proxy bid.
+type proxyAnswer struct {
+	proxyBid bid_entity.ProxyBid
+	amount   float64
+}
+
+// resolveProxyBids runs the active proxy bids of a lot, highest maximum
+// first, against its highest bid. Proxy bids that cannot beat it by an
+// increment are exhausted, unless they belong to the leader. Of the ones
+// left, the first bids one increment over the runner-up, its maximum or the
+// highest bid, within its own maximum; the earliest wins a tie. A leader's
+// proxy bid only raises its bid when another one could outbid it, so the
+// winning bid ends one increment over the runner-up's maximum, the way the
+// proxy bids would have bid against each other one increment at a time.
+// The first bid of the lot is at least its starting price.
+func resolveProxyBids(
+	proxyBids []bid_entity.ProxyBid,
+	highestBid *bid_entity.Bid,
+	increment, startingPrice float64) (*proxyAnswer, []bid_entity.ProxyBid) {
+	var price float64
+	var leaderId string
+	if highestBid != nil {
+		price, leaderId = highestBid.Amount, highestBid.UserId
+	}
+
+	var competing, exhausted []bid_entity.ProxyBid
+	for _, proxyBid := range proxyBids {
+		switch {
+		case proxyBid.UserId == leaderId:
+			competing = append(competing, proxyBid)
+		case highestBid != nil && proxyBid.MaxAmount < price+increment:
+			exhausted = append(exhausted, proxyBid)
+		default:
+			competing = append(competing, proxyBid)
+		}
+	}
+
+	if len(competing) == 0 {
+		return nil, exhausted
+	}
+
+	first := competing[0]
+	runnerUp := price
+	if len(competing) > 1 {
+		runnerUp = maxAmount(runnerUp, competing[1].MaxAmount)
+	} else if first.UserId == leaderId {
+		return nil, exhausted
+	}
+
+	amount := minAmount(first.MaxAmount, runnerUp+increment)
+	if highestBid == nil {
+		amount = minAmount(first.MaxAmount, maxAmount(amount, startingPrice))
+	}
+	if first.UserId == leaderId && amount <= price {
+		return nil, exhausted
+	}
+
+	return &proxyAnswer{proxyBid: first, amount: amount}, exhausted
+}
+
+func minAmount(a, b float64) float64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxAmount(a, b float64) float64 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func getProxyBidIncrement() float64 {
+	increment, err := strconv.ParseFloat(os.Getenv("PROXY_BID_INCREMENT"), 64)
+	if err != nil || increment <= 0 {
+		return 1
+	}
+	return increment
+}
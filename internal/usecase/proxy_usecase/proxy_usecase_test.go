@@ -0,0 +1,41 @@
+package proxy_usecase
+
+import (
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveProxyBids(t *testing.T) {
+	ann := bid_entity.ProxyBid{Id: "ann", UserId: "ann", MaxAmount: 150}
+	bob := bid_entity.ProxyBid{Id: "bob", UserId: "bob", MaxAmount: 100}
+
+	answer, exhausted := resolveProxyBids([]bid_entity.ProxyBid{ann}, nil, 5, 0)
+	assert.Equal(t, proxyAnswer{proxyBid: ann, amount: 5}, *answer)
+	assert.Empty(t, exhausted)
+
+	// The leader's proxy bid only answers another one.
+	answer, _ = resolveProxyBids([]bid_entity.ProxyBid{ann}, &bid_entity.Bid{UserId: "ann", Amount: 5}, 5, 0)
+	assert.Nil(t, answer)
+
+	answer, _ = resolveProxyBids([]bid_entity.ProxyBid{ann, bob}, &bid_entity.Bid{UserId: "ann", Amount: 5}, 5, 0)
+	assert.Equal(t, proxyAnswer{proxyBid: ann, amount: 105}, *answer)
+
+	answer, exhausted = resolveProxyBids([]bid_entity.ProxyBid{ann, bob}, &bid_entity.Bid{UserId: "ann", Amount: 105}, 5, 0)
+	assert.Nil(t, answer)
+	assert.Equal(t, []bid_entity.ProxyBid{bob}, exhausted)
+
+	answer, _ = resolveProxyBids([]bid_entity.ProxyBid{bob}, &bid_entity.Bid{UserId: "cid", Amount: 95}, 5, 0)
+	assert.Equal(t, proxyAnswer{proxyBid: bob, amount: 100}, *answer)
+
+	tie := bid_entity.ProxyBid{Id: "tie", UserId: "tie", MaxAmount: 150}
+	answer, _ = resolveProxyBids([]bid_entity.ProxyBid{ann, tie}, nil, 5, 0)
+	assert.Equal(t, proxyAnswer{proxyBid: ann, amount: 150}, *answer)
+
+	// The first bid opens the lot at its starting price.
+	answer, _ = resolveProxyBids([]bid_entity.ProxyBid{ann}, nil, 5, 120)
+	assert.Equal(t, proxyAnswer{proxyBid: ann, amount: 120}, *answer)
+	answer, _ = resolveProxyBids([]bid_entity.ProxyBid{ann, bob}, nil, 5, 120)
+	assert.Equal(t, proxyAnswer{proxyBid: ann, amount: 120}, *answer)
+}
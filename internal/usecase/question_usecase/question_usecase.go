@@ -0,0 +1,204 @@
+package question_usecase
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/configuration/clock"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/notification_entity"
+	"fullcycle-auction_go/internal/entity/question_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+)
+
+type QuestionInputDTO struct {
+	Text string `json:"text" binding:"required,min=3,max=500"`
+}
+
+type AnswerInputDTO struct {
+	Text string `json:"text" binding:"required,max=500"`
+}
+
+type ModerationInputDTO struct {
+	Status question_entity.QuestionStatus `json:"status" binding:"required,oneof=visible hidden"`
+}
+
+type QuestionOutputDTO struct {
+	Id         string     `json:"id"`
+	AuctionId  string     `json:"auction_id"`
+	UserId     string     `json:"user_id"`
+	Text       string     `json:"text"`
+	Answer     string     `json:"answer,omitempty"`
+	AnsweredAt *time.Time `json:"answered_at,omitempty" time_format:"2006-01-02 15:04:05"`
+	Status     string     `json:"status"`
+	Timestamp  time.Time  `json:"timestamp" time_format:"2006-01-02 15:04:05"`
+}
+
+type QuestionUseCaseInterface interface {
+	PostQuestion(
+		ctx context.Context, auctionId, userId string, questionInput QuestionInputDTO) (*QuestionOutputDTO, *internal_error.InternalError)
+
+	FindQuestions(
+		ctx context.Context, auctionId string) ([]QuestionOutputDTO, *internal_error.InternalError)
+
+	AnswerQuestion(
+		ctx context.Context, auctionId, questionId, sellerId string, answerInput AnswerInputDTO) (*QuestionOutputDTO, *internal_error.InternalError)
+
+	ModerateQuestion(
+		ctx context.Context, questionId string, moderationInput ModerationInputDTO) (*QuestionOutputDTO, *internal_error.InternalError)
+}
+
+type QuestionUseCase struct {
+	questionRepositoryInterface question_entity.QuestionRepositoryInterface
+	auctionRepositoryInterface  auction_entity.AuctionRepositoryInterface
+	notifier                    notification_entity.Notifier
+}
+
+func NewQuestionUseCase(
+	questionRepositoryInterface question_entity.QuestionRepositoryInterface,
+	auctionRepositoryInterface auction_entity.AuctionRepositoryInterface,
+	notifier notification_entity.Notifier) QuestionUseCaseInterface {
+	return &QuestionUseCase{
+		questionRepositoryInterface: questionRepositoryInterface,
+		auctionRepositoryInterface:  auctionRepositoryInterface,
+		notifier:                    notifier,
+	}
+}
+
+// PostQuestion adds a question to the thread of an active auction and lets
+// the seller know about it.
+func (qu *QuestionUseCase) PostQuestion(
+	ctx context.Context, auctionId, userId string, questionInput QuestionInputDTO) (*QuestionOutputDTO, *internal_error.InternalError) {
+	auction, err := qu.auctionRepositoryInterface.FindAuctionById(ctx, auctionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if auction.Status != auction_entity.Active {
+		return nil, internal_error.NewBadRequestError("Questions can only be asked on active auctions")
+	}
+
+	if auction.SellerId == userId {
+		return nil, internal_error.NewForbiddenError("Sellers cannot ask questions on their own auctions")
+	}
+
+	question, err := question_entity.CreateQuestion(auctionId, userId, questionInput.Text)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := qu.questionRepositoryInterface.CreateQuestion(ctx, question); err != nil {
+		return nil, err
+	}
+
+	if auction.SellerId != "" {
+		qu.notifier.Notify(ctx, notification_entity.NewNotification(
+			auction.SellerId, auctionId, notification_entity.QuestionPosted,
+			fmt.Sprintf("New question on %s: %s", auction.ProductName, question.Text)))
+	}
+
+	return toQuestionOutputDTO(question), nil
+}
+
+// FindQuestions lists the public thread of the auction, leaving out the
+// questions hidden by moderation.
+func (qu *QuestionUseCase) FindQuestions(
+	ctx context.Context, auctionId string) ([]QuestionOutputDTO, *internal_error.InternalError) {
+	questions, err := qu.questionRepositoryInterface.FindQuestions(ctx, auctionId)
+	if err != nil {
+		return nil, err
+	}
+
+	questionOutputList := make([]QuestionOutputDTO, 0, len(questions))
+	for _, question := range questions {
+		if question.Status == question_entity.Hidden {
+			continue
+		}
+
+		questionOutputList = append(questionOutputList, *toQuestionOutputDTO(&question))
+	}
+
+	return questionOutputList, nil
+}
+
+// AnswerQuestion stores the seller's reply, replacing any previous one, and
+// lets the asker know about it.
+func (qu *QuestionUseCase) AnswerQuestion(
+	ctx context.Context, auctionId, questionId, sellerId string, answerInput AnswerInputDTO) (*QuestionOutputDTO, *internal_error.InternalError) {
+	auction, err := qu.auctionRepositoryInterface.FindAuctionById(ctx, auctionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if auction.SellerId == "" || auction.SellerId != sellerId {
+		return nil, internal_error.NewForbiddenError("Only the seller can answer questions on this auction")
+	}
+
+	question, err := qu.findAuctionQuestion(ctx, auctionId, questionId)
+	if err != nil {
+		return nil, err
+	}
+
+	question.Answer = answerInput.Text
+	question.AnsweredAt = clock.Now()
+
+	if err := qu.questionRepositoryInterface.UpdateQuestion(ctx, question); err != nil {
+		return nil, err
+	}
+
+	qu.notifier.Notify(ctx, notification_entity.NewNotification(
+		question.UserId, auctionId, notification_entity.QuestionAnswered,
+		fmt.Sprintf("The seller of %s answered your question: %s", auction.ProductName, question.Answer)))
+
+	return toQuestionOutputDTO(question), nil
+}
+
+// ModerateQuestion hides a question from the public thread or restores it.
+func (qu *QuestionUseCase) ModerateQuestion(
+	ctx context.Context, questionId string, moderationInput ModerationInputDTO) (*QuestionOutputDTO, *internal_error.InternalError) {
+	question, err := qu.questionRepositoryInterface.FindQuestionById(ctx, questionId)
+	if err != nil {
+		return nil, err
+	}
+
+	question.Status = moderationInput.Status
+
+	if err := qu.questionRepositoryInterface.UpdateQuestion(ctx, question); err != nil {
+		return nil, err
+	}
+
+	return toQuestionOutputDTO(question), nil
+}
+
+func (qu *QuestionUseCase) findAuctionQuestion(
+	ctx context.Context, auctionId, questionId string) (*question_entity.Question, *internal_error.InternalError) {
+	question, err := qu.questionRepositoryInterface.FindQuestionById(ctx, questionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if question.AuctionId != auctionId {
+		return nil, internal_error.NewNotFoundError(
+			fmt.Sprintf("Question not found with this id = %s", questionId))
+	}
+
+	return question, nil
+}
+
+func toQuestionOutputDTO(question *question_entity.Question) *QuestionOutputDTO {
+	output := &QuestionOutputDTO{
+		Id:        question.Id,
+		AuctionId: question.AuctionId,
+		UserId:    question.UserId,
+		Text:      question.Text,
+		Answer:    question.Answer,
+		Status:    string(question.Status),
+		Timestamp: question.Timestamp,
+	}
+	if question.Answered() {
+		answeredAt := question.AnsweredAt
+		output.AnsweredAt = &answeredAt
+	}
+
+	return output
+}
@@ -0,0 +1,196 @@
+package registration_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/user_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"fullcycle-auction_go/internal/usecase/authorization"
+	"time"
+)
+
+type RegistrationInputDTO struct {
+	// Deposit is the amount the bidder puts down for the lot, if the seller
+	// asks for one.
+	Deposit float64 `json:"deposit" binding:"omitempty,gt=0"`
+}
+
+type DecisionInputDTO struct {
+	Status string `json:"status" binding:"required,oneof=approved rejected"`
+}
+
+type RegistrationOutputDTO struct {
+	Id        string     `json:"id"`
+	AuctionId string     `json:"auction_id"`
+	UserId    string     `json:"user_id"`
+	Deposit   float64    `json:"deposit,omitempty"`
+	Status    string     `json:"status"`
+	Timestamp time.Time  `json:"timestamp" time_format:"2006-01-02 15:04:05"`
+	DecidedBy string     `json:"decided_by,omitempty"`
+	DecidedAt *time.Time `json:"decided_at,omitempty" time_format:"2006-01-02 15:04:05"`
+}
+
+type RegistrationUseCaseInterface interface {
+	// Register asks for the user to bid on a lot requiring registration,
+	// pending until the seller or an admin decides on it. A rejected user
+	// can register again.
+	Register(
+		ctx context.Context,
+		auctionId, userId string,
+		registrationInput RegistrationInputDTO) (*RegistrationOutputDTO, *internal_error.InternalError)
+
+	FindRegistration(
+		ctx context.Context, auctionId, userId string) (*RegistrationOutputDTO, *internal_error.InternalError)
+
+	// FindRegistrations lists the registrations of the lot for its seller
+	// or an admin.
+	FindRegistrations(
+		ctx context.Context, auctionId, requesterId string) ([]RegistrationOutputDTO, *internal_error.InternalError)
+
+	// DecideRegistration approves or rejects the registration of the user,
+	// which only the seller of the lot or an admin can do.
+	DecideRegistration(
+		ctx context.Context,
+		auctionId, userId, requesterId string,
+		decisionInput DecisionInputDTO) (*RegistrationOutputDTO, *internal_error.InternalError)
+}
+
+type RegistrationUseCase struct {
+	registrationRepositoryInterface auction_entity.RegistrationRepositoryInterface
+	auctionRepositoryInterface      auction_entity.AuctionRepositoryInterface
+	userRepositoryInterface         user_entity.UserRepositoryInterface
+}
+
+func NewRegistrationUseCase(
+	registrationRepositoryInterface auction_entity.RegistrationRepositoryInterface,
+	auctionRepositoryInterface auction_entity.AuctionRepositoryInterface,
+	userRepositoryInterface user_entity.UserRepositoryInterface) RegistrationUseCaseInterface {
+	return &RegistrationUseCase{
+		registrationRepositoryInterface: registrationRepositoryInterface,
+		auctionRepositoryInterface:      auctionRepositoryInterface,
+		userRepositoryInterface:         userRepositoryInterface,
+	}
+}
+
+func (ru *RegistrationUseCase) Register(
+	ctx context.Context,
+	auctionId, userId string,
+	registrationInput RegistrationInputDTO) (*RegistrationOutputDTO, *internal_error.InternalError) {
+	auction, err := ru.auctionRepositoryInterface.FindAuctionById(ctx, auctionId)
+	if err != nil {
+		return nil, err
+	}
+	if !auction.RegistrationRequired {
+		return nil, internal_error.NewBadRequestError("This lot does not require registration")
+	}
+	if !registrationOpen(auction.Status) {
+		return nil, internal_error.NewBadRequestError("Registrations are only taken until the auction ends")
+	}
+	if auction.SellerId == userId {
+		return nil, internal_error.NewBadRequestError("Sellers cannot register for their own lots")
+	}
+
+	existing, err := ru.registrationRepositoryInterface.FindRegistration(ctx, auctionId, userId)
+	if err != nil && err.Err != "not_found" {
+		return nil, err
+	}
+	if existing != nil && existing.Status == auction_entity.RegistrationApproved {
+		return nil, internal_error.NewBadRequestError("Registration was already approved")
+	}
+
+	registration, err := auction_entity.CreateRegistration(userId, auctionId, registrationInput.Deposit)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ru.registrationRepositoryInterface.UpsertRegistration(ctx, registration); err != nil {
+		return nil, err
+	}
+
+	return ru.FindRegistration(ctx, auctionId, userId)
+}
+
+func (ru *RegistrationUseCase) FindRegistration(
+	ctx context.Context, auctionId, userId string) (*RegistrationOutputDTO, *internal_error.InternalError) {
+	registration, err := ru.registrationRepositoryInterface.FindRegistration(ctx, auctionId, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return toRegistrationOutputDTO(*registration), nil
+}
+
+func (ru *RegistrationUseCase) FindRegistrations(
+	ctx context.Context, auctionId, requesterId string) ([]RegistrationOutputDTO, *internal_error.InternalError) {
+	if err := ru.checkSellerOrAdmin(ctx, auctionId, requesterId); err != nil {
+		return nil, err
+	}
+
+	registrations, err := ru.registrationRepositoryInterface.FindRegistrations(ctx, auctionId)
+	if err != nil {
+		return nil, err
+	}
+
+	registrationOutputs := make([]RegistrationOutputDTO, 0, len(registrations))
+	for _, registration := range registrations {
+		registrationOutputs = append(registrationOutputs, *toRegistrationOutputDTO(registration))
+	}
+
+	return registrationOutputs, nil
+}
+
+func (ru *RegistrationUseCase) DecideRegistration(
+	ctx context.Context,
+	auctionId, userId, requesterId string,
+	decisionInput DecisionInputDTO) (*RegistrationOutputDTO, *internal_error.InternalError) {
+	if err := ru.checkSellerOrAdmin(ctx, auctionId, requesterId); err != nil {
+		return nil, err
+	}
+
+	if err := ru.registrationRepositoryInterface.DecideRegistration(
+		ctx, auctionId, userId, auction_entity.RegistrationStatus(decisionInput.Status), requesterId); err != nil {
+		return nil, err
+	}
+
+	return ru.FindRegistration(ctx, auctionId, userId)
+}
+
+// checkSellerOrAdmin returns a forbidden error unless the user is the
+// seller of the auction or an admin.
+func (ru *RegistrationUseCase) checkSellerOrAdmin(
+	ctx context.Context, auctionId, userId string) *internal_error.InternalError {
+	auction, err := ru.auctionRepositoryInterface.FindAuctionById(ctx, auctionId)
+	if err != nil {
+		return err
+	}
+
+	return authorization.CheckSellerOrAdmin(
+		ctx, ru.userRepositoryInterface, auction, userId, "manage the registrations of")
+}
+
+// registrationOpen tells whether an auction in the status still takes
+// registrations, which lots do until they end.
+func registrationOpen(status auction_entity.AuctionStatus) bool {
+	switch status {
+	case auction_entity.Completed, auction_entity.ClosedNoSale, auction_entity.Cancelled:
+		return false
+	}
+	return true
+}
+
+func toRegistrationOutputDTO(registration auction_entity.Registration) *RegistrationOutputDTO {
+	output := &RegistrationOutputDTO{
+		Id:        registration.Id,
+		AuctionId: registration.AuctionId,
+		UserId:    registration.UserId,
+		Deposit:   registration.Deposit,
+		Status:    string(registration.Status),
+		Timestamp: registration.Timestamp,
+		DecidedBy: registration.DecidedBy,
+	}
+	if !registration.DecidedAt.IsZero() {
+		output.DecidedAt = &registration.DecidedAt
+	}
+
+	return output
+}
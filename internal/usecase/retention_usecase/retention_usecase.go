@@ -0,0 +1,277 @@
+package retention_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/clock"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// RuleBidClientData clears the IP and device fingerprint of bids,
+	// rejected ones included.
+	RuleBidClientData = "bid_client_data"
+	// RuleLosingBidders replaces the bidder of the losing bids of ended
+	// auctions with a pseudonym, one per bidder and auction.
+	RuleLosingBidders = "losing_bidders"
+)
+
+// pseudonymNamespace marks the user ids given to anonymized bidders, which
+// are version 5 uuids while users get version 4 ones, so they are not
+// anonymized again.
+var pseudonymNamespace = uuid.MustParse("4f1e5b0c-8d0a-4c55-9d3e-6a1f2b7c9e10")
+
+type RetentionRuleReportDTO struct {
+	Rule      string    `json:"rule"`
+	Retention string    `json:"retention"`
+	Cutoff    time.Time `json:"cutoff" time_format:"2006-01-02 15:04:05"`
+	Affected  int64     `json:"affected"`
+}
+
+type RetentionReportDTO struct {
+	DryRun bool                     `json:"dry_run"`
+	RanAt  time.Time                `json:"ran_at" time_format:"2006-01-02 15:04:05"`
+	Rules  []RetentionRuleReportDTO `json:"rules"`
+}
+
+type RetentionUseCaseInterface interface {
+	// RunRetention applies the enabled retention rules to the data past
+	// their retention. With dryRun set, nothing is changed and the report
+	// tells what would be.
+	RunRetention(ctx context.Context, dryRun bool) (*RetentionReportDTO, *internal_error.InternalError)
+}
+
+// retentionRule applies to the data older than the cutoff and returns how
+// many records it changed, or would change on a dry run.
+type retentionRule struct {
+	name      string
+	retention time.Duration
+	apply     func(ctx context.Context, cutoff time.Time, dryRun bool) (int64, *internal_error.InternalError)
+}
+
+type RetentionUseCase struct {
+	auctionRepositoryInterface     auction_entity.AuctionRepositoryInterface
+	bidRepositoryInterface         bid_entity.BidEntityRepository
+	rejectedBidRepositoryInterface bid_entity.RejectedBidRepositoryInterface
+	rules                          []retentionRule
+}
+
+// NewRetentionUseCase also starts the compliance routine applying the
+// rules every RETENTION_INTERVAL, only reporting them when
+// RETENTION_DRY_RUN is set.
+func NewRetentionUseCase(
+	auctionRepositoryInterface auction_entity.AuctionRepositoryInterface,
+	bidRepositoryInterface bid_entity.BidEntityRepository,
+	rejectedBidRepositoryInterface bid_entity.RejectedBidRepositoryInterface) RetentionUseCaseInterface {
+	retentionUseCase := &RetentionUseCase{
+		auctionRepositoryInterface:     auctionRepositoryInterface,
+		bidRepositoryInterface:         bidRepositoryInterface,
+		rejectedBidRepositoryInterface: rejectedBidRepositoryInterface,
+	}
+	retentionUseCase.rules = []retentionRule{
+		{
+			name:      RuleBidClientData,
+			retention: getRetention("RETENTION_BID_CLIENT_DATA", 90*24*time.Hour),
+			apply:     retentionUseCase.purgeBidClientData,
+		},
+		{
+			name:      RuleLosingBidders,
+			retention: getRetention("RETENTION_LOSING_BIDDERS", 365*24*time.Hour),
+			apply:     retentionUseCase.anonymizeLosingBidders,
+		},
+	}
+
+	retentionUseCase.triggerComplianceRoutine(context.Background(), getRetentionDryRun())
+
+	return retentionUseCase
+}
+
+func (ru *RetentionUseCase) RunRetention(
+	ctx context.Context, dryRun bool) (*RetentionReportDTO, *internal_error.InternalError) {
+	now := clock.Now()
+	report := &RetentionReportDTO{DryRun: dryRun, RanAt: now, Rules: []RetentionRuleReportDTO{}}
+
+	for _, rule := range ru.rules {
+		if rule.retention <= 0 {
+			continue
+		}
+
+		cutoff := now.Add(-rule.retention)
+		affected, err := rule.apply(ctx, cutoff, dryRun)
+
+		// Every run is written to the audit log, failed ones included,
+		// since a failure may leave part of the data changed.
+		fields := []zap.Field{
+			zap.String("rule", rule.name),
+			zap.Bool("dry_run", dryRun),
+			zap.Time("cutoff", cutoff),
+			zap.Int64("affected", affected),
+		}
+		if err != nil {
+			logger.Info("Retention rule failed", append(fields, zap.String("reason", err.Message))...)
+			return nil, err
+		}
+		logger.Info("Retention rule applied", fields...)
+
+		report.Rules = append(report.Rules, RetentionRuleReportDTO{
+			Rule:      rule.name,
+			Retention: rule.retention.String(),
+			Cutoff:    cutoff,
+			Affected:  affected,
+		})
+	}
+
+	return report, nil
+}
+
+func (ru *RetentionUseCase) triggerComplianceRoutine(ctx context.Context, dryRun bool) {
+	interval := getRetentionInterval()
+	if interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(clock.Scale(interval))
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if _, err := ru.RunRetention(ctx, dryRun); err != nil {
+				logger.Error("error trying to apply retention rules", err)
+			}
+		}
+	}()
+}
+
+func (ru *RetentionUseCase) purgeBidClientData(
+	ctx context.Context, cutoff time.Time, dryRun bool) (int64, *internal_error.InternalError) {
+	purged, err := ru.bidRepositoryInterface.PurgeClientData(ctx, cutoff, dryRun)
+	if err != nil {
+		return purged, err
+	}
+
+	purgedRejected, err := ru.rejectedBidRepositoryInterface.PurgeClientData(ctx, cutoff, dryRun)
+	return purged + purgedRejected, err
+}
+
+// anonymizeLosingBidders walks the auctions that ended before the cutoff.
+// The winner of a sold auction keeps their bids, needed for the sale.
+func (ru *RetentionUseCase) anonymizeLosingBidders(
+	ctx context.Context, cutoff time.Time, dryRun bool) (int64, *internal_error.InternalError) {
+	var auctions []auction_entity.Auction
+	for _, status := range []auction_entity.AuctionStatus{
+		auction_entity.Completed, auction_entity.ClosedNoSale, auction_entity.Cancelled} {
+		if err := ru.auctionRepositoryInterface.StreamAuctions(ctx, status, "",
+			func(auction auction_entity.Auction) error {
+				if auction.EndsAt.Before(cutoff) {
+					auctions = append(auctions, auction)
+				}
+				return nil
+			}); err != nil {
+			return 0, err
+		}
+	}
+
+	var anonymized int64
+	for _, auction := range auctions {
+		bids, err := ru.bidRepositoryInterface.FindBidByAuctionId(ctx, auction.Id)
+		if err != nil {
+			return anonymized, err
+		}
+
+		pseudonyms, count := losingBidders(bids, winnerId(auction, bids))
+		if len(pseudonyms) == 0 {
+			continue
+		}
+		if dryRun {
+			anonymized += count
+			continue
+		}
+
+		replaced, err := ru.bidRepositoryInterface.ReplaceBidders(ctx, auction.Id, pseudonyms)
+		anonymized += replaced
+		if err != nil {
+			return anonymized, err
+		}
+	}
+
+	return anonymized, nil
+}
+
+// winnerId is the bidder who bought the auction, if anyone did: the one
+// the buying bid was placed by, or the highest bidder once the reserve is
+// met.
+func winnerId(auction auction_entity.Auction, bids []bid_entity.Bid) string {
+	if auction.Status != auction_entity.Completed {
+		return ""
+	}
+
+	var highest *bid_entity.Bid
+	for i, bid := range bids {
+		if auction.WinnerBidId != "" && bid.Id == auction.WinnerBidId {
+			return bid.UserId
+		}
+		if highest == nil || bid.Amount > highest.Amount {
+			highest = &bids[i]
+		}
+	}
+
+	if highest == nil || auction.WinnerBidId != "" || !auction.ReserveMet(highest.Amount) {
+		return ""
+	}
+	return highest.UserId
+}
+
+// losingBidders maps each bidder but the winner to a new pseudonym and
+// counts their bids, skipping bidders anonymized before.
+func losingBidders(bids []bid_entity.Bid, winnerId string) (map[string]string, int64) {
+	pseudonyms := make(map[string]string)
+	var count int64
+	for _, bid := range bids {
+		if bid.UserId == winnerId || isPseudonym(bid.UserId) {
+			continue
+		}
+
+		if _, ok := pseudonyms[bid.UserId]; !ok {
+			pseudonyms[bid.UserId] = uuid.NewSHA1(pseudonymNamespace, []byte(uuid.New().String())).String()
+		}
+		count++
+	}
+
+	return pseudonyms, count
+}
+
+func isPseudonym(userId string) bool {
+	id, err := uuid.Parse(userId)
+	return err == nil && id.Version() == 5
+}
+
+// getRetention reads a retention from the environment, where 0 disables
+// the rule.
+func getRetention(name string, fallback time.Duration) time.Duration {
+	duration, err := time.ParseDuration(os.Getenv(name))
+	if err != nil || duration < 0 {
+		return fallback
+	}
+	return duration
+}
+
+func getRetentionInterval() time.Duration {
+	duration, err := time.ParseDuration(os.Getenv("RETENTION_INTERVAL"))
+	if err != nil || duration < 0 {
+		return 24 * time.Hour
+	}
+	return duration
+}
+
+func getRetentionDryRun() bool {
+	dryRun, err := strconv.ParseBool(os.Getenv("RETENTION_DRY_RUN"))
+	return err == nil && dryRun
+}
@@ -0,0 +1,36 @@
+package retention_usecase
+
+import (
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLosingBidders(t *testing.T) {
+	ann, bob := uuid.New().String(), uuid.New().String()
+	bids := []bid_entity.Bid{
+		{Id: "1", UserId: ann, Amount: 10},
+		{Id: "2", UserId: bob, Amount: 20},
+		{Id: "3", UserId: ann, Amount: 30},
+	}
+
+	sold := auction_entity.Auction{Status: auction_entity.Completed}
+	assert.Equal(t, ann, winnerId(sold, bids))
+	assert.Equal(t, "", winnerId(auction_entity.Auction{Status: auction_entity.Cancelled}, bids))
+	assert.Equal(t, "", winnerId(auction_entity.Auction{Status: auction_entity.Completed, ReservePrice: 50}, bids))
+	assert.Equal(t, bob, winnerId(auction_entity.Auction{Status: auction_entity.Completed, WinnerBidId: "2"}, bids))
+
+	pseudonyms, count := losingBidders(bids, ann)
+	assert.Len(t, pseudonyms, 1)
+	assert.EqualValues(t, 1, count)
+	assert.True(t, isPseudonym(pseudonyms[bob]))
+
+	// Anonymized bidders are left alone on the next run.
+	bids[1].UserId = pseudonyms[bob]
+	pseudonyms, count = losingBidders(bids, ann)
+	assert.Empty(t, pseudonyms)
+	assert.Zero(t, count)
+}
@@ -0,0 +1,135 @@
+package transfer_usecase
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/notification_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type TransferInputDTO struct {
+	FromSellerId string `json:"from_seller_id" binding:"required,uuid"`
+	ToSellerId   string `json:"to_seller_id" binding:"required,uuid,nefield=FromSellerId"`
+	AdminId      string `json:"admin_id" binding:"required,uuid"`
+	Reason       string `json:"reason" binding:"required,min=3,max=500"`
+}
+
+type TransferOutputDTO struct {
+	Id           string    `json:"id"`
+	AuctionId    string    `json:"auction_id"`
+	FromSellerId string    `json:"from_seller_id"`
+	ToSellerId   string    `json:"to_seller_id"`
+	AdminId      string    `json:"admin_id"`
+	Reason       string    `json:"reason"`
+	Timestamp    time.Time `json:"timestamp" time_format:"2006-01-02 15:04:05"`
+}
+
+type TransferUseCaseInterface interface {
+	TransferAuction(
+		ctx context.Context, auctionId string, transferInput TransferInputDTO) (*TransferOutputDTO, *internal_error.InternalError)
+
+	FindTransfers(
+		ctx context.Context, auctionId string) ([]TransferOutputDTO, *internal_error.InternalError)
+}
+
+type TransferUseCase struct {
+	auctionRepositoryInterface  auction_entity.AuctionRepositoryInterface
+	transferRepositoryInterface auction_entity.TransferRepositoryInterface
+	notifier                    notification_entity.Notifier
+}
+
+func NewTransferUseCase(
+	auctionRepositoryInterface auction_entity.AuctionRepositoryInterface,
+	transferRepositoryInterface auction_entity.TransferRepositoryInterface,
+	notifier notification_entity.Notifier) TransferUseCaseInterface {
+	return &TransferUseCase{
+		auctionRepositoryInterface:  auctionRepositoryInterface,
+		transferRepositoryInterface: transferRepositoryInterface,
+		notifier:                    notifier,
+	}
+}
+
+// TransferAuction hands the auction over to another seller account. Its
+// winner, offers and payout follow the auction, since they are always
+// settled with its current seller. The seller the admin moves it from must
+// still own it, so concurrent transfers cannot both apply.
+func (tu *TransferUseCase) TransferAuction(
+	ctx context.Context, auctionId string, transferInput TransferInputDTO) (*TransferOutputDTO, *internal_error.InternalError) {
+	auction, err := tu.auctionRepositoryInterface.FindAuctionById(ctx, auctionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if auction.SellerId != transferInput.FromSellerId {
+		return nil, internal_error.NewBadRequestError("Auction does not belong to this seller")
+	}
+
+	transfer, err := auction_entity.CreateTransfer(
+		auctionId, transferInput.FromSellerId, transferInput.ToSellerId,
+		transferInput.AdminId, transferInput.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tu.auctionRepositoryInterface.UpdateAuctionSeller(
+		ctx, auctionId, transfer.FromSellerId, transfer.ToSellerId); err != nil {
+		return nil, err
+	}
+
+	logger.Info("Auction transferred",
+		zap.String("auction_id", auctionId),
+		zap.String("from_seller_id", transfer.FromSellerId),
+		zap.String("to_seller_id", transfer.ToSellerId),
+		zap.String("admin_id", transfer.AdminId),
+		zap.String("reason", transfer.Reason))
+
+	if err := tu.transferRepositoryInterface.CreateTransfer(ctx, transfer); err != nil {
+		return nil, err
+	}
+
+	tu.notifier.Notify(ctx, notification_entity.NewNotification(
+		transfer.FromSellerId, auctionId, notification_entity.AuctionTransferred,
+		fmt.Sprintf("%s was transferred to another seller account: %s", auction.ProductName, transfer.Reason)))
+	tu.notifier.Notify(ctx, notification_entity.NewNotification(
+		transfer.ToSellerId, auctionId, notification_entity.AuctionTransferred,
+		fmt.Sprintf("%s was transferred to your account: %s", auction.ProductName, transfer.Reason)))
+
+	return toTransferOutputDTO(transfer), nil
+}
+
+// FindTransfers lists the audit trail of the auction's transfers.
+func (tu *TransferUseCase) FindTransfers(
+	ctx context.Context, auctionId string) ([]TransferOutputDTO, *internal_error.InternalError) {
+	if _, err := tu.auctionRepositoryInterface.FindAuctionById(ctx, auctionId); err != nil {
+		return nil, err
+	}
+
+	transfers, err := tu.transferRepositoryInterface.FindTransfers(ctx, auctionId)
+	if err != nil {
+		return nil, err
+	}
+
+	transferOutputList := make([]TransferOutputDTO, 0, len(transfers))
+	for _, transfer := range transfers {
+		transferOutputList = append(transferOutputList, *toTransferOutputDTO(&transfer))
+	}
+
+	return transferOutputList, nil
+}
+
+func toTransferOutputDTO(transfer *auction_entity.Transfer) *TransferOutputDTO {
+	return &TransferOutputDTO{
+		Id:           transfer.Id,
+		AuctionId:    transfer.AuctionId,
+		FromSellerId: transfer.FromSellerId,
+		ToSellerId:   transfer.ToSellerId,
+		AdminId:      transfer.AdminId,
+		Reason:       transfer.Reason,
+		Timestamp:    transfer.Timestamp,
+	}
+}
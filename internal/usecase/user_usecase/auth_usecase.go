@@ -0,0 +1,84 @@
+package user_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/user_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+)
+
+type TokenIssuer interface {
+	Issue(userId string) (string, time.Time, error)
+}
+
+type RegisterInputDTO struct {
+	Name  string `json:"name" binding:"required,min=2,max=100"`
+	Email string `json:"email" binding:"required,email,max=254"`
+	// Password is capped at the 72 bytes bcrypt hashes.
+	Password string `json:"password" binding:"required,min=8,max=72"`
+}
+
+type LoginInputDTO struct {
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required"`
+}
+
+type TokenOutputDTO struct {
+	AccessToken string    `json:"access_token"`
+	TokenType   string    `json:"token_type"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	UserId      string    `json:"user_id"`
+}
+
+func (u *UserUseCase) RegisterUser(
+	ctx context.Context,
+	registerInput RegisterInputDTO) (*UserOutputDTO, *internal_error.InternalError) {
+	email := user_entity.NormalizeEmail(registerInput.Email)
+
+	_, err := u.UserRepository.FindUserByEmail(ctx, email)
+	if err == nil {
+		return nil, internal_error.NewBadRequestError("Email already registered")
+	}
+	if err.Err != "not_found" {
+		return nil, err
+	}
+
+	userEntity, err := user_entity.CreateUser(registerInput.Name, email, registerInput.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.UserRepository.CreateUser(ctx, userEntity); err != nil {
+		return nil, err
+	}
+
+	return toUserOutputDTO(userEntity), nil
+}
+
+// Login answers the same error for an unknown email and a wrong password,
+// so it cannot be used to find out who is registered.
+func (u *UserUseCase) Login(
+	ctx context.Context,
+	loginInput LoginInputDTO) (*TokenOutputDTO, *internal_error.InternalError) {
+	userEntity, err := u.UserRepository.FindUserByEmail(ctx, user_entity.NormalizeEmail(loginInput.Email))
+	if err != nil && err.Err != "not_found" {
+		return nil, err
+	}
+	if userEntity == nil || !userEntity.CheckPassword(loginInput.Password) {
+		return nil, internal_error.NewUnauthorizedError("Invalid email or password")
+	}
+
+	token, expiresAt, tokenErr := u.TokenIssuer.Issue(userEntity.Id)
+	if tokenErr != nil {
+		logger.Error("Error trying to issue token", tokenErr)
+		return nil, internal_error.NewInternalServerError("Error trying to issue token")
+	}
+
+	return &TokenOutputDTO{
+		AccessToken: token,
+		TokenType:   "Bearer",
+		ExpiresAt:   expiresAt,
+		UserId:      userEntity.Id,
+	}, nil
+}
@@ -6,25 +6,54 @@ import (
 	"fullcycle-auction_go/internal/internal_error"
 )
 
-func NewUserUseCase(userRepository user_entity.UserRepositoryInterface) UserUseCaseInterface {
+func NewUserUseCase(
+	userRepository user_entity.UserRepositoryInterface,
+	tokenIssuer TokenIssuer) UserUseCaseInterface {
 	return &UserUseCase{
-		userRepository,
+		UserRepository: userRepository,
+		TokenIssuer:    tokenIssuer,
 	}
 }
 
 type UserUseCase struct {
 	UserRepository user_entity.UserRepositoryInterface
+	TokenIssuer    TokenIssuer
 }
 
 type UserOutputDTO struct {
-	Id   string `json:"id"`
-	Name string `json:"name"`
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name,omitempty"`
+	AvatarUrl   string `json:"avatar_url,omitempty"`
+	Bio         string `json:"bio,omitempty"`
+	Role        string `json:"role,omitempty"`
 }
 
 type UserUseCaseInterface interface {
 	FindUserById(
 		ctx context.Context,
 		id string) (*UserOutputDTO, *internal_error.InternalError)
+
+	UpdateUserProfile(
+		ctx context.Context,
+		id string,
+		profileInput UserProfileInputDTO) (*UserOutputDTO, *internal_error.InternalError)
+
+	// UpdateUserRole grants the user a staff role, or takes it back with
+	// an empty one.
+	UpdateUserRole(
+		ctx context.Context,
+		id string,
+		roleInput UserRoleInputDTO) (*UserOutputDTO, *internal_error.InternalError)
+
+	RegisterUser(
+		ctx context.Context,
+		registerInput RegisterInputDTO) (*UserOutputDTO, *internal_error.InternalError)
+
+	// Login checks the credentials and issues a token for the user.
+	Login(
+		ctx context.Context,
+		loginInput LoginInputDTO) (*TokenOutputDTO, *internal_error.InternalError)
 }
 
 func (u *UserUseCase) FindUserById(
@@ -34,8 +63,16 @@ func (u *UserUseCase) FindUserById(
 		return nil, err
 	}
 
+	return toUserOutputDTO(userEntity), nil
+}
+
+func toUserOutputDTO(userEntity *user_entity.User) *UserOutputDTO {
 	return &UserOutputDTO{
-		Id:   userEntity.Id,
-		Name: userEntity.Name,
-	}, nil
+		Id:          userEntity.Id,
+		Name:        userEntity.Name,
+		DisplayName: userEntity.DisplayName,
+		AvatarUrl:   userEntity.AvatarUrl,
+		Bio:         userEntity.Bio,
+		Role:        string(userEntity.Role),
+	}
 }
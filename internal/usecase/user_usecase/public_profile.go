@@ -0,0 +1,54 @@
+package user_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/user_entity"
+)
+
+// PublicProfileDTO is the lightweight profile embedded in bids and auctions
+// to show who placed or sells them.
+type PublicProfileDTO struct {
+	Id          string `json:"id"`
+	DisplayName string `json:"display_name"`
+	AvatarUrl   string `json:"avatar_url,omitempty"`
+}
+
+// FindPublicProfiles looks up the public profiles of the users, keyed by
+// id. Unregistered users have no profile, and a failed lookup only leaves
+// the profiles out, since they are decoration on the listings.
+func FindPublicProfiles(
+	ctx context.Context,
+	userRepository user_entity.UserRepositoryInterface,
+	userIds []string) map[string]PublicProfileDTO {
+	seen := make(map[string]struct{}, len(userIds))
+	var uniqueIds []string
+	for _, userId := range userIds {
+		if _, ok := seen[userId]; ok || userId == "" {
+			continue
+		}
+		seen[userId] = struct{}{}
+		uniqueIds = append(uniqueIds, userId)
+	}
+
+	profiles := make(map[string]PublicProfileDTO, len(uniqueIds))
+	if len(uniqueIds) == 0 {
+		return profiles
+	}
+
+	users, err := userRepository.FindUsersByIds(ctx, uniqueIds)
+	if err != nil {
+		logger.Error("error trying to find public profiles", err)
+		return profiles
+	}
+
+	for _, user := range users {
+		profiles[user.Id] = PublicProfileDTO{
+			Id:          user.Id,
+			DisplayName: user.PublicName(),
+			AvatarUrl:   user.AvatarUrl,
+		}
+	}
+
+	return profiles
+}
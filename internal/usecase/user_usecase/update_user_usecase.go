@@ -0,0 +1,67 @@
+package user_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/user_entity"
+	"fullcycle-auction_go/internal/internal_error"
+)
+
+// UserProfileInputDTO updates only the fields present in the payload, an
+// empty string clears the field.
+type UserProfileInputDTO struct {
+	DisplayName *string `json:"display_name" binding:"omitempty,min=2,max=50"`
+	AvatarUrl   *string `json:"avatar_url" binding:"omitempty,url,max=500"`
+	Bio         *string `json:"bio" binding:"omitempty,max=500"`
+}
+
+type UserRoleInputDTO struct {
+	Role string `json:"role" binding:"omitempty,oneof=auctioneer staff admin"`
+}
+
+func (u *UserUseCase) UpdateUserProfile(
+	ctx context.Context,
+	id string,
+	profileInput UserProfileInputDTO) (*UserOutputDTO, *internal_error.InternalError) {
+	userEntity, err := u.UserRepository.FindUserById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if profileInput.DisplayName != nil {
+		userEntity.DisplayName = *profileInput.DisplayName
+	}
+	if profileInput.AvatarUrl != nil {
+		userEntity.AvatarUrl = *profileInput.AvatarUrl
+	}
+	if profileInput.Bio != nil {
+		userEntity.Bio = *profileInput.Bio
+	}
+
+	if err := u.UserRepository.UpdateUserProfile(ctx, userEntity); err != nil {
+		return nil, err
+	}
+
+	return toUserOutputDTO(userEntity), nil
+}
+
+func (u *UserUseCase) UpdateUserRole(
+	ctx context.Context,
+	id string,
+	roleInput UserRoleInputDTO) (*UserOutputDTO, *internal_error.InternalError) {
+	role := user_entity.Role(roleInput.Role)
+	if err := role.Validate(); err != nil {
+		return nil, err
+	}
+
+	userEntity, err := u.UserRepository.FindUserById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.UserRepository.UpdateUserRole(ctx, id, role); err != nil {
+		return nil, err
+	}
+	userEntity.Role = role
+
+	return toUserOutputDTO(userEntity), nil
+}
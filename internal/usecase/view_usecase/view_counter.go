@@ -0,0 +1,76 @@
+package view_usecase
+
+import "time"
+
+// viewCounter counts the views of auction pages once per viewer within the
+// dedup window, holding the counts until they are flushed. It is not safe
+// for concurrent use.
+type viewCounter struct {
+	window time.Duration
+	// seen holds when each viewer's view of an auction was last counted.
+	seen    map[viewKey]time.Time
+	pending map[string]int64
+}
+
+type viewKey struct {
+	auctionId string
+	viewer    string
+}
+
+func newViewCounter(window time.Duration) *viewCounter {
+	return &viewCounter{
+		window:  window,
+		seen:    make(map[viewKey]time.Time),
+		pending: make(map[string]int64),
+	}
+}
+
+// counts tells whether a view of the viewer at now would be counted.
+func (vc *viewCounter) counts(auctionId, viewer string, now time.Time) bool {
+	countedAt, ok := vc.seen[viewKey{auctionId, viewer}]
+	return !ok || now.Sub(countedAt) >= vc.window
+}
+
+// add counts the view unless the viewer's last one is within the window,
+// reporting whether it did.
+func (vc *viewCounter) add(auctionId, viewer string, now time.Time) bool {
+	if !vc.counts(auctionId, viewer, now) {
+		return false
+	}
+
+	vc.seen[viewKey{auctionId, viewer}] = now
+	vc.pending[auctionId]++
+	return true
+}
+
+// take removes and returns the views counted since the last take.
+func (vc *viewCounter) take() map[string]int64 {
+	pending := vc.pending
+	vc.pending = make(map[string]int64)
+	return pending
+}
+
+// restore puts back views a flush could not store.
+func (vc *viewCounter) restore(auctionId string, views int64) {
+	vc.pending[auctionId] += views
+}
+
+// prune forgets the viewers whose last view is past the window.
+func (vc *viewCounter) prune(now time.Time) {
+	for key, countedAt := range vc.seen {
+		if now.Sub(countedAt) >= vc.window {
+			delete(vc.seen, key)
+		}
+	}
+}
+
+// recent counts the viewers of each auction within the window.
+func (vc *viewCounter) recent(now time.Time) map[string]int {
+	recent := make(map[string]int)
+	for key, countedAt := range vc.seen {
+		if now.Sub(countedAt) < vc.window {
+			recent[key.auctionId]++
+		}
+	}
+	return recent
+}
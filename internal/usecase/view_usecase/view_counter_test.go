@@ -0,0 +1,26 @@
+package view_usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestViewCounter_CountsOncePerWindow(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	counter := newViewCounter(time.Hour)
+
+	assert.True(t, counter.add("a1", "ann", now))
+	assert.False(t, counter.add("a1", "ann", now.Add(59*time.Minute)))
+	assert.True(t, counter.add("a1", "bob", now))
+	assert.True(t, counter.add("a2", "ann", now))
+	assert.Equal(t, map[string]int{"a1": 2, "a2": 1}, counter.recent(now))
+
+	assert.True(t, counter.add("a1", "ann", now.Add(time.Hour)))
+	assert.Equal(t, map[string]int64{"a1": 3, "a2": 1}, counter.take())
+	assert.Empty(t, counter.take())
+
+	counter.prune(now.Add(90 * time.Minute))
+	assert.Equal(t, map[string]int{"a1": 1}, counter.recent(now.Add(90*time.Minute)))
+}
@@ -0,0 +1,181 @@
+package view_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/clock"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"os"
+	"sync"
+	"time"
+)
+
+// viewDedupWindow is how long a viewer's views of an auction count once.
+const viewDedupWindow = time.Hour
+
+type AuctionStatsOutputDTO struct {
+	AuctionId string `json:"auction_id"`
+	Views     int64  `json:"views"`
+	// RecentViews counts the viewers of the last hour.
+	RecentViews int     `json:"recent_views"`
+	Watchers    int     `json:"watchers"`
+	Bids        int     `json:"bids"`
+	Bidders     int     `json:"bidders"`
+	HeatScore   float64 `json:"heat_score"`
+}
+
+// Followers tells how many users follow an auction live.
+type Followers interface {
+	Followers(auctionId string) int
+}
+
+type ViewUseCaseInterface interface {
+	// RecordView counts a view of the auction page, once an hour per user,
+	// or per client IP for anonymous viewers.
+	RecordView(
+		ctx context.Context, auctionId, userId, clientIp string) *internal_error.InternalError
+
+	FindAuctionStats(
+		ctx context.Context, auctionId string) (*AuctionStatsOutputDTO, *internal_error.InternalError)
+
+	// RecentViews counts the viewers of the last hour of each auction.
+	RecentViews() map[string]int
+}
+
+type ViewUseCase struct {
+	auctionRepositoryInterface auction_entity.AuctionRepositoryInterface
+	bidRepositoryInterface     bid_entity.BidEntityRepository
+	followers                  Followers
+
+	counterMutex *sync.Mutex
+	counter      *viewCounter
+}
+
+// NewViewUseCase also starts the routine storing the views counted every
+// VIEW_FLUSH_INTERVAL.
+func NewViewUseCase(
+	auctionRepositoryInterface auction_entity.AuctionRepositoryInterface,
+	bidRepositoryInterface bid_entity.BidEntityRepository,
+	followers Followers) ViewUseCaseInterface {
+	viewUseCase := &ViewUseCase{
+		auctionRepositoryInterface: auctionRepositoryInterface,
+		bidRepositoryInterface:     bidRepositoryInterface,
+		followers:                  followers,
+		counterMutex:               &sync.Mutex{},
+		counter:                    newViewCounter(viewDedupWindow),
+	}
+
+	viewUseCase.triggerFlushRoutine(context.Background(), getFlushInterval())
+
+	return viewUseCase
+}
+
+func (vu *ViewUseCase) RecordView(
+	ctx context.Context, auctionId, userId, clientIp string) *internal_error.InternalError {
+	viewer := userId
+	if viewer == "" {
+		viewer = "ip:" + clientIp
+	}
+
+	vu.counterMutex.Lock()
+	counts := vu.counter.counts(auctionId, viewer, clock.Now())
+	vu.counterMutex.Unlock()
+	if !counts {
+		return nil
+	}
+
+	// Only the views counted look the auction up, so repeated views stay
+	// off the database.
+	if _, err := vu.auctionRepositoryInterface.FindAuctionById(ctx, auctionId); err != nil {
+		return err
+	}
+
+	vu.counterMutex.Lock()
+	vu.counter.add(auctionId, viewer, clock.Now())
+	vu.counterMutex.Unlock()
+
+	return nil
+}
+
+// FindAuctionStats adds the views not stored yet to the stored ones.
+func (vu *ViewUseCase) FindAuctionStats(
+	ctx context.Context, auctionId string) (*AuctionStatsOutputDTO, *internal_error.InternalError) {
+	auction, err := vu.auctionRepositoryInterface.FindAuctionById(ctx, auctionId)
+	if err != nil {
+		return nil, err
+	}
+
+	bids, err := vu.bidRepositoryInterface.FindBidByAuctionId(ctx, auctionId)
+	if err != nil {
+		return nil, err
+	}
+
+	bidders := make(map[string]bool)
+	for _, bid := range bids {
+		bidders[bid.UserId] = true
+	}
+
+	vu.counterMutex.Lock()
+	pending := vu.counter.pending[auctionId]
+	recent := vu.counter.recent(clock.Now())[auctionId]
+	vu.counterMutex.Unlock()
+
+	return &AuctionStatsOutputDTO{
+		AuctionId:   auctionId,
+		Views:       auction.Views + pending,
+		RecentViews: recent,
+		Watchers:    vu.followers.Followers(auctionId),
+		Bids:        len(bids),
+		Bidders:     len(bidders),
+		HeatScore:   auction.HeatScore,
+	}, nil
+}
+
+func (vu *ViewUseCase) RecentViews() map[string]int {
+	vu.counterMutex.Lock()
+	defer vu.counterMutex.Unlock()
+
+	return vu.counter.recent(clock.Now())
+}
+
+func (vu *ViewUseCase) triggerFlushRoutine(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(clock.Scale(interval))
+		defer ticker.Stop()
+
+		for range ticker.C {
+			vu.flush(ctx)
+		}
+	}()
+}
+
+// flush stores the views counted, keeping the ones it could not store for
+// the next flush, and forgets the viewers past the dedup window.
+func (vu *ViewUseCase) flush(ctx context.Context) {
+	vu.counterMutex.Lock()
+	pending := vu.counter.take()
+	vu.counter.prune(clock.Now())
+	vu.counterMutex.Unlock()
+
+	for auctionId, views := range pending {
+		err := vu.auctionRepositoryInterface.AddViews(ctx, auctionId, views)
+		if err == nil || err.Err == "not_found" {
+			continue
+		}
+
+		logger.Error("error trying to store auction views", err)
+		vu.counterMutex.Lock()
+		vu.counter.restore(auctionId, views)
+		vu.counterMutex.Unlock()
+	}
+}
+
+func getFlushInterval() time.Duration {
+	interval, err := time.ParseDuration(os.Getenv("VIEW_FLUSH_INTERVAL"))
+	if err != nil || interval <= 0 {
+		return 10 * time.Second
+	}
+	return interval
+}
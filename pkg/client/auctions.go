@@ -0,0 +1,142 @@
+package client
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type AuctionFilter struct {
+	Status      AuctionStatus
+	Category    string
+	ProductName string
+	Attributes  map[string]string
+
+	// MinPrice and MaxPrice bound the starting price, zero leaving the
+	// bound open.
+	MinPrice float64
+	MaxPrice float64
+
+	// EndingBefore, EndingAfter and CreatedAfter are exclusive bounds on
+	// the end and creation times, zero leaving the bound open.
+	EndingBefore time.Time
+	EndingAfter  time.Time
+	CreatedAfter time.Time
+
+	// Sort names the order, newest first when empty.
+	Sort string
+}
+
+func (c *Client) CreateAuction(ctx context.Context, input AuctionInput) error {
+	return c.post(ctx, "/auction", input)
+}
+
+// FindAuctions returns a page of the auctions matching the filter, along
+// with their total count and the cursor of the next page.
+func (c *Client) FindAuctions(ctx context.Context, filter AuctionFilter, page Page) (*AuctionPage, error) {
+	query := url.Values{}
+	query.Set("status", filter.Status.String())
+	if filter.Category != "" {
+		query.Set("category", filter.Category)
+	}
+	if filter.ProductName != "" {
+		query.Set("productName", filter.ProductName)
+	}
+	if filter.MinPrice > 0 {
+		query.Set("minPrice", strconv.FormatFloat(filter.MinPrice, 'f', -1, 64))
+	}
+	if filter.MaxPrice > 0 {
+		query.Set("maxPrice", strconv.FormatFloat(filter.MaxPrice, 'f', -1, 64))
+	}
+	if !filter.EndingBefore.IsZero() {
+		query.Set("endingBefore", filter.EndingBefore.Format(time.RFC3339))
+	}
+	if !filter.EndingAfter.IsZero() {
+		query.Set("endingAfter", filter.EndingAfter.Format(time.RFC3339))
+	}
+	if !filter.CreatedAfter.IsZero() {
+		query.Set("createdAfter", filter.CreatedAfter.Format(time.RFC3339))
+	}
+	for name, value := range filter.Attributes {
+		query.Set("attributes["+name+"]", value)
+	}
+	if filter.Sort != "" {
+		query.Set("sort", filter.Sort)
+	}
+	page.set(query)
+
+	var auctions AuctionPage
+	if err := c.get(ctx, "/auction", query, &auctions); err != nil {
+		return nil, err
+	}
+
+	return &auctions, nil
+}
+
+// FindEndingSoonAuctions returns a page of the active auctions ending
+// within the window, soonest first, the API default of an hour when zero.
+func (c *Client) FindEndingSoonAuctions(ctx context.Context, within time.Duration, page Page) (*AuctionPage, error) {
+	query := url.Values{}
+	if within > 0 {
+		query.Set("within", within.String())
+	}
+	page.set(query)
+
+	var auctions AuctionPage
+	if err := c.get(ctx, "/auction/ending-soon", query, &auctions); err != nil {
+		return nil, err
+	}
+
+	return &auctions, nil
+}
+
+// FindTrendingAuctions returns a page of the active auctions with the
+// highest recent bid velocity first.
+func (c *Client) FindTrendingAuctions(ctx context.Context, page Page) (*AuctionPage, error) {
+	query := url.Values{}
+	page.set(query)
+
+	var auctions AuctionPage
+	if err := c.get(ctx, "/auction/trending", query, &auctions); err != nil {
+		return nil, err
+	}
+
+	return &auctions, nil
+}
+
+func (c *Client) FindAuctionById(ctx context.Context, auctionId string) (*Auction, error) {
+	var auction Auction
+	if err := c.get(ctx, "/auction/"+url.PathEscape(auctionId), nil, &auction); err != nil {
+		return nil, err
+	}
+
+	return &auction, nil
+}
+
+// FindAuctionChanges lists auctions changed after since, which is either
+// the Cursor of a previous call or an RFC 3339 timestamp.
+func (c *Client) FindAuctionChanges(ctx context.Context, since string) (*AuctionChanges, error) {
+	var changes AuctionChanges
+	if err := c.get(ctx, "/auction/changes", url.Values{"since": {since}}, &changes); err != nil {
+		return nil, err
+	}
+
+	return &changes, nil
+}
+
+func (c *Client) FindWinningBidByAuctionId(ctx context.Context, auctionId string) (*WinningInfo, error) {
+	var winner WinningInfo
+	if err := c.get(ctx, "/auction/winner/"+url.PathEscape(auctionId), nil, &winner); err != nil {
+		return nil, err
+	}
+
+	return &winner, nil
+}
+
+// EndAuctionEarly closes an auction on behalf of its seller, the client's
+// authenticated user. The API refuses it once the auction has received any
+// bid.
+func (c *Client) EndAuctionEarly(ctx context.Context, auctionId string) error {
+	return c.post(ctx, "/auction/"+url.PathEscape(auctionId)+"/end-early", nil)
+}
@@ -0,0 +1,130 @@
+package client
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/usecase/bid_usecase"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// minStatusPoll keeps WaitForBid from polling in a tight loop when the API
+// expects the bid to be written right away.
+const minStatusPoll = 100 * time.Millisecond
+
+// CreateBid submits a bid. The API accepts bids asynchronously: the bid
+// returned is queued, not visible yet, and is expected to be written after
+// its EstimatedPersistenceMs. FindBidStatus or WaitForBid follow it.
+func (c *Client) CreateBid(ctx context.Context, input BidInput) (*BidAccepted, error) {
+	var accepted BidAccepted
+	if err := c.do(ctx, http.MethodPost, "/bid", nil, input, &accepted); err != nil {
+		return nil, err
+	}
+
+	return &accepted, nil
+}
+
+// CreateBidSync submits a bid and waits for the API to write it, the bid
+// returned being persisted. A bid rejected or lost meanwhile fails with the
+// API error, and one whose batch takes too long comes back pending, for
+// WaitForBid to follow.
+func (c *Client) CreateBidSync(ctx context.Context, input BidInput) (*BidAccepted, error) {
+	var accepted BidAccepted
+	query := url.Values{"sync": {"true"}}
+	if err := c.do(ctx, http.MethodPost, "/bid", query, input, &accepted); err != nil {
+		return nil, err
+	}
+
+	return &accepted, nil
+}
+
+// RetractBid takes back a bid of the authenticated user, allowed shortly
+// after placing it and while no higher bid superseded it. Its status turns
+// to retracted.
+func (c *Client) RetractBid(ctx context.Context, bidId string) error {
+	return c.do(ctx, http.MethodDelete, "/bid/"+url.PathEscape(bidId), nil, nil, nil)
+}
+
+func (c *Client) FindBidStatus(ctx context.Context, auctionId, bidId string) (*BidStatus, error) {
+	var status BidStatus
+	path := "/bid/" + url.PathEscape(auctionId) + "/status/" + url.PathEscape(bidId)
+	if err := c.get(ctx, path, nil, &status); err != nil {
+		return nil, err
+	}
+
+	return &status, nil
+}
+
+// WaitForBid polls the status of the accepted bid until it leaves the
+// buffer, first after its estimated persistence delay, then as often again
+// as the API estimates, and returns its final status.
+func (c *Client) WaitForBid(ctx context.Context, accepted *BidAccepted) (*BidStatus, error) {
+	wait := time.Duration(accepted.EstimatedPersistenceMs) * time.Millisecond
+
+	for {
+		if wait < minStatusPoll {
+			wait = minStatusPoll
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+
+		status, err := c.FindBidStatus(ctx, accepted.AuctionId, accepted.Id)
+		if err != nil {
+			return nil, err
+		}
+		if status.Status != bid_usecase.BidPending {
+			return status, nil
+		}
+
+		wait = time.Duration(status.EstimatedPersistenceMs) * time.Millisecond
+	}
+}
+
+// CreateBids submits bids on several auctions at once. Each bid is accepted
+// or rejected on its own, as told by its result. Like CreateBid, it is
+// never retried.
+func (c *Client) CreateBids(ctx context.Context, input BulkBidInput) ([]BulkBidResult, error) {
+	var results []BulkBidResult
+	if err := c.do(ctx, http.MethodPost, "/bid/bulk", nil, input, &results); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+type BidFilter struct {
+	// Source keeps the bids placed through it when not empty.
+	Source string
+	// Sort names the order, the order the bids were placed in when empty.
+	Sort string
+	// ReadYourWrites adds the bids of the authenticated user still waiting
+	// to be written, flagged as pending.
+	ReadYourWrites bool
+}
+
+// FindBidByAuctionId returns a page of the auction bids matching the filter.
+func (c *Client) FindBidByAuctionId(
+	ctx context.Context, auctionId string, filter BidFilter, page Page) (*BidPage, error) {
+	query := url.Values{}
+	if filter.Source != "" {
+		query.Set("source", filter.Source)
+	}
+	if filter.Sort != "" {
+		query.Set("sort", filter.Sort)
+	}
+	if filter.ReadYourWrites {
+		query.Set("consistency", "read_your_writes")
+	}
+	page.set(query)
+
+	var bids BidPage
+	if err := c.get(ctx, "/bid/"+url.PathEscape(auctionId), query, &bids); err != nil {
+		return nil, err
+	}
+
+	return &bids, nil
+}
@@ -0,0 +1,173 @@
+// Package client is a Go SDK for the auction REST API.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	token      string
+	tenant     string
+	maxRetries int
+	backoff    time.Duration
+}
+
+type Option func(*Client)
+
+// WithHTTPClient replaces the default http.Client, e.g. to set timeouts or
+// a custom transport.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithBearerToken sends token in the Authorization header of every request.
+func WithBearerToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// WithTenant names the tenant of every request in the X-Tenant-Id header,
+// for multi-tenant deployments not serving the tenant on a host of its own.
+func WithTenant(tenant string) Option {
+	return func(c *Client) {
+		c.tenant = tenant
+	}
+}
+
+// WithRetries sets how many times a GET request is retried after a network
+// error or a 429/5xx response, waiting backoff, doubled on each attempt.
+// Writes are never retried since the API does not deduplicate them.
+func WithRetries(maxRetries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.backoff = backoff
+	}
+}
+
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		maxRetries: 3,
+		backoff:    200 * time.Millisecond,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+func (c *Client) get(ctx context.Context, path string, query url.Values, out interface{}) error {
+	backoff := c.backoff
+
+	for attempt := 0; ; attempt++ {
+		err := c.do(ctx, http.MethodGet, path, query, nil, out)
+		if attempt >= c.maxRetries || !retryable(err) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (c *Client) post(ctx context.Context, path string, body interface{}) error {
+	return c.do(ctx, http.MethodPost, path, nil, body, nil)
+}
+
+func (c *Client) do(
+	ctx context.Context,
+	method, path string,
+	query url.Values,
+	body, out interface{}) error {
+	endpoint := c.baseURL + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Accept", "application/json")
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		request.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	if c.tenant != "" {
+		request.Header.Set("X-Tenant-Id", c.tenant)
+	}
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= http.StatusBadRequest {
+		return decodeError(response)
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return json.NewDecoder(response.Body).Decode(out)
+}
+
+// decodeError turns an error response into *Error, falling back to the
+// status text when the body is not a rest_err payload.
+func decodeError(response *http.Response) error {
+	apiErr := &Error{}
+	if err := json.NewDecoder(response.Body).Decode(apiErr); err != nil || apiErr.Message == "" {
+		apiErr.Message = fmt.Sprintf("unexpected status %d", response.StatusCode)
+	}
+	apiErr.Code = response.StatusCode
+
+	return apiErr
+}
+
+func retryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	apiErr, ok := err.(*Error)
+	if !ok {
+		// Context cancellation is final, any other transport error is not.
+		return err != context.Canceled && err != context.DeadlineExceeded
+	}
+
+	return apiErr.Code == http.StatusTooManyRequests || apiErr.Code >= http.StatusInternalServerError
+}
@@ -0,0 +1,82 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_RetriesServerErrors(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(Auction{Id: "auction-id", ProductName: "Laptop"})
+	}))
+	defer server.Close()
+
+	c := New(server.URL, WithBearerToken("secret"), WithRetries(3, time.Millisecond))
+	auction, err := c.FindAuctionById(context.Background(), "auction-id")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, "Laptop", auction.ProductName)
+}
+
+func TestClient_DecodesErrorsWithoutRetrying(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(Error{Message: "Auction not found", Err: "not_found", Code: http.StatusNotFound})
+	}))
+	defer server.Close()
+
+	c := New(server.URL, WithRetries(3, time.Millisecond))
+	_, err := c.FindAuctionById(context.Background(), "missing")
+
+	apiErr, ok := err.(*Error)
+	assert.True(t, ok)
+	assert.Equal(t, 1, attempts)
+	assert.Equal(t, "not_found", apiErr.Err)
+	assert.Equal(t, http.StatusNotFound, apiErr.Code)
+}
+
+func TestClient_WaitsForAcceptedBid(t *testing.T) {
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusAccepted)
+			json.NewEncoder(w).Encode(BidAccepted{Id: "bid-id", AuctionId: "auction-id", Status: "pending"})
+			return
+		}
+
+		assert.Equal(t, "/bid/auction-id/status/bid-id", r.URL.Path)
+		polls++
+		status := BidStatus{Id: "bid-id", AuctionId: "auction-id", Status: "persisted"}
+		if polls < 2 {
+			status.Status = "pending"
+		}
+		json.NewEncoder(w).Encode(status)
+	}))
+	defer server.Close()
+
+	c := New(server.URL)
+	accepted, err := c.CreateBid(context.Background(), BidInput{AuctionId: "auction-id", Amount: 10})
+	assert.NoError(t, err)
+
+	status, err := c.WaitForBid(context.Background(), accepted)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, polls)
+	assert.EqualValues(t, "persisted", status.Status)
+}
@@ -0,0 +1,56 @@
+package client
+
+import (
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/usecase/auction_usecase"
+	"fullcycle-auction_go/internal/usecase/bid_usecase"
+	"fullcycle-auction_go/internal/usecase/pagination"
+	"fullcycle-auction_go/internal/usecase/user_usecase"
+	"net/url"
+	"strconv"
+)
+
+// The SDK reuses the API DTOs through aliases so request and response
+// shapes cannot drift from what the server binds and renders.
+type (
+	AuctionInput     = auction_usecase.AuctionInputDTO
+	Auction          = auction_usecase.AuctionOutputDTO
+	AuctionChanges   = auction_usecase.AuctionChangesOutputDTO
+	AuctionStatus    = auction_usecase.AuctionStatus
+	ConditionGrading = auction_usecase.ConditionGradingDTO
+	WinningInfo      = auction_usecase.WinningInfoOutputDTO
+	AuctionPage      = pagination.Page[Auction]
+
+	BidInput         = bid_usecase.BidInputDTO
+	Bid              = bid_usecase.BidOutputDTO
+	BidAccepted      = bid_usecase.BidAcceptedOutputDTO
+	BidStatus        = bid_usecase.BidStatusOutputDTO
+	BidPage          = pagination.Page[Bid]
+	BulkBidInput     = bid_usecase.BulkBidInputDTO
+	BulkBidResult    = bid_usecase.BulkBidResultDTO
+	User             = user_usecase.UserOutputDTO
+	UserProfileInput = user_usecase.UserProfileInputDTO
+	PublicProfile    = user_usecase.PublicProfileDTO
+	Error            = rest_err.RestErr
+)
+
+// Page selects a page of a listing: up to Limit items after Cursor, the
+// NextCursor of the previous page, or after the first Offset items. Zero
+// values leave the API defaults.
+type Page struct {
+	Limit  int
+	Offset int
+	Cursor string
+}
+
+func (p Page) set(query url.Values) {
+	if p.Limit > 0 {
+		query.Set("limit", strconv.Itoa(p.Limit))
+	}
+	if p.Offset > 0 {
+		query.Set("offset", strconv.Itoa(p.Offset))
+	}
+	if p.Cursor != "" {
+		query.Set("cursor", p.Cursor)
+	}
+}
@@ -0,0 +1,28 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+func (c *Client) FindUserById(ctx context.Context, userId string) (*User, error) {
+	var user User
+	if err := c.get(ctx, "/user/"+url.PathEscape(userId), nil, &user); err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// UpdateUserProfile changes only the profile fields set in input.
+func (c *Client) UpdateUserProfile(
+	ctx context.Context, userId string, input UserProfileInput) (*User, error) {
+	var user User
+	if err := c.do(ctx, http.MethodPatch, "/users/"+url.PathEscape(userId)+"/profile",
+		nil, input, &user); err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}